@@ -48,6 +48,7 @@ func addBackfiller(root *cobra.Command) {
 	}
 	addVaaCountCommand(metrics)
 	addVaaVolumeCommand(metrics)
+	addReconcileCommand(metrics)
 	root.AddCommand(metrics)
 
 	prices := &cobra.Command{
@@ -187,6 +188,60 @@ func addVaaVolumeCommand(parent *cobra.Command) {
 	parent.AddCommand(vaaVolumeCmd)
 }
 
+func addReconcileCommand(parent *cobra.Command) {
+	var cfg metrics.ReconcileConfig
+	var start, end string
+	reconcileCmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Recompute vaa-count metrics for a time range from MongoDB and report or fix the drift against InfluxDB",
+		Run: func(_ *cobra.Command, _ []string) {
+			st, err := time.Parse(time.RFC3339, start)
+			if err != nil {
+				log.Fatal("Failed to parse start: ", err)
+			}
+			cfg.Start = st
+			et := time.Now()
+			if end != "" {
+				et, err = time.Parse(time.RFC3339, end)
+				if err != nil {
+					log.Fatal("Failed to parse end: ", err)
+				}
+			}
+			cfg.End = et
+			metrics.RunReconcileVaaCount(cfg)
+		},
+	}
+
+	//mongo flags
+	reconcileCmd.Flags().StringVar(&cfg.MongoUri, "mongo-uri", "", "Mongo connection")
+	reconcileCmd.MarkFlagRequired("mongo-uri")
+	reconcileCmd.Flags().StringVar(&cfg.MongoDb, "mongo-database", "", "Mongo database")
+	reconcileCmd.MarkFlagRequired("mongo-database")
+
+	//influx flags
+	reconcileCmd.Flags().StringVar(&cfg.InfluxUrl, "influx-url", "", "InfluxDB URL")
+	reconcileCmd.MarkFlagRequired("influx-url")
+	reconcileCmd.Flags().StringVar(&cfg.InfluxToken, "influx-token", "", "InfluxDB token")
+	reconcileCmd.MarkFlagRequired("influx-token")
+	reconcileCmd.Flags().StringVar(&cfg.InfluxOrganization, "influx-organization", "", "InfluxDB organization")
+	reconcileCmd.MarkFlagRequired("influx-organization")
+	reconcileCmd.Flags().StringVar(&cfg.InfluxBucket, "influx-bucket", "", "InfluxDB bucket that stores the vaa_count measurement (30 days retention)")
+	reconcileCmd.MarkFlagRequired("influx-bucket")
+
+	// start flag
+	reconcileCmd.Flags().StringVar(&start, "start", "", "start timestamp in RFC3339 format")
+	reconcileCmd.MarkFlagRequired("start")
+
+	// end flag
+	reconcileCmd.Flags().StringVar(&end, "end", "", "end timestamp in RFC3339 format, defaults to now")
+
+	// fix flag
+	reconcileCmd.Flags().BoolVar(&cfg.Fix, "fix", false, "rewrite the points of the chains with drift")
+	reconcileCmd.Flags().IntVar(&cfg.BatchSize, "batch-size", 1000, "number of points written to InfluxDB at a time")
+
+	parent.AddCommand(reconcileCmd)
+}
+
 func addPricesCommand(parent *cobra.Command) {
 	addHistoryPrices(parent)
 	addVaasPrices(parent)
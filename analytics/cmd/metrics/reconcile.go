@@ -0,0 +1,317 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ReconcileConfig contains the parameters of the vaa count reconciliation.
+type ReconcileConfig struct {
+	MongoUri           string
+	MongoDb            string
+	InfluxUrl          string
+	InfluxToken        string
+	InfluxOrganization string
+	InfluxBucket       string
+	Start              time.Time
+	End                time.Time
+	// Fix rewrites the `vaa_count` points of the chains that have drift.
+	Fix bool
+	// BatchSize is the number of points written to InfluxDB at a time.
+	BatchSize int
+}
+
+// chainDrift is the difference between the VAAs stored in MongoDB and the VAAs counted in InfluxDB for a chain.
+type chainDrift struct {
+	chainID  sdk.ChainID
+	expected int64
+	actual   int64
+}
+
+func (d chainDrift) drift() int64 {
+	return d.actual - d.expected
+}
+
+// RunReconcileVaaCount recomputes the `vaa_count` measurement for a time range from the
+// MongoDB `vaas` collection and reports the drift against InfluxDB.
+//
+// When fix is enabled, the points of every chain with drift are deleted for the time range
+// and written again from MongoDB. Points are deterministic, so the fix can be run many times.
+func RunReconcileVaaCount(cfg ReconcileConfig) {
+
+	ctx := context.Background()
+
+	logger := logger.New("wormhole-explorer-analytics")
+
+	logger.Info("starting vaa count reconciliation",
+		zap.Time("start", cfg.Start),
+		zap.Time("end", cfg.End),
+		zap.Bool("fix", cfg.Fix))
+
+	if !cfg.Start.Before(cfg.End) {
+		logger.Fatal("start must be before end")
+	}
+
+	db, err := dbutil.Connect(ctx, logger, cfg.MongoUri, cfg.MongoDb, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	defer db.DisconnectWithTimeout(10 * time.Second)
+
+	influxCli := influxdb2.NewClient(cfg.InfluxUrl, cfg.InfluxToken)
+	defer influxCli.Close()
+
+	vaas := db.Database.Collection("vaas")
+
+	expected, err := countVaasByChain(ctx, vaas, cfg.Start, cfg.End)
+	if err != nil {
+		logger.Fatal("Failed to count vaas in MongoDB", zap.Error(err))
+	}
+
+	actual, err := countVaaCountPointsByChain(ctx, influxCli, cfg)
+	if err != nil {
+		logger.Fatal("Failed to count vaa_count points in InfluxDB", zap.Error(err))
+	}
+
+	drifts := computeDrifts(expected, actual)
+	if len(drifts) == 0 {
+		logger.Info("no drift found")
+		return
+	}
+
+	for _, d := range drifts {
+		logger.Warn("found drift",
+			zap.Uint16("chainId", uint16(d.chainID)),
+			zap.Int64("mongo", d.expected),
+			zap.Int64("influx", d.actual),
+			zap.Int64("drift", d.drift()))
+	}
+
+	if !cfg.Fix {
+		logger.Info("finished vaa count reconciliation, run with --fix to rewrite the points", zap.Int("chains", len(drifts)))
+		return
+	}
+
+	for _, d := range drifts {
+		written, err := rewriteVaaCount(ctx, vaas, influxCli, cfg, d.chainID)
+		if err != nil {
+			logger.Error("Failed to fix drift", zap.Uint16("chainId", uint16(d.chainID)), zap.Error(err))
+			continue
+		}
+		logger.Info("fixed drift", zap.Uint16("chainId", uint16(d.chainID)), zap.Int64("points", written))
+	}
+
+	logger.Info("finished vaa count reconciliation")
+}
+
+// countVaasByChain counts the `vaa_count` points that the VAAs stored in MongoDB produce for each
+// emitter chain in the time range.
+//
+// The points are generated the same way the analytics service does, so the counts match InfluxDB:
+// a point is counted by its time and not by the VAA timestamp, and VAAs whose points share the
+// same chain and time are counted once, as InfluxDB overwrites them.
+func countVaasByChain(ctx context.Context, vaas *mongo.Collection, start, end time.Time) (map[sdk.ChainID]int64, error) {
+
+	seen := make(map[sdk.ChainID]map[int64]struct{})
+	err := forEachVaaCountPoint(ctx, vaas, start, end, nil, func(chainID sdk.ChainID, point *write.Point) error {
+		times, ok := seen[chainID]
+		if !ok {
+			times = make(map[int64]struct{})
+			seen[chainID] = times
+		}
+		times[point.Time().UnixNano()] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[sdk.ChainID]int64, len(seen))
+	for chainID, times := range seen {
+		counts[chainID] = int64(len(times))
+	}
+	return counts, nil
+}
+
+// forEachVaaCountPoint calls fn with the `vaa_count` point of every VAA stored in MongoDB whose point
+// falls in the time range. When chainID is not nil, only the VAAs of that emitter chain are read.
+//
+// Points are shifted up to metric.MaxTimestampOffset after the VAA timestamp, so the VAAs are read
+// from that much earlier than the start, and the points are filtered by their own time.
+func forEachVaaCountPoint(
+	ctx context.Context,
+	vaas *mongo.Collection,
+	start, end time.Time,
+	chainID *sdk.ChainID,
+	fn func(sdk.ChainID, *write.Point) error,
+) error {
+
+	filter := bson.D{
+		{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: start.Add(-metric.MaxTimestampOffset)}, {Key: "$lt", Value: end}}},
+	}
+	if chainID != nil {
+		filter = append(filter, bson.E{Key: "emitterChain", Value: *chainID})
+	} else {
+		filter = append(filter, bson.E{Key: "emitterChain", Value: bson.D{{Key: "$ne", Value: sdk.ChainIDPythNet}}})
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "vaas", Value: 1}})
+	cur, err := vaas.Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc struct {
+			Vaa []byte `bson:"vaas"`
+		}
+		if err := cur.Decode(&doc); err != nil {
+			return err
+		}
+		vaa, err := sdk.Unmarshal(doc.Vaa)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal vaa: %w", err)
+		}
+		point, err := metric.MakePointForVaaCount(vaa)
+		if err != nil {
+			return err
+		}
+		if point == nil || !inRange(point.Time(), start, end) {
+			continue
+		}
+		if err := fn(vaa.EmitterChain, point); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// inRange returns true if t is in the half-open range [start, end).
+func inRange(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+// countVaaCountPointsByChain counts the `vaa_count` points stored in InfluxDB for each chain in the time range.
+func countVaaCountPointsByChain(ctx context.Context, influxCli influxdb2.Client, cfg ReconcileConfig) (map[sdk.ChainID]int64, error) {
+
+	query := fmt.Sprintf(`
+		from(bucket: "%s")
+			|> range(start: %s, stop: %s)
+			|> filter(fn: (r) => r._measurement == "%s" and r._field == "count")
+			|> group(columns: ["chain_id"])
+			|> count()
+	`, cfg.InfluxBucket, cfg.Start.Format(time.RFC3339Nano), cfg.End.Format(time.RFC3339Nano), metric.VaaCountMeasurement)
+
+	result, err := influxCli.QueryAPI(cfg.InfluxOrganization).Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	counts := make(map[sdk.ChainID]int64)
+	for result.Next() {
+		record := result.Record()
+		chain, ok := record.ValueByKey("chain_id").(string)
+		if !ok {
+			continue
+		}
+		chainID, err := strconv.ParseUint(chain, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain_id tag %s: %w", chain, err)
+		}
+		count, ok := record.Value().(int64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected count value %v for chain %s", record.Value(), chain)
+		}
+		counts[sdk.ChainID(chainID)] = count
+	}
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+	return counts, nil
+}
+
+// computeDrifts returns the chains whose counts differ, sorted by chain id.
+func computeDrifts(expected, actual map[sdk.ChainID]int64) []chainDrift {
+	chains := make(map[sdk.ChainID]struct{})
+	for chainID := range expected {
+		chains[chainID] = struct{}{}
+	}
+	for chainID := range actual {
+		chains[chainID] = struct{}{}
+	}
+
+	var drifts []chainDrift
+	for chainID := range chains {
+		d := chainDrift{chainID: chainID, expected: expected[chainID], actual: actual[chainID]}
+		if d.drift() != 0 {
+			drifts = append(drifts, d)
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].chainID < drifts[j].chainID
+	})
+	return drifts
+}
+
+// rewriteVaaCount deletes the `vaa_count` points of a chain for the time range and writes them again from MongoDB.
+func rewriteVaaCount(
+	ctx context.Context,
+	vaas *mongo.Collection,
+	influxCli influxdb2.Client,
+	cfg ReconcileConfig,
+	chainID sdk.ChainID,
+) (int64, error) {
+
+	predicate := fmt.Sprintf(`_measurement="%s" AND chain_id="%d"`, metric.VaaCountMeasurement, chainID)
+	err := influxCli.DeleteAPI().DeleteWithName(ctx, cfg.InfluxOrganization, cfg.InfluxBucket, cfg.Start, cfg.End.Add(-time.Nanosecond), predicate)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete points: %w", err)
+	}
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	writeAPI := influxCli.WriteAPIBlocking(cfg.InfluxOrganization, cfg.InfluxBucket)
+	var written int64
+	points := make([]*write.Point, 0, batchSize)
+	flush := func() error {
+		if len(points) == 0 {
+			return nil
+		}
+		if err := writeAPI.WritePoint(ctx, points...); err != nil {
+			return fmt.Errorf("failed to write points: %w", err)
+		}
+		written += int64(len(points))
+		points = points[:0]
+		return nil
+	}
+
+	err = forEachVaaCountPoint(ctx, vaas, cfg.Start, cfg.End, &chainID, func(_ sdk.ChainID, point *write.Point) error {
+		points = append(points, point)
+		if len(points) >= batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+	return written, flush()
+}
@@ -45,7 +45,9 @@ func RunBackFillerVaaVolumeV3(vaasBsonFile, outputFile, pricesFile, vaaPayloadPa
 	}
 	defer vaasFile.Close()
 
-	failedVaas, err := os.OpenFile("failed_vaas.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	// The output files are truncated, so that running the backfiller again produces the same files
+	// instead of appending duplicated line protocols.
+	failedVaas, err := os.Create("failed_vaas.log")
 	if err != nil {
 		loggerInstance.Fatal("creating failedVaas file", zap.Error(err))
 	}
@@ -75,9 +77,10 @@ func RunBackFillerVaaVolumeV3(vaasBsonFile, outputFile, pricesFile, vaaPayloadPa
 	}
 
 	lpChan := make(chan lpChanData)
+	lpDone := make(chan struct{})
 
 	go processVaas(ctx, workersPool, vaasChan, converter, loggerInstance, lpChan, failedVaas)
-	go processLineProtocols(ctx, outputFile, loggerInstance, lpChan, failedVaas)
+	go processLineProtocols(outputFile, loggerInstance, lpChan, lpDone, failedVaas)
 
 	i := uint64(1)
 	offset := uint64(1)
@@ -137,7 +140,12 @@ func RunBackFillerVaaVolumeV3(vaasBsonFile, outputFile, pricesFile, vaaPayloadPa
 	}
 	loggerInstance.Info("finished processing vaas")
 	loggerInstance.Info("waiting for workers to finish...")
-	<-workersPool
+	for i := 0; i < batchSize; i++ {
+		<-workersPool
+	}
+	// all the workers are done, so every line protocol was sent and can be flushed before exiting
+	close(lpChan)
+	<-lpDone
 	loggerInstance.Info("finished waiting, now cancelling ctx.")
 	cancel()
 
@@ -195,33 +203,29 @@ func processVaa(ctx context.Context, workersPool chan struct{}, vaaData vaaChanD
 
 }
 
-func processLineProtocols(ctx context.Context, outputFile string, logger *zap.Logger, lpChan chan lpChanData, failedVaas *os.File) {
+func processLineProtocols(outputFile string, logger *zap.Logger, lpChan chan lpChanData, done chan struct{}, failedVaas *os.File) {
+	defer close(done)
 
-	fout, err := os.OpenFile(outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	fout, err := os.Create(outputFile)
 	if err != nil {
 		logger.Fatal("creating output file", zap.Error(err))
 	}
 	defer fout.Close()
 
-	for {
-		select {
-		case lpData := <-lpChan:
-			_, err = fout.Write([]byte(lpData.lp))
-			if err != nil {
-				_, errLog := failedVaas.WriteString("vaa: " + lpData.vaaId + "|error: " + err.Error() + "\n")
-				if errLog != nil {
-					fmt.Printf("\n[%s][processLineProtocols]:failed to write to failedVaas.log. error:%s|output_file_error=%s", time.Now().Format(time.RFC3339), errLog.Error(), err.Error())
-					return
-				}
-				logger.Error("[processLineProtocols]:failed to write line protocol to file", zap.Error(err))
-			} else {
-				logger.Info("wrote line protocol to file", zap.String("vaaId", lpData.vaaId), zap.Uint64("index", lpData.index))
+	for lpData := range lpChan {
+		_, err = fout.Write([]byte(lpData.lp))
+		if err != nil {
+			_, errLog := failedVaas.WriteString("vaa: " + lpData.vaaId + "|error: " + err.Error() + "\n")
+			if errLog != nil {
+				fmt.Printf("\n[%s][processLineProtocols]:failed to write to failedVaas.log. error:%s|output_file_error=%s", time.Now().Format(time.RFC3339), errLog.Error(), err.Error())
+				continue
 			}
-		case <-ctx.Done():
-			logger.Info("exiting processLineProtocols, ctx cancelled")
-			return
+			logger.Error("[processLineProtocols]:failed to write line protocol to file", zap.Error(err))
+		} else {
+			logger.Info("wrote line protocol to file", zap.String("vaaId", lpData.vaaId), zap.Uint64("index", lpData.index))
 		}
 	}
+	logger.Info("exiting processLineProtocols, lpChan is closed")
 }
 
 type vaaChanData struct {
@@ -31,6 +31,13 @@ type VaasPrices struct {
 	Sequence            *string
 }
 
+// pageTransfer is a transfer VAA of a page with its transferred token.
+type pageTransfer struct {
+	id    string
+	vaa   *sdk.VAA
+	token *token.TransferredToken
+}
+
 func RunVaasPrices(cfg VaasPrices) {
 
 	ctx := context.Background()
@@ -65,7 +72,7 @@ func RunVaasPrices(cfg VaasPrices) {
 	tokenProvider := domain.NewTokenProvider(cfg.P2PNetwork)
 
 	// create a price api
-	api := apiPrices.NewPricesApi(cfg.NotionalUrl, logger)
+	api := apiPrices.NewBatchPricesClient(cfg.NotionalUrl, 50*time.Millisecond, int(cfg.PageSize), logger)
 
 	query := repository.VaaQuery{
 		StartTime:      cfg.StartTime,
@@ -95,6 +102,9 @@ func RunVaasPrices(cfg VaasPrices) {
 			logger.Info("Empty page", zap.Int64("page", pagination.Page))
 			break
 		}
+		// resolve the transferred tokens of the page, so their prices are requested in a single batch.
+		transfers := make([]pageTransfer, 0, len(vaas))
+		requests := make([]apiPrices.PriceRequest, 0, len(vaas))
 		for _, v := range vaas {
 			logger.Debug("Processing vaa", zap.String("id", v.ID))
 			vaa, err := sdk.Unmarshal(v.Vaa)
@@ -112,11 +122,26 @@ func RunVaasPrices(cfg VaasPrices) {
 				}
 				continue
 			}
+			if transferredToken == nil {
+				continue
+			}
+			transfers = append(transfers, pageTransfer{id: v.ID, vaa: vaa, token: transferredToken})
+
+			tokenMeta, ok := tokenProvider.GetTokenByAddress(transferredToken.TokenChain, transferredToken.TokenAddress.String())
+			if ok && tokenMeta.CoingeckoID != "" {
+				requests = append(requests, apiPrices.PriceRequest{CoingeckoID: tokenMeta.CoingeckoID, DateTime: vaa.Timestamp})
+			}
+		}
 
+		if err := api.Prefetch(ctx, requests); err != nil {
+			logger.Warn("Failed to prefetch prices", zap.Int("requests", len(requests)), zap.Error(err))
+		}
+
+		for _, t := range transfers {
 			if err := metric.UpsertTransferPrices(
 				ctx,
 				logger,
-				vaa,
+				t.vaa,
 				transferPricesCollection,
 				func(tokenID, coinGeckoID string, timestamp time.Time) (decimal.Decimal, error) {
 					price, err := api.GetPriceByTime(ctx, coinGeckoID, timestamp)
@@ -125,12 +150,11 @@ func RunVaasPrices(cfg VaasPrices) {
 					}
 					return price, nil
 				},
-				transferredToken,
+				t.token,
 				tokenProvider,
 			); err != nil {
-				logger.Error("Failed to upsert transfer prices", zap.String("id", v.ID), zap.Error(err))
+				logger.Error("Failed to upsert transfer prices", zap.String("id", t.id), zap.Error(err))
 			}
-
 		}
 		pagination.Page++
 	}
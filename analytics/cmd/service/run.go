@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/config"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/consumer"
@@ -23,6 +26,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/queue"
+	wormscanCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
 	sqs_client "github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
@@ -30,6 +34,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	health "github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
@@ -59,40 +64,79 @@ func Run() {
 	logger := logger.New("wormhole-explorer-analytics", logger.WithLevel(config.LogLevel))
 	logger.Info("starting analytics service...")
 
+	service, err := Start(rootCtx, config, logger, prometheus.DefaultRegisterer.(*prometheus.Registry))
+	if err != nil {
+		logger.Fatal("failed to start analytics service", zap.Error(err))
+	}
+
+	// Waiting for signal
+	logger.Info("waiting for termination signal or context cancellation...")
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("terminating (root context cancelled)")
+	case signal := <-sigterm:
+		logger.Info("terminating (signal received)", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("cancelling root context...")
+	rootCtxCancel()
+
+	service.Stop()
+
+	logger.Info("terminated successfully")
+}
+
+// Service is a running analytics service, started by Run or by the services that run the analytics in-process.
+type Service struct {
+	db     *dbutil.Session
+	metric *metric.Metric
+	server *http.Server
+	logger *zap.Logger
+}
+
+// Start starts the analytics service with the configuration. The consumers run until the context is cancelled.
+// The metrics are registered in the registry and served by the http server.
+func Start(rootCtx context.Context, config *config.Configuration, logger *zap.Logger, registry *prometheus.Registry) (*Service, error) {
+
 	// setup DB connection
 	logger.Info("connecting to MongoDB...")
 	db, err := dbutil.Connect(rootCtx, logger, config.MongodbURI, config.MongodbDatabase, false)
 	if err != nil {
-		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+		return nil, fmt.Errorf("failed to connect MongoDB: %w", err)
 	}
 
 	// create influxdb client.
-	logger.Info("initializing InfluxDB client...")
-	influxCli := newInfluxClient(config.InfluxUrl, config.InfluxToken)
-	influxCli.Options().SetBatchSize(100)
+	var influxCli influxdb2.Client
+	if config.HasMetricSink(metric.SinkInflux) {
+		logger.Info("initializing InfluxDB client...")
+		influxCli = newInfluxClient(config.InfluxUrl, config.InfluxToken)
+		influxCli.Options().SetBatchSize(100)
+	}
 
 	// get health check functions.
 	logger.Info("creating health check functions...")
 	healthChecks, err := newHealthChecks(rootCtx, config, influxCli, db.Database)
 	if err != nil {
-		logger.Fatal("failed to create health checks", zap.Error(err))
+		return nil, fmt.Errorf("failed to create health checks: %w", err)
 	}
 
 	//create notional cache
 	logger.Info("initializing notional cache...")
 	notionalCache, err := newNotionalCache(rootCtx, config, logger)
 	if err != nil {
-		logger.Fatal("failed to create notional cache", zap.Error(err))
+		return nil, fmt.Errorf("failed to create notional cache: %w", err)
 	}
 
 	// create prometheus client
-	metrics := metrics.NewPrometheusMetrics(config.Environment)
+	metrics := metrics.NewPrometheusMetrics(registry, config.Environment)
 
 	// create a parserVAAAPIClient
 	parserVAAAPIClient, err := parser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout,
 		config.VaaPayloadParserURL, logger)
 	if err != nil {
-		logger.Fatal("failed to create parse vaa api client")
+		return nil, fmt.Errorf("failed to create parse vaa api client: %w", err)
 	}
 
 	// create a token resolver
@@ -101,24 +145,39 @@ func Run() {
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
 
+	// create the metric sinks
+	logger.Info("initializing metric sinks...", zap.Strings("sinks", config.GetMetricSinks()))
+	sink, err := newMetricSink(rootCtx, config, influxCli, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metric sink: %w", err)
+	}
+
 	// create a metrics instance
 	logger.Info("initializing metrics instance...")
-	metric, err := metric.New(rootCtx, db.Database, influxCli, config.InfluxOrganization, config.InfluxBucketInfinite,
-		config.InfluxBucket30Days, config.InfluxBucket24Hours, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider, logger)
+	metric, err := metric.New(rootCtx, db.Database, sink, notionalCache, metrics, tokenResolver.GetTransferredTokenByVaa, tokenProvider, logger)
 	if err != nil {
-		logger.Fatal("failed to create metrics instance", zap.Error(err))
+		return nil, fmt.Errorf("failed to create metrics instance: %w", err)
 	}
 
+	// create the invalidator of the cached api responses.
+	responseInvalidator := newResponseInvalidator(config, logger)
+
 	// create and start a vaa consumer.
 	logger.Info("initializing vaa consumer...")
-	vaaConsumeFunc := newVAAConsumeFunc(rootCtx, config, logger)
-	vaaConsumer := consumer.New(vaaConsumeFunc, metric.Push, logger, metrics, config.P2pNetwork)
+	vaaConsumeFunc, err := newVAAConsumeFunc(rootCtx, config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vaa consumer: %w", err)
+	}
+	vaaConsumer := consumer.New(vaaConsumeFunc, metric.Push, responseInvalidator, logger, metrics, config.P2pNetwork)
 	vaaConsumer.Start(rootCtx)
 
 	// create and start a notification consumer.
 	logger.Info("initializing notification consumer...")
-	notificationConsumeFunc := newNotificationConsumeFunc(rootCtx, config, logger)
-	notificationConsumer := consumer.New(notificationConsumeFunc, metric.Push, logger, metrics, config.P2pNetwork)
+	notificationConsumeFunc, err := newNotificationConsumeFunc(rootCtx, config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification consumer: %w", err)
+	}
+	notificationConsumer := consumer.New(notificationConsumeFunc, metric.Push, responseInvalidator, logger, metrics, config.P2pNetwork)
 	notificationConsumer.Start(rootCtx)
 
 	// create and start server.
@@ -126,55 +185,57 @@ func Run() {
 
 	vaaRepository := vaa.NewRepository(db.Database, logger)
 	vaaController := vaa.NewController(metric.Push, vaaRepository, logger)
-	server := http.NewServer(logger, config.Port, config.PprofEnabled, vaaController, healthChecks...)
+	server := http.NewServer(logger, config.Port, config.PprofEnabled, vaaController, registry, healthChecks...)
 	server.Start()
 
-	// Waiting for signal
-	logger.Info("waiting for termination signal or context cancellation...")
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("terminating (root context cancelled)")
-	case signal := <-sigterm:
-		logger.Info("terminating (signal received)", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("cancelling root context...")
-	rootCtxCancel()
-
-	logger.Info("closing metrics client...")
-	metric.Close()
+	return &Service{db: db, metric: metric, server: server, logger: logger}, nil
+}
 
-	logger.Info("closing HTTP server...")
-	server.Stop()
+// Stop closes the metrics client, the http server and the MongoDB connection.
+func (s *Service) Stop() {
+	s.logger.Info("closing metrics client...")
+	s.metric.Close()
 
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+	s.logger.Info("closing HTTP server...")
+	s.server.Stop()
 
-	logger.Info("terminated successfully")
+	s.logger.Info("closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
 }
 
 // Creates a callbacks depending on whether the execution is local (memory queue) or not (SQS queue)
-func newVAAConsumeFunc(appCtx context.Context, config *config.Configuration, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.PipelineSQSUrl)
+func newVAAConsumeFunc(appCtx context.Context, config *config.Configuration, logger *zap.Logger) (queue.ConsumeFunc, error) {
+	receiver, err := newQueueReceiver(appCtx, config, config.PipelineSQSUrl, config.PipelineQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewVaaConverter(logger), logger)
-	return vaaQueue.Consume
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewVaaConverter(logger), logger)
+	return vaaQueue.Consume, nil
 }
 
-func newNotificationConsumeFunc(ctx context.Context, cfg *config.Configuration, logger *zap.Logger) queue.ConsumeFunc {
+func newNotificationConsumeFunc(ctx context.Context, cfg *config.Configuration, logger *zap.Logger) (queue.ConsumeFunc, error) {
 
-	sqsConsumer, err := newSQSConsumer(ctx, cfg, cfg.NotificationsSQSUrl)
+	receiver, err := newQueueReceiver(ctx, cfg, cfg.NotificationsSQSUrl, cfg.NotificationsQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewNotificationEvent(logger), logger)
-	return vaaQueue.Consume
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewNotificationEvent(logger), logger)
+	return vaaQueue.Consume, nil
+}
+
+// newQueueReceiver creates a receiver of the configured queue backend.
+func newQueueReceiver(ctx context.Context, cfg *config.Configuration, sqsUrl, queueName string, logger *zap.Logger) (common_queue.Receiver, error) {
+	if !cfg.IsSQS() {
+		return common_queue.NewReceiver(ctx, &cfg.Config, queueName)
+	}
+
+	sqsConsumer, err := newSQSConsumer(ctx, cfg, sqsUrl)
+	if err != nil {
+		return nil, err
+	}
+	return common_queue.NewSQSReceiver(sqsConsumer, logger), nil
 }
 
 func newSQSConsumer(appCtx context.Context, config *config.Configuration, sqsUrl string) (*sqs_client.Consumer, error) {
@@ -231,21 +292,97 @@ func newHealthChecks(
 		return nil, err
 	}
 
-	healthChecks := []health.Check{
-		health.SQS(awsConfig, config.PipelineSQSUrl),
-		health.SQS(awsConfig, config.NotificationsSQSUrl),
-		health.Influx(influxCli),
-		health.Mongo(db),
+	healthChecks := []health.Check{health.Mongo(db)}
+	if config.IsSQS() {
+		healthChecks = append(healthChecks,
+			health.SQS(awsConfig, config.PipelineSQSUrl),
+			health.SQS(awsConfig, config.NotificationsSQSUrl))
+	}
+	if influxCli != nil {
+		healthChecks = append(healthChecks, health.Influx(influxCli))
 	}
 	return healthChecks, nil
 }
 
+// newMetricSink creates the configured metric sinks. When more than one sink is configured,
+// the first one is the primary sink and the others are written in dual-write mode.
+func newMetricSink(
+	ctx context.Context,
+	cfg *config.Configuration,
+	influxCli influxdb2.Client,
+	metrics metrics.Metrics,
+	logger *zap.Logger,
+) (metric.Sink, error) {
+
+	var sinks []metric.Sink
+	for _, name := range cfg.GetMetricSinks() {
+		switch name {
+		case metric.SinkInflux:
+			sinks = append(sinks, metric.NewInfluxSink(influxCli, cfg.InfluxOrganization,
+				cfg.InfluxBucketInfinite, cfg.InfluxBucket30Days, cfg.InfluxBucket24Hours))
+		case metric.SinkClickHouse:
+			s, err := metric.NewClickHouseSink(metric.ClickHouseConfig{
+				URL:      cfg.ClickHouseURL,
+				Database: cfg.ClickHouseDatabase,
+				Username: cfg.ClickHouseUsername,
+				Password: cfg.ClickHousePassword,
+			})
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, s)
+		case metric.SinkPrometheus:
+			s, err := metric.NewPrometheusSink(ctx, metric.PrometheusConfig{
+				URL:           cfg.PrometheusRemoteWriteURL,
+				Username:      cfg.PrometheusUsername,
+				Password:      cfg.PrometheusPassword,
+				BearerToken:   cfg.PrometheusBearerToken,
+				DropLabels:    strings.Split(cfg.PrometheusDropLabels, ","),
+				FlushInterval: time.Duration(cfg.PrometheusFlushIntervalSecs) * time.Second,
+			}, logger)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, s)
+		case metric.SinkNoop:
+			sinks = append(sinks, metric.NewNoopSink())
+		default:
+			return nil, fmt.Errorf("unknown metric sink %s", name)
+		}
+	}
+
+	switch len(sinks) {
+	case 0:
+		return nil, errors.New("at least one metric sink must be configured")
+	case 1:
+		return sinks[0], nil
+	default:
+		return metric.NewMultiSink(sinks[0], sinks[1:], cfg.MetricDualWriteStrict, metrics, logger), nil
+	}
+}
+
+// newResponseInvalidator creates the invalidator of the cached api responses.
+// Metrics are pushed for every vaa, so each group is invalidated at most once per minute.
+// Without a cache url there are no cached responses to invalidate.
+func newResponseInvalidator(cfg *config.Configuration, logger *zap.Logger) wormscanCache.ResponseInvalidator {
+	if cfg.CacheURL == "" {
+		return wormscanCache.NewNoopResponseInvalidator()
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.CacheURL})
+	return wormscanCache.NewResponseInvalidator(redisClient, cfg.CachePrefix, time.Minute, logger)
+}
+
 func newNotionalCache(
 	ctx context.Context,
 	cfg *config.Configuration,
 	logger *zap.Logger,
 ) (wormscanNotionalCache.NotionalLocalCacheReadable, error) {
 
+	// without a cache url the notional of the tokens is not resolved.
+	if cfg.CacheURL == "" {
+		return wormscanNotionalCache.NewDummyNotionalCache(), nil
+	}
+
 	// use a distributed cache and for notional a pubsub to sync local cache.
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.CacheURL})
 
@@ -2,9 +2,11 @@ package config
 
 import (
 	"context"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 // Configuration represents the application configuration with the default values.
@@ -19,6 +21,8 @@ type Configuration struct {
 	AwsRegion               string `env:"AWS_REGION"`
 	PipelineSQSUrl          string `env:"PIPELINE_SQS_URL"`
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
+	PipelineQueueName       string `env:"PIPELINE_QUEUE_NAME,default=wormscan-pipeline"`
+	NotificationsQueueName  string `env:"NOTIFICATIONS_QUEUE_NAME,default=wormscan-notifications"`
 	InfluxUrl               string `env:"INFLUX_URL"`
 	InfluxToken             string `env:"INFLUX_TOKEN"`
 	InfluxOrganization      string `env:"INFLUX_ORGANIZATION"`
@@ -34,6 +38,27 @@ type Configuration struct {
 	CacheChannel            string `env:"CACHE_CHANNEL,required"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
+	MetricSinksConfiguration
+	// Config is the queue backend. The SQS urls are used with the sqs backend, and the queue names with the others.
+	queue.Config
+}
+
+// MetricSinksConfiguration contains the configuration of the metric sinks.
+type MetricSinksConfiguration struct {
+	// MetricSinks is the list of sinks to write the metrics to. The first one is the primary
+	// sink, and the others are written in dual-write mode.
+	MetricSinks                 string `env:"METRIC_SINKS,default=influx"`
+	MetricDualWriteStrict       bool   `env:"METRIC_DUAL_WRITE_STRICT,default=false"`
+	ClickHouseURL               string `env:"CLICKHOUSE_URL"`
+	ClickHouseDatabase          string `env:"CLICKHOUSE_DATABASE,default=wormscan"`
+	ClickHouseUsername          string `env:"CLICKHOUSE_USERNAME"`
+	ClickHousePassword          string `env:"CLICKHOUSE_PASSWORD"`
+	PrometheusRemoteWriteURL    string `env:"PROMETHEUS_REMOTE_WRITE_URL"`
+	PrometheusUsername          string `env:"PROMETHEUS_REMOTE_WRITE_USERNAME"`
+	PrometheusPassword          string `env:"PROMETHEUS_REMOTE_WRITE_PASSWORD"`
+	PrometheusBearerToken       string `env:"PROMETHEUS_REMOTE_WRITE_BEARER_TOKEN"`
+	PrometheusDropLabels        string `env:"PROMETHEUS_REMOTE_WRITE_DROP_LABELS,default=token_address"`
+	PrometheusFlushIntervalSecs int64  `env:"PROMETHEUS_REMOTE_WRITE_FLUSH_INTERVAL_SECONDS,default=15"`
 }
 
 // New creates a configuration with the values from .env file and environment variables.
@@ -52,3 +77,24 @@ func New(ctx context.Context) (*Configuration, error) {
 func (c *Configuration) IsQueueConsumer() bool {
 	return c.ConsumerMode == "QUEUE"
 }
+
+// GetMetricSinks returns the names of the metric sinks, the primary sink first.
+func (c *MetricSinksConfiguration) GetMetricSinks() []string {
+	var sinks []string
+	for _, s := range strings.Split(c.MetricSinks, ",") {
+		if s = strings.TrimSpace(strings.ToLower(s)); s != "" {
+			sinks = append(sinks, s)
+		}
+	}
+	return sinks
+}
+
+// HasMetricSink checks if the sink is one of the metric sinks.
+func (c *MetricSinksConfiguration) HasMetricSink(name string) bool {
+	for _, s := range c.GetMetricSinks() {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
@@ -6,22 +6,32 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// invalidatedResponseGroups are the groups of cached api responses built from the metrics.
+var invalidatedResponseGroups = []string{
+	cache.ResponseGroupScorecards,
+	cache.ResponseGroupTopAssets,
+	cache.ResponseGroupChainActivity,
+	cache.ResponseGroupProtocols,
+}
+
 // Consumer consumer struct definition.
 type Consumer struct {
-	consume    queue.ConsumeFunc
-	pushMetric metric.MetricPushFunc
-	logger     *zap.Logger
-	metrics    metrics.Metrics
-	p2pNetwork string
+	consume     queue.ConsumeFunc
+	pushMetric  metric.MetricPushFunc
+	invalidator cache.ResponseInvalidator
+	logger      *zap.Logger
+	metrics     metrics.Metrics
+	p2pNetwork  string
 }
 
 // New creates a new vaa consumer.
-func New(consume queue.ConsumeFunc, pushMetric metric.MetricPushFunc, logger *zap.Logger, metrics metrics.Metrics, p2pNetwork string) *Consumer {
-	return &Consumer{consume: consume, pushMetric: pushMetric, logger: logger, metrics: metrics, p2pNetwork: p2pNetwork}
+func New(consume queue.ConsumeFunc, pushMetric metric.MetricPushFunc, invalidator cache.ResponseInvalidator, logger *zap.Logger, metrics metrics.Metrics, p2pNetwork string) *Consumer {
+	return &Consumer{consume: consume, pushMetric: pushMetric, invalidator: invalidator, logger: logger, metrics: metrics, p2pNetwork: p2pNetwork}
 }
 
 // Start consumes messages from VAA queue, parse and store those messages in a repository.
@@ -59,6 +69,7 @@ func (c *Consumer) Start(ctx context.Context) {
 
 			msg.Done()
 			c.logger.Debug("Pushed vaa metric", zap.String("id", event.ID))
+			_ = c.invalidator.Invalidate(ctx, invalidatedResponseGroups...)
 			c.metrics.IncProcessedMessage(chainID, event.Source, msg.Retry())
 			c.metrics.VaaProcessingDuration(chainID, msg.SentTimestamp())
 		}
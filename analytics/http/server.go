@@ -3,7 +3,10 @@ package http
 import (
 	"github.com/ansrivas/fiberprometheus/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/http/vaa"
 	health "github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"go.uber.org/zap"
@@ -15,12 +18,12 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, vaaController *vaa.Controller, checks ...health.Check) *Server {
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, vaaController *vaa.Controller, registry *prometheus.Registry, checks ...health.Check) *Server {
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	// Configure prometheus middleware
-	prometheus := fiberprometheus.New("wormscan-analytics")
-	prometheus.RegisterAt(app, "/metrics")
+	prometheus := fiberprometheus.NewWithRegistry(registry, "wormscan-analytics", "http", "", nil)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
 	app.Use(prometheus.Middleware)
 
 	// config use of middlware.
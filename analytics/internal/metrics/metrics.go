@@ -7,6 +7,7 @@ const serviceName = "wormscan-analytics"
 type Metrics interface {
 	IncFailedMeasurement(measurement string)
 	IncSuccessfulMeasurement(measurement string)
+	IncSkippedMeasurement(measurement string)
 	IncMissingNotional(symbol string)
 	IncFoundNotional(symbol string)
 	IncMissingToken(chain, token string)
@@ -16,4 +17,6 @@ type Metrics interface {
 	IncUnprocessedMessage(chain, source string, retry uint8)
 	IncProcessedMessage(chain, source string, retry uint8)
 	VaaProcessingDuration(chain string, start *time.Time)
+	IncFailedSinkWrite(sink string)
+	IncSuccessfulSinkWrite(sink string)
 }
@@ -17,6 +17,9 @@ func (p *NoopMetrics) IncFailedMeasurement(measurement string) {
 func (p *NoopMetrics) IncSuccessfulMeasurement(measurement string) {
 }
 
+func (p *NoopMetrics) IncSkippedMeasurement(measurement string) {
+}
+
 func (p *NoopMetrics) IncMissingNotional(symbol string) {
 }
 
@@ -43,3 +46,9 @@ func (p *NoopMetrics) IncProcessedMessage(chain, source string, retry uint8) {
 
 func (m *NoopMetrics) VaaProcessingDuration(chain string, start *time.Time) {
 }
+
+func (p *NoopMetrics) IncFailedSinkWrite(sink string) {
+}
+
+func (p *NoopMetrics) IncSuccessfulSinkWrite(sink string) {
+}
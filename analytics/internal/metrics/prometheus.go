@@ -14,24 +14,26 @@ type PrometheusMetrics struct {
 	tokenRequestsCount    *prometheus.CounterVec
 	processedMessage      *prometheus.CounterVec
 	vaaProcessingDuration *prometheus.HistogramVec
+	sinkWriteCount        *prometheus.CounterVec
 }
 
-// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
 
 	constLabels := map[string]string{
 		"environment": environment,
 		"service":     serviceName,
 	}
 
-	measurementCount := promauto.NewCounterVec(
+	measurementCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "measurement_count",
 			Help:        "Total number of measurement",
 			ConstLabels: constLabels,
 		}, []string{"measurement", "status"})
 
-	notionalRequestsCount := promauto.NewCounterVec(
+	notionalRequestsCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "notional_requests_count_by_symbol",
 			Help:        "Total number requests of notional by symbol",
@@ -40,7 +42,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		[]string{"symbol", "status"},
 	)
 
-	tokenRequestsCount := promauto.NewCounterVec(
+	tokenRequestsCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "token_requests_count",
 			Help:        "Total number of missing notional by symbol",
@@ -49,7 +51,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		[]string{"chain", "token", "status"},
 	)
 
-	processedMessage := promauto.NewCounterVec(
+	processedMessage := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "processed_message",
 			Help:        "Total number of processed message",
@@ -57,7 +59,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		},
 		[]string{"chain", "source", "status", "retry"},
 	)
-	vaaProcessingDuration := promauto.NewHistogramVec(
+	vaaProcessingDuration := factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:        "vaa_processing_duration_seconds",
 			Help:        "Duration of all vaa processing by chain.",
@@ -66,12 +68,21 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		},
 		[]string{"chain"},
 	)
+	sinkWriteCount := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "metric_sink_write_count",
+			Help:        "Total number of writes to the metric sinks",
+			ConstLabels: constLabels,
+		},
+		[]string{"sink", "status"},
+	)
 	return &PrometheusMetrics{
 		measurementCount:      measurementCount,
 		notionalCount:         notionalRequestsCount,
 		tokenRequestsCount:    tokenRequestsCount,
 		processedMessage:      processedMessage,
 		vaaProcessingDuration: vaaProcessingDuration,
+		sinkWriteCount:        sinkWriteCount,
 	}
 }
 
@@ -83,6 +94,10 @@ func (p *PrometheusMetrics) IncSuccessfulMeasurement(measurement string) {
 	p.measurementCount.WithLabelValues(measurement, "successful").Inc()
 }
 
+func (p *PrometheusMetrics) IncSkippedMeasurement(measurement string) {
+	p.measurementCount.WithLabelValues(measurement, "skipped").Inc()
+}
+
 func (p *PrometheusMetrics) IncMissingNotional(symbol string) {
 	p.notionalCount.WithLabelValues(symbol, "missing").Inc()
 }
@@ -122,3 +137,11 @@ func (p *PrometheusMetrics) VaaProcessingDuration(chain string, start *time.Time
 	elapsed := float64(time.Since(*start).Nanoseconds()) / 1e9
 	p.vaaProcessingDuration.WithLabelValues(chain).Observe(elapsed)
 }
+
+func (p *PrometheusMetrics) IncFailedSinkWrite(sink string) {
+	p.sinkWriteCount.WithLabelValues(sink, "failed").Inc()
+}
+
+func (p *PrometheusMetrics) IncSuccessfulSinkWrite(sink string) {
+	p.sinkWriteCount.WithLabelValues(sink, "successful").Inc()
+}
@@ -0,0 +1,145 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// clickHouseTimeLayout is the layout of the DateTime64(9) columns.
+const clickHouseTimeLayout = "2006-01-02 15:04:05.999999999"
+
+var clickHouseIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// DefaultClickHouseDatabase is the database where scripts/clickhouse create the tables.
+const DefaultClickHouseDatabase = "wormscan"
+
+// ClickHouseConfig is the configuration of the ClickHouse sink.
+type ClickHouseConfig struct {
+	// URL is the ClickHouse HTTP interface url.
+	URL string
+	// Database is the database of the tables, the one created by scripts/clickhouse by default.
+	Database string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// ClickHouseSink writes the points to ClickHouse through its HTTP interface.
+//
+// Each measurement is written to the table with the same name, with a `time` column and
+// one column per tag and field. The retention of the bucket is defined by the TTL of
+// the tables (see scripts/clickhouse).
+type ClickHouseSink struct {
+	cfg    ClickHouseConfig
+	client *http.Client
+}
+
+// NewClickHouseSink creates a new ClickHouseSink.
+func NewClickHouseSink(cfg ClickHouseConfig) (*ClickHouseSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("clickhouse url can not be empty")
+	}
+	if cfg.Database == "" {
+		cfg.Database = DefaultClickHouseDatabase
+	}
+	if !clickHouseIdentifier.MatchString(cfg.Database) {
+		return nil, fmt.Errorf("invalid clickhouse database %s", cfg.Database)
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &ClickHouseSink{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}, nil
+}
+
+// Name returns the name of the sink.
+func (s *ClickHouseSink) Name() string {
+	return SinkClickHouse
+}
+
+// Write inserts the points in the measurement tables.
+func (s *ClickHouseSink) Write(ctx context.Context, _ Bucket, points ...*write.Point) error {
+	// group the rows by table, keeping the order of the points.
+	var tables []string
+	rows := make(map[string]*bytes.Buffer)
+	for _, p := range points {
+		table := p.Name()
+		if !clickHouseIdentifier.MatchString(table) {
+			return fmt.Errorf("invalid measurement name %s", table)
+		}
+		buf, ok := rows[table]
+		if !ok {
+			buf = &bytes.Buffer{}
+			rows[table] = buf
+			tables = append(tables, table)
+		}
+		row, err := json.Marshal(toClickHouseRow(p))
+		if err != nil {
+			return err
+		}
+		buf.Write(row)
+		buf.WriteByte('\n')
+	}
+
+	for _, table := range tables {
+		if err := s.insert(ctx, table, rows[table]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close does nothing, the points are written synchronously.
+func (s *ClickHouseSink) Close(_ context.Context) error {
+	return nil
+}
+
+func (s *ClickHouseSink) insert(ctx context.Context, table string, body io.Reader) error {
+	query := url.Values{}
+	query.Set("query", fmt.Sprintf("INSERT INTO %s.%s FORMAT JSONEachRow", s.cfg.Database, table))
+	query.Set("input_format_skip_unknown_fields", "1")
+	// let the server batch the small inserts.
+	query.Set("async_insert", "1")
+	query.Set("wait_for_async_insert", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL+"/?"+query.Encode(), body)
+	if err != nil {
+		return err
+	}
+	if s.cfg.Username != "" {
+		req.Header.Set("X-ClickHouse-User", s.cfg.Username)
+		req.Header.Set("X-ClickHouse-Key", s.cfg.Password)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("failed to insert into %s: status code %d: %s", table, res.StatusCode, string(msg))
+	}
+	return nil
+}
+
+// toClickHouseRow converts a point into a JSONEachRow row.
+func toClickHouseRow(p *write.Point) map[string]any {
+	row := make(map[string]any, len(p.TagList())+len(p.FieldList())+1)
+	row["time"] = p.Time().UTC().Format(clickHouseTimeLayout)
+	for _, t := range p.TagList() {
+		row[t.Key] = t.Value
+	}
+	for _, f := range p.FieldList() {
+		row[f.Key] = f.Value
+	}
+	return row
+}
@@ -0,0 +1,36 @@
+package metric
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToClickHouseRow(t *testing.T) {
+	location := time.FixedZone("UTC+3", 3*60*60)
+	p := write.NewPoint(
+		"vaa_volume_v3",
+		map[string]string{"emitter_chain": "2", "token_address": "0xabc"},
+		map[string]interface{}{"volume": uint64(1500), "symbol": "USDC"},
+		time.Date(2024, 5, 1, 13, 4, 5, 123456789, location),
+	)
+
+	row := toClickHouseRow(p)
+
+	// the time is converted to UTC, with nanoseconds.
+	assert.Equal(t, map[string]any{
+		"time":          "2024-05-01 10:04:05.123456789",
+		"emitter_chain": "2",
+		"token_address": "0xabc",
+		"volume":        uint64(1500),
+		"symbol":        "USDC",
+	}, row)
+}
+
+func TestNewClickHouseSink_DefaultDatabase(t *testing.T) {
+	s, err := NewClickHouseSink(ClickHouseConfig{URL: "http://localhost:8123"})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultClickHouseDatabase, s.cfg.Database)
+}
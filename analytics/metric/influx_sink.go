@@ -0,0 +1,54 @@
+package metric
+
+import (
+	"context"
+	"fmt"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// InfluxSink writes the points to InfluxDB, one bucket per retention.
+type InfluxSink struct {
+	client influxdb2.Client
+	apis   map[Bucket]api.WriteAPIBlocking
+}
+
+// NewInfluxSink creates a new InfluxSink.
+func NewInfluxSink(client influxdb2.Client, organization, bucketInfinite, bucket30Days, bucket24Hours string) *InfluxSink {
+	apiBucket24Hours := client.WriteAPIBlocking(organization, bucket24Hours)
+	apiBucket24Hours.EnableBatching()
+
+	return &InfluxSink{
+		client: client,
+		apis: map[Bucket]api.WriteAPIBlocking{
+			BucketInfinite: client.WriteAPIBlocking(organization, bucketInfinite),
+			Bucket30Days:   client.WriteAPIBlocking(organization, bucket30Days),
+			Bucket24Hours:  apiBucket24Hours,
+		},
+	}
+}
+
+// Name returns the name of the sink.
+func (s *InfluxSink) Name() string {
+	return SinkInflux
+}
+
+// Write writes the points to the bucket.
+func (s *InfluxSink) Write(ctx context.Context, bucket Bucket, points ...*write.Point) error {
+	writeAPI, ok := s.apis[bucket]
+	if !ok {
+		return fmt.Errorf("unknown bucket %s", bucket)
+	}
+	return writeAPI.WritePoint(ctx, points...)
+}
+
+// Close flushes all buckets and closes the influx client.
+func (s *InfluxSink) Close(ctx context.Context) error {
+	for _, writeAPI := range s.apis {
+		writeAPI.Flush(ctx)
+	}
+	s.client.Close()
+	return nil
+}
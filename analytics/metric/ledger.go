@@ -0,0 +1,137 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TransferPricesMeasurement is the ledger key of the `transferPrices` collection upsert.
+const TransferPricesMeasurement = "transfer_prices"
+
+// ledgerRetention is the time a VAA is kept in the ledger after its last update.
+//
+// Messages are only retried for a few hours, and a VAA that is processed again after its entry
+// expired writes points with the same tags and timestamps, so the previous points are overwritten.
+const ledgerRetention = 30 * 24 * time.Hour
+
+// MeasurementStatus is the state of a measurement write for a VAA.
+type MeasurementStatus string
+
+const (
+	// MeasurementStatusDone means the measurement was written (or intentionally skipped) and must not be written again.
+	MeasurementStatusDone MeasurementStatus = "done"
+	// MeasurementStatusFailed means the last attempt to write the measurement failed and it must be retried.
+	MeasurementStatusFailed MeasurementStatus = "failed"
+)
+
+// MeasurementState is the write state of a single measurement.
+type MeasurementState struct {
+	Status    MeasurementStatus `bson:"status"`
+	Attempts  int               `bson:"attempts"`
+	Error     string            `bson:"error,omitempty"`
+	UpdatedAt time.Time         `bson:"updatedAt"`
+}
+
+// ProcessedVaaDoc models a document in the `processedVaaMetrics` collection.
+type ProcessedVaaDoc struct {
+	// ID is the message id of the VAA (chain/emitter/sequence).
+	ID string `bson:"_id"`
+	// Measurements contains the write state of each measurement, keyed by measurement name.
+	Measurements map[string]MeasurementState `bson:"measurements"`
+	CreatedAt    time.Time                   `bson:"createdAt"`
+	UpdatedAt    time.Time                   `bson:"updatedAt"`
+}
+
+// IsDone returns true if the measurement was already written for the VAA.
+func (d *ProcessedVaaDoc) IsDone(measurement string) bool {
+	if d == nil {
+		return false
+	}
+	state, ok := d.Measurements[measurement]
+	return ok && state.Status == MeasurementStatusDone
+}
+
+// Ledger keeps track of the measurements written for each VAA, so that a VAA that is
+// processed more than once (e.g.: an SQS message retried after a partial failure)
+// only retries the measurements that failed.
+type Ledger struct {
+	collection *mongo.Collection
+}
+
+// NewLedger creates a new processed-VAA ledger.
+func NewLedger(db *mongo.Database) *Ledger {
+	return &Ledger{collection: db.Collection("processedVaaMetrics")}
+}
+
+// CreateIndexes creates the TTL index that removes the VAAs that were not updated during the ledger retention.
+func (l *Ledger) CreateIndexes(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "updatedAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(ledgerRetention.Seconds())),
+	}
+	_, err := l.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// Get returns the ledger entry of a VAA. If the VAA was never processed, an empty entry is returned.
+func (l *Ledger) Get(ctx context.Context, vaaID string) (*ProcessedVaaDoc, error) {
+	var doc ProcessedVaaDoc
+	err := l.collection.FindOne(ctx, bson.M{"_id": vaaID}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &ProcessedVaaDoc{ID: vaaID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processed vaa %s: %w", vaaID, err)
+	}
+	return &doc, nil
+}
+
+// Record stores the result of the measurement writes of a VAA, keyed by measurement name.
+// A nil error marks the measurement as done, otherwise it is marked as failed.
+func (l *Ledger) Record(ctx context.Context, vaaID string, results map[string]error) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	update := newRecordUpdate(results, time.Now())
+	_, err := l.collection.UpdateByID(ctx, vaaID, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to record measurements for vaa %s: %w", vaaID, err)
+	}
+	return nil
+}
+
+// newRecordUpdate builds the upsert that stores the result of the measurement writes of a VAA.
+func newRecordUpdate(results map[string]error, now time.Time) bson.M {
+	set := bson.M{"updatedAt": now}
+	unset := bson.M{}
+	inc := bson.M{}
+	for measurement, writeErr := range results {
+		prefix := "measurements." + measurement
+		set[prefix+".updatedAt"] = now
+		inc[prefix+".attempts"] = 1
+		if writeErr != nil {
+			set[prefix+".status"] = MeasurementStatusFailed
+			set[prefix+".error"] = writeErr.Error()
+		} else {
+			set[prefix+".status"] = MeasurementStatusDone
+			unset[prefix+".error"] = ""
+		}
+	}
+
+	update := bson.M{
+		"$set":         set,
+		"$inc":         inc,
+		"$setOnInsert": bson.M{"createdAt": now},
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
@@ -0,0 +1,70 @@
+package metric
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestProcessedVaaDoc_IsDone(t *testing.T) {
+	doc := &ProcessedVaaDoc{
+		ID: "2/0000000000000000000000000000000000000000000000000000000000000001/1",
+		Measurements: map[string]MeasurementState{
+			VaaCountMeasurement:  {Status: MeasurementStatusDone, Attempts: 1},
+			VaaVolumeMeasurement: {Status: MeasurementStatusFailed, Attempts: 2, Error: "timeout"},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		doc         *ProcessedVaaDoc
+		measurement string
+		expected    bool
+	}{
+		{name: "done measurement", doc: doc, measurement: VaaCountMeasurement, expected: true},
+		{name: "failed measurement", doc: doc, measurement: VaaVolumeMeasurement, expected: false},
+		{name: "missing measurement", doc: doc, measurement: TransferPricesMeasurement, expected: false},
+		{name: "empty entry", doc: &ProcessedVaaDoc{}, measurement: VaaCountMeasurement, expected: false},
+		{name: "nil entry", doc: nil, measurement: VaaCountMeasurement, expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.doc.IsDone(tc.measurement))
+		})
+	}
+}
+
+func TestNewRecordUpdate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	update := newRecordUpdate(map[string]error{
+		VaaCountMeasurement:  nil,
+		VaaVolumeMeasurement: errors.New("timeout"),
+	}, now)
+
+	assert.Equal(t, bson.M{
+		"updatedAt":                            now,
+		"measurements.vaa_count.updatedAt":     now,
+		"measurements.vaa_count.status":        MeasurementStatusDone,
+		"measurements.vaa_volume_v2.updatedAt": now,
+		"measurements.vaa_volume_v2.status":    MeasurementStatusFailed,
+		"measurements.vaa_volume_v2.error":     "timeout",
+	}, update["$set"])
+	assert.Equal(t, bson.M{
+		"measurements.vaa_count.attempts":     1,
+		"measurements.vaa_volume_v2.attempts": 1,
+	}, update["$inc"])
+	assert.Equal(t, bson.M{"measurements.vaa_count.error": ""}, update["$unset"])
+	assert.Equal(t, bson.M{"createdAt": now}, update["$setOnInsert"])
+}
+
+func TestNewRecordUpdate_WithoutSuccessfulWrites(t *testing.T) {
+	update := newRecordUpdate(map[string]error{VaaCountMeasurement: errors.New("timeout")}, time.Now())
+
+	_, ok := update["$unset"]
+	assert.False(t, ok, "an empty $unset is rejected by MongoDB")
+}
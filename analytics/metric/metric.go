@@ -2,13 +2,13 @@ package metric
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
-	"github.com/influxdata/influxdb-client-go/v2/api"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
 	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
@@ -26,15 +26,20 @@ const (
 	VaaAllMessagesMeasurement = "vaa_count_all_messages"
 )
 
+// processedVaaLedger keeps track of the measurements written for each VAA.
+type processedVaaLedger interface {
+	Get(ctx context.Context, vaaID string) (*ProcessedVaaDoc, error)
+	Record(ctx context.Context, vaaID string, results map[string]error) error
+}
+
 // Metric definition.
 type Metric struct {
 	db *mongo.Database
 	// transferPrices contains the notional price for each token bridge transfer.
-	transferPrices           *mongo.Collection
-	influxCli                influxdb2.Client
-	apiBucketInfinite        api.WriteAPIBlocking
-	apiBucket30Days          api.WriteAPIBlocking
-	apiBucket24Hours         api.WriteAPIBlocking
+	transferPrices *mongo.Collection
+	// ledger keeps track of the measurements written for each VAA.
+	ledger                   processedVaaLedger
+	sink                     Sink
 	notionalCache            wormscanNotionalCache.NotionalLocalCacheReadable
 	metrics                  metrics.Metrics
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa
@@ -46,11 +51,7 @@ type Metric struct {
 func New(
 	ctx context.Context,
 	db *mongo.Database,
-	influxCli influxdb2.Client,
-	organization string,
-	bucketInifite string,
-	bucket30Days string,
-	bucket24Hours string,
+	sink Sink,
 	notionalCache wormscanNotionalCache.NotionalLocalCacheReadable,
 	metrics metrics.Metrics,
 	getTransferredTokenByVaa token.GetTransferredTokenByVaa,
@@ -58,18 +59,16 @@ func New(
 	logger *zap.Logger,
 ) (*Metric, error) {
 
-	apiBucketInfinite := influxCli.WriteAPIBlocking(organization, bucketInifite)
-	apiBucket30Days := influxCli.WriteAPIBlocking(organization, bucket30Days)
-	apiBucket24Hours := influxCli.WriteAPIBlocking(organization, bucket24Hours)
-	apiBucket24Hours.EnableBatching()
+	ledger := NewLedger(db)
+	if err := ledger.CreateIndexes(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create processed vaa ledger indexes: %w", err)
+	}
 
 	m := Metric{
 		db:                       db,
 		transferPrices:           db.Collection("transferPrices"),
-		influxCli:                influxCli,
-		apiBucketInfinite:        apiBucketInfinite,
-		apiBucket24Hours:         apiBucket24Hours,
-		apiBucket30Days:          apiBucket30Days,
+		ledger:                   ledger,
+		sink:                     sink,
 		logger:                   logger,
 		notionalCache:            notionalCache,
 		metrics:                  metrics,
@@ -80,91 +79,148 @@ func New(
 }
 
 // Push implement MetricPushFunc definition.
+//
+// Each measurement is written at most once per VAA: the processed-VAA ledger records the
+// result of every write, so when a message is retried after a partial failure only the
+// measurements that failed are written again.
 func (m *Metric) Push(ctx context.Context, params *Params) error {
 
-	var err1, err2, err3, err4 error
+	vaaID := params.Vaa.MessageID()
+
+	processed, err := m.ledger.Get(ctx, vaaID)
+	if err != nil {
+		m.logger.Error("Failed to get processed vaa from ledger",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", vaaID),
+			zap.Error(err))
+		return err
+	}
+
+	results := make(map[string]error)
+	write := func(measurement string, writeFunc func() error) {
+		if processed.IsDone(measurement) {
+			m.logger.Debug("Measurement already written for this VAA, skipping",
+				zap.String("trackId", params.TrackID),
+				zap.String("vaaId", vaaID),
+				zap.String("measurement", measurement))
+			m.metrics.IncSkippedMeasurement(measurement)
+			return
+		}
+		results[measurement] = writeFunc()
+	}
 
 	isVaaSigned := params.VaaIsSigned
 
 	if isVaaSigned {
-		err1 = m.vaaCountMeasurement(ctx, params)
+		write(VaaCountMeasurement, func() error {
+			return m.vaaCountMeasurement(ctx, params)
+		})
 
-		err2 = m.vaaCountAllMessagesMeasurement(ctx, params)
+		write(VaaAllMessagesMeasurement, func() error {
+			return m.vaaCountAllMessagesMeasurement(ctx, params)
+		})
 	}
 
-	if params.Vaa.EmitterChain != sdk.ChainIDPythNet {
+	pendingVolume := isVaaSigned && !processed.IsDone(VaaVolumeMeasurement)
+	pendingPrices := !processed.IsDone(TransferPricesMeasurement)
+
+	if params.Vaa.EmitterChain != sdk.ChainIDPythNet && (pendingVolume || pendingPrices) {
 
 		transferredToken, err := m.getTransferredTokenByVaa(ctx, params.Vaa)
 		if err != nil {
 			if !token.IsUnknownTokenErr(err) {
 				m.logger.Error("Failed to obtain transferred token for this VAA",
 					zap.String("trackId", params.TrackID),
-					zap.String("vaaId", params.Vaa.MessageID()),
+					zap.String("vaaId", vaaID),
 					zap.Error(err))
-				return err
+				return errors.Join(err, m.recordResults(ctx, params, results))
 			}
 		}
 
 		if transferredToken != nil {
 
 			if isVaaSigned {
-				err3 = m.volumeMeasurement(ctx, params, transferredToken.Clone())
+				write(VaaVolumeMeasurement, func() error {
+					return m.volumeMeasurement(ctx, params, transferredToken.Clone())
+				})
 			}
 
-			err4 = UpsertTransferPrices(
-				ctx,
-				m.logger,
-				params.Vaa,
-				m.transferPrices,
-				func(tokenID, _ string, timestamp time.Time) (decimal.Decimal, error) {
-
-					priceData, err := m.notionalCache.Get(tokenID)
-					if err != nil {
-						return decimal.NewFromInt(0), err
-					}
-					return priceData.NotionalUsd, nil
-				},
-				transferredToken.Clone(),
-				m.tokenProvider,
-			)
+			write(TransferPricesMeasurement, func() error {
+				return UpsertTransferPrices(
+					ctx,
+					m.logger,
+					params.Vaa,
+					m.transferPrices,
+					func(tokenID, _ string, timestamp time.Time) (decimal.Decimal, error) {
+
+						priceData, err := m.notionalCache.Get(tokenID)
+						if err != nil {
+							return decimal.NewFromInt(0), err
+						}
+						return priceData.NotionalUsd, nil
+					},
+					transferredToken.Clone(),
+					m.tokenProvider,
+				)
+			})
 
 		} else {
 			m.logger.Warn("Cannot obtain transferred token for this VAA",
 				zap.Error(err),
 				zap.String("trackId", params.TrackID),
-				zap.String("vaaId", params.Vaa.MessageID()),
+				zap.String("vaaId", vaaID),
 			)
 		}
 	}
 
-	//TODO if we had go 1.20, we could just use `errors.Join(err1, err2, err3, ...)` here.
-	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
-		return fmt.Errorf("err1=%w, err2=%w, err3=%w err4=%w", err1, err2, err3, err4)
+	var errs []error
+	for measurement, err := range results {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", measurement, err))
+		}
+	}
+	errs = append(errs, m.recordResults(ctx, params, results))
+	if err := errors.Join(errs...); err != nil {
+		return err
 	}
 
 	if params.Vaa.EmitterChain != sdk.ChainIDPythNet {
 		m.logger.Info("Transaction processed successfully",
 			zap.String("trackId", params.TrackID),
 			zap.Bool("isVaaSigned", isVaaSigned),
-			zap.String("vaaId", params.Vaa.MessageID()))
+			zap.String("vaaId", vaaID))
 	}
 
 	return nil
 }
 
-// Close influx client.
+// recordResults stores the result of the measurement writes in the processed-VAA ledger.
+//
+// If the ledger can not be updated the error is returned so that the message is retried:
+// points have deterministic tags and timestamps, so rewriting them overwrites the
+// previous points instead of double counting.
+func (m *Metric) recordResults(ctx context.Context, params *Params, results map[string]error) error {
+	err := m.ledger.Record(ctx, params.Vaa.MessageID(), results)
+	if err != nil {
+		m.logger.Error("Failed to record measurements in ledger",
+			zap.String("trackId", params.TrackID),
+			zap.String("vaaId", params.Vaa.MessageID()),
+			zap.Error(err))
+	}
+	return err
+}
+
+// Close the metric sink.
 func (m *Metric) Close() {
 
 	const flushTimeout = 5 * time.Second
 
 	// wait a bounded amount of time for all buckets to flush
 	ctx, cancelFunc := context.WithTimeout(context.Background(), flushTimeout)
-	m.apiBucket24Hours.Flush(ctx)
-	m.apiBucket30Days.Flush(ctx)
-	m.apiBucketInfinite.Flush(ctx)
-	cancelFunc()
-
-	m.influxCli.Close()
+	defer cancelFunc()
+	if err := m.sink.Close(ctx); err != nil {
+		m.logger.Error("Failed to close metric sink", zap.Error(err))
+	}
 }
 
 // vaaCountMeasurement creates a new point for the `vaa_count` measurement.
@@ -186,8 +242,8 @@ func (m *Metric) vaaCountMeasurement(ctx context.Context, p *Params) error {
 		return nil
 	}
 
-	// Write the point to influx
-	err = m.apiBucket30Days.WritePoint(ctx, point)
+	// Write the point to the sink
+	err = m.sink.Write(ctx, Bucket30Days, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", point.Name()),
@@ -224,8 +280,8 @@ func (m *Metric) vaaCountAllMessagesMeasurement(ctx context.Context, params *Par
 		AddField("count", 1).
 		SetTime(generateUniqueTimestamp(params.Vaa))
 
-	// Write the point to influx
-	err := m.apiBucket24Hours.WritePoint(ctx, point)
+	// Write the point to the sink
+	err := m.sink.Write(ctx, Bucket24Hours, point)
 	if err != nil {
 		m.logger.Error("Failed to write metric",
 			zap.String("measurement", VaaAllMessagesMeasurement),
@@ -271,8 +327,8 @@ func (m *Metric) volumeMeasurement(ctx context.Context, params *Params, token *t
 
 	vaaVolumeV3point := m.MakePointVaaVolumeV3(point, params, token)
 
-	// Write the point to influx
-	err = m.apiBucketInfinite.WritePoint(ctx, point, vaaVolumeV3point)
+	// Write the point to the sink
+	err = m.sink.Write(ctx, BucketInfinite, point, vaaVolumeV3point)
 	if err != nil {
 		m.metrics.IncFailedMeasurement(VaaVolumeMeasurement)
 		return err
@@ -440,7 +496,7 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 		AddTag("token_chain", fmt.Sprintf("%d", params.TransferredToken.TokenChain)).
 		// Measurement version
 		AddTag("version", "v2").
-		SetTime(params.Vaa.Timestamp)
+		SetTime(generateUniqueTimestamp(params.Vaa))
 
 	// Get the token metadata
 	//
@@ -516,12 +572,15 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 		// Token price at the time the VAA was emitted, integer, 8 decimals of precision
 		AddField("notional", notionalBigInt.Uint64()).
 		// Volume in USD, integer, 8 decimals of precision
-		AddField("volume", volume.Uint64()).
-		SetTime(generateUniqueTimestamp(params.Vaa))
+		AddField("volume", volume.Uint64())
 
 	return point, nil
 }
 
+// MaxTimestampOffset is the upper bound of the offset that generateUniqueTimestamp adds to the
+// VAA timestamp, so a point may fall up to this amount after the timestamp of its VAA.
+const MaxTimestampOffset = time.Millisecond
+
 // generateUniqueTimestamp generates a unique timestamp for each VAA.
 //
 // Most VAA timestamps only have millisecond resolution, so it is possible that two VAAs
@@ -529,6 +588,8 @@ func MakePointForVaaVolume(params *MakePointForVaaVolumeParams) (*write.Point, e
 // By the way InfluxDB works, two points with the same timesamp will overwrite each other.
 //
 // Hence, we are forced to generate a deterministic unique timestamp for each VAA.
+// Since points are keyed by their tags and timestamp, writing the same VAA twice overwrites
+// the previous point, so this function must not change or replays would double count.
 func generateUniqueTimestamp(vaa *sdk.VAA) time.Time {
 
 	// We're adding 1 a nanosecond offset per sequence.
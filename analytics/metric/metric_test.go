@@ -0,0 +1,168 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/token"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// memoryLedger is an in-memory processedVaaLedger.
+type memoryLedger struct {
+	docs   map[string]*ProcessedVaaDoc
+	getErr error
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{docs: make(map[string]*ProcessedVaaDoc)}
+}
+
+func (l *memoryLedger) Get(_ context.Context, vaaID string) (*ProcessedVaaDoc, error) {
+	if l.getErr != nil {
+		return nil, l.getErr
+	}
+	if doc, ok := l.docs[vaaID]; ok {
+		return doc, nil
+	}
+	return &ProcessedVaaDoc{ID: vaaID}, nil
+}
+
+func (l *memoryLedger) Record(_ context.Context, vaaID string, results map[string]error) error {
+	if len(results) == 0 {
+		return nil
+	}
+	doc, ok := l.docs[vaaID]
+	if !ok {
+		doc = &ProcessedVaaDoc{ID: vaaID, Measurements: make(map[string]MeasurementState)}
+		l.docs[vaaID] = doc
+	}
+	for measurement, writeErr := range results {
+		state := doc.Measurements[measurement]
+		state.Attempts++
+		state.Status = MeasurementStatusDone
+		state.Error = ""
+		if writeErr != nil {
+			state.Status = MeasurementStatusFailed
+			state.Error = writeErr.Error()
+		}
+		doc.Measurements[measurement] = state
+	}
+	return nil
+}
+
+// stubSink stores the written points by bucket and fails the writes of the buckets in failures.
+type stubSink struct {
+	points   map[Bucket][]*write.Point
+	failures map[Bucket]error
+}
+
+func newStubSink() *stubSink {
+	return &stubSink{points: make(map[Bucket][]*write.Point), failures: make(map[Bucket]error)}
+}
+
+func (s *stubSink) Name() string {
+	return "stub"
+}
+
+func (s *stubSink) Write(_ context.Context, bucket Bucket, points ...*write.Point) error {
+	if err := s.failures[bucket]; err != nil {
+		return err
+	}
+	s.points[bucket] = append(s.points[bucket], points...)
+	return nil
+}
+
+func (s *stubSink) Close(context.Context) error {
+	return nil
+}
+
+func newTestMetric(ledger processedVaaLedger, sink Sink) *Metric {
+	return &Metric{
+		ledger:  ledger,
+		sink:    sink,
+		metrics: metrics.NewNoopMetrics(),
+		getTransferredTokenByVaa: func(context.Context, *sdk.VAA) (*token.TransferredToken, error) {
+			return nil, nil
+		},
+		logger: zap.NewNop(),
+	}
+}
+
+func newTestParams() *Params {
+	return &Params{
+		TrackID: "test",
+		Vaa: &sdk.VAA{
+			EmitterChain: sdk.ChainIDEthereum,
+			Sequence:     42,
+			Timestamp:    time.Now().Add(-time.Minute),
+		},
+		VaaIsSigned: true,
+	}
+}
+
+func TestPush_RetriesOnlyFailedMeasurements(t *testing.T) {
+	ledger := newMemoryLedger()
+	sink := newStubSink()
+	m := newTestMetric(ledger, sink)
+	params := newTestParams()
+	vaaID := params.Vaa.MessageID()
+
+	// the first attempt fails to write the all messages count
+	sink.failures[Bucket24Hours] = errors.New("sink unavailable")
+	err := m.Push(context.Background(), params)
+	require.Error(t, err)
+	assert.Len(t, sink.points[Bucket30Days], 1)
+	assert.Empty(t, sink.points[Bucket24Hours])
+	assert.True(t, ledger.docs[vaaID].IsDone(VaaCountMeasurement))
+	assert.False(t, ledger.docs[vaaID].IsDone(VaaAllMessagesMeasurement))
+	assert.Equal(t, "sink unavailable", ledger.docs[vaaID].Measurements[VaaAllMessagesMeasurement].Error)
+
+	// the retry only writes the measurement that failed
+	delete(sink.failures, Bucket24Hours)
+	err = m.Push(context.Background(), params)
+	require.NoError(t, err)
+	assert.Len(t, sink.points[Bucket30Days], 1)
+	assert.Len(t, sink.points[Bucket24Hours], 1)
+	assert.Equal(t, 1, ledger.docs[vaaID].Measurements[VaaCountMeasurement].Attempts)
+	assert.Equal(t, 2, ledger.docs[vaaID].Measurements[VaaAllMessagesMeasurement].Attempts)
+	assert.True(t, ledger.docs[vaaID].IsDone(VaaAllMessagesMeasurement))
+	assert.Empty(t, ledger.docs[vaaID].Measurements[VaaAllMessagesMeasurement].Error)
+
+	// once every measurement is done, processing the VAA again does not write anything
+	err = m.Push(context.Background(), params)
+	require.NoError(t, err)
+	assert.Len(t, sink.points[Bucket30Days], 1)
+	assert.Len(t, sink.points[Bucket24Hours], 1)
+}
+
+func TestPush_UnsignedVaaDoesNotWriteCounts(t *testing.T) {
+	ledger := newMemoryLedger()
+	sink := newStubSink()
+	m := newTestMetric(ledger, sink)
+	params := newTestParams()
+	params.VaaIsSigned = false
+
+	err := m.Push(context.Background(), params)
+	require.NoError(t, err)
+	assert.Empty(t, sink.points)
+	assert.NotContains(t, ledger.docs, params.Vaa.MessageID())
+}
+
+func TestPush_LedgerErrorDoesNotWrite(t *testing.T) {
+	ledger := newMemoryLedger()
+	ledger.getErr = errors.New("mongo unavailable")
+	sink := newStubSink()
+	m := newTestMetric(ledger, sink)
+
+	err := m.Push(context.Background(), newTestParams())
+	require.ErrorIs(t, err, ledger.getErr)
+	assert.Empty(t, sink.points)
+}
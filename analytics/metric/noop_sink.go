@@ -0,0 +1,30 @@
+package metric
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+)
+
+// NoopSink discards the points. It is used to run the analytics without a time series backend.
+type NoopSink struct{}
+
+// NewNoopSink creates a new NoopSink.
+func NewNoopSink() *NoopSink {
+	return &NoopSink{}
+}
+
+// Name returns the name of the sink.
+func (s *NoopSink) Name() string {
+	return SinkNoop
+}
+
+// Write discards the points.
+func (s *NoopSink) Write(_ context.Context, _ Bucket, _ ...*write.Point) error {
+	return nil
+}
+
+// Close does nothing.
+func (s *NoopSink) Close(_ context.Context) error {
+	return nil
+}
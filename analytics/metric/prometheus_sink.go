@@ -0,0 +1,281 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+var invalidPrometheusChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// PrometheusConfig is the configuration of the Prometheus remote-write sink.
+type PrometheusConfig struct {
+	// URL is the remote-write endpoint.
+	URL         string
+	Username    string
+	Password    string
+	BearerToken string
+	// Namespace is the prefix of the series names.
+	Namespace string
+	// DropLabels are the tags that are not converted to labels, to limit the cardinality.
+	DropLabels    []string
+	FlushInterval time.Duration
+	Timeout       time.Duration
+}
+
+// PrometheusSink pushes the points to a Prometheus remote-write endpoint.
+//
+// Every numeric field of a point is added to a counter named
+// `<namespace>_<measurement>_<field>_total`, labeled by the tags of the point. The counters
+// are pushed periodically with the time of their latest point, so the points are aggregated
+// over the flush interval and the bucket retention is left to the Prometheus configuration.
+type PrometheusSink struct {
+	cfg        PrometheusConfig
+	client     *http.Client
+	dropLabels map[string]bool
+	logger     *zap.Logger
+
+	mu     sync.Mutex
+	series map[string]*promSeries
+	dirty  bool
+}
+
+type promLabel struct {
+	name  string
+	value string
+}
+
+type promSeries struct {
+	labels []promLabel
+	value  float64
+	// timestamp is the time in milliseconds of the latest point added to the counter.
+	// It never decreases, as prometheus rejects the out of order samples.
+	timestamp int64
+}
+
+// NewPrometheusSink creates a new PrometheusSink and starts pushing the counters
+// until the context is cancelled.
+func NewPrometheusSink(ctx context.Context, cfg PrometheusConfig, logger *zap.Logger) (*PrometheusSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("prometheus remote-write url can not be empty")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = "wormscan"
+	}
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = 15 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	dropLabels := make(map[string]bool, len(cfg.DropLabels))
+	for _, l := range cfg.DropLabels {
+		dropLabels[strings.TrimSpace(l)] = true
+	}
+
+	s := &PrometheusSink{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		dropLabels: dropLabels,
+		logger:     logger,
+		series:     make(map[string]*promSeries),
+	}
+	go s.run(ctx)
+	return s, nil
+}
+
+// Name returns the name of the sink.
+func (s *PrometheusSink) Name() string {
+	return SinkPrometheus
+}
+
+// Write adds the numeric fields of the points to their counters.
+func (s *PrometheusSink) Write(_ context.Context, _ Bucket, points ...*write.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range points {
+		timestamp := p.Time()
+		if timestamp.IsZero() {
+			timestamp = time.Now()
+		}
+		for _, f := range p.FieldList() {
+			value, ok := toFloat(f.Value)
+			if !ok {
+				continue
+			}
+			labels := s.labels(p, f.Key)
+			key := seriesKey(labels)
+			series, ok := s.series[key]
+			if !ok {
+				series = &promSeries{labels: labels}
+				s.series[key] = series
+			}
+			series.value += value
+			series.timestamp = max(series.timestamp, timestamp.UnixMilli())
+			s.dirty = true
+		}
+	}
+	return nil
+}
+
+// Close pushes the counters.
+func (s *PrometheusSink) Close(ctx context.Context) error {
+	return s.flush(ctx)
+}
+
+func (s *PrometheusSink) run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.flush(ctx); err != nil {
+				s.logger.Error("Failed to push metrics to prometheus", zap.Error(err))
+			}
+		}
+	}
+}
+
+// flush pushes the current value of every counter.
+func (s *PrometheusSink) flush(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	body := encodeWriteRequest(s.series)
+	s.dirty = false
+	s.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(snappy.Encode(nil, body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if s.cfg.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.cfg.BearerToken)
+	} else if s.cfg.Username != "" {
+		req.SetBasicAuth(s.cfg.Username, s.cfg.Password)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		s.markDirty()
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		s.markDirty()
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("remote write failed: status code %d: %s", res.StatusCode, string(msg))
+	}
+	return nil
+}
+
+func (s *PrometheusSink) markDirty() {
+	s.mu.Lock()
+	s.dirty = true
+	s.mu.Unlock()
+}
+
+// labels returns the sorted labels of the counter of a point field.
+func (s *PrometheusSink) labels(p *write.Point, field string) []promLabel {
+	name := fmt.Sprintf("%s_%s_%s_total", s.cfg.Namespace, p.Name(), field)
+	labels := []promLabel{{name: "__name__", value: sanitizePrometheusName(name)}}
+	for _, t := range p.TagList() {
+		if s.dropLabels[t.Key] {
+			continue
+		}
+		labels = append(labels, promLabel{name: sanitizePrometheusName(t.Key), value: t.Value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+	return labels
+}
+
+func seriesKey(labels []promLabel) string {
+	var sb strings.Builder
+	for _, l := range labels {
+		sb.WriteString(l.name)
+		sb.WriteByte('=')
+		sb.WriteString(l.value)
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+func sanitizePrometheusName(name string) string {
+	return invalidPrometheusChars.ReplaceAllString(name, "_")
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// encodeWriteRequest encodes the series as a prometheus remote-write WriteRequest.
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries { repeated Label labels = 1; repeated Sample samples = 2; }
+//	message Label { string name = 1; string value = 2; }
+//	message Sample { double value = 1; int64 timestamp = 2; }
+func encodeWriteRequest(series map[string]*promSeries) []byte {
+	// sort the series to get a deterministic request.
+	keys := make([]string, 0, len(series))
+	for k := range series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var req []byte
+	for _, k := range keys {
+		s := series[k]
+		var ts []byte
+		for _, l := range s.labels {
+			var label []byte
+			label = protowire.AppendTag(label, 1, protowire.BytesType)
+			label = protowire.AppendString(label, l.name)
+			label = protowire.AppendTag(label, 2, protowire.BytesType)
+			label = protowire.AppendString(label, l.value)
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, label)
+		}
+		var sample []byte
+		sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+		sample = protowire.AppendFixed64(sample, math.Float64bits(s.value))
+		sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+		sample = protowire.AppendVarint(sample, uint64(s.timestamp))
+		ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, sample)
+
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, ts)
+	}
+	return req
+}
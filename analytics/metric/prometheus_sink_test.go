@@ -0,0 +1,122 @@
+package metric
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type decodedSeries struct {
+	labels    map[string]string
+	value     float64
+	timestamp int64
+}
+
+// consumeMessage calls fn with the number, type and value of every field of a protobuf message.
+func consumeMessage(t *testing.T, b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		m := protowire.ConsumeFieldValue(num, typ, b)
+		require.GreaterOrEqual(t, m, 0)
+		fn(num, typ, b[:m])
+		b = b[m:]
+	}
+}
+
+// decodeWriteRequest decodes a prometheus remote-write WriteRequest.
+func decodeWriteRequest(t *testing.T, b []byte) []decodedSeries {
+	var series []decodedSeries
+	consumeMessage(t, b, func(_ protowire.Number, _ protowire.Type, v []byte) {
+		ts, _ := protowire.ConsumeBytes(v)
+		s := decodedSeries{labels: make(map[string]string)}
+		consumeMessage(t, ts, func(num protowire.Number, _ protowire.Type, v []byte) {
+			msg, _ := protowire.ConsumeBytes(v)
+			switch num {
+			case 1:
+				var name, value string
+				consumeMessage(t, msg, func(num protowire.Number, _ protowire.Type, v []byte) {
+					str, _ := protowire.ConsumeString(v)
+					if num == 1 {
+						name = str
+					} else {
+						value = str
+					}
+				})
+				s.labels[name] = value
+			case 2:
+				consumeMessage(t, msg, func(num protowire.Number, _ protowire.Type, v []byte) {
+					if num == 1 {
+						bits, _ := protowire.ConsumeFixed64(v)
+						s.value = math.Float64frombits(bits)
+					} else {
+						ts, _ := protowire.ConsumeVarint(v)
+						s.timestamp = int64(ts)
+					}
+				})
+			}
+		})
+		series = append(series, s)
+	})
+	return series
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	series := map[string]*promSeries{
+		"b": {labels: []promLabel{{name: "__name__", value: "wormscan_b_total"}}, value: 2, timestamp: 2000},
+		"a": {labels: []promLabel{{name: "__name__", value: "wormscan_a_total"}, {name: "chain", value: "2"}}, value: 1.5, timestamp: 1000},
+	}
+
+	decoded := decodeWriteRequest(t, encodeWriteRequest(series))
+
+	// the series are sorted by key.
+	assert.Equal(t, []decodedSeries{
+		{labels: map[string]string{"__name__": "wormscan_a_total", "chain": "2"}, value: 1.5, timestamp: 1000},
+		{labels: map[string]string{"__name__": "wormscan_b_total"}, value: 2, timestamp: 2000},
+	}, decoded)
+}
+
+func TestPrometheusSink_Write(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		compressed, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body, err = snappy.Decode(nil, compressed)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sink, err := NewPrometheusSink(ctx, PrometheusConfig{URL: server.URL, DropLabels: []string{"version"}}, zap.NewNop())
+	require.NoError(t, err)
+
+	first := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	tags := map[string]string{"chain_id": "2", "version": "1"}
+	points := []*write.Point{
+		write.NewPoint("vaa_count", tags, map[string]interface{}{"count": int64(1)}, first.Add(time.Minute)),
+		// a point older than the latest one does not move the time of the counter back.
+		write.NewPoint("vaa_count", tags, map[string]interface{}{"count": int64(2), "symbol": "USDC"}, first),
+	}
+	require.NoError(t, sink.Write(context.Background(), BucketInfinite, points...))
+	require.NoError(t, sink.Close(context.Background()))
+
+	assert.Equal(t, []decodedSeries{{
+		labels:    map[string]string{"__name__": "wormscan_vaa_count_count_total", "chain_id": "2"},
+		value:     3,
+		timestamp: first.Add(time.Minute).UnixMilli(),
+	}}, decodeWriteRequest(t, body))
+}
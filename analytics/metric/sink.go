@@ -0,0 +1,108 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// Bucket is the retention of the points written to a sink.
+type Bucket string
+
+const (
+	BucketInfinite Bucket = "infinite"
+	Bucket30Days   Bucket = "30days"
+	Bucket24Hours  Bucket = "24hours"
+)
+
+// Sink names.
+const (
+	SinkInflux     = "influx"
+	SinkClickHouse = "clickhouse"
+	SinkPrometheus = "prometheus"
+	SinkNoop       = "noop"
+)
+
+// Sink writes the measurement points to a time series backend.
+type Sink interface {
+	// Name returns the name of the sink.
+	Name() string
+	// Write writes the points with the retention of the bucket.
+	Write(ctx context.Context, bucket Bucket, points ...*write.Point) error
+	// Close flushes the pending points and releases the resources of the sink.
+	Close(ctx context.Context) error
+}
+
+// MultiSink writes the points to a primary sink and to a list of secondary sinks.
+//
+// It is used to migrate between backends: the secondary sinks are filled with the same points
+// while the primary one keeps serving the reads. Errors from the secondary sinks are logged
+// and only returned when the multi sink is strict.
+type MultiSink struct {
+	primary     Sink
+	secondaries []Sink
+	strict      bool
+	metrics     metrics.Metrics
+	logger      *zap.Logger
+}
+
+// NewMultiSink creates a new MultiSink.
+func NewMultiSink(primary Sink, secondaries []Sink, strict bool, metrics metrics.Metrics, logger *zap.Logger) *MultiSink {
+	return &MultiSink{
+		primary:     primary,
+		secondaries: secondaries,
+		strict:      strict,
+		metrics:     metrics,
+		logger:      logger,
+	}
+}
+
+// Name returns the name of the sink.
+func (m *MultiSink) Name() string {
+	return "multi"
+}
+
+// Write writes the points to the primary and the secondary sinks.
+func (m *MultiSink) Write(ctx context.Context, bucket Bucket, points ...*write.Point) error {
+	var errs []error
+	if err := m.write(ctx, m.primary, bucket, points); err != nil {
+		errs = append(errs, err)
+	}
+	for _, s := range m.secondaries {
+		err := m.write(ctx, s, bucket, points)
+		if err == nil {
+			continue
+		}
+		if m.strict {
+			errs = append(errs, err)
+			continue
+		}
+		m.logger.Warn("Failed to write points to secondary sink",
+			zap.String("sink", s.Name()),
+			zap.String("bucket", string(bucket)),
+			zap.Error(err))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MultiSink) write(ctx context.Context, s Sink, bucket Bucket, points []*write.Point) error {
+	if err := s.Write(ctx, bucket, points...); err != nil {
+		m.metrics.IncFailedSinkWrite(s.Name())
+		return fmt.Errorf("sink %s: %w", s.Name(), err)
+	}
+	m.metrics.IncSuccessfulSinkWrite(s.Name())
+	return nil
+}
+
+// Close closes all the sinks.
+func (m *MultiSink) Close(ctx context.Context) error {
+	errs := []error{m.primary.Close(ctx)}
+	for _, s := range m.secondaries {
+		errs = append(errs, s.Close(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-client-go/v2/api/write"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// recordingSink records the points written to it, or fails with err.
+type recordingSink struct {
+	name   string
+	err    error
+	points []*write.Point
+	closed bool
+}
+
+func (s *recordingSink) Name() string {
+	return s.name
+}
+
+func (s *recordingSink) Write(_ context.Context, _ Bucket, points ...*write.Point) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.points = append(s.points, points...)
+	return nil
+}
+
+func (s *recordingSink) Close(_ context.Context) error {
+	s.closed = true
+	return nil
+}
+
+func newTestPoint() *write.Point {
+	return write.NewPoint("vaa_count", map[string]string{"chain_id": "2"}, map[string]interface{}{"count": int64(1)}, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func TestMultiSink_Write(t *testing.T) {
+	primary := &recordingSink{name: "primary"}
+	failing := &recordingSink{name: "failing", err: errors.New("unavailable")}
+	secondary := &recordingSink{name: "secondary"}
+
+	tests := []struct {
+		name    string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "non strict", strict: false, wantErr: false},
+		{name: "strict", strict: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary.points, secondary.points = nil, nil
+			sink := NewMultiSink(primary, []Sink{failing, secondary}, tt.strict, metrics.NewNoopMetrics(), zap.NewNop())
+
+			err := sink.Write(context.Background(), BucketInfinite, newTestPoint())
+
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "sink failing: unavailable")
+			} else {
+				assert.NoError(t, err)
+			}
+			// the failure of a secondary sink does not prevent the writes to the other sinks.
+			assert.Len(t, primary.points, 1)
+			assert.Len(t, secondary.points, 1)
+		})
+	}
+}
+
+func TestMultiSink_PrimaryError(t *testing.T) {
+	primary := &recordingSink{name: "primary", err: errors.New("unavailable")}
+	secondary := &recordingSink{name: "secondary"}
+	sink := NewMultiSink(primary, []Sink{secondary}, false, metrics.NewNoopMetrics(), zap.NewNop())
+
+	err := sink.Write(context.Background(), BucketInfinite, newTestPoint())
+
+	assert.ErrorContains(t, err, "sink primary: unavailable")
+	assert.Len(t, secondary.points, 1)
+
+	assert.NoError(t, sink.Close(context.Background()))
+	assert.True(t, primary.closed)
+	assert.True(t, secondary.closed)
+}
@@ -3,12 +3,9 @@ package queue
 import (
 	"context"
 	"time"
-)
 
-type sqsEvent struct {
-	MessageID string `json:"MessageId"`
-	Message   string `json:"Message"`
-}
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+)
 
 // Event represents a event data to be handle.
 type Event struct {
@@ -24,14 +21,10 @@ type Event struct {
 }
 
 // ConsumerMessage defition.
-type ConsumerMessage interface {
-	Retry() uint8
-	Data() *Event
-	Done()
-	Failed()
-	IsExpired() bool
-	SentTimestamp() *time.Time
-}
+type ConsumerMessage = common_queue.Message[Event]
+
+// ConverterFunc converts a message from a queue message.
+type ConverterFunc = common_queue.ConverterFunc[Event]
 
 // ConsumeFunc is a function to consume VAAEvent.
 type ConsumeFunc func(context.Context) <-chan ConsumerMessage
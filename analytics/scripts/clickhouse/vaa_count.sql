@@ -0,0 +1,13 @@
+-- vaa_count: one row per signed VAA, kept for 30 days (influx bucket 30 days).
+CREATE DATABASE IF NOT EXISTS wormscan;
+
+CREATE TABLE IF NOT EXISTS wormscan.vaa_count
+(
+    time     DateTime64(9, 'UTC'),
+    chain_id LowCardinality(String),
+    count    UInt64
+)
+ENGINE = ReplacingMergeTree
+PARTITION BY toYYYYMMDD(time)
+ORDER BY (chain_id, time)
+TTL toDateTime(time) + INTERVAL 30 DAY;
@@ -0,0 +1,13 @@
+-- vaa_count_all_messages: one row per VAA, including pythnet, kept for 24 hours (influx bucket 24 hours).
+CREATE DATABASE IF NOT EXISTS wormscan;
+
+CREATE TABLE IF NOT EXISTS wormscan.vaa_count_all_messages
+(
+    time     DateTime64(9, 'UTC'),
+    chain_id LowCardinality(String),
+    count    UInt64
+)
+ENGINE = ReplacingMergeTree
+PARTITION BY toStartOfHour(time)
+ORDER BY (chain_id, time)
+TTL toDateTime(time) + INTERVAL 1 DAY;
@@ -0,0 +1,21 @@
+-- vaa_volume_v2: one row per token transfer (influx bucket infinite).
+-- amount, notional and volume are integers with 8 decimals of precision.
+CREATE DATABASE IF NOT EXISTS wormscan;
+
+CREATE TABLE IF NOT EXISTS wormscan.vaa_volume_v2
+(
+    time              DateTime64(9, 'UTC'),
+    app_id            LowCardinality(String),
+    emitter_chain     LowCardinality(String),
+    destination_chain LowCardinality(String),
+    token_address     String,
+    token_chain       LowCardinality(String),
+    version           LowCardinality(String),
+    symbol            LowCardinality(String) DEFAULT '',
+    amount            UInt64 DEFAULT 0,
+    notional          UInt64 DEFAULT 0,
+    volume            UInt64 DEFAULT 0
+)
+ENGINE = ReplacingMergeTree
+PARTITION BY toYYYYMM(time)
+ORDER BY (app_id, emitter_chain, destination_chain, token_chain, token_address, version, time);
@@ -0,0 +1,26 @@
+-- vaa_volume_v3: one row per token transfer with all the app ids of the VAA (influx bucket infinite).
+-- amount, notional and volume are integers with 8 decimals of precision.
+CREATE DATABASE IF NOT EXISTS wormscan;
+
+CREATE TABLE IF NOT EXISTS wormscan.vaa_volume_v3
+(
+    time              DateTime64(9, 'UTC'),
+    emitter_chain     LowCardinality(String),
+    destination_chain LowCardinality(String),
+    token_address     String,
+    token_chain       LowCardinality(String),
+    version           LowCardinality(String),
+    app_id_1          LowCardinality(String),
+    app_id_2          LowCardinality(String),
+    app_id_3          LowCardinality(String),
+    size              LowCardinality(String),
+    symbol            LowCardinality(String) DEFAULT '',
+    amount            UInt64 DEFAULT 0,
+    notional          UInt64 DEFAULT 0,
+    volume            UInt64 DEFAULT 0,
+    from_address      String DEFAULT '',
+    to_address        String DEFAULT ''
+)
+ENGINE = ReplacingMergeTree
+PARTITION BY toYYYYMM(time)
+ORDER BY (app_id_1, app_id_2, app_id_3, emitter_chain, destination_chain, token_chain, token_address, version, time);
@@ -0,0 +1,544 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/supply"
+
+	frs "github.com/XLabs/fiber-redis-storage"
+	"github.com/ansrivas/fiberprometheus/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/adaptor/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/emitters"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
+	guardianHandlers "github.com/wormhole-foundation/wormhole-explorer/api/handlers/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/infrastructure"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/search"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/openapi"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/tvl"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan"
+	rpcApi "github.com/wormhole-foundation/wormhole-explorer/api/rpc"
+	wormscanCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	wormscanNotionalCache "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/coingecko"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	commonProtocols "github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	stats2 "github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// Service is a running api, started by the api binary or by the services that run the api in-process.
+type Service struct {
+	app           *fiber.App
+	db            *dbutil.Session
+	cache         wormscanCache.Cache
+	notionalCache wormscanNotionalCache.NotionalLocalCacheReadable
+	logger        *zap.Logger
+}
+
+// LoadConfig loads the configuration of the api from the WORMSCAN_ environment variables.
+func LoadConfig() (*config.AppConfig, error) {
+	return config.Get()
+}
+
+// GetSwagger returns the handler of the swagger specification embedded in the api binary.
+// GetSwagger godoc
+// @Description Returns the swagger specification for this API.
+// @Tags wormholescan
+// @ID swagger
+// @Success 200 {object} object
+// @Failure 400
+// @Failure 500
+// @Router /swagger.json [get]
+func GetSwagger(swagger []byte) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+
+		written, err := ctx.
+			Response().
+			BodyWriter().
+			Write(swagger)
+
+		if written != len(swagger) {
+			return fmt.Errorf("partial write to response body: wrote %d bytes, expected %d", written, len(swagger))
+		}
+
+		return err
+	}
+}
+
+// GetOpenAPI returns the handler of the OpenAPI 3.1 document generated from the routes of the API.
+// GetOpenAPI godoc
+// @Description Returns the OpenAPI 3.1 document generated from the routes of the API.
+// @Tags wormholescan
+// @ID openapi
+// @Success 200 {object} object
+// @Router /openapi.json [get]
+func GetOpenAPI(doc *openapi.Document) fiber.Handler {
+	body, err := json.Marshal(doc)
+	return func(ctx *fiber.Ctx) error {
+		if err != nil {
+			return err
+		}
+		ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return ctx.Send(body)
+	}
+}
+
+// Start starts the api with the configuration. The swagger specification is served at /swagger.json.
+// The metrics are registered in the registry and served at /metrics.
+func Start(appCtx context.Context, cfg *config.AppConfig, rootLogger *zap.Logger, swagger []byte, registry *prometheus.Registry) (*Service, error) {
+
+	// Setup DB
+	rootLogger.Info("connecting to MongoDB")
+	db, err := dbutil.Connect(appCtx, rootLogger, cfg.DB.URL, cfg.DB.Name, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	// Get cache get function
+	rootLogger.Info("initializing cache")
+	cache, err := NewCache(appCtx, cfg, rootLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	// Get notional cache
+	rootLogger.Info("initializing notional cache")
+	notionalCache, err := NewNotionalCache(appCtx, cfg, rootLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize notional cache: %w", err)
+	}
+
+	// cfg.Cache.Expiration
+	rootLogger.Info("initializing TVL cache")
+	tvl := tvl.NewTVL(cfg.P2pNetwork, cache, cfg.Cache.TvlKey, cfg.Cache.TvlExpiration, rootLogger)
+
+	// coingeckoAPI client
+	coingeckoAPI := coingecko.NewCoinGeckoAPI(cfg.Coingecko.URL,
+		cfg.Coingecko.HeaderKey, cfg.Coingecko.ApiKey)
+
+	//InfluxDB client
+	rootLogger.Info("initializing InfluxDB client")
+	influxCli := newInfluxClient(cfg.Influx.URL, cfg.Influx.Token)
+
+	//VaaPayloadParser client
+	vaaParserFunc, err := NewVaaParserFunc(cfg, rootLogger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize VAA parser: %w", err)
+	}
+
+	// create token provider
+	tokenProvider := domain.NewTokenProvider(cfg.P2pNetwork)
+
+	// Set up repositories
+	rootLogger.Info("initializing repositories")
+	addressRepo := address.NewRepository(db.Database, rootLogger)
+	vaaRepo := vaa.NewRepository(db.Database, rootLogger)
+	obsRepo := observations.NewRepository(db.Database, rootLogger)
+	governorRepo := governor.NewRepository(db.Database, rootLogger)
+	infrastructureRepo := infrastructure.NewRepository(db.Database, rootLogger)
+	heartbeatsRepo := heartbeats.NewRepository(db.Database, rootLogger)
+	transactionsRepo := transactions.NewRepository(
+		tvl,
+		cfg.P2pNetwork,
+		influxCli,
+		cfg.Influx.Organization,
+		cfg.Influx.Bucket24Hours,
+		cfg.Influx.Bucket30Days,
+		cfg.Influx.BucketInfinite,
+		db.Database,
+		rootLogger,
+		cfg.MayanBaseURL,
+	)
+	relaysRepo := relays.NewRepository(db.Database, rootLogger)
+	operationsRepo := operations.NewRepository(db.Database, rootLogger)
+	nttRepo := stats2.NewNTTRepository(
+		influxCli,
+		cfg.Influx.Organization,
+		cfg.Influx.BucketInfinite,
+		cache,
+		rootLogger)
+	statsRepo := stats.NewRepository(
+		nttRepo,
+		influxCli,
+		cfg.Influx.Organization,
+		cfg.Influx.Bucket24Hours,
+		cfg.Influx.BucketInfinite,
+		coingeckoAPI,
+		tokenProvider,
+		rootLogger)
+	statsAddressRepo := stats2.NewAddressRepository(
+		influxCli,
+		cfg.Influx.Organization,
+		cfg.Influx.BucketInfinite,
+		cache,
+		rootLogger)
+	statsHolderRepo := stats2.NewHolderRepositoryReadable(cache, rootLogger)
+	nttSymbolRepo := stats2.NewNTTSymbolRepository(cache)
+
+	protocolsRepo := protocols.NewRepository(
+		protocols.WrapQueryAPI(influxCli.QueryAPI(cfg.Influx.Organization)),
+		cfg.Influx.BucketInfinite,
+		cfg.Influx.Bucket30Days,
+		cfg.Influx.Bucket24Hours,
+		rootLogger)
+	guardianSetRepository := repository.NewGuardianSetRepository(db.Database, rootLogger)
+	emitterRegistry := repository.NewEmitterRepository(db.Database, rootLogger)
+	emittersRepo := emitters.NewRepository(db.Database, emitterRegistry, rootLogger)
+	apiKeysRepo := apikeys.NewRepository(db.Database, rootLogger)
+	searchRepo := search.NewRepository(db.Database, rootLogger)
+
+	// Set up the emitter registry used to translate emitter addresses
+	rootLogger.Info("initializing emitter registry")
+	if err := emitterRegistry.Seed(appCtx, domain.KnownEmitters()); err != nil {
+		rootLogger.Error("failed to seed emitter registry", zap.Error(err))
+	}
+	emitterCache := repository.NewEmitterCache(emitterRegistry, 5*time.Minute, rootLogger)
+	emitterCache.Start(appCtx)
+	domain.SetEmitterResolver(emitterCache)
+
+	metrics := metrics.NewPrometheusMetrics(registry, cfg.Environment)
+
+	// Set up services
+	rootLogger.Info("initializing services")
+	expirationTime := time.Duration(cfg.Cache.MetricExpiration) * time.Minute
+	addressService := address.NewService(addressRepo, cache, expirationTime, metrics, rootLogger)
+	vaaService := vaa.NewService(vaaRepo, guardianSetRepository, cache.Get, vaaParserFunc, rootLogger)
+	obsService := observations.NewService(obsRepo, rootLogger)
+	governorService := governor.NewService(governorRepo, cache, notionalCache, tokenProvider, metrics, rootLogger)
+	infrastructureService := infrastructure.NewService(infrastructureRepo, rootLogger)
+	heartbeatsService := heartbeats.NewService(heartbeatsRepo, rootLogger)
+	transactionsService := transactions.NewService(transactionsRepo, cache, expirationTime, tokenProvider, metrics, rootLogger)
+	relaysService := relays.NewService(relaysRepo, rootLogger)
+	operationsService := operations.NewService(operationsRepo, rootLogger)
+	statsService := stats.NewService(statsRepo, statsAddressRepo, statsHolderRepo, nttSymbolRepo, cache, expirationTime, metrics, rootLogger)
+	enabled, err := enabledProtocols(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load protocols: %w", err)
+	}
+	protocolsService := protocols.NewService(enabled, protocolsRepo, rootLogger, cache, cfg.Cache.ProtocolsStatsKey, cfg.Cache.ProtocolsStatsExpiration, metrics, tvl)
+	guardianService := guardianHandlers.NewService(guardianSetRepository, cfg.P2pNetwork, cache, metrics, rootLogger)
+	supplyService := supply.NewService(rootLogger)
+	emittersService := emitters.NewService(emittersRepo, emitterRegistry, emitterCache, cache, expirationTime, rootLogger)
+	searchService := search.NewService(searchRepo, emitterRegistry, tokenProvider, rootLogger)
+	apiKeysService := apikeys.NewService(apiKeysRepo, NewApiKeysUsageCounter(cfg), cfg.GetApiTokens(), cfg.GetAdminTokens(), metrics, rootLogger)
+
+	// Set up a custom error handler
+	response.SetEnableStackTrace(*cfg)
+	app := fiber.New(fiber.Config{
+		ErrorHandler:          middleware.ErrorHandler,
+		DisableStartupMessage: true,
+		Immutable:             true,
+	})
+
+	// Configure middleware
+	labels := map[string]string{"service": "wormscan-api", "environment": cfg.Environment}
+	prometheus := fiberprometheus.NewWithRegistry(registry, "", "http", "", labels)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
+	app.Use(prometheus.Middleware)
+	app.Use(middleware.OriginMetrics(metrics))
+
+	app.Use(requestid.New())
+	app.Use(logger.New(logger.Config{
+		Format: "level=info timestamp=${time} method=${method} path=${path} latency=${latency} status${status} request_id=${locals:requestid} ip=${ips} queryParams=${queryParams}\n",
+		Next: func(c *fiber.Ctx) bool {
+			return middleware.IsK8sPath(c.Path())
+		},
+	}))
+	if cfg.PprofEnabled {
+		app.Use(pprof.New())
+	}
+	app.Use(cors.New())
+
+	// Resolve the api key of the requests, it must run before the rate limiter
+	app.Use(middleware.ApiKeyAuth(apiKeysService))
+
+	// Configure rate limiter
+	if cfg.RateLimit.Enabled {
+		rl, err := NewRateLimiter(appCtx, cfg, rootLogger)
+		if err != nil {
+			return nil, err
+		}
+		app.Use(rl)
+	}
+
+	// Configure export quota
+	exportQuota, err := NewExportQuota(appCtx, cfg, rootLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure response cache
+	responseCache := middleware.NewResponseCache(cache, metrics, rootLogger)
+
+	// Configure the validation of the requests against the OpenAPI document, which is set once the routes are registered
+	openapiValidator := openapi.NewValidator()
+	if cfg.OpenAPI.ValidateRequests {
+		app.Use(openapiValidator.Handler())
+	}
+
+	notSupportedByEnv := middleware.NotSupportedByTestnetEnv(cfg.P2pNetwork)
+	// Set up route handlers
+	app.Get("/swagger.json", GetSwagger(swagger))
+	wormscan.RegisterRoutes(cfg, notSupportedByEnv, exportQuota, responseCache, app, rootLogger, addressService, vaaService, obsService, governorService, infrastructureService, transactionsService, relaysService, operationsService, statsService, protocolsService, supplyService, emittersService, apiKeysService, searchService)
+	guardian.RegisterRoutes(cfg, app, rootLogger, vaaService, governorService, heartbeatsService, guardianService)
+
+	// Generate the OpenAPI document from the registered routes
+	openapiDoc := wormscan.OpenAPI(app)
+	openapiValidator.SetDocument(openapiDoc)
+	app.Get("/openapi.json", GetOpenAPI(openapiDoc))
+
+	// Set up gRPC handlers
+	handler := rpcApi.NewHandler(vaaService, heartbeatsService, governorService, guardianService, rootLogger)
+	grpcServer := rpcApi.NewServer(handler, rootLogger)
+	grpcWebServer := grpcweb.WrapServer(grpcServer)
+	app.Use(
+		adaptor.HTTPMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if grpcWebServer.IsGrpcWebRequest(r) {
+					grpcWebServer.ServeHTTP(w, r)
+				} else {
+					next.ServeHTTP(w, r)
+				}
+			})
+		}))
+
+	rootLogger.Info("starting HTTP server in a separate goroutine")
+	go func() {
+		if err := app.Listen(":" + strconv.Itoa(cfg.PORT)); err != nil {
+			panic("failed to start HTTP server: " + err.Error())
+		}
+	}()
+
+	return &Service{app: app, db: db, cache: cache, notionalCache: notionalCache, logger: rootLogger}, nil
+}
+
+// Stop shuts down the server and closes the caches and the MongoDB connection.
+func (s *Service) Stop() {
+	s.logger.Info("shutting down server...")
+	s.app.Shutdown()
+
+	s.logger.Info("closing cache...")
+	s.cache.Close()
+	s.notionalCache.Close()
+
+	s.logger.Info("closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
+}
+
+// NewCache get a CacheGetFunc to get a value by a Key from cache and a CacheReadable to get a value by a Key from notional local cache.
+func NewCache(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (wormscanCache.Cache, error) {
+
+	// if run mode is development with cache is disabled, return a dummy cache client and a dummy notional cache client.
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.Cache.Enabled {
+		dummyCacheClient := wormscanCache.NewDummyCacheClient()
+		return dummyCacheClient, nil
+	}
+
+	// if we are not in development mode, use a distributed cache and for notional a pubsub to sync local cache.
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Cache.URL})
+
+	// get cache client
+	cacheClient, err := wormscanCache.NewCacheClient(redisClient, cfg.Cache.Enabled, cfg.Cache.Prefix, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache client: %w", err)
+	}
+
+	return cacheClient, nil
+}
+
+// NewNotionalCache get a NotionalLocalCacheReadable to get the token prices published by the notional job.
+func NewNotionalCache(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (wormscanNotionalCache.NotionalLocalCacheReadable, error) {
+
+	// if run mode is development with cache is disabled, return a dummy notional cache client.
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.Cache.Enabled {
+		return wormscanNotionalCache.NewDummyNotionalCache(), nil
+	}
+
+	// use a distributed cache and a pubsub to sync the local cache.
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Cache.URL})
+	notionalCache, err := wormscanNotionalCache.NewNotionalCache(ctx, redisClient, cfg.Cache.Prefix, cfg.Cache.NotionalChannel, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notional cache client: %w", err)
+	}
+	if err := notionalCache.Init(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load notional cache: %w", err)
+	}
+
+	return notionalCache, nil
+}
+
+// enabledProtocols registers the protocols declared in WORMSCAN_PROTOCOLSJSON and returns the protocols of
+// WORMSCAN_PROTOCOLS, or every registered protocol when it is empty.
+func enabledProtocols(cfg *config.AppConfig) ([]string, error) {
+	declarations, err := commonProtocols.Parse(cfg.ProtocolsJson)
+	if err != nil {
+		return nil, err
+	}
+	protocols.RegisterDeclarations(declarations...)
+	if len(cfg.Protocols) == 0 {
+		return protocols.RegisteredProtocols(), nil
+	}
+	return cfg.Protocols, nil
+}
+
+func newInfluxClient(url, token string) influxdb2.Client {
+	return influxdb2.NewClient(url, token)
+}
+
+func NewRateLimiter(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (func(*fiber.Ctx) error, error) {
+
+	if cfg.RateLimit.Prefix != "" {
+		cfg.RateLimit.Prefix += ":rate-limiter:"
+	} else {
+		cfg.RateLimit.Prefix = "rate-limiter:"
+	}
+
+	// initialize rate limiter
+	store, err := frs.New(
+		frs.Config{URL: cfg.Cache.URL, Prefix: cfg.RateLimit.Prefix})
+	if err != nil {
+		logger.Error("failed to initialize rate limiter",
+			zap.String("url", cfg.Cache.URL),
+			zap.String("prefix", cfg.RateLimit.Prefix),
+			zap.Error(err))
+		return nil, err
+	}
+
+	// default to 60 requests per minute
+	if cfg.RateLimit.Max == 0 {
+		cfg.RateLimit.Max = 60
+	}
+
+	logger.Info("rate limit enabled", zap.Int("max requests per minute", cfg.RateLimit.Max))
+
+	router := limiter.New(limiter.Config{
+		Next: func(c *fiber.Ctx) bool {
+			// requests with an api key are limited by the limits of the key
+			if middleware.GetApiKeyIdentity(c) != nil {
+				return true
+			}
+			ip := utils.GetRealIp(c)
+			return utils.IsPrivateIPAsString(ip)
+		},
+		Max:        cfg.RateLimit.Max,
+		Expiration: 60 * time.Second,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return utils.GetRealIp(c)
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusTooManyRequests)
+		},
+		Storage: store,
+	})
+
+	return router, nil
+
+}
+
+// NewApiKeysUsageCounter returns the counter of the requests made with managed api keys.
+// In development mode with the cache disabled, requests are not counted.
+func NewApiKeysUsageCounter(cfg *config.AppConfig) apikeys.UsageCounter {
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.Cache.Enabled {
+		return apikeys.NewNoopUsageCounter()
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.Cache.URL})
+	return apikeys.NewRedisUsageCounter(redisClient, cfg.Cache.Prefix)
+}
+
+// NewExportQuota returns the handler that limits each api key to cfg.Export.DailyQuota exports per day.
+// A quota of 0 disables the limit.
+func NewExportQuota(ctx context.Context, cfg *config.AppConfig, logger *zap.Logger) (fiber.Handler, error) {
+
+	if cfg.Export.DailyQuota == 0 {
+		logger.Info("export quota disabled")
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}, nil
+	}
+
+	prefix := "export-quota:"
+	if cfg.Cache.Prefix != "" {
+		prefix = cfg.Cache.Prefix + ":" + prefix
+	}
+
+	store, err := frs.New(
+		frs.Config{URL: cfg.Cache.URL, Prefix: prefix})
+	if err != nil {
+		logger.Error("failed to initialize export quota",
+			zap.String("url", cfg.Cache.URL),
+			zap.String("prefix", prefix),
+			zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("export quota enabled", zap.Int("max exports per day", cfg.Export.DailyQuota))
+
+	quota := limiter.New(limiter.Config{
+		Max:        cfg.Export.DailyQuota,
+		Expiration: 24 * time.Hour,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			if identity := middleware.GetApiKeyIdentity(c); identity != nil && !identity.Static {
+				return identity.ID
+			}
+			return c.Get("X-API-KEY")
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusTooManyRequests)
+		},
+		Storage: store,
+	})
+
+	return quota, nil
+}
+
+// NewVaaParserFunc returns a function to parse VAA payload.
+func NewVaaParserFunc(cfg *config.AppConfig, logger *zap.Logger) (vaaPayloadParser.ParseVaaFunc, error) {
+	if cfg.RunMode == config.RunModeDevelopmernt && !cfg.VaaPayloadParser.Enabled {
+		return func(vaa *sdk.VAA) (any, error) {
+			return nil, nil
+		}, nil
+	}
+	vaaPayloadParserClient, err := vaaPayloadParser.NewParserVAAAPIClient(cfg.VaaPayloadParser.Timeout,
+		cfg.VaaPayloadParser.URL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize VAA parser client: %w", err)
+	}
+	return vaaPayloadParserClient.ParseVaa, nil
+}
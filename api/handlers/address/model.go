@@ -1,7 +1,85 @@
 package address
 
-import "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
 
 type AddressOverview struct {
 	Vaas []*vaa.VaaDoc `json:"vaas"`
 }
+
+// Portfolio is the cross-chain activity of an address.
+type Portfolio struct {
+	Address        string             `json:"address"`
+	Chains         []sdk.ChainID      `json:"chains"`
+	FirstActivity  *time.Time         `json:"firstActivity,omitempty"`
+	LastActivity   *time.Time         `json:"lastActivity,omitempty"`
+	Operations     int                `json:"operations"`
+	Tokens         []*TokenTotals     `json:"tokens"`
+	Counterparties []*Counterparty    `json:"counterparties"`
+	PendingInbound []*PendingTransfer `json:"pendingInbound"`
+	// Truncated is set when the address has more operations than the ones aggregated in the portfolio.
+	Truncated bool `json:"truncated"`
+}
+
+// TokenTotals are the amounts of a token sent and received by an address.
+// Amounts are in token units and USD values are the notional at transfer time.
+type TokenTotals struct {
+	TokenChain   sdk.ChainID     `json:"tokenChain"`
+	TokenAddress string          `json:"tokenAddress"`
+	Symbol       string          `json:"symbol,omitempty"`
+	Sent         decimal.Decimal `json:"sent"`
+	SentUsd      decimal.Decimal `json:"sentUsd"`
+	Received     decimal.Decimal `json:"received"`
+	ReceivedUsd  decimal.Decimal `json:"receivedUsd"`
+	Transfers    int             `json:"transfers"`
+}
+
+// Counterparty is an address that sent tokens to or received tokens from the address.
+type Counterparty struct {
+	Chain     sdk.ChainID     `json:"chain"`
+	Address   string          `json:"address"`
+	Sent      int             `json:"sent"`
+	Received  int             `json:"received"`
+	UsdVolume decimal.Decimal `json:"usdVolume"`
+}
+
+// PendingTransfer is an inbound transfer to the address that was not redeemed yet.
+type PendingTransfer struct {
+	ID           string                 `json:"id"`
+	FromChain    sdk.ChainID            `json:"fromChain"`
+	FromAddress  string                 `json:"fromAddress"`
+	ToChain      sdk.ChainID            `json:"toChain"`
+	TokenChain   sdk.ChainID            `json:"tokenChain"`
+	TokenAddress string                 `json:"tokenAddress"`
+	Symbol       string                 `json:"symbol,omitempty"`
+	Amount       decimal.Decimal        `json:"amount"`
+	UsdAmount    decimal.Decimal        `json:"usdAmount"`
+	Status       domain.OperationStatus `json:"status"`
+	Timestamp    time.Time              `json:"timestamp"`
+}
+
+// portfolioOperationDoc is an operation of an address with the fields used to build the portfolio.
+type portfolioOperationDoc struct {
+	ID                     string    `bson:"_id"`
+	Timestamp              time.Time `bson:"timestamp"`
+	StandardizedProperties struct {
+		FromChain    sdk.ChainID `bson:"fromChain"`
+		FromAddress  string      `bson:"fromAddress"`
+		ToChain      sdk.ChainID `bson:"toChain"`
+		ToAddress    string      `bson:"toAddress"`
+		TokenChain   sdk.ChainID `bson:"tokenChain"`
+		TokenAddress string      `bson:"tokenAddress"`
+		Amount       string      `bson:"amount"`
+	} `bson:"standardizedProperties"`
+	OriginFrom          string `bson:"originFrom"`
+	DestinationTxStatus string `bson:"destinationTxStatus"`
+	Symbol              string `bson:"symbol"`
+	TokenAmount         string `bson:"tokenAmount"`
+	UsdAmount           string `bson:"usdAmount"`
+}
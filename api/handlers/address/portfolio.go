@@ -0,0 +1,221 @@
+package address
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+const (
+	// maxPortfolioOperations is the max number of operations aggregated in a portfolio.
+	maxPortfolioOperations = 10_000
+	// maxPortfolioCounterparties is the max number of counterparties returned in a portfolio.
+	maxPortfolioCounterparties = 50
+	// normalizedAmountDecimals are the decimals of the amounts of the standardized properties.
+	normalizedAmountDecimals = 8
+)
+
+// AddressEncodings returns the encodings an address can be stored with. When chainID is set,
+// the address is decoded from the native format of the chain.
+func AddressEncodings(address string, chainID *sdk.ChainID) ([]string, error) {
+	encodings := []string{address}
+	add := func(s string) {
+		for _, e := range encodings {
+			if e == s {
+				return
+			}
+		}
+		encodings = append(encodings, s)
+	}
+
+	hexAddress := strings.ToLower(address)
+	if chainID != nil {
+		decoded, err := domain.DecodeNativeAddressToHex(*chainID, address)
+		if err != nil {
+			return nil, err
+		}
+		hexAddress = strings.ToLower(decoded)
+	}
+	hexAddress = strings.TrimPrefix(hexAddress, "0x")
+
+	add(hexAddress)
+	add("0x" + hexAddress)
+	if len(hexAddress) < 64 && isHex(hexAddress) {
+		padded := strings.Repeat("0", 64-len(hexAddress)) + hexAddress
+		add(padded)
+		add("0x" + padded)
+	}
+	return encodings, nil
+}
+
+// buildPortfolio aggregates the operations of an address, known by its encodings, in a portfolio.
+func buildPortfolio(address string, encodings []string, docs []*portfolioOperationDoc) *Portfolio {
+	isAddress := func(s string) bool {
+		for _, e := range encodings {
+			if strings.EqualFold(e, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	portfolio := &Portfolio{
+		Address:        address,
+		Chains:         []sdk.ChainID{},
+		Tokens:         []*TokenTotals{},
+		Counterparties: []*Counterparty{},
+		PendingInbound: []*PendingTransfer{},
+	}
+	if len(docs) > maxPortfolioOperations {
+		docs = docs[:maxPortfolioOperations]
+		portfolio.Truncated = true
+	}
+
+	chains := make(map[sdk.ChainID]bool)
+	tokens := make(map[string]*TokenTotals)
+	counterparties := make(map[string]*Counterparty)
+
+	for _, doc := range docs {
+		p := doc.StandardizedProperties
+		sent := isAddress(p.FromAddress) || isAddress(doc.OriginFrom)
+		received := isAddress(p.ToAddress)
+		if !sent && !received {
+			continue
+		}
+
+		portfolio.Operations++
+		timestamp := doc.Timestamp
+		if portfolio.FirstActivity == nil || timestamp.Before(*portfolio.FirstActivity) {
+			portfolio.FirstActivity = &timestamp
+		}
+		if portfolio.LastActivity == nil || timestamp.After(*portfolio.LastActivity) {
+			portfolio.LastActivity = &timestamp
+		}
+
+		amount := doc.amount()
+		usdAmount, _ := decimal.NewFromString(doc.UsdAmount)
+
+		// token totals
+		tokenKey := p.TokenChain.String() + "/" + p.TokenAddress
+		token, ok := tokens[tokenKey]
+		if !ok {
+			token = &TokenTotals{TokenChain: p.TokenChain, TokenAddress: p.TokenAddress}
+			tokens[tokenKey] = token
+		}
+		if token.Symbol == "" {
+			token.Symbol = doc.Symbol
+		}
+		token.Transfers++
+
+		if sent {
+			chains[p.FromChain] = true
+			token.Sent = token.Sent.Add(amount)
+			token.SentUsd = token.SentUsd.Add(usdAmount)
+			if p.ToAddress != "" {
+				counterparty := getCounterparty(counterparties, p.ToChain, p.ToAddress)
+				counterparty.Sent++
+				counterparty.UsdVolume = counterparty.UsdVolume.Add(usdAmount)
+			}
+		}
+
+		if received {
+			chains[p.ToChain] = true
+			token.Received = token.Received.Add(amount)
+			token.ReceivedUsd = token.ReceivedUsd.Add(usdAmount)
+			from := p.FromAddress
+			if from == "" {
+				from = doc.OriginFrom
+			}
+			if from != "" {
+				counterparty := getCounterparty(counterparties, p.FromChain, from)
+				counterparty.Received++
+				counterparty.UsdVolume = counterparty.UsdVolume.Add(usdAmount)
+			}
+			status := doc.status()
+			if status != domain.OperationStatusRedeemed {
+				portfolio.PendingInbound = append(portfolio.PendingInbound, &PendingTransfer{
+					ID:           doc.ID,
+					FromChain:    p.FromChain,
+					FromAddress:  from,
+					ToChain:      p.ToChain,
+					TokenChain:   p.TokenChain,
+					TokenAddress: p.TokenAddress,
+					Symbol:       doc.Symbol,
+					Amount:       amount,
+					UsdAmount:    usdAmount,
+					Status:       status,
+					Timestamp:    doc.Timestamp,
+				})
+			}
+		}
+	}
+
+	for chainID := range chains {
+		portfolio.Chains = append(portfolio.Chains, chainID)
+	}
+	sort.Slice(portfolio.Chains, func(i, j int) bool { return portfolio.Chains[i] < portfolio.Chains[j] })
+
+	for _, token := range tokens {
+		portfolio.Tokens = append(portfolio.Tokens, token)
+	}
+	sort.Slice(portfolio.Tokens, func(i, j int) bool {
+		vi := portfolio.Tokens[i].SentUsd.Add(portfolio.Tokens[i].ReceivedUsd)
+		vj := portfolio.Tokens[j].SentUsd.Add(portfolio.Tokens[j].ReceivedUsd)
+		if !vi.Equal(vj) {
+			return vi.GreaterThan(vj)
+		}
+		return portfolio.Tokens[i].Transfers > portfolio.Tokens[j].Transfers
+	})
+
+	for _, counterparty := range counterparties {
+		portfolio.Counterparties = append(portfolio.Counterparties, counterparty)
+	}
+	sort.Slice(portfolio.Counterparties, func(i, j int) bool {
+		ci, cj := portfolio.Counterparties[i], portfolio.Counterparties[j]
+		if ci.Sent+ci.Received != cj.Sent+cj.Received {
+			return ci.Sent+ci.Received > cj.Sent+cj.Received
+		}
+		return ci.UsdVolume.GreaterThan(cj.UsdVolume)
+	})
+	if len(portfolio.Counterparties) > maxPortfolioCounterparties {
+		portfolio.Counterparties = portfolio.Counterparties[:maxPortfolioCounterparties]
+	}
+
+	return portfolio
+}
+
+func isHex(s string) bool {
+	return s != "" && strings.Trim(s, "0123456789abcdef") == ""
+}
+
+func getCounterparty(counterparties map[string]*Counterparty, chainID sdk.ChainID, address string) *Counterparty {
+	key := chainID.String() + "/" + address
+	counterparty, ok := counterparties[key]
+	if !ok {
+		counterparty = &Counterparty{Chain: chainID, Address: address}
+		counterparties[key] = counterparty
+	}
+	return counterparty
+}
+
+// amount returns the amount of the operation in token units. The amount priced at transfer time is used
+// when available, otherwise the normalized amount of the standardized properties.
+func (d *portfolioOperationDoc) amount() decimal.Decimal {
+	if amount, err := decimal.NewFromString(d.TokenAmount); err == nil {
+		return amount
+	}
+	amount, err := decimal.NewFromString(d.StandardizedProperties.Amount)
+	if err != nil {
+		return decimal.Zero
+	}
+	return amount.Shift(-normalizedAmountDecimals)
+}
+
+// status returns the lifecycle status of the operation. The operations of a portfolio are read from
+// the parsedVaa collection, so their VAA is always signed.
+func (d *portfolioOperationDoc) status() domain.OperationStatus {
+	return domain.DeriveOperationStatus(true, false, d.DestinationTxStatus)
+}
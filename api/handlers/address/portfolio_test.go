@@ -0,0 +1,70 @@
+package address
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestAddressEncodings(t *testing.T) {
+	encodings, err := AddressEncodings("0xAbC", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0xAbC", "abc", "0xabc", "0000000000000000000000000000000000000000000000000000000000000abc",
+		"0x0000000000000000000000000000000000000000000000000000000000000abc"}, encodings)
+
+	chainID := sdk.ChainIDSolana
+	encodings, err = AddressEncodings("11111111111111111111111111111111", &chainID)
+	assert.NoError(t, err)
+	assert.Contains(t, encodings, "0x0000000000000000000000000000000000000000000000000000000000000000")
+
+	_, err = AddressEncodings("not-base58-0OIl", &chainID)
+	assert.Error(t, err)
+}
+
+func TestBuildPortfolio(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	sent := &portfolioOperationDoc{ID: "1", Timestamp: t1, DestinationTxStatus: domain.DstTxStatusConfirmed, Symbol: "USDC", TokenAmount: "10", UsdAmount: "10"}
+	sent.StandardizedProperties.FromChain = sdk.ChainIDEthereum
+	sent.StandardizedProperties.FromAddress = "0xabc"
+	sent.StandardizedProperties.ToChain = sdk.ChainIDSolana
+	sent.StandardizedProperties.ToAddress = "sol1"
+	sent.StandardizedProperties.TokenChain = sdk.ChainIDEthereum
+	sent.StandardizedProperties.TokenAddress = "0xusdc"
+
+	received := &portfolioOperationDoc{ID: "2", Timestamp: t2}
+	received.StandardizedProperties.FromChain = sdk.ChainIDSolana
+	received.StandardizedProperties.FromAddress = "sol1"
+	received.StandardizedProperties.ToChain = sdk.ChainIDBase
+	received.StandardizedProperties.ToAddress = "0xABC"
+	received.StandardizedProperties.TokenChain = sdk.ChainIDEthereum
+	received.StandardizedProperties.TokenAddress = "0xusdc"
+	received.StandardizedProperties.Amount = "250000000"
+
+	portfolio := buildPortfolio("0xabc", []string{"0xabc"}, []*portfolioOperationDoc{received, sent})
+
+	assert.Equal(t, 2, portfolio.Operations)
+	assert.Equal(t, []sdk.ChainID{sdk.ChainIDEthereum, sdk.ChainIDBase}, portfolio.Chains)
+	assert.Equal(t, t1, *portfolio.FirstActivity)
+	assert.Equal(t, t2, *portfolio.LastActivity)
+
+	assert.Len(t, portfolio.Tokens, 1)
+	assert.Equal(t, "USDC", portfolio.Tokens[0].Symbol)
+	assert.True(t, decimal.NewFromInt(10).Equal(portfolio.Tokens[0].Sent))
+	assert.True(t, decimal.RequireFromString("2.5").Equal(portfolio.Tokens[0].Received))
+	assert.Equal(t, 2, portfolio.Tokens[0].Transfers)
+
+	assert.Len(t, portfolio.Counterparties, 1)
+	assert.Equal(t, 1, portfolio.Counterparties[0].Sent)
+	assert.Equal(t, 1, portfolio.Counterparties[0].Received)
+
+	assert.Len(t, portfolio.PendingInbound, 1)
+	assert.Equal(t, "2", portfolio.PendingInbound[0].ID)
+	assert.Equal(t, domain.OperationStatusVaaSigned, portfolio.PendingInbound[0].Status)
+	assert.False(t, portfolio.Truncated)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -16,7 +17,8 @@ type Repository struct {
 	logger *zap.Logger
 
 	collections struct {
-		parsedVaa *mongo.Collection
+		parsedVaa          *mongo.Collection
+		globalTransactions *mongo.Collection
 	}
 }
 
@@ -24,9 +26,11 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "AddressRepository")),
 		collections: struct {
-			parsedVaa *mongo.Collection
+			parsedVaa          *mongo.Collection
+			globalTransactions *mongo.Collection
 		}{
-			parsedVaa: db.Collection("parsedVaa"),
+			parsedVaa:          db.Collection("parsedVaa"),
+			globalTransactions: db.Collection("globalTransactions"),
 		},
 	}
 }
@@ -126,3 +130,103 @@ func (r *Repository) GetAddressOverview(ctx context.Context, params *GetAddressO
 	}
 	return &AddressOverview{Vaas: vaas}, nil
 }
+
+// FindPortfolioOperations returns the most recent operations sent or received by any of the addresses,
+// which are the encodings of the same address, with the prices and the destination tx status of each operation.
+func (r *Repository) FindPortfolioOperations(ctx context.Context, addresses []string, limit int64) ([]*portfolioOperationDoc, error) {
+
+	// the sender of an operation is only known by tx-tracker for some protocols
+	senderIDs, err := r.findOperationIdsBySender(ctx, addresses, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var pipeline mongo.Pipeline
+
+	// filter operations of the address
+	pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{"standardizedProperties.fromAddress": bson.M{"$in": addresses}},
+		bson.M{"standardizedProperties.toAddress": bson.M{"$in": addresses}},
+		bson.M{"_id": bson.M{"$in": senderIDs}},
+	}}}})
+
+	// most recent operations first
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}}})
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+
+	// lookup transferPrices and globalTransactions
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "transferPrices"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "transferPrices"}}}})
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}})
+
+	// project the fields of the portfolio
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.D{
+		{Key: "timestamp", Value: 1},
+		{Key: "standardizedProperties", Value: 1},
+		{Key: "originFrom", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.originTx.from", 0}}}},
+		{Key: "destinationTxStatus", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx.status", 0}}}},
+		{Key: "symbol", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.symbol", 0}}}},
+		{Key: "tokenAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.tokenAmount", 0}}}},
+		{Key: "usdAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.usdAmount", 0}}}},
+	}}})
+
+	cur, err := r.collections.parsedVaa.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute aggregation pipeline to get portfolio operations",
+			zap.Error(err),
+			zap.Strings("addresses", addresses),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+
+	docs := []*portfolioOperationDoc{}
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor for portfolio operations",
+			zap.Error(err),
+			zap.Strings("addresses", addresses),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+	return docs, nil
+}
+
+// findOperationIdsBySender returns the ids of the most recent operations whose origin tx was sent by any of the addresses.
+func (r *Repository) findOperationIdsBySender(ctx context.Context, addresses []string, limit int64) ([]string, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "originTx.timestamp", Value: -1}}).
+		SetLimit(limit)
+
+	cur, err := r.collections.globalTransactions.Find(ctx, bson.M{"originTx.from": bson.M{"$in": addresses}}, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find operations by sender",
+			zap.Error(err),
+			zap.Strings("addresses", addresses),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode operations by sender",
+			zap.Error(err),
+			zap.Strings("addresses", addresses),
+			zap.String("requestID", requestID),
+		)
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
@@ -2,22 +2,36 @@ package address
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"go.uber.org/zap"
 )
 
+const portfolioKey = "wormscan:address-portfolio"
+
 type Service struct {
-	repo   *Repository
-	logger *zap.Logger
+	repo       *Repository
+	cache      cache.Cache
+	expiration time.Duration
+	metrics    metrics.Metrics
+	logger     *zap.Logger
 }
 
-func NewService(r *Repository, logger *zap.Logger) *Service {
+func NewService(r *Repository, cache cache.Cache, expiration time.Duration, metrics metrics.Metrics, logger *zap.Logger) *Service {
 
 	srv := Service{
-		repo:   r,
-		logger: logger.With(zap.String("module", "AddressService")),
+		repo:       r,
+		cache:      cache,
+		expiration: expiration,
+		metrics:    metrics,
+		logger:     logger.With(zap.String("module", "AddressService")),
 	}
 
 	return &srv
@@ -44,3 +58,23 @@ func (s *Service) GetAddressOverview(
 	response.Data = overview
 	return response, nil
 }
+
+// GetPortfolio returns the portfolio of an address from its operations.
+// encodings are the encodings the address can be stored with, as returned by AddressEncodings.
+// The portfolio aggregates up to maxPortfolioOperations operations, so it is cached.
+func (s *Service) GetPortfolio(ctx context.Context, address string, encodings []string) (*response.Response[*Portfolio], error) {
+	key := fmt.Sprintf("%s:%s", portfolioKey, strings.Join(encodings, ","))
+	portfolio, err := cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
+		func() (*Portfolio, error) {
+			// one extra operation is requested to know if the portfolio is truncated
+			docs, err := s.repo.FindPortfolioOperations(ctx, encodings, maxPortfolioOperations+1)
+			if err != nil {
+				return nil, err
+			}
+			return buildPortfolio(address, encodings, docs), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return &response.Response[*Portfolio]{Data: portfolio}, nil
+}
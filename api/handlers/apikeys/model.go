@@ -0,0 +1,113 @@
+package apikeys
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Scope is a permission granted to an api key.
+type Scope string
+
+// scope constants.
+const (
+	ScopeExport   Scope = "export"
+	ScopeAdmin    Scope = "admin"
+	ScopeWebhooks Scope = "webhooks"
+)
+
+// Scopes returns all the scopes.
+func Scopes() []Scope {
+	return []Scope{ScopeExport, ScopeAdmin, ScopeWebhooks}
+}
+
+// ParseScope parses a scope.
+func ParseScope(s string) (Scope, error) {
+	scope := Scope(s)
+	if !slices.Contains(Scopes(), scope) {
+		return "", fmt.Errorf("unknown scope %s", s)
+	}
+	return scope, nil
+}
+
+// ApiKeyDoc defines the api key document of the apiKeys collection.
+type ApiKeyDoc struct {
+	ID     string  `bson:"_id"`
+	Owner  string  `bson:"owner"`
+	Scopes []Scope `bson:"scopes"`
+	// Hash is the sha256 of the key, the key itself is never stored.
+	Hash string `bson:"hash"`
+	// PreviousHash is the hash of the key before the last rotation, valid until PreviousExpiresAt.
+	PreviousHash      string     `bson:"previousHash,omitempty"`
+	PreviousExpiresAt *time.Time `bson:"previousExpiresAt,omitempty"`
+	// RateLimit is the max number of requests per minute, 0 means unlimited.
+	RateLimit int64 `bson:"rateLimit"`
+	// DailyQuota is the max number of requests per day, 0 means unlimited.
+	DailyQuota int64      `bson:"dailyQuota"`
+	RevokedAt  *time.Time `bson:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `bson:"createdAt"`
+	UpdatedAt  time.Time  `bson:"updatedAt"`
+}
+
+// ApiKey definition.
+type ApiKey struct {
+	ID         string     `json:"id"`
+	Owner      string     `json:"owner"`
+	Scopes     []Scope    `json:"scopes"`
+	RateLimit  int64      `json:"rateLimit"`
+	DailyQuota int64      `json:"dailyQuota"`
+	Revoked    bool       `json:"revoked"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// CreatedApiKey is an api key with its secret. The secret is only returned when the key is created or rotated.
+type CreatedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+// UpsertApiKey contains the editable fields of an api key.
+type UpsertApiKey struct {
+	Owner      string  `json:"owner"`
+	Scopes     []Scope `json:"scopes"`
+	RateLimit  int64   `json:"rateLimit"`
+	DailyQuota int64   `json:"dailyQuota"`
+}
+
+// Usage is the number of requests made with an api key in a day.
+type Usage struct {
+	Date     string `json:"date"`
+	Requests int64  `json:"requests"`
+}
+
+// Identity is the caller of a request authenticated with an api key.
+type Identity struct {
+	ID         string
+	Owner      string
+	Scopes     []Scope
+	RateLimit  int64
+	DailyQuota int64
+	// Static is set for the tokens of the configuration, which are not rate limited nor accounted.
+	Static bool
+}
+
+// HasScope reports whether the identity was granted scope.
+func (i *Identity) HasScope(scope Scope) bool {
+	return slices.Contains(i.Scopes, scope)
+}
+
+func toApiKey(d *ApiKeyDoc) *ApiKey {
+	return &ApiKey{
+		ID:         d.ID,
+		Owner:      d.Owner,
+		Scopes:     d.Scopes,
+		RateLimit:  d.RateLimit,
+		DailyQuota: d.DailyQuota,
+		Revoked:    d.RevokedAt != nil,
+		RevokedAt:  d.RevokedAt,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
@@ -0,0 +1,132 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	logger      *zap.Logger
+	collections struct {
+		apiKeys *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{
+		logger: logger.With(zap.String("module", "ApiKeysRepository")),
+		collections: struct {
+			apiKeys *mongo.Collection
+		}{
+			apiKeys: db.Collection(repository.ApiKeys),
+		},
+	}
+}
+
+// Insert inserts a new api key.
+func (r *Repository) Insert(ctx context.Context, doc *ApiKeyDoc) error {
+	_, err := r.collections.apiKeys.InsertOne(ctx, doc)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to insert api key", zap.Error(err), zap.String("id", doc.ID), zap.String("requestID", requestID))
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// FindOne returns an api key by id, or nil if it does not exist.
+func (r *Repository) FindOne(ctx context.Context, id string) (*ApiKeyDoc, error) {
+	var doc ApiKeyDoc
+	err := r.collections.apiKeys.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find api key", zap.Error(err), zap.String("id", id), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &doc, nil
+}
+
+// FindAll returns a page of api keys sorted by creation date.
+func (r *Repository) FindAll(ctx context.Context, p *pagination.Pagination) ([]*ApiKeyDoc, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: p.GetSortInt()}, {Key: "_id", Value: 1}}).
+		SetSkip(p.Skip).
+		SetLimit(p.Limit)
+
+	cur, err := r.collections.apiKeys.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find api keys", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	docs := []*ApiKeyDoc{}
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode api keys", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return docs, nil
+}
+
+// update applies update to an api key that is not revoked and returns the updated document,
+// or nil if there is no such api key.
+func (r *Repository) update(ctx context.Context, id string, update bson.M) (*ApiKeyDoc, error) {
+	filter := bson.M{"_id": id, "revokedAt": bson.M{"$exists": false}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var doc ApiKeyDoc
+	err := r.collections.apiKeys.FindOneAndUpdate(ctx, filter, bson.M{"$set": update}, opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to update api key", zap.Error(err), zap.String("id", id), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return &doc, nil
+}
+
+// Update updates the owner, scopes and limits of an api key.
+func (r *Repository) Update(ctx context.Context, id string, owner string, scopes []Scope, rateLimit, dailyQuota int64, now time.Time) (*ApiKeyDoc, error) {
+	return r.update(ctx, id, bson.M{
+		"owner":      owner,
+		"scopes":     scopes,
+		"rateLimit":  rateLimit,
+		"dailyQuota": dailyQuota,
+		"updatedAt":  now,
+	})
+}
+
+// Rotate replaces the hash of an api key. The previous hash remains valid until previousExpiresAt.
+func (r *Repository) Rotate(ctx context.Context, id string, hash, previousHash string, previousExpiresAt, now time.Time) (*ApiKeyDoc, error) {
+	return r.update(ctx, id, bson.M{
+		"hash":              hash,
+		"previousHash":      previousHash,
+		"previousExpiresAt": previousExpiresAt,
+		"updatedAt":         now,
+	})
+}
+
+// Revoke revokes an api key.
+func (r *Repository) Revoke(ctx context.Context, id string, now time.Time) (*ApiKeyDoc, error) {
+	return r.update(ctx, id, bson.M{
+		"revokedAt": now,
+		"updatedAt": now,
+	})
+}
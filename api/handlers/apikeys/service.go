@@ -0,0 +1,323 @@
+// Package apikeys handle the api keys used by partners to call the API.
+package apikeys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// Error definitions of the api key authentication.
+var (
+	ErrInvalidApiKey = errors.New("INVALID API KEY")
+	ErrRateLimited   = errors.New("API KEY RATE LIMIT EXCEEDED")
+	ErrQuotaExceeded = errors.New("API KEY DAILY QUOTA EXCEEDED")
+)
+
+const (
+	// keyPrefix identifies the keys managed by the service, keys are formatted as wsk_<id>_<secret>.
+	keyPrefix = "wsk_"
+	// docCacheExpiration is the time an api key is cached after being read from the database,
+	// so updates and revocations take up to this time to be applied by every instance.
+	docCacheExpiration = time.Minute
+)
+
+type apiKeyRepository interface {
+	Insert(ctx context.Context, doc *ApiKeyDoc) error
+	FindOne(ctx context.Context, id string) (*ApiKeyDoc, error)
+	FindAll(ctx context.Context, p *pagination.Pagination) ([]*ApiKeyDoc, error)
+	Update(ctx context.Context, id string, owner string, scopes []Scope, rateLimit, dailyQuota int64, now time.Time) (*ApiKeyDoc, error)
+	Rotate(ctx context.Context, id string, hash, previousHash string, previousExpiresAt, now time.Time) (*ApiKeyDoc, error)
+	Revoke(ctx context.Context, id string, now time.Time) (*ApiKeyDoc, error)
+}
+
+type cachedDoc struct {
+	doc       *ApiKeyDoc
+	expiresAt time.Time
+}
+
+// Service definition.
+type Service struct {
+	repo    apiKeyRepository
+	counter UsageCounter
+	static  map[string]*Identity
+	metrics metrics.Metrics
+	logger  *zap.Logger
+
+	mu    sync.RWMutex
+	cache map[string]cachedDoc
+}
+
+// NewService create a new apikeys.Service. The apiTokens and adminTokens of the configuration are
+// accepted as static keys: apiTokens are granted the export and webhooks scopes, adminTokens the admin scope.
+func NewService(repo apiKeyRepository, counter UsageCounter, apiTokens, adminTokens []string, metrics metrics.Metrics, logger *zap.Logger) *Service {
+	static := make(map[string]*Identity)
+	for _, token := range apiTokens {
+		if token = strings.TrimSpace(token); token != "" {
+			static[token] = &Identity{ID: "static", Owner: "static", Scopes: []Scope{ScopeExport, ScopeWebhooks}, Static: true}
+		}
+	}
+	for _, token := range adminTokens {
+		if token = strings.TrimSpace(token); token != "" {
+			static[token] = &Identity{ID: "admin", Owner: "admin", Scopes: []Scope{ScopeAdmin}, Static: true}
+		}
+	}
+	return &Service{
+		repo:    repo,
+		counter: counter,
+		static:  static,
+		metrics: metrics,
+		logger:  logger.With(zap.String("module", "ApiKeysService")),
+		cache:   make(map[string]cachedDoc),
+	}
+}
+
+// IsManagedKey reports whether key has the format of the keys created by the service.
+func IsManagedKey(key string) bool {
+	return strings.HasPrefix(key, keyPrefix)
+}
+
+// Authenticate returns the identity of an api key. It returns nil if the key is neither a static key
+// nor a managed key, and ErrInvalidApiKey if the managed key does not exist or was revoked.
+func (s *Service) Authenticate(ctx context.Context, key string) (*Identity, error) {
+	for token, identity := range s.static {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+			return identity, nil
+		}
+	}
+
+	if !IsManagedKey(key) {
+		return nil, nil
+	}
+	id, _, found := strings.Cut(strings.TrimPrefix(key, keyPrefix), "_")
+	if !found {
+		return nil, ErrInvalidApiKey
+	}
+
+	doc, err := s.findCached(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil || doc.RevokedAt != nil || !validHash(doc, hashKey(key), time.Now()) {
+		return nil, ErrInvalidApiKey
+	}
+
+	return &Identity{
+		ID:         doc.ID,
+		Owner:      doc.Owner,
+		Scopes:     doc.Scopes,
+		RateLimit:  doc.RateLimit,
+		DailyQuota: doc.DailyQuota,
+	}, nil
+}
+
+// Track counts a request of the identity. It returns ErrRateLimited or ErrQuotaExceeded if the request
+// exceeds the limits of the api key. Requests are allowed if they can not be counted.
+func (s *Service) Track(ctx context.Context, identity *Identity) error {
+	if identity.Static {
+		return nil
+	}
+
+	s.metrics.IncApiKeyRequest(identity.ID, identity.Owner)
+
+	minute, day, err := s.counter.Incr(ctx, identity.ID, time.Now())
+	if err != nil {
+		s.logger.Error("failed to count api key request", zap.Error(err), zap.String("id", identity.ID))
+		return nil
+	}
+	if identity.RateLimit > 0 && minute > identity.RateLimit {
+		s.metrics.IncApiKeyLimited(identity.ID, identity.Owner, "rate_limit")
+		return ErrRateLimited
+	}
+	if identity.DailyQuota > 0 && day > identity.DailyQuota {
+		s.metrics.IncApiKeyLimited(identity.ID, identity.Owner, "daily_quota")
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// FindAll returns a page of api keys.
+func (s *Service) FindAll(ctx context.Context, p *pagination.Pagination) (*response.Response[[]*ApiKey], error) {
+	docs, err := s.repo.FindAll(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*ApiKey, 0, len(docs))
+	for _, d := range docs {
+		keys = append(keys, toApiKey(d))
+	}
+	return &response.Response[[]*ApiKey]{Data: keys}, nil
+}
+
+// FindUsage returns the number of requests of an api key in each of the last days.
+func (s *Service) FindUsage(ctx context.Context, id string, days int) ([]Usage, error) {
+	doc, err := s.repo.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errs.ErrNotFound
+	}
+	usage, err := s.counter.Usage(ctx, id, time.Now(), days)
+	if err != nil {
+		s.logger.Error("failed to get api key usage", zap.Error(err), zap.String("id", id))
+		return nil, err
+	}
+	return usage, nil
+}
+
+// Create creates a new api key. The returned key is the only time the secret is available.
+func (s *Service) Create(ctx context.Context, u *UpsertApiKey) (*CreatedApiKey, error) {
+	id, key, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	doc := &ApiKeyDoc{
+		ID:         id,
+		Owner:      u.Owner,
+		Scopes:     u.Scopes,
+		Hash:       hashKey(key),
+		RateLimit:  u.RateLimit,
+		DailyQuota: u.DailyQuota,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.repo.Insert(ctx, doc); err != nil {
+		return nil, err
+	}
+	return &CreatedApiKey{ApiKey: *toApiKey(doc), Key: key}, nil
+}
+
+// Update updates the owner, scopes and limits of an api key.
+func (s *Service) Update(ctx context.Context, id string, u *UpsertApiKey) (*ApiKey, error) {
+	doc, err := s.repo.Update(ctx, id, u.Owner, u.Scopes, u.RateLimit, u.DailyQuota, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errs.ErrNotFound
+	}
+	s.invalidate(id)
+	return toApiKey(doc), nil
+}
+
+// Rotate generates a new secret for an api key. The previous key remains valid during gracePeriod,
+// so the owner can replace it without downtime.
+func (s *Service) Rotate(ctx context.Context, id string, gracePeriod time.Duration) (*CreatedApiKey, error) {
+	current, err := s.repo.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil || current.RevokedAt != nil {
+		return nil, errs.ErrNotFound
+	}
+
+	key, err := generateSecret(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	doc, err := s.repo.Rotate(ctx, id, hashKey(key), current.Hash, now.Add(gracePeriod), now)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errs.ErrNotFound
+	}
+	s.invalidate(id)
+	return &CreatedApiKey{ApiKey: *toApiKey(doc), Key: key}, nil
+}
+
+// Revoke revokes an api key.
+func (s *Service) Revoke(ctx context.Context, id string) error {
+	doc, err := s.repo.Revoke(ctx, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return errs.ErrNotFound
+	}
+	s.invalidate(id)
+	return nil
+}
+
+func (s *Service) findCached(ctx context.Context, id string) (*ApiKeyDoc, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[id]
+	s.mu.RUnlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.doc, nil
+	}
+
+	doc, err := s.repo.FindOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the existing keys are cached, so the cache is bounded by the number of api keys
+	// and requests with made-up ids do not grow it.
+	s.mu.Lock()
+	if doc != nil {
+		s.cache[id] = cachedDoc{doc: doc, expiresAt: time.Now().Add(docCacheExpiration)}
+	} else {
+		delete(s.cache, id)
+	}
+	s.mu.Unlock()
+	return doc, nil
+}
+
+func (s *Service) invalidate(id string) {
+	s.mu.Lock()
+	delete(s.cache, id)
+	s.mu.Unlock()
+}
+
+// validHash reports whether hash is the current hash of the api key, or the previous one during the rotation grace period.
+func validHash(doc *ApiKeyDoc, hash string, now time.Time) bool {
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(doc.Hash)) == 1 {
+		return true
+	}
+	return doc.PreviousHash != "" &&
+		doc.PreviousExpiresAt != nil &&
+		now.Before(*doc.PreviousExpiresAt) &&
+		subtle.ConstantTimeCompare([]byte(hash), []byte(doc.PreviousHash)) == 1
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateKey returns the id and the key of a new api key.
+func generateKey() (string, string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	id := hex.EncodeToString(b)
+	key, err := generateSecret(id)
+	return id, key, err
+}
+
+// generateSecret returns a new key for the api key id.
+func generateSecret(id string) (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + id + "_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
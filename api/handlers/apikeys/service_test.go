@@ -0,0 +1,201 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"go.uber.org/zap"
+)
+
+// memoryRepository is a repository that stores the api keys in memory.
+type memoryRepository struct {
+	docs  map[string]*ApiKeyDoc
+	reads int
+}
+
+func (r *memoryRepository) Insert(_ context.Context, doc *ApiKeyDoc) error {
+	d := *doc
+	r.docs[doc.ID] = &d
+	return nil
+}
+
+func (r *memoryRepository) FindOne(_ context.Context, id string) (*ApiKeyDoc, error) {
+	r.reads++
+	doc, ok := r.docs[id]
+	if !ok {
+		return nil, nil
+	}
+	d := *doc
+	return &d, nil
+}
+
+func (r *memoryRepository) FindAll(context.Context, *pagination.Pagination) ([]*ApiKeyDoc, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryRepository) Update(context.Context, string, string, []Scope, int64, int64, time.Time) (*ApiKeyDoc, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryRepository) Rotate(_ context.Context, id string, hash, previousHash string, previousExpiresAt, now time.Time) (*ApiKeyDoc, error) {
+	doc, ok := r.docs[id]
+	if !ok || doc.RevokedAt != nil {
+		return nil, nil
+	}
+	doc.Hash = hash
+	doc.PreviousHash = previousHash
+	doc.PreviousExpiresAt = &previousExpiresAt
+	doc.UpdatedAt = now
+	d := *doc
+	return &d, nil
+}
+
+func (r *memoryRepository) Revoke(_ context.Context, id string, now time.Time) (*ApiKeyDoc, error) {
+	doc, ok := r.docs[id]
+	if !ok || doc.RevokedAt != nil {
+		return nil, nil
+	}
+	doc.RevokedAt = &now
+	d := *doc
+	return &d, nil
+}
+
+// fixedUsageCounter is a UsageCounter that returns fixed counts.
+type fixedUsageCounter struct {
+	minute, day int64
+	err         error
+}
+
+func (c *fixedUsageCounter) Incr(context.Context, string, time.Time) (int64, int64, error) {
+	return c.minute, c.day, c.err
+}
+
+func (c *fixedUsageCounter) Usage(context.Context, string, time.Time, int) ([]Usage, error) {
+	return nil, c.err
+}
+
+func TestAuthenticateStaticTokens(t *testing.T) {
+	srv := NewService(nil, NewNoopUsageCounter(), []string{"token", ""}, []string{"admin-token"}, metrics.NewNoOpMetrics(), zap.NewNop())
+
+	identity, err := srv.Authenticate(context.Background(), "token")
+	assert.NoError(t, err)
+	assert.True(t, identity.Static)
+	assert.True(t, identity.HasScope(ScopeExport))
+	assert.False(t, identity.HasScope(ScopeAdmin))
+
+	identity, err = srv.Authenticate(context.Background(), "admin-token")
+	assert.NoError(t, err)
+	assert.True(t, identity.HasScope(ScopeAdmin))
+
+	identity, err = srv.Authenticate(context.Background(), "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+
+	_, err = srv.Authenticate(context.Background(), keyPrefix+"malformed")
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+}
+
+func TestGenerateKey(t *testing.T) {
+	id, key, err := generateKey()
+	assert.NoError(t, err)
+	assert.Len(t, id, 16)
+	assert.True(t, IsManagedKey(key))
+	assert.Contains(t, key, keyPrefix+id+"_")
+}
+
+func TestValidHash(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	doc := &ApiKeyDoc{Hash: hashKey("new"), PreviousHash: hashKey("old"), PreviousExpiresAt: &expiresAt}
+
+	assert.True(t, validHash(doc, hashKey("new"), now))
+	assert.True(t, validHash(doc, hashKey("old"), now))
+	assert.False(t, validHash(doc, hashKey("old"), now.Add(2*time.Hour)))
+	assert.False(t, validHash(doc, hashKey("other"), now))
+}
+
+func TestAuthenticateDoesNotCacheUnknownKeys(t *testing.T) {
+	repo := &memoryRepository{docs: map[string]*ApiKeyDoc{}}
+	srv := NewService(repo, NewNoopUsageCounter(), nil, nil, metrics.NewNoOpMetrics(), zap.NewNop())
+
+	for i := 0; i < 2; i++ {
+		_, err := srv.Authenticate(context.Background(), keyPrefix+"unknown_secret")
+		assert.ErrorIs(t, err, ErrInvalidApiKey)
+	}
+	assert.Equal(t, 2, repo.reads)
+	assert.Empty(t, srv.cache)
+}
+
+func TestRotate(t *testing.T) {
+	repo := &memoryRepository{docs: map[string]*ApiKeyDoc{}}
+	srv := NewService(repo, NewNoopUsageCounter(), nil, nil, metrics.NewNoOpMetrics(), zap.NewNop())
+	ctx := context.Background()
+
+	created, err := srv.Create(ctx, &UpsertApiKey{Owner: "partner", Scopes: []Scope{ScopeExport}})
+	require.NoError(t, err)
+	identity, err := srv.Authenticate(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, identity.ID)
+
+	// both keys are valid during the grace period
+	rotated, err := srv.Rotate(ctx, created.ID, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Key, rotated.Key)
+	_, err = srv.Authenticate(ctx, rotated.Key)
+	assert.NoError(t, err)
+	_, err = srv.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+
+	// the previous key is no longer valid without grace period
+	rotatedAgain, err := srv.Rotate(ctx, created.ID, 0)
+	require.NoError(t, err)
+	_, err = srv.Authenticate(ctx, rotated.Key)
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+	_, err = srv.Authenticate(ctx, created.Key)
+	assert.ErrorIs(t, err, ErrInvalidApiKey)
+	_, err = srv.Authenticate(ctx, rotatedAgain.Key)
+	assert.NoError(t, err)
+
+	// revoked and unknown keys can not be rotated
+	require.NoError(t, srv.Revoke(ctx, created.ID))
+	_, err = srv.Rotate(ctx, created.ID, time.Hour)
+	assert.Error(t, err)
+	_, err = srv.Rotate(ctx, "unknown", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestTrack(t *testing.T) {
+	identity := &Identity{ID: "id", Owner: "partner", RateLimit: 10, DailyQuota: 100}
+
+	cases := []struct {
+		name     string
+		identity *Identity
+		counter  *fixedUsageCounter
+		expected error
+	}{
+		{name: "within limits", identity: identity, counter: &fixedUsageCounter{minute: 10, day: 100}},
+		{name: "rate limited", identity: identity, counter: &fixedUsageCounter{minute: 11, day: 11}, expected: ErrRateLimited},
+		{name: "quota exceeded", identity: identity, counter: &fixedUsageCounter{minute: 1, day: 101}, expected: ErrQuotaExceeded},
+		{name: "no limits", identity: &Identity{ID: "id"}, counter: &fixedUsageCounter{minute: 1000, day: 100000}},
+		{name: "static key", identity: &Identity{ID: "static", Static: true, RateLimit: 1}, counter: &fixedUsageCounter{minute: 2, day: 2}},
+		{name: "counter failure", identity: identity, counter: &fixedUsageCounter{minute: 11, err: errors.New("redis down")}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := NewService(nil, tc.counter, nil, nil, metrics.NewNoOpMetrics(), zap.NewNop())
+			err := srv.Track(context.Background(), tc.identity)
+			if tc.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
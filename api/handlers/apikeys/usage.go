@@ -0,0 +1,111 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// usageExpiration is the retention of the daily usage counters.
+	usageExpiration = 35 * 24 * time.Hour
+	usageDateLayout = "2006-01-02"
+)
+
+// UsageCounter counts the requests made with each api key.
+type UsageCounter interface {
+	// Incr counts a request and returns the number of requests of the key in the current minute and day.
+	Incr(ctx context.Context, id string, now time.Time) (minute int64, day int64, err error)
+	// Usage returns the number of requests of the key in each of the last days.
+	Usage(ctx context.Context, id string, now time.Time, days int) ([]Usage, error)
+}
+
+// RedisUsageCounter is a UsageCounter backed by redis counters.
+type RedisUsageCounter struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisUsageCounter creates a new RedisUsageCounter.
+func NewRedisUsageCounter(client *redis.Client, prefix string) *RedisUsageCounter {
+	if prefix != "" {
+		prefix += ":"
+	}
+	return &RedisUsageCounter{client: client, prefix: prefix + "api-keys:"}
+}
+
+func (c *RedisUsageCounter) minuteKey(id string, now time.Time) string {
+	return fmt.Sprintf("%srpm:%s:%d", c.prefix, id, now.Unix()/60)
+}
+
+func (c *RedisUsageCounter) dayKey(id string, day time.Time) string {
+	return fmt.Sprintf("%susage:%s:%s", c.prefix, id, day.UTC().Format(usageDateLayout))
+}
+
+// Incr counts a request and returns the number of requests of the key in the current minute and day.
+func (c *RedisUsageCounter) Incr(ctx context.Context, id string, now time.Time) (int64, int64, error) {
+	minuteKey := c.minuteKey(id, now)
+	dayKey := c.dayKey(id, now)
+
+	pipe := c.client.TxPipeline()
+	minute := pipe.Incr(ctx, minuteKey)
+	pipe.Expire(ctx, minuteKey, 2*time.Minute)
+	day := pipe.Incr(ctx, dayKey)
+	pipe.Expire(ctx, dayKey, usageExpiration)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	return minute.Val(), day.Val(), nil
+}
+
+// Usage returns the number of requests of the key in each of the last days, starting from the current day.
+func (c *RedisUsageCounter) Usage(ctx context.Context, id string, now time.Time, days int) ([]Usage, error) {
+	keys := make([]string, 0, days)
+	dates := make([]string, 0, days)
+	for i := 0; i < days; i++ {
+		day := now.UTC().AddDate(0, 0, -i)
+		keys = append(keys, c.dayKey(id, day))
+		dates = append(dates, day.Format(usageDateLayout))
+	}
+
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]Usage, 0, days)
+	for i, v := range values {
+		var requests int64
+		if s, ok := v.(string); ok {
+			requests, err = strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		usage = append(usage, Usage{Date: dates[i], Requests: requests})
+	}
+	return usage, nil
+}
+
+// noopUsageCounter does not count requests, so api keys are never limited.
+type noopUsageCounter struct{}
+
+// NewNoopUsageCounter creates a UsageCounter that does not count requests.
+func NewNoopUsageCounter() UsageCounter {
+	return &noopUsageCounter{}
+}
+
+func (c *noopUsageCounter) Incr(_ context.Context, _ string, _ time.Time) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+func (c *noopUsageCounter) Usage(_ context.Context, _ string, now time.Time, days int) ([]Usage, error) {
+	usage := make([]Usage, 0, days)
+	for i := 0; i < days; i++ {
+		usage = append(usage, Usage{Date: now.UTC().AddDate(0, 0, -i).Format(usageDateLayout)})
+	}
+	return usage, nil
+}
@@ -0,0 +1,36 @@
+package emitters
+
+import (
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Emitter definition.
+type Emitter struct {
+	ChainID       sdk.ChainID `json:"chainId"`
+	Address       string      `json:"address"`
+	NativeAddress string      `json:"nativeAddress"`
+	AppID         string      `json:"appId"`
+	Label         string      `json:"label"`
+	Source        string      `json:"source"`
+	VaaCount      int64       `json:"vaaCount"`
+	FirstSeenAt   *time.Time  `json:"firstSeenAt,omitempty"`
+	LastSeenAt    *time.Time  `json:"lastSeenAt,omitempty"`
+	UpdatedAt     time.Time   `json:"updatedAt"`
+}
+
+// UpsertEmitter contains the editable fields of an emitter.
+type UpsertEmitter struct {
+	NativeAddress string `json:"nativeAddress"`
+	AppID         string `json:"appId"`
+	Label         string `json:"label"`
+}
+
+type vaaCountResult struct {
+	ID struct {
+		EmitterChain sdk.ChainID `bson:"emitterChain"`
+		EmitterAddr  string      `bson:"emitterAddr"`
+	} `bson:"_id"`
+	Count int64 `bson:"count"`
+}
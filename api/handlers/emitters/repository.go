@@ -0,0 +1,105 @@
+package emitters
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	registry    *repository.EmitterRepository
+	logger      *zap.Logger
+	collections struct {
+		vaas *mongo.Collection
+	}
+}
+
+// NewRepository create a new Repository.
+func NewRepository(db *mongo.Database, registry *repository.EmitterRepository, logger *zap.Logger) *Repository {
+	return &Repository{
+		registry: registry,
+		logger:   logger.With(zap.String("module", "EmittersRepository")),
+		collections: struct {
+			vaas *mongo.Collection
+		}{
+			vaas: db.Collection(repository.Vaas),
+		},
+	}
+}
+
+// FindAll returns a page of the emitter registry.
+func (r *Repository) FindAll(ctx context.Context, chainID *sdk.ChainID, appID string, p *pagination.Pagination) ([]*repository.EmitterDoc, error) {
+	docs, err := r.registry.Find(ctx, repository.EmitterQuery{
+		ChainID: chainID,
+		AppID:   appID,
+		Skip:    p.Skip,
+		Limit:   p.Limit,
+	})
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find emitters", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return docs, nil
+}
+
+// FindOne returns an emitter of the registry.
+func (r *Repository) FindOne(ctx context.Context, chainID sdk.ChainID, address string) (*repository.EmitterDoc, error) {
+	doc, err := r.registry.FindOne(ctx, chainID, address)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find emitter", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return doc, nil
+}
+
+// CountVaas returns the number of VAAs emitted by each of the given emitters, keyed by emitter id.
+func (r *Repository) CountVaas(ctx context.Context, docs []*repository.EmitterDoc) (map[string]int64, error) {
+	counts := make(map[string]int64, len(docs))
+	if len(docs) == 0 {
+		return counts, nil
+	}
+
+	emitters := make(bson.A, 0, len(docs))
+	for _, d := range docs {
+		emitters = append(emitters, bson.D{
+			{Key: "emitterChain", Value: d.ChainID},
+			{Key: "emitterAddr", Value: d.Address},
+		})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "$or", Value: emitters}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "emitterChain", Value: "$emitterChain"},
+				{Key: "emitterAddr", Value: "$emitterAddr"},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	}
+
+	cur, err := r.collections.vaas.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to count vaas by emitter", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	var results []vaaCountResult
+	if err := cur.All(ctx, &results); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, res := range results {
+		counts[repository.EmitterID(res.ID.EmitterChain, res.ID.EmitterAddr)] = res.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,189 @@
+// Package emitters handle the request of the emitter registry.
+package emitters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const emitterVaaCountKey = "wormscan:emitter-vaa-count"
+
+// Service definition.
+type Service struct {
+	repo         *Repository
+	registry     *repository.EmitterRepository
+	emitterCache *repository.EmitterCache
+	cache        cache.Cache
+	expiration   time.Duration
+	logger       *zap.Logger
+}
+
+// NewService create a new emitters.Service.
+func NewService(repo *Repository, registry *repository.EmitterRepository, emitterCache *repository.EmitterCache, cache cache.Cache, expiration time.Duration, logger *zap.Logger) *Service {
+	return &Service{
+		repo:         repo,
+		registry:     registry,
+		emitterCache: emitterCache,
+		cache:        cache,
+		expiration:   expiration,
+		logger:       logger.With(zap.String("module", "EmittersService")),
+	}
+}
+
+// FindAll returns a page of emitters with their VAA counts.
+func (s *Service) FindAll(ctx context.Context, chainID *sdk.ChainID, appID string, p *pagination.Pagination) (*response.Response[[]*Emitter], error) {
+	if p == nil {
+		p = pagination.Default()
+	}
+	docs, err := s.repo.FindAll(ctx, chainID, appID, p)
+	if err != nil {
+		return nil, err
+	}
+	emitters, err := s.withVaaCounts(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+	return &response.Response[[]*Emitter]{Data: emitters}, nil
+}
+
+// FindOne returns an emitter with its VAA count.
+func (s *Service) FindOne(ctx context.Context, chainID sdk.ChainID, address string) (*Emitter, error) {
+	doc, err := s.repo.FindOne(ctx, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errs.ErrNotFound
+	}
+	emitters, err := s.withVaaCounts(ctx, []*repository.EmitterDoc{doc})
+	if err != nil {
+		return nil, err
+	}
+	return emitters[0], nil
+}
+
+// Upsert creates or updates an emitter of the registry.
+func (s *Service) Upsert(ctx context.Context, chainID sdk.ChainID, address string, u *UpsertEmitter) (*Emitter, error) {
+	info := &domain.EmitterInfo{
+		ChainID:       chainID,
+		Address:       address,
+		NativeAddress: u.NativeAddress,
+		AppID:         u.AppID,
+		Label:         u.Label,
+	}
+	doc, err := s.registry.Upsert(ctx, info, repository.EmitterSourceAPI)
+	if err != nil {
+		s.logger.Error("failed to upsert emitter", zap.Error(err), zap.String("id", repository.EmitterID(chainID, address)))
+		return nil, err
+	}
+	if s.emitterCache != nil {
+		s.emitterCache.Set(doc.ToEmitterInfo())
+	}
+	return toEmitter(doc, 0), nil
+}
+
+// Delete deletes an emitter of the registry.
+func (s *Service) Delete(ctx context.Context, chainID sdk.ChainID, address string) error {
+	deleted, err := s.registry.Delete(ctx, chainID, address)
+	if err != nil {
+		s.logger.Error("failed to delete emitter", zap.Error(err), zap.String("id", repository.EmitterID(chainID, address)))
+		return err
+	}
+	if !deleted {
+		return errs.ErrNotFound
+	}
+	if s.emitterCache != nil {
+		s.emitterCache.Remove(chainID, address)
+	}
+	return nil
+}
+
+// withVaaCounts adds the VAA count to each emitter. The counts are cached by emitter, and the
+// emitters that are not in the cache are counted with a single grouped query.
+func (s *Service) withVaaCounts(ctx context.Context, docs []*repository.EmitterDoc) ([]*Emitter, error) {
+	counts := make(map[string]int64, len(docs))
+	var missing []*repository.EmitterDoc
+	for _, d := range docs {
+		id := repository.EmitterID(d.ChainID, d.Address)
+		count, ok := s.getCachedVaaCount(ctx, id)
+		if !ok {
+			missing = append(missing, d)
+			continue
+		}
+		counts[id] = count
+	}
+
+	if len(missing) > 0 {
+		loaded, err := s.repo.CountVaas(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range missing {
+			id := repository.EmitterID(d.ChainID, d.Address)
+			counts[id] = loaded[id]
+			s.setCachedVaaCount(ctx, id, loaded[id])
+		}
+	}
+
+	emitters := make([]*Emitter, 0, len(docs))
+	for _, d := range docs {
+		emitters = append(emitters, toEmitter(d, counts[repository.EmitterID(d.ChainID, d.Address)]))
+	}
+	return emitters, nil
+}
+
+func (s *Service) getCachedVaaCount(ctx context.Context, id string) (int64, bool) {
+	key := fmt.Sprintf("%s:%s", emitterVaaCountKey, id)
+	value, err := s.cache.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, cache.ErrNotFound) && !errors.Is(err, cache.ErrCacheNotEnabled) {
+			s.logger.Warn("getting emitter vaa count from cache", zap.Error(err), zap.String("key", key))
+		}
+		return 0, false
+	}
+	var cached cacheable.CachedResult[int64]
+	if err := json.Unmarshal([]byte(value), &cached); err != nil {
+		s.logger.Warn("unmarshal emitter vaa count", zap.Error(err), zap.String("key", key))
+		return 0, false
+	}
+	if cached.IsExpired(s.expiration) {
+		return 0, false
+	}
+	return cached.Result, true
+}
+
+func (s *Service) setCachedVaaCount(ctx context.Context, id string, count int64) {
+	key := fmt.Sprintf("%s:%s", emitterVaaCountKey, id)
+	value := cacheable.CachedResult[int64]{Timestamp: time.Now(), Result: count}
+	if err := s.cache.Set(ctx, key, value, s.expiration); err != nil {
+		s.logger.Warn("saving emitter vaa count in the cache", zap.Error(err), zap.String("key", key))
+	}
+}
+
+func toEmitter(d *repository.EmitterDoc, vaaCount int64) *Emitter {
+	return &Emitter{
+		ChainID:       d.ChainID,
+		Address:       d.Address,
+		NativeAddress: d.NativeAddress,
+		AppID:         d.AppID,
+		Label:         d.Label,
+		Source:        d.Source,
+		VaaCount:      vaaCount,
+		FirstSeenAt:   d.FirstSeenAt,
+		LastSeenAt:    d.LastSeenAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -205,3 +206,112 @@ type EnqueuedVaaItem struct {
 	NotionalValue  mongo.Uint64 `bson:"notionalvalue" json:"notionalValue"`
 	TxHash         string       `bson:"txhash" json:"txHash"`
 }
+
+// DailyAvailableNotional is the minimum available notional reported by a guardian for a chain in a day.
+type DailyAvailableNotional struct {
+	ChainID              vaa.ChainID  `bson:"chainId"`
+	NodeAddress          string       `bson:"nodeAddress"`
+	Date                 time.Time    `bson:"date"`
+	MinAvailableNotional mongo.Uint64 `bson:"minAvailableNotional"`
+}
+
+// GovernorVaaEvent is the enqueue and release of a vaa by the governor.
+type GovernorVaaEvent struct {
+	ChainID    vaa.ChainID `bson:"chainId"`
+	EnqueuedAt time.Time   `bson:"enqueuedAt"`
+	ReleasedAt *time.Time  `bson:"releasedAt"`
+}
+
+// NotionalHistory definition.
+type NotionalHistory struct {
+	ChainID              vaa.ChainID `json:"chainId"`
+	Date                 time.Time   `json:"date"`
+	NotionalLimit        uint64      `json:"notionalLimit"`
+	MinAvailableNotional uint64      `json:"minAvailableNotional"`
+	// MaxUtilization is the maximum percentage of the daily limit used in the day.
+	MaxUtilization float64 `json:"maxUtilization"`
+}
+
+// EnqueuedVaaHistory definition.
+type EnqueuedVaaHistory struct {
+	ChainID        vaa.ChainID         `json:"chainId"`
+	Enqueued       int                 `json:"enqueued"`
+	Released       int                 `json:"released"`
+	Pending        int                 `json:"pending"`
+	ReleaseLatency *ReleaseLatency     `json:"releaseLatency,omitempty"`
+	Daily          []*EnqueuedVaaCount `json:"daily"`
+}
+
+// ReleaseLatency is the percentiles of the time, in seconds, from the enqueue to the release of the vaas.
+type ReleaseLatency struct {
+	P50 int64 `json:"p50"`
+	P90 int64 `json:"p90"`
+	P99 int64 `json:"p99"`
+}
+
+// EnqueuedVaaCount definition.
+type EnqueuedVaaCount struct {
+	Date     time.Time `json:"date"`
+	Enqueued int       `json:"enqueued"`
+}
+
+// HourlyAvailableNotional is the available notional reported by a guardian for a chain at the end of an hour.
+type HourlyAvailableNotional struct {
+	NodeAddress       string       `bson:"nodeAddress"`
+	Hour              time.Time    `bson:"hour"`
+	AvailableNotional mongo.Uint64 `bson:"availableNotional"`
+}
+
+// GovernorSimulationQuery respresent a transfer whose governor delay is simulated.
+type GovernorSimulationQuery struct {
+	// ChainID is the chain the transfer is sent from.
+	ChainID      vaa.ChainID
+	TokenChainID vaa.ChainID
+	// TokenAddress is the original address of the token, as a 32-byte hex string.
+	TokenAddress string
+	Amount       decimal.Decimal
+}
+
+// Reasons of the governor to delay a transfer.
+const (
+	DelayReasonBigTransaction = "big_transaction"
+	DelayReasonNotionalLimit  = "notional_limit"
+)
+
+// GovernorSimulation is the simulated governor delay of a transfer.
+type GovernorSimulation struct {
+	ChainID      vaa.ChainID `json:"chainId"`
+	TokenChainID vaa.ChainID `json:"tokenChainId"`
+	TokenAddress string      `json:"tokenAddress"`
+	Amount       string      `json:"amount"`
+	// MarketPrice is the current price of the token, zero when the token price is unknown.
+	MarketPrice float64 `json:"marketPrice"`
+	// Governed is true when the token is governed on the chain by any guardian.
+	Governed bool `json:"governed"`
+	// Delayed is true when less than a quorum of guardians would sign the transfer immediately.
+	Delayed bool   `json:"delayed"`
+	Reason  string `json:"reason,omitempty"`
+	Quorum  int    `json:"quorum"`
+	// GuardiansDelaying is the number of guardians that would enqueue the transfer.
+	GuardiansDelaying int `json:"guardiansDelaying"`
+	// ExpectedDelay is the maximum time, in seconds, the transfer would be enqueued.
+	ExpectedDelay int64 `json:"expectedDelay"`
+	// EstimatedRelease is the estimated time, in seconds, until a quorum of guardians would release the transfer.
+	EstimatedRelease int64                 `json:"estimatedRelease"`
+	Guardians        []*GuardianSimulation `json:"guardians"`
+}
+
+// GuardianSimulation is the simulated governor delay of a transfer by a guardian.
+type GuardianSimulation struct {
+	GuardianAddress    string  `json:"guardianAddress"`
+	NodeName           string  `json:"nodeName"`
+	Governed           bool    `json:"governed"`
+	Price              float64 `json:"price"`
+	NotionalValue      uint64  `json:"notionalValue"`
+	NotionalLimit      uint64  `json:"notionalLimit"`
+	BigTransactionSize uint64  `json:"bigTransactionSize"`
+	AvailableNotional  uint64  `json:"availableNotional"`
+	Delayed            bool    `json:"delayed"`
+	Reason             string  `json:"reason,omitempty"`
+	EstimatedRelease   int64   `json:"estimatedRelease"`
+}
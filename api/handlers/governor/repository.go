@@ -11,6 +11,7 @@ import (
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	mongoTypes "github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
@@ -26,9 +27,11 @@ type Repository struct {
 	db          *mongo.Database
 	logger      *zap.Logger
 	collections struct {
-		governorConfig *mongo.Collection
-		governorStatus *mongo.Collection
-		governorVaas   *mongo.Collection
+		governorConfig    *mongo.Collection
+		governorStatus    *mongo.Collection
+		governorVaas      *mongo.Collection
+		governorNotional  *mongo.Collection
+		governorVaaEvents *mongo.Collection
 	}
 }
 
@@ -37,13 +40,17 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 	return &Repository{db: db,
 		logger: logger.With(zap.String("module", "GovernorRepository")),
 		collections: struct {
-			governorConfig *mongo.Collection
-			governorStatus *mongo.Collection
-			governorVaas   *mongo.Collection
+			governorConfig    *mongo.Collection
+			governorStatus    *mongo.Collection
+			governorVaas      *mongo.Collection
+			governorNotional  *mongo.Collection
+			governorVaaEvents *mongo.Collection
 		}{
-			governorConfig: db.Collection("governorConfig"),
-			governorStatus: db.Collection("governorStatus"),
-			governorVaas:   db.Collection("governorVaas"),
+			governorConfig:    db.Collection("governorConfig"),
+			governorStatus:    db.Collection("governorStatus"),
+			governorVaas:      db.Collection("governorVaas"),
+			governorNotional:  db.Collection(repository.GovernorNotional),
+			governorVaaEvents: db.Collection(repository.GovernorVaaEvents),
 		},
 	}
 }
@@ -1738,7 +1745,9 @@ type GovernorVaaDoc struct {
 	TxHash         string            `bson:"txHash"`
 	ReleaseTime    time.Time         `bson:"releaseTime"`
 	Amount         mongoTypes.Uint64 `bson:"amount"`
-	Vaas           []any             `bson:"vaas"`
+	// QuorumReleaseTime is the time at which a quorum of guardians releases the vaa.
+	QuorumReleaseTime *time.Time `bson:"quorumReleaseTime"`
+	Vaas              []any      `bson:"vaas"`
 }
 
 func (r *Repository) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, error) {
@@ -1772,3 +1781,165 @@ func (r *Repository) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, err
 	}
 	return result, nil
 }
+
+// GetQuorumReleaseTime gets the time at which a quorum of guardians releases an enqueued vaa.
+// It returns nil when the vaa is not enqueued or its quorum release time is unknown.
+func (r *Repository) GetQuorumReleaseTime(ctx context.Context, vaaID string) (*time.Time, error) {
+	var doc struct {
+		QuorumReleaseTime *time.Time `bson:"quorumReleaseTime"`
+	}
+	opts := options.FindOne().SetProjection(bson.D{{Key: "quorumReleaseTime", Value: 1}})
+	err := r.collections.governorVaas.FindOne(ctx, bson.D{{Key: "_id", Value: vaaID}}, opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to get the quorum release time of the governor vaa",
+			zap.Error(err),
+			zap.String("vaaId", vaaID),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+	return doc.QuorumReleaseTime, nil
+}
+
+// GovernorHistoryQuery respresent a query for the governor history.
+type GovernorHistoryQuery struct {
+	ChainID *vaa.ChainID
+	From    time.Time
+	To      time.Time
+}
+
+// GetDailyAvailableNotional gets the minimum available notional reported by each guardian for each chain and day.
+func (r *Repository) GetDailyAvailableNotional(ctx context.Context, q *GovernorHistoryQuery) ([]*DailyAvailableNotional, error) {
+
+	match := bson.D{{Key: "hour", Value: bson.D{{Key: "$gte", Value: q.From}, {Key: "$lt", Value: q.To}}}}
+	if q.ChainID != nil {
+		match = append(match, bson.E{Key: "chainId", Value: *q.ChainID})
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "chainId", Value: "$chainId"},
+				{Key: "nodeAddress", Value: "$nodeAddress"},
+				{Key: "date", Value: bson.D{{Key: "$dateTrunc", Value: bson.D{{Key: "date", Value: "$hour"}, {Key: "unit", Value: "day"}}}}},
+			}},
+			{Key: "minAvailableNotional", Value: bson.D{{Key: "$min", Value: "$minAvailableNotional"}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "chainId", Value: "$_id.chainId"},
+			{Key: "nodeAddress", Value: "$_id.nodeAddress"},
+			{Key: "date", Value: "$_id.date"},
+			{Key: "minAvailableNotional", Value: 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "chainId", Value: 1}, {Key: "date", Value: 1}}}},
+	}
+
+	cur, err := r.collections.governorNotional.Aggregate(ctx, pipeline)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to execute Aggregate command to get daily available notional",
+			zap.Error(err),
+			zap.Any("q", q),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+
+	result := []*DailyAvailableNotional{}
+	err = cur.All(ctx, &result)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor into []*DailyAvailableNotional",
+			zap.Error(err),
+			zap.Any("q", q),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// GetGovernorVaaEvents gets the enqueue and release events of the vaas enqueued in the period.
+func (r *Repository) GetGovernorVaaEvents(ctx context.Context, q *GovernorHistoryQuery) ([]*GovernorVaaEvent, error) {
+
+	filter := bson.D{{Key: "enqueuedAt", Value: bson.D{{Key: "$gte", Value: q.From}, {Key: "$lt", Value: q.To}}}}
+	if q.ChainID != nil {
+		filter = append(filter, bson.E{Key: "chainId", Value: *q.ChainID})
+	}
+	opts := options.Find().SetProjection(bson.D{
+		{Key: "chainId", Value: 1},
+		{Key: "enqueuedAt", Value: 1},
+		{Key: "releasedAt", Value: 1},
+	})
+
+	cur, err := r.collections.governorVaaEvents.Find(ctx, filter, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to execute Find command to get governor vaa events",
+			zap.Error(err),
+			zap.Any("q", q),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+
+	result := []*GovernorVaaEvent{}
+	err = cur.All(ctx, &result)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor into []*GovernorVaaEvent",
+			zap.Error(err),
+			zap.Any("q", q),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
+
+// GetHourlyAvailableNotional gets the available notional reported by each guardian for a chain at the end of each hour
+// of the period, sorted by guardian and hour.
+func (r *Repository) GetHourlyAvailableNotional(ctx context.Context, chainID vaa.ChainID, from, to time.Time) ([]*HourlyAvailableNotional, error) {
+
+	filter := bson.D{
+		{Key: "chainId", Value: chainID},
+		{Key: "hour", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+	}
+	opts := options.Find().
+		SetProjection(bson.D{
+			{Key: "nodeAddress", Value: 1},
+			{Key: "hour", Value: 1},
+			{Key: "availableNotional", Value: 1},
+		}).
+		SetSort(bson.D{{Key: "nodeAddress", Value: 1}, {Key: "hour", Value: 1}})
+
+	cur, err := r.collections.governorNotional.Find(ctx, filter, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to execute Find command to get hourly available notional",
+			zap.Error(err),
+			zap.Uint16("chainId", uint16(chainID)),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+
+	result := []*HourlyAvailableNotional{}
+	err = cur.All(ctx, &result)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor into []*HourlyAvailableNotional",
+			zap.Error(err),
+			zap.Uint16("chainId", uint16(chainID)),
+			zap.String("requestID", requestID),
+		)
+		return nil, errors.WithStack(err)
+	}
+	return result, nil
+}
@@ -4,23 +4,54 @@ package governor
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// governorRepository decouples the service from the governor repository.
+type governorRepository interface {
+	FindGovConfigurations(ctx context.Context, q *GovernorQuery) ([]*GovConfig, error)
+	FindGovernorStatus(ctx context.Context, q *GovernorQuery) ([]*GovStatus, error)
+	FindOneGovernorStatus(ctx context.Context, q *GovernorQuery) (*GovStatus, error)
+	FindNotionalLimit(ctx context.Context, q *NotionalLimitQuery) ([]*NotionalLimit, error)
+	GetNotionalLimitByChainID(ctx context.Context, q *NotionalLimitQuery) ([]*NotionalLimitDetail, error)
+	GetAvailableNotional(ctx context.Context, q *NotionalLimitQuery) ([]*NotionalAvailable, error)
+	GetAvailableNotionalByChainID(ctx context.Context, q *NotionalLimitQuery) ([]*NotionalAvailableDetail, error)
+	GetMaxNotionalAvailableByChainID(ctx context.Context, q *NotionalLimitQuery) (*MaxNotionalAvailableRecord, error)
+	GetEnqueueVass(ctx context.Context, q *EnqueuedVaaQuery) ([]*EnqueuedVaas, error)
+	GetEnqueueVassByChainID(ctx context.Context, q *EnqueuedVaaQuery) ([]*EnqueuedVaaDetail, error)
+	GetGovernorLimit(ctx context.Context, q *GovernorQuery) ([]*GovernorLimit, error)
+	GetAvailNotionByChain(ctx context.Context) ([]*AvailableNotionalByChain, error)
+	GetTokenList(ctx context.Context) ([]*TokenList, error)
+	GetEnqueuedVaas(ctx context.Context) ([]*EnqueuedVaaItem, error)
+	IsVaaEnqueued(ctx context.Context, chainID vaa.ChainID, emitter *types.Address, sequence string) (bool, error)
+	GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, error)
+	GetQuorumReleaseTime(ctx context.Context, vaaID string) (*time.Time, error)
+	GetDailyAvailableNotional(ctx context.Context, q *GovernorHistoryQuery) ([]*DailyAvailableNotional, error)
+	GetGovernorVaaEvents(ctx context.Context, q *GovernorHistoryQuery) ([]*GovernorVaaEvent, error)
+	GetHourlyAvailableNotional(ctx context.Context, chainID vaa.ChainID, from, to time.Time) ([]*HourlyAvailableNotional, error)
+}
+
 type Service struct {
-	repo              *Repository
+	repo              governorRepository
 	cache             cache.Cache
+	notionalCache     notional.NotionalLocalCacheReadable
+	tokenProvider     *domain.TokenProvider
 	metrics           metrics.Metrics
 	supportedChainIDs map[vaa.ChainID]string
 	logger            *zap.Logger
@@ -29,12 +60,14 @@ type Service struct {
 const (
 	availableNotionByChain = "wormscan:available-notion-by-chain"
 	tokenList              = "wormscan:token-list"
+	notionalHistory        = "wormscan:governor-notional-history"
+	enqueuedVaaHistory     = "wormscan:governor-enqueued-vaa-history"
 )
 
 // NewService create a new governor.Service.
-func NewService(dao *Repository, cache cache.Cache, metrics metrics.Metrics, logger *zap.Logger) *Service {
+func NewService(dao *Repository, cache cache.Cache, notionalCache notional.NotionalLocalCacheReadable, tokenProvider *domain.TokenProvider, metrics metrics.Metrics, logger *zap.Logger) *Service {
 	supportedChainIDs := domain.GetSupportedChainIDs()
-	return &Service{repo: dao, cache: cache, metrics: metrics, supportedChainIDs: supportedChainIDs, logger: logger.With(zap.String("module", "GovernorService"))}
+	return &Service{repo: dao, cache: cache, notionalCache: notionalCache, tokenProvider: tokenProvider, metrics: metrics, supportedChainIDs: supportedChainIDs, logger: logger.With(zap.String("module", "GovernorService"))}
 }
 
 // FindGovernorConfig get a list of governor configurations.
@@ -229,6 +262,11 @@ func (s *Service) IsVaaEnqueued(ctx context.Context, chainID vaa.ChainID, emitte
 	return isEnqueued, err
 }
 
+// GetQuorumReleaseTime get the time at which a quorum of guardians releases an enqueued vaa.
+func (s *Service) GetQuorumReleaseTime(ctx context.Context, chainID vaa.ChainID, emitter *types.Address, seq string) (*time.Time, error) {
+	return s.repo.GetQuorumReleaseTime(ctx, fmt.Sprintf("%d/%s/%s", chainID, emitter.Hex(), seq))
+}
+
 // GetGovernorVaas get enqueued vaas.
 // Guardian api migration.
 func (s *Service) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, error) {
@@ -238,3 +276,304 @@ func (s *Service) GetGovernorVaas(ctx context.Context) ([]GovernorVaaDoc, error)
 	}
 	return result, nil
 }
+
+// historyCacheKey returns the cache key of a governor history query.
+// The period defaults to the last 30 days until now, so its ends are truncated to the hour to share the cached history
+// between requests.
+func historyCacheKey(prefix string, q *GovernorHistoryQuery) string {
+	chain := "all"
+	if q.ChainID != nil {
+		chain = q.ChainID.String()
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", prefix, chain, q.From.Truncate(time.Hour).Format(time.RFC3339), q.To.Truncate(time.Hour).Format(time.RFC3339))
+}
+
+// GetNotionalHistory get the daily limit utilization of the chains.
+//
+// The available notional of a chain in a day is the minimum notional reported by the guardian with the
+// 13th highest value, as in the governor limit, and the utilization is relative to the current notional limit.
+func (s *Service) GetNotionalHistory(ctx context.Context, q *GovernorHistoryQuery) ([]*NotionalHistory, error) {
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 5*time.Minute, historyCacheKey(notionalHistory, q), s.metrics,
+		func() ([]*NotionalHistory, error) {
+			return s.getNotionalHistory(ctx, q)
+		})
+}
+
+func (s *Service) getNotionalHistory(ctx context.Context, q *GovernorHistoryQuery) ([]*NotionalHistory, error) {
+	rows, err := s.repo.GetDailyAvailableNotional(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	limitQuery := NewGovernorQuery()
+	limitQuery.Limit = 1000
+	limits, err := s.repo.GetGovernorLimit(ctx, limitQuery)
+	if err != nil {
+		return nil, err
+	}
+	notionalLimits := make(map[vaa.ChainID]uint64, len(limits))
+	for _, l := range limits {
+		notionalLimits[l.ChainID] = uint64(l.NotionalLimit)
+	}
+
+	// group the notional reported by the guardians by chain and day.
+	type chainDay struct {
+		chainID vaa.ChainID
+		date    time.Time
+	}
+	var keys []chainDay
+	notionals := make(map[chainDay][]uint64)
+	for _, r := range rows {
+		key := chainDay{chainID: r.ChainID, date: r.Date}
+		if _, ok := notionals[key]; !ok {
+			keys = append(keys, key)
+		}
+		notionals[key] = append(notionals[key], uint64(r.MinAvailableNotional))
+	}
+
+	result := make([]*NotionalHistory, 0, len(keys))
+	for _, key := range keys {
+		values := notionals[key]
+		sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+		available := values[min(minGuardianNum, len(values))-1]
+
+		h := &NotionalHistory{
+			ChainID:              key.chainID,
+			Date:                 key.date,
+			NotionalLimit:        notionalLimits[key.chainID],
+			MinAvailableNotional: available,
+		}
+		if h.NotionalLimit > 0 && available <= h.NotionalLimit {
+			h.MaxUtilization = float64(h.NotionalLimit-available) / float64(h.NotionalLimit) * 100
+		}
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+// GetEnqueuedVaaHistory get the enqueued and released vaas and the release latency percentiles of the chains.
+func (s *Service) GetEnqueuedVaaHistory(ctx context.Context, q *GovernorHistoryQuery) ([]*EnqueuedVaaHistory, error) {
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, 5*time.Minute, historyCacheKey(enqueuedVaaHistory, q), s.metrics,
+		func() ([]*EnqueuedVaaHistory, error) {
+			return s.getEnqueuedVaaHistory(ctx, q)
+		})
+}
+
+func (s *Service) getEnqueuedVaaHistory(ctx context.Context, q *GovernorHistoryQuery) ([]*EnqueuedVaaHistory, error) {
+	events, err := s.repo.GetGovernorVaaEvents(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make(map[vaa.ChainID]*EnqueuedVaaHistory)
+	latencies := make(map[vaa.ChainID][]int64)
+	daily := make(map[vaa.ChainID]map[time.Time]*EnqueuedVaaCount)
+	for _, e := range events {
+		h, ok := histories[e.ChainID]
+		if !ok {
+			h = &EnqueuedVaaHistory{ChainID: e.ChainID, Daily: []*EnqueuedVaaCount{}}
+			histories[e.ChainID] = h
+			daily[e.ChainID] = make(map[time.Time]*EnqueuedVaaCount)
+		}
+
+		h.Enqueued++
+		if e.ReleasedAt != nil {
+			h.Released++
+			latencies[e.ChainID] = append(latencies[e.ChainID], int64(e.ReleasedAt.Sub(e.EnqueuedAt).Seconds()))
+		} else {
+			h.Pending++
+		}
+
+		date := e.EnqueuedAt.UTC().Truncate(24 * time.Hour)
+		count, ok := daily[e.ChainID][date]
+		if !ok {
+			count = &EnqueuedVaaCount{Date: date}
+			daily[e.ChainID][date] = count
+			h.Daily = append(h.Daily, count)
+		}
+		count.Enqueued++
+	}
+
+	result := make([]*EnqueuedVaaHistory, 0, len(histories))
+	for chainID, h := range histories {
+		sort.Slice(h.Daily, func(i, j int) bool { return h.Daily[i].Date.Before(h.Daily[j].Date) })
+		if l := latencies[chainID]; len(l) > 0 {
+			sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+			h.ReleaseLatency = &ReleaseLatency{P50: utils.Percentile(l, 50), P90: utils.Percentile(l, 90), P99: utils.Percentile(l, 99)}
+		}
+		result = append(result, h)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ChainID < result[j].ChainID })
+	return result, nil
+}
+
+// governorDelay is the time the governor holds an enqueued vaa before releasing it.
+const governorDelay = 24 * time.Hour
+
+// SimulateTransfer simulates whether the governor of the guardians would delay a transfer.
+//
+// Each guardian values the transfer at the highest of the token price in its governor configuration and the
+// market price, holds for 24 hours the transfers bigger than the big transaction size of the chain, and enqueues
+// the transfers bigger than the available notional of the chain until enough notional is freed, up to 24 hours.
+// The transfer is delayed when less than a quorum of guardians would sign it immediately.
+func (s *Service) SimulateTransfer(ctx context.Context, q *GovernorSimulationQuery) (*GovernorSimulation, error) {
+	query := NewGovernorQuery()
+	query.Limit = 1000
+	configs, err := s.repo.FindGovConfigurations(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := s.repo.FindGovernorStatus(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// the notional used in the last 24 hours is freed during the next 24 hours.
+	now := time.Now().UTC()
+	history, err := s.repo.GetHourlyAvailableNotional(ctx, q.ChainID, now.Add(-governorDelay-time.Hour), now)
+	if err != nil {
+		return nil, err
+	}
+	historyByGuardian := make(map[string][]*HourlyAvailableNotional)
+	for _, h := range history {
+		historyByGuardian[h.NodeAddress] = append(historyByGuardian[h.NodeAddress], h)
+	}
+
+	availableByGuardian := make(map[string]uint64, len(statuses))
+	for _, status := range statuses {
+		for _, c := range status.Chains {
+			if c.ChainID == q.ChainID {
+				availableByGuardian[status.ID] = uint64(c.RemainingAvailableNotional)
+			}
+		}
+	}
+
+	result := &GovernorSimulation{
+		ChainID:      q.ChainID,
+		TokenChainID: q.TokenChainID,
+		TokenAddress: q.TokenAddress,
+		Amount:       q.Amount.String(),
+		MarketPrice:  s.marketPrice(q.TokenChainID, q.TokenAddress),
+		Quorum:       min(minGuardianNum, len(configs)),
+		Guardians:    make([]*GuardianSimulation, 0, len(configs)),
+	}
+	releases := make([]*GuardianSimulation, 0, len(configs))
+	for _, config := range configs {
+		available, ok := availableByGuardian[config.ID]
+		g := simulateGuardianTransfer(now, q, config, result.MarketPrice, available, ok, historyByGuardian[config.ID])
+		result.Governed = result.Governed || g.Governed
+		if g.Delayed {
+			result.GuardiansDelaying++
+		}
+		result.Guardians = append(result.Guardians, g)
+		releases = append(releases, g)
+	}
+
+	if result.Quorum == 0 || len(configs)-result.GuardiansDelaying >= result.Quorum {
+		return result, nil
+	}
+
+	// the transfer is released when the guardian with the quorum-th earliest release signs it.
+	sort.SliceStable(releases, func(i, j int) bool { return releases[i].EstimatedRelease < releases[j].EstimatedRelease })
+	quorumRelease := releases[result.Quorum-1]
+	result.Delayed = true
+	result.Reason = quorumRelease.Reason
+	result.ExpectedDelay = int64(governorDelay.Seconds())
+	result.EstimatedRelease = quorumRelease.EstimatedRelease
+	return result, nil
+}
+
+// marketPrice returns the current price of a token from the notional cache, or zero when it is unknown.
+func (s *Service) marketPrice(tokenChainID vaa.ChainID, tokenAddress string) float64 {
+	token, ok := s.tokenProvider.GetTokenByAddress(tokenChainID, tokenAddress)
+	if !ok {
+		return 0
+	}
+	priceData, err := s.notionalCache.Get(token.GetTokenID())
+	if err != nil {
+		return 0
+	}
+	price, _ := priceData.NotionalUsd.Float64()
+	return price
+}
+
+// simulateGuardianTransfer simulates the governor of a guardian for a transfer.
+// The guardians without a status for the chain are assumed to have the whole notional limit available.
+func simulateGuardianTransfer(
+	now time.Time,
+	q *GovernorSimulationQuery,
+	config *GovConfig,
+	marketPrice float64,
+	available uint64,
+	hasStatus bool,
+	history []*HourlyAvailableNotional,
+) *GuardianSimulation {
+
+	g := &GuardianSimulation{GuardianAddress: config.ID, NodeName: config.NodeName}
+
+	var chain *GovConfigChains
+	for _, c := range config.Chains {
+		if c.ChainID == q.ChainID {
+			chain = c
+		}
+	}
+	var token *GovConfigfTokens
+	for _, t := range config.Tokens {
+		if vaa.ChainID(t.OriginChainID) == q.TokenChainID && normalizeTokenAddress(t.OriginAddress) == q.TokenAddress {
+			token = t
+		}
+	}
+	if chain == nil || token == nil {
+		return g
+	}
+
+	g.Governed = true
+	g.Price = max(float64(token.Price), marketPrice)
+	g.NotionalValue = uint64(q.Amount.Mul(decimal.NewFromFloat(g.Price)).IntPart())
+	g.NotionalLimit = uint64(chain.NotionalLimit)
+	g.BigTransactionSize = uint64(chain.BigTransactionSize)
+	g.AvailableNotional = g.NotionalLimit
+	if hasStatus {
+		g.AvailableNotional = available
+	}
+
+	switch {
+	case g.BigTransactionSize > 0 && g.NotionalValue >= g.BigTransactionSize:
+		g.Delayed = true
+		g.Reason = DelayReasonBigTransaction
+		g.EstimatedRelease = int64(governorDelay.Seconds())
+	case g.NotionalValue > g.AvailableNotional:
+		g.Delayed = true
+		g.Reason = DelayReasonNotionalLimit
+		g.EstimatedRelease = int64(estimateRelease(now, g.NotionalValue-g.AvailableNotional, history).Seconds())
+	}
+	return g
+}
+
+// estimateRelease estimates the time until a guardian frees the notional needed to release an enqueued transfer.
+//
+// The notional used during an hour is estimated as the decrease of the available notional reported by the guardian
+// in the hour, and it is freed 24 hours later. The enqueued transfers are released after 24 hours anyway.
+func estimateRelease(now time.Time, needed uint64, history []*HourlyAvailableNotional) time.Duration {
+	var freed uint64
+	for i := 1; i < len(history); i++ {
+		prev, curr := uint64(history[i-1].AvailableNotional), uint64(history[i].AvailableNotional)
+		if prev > curr {
+			freed += prev - curr
+		}
+		if freed >= needed {
+			release := history[i].Hour.Add(time.Hour + governorDelay).Sub(now)
+			return min(max(release, 0), governorDelay)
+		}
+	}
+	return governorDelay
+}
+
+// normalizeTokenAddress converts the token addresses of the governor configurations into 32-byte hex strings.
+func normalizeTokenAddress(address string) string {
+	address = strings.ToLower(strings.TrimPrefix(address, "0x"))
+	if len(address) < 64 {
+		address = strings.Repeat("0", 64-len(address)) + address
+	}
+	return address
+}
@@ -0,0 +1,387 @@
+package governor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/mongo"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// stubGovernorRepository is a governor repository that returns fixed documents.
+type stubGovernorRepository struct {
+	governorRepository
+	notionals []*DailyAvailableNotional
+	limits    []*GovernorLimit
+	events    []*GovernorVaaEvent
+	configs   []*GovConfig
+	statuses  []*GovStatus
+	history   []*HourlyAvailableNotional
+}
+
+func (r *stubGovernorRepository) GetDailyAvailableNotional(context.Context, *GovernorHistoryQuery) ([]*DailyAvailableNotional, error) {
+	return r.notionals, nil
+}
+
+func (r *stubGovernorRepository) GetGovernorLimit(context.Context, *GovernorQuery) ([]*GovernorLimit, error) {
+	return r.limits, nil
+}
+
+func (r *stubGovernorRepository) GetGovernorVaaEvents(context.Context, *GovernorHistoryQuery) ([]*GovernorVaaEvent, error) {
+	return r.events, nil
+}
+
+func (r *stubGovernorRepository) FindGovConfigurations(context.Context, *GovernorQuery) ([]*GovConfig, error) {
+	return r.configs, nil
+}
+
+func (r *stubGovernorRepository) FindGovernorStatus(context.Context, *GovernorQuery) ([]*GovStatus, error) {
+	return r.statuses, nil
+}
+
+func (r *stubGovernorRepository) GetHourlyAvailableNotional(context.Context, vaa.ChainID, time.Time, time.Time) ([]*HourlyAvailableNotional, error) {
+	return r.history, nil
+}
+
+func newTestService(repo governorRepository) *Service {
+	return &Service{
+		repo:          repo,
+		cache:         cache.NewDummyCacheClient(),
+		tokenProvider: domain.NewTokenProvider(domain.P2pMainNet),
+		metrics:       metrics.NewNoOpMetrics(),
+		logger:        zap.NewNop(),
+	}
+}
+
+func TestGetNotionalHistory(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &stubGovernorRepository{
+		limits: []*GovernorLimit{{ChainID: vaa.ChainIDEthereum, NotionalLimit: 10000}},
+	}
+	// 14 guardians report the available notional of ethereum, the 13th highest is 2000.
+	for i := 1; i <= 14; i++ {
+		repo.notionals = append(repo.notionals, &DailyAvailableNotional{
+			ChainID:              vaa.ChainIDEthereum,
+			NodeAddress:          string(rune('a' + i)),
+			Date:                 day,
+			MinAvailableNotional: mongo.Uint64(i * 1000),
+		})
+	}
+	// solana is reported by fewer guardians than the quorum and has no limit.
+	repo.notionals = append(repo.notionals,
+		&DailyAvailableNotional{ChainID: vaa.ChainIDSolana, NodeAddress: "a", Date: day, MinAvailableNotional: 500},
+		&DailyAvailableNotional{ChainID: vaa.ChainIDSolana, NodeAddress: "b", Date: day, MinAvailableNotional: 300},
+	)
+
+	result, err := newTestService(repo).GetNotionalHistory(context.Background(), &GovernorHistoryQuery{From: day, To: day.Add(24 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, vaa.ChainIDEthereum, result[0].ChainID)
+	assert.Equal(t, day, result[0].Date)
+	assert.Equal(t, uint64(10000), result[0].NotionalLimit)
+	assert.Equal(t, uint64(2000), result[0].MinAvailableNotional)
+	assert.InDelta(t, 80, result[0].MaxUtilization, 0.001)
+
+	assert.Equal(t, vaa.ChainIDSolana, result[1].ChainID)
+	assert.Equal(t, uint64(0), result[1].NotionalLimit)
+	assert.Equal(t, uint64(300), result[1].MinAvailableNotional)
+	assert.Zero(t, result[1].MaxUtilization)
+}
+
+func TestGetEnqueuedVaaHistory(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := &stubGovernorRepository{}
+	// 10 ethereum vaas released after 100, 200, ..., 1000 seconds, the first 5 on the first day.
+	for i := 1; i <= 10; i++ {
+		enqueuedAt := day.Add(time.Duration(i) * 4 * time.Hour)
+		releasedAt := enqueuedAt.Add(time.Duration(i*100) * time.Second)
+		repo.events = append(repo.events, &GovernorVaaEvent{ChainID: vaa.ChainIDEthereum, EnqueuedAt: enqueuedAt, ReleasedAt: &releasedAt})
+	}
+	repo.events = append(repo.events,
+		&GovernorVaaEvent{ChainID: vaa.ChainIDEthereum, EnqueuedAt: day.Add(time.Hour)},
+		&GovernorVaaEvent{ChainID: vaa.ChainIDSolana, EnqueuedAt: day.Add(time.Hour)},
+	)
+
+	result, err := newTestService(repo).GetEnqueuedVaaHistory(context.Background(), &GovernorHistoryQuery{From: day, To: day.Add(48 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	solana := result[0]
+	assert.Equal(t, vaa.ChainIDSolana, solana.ChainID)
+	assert.Equal(t, 1, solana.Enqueued)
+	assert.Equal(t, 1, solana.Pending)
+	assert.Nil(t, solana.ReleaseLatency)
+	assert.Equal(t, []*EnqueuedVaaCount{{Date: day, Enqueued: 1}}, solana.Daily)
+
+	ethereum := result[1]
+	assert.Equal(t, vaa.ChainIDEthereum, ethereum.ChainID)
+	assert.Equal(t, 11, ethereum.Enqueued)
+	assert.Equal(t, 10, ethereum.Released)
+	assert.Equal(t, 1, ethereum.Pending)
+	assert.Equal(t, &ReleaseLatency{P50: 500, P90: 900, P99: 1000}, ethereum.ReleaseLatency)
+	assert.Equal(t, []*EnqueuedVaaCount{
+		{Date: day, Enqueued: 6},
+		{Date: day.Add(24 * time.Hour), Enqueued: 5},
+	}, ethereum.Daily)
+}
+
+func TestHistoryCacheKey(t *testing.T) {
+	to := time.Date(2024, 3, 1, 10, 5, 0, 0, time.UTC)
+	q := &GovernorHistoryQuery{From: to.Add(-30 * 24 * time.Hour), To: to}
+	key := historyCacheKey(notionalHistory, q)
+	assert.Equal(t, "wormscan:governor-notional-history:all:2024-01-31T10:00:00Z:2024-03-01T10:00:00Z", key)
+
+	// the queries in the same hour share the cache key.
+	later := to.Add(50 * time.Minute)
+	assert.Equal(t, key, historyCacheKey(notionalHistory, &GovernorHistoryQuery{From: later.Add(-30 * 24 * time.Hour), To: later}))
+
+	nextHour := to.Add(time.Hour)
+	assert.NotEqual(t, key, historyCacheKey(notionalHistory, &GovernorHistoryQuery{From: nextHour.Add(-30 * 24 * time.Hour), To: nextHour}))
+
+	chainID := vaa.ChainIDSolana
+	assert.Equal(t, "wormscan:governor-notional-history:solana:2024-01-31T10:00:00Z:2024-03-01T10:00:00Z",
+		historyCacheKey(notionalHistory, &GovernorHistoryQuery{ChainID: &chainID, From: q.From, To: q.To}))
+}
+
+// simulatedToken is the token of the simulated transfers, unknown to the token registry so that it has no market price.
+const simulatedToken = "00000000000000000000000000000000000000000000000000000000000abcde"
+
+func simulationConfig(id string, notionalLimit, bigTransactionSize uint64, price float32) *GovConfig {
+	return &GovConfig{
+		ID:     id,
+		Chains: []*GovConfigChains{{ChainID: vaa.ChainIDEthereum, NotionalLimit: mongo.Uint64(notionalLimit), BigTransactionSize: mongo.Uint64(bigTransactionSize)}},
+		Tokens: []*GovConfigfTokens{{OriginChainID: int(vaa.ChainIDEthereum), OriginAddress: "0xABCDE", Price: price}},
+	}
+}
+
+func TestSimulateGuardianTransfer(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	q := &GovernorSimulationQuery{
+		ChainID:      vaa.ChainIDEthereum,
+		TokenChainID: vaa.ChainIDEthereum,
+		TokenAddress: simulatedToken,
+		Amount:       decimal.NewFromInt(100),
+	}
+	// the guardian used 600 of notional in the hour 24 hours ago, which is freed 30 minutes from now.
+	history := []*HourlyAvailableNotional{
+		{Hour: now.Add(-25 * time.Hour).Truncate(time.Hour), AvailableNotional: 1000},
+		{Hour: now.Add(-24 * time.Hour).Truncate(time.Hour), AvailableNotional: 400},
+	}
+
+	tests := []struct {
+		name        string
+		config      *GovConfig
+		marketPrice float64
+		available   uint64
+		hasStatus   bool
+		history     []*HourlyAvailableNotional
+		expected    *GuardianSimulation
+	}{
+		{
+			name:     "chain not governed",
+			config:   &GovConfig{ID: "g", Tokens: simulationConfig("g", 0, 0, 1).Tokens},
+			expected: &GuardianSimulation{GuardianAddress: "g"},
+		},
+		{
+			name:     "token not governed",
+			config:   &GovConfig{ID: "g", Chains: simulationConfig("g", 10000, 0, 1).Chains},
+			expected: &GuardianSimulation{GuardianAddress: "g"},
+		},
+		{
+			name:        "configured price higher than the market price",
+			config:      simulationConfig("g", 10000, 0, 10),
+			marketPrice: 2,
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 10, NotionalValue: 1000,
+				NotionalLimit: 10000, AvailableNotional: 10000},
+		},
+		{
+			name:        "market price higher than the configured price",
+			config:      simulationConfig("g", 10000, 0, 1),
+			marketPrice: 20,
+			available:   5000,
+			hasStatus:   true,
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 20, NotionalValue: 2000,
+				NotionalLimit: 10000, AvailableNotional: 5000},
+		},
+		{
+			name:   "big transaction",
+			config: simulationConfig("g", 10000, 1000, 10),
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 10, NotionalValue: 1000,
+				NotionalLimit: 10000, BigTransactionSize: 1000, AvailableNotional: 10000,
+				Delayed: true, Reason: DelayReasonBigTransaction, EstimatedRelease: 86400},
+		},
+		{
+			name:      "big transaction takes precedence over the notional limit",
+			config:    simulationConfig("g", 10000, 500, 10),
+			hasStatus: true,
+			history:   history,
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 10, NotionalValue: 1000,
+				NotionalLimit: 10000, BigTransactionSize: 500,
+				Delayed: true, Reason: DelayReasonBigTransaction, EstimatedRelease: 86400},
+		},
+		{
+			name:      "notional limit",
+			config:    simulationConfig("g", 10000, 0, 10),
+			available: 500,
+			hasStatus: true,
+			history:   history,
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 10, NotionalValue: 1000,
+				NotionalLimit: 10000, AvailableNotional: 500,
+				Delayed: true, Reason: DelayReasonNotionalLimit, EstimatedRelease: 1800},
+		},
+		{
+			name:   "notional limit without status",
+			config: simulationConfig("g", 800, 0, 10),
+			expected: &GuardianSimulation{GuardianAddress: "g", Governed: true, Price: 10, NotionalValue: 1000,
+				NotionalLimit: 800, AvailableNotional: 800,
+				Delayed: true, Reason: DelayReasonNotionalLimit, EstimatedRelease: 86400},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := simulateGuardianTransfer(now, q, tt.config, tt.marketPrice, tt.available, tt.hasStatus, tt.history)
+			assert.Equal(t, tt.expected, g)
+		})
+	}
+}
+
+func TestEstimateRelease(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	hour := func(hoursAgo int) time.Time {
+		return now.Truncate(time.Hour).Add(-time.Duration(hoursAgo) * time.Hour)
+	}
+
+	tests := []struct {
+		name     string
+		needed   uint64
+		history  []*HourlyAvailableNotional
+		expected time.Duration
+	}{
+		{
+			name:     "no history",
+			needed:   100,
+			expected: 24 * time.Hour,
+		},
+		{
+			name:   "notional freed in the next hour",
+			needed: 100,
+			history: []*HourlyAvailableNotional{
+				{Hour: hour(25), AvailableNotional: 1000},
+				{Hour: hour(24), AvailableNotional: 800},
+			},
+			expected: 30 * time.Minute,
+		},
+		{
+			name:   "notional freed over several hours",
+			needed: 300,
+			history: []*HourlyAvailableNotional{
+				{Hour: hour(25), AvailableNotional: 1000},
+				{Hour: hour(24), AvailableNotional: 800},
+				{Hour: hour(23), AvailableNotional: 900},
+				{Hour: hour(22), AvailableNotional: 700},
+			},
+			expected: 150 * time.Minute,
+		},
+		{
+			name:   "notional already freed",
+			needed: 100,
+			history: []*HourlyAvailableNotional{
+				{Hour: hour(27), AvailableNotional: 1000},
+				{Hour: hour(26), AvailableNotional: 500},
+			},
+			expected: 0,
+		},
+		{
+			name:   "not enough notional freed",
+			needed: 1000,
+			history: []*HourlyAvailableNotional{
+				{Hour: hour(25), AvailableNotional: 1000},
+				{Hour: hour(24), AvailableNotional: 800},
+			},
+			expected: 24 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, estimateRelease(now, tt.needed, tt.history))
+		})
+	}
+}
+
+func TestSimulateTransfer_QuorumRelease(t *testing.T) {
+	now := time.Now().UTC()
+	repo := &stubGovernorRepository{}
+	// 6 guardians sign the transfer immediately.
+	for i := 0; i < 6; i++ {
+		repo.configs = append(repo.configs, simulationConfig(fmt.Sprintf("free-%d", i), 10000, 0, 10))
+	}
+	// 7 guardians, listed from the latest to the earliest release, free the notional in 7, 6, ..., 1 hours.
+	for i := 7; i >= 1; i-- {
+		id := fmt.Sprintf("limited-%d", i)
+		repo.configs = append(repo.configs, simulationConfig(id, 10000, 0, 10))
+		repo.statuses = append(repo.statuses, &GovStatus{ID: id, Chains: []*GovStatusChains{{ChainID: vaa.ChainIDEthereum}}})
+		repo.history = append(repo.history,
+			&HourlyAvailableNotional{NodeAddress: id, Hour: now.Add(-26 * time.Hour), AvailableNotional: 5000},
+			&HourlyAvailableNotional{NodeAddress: id, Hour: now.Add(time.Duration(i-25) * time.Hour), AvailableNotional: 0},
+		)
+	}
+	// 2 guardians hold the transfer as a big transaction.
+	for i := 0; i < 2; i++ {
+		repo.configs = append(repo.configs, simulationConfig(fmt.Sprintf("big-%d", i), 10000, 500, 10))
+	}
+
+	result, err := newTestService(repo).SimulateTransfer(context.Background(), &GovernorSimulationQuery{
+		ChainID:      vaa.ChainIDEthereum,
+		TokenChainID: vaa.ChainIDEthereum,
+		TokenAddress: simulatedToken,
+		Amount:       decimal.NewFromInt(100),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.Governed)
+	assert.True(t, result.Delayed)
+	assert.Equal(t, 13, result.Quorum)
+	assert.Equal(t, 9, result.GuardiansDelaying)
+	assert.Len(t, result.Guardians, 15)
+	// the 13th earliest release is the latest release of the guardians limited by the notional.
+	assert.Equal(t, DelayReasonNotionalLimit, result.Reason)
+	assert.InDelta(t, (7 * time.Hour).Seconds(), result.EstimatedRelease, 60)
+	assert.Equal(t, int64(86400), result.ExpectedDelay)
+}
+
+func TestSimulateTransfer_NotDelayed(t *testing.T) {
+	repo := &stubGovernorRepository{}
+	for i := 0; i < 15; i++ {
+		bigTransactionSize := uint64(0)
+		if i < 2 {
+			bigTransactionSize = 500
+		}
+		repo.configs = append(repo.configs, simulationConfig(fmt.Sprintf("guardian-%d", i), 10000, bigTransactionSize, 10))
+	}
+
+	result, err := newTestService(repo).SimulateTransfer(context.Background(), &GovernorSimulationQuery{
+		ChainID:      vaa.ChainIDEthereum,
+		TokenChainID: vaa.ChainIDEthereum,
+		TokenAddress: simulatedToken,
+		Amount:       decimal.NewFromInt(100),
+	})
+	require.NoError(t, err)
+
+	// 13 guardians sign the transfer immediately.
+	assert.True(t, result.Governed)
+	assert.False(t, result.Delayed)
+	assert.Equal(t, 2, result.GuardiansDelaying)
+	assert.Zero(t, result.EstimatedRelease)
+}
@@ -3,21 +3,25 @@ package operations
 import (
 	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
 // OperationDto operation data transfer object.
 type OperationDto struct {
-	ID                     string                  `bson:"_id"`
-	TxHash                 string                  `bson:"txHash"`
-	Symbol                 string                  `bson:"symbol"`
-	UsdAmount              string                  `bson:"usdAmount"`
-	TokenAmount            string                  `bson:"tokenAmount"`
-	Vaa                    *VaaDto                 `bson:"vaa"`
-	SourceTx               *OriginTx               `bson:"originTx" json:"originTx"`
-	DestinationTx          *DestinationTx          `bson:"destinationTx" json:"destinationTx"`
-	Payload                map[string]any          `bson:"payload"`
-	StandardizedProperties *StandardizedProperties `bson:"standardizedProperties"`
+	ID                     string                         `bson:"_id"`
+	TxHash                 string                         `bson:"txHash"`
+	Symbol                 string                         `bson:"symbol"`
+	UsdAmount              string                         `bson:"usdAmount"`
+	TokenAmount            string                         `bson:"tokenAmount"`
+	Vaa                    *VaaDto                        `bson:"vaa"`
+	SourceTx               *OriginTx                      `bson:"originTx" json:"originTx"`
+	DestinationTx          *DestinationTx                 `bson:"destinationTx" json:"destinationTx"`
+	Payload                map[string]any                 `bson:"payload"`
+	StandardizedProperties *StandardizedProperties        `bson:"standardizedProperties"`
+	Status                 string                         `bson:"status"`
+	Lifecycle              *repository.OperationLifecycle `bson:"lifecycle"`
+	Governor               *GovernorVaaDto                `bson:"governor"`
 }
 
 // StandardizedProperties represents the standardized properties of a operation.
@@ -35,6 +39,12 @@ type StandardizedProperties struct {
 	Fee          string      `json:"fee" bson:"fee"`
 }
 
+// GovernorVaaDto is the governor data of an enqueued vaa.
+type GovernorVaaDto struct {
+	ReleaseTime       time.Time  `bson:"releaseTime"`
+	QuorumReleaseTime *time.Time `bson:"quorumReleaseTime"`
+}
+
 // VaaDto vaa data transfer object.
 type VaaDto struct {
 	ID                string      `bson:"_id" json:"id"`
@@ -9,11 +9,15 @@ import (
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +29,8 @@ type Repository struct {
 		vaas               *mongo.Collection
 		parsedVaa          *mongo.Collection
 		globalTransactions *mongo.Collection
+		stuckOperations    *mongo.Collection
+		operationsSla      *mongo.Collection
 	}
 }
 
@@ -36,10 +42,14 @@ func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
 			vaas               *mongo.Collection
 			parsedVaa          *mongo.Collection
 			globalTransactions *mongo.Collection
+			stuckOperations    *mongo.Collection
+			operationsSla      *mongo.Collection
 		}{
 			vaas:               db.Collection("vaas"),
 			parsedVaa:          db.Collection("parsedVaa"),
 			globalTransactions: db.Collection("globalTransactions"),
+			stuckOperations:    db.Collection(repository.StuckOperations),
+			operationsSla:      db.Collection(repository.OperationsSla),
 		},
 	}
 }
@@ -64,6 +74,9 @@ func (r *Repository) FindById(ctx context.Context, id string) (*OperationDto, er
 	// lookup parsedVaa
 	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "parsedVaa"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "parsedVaa"}}}})
 
+	// lookup governorVaas
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: repository.GovernorVaas}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "governorVaas"}}}})
+
 	// add fields
 	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
 		{Key: "payload", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.parsedPayload", 0}}}},
@@ -72,10 +85,11 @@ func (r *Repository) FindById(ctx context.Context, id string) (*OperationDto, er
 		{Key: "symbol", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.symbol", 0}}}},
 		{Key: "usdAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.usdAmount", 0}}}},
 		{Key: "tokenAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.tokenAmount", 0}}}},
+		{Key: "governor", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$governorVaas", 0}}}},
 	}}})
 
 	// unset
-	pipeline = append(pipeline, bson.D{{Key: "$unset", Value: bson.A{"transferPrices", "parsedVaa"}}})
+	pipeline = append(pipeline, bson.D{{Key: "$unset", Value: bson.A{"transferPrices", "parsedVaa", "governorVaas"}}})
 
 	// Execute the aggregation pipeline
 	cur, err := r.collections.globalTransactions.Aggregate(ctx, pipeline)
@@ -120,6 +134,7 @@ type OperationQuery struct {
 	PayloadType    []int
 	From           *time.Time
 	To             *time.Time
+	Statuses       []domain.OperationStatus
 }
 
 func buildQueryOperationsByChain(sourceChainIDs, targetChainIDs []vaa.ChainID) bson.D {
@@ -257,30 +272,7 @@ func (r *Repository) FindFromParsedVaa(ctx context.Context, query OperationQuery
 
 func BuildPipelineSearchFromParsedVaa(query OperationQuery) mongo.Pipeline {
 
-	var pipeline mongo.Pipeline
-
-	if len(query.PayloadType) > 0 {
-		payloadTypeFilter := bson.D{{Key: "$match", Value: bson.M{"parsedPayload.payloadType": bson.M{"$in": query.PayloadType}}}}
-		pipeline = append(pipeline, payloadTypeFilter)
-	}
-
-	if len(query.SourceChainIDs) > 0 || len(query.TargetChainIDs) > 0 {
-		matchBySourceTargetChain := buildQueryOperationsByChain(query.SourceChainIDs, query.TargetChainIDs)
-		pipeline = append(pipeline, matchBySourceTargetChain)
-	}
-
-	if len(query.AppIDs) > 0 {
-		matchByAppId := buildQueryOperationsByAppID(query.AppIDs, query.ExclusiveAppId)
-		pipeline = append(pipeline, matchByAppId)
-	}
-
-	if query.From != nil {
-		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": query.From}}}})
-	}
-
-	if query.To != nil {
-		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$lte": query.To}}}})
-	}
+	pipeline := buildParsedVaaFilters(query)
 
 	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{
 		bson.E{Key: "timestamp", Value: query.Pagination.GetSortInt()},
@@ -298,7 +290,9 @@ func BuildPipelineSearchFromParsedVaa(query OperationQuery) mongo.Pipeline {
 	// lookup transferPrices
 	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "transferPrices"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "transferPrices"}}}})
 
-	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}})
+	if len(query.Statuses) == 0 {
+		pipeline = append(pipeline, lookupGlobalTransactions())
+	}
 
 	// add fields
 	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
@@ -309,6 +303,8 @@ func BuildPipelineSearchFromParsedVaa(query OperationQuery) mongo.Pipeline {
 		{Key: "tokenAmount", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices.tokenAmount", 0}}}},
 		{Key: "originTx", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.originTx", 0}}}},
 		{Key: "destinationTx", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx", 0}}}},
+		{Key: "status", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.status", 0}}}},
+		{Key: "lifecycle", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.lifecycle", 0}}}},
 	}}})
 
 	// unset
@@ -316,6 +312,47 @@ func BuildPipelineSearchFromParsedVaa(query OperationQuery) mongo.Pipeline {
 	return pipeline
 }
 
+// buildParsedVaaFilters returns the stages that filter the parsedVaa collection by query.
+func buildParsedVaaFilters(query OperationQuery) mongo.Pipeline {
+
+	var pipeline mongo.Pipeline
+
+	if len(query.PayloadType) > 0 {
+		payloadTypeFilter := bson.D{{Key: "$match", Value: bson.M{"parsedPayload.payloadType": bson.M{"$in": query.PayloadType}}}}
+		pipeline = append(pipeline, payloadTypeFilter)
+	}
+
+	if len(query.SourceChainIDs) > 0 || len(query.TargetChainIDs) > 0 {
+		matchBySourceTargetChain := buildQueryOperationsByChain(query.SourceChainIDs, query.TargetChainIDs)
+		pipeline = append(pipeline, matchBySourceTargetChain)
+	}
+
+	if len(query.AppIDs) > 0 {
+		matchByAppId := buildQueryOperationsByAppID(query.AppIDs, query.ExclusiveAppId)
+		pipeline = append(pipeline, matchByAppId)
+	}
+
+	if query.From != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$gte": query.From}}}})
+	}
+
+	if query.To != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"timestamp": bson.M{"$lte": query.To}}}})
+	}
+
+	// the status is stored in the globalTransactions collection, so it must be looked up before paginating.
+	if len(query.Statuses) > 0 {
+		pipeline = append(pipeline, lookupGlobalTransactions())
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"globalTransactions.status": bson.M{"$in": query.Statuses}}}})
+	}
+
+	return pipeline
+}
+
+func lookupGlobalTransactions() bson.D {
+	return bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "globalTransactions"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}}
+}
+
 // FindAll returns all operations filtered by q.
 func (r *Repository) FindAll(ctx context.Context, query OperationQuery) ([]*OperationDto, error) {
 
@@ -346,6 +383,10 @@ func (r *Repository) FindAll(ctx context.Context, query OperationQuery) ([]*Oper
 		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"originTx.timestamp": bson.M{"$lte": query.To}}}})
 	}
 
+	if len(query.Statuses) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"status": bson.M{"$in": query.Statuses}}}})
+	}
+
 	// sort
 	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{
 		bson.E{Key: "originTx.timestamp", Value: query.Pagination.GetSortInt()},
@@ -400,3 +441,228 @@ func (r *Repository) FindAll(ctx context.Context, query OperationQuery) ([]*Oper
 
 	return operations, nil
 }
+
+// OperationExportQuery is a query of the operations export.
+type OperationExportQuery struct {
+	OperationQuery
+	// Cursor is the position of the last exported operation, nil to start from the latest one.
+	Cursor *export.Cursor
+	// Until is the position of the last operation of the export, nil to export every operation after Cursor.
+	Until *export.Cursor
+	Limit int64
+}
+
+// buildExportFilters returns the stages that filter the parsedVaa collection by the export query,
+// starting after the cursor, up to the until cursor, and sorted from the latest operation.
+func (r *Repository) buildExportFilters(ctx context.Context, query OperationExportQuery) (mongo.Pipeline, error) {
+
+	pipeline := buildParsedVaaFilters(query.OperationQuery)
+
+	if query.Address != "" {
+		ids, err := findOperationsIdByAddress(ctx, r.db, query.Address, nil)
+		if err != nil {
+			return nil, err
+		}
+		if ids == nil {
+			ids = []string{}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": ids}}}})
+	}
+
+	if query.Cursor != nil {
+		pipeline = append(pipeline, query.Cursor.Match("timestamp"))
+	}
+	if query.Until != nil {
+		pipeline = append(pipeline, query.Until.MatchUntil("timestamp"))
+	}
+
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{
+		bson.E{Key: "timestamp", Value: -1},
+		bson.E{Key: "_id", Value: -1},
+	}}})
+	return pipeline, nil
+}
+
+// BuildExportProjection returns the stages that project the operations in the transfer report column set.
+func BuildExportProjection(query OperationExportQuery) mongo.Pipeline {
+
+	var pipeline mongo.Pipeline
+
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "vaas"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "vaas"}}}})
+
+	// lookup transferPrices
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.D{{Key: "from", Value: "transferPrices"}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "transferPrices"}}}})
+
+	if len(query.Statuses) == 0 {
+		pipeline = append(pipeline, lookupGlobalTransactions())
+	}
+
+	// add nested fields
+	pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.D{
+		{Key: "vaa", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$vaas", 0}}}},
+		{Key: "transferPrices", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$transferPrices", 0}}}},
+		{Key: "globalTransactions", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions", 0}}}},
+	}}})
+
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.D{
+		{Key: "vaaId", Value: "$_id"},
+		{Key: "vaaHash", Value: "$vaa.txHash"},
+		{Key: "sourceChain", Value: "$emitterChain"},
+		{Key: "emitterAddress", Value: "$emitterAddr"},
+		{Key: "sequence", Value: "$sequence"},
+		{Key: "timestamp", Value: "$timestamp"},
+		{Key: "sourceTxHash", Value: "$globalTransactions.originTx.nativeTxHash"},
+		{Key: "sourceSenderAddress", Value: "$globalTransactions.originTx.from"},
+		{Key: "destinationChain", Value: "$rawStandardizedProperties.toChain"},
+		{Key: "destinationAddress", Value: "$rawStandardizedProperties.toAddress"},
+		{Key: "destinationTxHash", Value: "$globalTransactions.destinationTx.txHash"},
+		{Key: "portalPayloadType", Value: "$parsedPayload.payloadType"},
+		{Key: "appIds", Value: "$appIds"},
+		{Key: "tokenChain", Value: "$rawStandardizedProperties.tokenChain"},
+		{Key: "tokenAddress", Value: "$rawStandardizedProperties.tokenAddress"},
+		{Key: "amount", Value: "$transferPrices.tokenAmount"},
+		{Key: "notionalUSD", Value: "$transferPrices.usdAmount"},
+		{Key: "fee", Value: "$rawStandardizedProperties.fee"},
+		{Key: "coinGeckoId", Value: "$transferPrices.coinGeckoId"},
+		{Key: "symbol", Value: "$transferPrices.symbol"},
+	}}})
+
+	return pipeline
+}
+
+// Export returns a cursor over the operations of the export query, in the transfer report column set.
+// The operations are bounded by the until cursor of the query instead of its limit, so the operations
+// indexed after FindExportNextCursor are neither skipped nor repeated by the next export.
+// The caller must close the cursor.
+func (r *Repository) Export(ctx context.Context, query OperationExportQuery) (*mongo.Cursor, error) {
+
+	pipeline, err := r.buildExportFilters(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to build export pipeline", zap.Error(err))
+		return nil, err
+	}
+	pipeline = append(pipeline, BuildExportProjection(query)...)
+
+	cur, err := r.collections.parsedVaa.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		r.logger.Error("failed execute export aggregation pipeline", zap.Error(err))
+		return nil, err
+	}
+	return cur, nil
+}
+
+// FindExportNextCursor returns the position of the last operation of the export query, to resume the export
+// after it, or nil if there are no more operations to export.
+func (r *Repository) FindExportNextCursor(ctx context.Context, query OperationExportQuery) (*export.Cursor, error) {
+
+	pipeline, err := r.buildExportFilters(ctx, query)
+	if err != nil {
+		r.logger.Error("failed to build export pipeline", zap.Error(err))
+		return nil, err
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$skip", Value: query.Limit - 1}})
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: 2}})
+	pipeline = append(pipeline, bson.D{{Key: "$project", Value: bson.D{{Key: "timestamp", Value: 1}}}})
+
+	cur, err := r.collections.parsedVaa.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		r.logger.Error("failed execute export aggregation pipeline", zap.Error(err))
+		return nil, err
+	}
+
+	var docs []struct {
+		ID        string    `bson:"_id"`
+		Timestamp time.Time `bson:"timestamp"`
+	}
+	err = cur.All(ctx, &docs)
+	if err != nil {
+		r.logger.Error("failed to decode cursor", zap.Error(err))
+		return nil, err
+	}
+
+	// the last operation of the export is only followed by more operations if the page is full.
+	if len(docs) < 2 {
+		return nil, nil
+	}
+	return &export.Cursor{Timestamp: docs[0].Timestamp, ID: docs[0].ID}, nil
+}
+
+type StuckOperationQuery struct {
+	Pagination     pagination.Pagination
+	SourceChainIDs []vaa.ChainID
+	TargetChainIDs []vaa.ChainID
+	AppIDs         []string
+	Causes         []domain.StuckCause
+}
+
+// BuildStuckOperationsFilter returns the filter of the stuckOperations collection for the query.
+func BuildStuckOperationsFilter(query StuckOperationQuery) bson.D {
+	filter := bson.D{}
+	if len(query.SourceChainIDs) > 0 {
+		filter = append(filter, bson.E{Key: "emitterChain", Value: bson.M{"$in": query.SourceChainIDs}})
+	}
+	if len(query.TargetChainIDs) > 0 {
+		// the target chain of the operations enqueued by the governor is unknown until their VAA is signed.
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.M{"targetChain": bson.M{"$in": query.TargetChainIDs}},
+			bson.M{"cause": domain.StuckCauseGovernorEnqueued, "targetChain": vaa.ChainIDUnset},
+		}})
+	}
+	if len(query.AppIDs) > 0 {
+		filter = append(filter, bson.E{Key: "appIds", Value: bson.M{"$in": query.AppIDs}})
+	}
+	if len(query.Causes) > 0 {
+		filter = append(filter, bson.E{Key: "cause", Value: bson.M{"$in": query.Causes}})
+	}
+	return filter
+}
+
+// FindStuck returns the operations that were not redeemed on the target chain, filtered by query.
+func (r *Repository) FindStuck(ctx context.Context, query StuckOperationQuery) ([]*repository.StuckOperationDoc, error) {
+
+	opts := options.Find().
+		SetSort(bson.D{
+			{Key: "stuckSince", Value: query.Pagination.GetSortInt()},
+			{Key: "_id", Value: -1},
+		}).
+		SetSkip(query.Pagination.Skip).
+		SetLimit(query.Pagination.Limit)
+
+	cur, err := r.collections.stuckOperations.Find(ctx, BuildStuckOperationsFilter(query), opts)
+	if err != nil {
+		r.logger.Error("failed to find stuck operations", zap.Error(err))
+		return nil, err
+	}
+
+	stuck := []*repository.StuckOperationDoc{}
+	err = cur.All(ctx, &stuck)
+	if err != nil {
+		r.logger.Error("failed to decode cursor", zap.Error(err))
+		return nil, err
+	}
+	return stuck, nil
+}
+
+// FindSla returns the time to redeem percentiles. If groupBy is empty, all the groups are returned.
+func (r *Repository) FindSla(ctx context.Context, groupBy string) ([]*repository.OperationSlaDoc, error) {
+
+	filter := bson.D{}
+	if groupBy != "" {
+		filter = append(filter, bson.E{Key: "groupBy", Value: groupBy})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "groupBy", Value: 1}, {Key: "key", Value: 1}})
+
+	cur, err := r.collections.operationsSla.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("failed to find operations sla", zap.Error(err))
+		return nil, err
+	}
+
+	sla := []*repository.OperationSlaDoc{}
+	err = cur.All(ctx, &sla)
+	if err != nil {
+		r.logger.Error("failed to decode cursor", zap.Error(err))
+		return nil, err
+	}
+	return sla, nil
+}
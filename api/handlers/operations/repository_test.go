@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -39,6 +40,8 @@ var addFieldsStage = bson.D{{"$addFields", bson.D{
 	{"tokenAmount", bson.D{{"$arrayElemAt", bson.A{"$transferPrices.tokenAmount", 0}}}},
 	{"originTx", bson.D{{"$arrayElemAt", bson.A{"$globalTransactions.originTx", 0}}}},
 	{"destinationTx", bson.D{{"$arrayElemAt", bson.A{"$globalTransactions.destinationTx", 0}}}},
+	{"status", bson.D{{"$arrayElemAt", bson.A{"$globalTransactions.status", 0}}}},
+	{"lifecycle", bson.D{{"$arrayElemAt", bson.A{"$globalTransactions.lifecycle", 0}}}},
 }}}
 var unSetStage = bson.D{{"$unset", bson.A{"transferPrices"}}}
 
@@ -258,6 +261,30 @@ func TestPipeline_FindByChainAndAppId(t *testing.T) {
 				unSetStage,
 			},
 		},
+		{
+			name: "Search by status",
+			query: operations.OperationQuery{
+				TargetChainIDs: []sdk.ChainID{2},
+				Statuses:       []domain.OperationStatus{domain.OperationStatusVaaSigned, domain.OperationStatusTargetFailed},
+			},
+			expected: mongo.Pipeline{
+				bson.D{{"$match", bson.M{"$and": bson.A{
+					bson.M{"rawStandardizedProperties.toChain": bson.M{"$in": []sdk.ChainID{2}}},
+				}}}},
+				lookupGlobalTransactionsStage,
+				bson.D{{"$match", bson.M{"globalTransactions.status": bson.M{"$in": []domain.OperationStatus{
+					domain.OperationStatusVaaSigned,
+					domain.OperationStatusTargetFailed,
+				}}}}},
+				sortStage,
+				skipStage,
+				limitStage,
+				lookupVaasStage,
+				lookupTransferPricesStage,
+				addFieldsStage,
+				unSetStage,
+			},
+		},
 	}
 
 	for _, testCase := range cases {
@@ -267,3 +294,19 @@ func TestPipeline_FindByChainAndAppId(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildStuckOperationsFilter(t *testing.T) {
+	query := operations.StuckOperationQuery{
+		TargetChainIDs: []sdk.ChainID{sdk.ChainIDEthereum},
+		Causes:         []domain.StuckCause{domain.StuckCauseRedeemFailed},
+	}
+	expected := bson.D{
+		{"$or", bson.A{
+			bson.M{"targetChain": bson.M{"$in": []sdk.ChainID{sdk.ChainIDEthereum}}},
+			bson.M{"cause": domain.StuckCauseGovernorEnqueued, "targetChain": sdk.ChainIDUnset},
+		}},
+		{"cause", bson.M{"$in": []domain.StuckCause{domain.StuckCauseRedeemFailed}}},
+	}
+	assert.Equal(t, expected, operations.BuildStuckOperationsFilter(query))
+	assert.Equal(t, bson.D{}, operations.BuildStuckOperationsFilter(operations.StuckOperationQuery{}))
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	commonexport "github.com/wormhole-foundation/wormhole-explorer/common/export"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -43,6 +47,7 @@ type OperationFilter struct {
 	PayloadType    []int
 	From           *time.Time
 	To             *time.Time
+	Statuses       []domain.OperationStatus
 }
 
 // FindAll returns all operations filtered by q.
@@ -63,6 +68,7 @@ func (s *Service) FindAll(ctx context.Context, filter OperationFilter) ([]*Opera
 		PayloadType:    filter.PayloadType,
 		From:           filter.From,
 		To:             filter.To,
+		Statuses:       filter.Statuses,
 	}
 
 	if len(operationQuery.AppIDs) != 0 || len(operationQuery.SourceChainIDs) > 0 || len(operationQuery.TargetChainIDs) > 0 || len(operationQuery.PayloadType) > 0 {
@@ -75,3 +81,76 @@ func (s *Service) FindAll(ctx context.Context, filter OperationFilter) ([]*Opera
 	}
 	return operations, nil
 }
+
+// ExportFilter is the filter of the operations export.
+type ExportFilter struct {
+	Address        string
+	SourceChainIDs []vaa.ChainID
+	TargetChainIDs []vaa.ChainID
+	AppIDs         []string
+	ExclusiveAppId bool
+	PayloadType    []int
+	From           *time.Time
+	To             *time.Time
+	Statuses       []domain.OperationStatus
+	Cursor         *export.Cursor
+	Limit          int64
+}
+
+// Export returns the operations filtered by filter in the transfer report column set, and the cursor
+// to resume the export after them. The cursor is nil if there are no more operations to export.
+func (s *Service) Export(ctx context.Context, filter ExportFilter) (export.Source, *export.Cursor, error) {
+	query := OperationExportQuery{
+		OperationQuery: OperationQuery{
+			Address:        filter.Address,
+			SourceChainIDs: filter.SourceChainIDs,
+			TargetChainIDs: filter.TargetChainIDs,
+			AppIDs:         filter.AppIDs,
+			ExclusiveAppId: filter.ExclusiveAppId,
+			PayloadType:    filter.PayloadType,
+			From:           filter.From,
+			To:             filter.To,
+			Statuses:       filter.Statuses,
+		},
+		Cursor: filter.Cursor,
+		Limit:  filter.Limit,
+	}
+
+	next, err := s.repo.FindExportNextCursor(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the export stops at the next cursor, so it ends at the same operation as the next export starts.
+	query.Until = next
+	cur, err := s.repo.Export(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return export.NewCursorSource[commonexport.TransferRecord](cur), next, nil
+}
+
+type StuckOperationFilter struct {
+	SourceChainIDs []vaa.ChainID
+	TargetChainIDs []vaa.ChainID
+	AppIDs         []string
+	Causes         []domain.StuckCause
+	Pagination     pagination.Pagination
+}
+
+// FindStuck returns the operations that were not redeemed on the target chain.
+func (s *Service) FindStuck(ctx context.Context, filter StuckOperationFilter) ([]*repository.StuckOperationDoc, error) {
+	query := StuckOperationQuery{
+		Pagination:     filter.Pagination,
+		SourceChainIDs: filter.SourceChainIDs,
+		TargetChainIDs: filter.TargetChainIDs,
+		AppIDs:         filter.AppIDs,
+		Causes:         filter.Causes,
+	}
+	return s.repo.FindStuck(ctx, query)
+}
+
+// FindSla returns the time to redeem percentiles grouped by target chain and app id.
+func (s *Service) FindSla(ctx context.Context, groupBy string) ([]*repository.OperationSlaDoc, error) {
+	return s.repo.FindSla(ctx, groupBy)
+}
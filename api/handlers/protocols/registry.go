@@ -0,0 +1,102 @@
+package protocols
+
+import (
+	"sort"
+	"strings"
+
+	commonProtocols "github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+)
+
+// StatsSource is the source of the totals of a protocol.
+type StatsSource int
+
+const (
+	// StatsSourceCore are the stats computed from the vaas of the protocol, returned with every core protocol.
+	StatsSourceCore StatsSource = iota
+	// StatsSourceExternal are the stats written by the protocols stats job from the api of the protocol.
+	StatsSourceExternal
+	// StatsSourceCCTP are the totals of the cctp transfers.
+	StatsSourceCCTP
+)
+
+// ActivitySource is the source of the volume of a protocol.
+type ActivitySource int
+
+const (
+	// ActivitySourceStats reads the volume from the stats of the protocol.
+	ActivitySourceStats ActivitySource = iota
+	// ActivitySourceExternal reads the volume from the activity written by the protocols stats job.
+	ActivitySourceExternal
+)
+
+// TVLSource is the source of the total value locked of a protocol.
+type TVLSource int
+
+const (
+	TVLSourceNone TVLSource = iota
+	// TVLSourceExternal reads the total value locked from the stats written by the protocols stats job.
+	TVLSourceExternal
+	// TVLSourceTokenBridge is the total value locked of the portal token bridge.
+	TVLSourceTokenBridge
+)
+
+// Protocol declares where the stats of a protocol are read from.
+type Protocol struct {
+	// AppID is the app id of the protocol in the vaas, as set in WORMSCAN_PROTOCOLS.
+	AppID string
+	// Name is the name of the protocol in the response, and the protocol tag written by the protocols stats job.
+	Name     string
+	Stats    StatsSource
+	Activity ActivitySource
+	TVL      TVLSource
+}
+
+var registry = map[string]Protocol{}
+
+func init() {
+	Register(Protocol{AppID: PortalTokenBridge, Name: "portal_token_bridge", Stats: StatsSourceCore, TVL: TVLSourceTokenBridge})
+	Register(Protocol{AppID: GenericRelayer, Name: "standard_relayer", Stats: StatsSourceCore})
+	Register(Protocol{AppID: CCTP, Name: "cctp", Stats: StatsSourceCCTP})
+	RegisterDeclarations(commonProtocols.Mayan, commonProtocols.AllBridge, commonProtocols.Magpie, commonProtocols.FolksFinance)
+}
+
+// Register registers a protocol, replacing the protocol registered with the same app id.
+func Register(p Protocol) {
+	registry[p.AppID] = p
+}
+
+// RegisterDeclarations registers the protocols whose stats are written by the protocols stats job, as declared
+// in PROTOCOLS_JSON.
+func RegisterDeclarations(declarations ...commonProtocols.Declaration) {
+	for _, d := range declarations {
+		p := Protocol{AppID: d.AppID, Name: d.Name, Stats: StatsSourceExternal}
+		if d.HasActivity() {
+			p.Activity = ActivitySourceExternal
+		}
+		if d.HasTVL() {
+			p.TVL = TVLSourceExternal
+		}
+		Register(p)
+	}
+}
+
+// RegisteredProtocols returns the app ids of the registered protocols whose stats are not computed from the vaas.
+func RegisteredProtocols() []string {
+	result := make([]string, 0, len(registry))
+	for appID, p := range registry {
+		if p.Stats != StatsSourceCore {
+			result = append(result, appID)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// lookupProtocol returns the registered protocol of an app id, or a core protocol named as the lowercase app id.
+func lookupProtocol(appID string) (Protocol, bool) {
+	p, ok := registry[appID]
+	if !ok {
+		return Protocol{AppID: appID, Name: strings.ToLower(appID), Stats: StatsSourceCore}, false
+	}
+	return p, true
+}
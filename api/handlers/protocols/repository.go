@@ -193,11 +193,6 @@ type rowActivity struct {
 	Last24HrTotalValueTransferred float64
 }
 
-type stats struct {
-	Latest rowStat
-	Last24 rowStat
-}
-
 type QueryDoer interface {
 	Query(ctx context.Context, query string) (QueryResult, error)
 }
@@ -248,33 +243,31 @@ func (r *Repository) getProtocolStats24hrAgo(ctx context.Context, protocol strin
 	return fetchSingleRecord[rowStat](ctx, r.logger, r.queryAPI, q, protocol)
 }
 
-func (r *Repository) getAllbridgeActivity(ctx context.Context) (rowActivity, error) {
-
-	const allbridge = "allbridge"
-
-	q := fmt.Sprintf(QueryTemplateProtocolActivity, r.bucketInfinite, "1970-01-01T00:00:00Z", dbconsts.ProtocolsActivityMeasurementDaily, allbridge)
-	activityDaily, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, allbridge)
+// getProtocolActivity returns the total and the last 24 hours volume of the activity of a protocol written by the protocols stats job.
+func (r *Repository) getProtocolActivity(ctx context.Context, protocol string) (rowActivity, error) {
+	q := fmt.Sprintf(QueryTemplateProtocolActivity, r.bucketInfinite, "1970-01-01T00:00:00Z", dbconsts.ProtocolsActivityMeasurementDaily, protocol)
+	activityDaily, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, protocol)
 	if err != nil {
 		r.logger.Error("error fetching latest daily activity", zap.Error(err))
 		return rowActivity{}, err
 	}
 	startOfDay := time.Now().UTC().Truncate(24 * time.Hour).Format(time.RFC3339)
-	q = fmt.Sprintf(QueryTemplateProtocolActivity, r.bucket30d, startOfDay, dbconsts.ProtocolsActivityMeasurementHourly, allbridge)
-	activityHourly, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, allbridge)
+	q = fmt.Sprintf(QueryTemplateProtocolActivity, r.bucket30d, startOfDay, dbconsts.ProtocolsActivityMeasurementHourly, protocol)
+	activityHourly, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, protocol)
 	if err != nil {
 		r.logger.Error("error fetching latest hourly activity", zap.Error(err))
 		return rowActivity{}, err
 	}
 
-	q = fmt.Sprintf(QueryLast24HrActivity, r.bucketInfinite, dbconsts.ProtocolsActivityMeasurementDaily, allbridge)
-	last24HrActivity, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, allbridge)
+	q = fmt.Sprintf(QueryLast24HrActivity, r.bucketInfinite, dbconsts.ProtocolsActivityMeasurementDaily, protocol)
+	last24HrActivity, err := fetchSingleRecord[rowActivity](ctx, r.logger, r.queryAPI, q, protocol)
 	if err != nil {
 		r.logger.Error("error fetching last 24 hr activity", zap.Error(err))
 		return rowActivity{}, err
 	}
 
 	return rowActivity{
-		Protocol:                      allbridge,
+		Protocol:                      protocol,
 		Txs:                           activityDaily.Txs + activityHourly.Txs,
 		TotalValueTransferred:         activityDaily.TotalValueTransferred + activityHourly.TotalValueTransferred,
 		Last24HrTotalValueTransferred: last24HrActivity.TotalValueTransferred,
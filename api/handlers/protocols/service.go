@@ -79,7 +79,7 @@ func (s *Service) GetProtocolsTotalValues(ctx context.Context) []ProtocolTotalVa
 
 	for _, p := range s.protocols {
 		fetchFn := s.getProtocolTotalValuesFn(p)
-		go s.fetchProtocolValues(ctx, wg, p, results, fetchFn) // fetch protocols which are populated from other sources: CCTP, MAYAN, ALLBRIDGE
+		go s.fetchProtocolValues(ctx, wg, p, results, fetchFn) // fetch protocols which are populated from other sources, see the registry
 	}
 
 	wg.Add(1)
@@ -126,12 +126,13 @@ func (s *Service) fetchProtocolValues(ctx context.Context, wg *sync.WaitGroup, p
 }
 
 func (s *Service) getProtocolTotalValuesFn(protocol string) fetchProtocolTotalValues {
-	switch protocol {
-	case MAYAN:
-		return s.getMayanStats
-	case ALLBRIDGE:
-		return s.getAllbridgeStats
-	case CCTP:
+	p, _ := lookupProtocol(protocol)
+	switch p.Stats {
+	case StatsSourceExternal:
+		return func(ctx context.Context, _ string) (ProtocolStats, error) {
+			return s.getExternalStats(ctx, p)
+		}
+	case StatsSourceCCTP:
 		return s.getCCTPStats
 	default:
 		return func(_ context.Context, _ string) (ProtocolStats, error) {
@@ -206,7 +207,7 @@ func (s *Service) getAllProtocolStats(ctx context.Context, excludeProtocols []st
 			val.LastDayVolumeDiffPercentage = percentage
 		}
 
-		if PortalTokenBridge == protocol {
+		if p, _ := lookupProtocol(protocol); p.TVL == TVLSourceTokenBridge {
 			tvl, errTvl := s.tvl.Get(ctx)
 			if errTvl != nil {
 				s.logger.Error("error fetching tvl", zap.Error(errTvl), zap.String("protocol", protocol))
@@ -225,16 +226,8 @@ func (s *Service) getAllProtocolStats(ctx context.Context, excludeProtocols []st
 }
 
 func getProtocolNameDto(protocol string) string {
-	switch protocol {
-	case CCTP:
-		return "cctp"
-	case PortalTokenBridge:
-		return "portal_token_bridge"
-	case GenericRelayer:
-		return "standard_relayer"
-	default:
-		return strings.ToLower(protocol)
-	}
+	p, _ := lookupProtocol(protocol)
+	return p.Name
 }
 
 func (s *Service) getCCTPStats(ctx context.Context, protocol string) (ProtocolStats, error) {
@@ -271,89 +264,64 @@ func (s *Service) getCCTPStats(ctx context.Context, protocol string) (ProtocolSt
 	return val, nil
 }
 
-func (s *Service) getMayanStats(ctx context.Context, _ string) (ProtocolStats, error) {
-	const mayan = "mayan"
-	mayanNow, errStats := s.repo.getProtocolStatsNow(ctx, mayan)
-	if errStats != nil {
-		s.logger.Error("error fetching Mayan stats", zap.Error(errStats), zap.String("protocol", mayan))
-		return ProtocolStats{Protocol: mayan}, errStats
-	}
-	mayan24hrAgo, errStats := s.repo.getProtocolStats24hrAgo(ctx, mayan)
-	if errStats != nil {
-		s.logger.Error("error fetching Mayan stats 24hr ago", zap.Error(errStats), zap.String("protocol", mayan))
-		return ProtocolStats{Protocol: mayan}, errStats
-	}
-
-	last24HrMessages := mayanNow.TotalMessages - mayan24hrAgo.TotalMessages
-	last24HrVolume := mayanNow.Volume - mayan24hrAgo.Volume
-	return ProtocolStats{
-		Protocol:                    mayan,
-		TotalValueLocked:            mayanNow.TotalValueLocked,
-		TotalMessages:               mayanNow.TotalMessages,
-		TotalValueTransferred:       mayanNow.Volume,
-		LastDayMessages:             last24HrMessages,
-		Last24HourVolume:            mayanNow.Volume - mayan24hrAgo.Volume,
-		LastDayDiffPercentage:       strconv.FormatFloat(float64(last24HrMessages)/float64(mayan24hrAgo.TotalMessages)*100, 'f', 2, 64) + "%",
-		LastDayVolumeDiffPercentage: strconv.FormatFloat(last24HrVolume/mayan24hrAgo.Volume*100, 'f', 2, 64) + "%",
-	}, nil
-}
-
-func (s *Service) getAllbridgeStats(ctx context.Context, _ string) (ProtocolStats, error) {
+// getExternalStats returns the stats of a protocol written by the protocols stats job.
+func (s *Service) getExternalStats(ctx context.Context, p Protocol) (ProtocolStats, error) {
 
-	const allbridge = "allbridge"
-	type statsResult struct {
-		result stats
+	type activityResult struct {
+		result rowActivity
 		Err    error
 	}
-	statsRes := make(chan statsResult, 1)
+	activityRes := make(chan activityResult, 1)
 	go func() {
-		defer close(statsRes)
-		statsNow, errStats := s.repo.getProtocolStatsNow(ctx, allbridge)
-		if errStats != nil {
-			statsRes <- statsResult{Err: errStats}
+		defer close(activityRes)
+		if p.Activity != ActivitySourceExternal {
 			return
 		}
-		stats24hrAgo, errStats := s.repo.getProtocolStats24hrAgo(ctx, allbridge)
-		if errStats != nil {
-			statsRes <- statsResult{Err: errStats}
-			return
-		}
-		statsRes <- statsResult{result: stats{Latest: statsNow, Last24: stats24hrAgo}}
+		activity, err := s.repo.getProtocolActivity(ctx, p.Name)
+		activityRes <- activityResult{result: activity, Err: err}
 	}()
 
-	activity, err := s.repo.getAllbridgeActivity(ctx)
-	if err != nil {
-		s.logger.Error("error fetching allbridge activity", zap.Error(err), zap.String("protocol", allbridge))
-		return ProtocolStats{Protocol: allbridge}, err
+	statsNow, errStats := s.repo.getProtocolStatsNow(ctx, p.Name)
+	if errStats != nil {
+		s.logger.Error("error fetching protocol stats", zap.Error(errStats), zap.String("protocol", p.Name))
+		return ProtocolStats{Protocol: p.AppID}, errStats
 	}
-
-	rStats := <-statsRes
-	if rStats.Err != nil {
-		s.logger.Error("error fetching allbridge stats", zap.Error(rStats.Err), zap.String("protocol", allbridge))
-		return ProtocolStats{Protocol: allbridge}, rStats.Err
+	stats24hrAgo, errStats := s.repo.getProtocolStats24hrAgo(ctx, p.Name)
+	if errStats != nil {
+		s.logger.Error("error fetching protocol stats 24hr ago", zap.Error(errStats), zap.String("protocol", p.Name))
+		return ProtocolStats{Protocol: p.AppID}, errStats
 	}
 
+	last24HrMessages := statsNow.TotalMessages - stats24hrAgo.TotalMessages
 	dto := ProtocolStats{
-		Protocol:                    allbridge,
-		TotalMessages:               rStats.result.Latest.TotalMessages,
-		TotalValueTransferred:       activity.TotalValueTransferred,
-		Last24HourVolume:            activity.Last24HrTotalValueTransferred,
+		Protocol:                    p.AppID,
+		TotalMessages:               statsNow.TotalMessages,
+		TotalValueTransferred:       statsNow.Volume,
+		LastDayMessages:             last24HrMessages,
+		Last24HourVolume:            statsNow.Volume - stats24hrAgo.Volume,
 		LastDayDiffPercentage:       "0.00%",
 		LastDayVolumeDiffPercentage: "0.00%",
 	}
+	if p.TVL == TVLSourceExternal {
+		dto.TotalValueLocked = statsNow.TotalValueLocked
+	}
+	if stats24hrAgo.TotalMessages != 0 {
+		dto.LastDayDiffPercentage = strconv.FormatFloat(float64(last24HrMessages)/float64(stats24hrAgo.TotalMessages)*100, 'f', 2, 64) + "%"
+	}
 
-	totalMsgNow := rStats.result.Latest.TotalMessages
-	totalMessagesAsFromLast24hr := rStats.result.Last24.TotalMessages
-	if totalMessagesAsFromLast24hr != 0 {
-		last24HrMessages := totalMsgNow - totalMessagesAsFromLast24hr
-		dto.LastDayMessages = last24HrMessages
-		dto.LastDayDiffPercentage = strconv.FormatFloat(float64(last24HrMessages)/float64(totalMessagesAsFromLast24hr)*100, 'f', 2, 64) + "%"
+	if p.Activity == ActivitySourceExternal {
+		rActivity := <-activityRes
+		if rActivity.Err != nil {
+			s.logger.Error("error fetching protocol activity", zap.Error(rActivity.Err), zap.String("protocol", p.Name))
+			return ProtocolStats{Protocol: p.AppID}, rActivity.Err
+		}
+		dto.TotalValueTransferred = rActivity.result.TotalValueTransferred
+		dto.Last24HourVolume = rActivity.result.Last24HrTotalValueTransferred
 	}
 
-	totalVolumeAsFromLast24Hr := activity.TotalValueTransferred - activity.Last24HrTotalValueTransferred
+	totalVolumeAsFromLast24Hr := dto.TotalValueTransferred - dto.Last24HourVolume
 	if totalVolumeAsFromLast24Hr != 0 {
-		last24HrVolume := activity.Last24HrTotalValueTransferred
-		dto.LastDayVolumeDiffPercentage = strconv.FormatFloat(last24HrVolume/totalVolumeAsFromLast24Hr*100, 'f', 2, 64) + "%"
+		dto.LastDayVolumeDiffPercentage = strconv.FormatFloat(dto.Last24HourVolume/totalVolumeAsFromLast24Hr*100, 'f', 2, 64) + "%"
 	}
 
 	return dto, nil
@@ -12,6 +12,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	cacheMock "github.com/wormhole-foundation/wormhole-explorer/common/client/cache/mock"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbconsts"
+	commonProtocols "github.com/wormhole-foundation/wormhole-explorer/common/protocols"
 	"go.uber.org/zap"
 	"testing"
 	"time"
@@ -251,6 +252,63 @@ func TestService_GetProtocolsTotalValues_Mayan(t *testing.T) {
 
 }
 
+func TestService_GetProtocolsTotalValues_DeclaredProtocol(t *testing.T) {
+	const folksFinance = "folks_finance_v2"
+	var errNil error
+	respStatsLatest := &mockQueryTableResult{}
+	respStatsLatest.On("Next").Return(true)
+	respStatsLatest.On("Err").Return(errNil)
+	respStatsLatest.On("Close").Return(errNil)
+	respStatsLatest.On("Record").Return(query.NewFluxRecord(1, map[string]interface{}{
+		"protocol":           folksFinance,
+		"total_messages":     uint64(12),
+		"total_value_locked": float64(300),
+		"volume":             float64(60),
+	}))
+
+	respStatsLastDay := &mockQueryTableResult{}
+	respStatsLastDay.On("Next").Return(true)
+	respStatsLastDay.On("Err").Return(errNil)
+	respStatsLastDay.On("Close").Return(errNil)
+	respStatsLastDay.On("Record").Return(query.NewFluxRecord(1, map[string]interface{}{
+		"protocol":       folksFinance,
+		"total_messages": uint64(8),
+		"volume":         float64(40),
+	}))
+
+	ctx := context.Background()
+	queryAPI := &mockQueryAPI{}
+	queryAPI.On("Query", ctx, fmt.Sprintf(protocols.QueryTemplateProtocolStatsNow, "bucket30d", dbconsts.ProtocolsStatsMeasurementHourly, folksFinance)).Return(respStatsLatest, nil)
+	queryAPI.On("Query", ctx, fmt.Sprintf(protocols.QueryTemplateProtocolStats24HrAgo, "bucket30d", dbconsts.ProtocolsStatsMeasurementHourly, folksFinance)).Return(respStatsLastDay, nil)
+
+	// core protocols influx calls
+	queryAPI.On("Query", ctx, fmt.Sprintf(protocols.AllProtocolStats24HrAgo, "bucketInfinite")).Return(emptyQueryTableResult(), nil)
+	queryAPI.On("Query", ctx, fmt.Sprintf(protocols.AllProtocolsDeltaSinceStartOfDay, "bucket30d")).Return(emptyQueryTableResult(), nil)
+	queryAPI.On("Query", ctx, fmt.Sprintf(protocols.AllProtocolsDeltaLastDay, "bucket30d")).Return(emptyQueryTableResult(), nil)
+
+	// a protocol declared in PROTOCOLS_JSON is served without any code change.
+	declarations, err := commonProtocols.Parse(`[{"name":"folks_finance_v2","app_id":"FOLKS_FINANCE_V2","url":"https://api.folks.finance","stats":{"path":"/v2/stats","fields":{"total_value_locked":"tvl"}}}]`)
+	assert.Nil(t, err)
+	protocols.RegisterDeclarations(declarations...)
+	assert.Contains(t, protocols.RegisteredProtocols(), "FOLKS_FINANCE_V2")
+	assert.Contains(t, protocols.RegisteredProtocols(), commonProtocols.FolksFinance.AppID)
+	assert.NotContains(t, protocols.RegisteredProtocols(), protocols.PortalTokenBridge)
+
+	repository := protocols.NewRepository(queryAPI, "bucketInfinite", "bucket30d", "bucket24hr", zap.NewNop())
+	service := protocols.NewService([]string{"FOLKS_FINANCE_V2"}, repository, zap.NewNop(), cache.NewDummyCacheClient(), "WORMSCAN:PROTOCOLS", 0, metrics.NewNoOpMetrics(), &mockTvl{})
+
+	values := service.GetProtocolsTotalValues(ctx)
+	assert.Equal(t, 1, len(values))
+	assert.Equal(t, folksFinance, values[0].Protocol)
+	assert.Equal(t, uint64(12), values[0].TotalMessages)
+	assert.Equal(t, float64(300), values[0].TotalValueLocked)
+	assert.Equal(t, float64(60), values[0].TotalValueTransferred)
+	assert.Equal(t, uint64(4), values[0].LastDayMessages)
+	assert.Equal(t, "50.00%", values[0].LastDayDiffPercentage)
+	assert.Equal(t, float64(20), values[0].Last24HourVolume)
+	assert.Equal(t, "50.00%", values[0].LastDayVolumeDiffPercentage)
+}
+
 func TestService_GetProtocolsTotalValues_CacheHit(t *testing.T) {
 	ctx := context.Background()
 	mockCache := &cacheMock.CacheMock{}
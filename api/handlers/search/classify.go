@@ -0,0 +1,83 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// minTxHashPrefixLength is the min length of a hex query to search tx hashes by prefix.
+	minTxHashPrefixLength = 8
+	// maxQueryLength is the max length of a search query.
+	maxQueryLength = 128
+)
+
+var (
+	vaaIDRegex  = regexp.MustCompile(`^\d{1,5}/(0x)?[0-9a-fA-F]{64}/\d{1,20}$`)
+	hexRegex    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	base58Regex = regexp.MustCompile(`^[1-9A-HJ-NP-Za-km-z]+$`)
+	bech32Regex = regexp.MustCompile(`^[a-z]{1,83}1[02-9ac-hj-np-z]{38,}$`)
+	symbolRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9.]{0,11}$`)
+)
+
+// Classify returns the kinds of input the query can be, most specific first.
+func Classify(q string) []InputKind {
+	q = strings.TrimSpace(q)
+	if q == "" || len(q) > maxQueryLength {
+		return nil
+	}
+
+	if vaaIDRegex.MatchString(q) {
+		return []InputKind{KindVaaID}
+	}
+
+	var kinds []InputKind
+	hasHexPrefix := strings.HasPrefix(q, "0x") || strings.HasPrefix(q, "0X")
+	if body := trimHexPrefix(q); hexRegex.MatchString(body) {
+		switch {
+		// 32 bytes: tx hash on most chains, or an address in wormhole format
+		case len(body) == 64:
+			kinds = append(kinds, KindTxHash, KindAddress)
+		// 20 bytes: evm address
+		case len(body) == 40:
+			kinds = append(kinds, KindAddress)
+		case len(body) >= minTxHashPrefixLength && len(body) < 64:
+			kinds = append(kinds, KindTxHashPrefix)
+		}
+	}
+
+	if !hasHexPrefix && base58Regex.MatchString(q) {
+		switch {
+		// 64 bytes: solana tx signature
+		case len(q) >= 86 && len(q) <= 88:
+			kinds = appendKind(kinds, KindTxHash)
+		// 32 bytes: solana address, or sui tx digest
+		case len(q) >= 32 && len(q) <= 44:
+			kinds = appendKind(kinds, KindAddress)
+			kinds = appendKind(kinds, KindTxHash)
+		}
+	}
+
+	if bech32Regex.MatchString(q) {
+		kinds = appendKind(kinds, KindAddress)
+	}
+
+	if symbolRegex.MatchString(q) && len(kinds) == 0 {
+		kinds = append(kinds, KindSymbol)
+	}
+
+	return kinds
+}
+
+func appendKind(kinds []InputKind, kind InputKind) []InputKind {
+	for _, k := range kinds {
+		if k == kind {
+			return kinds
+		}
+	}
+	return append(kinds, kind)
+}
+
+func trimHexPrefix(s string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+}
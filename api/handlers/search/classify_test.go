@@ -0,0 +1,73 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	tests := []struct {
+		name     string
+		q        string
+		expected []InputKind
+	}{
+		{name: "empty", q: "  ", expected: nil},
+		{name: "vaa id", q: "2/" + hash + "/123", expected: []InputKind{KindVaaID}},
+		{name: "vaa id with 0x emitter", q: "2/0x" + hash + "/123", expected: []InputKind{KindVaaID}},
+		{name: "evm tx hash", q: "0x" + hash, expected: []InputKind{KindTxHash, KindAddress}},
+		{name: "tx hash without 0x", q: hash, expected: []InputKind{KindTxHash, KindAddress}},
+		{name: "evm address", q: "0x" + strings.Repeat("ab", 20), expected: []InputKind{KindAddress}},
+		{name: "tx hash prefix", q: "0xabcdef12", expected: []InputKind{KindTxHashPrefix}},
+		{name: "short hex", q: "0xabc", expected: nil},
+		{name: "solana signature", q: strings.Repeat("5", 88), expected: []InputKind{KindTxHash}},
+		{name: "solana address", q: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", expected: []InputKind{KindAddress, KindTxHash}},
+		{name: "bech32 address", q: "cosmos1" + strings.Repeat("q", 38), expected: []InputKind{KindAddress}},
+		{name: "symbol", q: "USDC", expected: []InputKind{KindSymbol}},
+		{name: "symbol with dot", q: "USDC.e", expected: []InputKind{KindSymbol}},
+		{name: "invalid", q: "hello world!", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Classify(tt.q))
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	results := rank([]*Result{
+		{Type: ResultTypeToken, ID: "1/a", Score: scorePrefix},
+		{Type: ResultTypeOperation, ID: "2/b/1", Score: scoreExact},
+		{Type: ResultTypeVaa, ID: "2/b/1", Score: scoreExact},
+		{Type: ResultTypeOperation, ID: "2/b/1", Score: scoreExact},
+		{Type: ResultTypeEmitter, ID: "1/c", Score: scorePrefix},
+	})
+
+	var got []string
+	for _, r := range results {
+		got = append(got, string(r.Type)+":"+r.ID)
+	}
+	assert.Equal(t, []string{"vaa:2/b/1", "operation:2/b/1", "emitter:1/c", "token:1/a"}, got)
+}
+
+func TestTxHashPrefixes(t *testing.T) {
+	assert.Equal(t, []string{"abcdef12", "0xabcdef12", "ABCDEF12"}, txHashPrefixes("0xAbCdEf12"))
+	assert.Equal(t, []string{"12345678", "0x12345678"}, txHashPrefixes("12345678"))
+}
+
+func TestMatchTxHash(t *testing.T) {
+	prefixes := txHashPrefixes("abcdef12")
+
+	// the prefix matches the tx hashes of every chain, in the case the chain stores them with.
+	assert.True(t, matchTxHash("0xabcdef12"+strings.Repeat("0", 56), "abcdef12", prefixes))
+	assert.True(t, matchTxHash("abcdef12"+strings.Repeat("0", 56), "abcdef12", prefixes))
+	assert.True(t, matchTxHash("ABCDEF12"+strings.Repeat("0", 56), "abcdef12", prefixes))
+	assert.False(t, matchTxHash("AbCdEf12"+strings.Repeat("0", 56), "abcdef12", prefixes))
+
+	hash := strings.Repeat("AB", 32)
+	assert.True(t, matchTxHash(hash, "0x"+strings.ToLower(hash), nil))
+	assert.False(t, matchTxHash("0x"+hash, hash, nil))
+}
@@ -0,0 +1,76 @@
+package search
+
+import (
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// InputKind is a kind of input the search query was classified as.
+type InputKind string
+
+// input kind constants.
+const (
+	KindVaaID        InputKind = "vaaId"
+	KindTxHash       InputKind = "txHash"
+	KindTxHashPrefix InputKind = "txHashPrefix"
+	KindAddress      InputKind = "address"
+	KindSymbol       InputKind = "symbol"
+)
+
+// ResultType is the type of a search result.
+type ResultType string
+
+// result type constants, in ranking order for results with the same score.
+const (
+	ResultTypeVaa       ResultType = "vaa"
+	ResultTypeOperation ResultType = "operation"
+	ResultTypeAddress   ResultType = "address"
+	ResultTypeEmitter   ResultType = "emitter"
+	ResultTypeToken     ResultType = "token"
+)
+
+var resultTypeRank = map[ResultType]int{
+	ResultTypeVaa:       0,
+	ResultTypeOperation: 1,
+	ResultTypeAddress:   2,
+	ResultTypeEmitter:   3,
+	ResultTypeToken:     4,
+}
+
+// match scores of the results.
+const (
+	scorePrefix = 1
+	scoreExact  = 2
+)
+
+// Result is a search result.
+type Result struct {
+	Type ResultType `json:"type"`
+	// ID identifies the result in its resource: the vaa id, the address, the token id, etc.
+	ID    string       `json:"id"`
+	Chain *sdk.ChainID `json:"chain,omitempty"`
+	// Match is the value that matched the query.
+	Match string `json:"match"`
+	Label string `json:"label,omitempty"`
+	// Link is the API path of the resource of the result.
+	Link  string `json:"link"`
+	Score int    `json:"score"`
+}
+
+// SearchResult is the result of a search.
+type SearchResult struct {
+	Query   string      `json:"query"`
+	Kinds   []InputKind `json:"kinds"`
+	Results []*Result   `json:"results"`
+}
+
+// operationMatchDoc is an operation whose origin or destination tx hash matched the query.
+type operationMatchDoc struct {
+	ID       string `bson:"_id"`
+	OriginTx *struct {
+		NativeTxHash string `bson:"nativeTxHash"`
+	} `bson:"originTx"`
+	DestinationTx *struct {
+		ChainID sdk.ChainID `bson:"chainId"`
+		TxHash  string      `bson:"txHash"`
+	} `bson:"destinationTx"`
+}
@@ -0,0 +1,120 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Repository definition.
+type Repository struct {
+	db          *mongo.Database
+	logger      *zap.Logger
+	collections struct {
+		vaas               *mongo.Collection
+		parsedVaa          *mongo.Collection
+		globalTransactions *mongo.Collection
+	}
+}
+
+// NewRepository create a new search repository.
+func NewRepository(db *mongo.Database, logger *zap.Logger) *Repository {
+	return &Repository{db: db,
+		logger: logger.With(zap.String("module", "SearchRepository")),
+		collections: struct {
+			vaas               *mongo.Collection
+			parsedVaa          *mongo.Collection
+			globalTransactions *mongo.Collection
+		}{
+			vaas:               db.Collection("vaas"),
+			parsedVaa:          db.Collection("parsedVaa"),
+			globalTransactions: db.Collection("globalTransactions"),
+		},
+	}
+}
+
+// ExistsVaa reports whether the vaa with the given id exists.
+func (r *Repository) ExistsVaa(ctx context.Context, id string) (bool, error) {
+	count, err := r.collections.vaas.CountDocuments(ctx, bson.M{"_id": id}, options.Count().SetLimit(1))
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find vaa by id", zap.Error(err), zap.String("id", id),
+			zap.String("requestID", requestID))
+		return false, errors.WithStack(err)
+	}
+	return count > 0, nil
+}
+
+// FindOperationsByTxHash finds the operations whose origin or destination tx hash is one of hashes.
+// It also finds the operations whose tx hashes start with one of prefixes.
+// Prefixes are matched with anchored, case sensitive regular expressions so they can use the tx hash indexes.
+func (r *Repository) FindOperationsByTxHash(ctx context.Context, hashes []string, prefixes []string, limit int64) ([]*operationMatchDoc, error) {
+	var or bson.A
+	if len(hashes) > 0 {
+		or = append(or,
+			bson.M{"originTx.nativeTxHash": bson.M{"$in": hashes}},
+			bson.M{"destinationTx.txHash": bson.M{"$in": hashes}},
+		)
+	}
+	for _, prefix := range prefixes {
+		regex := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}
+		or = append(or,
+			bson.M{"originTx.nativeTxHash": regex},
+			bson.M{"destinationTx.txHash": regex},
+		)
+	}
+
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"originTx.nativeTxHash": 1, "destinationTx.chainId": 1, "destinationTx.txHash": 1}).
+		SetLimit(limit)
+	cur, err := r.collections.globalTransactions.Find(ctx, bson.M{"$or": or}, opts)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find operations by tx hash", zap.Error(err), zap.Strings("hashes", hashes),
+			zap.Strings("prefixes", prefixes), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	var docs []*operationMatchDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode operations by tx hash", zap.Error(err), zap.Strings("hashes", hashes),
+			zap.Strings("prefixes", prefixes), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return docs, nil
+}
+
+// ExistsAddress reports whether an address, known by its encodings, sent or received any operation.
+func (r *Repository) ExistsAddress(ctx context.Context, encodings []string) (bool, error) {
+	exists := func(c *mongo.Collection, filter bson.M) (bool, error) {
+		count, err := c.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		return count > 0, err
+	}
+
+	found, err := exists(r.collections.parsedVaa, bson.M{"$or": bson.A{
+		bson.M{"standardizedProperties.toAddress": bson.M{"$in": encodings}},
+		bson.M{"standardizedProperties.fromAddress": bson.M{"$in": encodings}},
+	}})
+	if err == nil && !found {
+		found, err = exists(r.collections.globalTransactions, bson.M{"originTx.from": bson.M{"$in": encodings}})
+	}
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to find address", zap.Error(err), zap.Strings("encodings", encodings),
+			zap.String("requestID", requestID))
+		return false, errors.WithStack(err)
+	}
+	return found, nil
+}
@@ -0,0 +1,323 @@
+// Package search handle the request of the search across transactions, addresses, tokens and emitters.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxResultsPerSource is the max number of results returned by each source of a search.
+	maxResultsPerSource = 10
+	// maxResults is the max number of results of a search.
+	maxResults = 25
+)
+
+// tokenEntry is a token indexed by its symbol.
+type tokenEntry struct {
+	symbol string
+	token  domain.TokenMetadata
+}
+
+// Service definition.
+type Service struct {
+	repo        *Repository
+	emitterRepo *repository.EmitterRepository
+	tokens      []tokenEntry
+	logger      *zap.Logger
+}
+
+// NewService create a new search.Service.
+func NewService(repo *Repository, emitterRepo *repository.EmitterRepository, tokenProvider *domain.TokenProvider, logger *zap.Logger) *Service {
+	// sort tokens by symbol to search them by prefix
+	all := tokenProvider.GetAllTokens()
+	tokens := make([]tokenEntry, 0, len(all))
+	for _, t := range all {
+		tokens = append(tokens, tokenEntry{symbol: strings.ToUpper(t.Symbol.String()), token: t})
+	}
+	sort.SliceStable(tokens, func(i, j int) bool { return tokens[i].symbol < tokens[j].symbol })
+
+	return &Service{
+		repo:        repo,
+		emitterRepo: emitterRepo,
+		tokens:      tokens,
+		logger:      logger.With(zap.String("module", "SearchService")),
+	}
+}
+
+// Search classifies the query and searches it in every source that can match its kinds concurrently.
+// Results are ranked by score, exact matches first.
+func (s *Service) Search(ctx context.Context, q string) (*SearchResult, error) {
+	q = strings.TrimSpace(q)
+	kinds := Classify(q)
+	result := &SearchResult{Query: q, Kinds: kinds, Results: []*Result{}}
+	if len(kinds) == 0 {
+		return result, nil
+	}
+
+	var searches []func(context.Context, string) ([]*Result, error)
+	for _, kind := range kinds {
+		switch kind {
+		case KindVaaID:
+			searches = append(searches, s.searchVaa)
+		case KindTxHash:
+			searches = append(searches, s.searchTxHash)
+		case KindTxHashPrefix:
+			searches = append(searches, s.searchTxHashPrefix)
+		case KindAddress:
+			searches = append(searches, s.searchAddress, s.searchEmitterByAddress)
+		case KindSymbol:
+			searches = append(searches, s.searchToken, s.searchEmitterByLabel)
+		}
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results []*Result
+		errs    []error
+	)
+	for _, search := range searches {
+		wg.Add(1)
+		go func(search func(context.Context, string) ([]*Result, error)) {
+			defer wg.Done()
+			r, err := search(ctx, q)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			results = append(results, r...)
+		}(search)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		s.logger.Error("failed to search", zap.Error(err), zap.String("q", q), zap.String("requestID", requestID))
+		return nil, err
+	}
+
+	result.Results = rank(results)
+	return result, nil
+}
+
+// rank removes the duplicated results and sorts them by score and type.
+func rank(results []*Result) []*Result {
+	seen := make(map[string]bool, len(results))
+	ranked := make([]*Result, 0, len(results))
+	for _, r := range results {
+		key := string(r.Type) + ":" + r.ID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ranked = append(ranked, r)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].Type != ranked[j].Type {
+			return resultTypeRank[ranked[i].Type] < resultTypeRank[ranked[j].Type]
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+	if len(ranked) > maxResults {
+		ranked = ranked[:maxResults]
+	}
+	return ranked
+}
+
+func (s *Service) searchVaa(ctx context.Context, q string) ([]*Result, error) {
+	parts := strings.Split(q, "/")
+	chain, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, nil
+	}
+	seq, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+	chainID := sdk.ChainID(chain)
+	emitter := strings.TrimPrefix(strings.ToLower(parts[1]), "0x")
+	id := fmt.Sprintf("%d/%s/%d", chainID, emitter, seq)
+
+	found, err := s.repo.ExistsVaa(ctx, id)
+	if err != nil || !found {
+		return nil, err
+	}
+	return []*Result{
+		{Type: ResultTypeVaa, ID: id, Chain: &chainID, Match: q, Link: "/api/v1/vaas/" + id, Score: scoreExact},
+		{Type: ResultTypeOperation, ID: id, Chain: &chainID, Match: q, Link: "/api/v1/operations/" + id, Score: scoreExact},
+	}, nil
+}
+
+func (s *Service) searchTxHash(ctx context.Context, q string) ([]*Result, error) {
+	return s.searchOperations(ctx, q, txHashEncodings(q), nil)
+}
+
+func (s *Service) searchTxHashPrefix(ctx context.Context, q string) ([]*Result, error) {
+	return s.searchOperations(ctx, q, nil, txHashPrefixes(q))
+}
+
+func (s *Service) searchOperations(ctx context.Context, q string, hashes []string, prefixes []string) ([]*Result, error) {
+	docs, err := s.repo.FindOperationsByTxHash(ctx, hashes, prefixes, maxResultsPerSource)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, len(docs))
+	for _, doc := range docs {
+		r := &Result{Type: ResultTypeOperation, ID: doc.ID, Link: "/api/v1/operations/" + doc.ID, Score: scorePrefix}
+		if chain, _, ok := strings.Cut(doc.ID, "/"); ok {
+			if c, err := strconv.ParseUint(chain, 10, 16); err == nil {
+				chainID := sdk.ChainID(c)
+				r.Chain = &chainID
+			}
+		}
+		switch {
+		case doc.OriginTx != nil && matchTxHash(doc.OriginTx.NativeTxHash, q, prefixes):
+			r.Match = doc.OriginTx.NativeTxHash
+			r.Label = "origin tx"
+		case doc.DestinationTx != nil && matchTxHash(doc.DestinationTx.TxHash, q, prefixes):
+			r.Match = doc.DestinationTx.TxHash
+			r.Label = "destination tx"
+		}
+		if len(prefixes) == 0 {
+			r.Score = scoreExact
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+func (s *Service) searchAddress(ctx context.Context, q string) ([]*Result, error) {
+	encodings, err := address.AddressEncodings(q, nil)
+	if err != nil {
+		return nil, nil
+	}
+	found, err := s.repo.ExistsAddress(ctx, encodings)
+	if err != nil || !found {
+		return nil, err
+	}
+	return []*Result{{Type: ResultTypeAddress, ID: q, Match: q, Link: "/api/v1/address/" + q + "/portfolio", Score: scoreExact}}, nil
+}
+
+func (s *Service) searchEmitterByAddress(ctx context.Context, q string) ([]*Result, error) {
+	addr := q
+	if body := trimHexPrefix(q); hexRegex.MatchString(body) {
+		addr = strings.Repeat("0", max(0, 64-len(body))) + body
+	}
+	docs, err := s.emitterRepo.Search(ctx, addr, "", maxResultsPerSource)
+	if err != nil {
+		return nil, err
+	}
+	return emitterResults(docs, q, scoreExact), nil
+}
+
+func (s *Service) searchEmitterByLabel(ctx context.Context, q string) ([]*Result, error) {
+	docs, err := s.emitterRepo.Search(ctx, "", q, maxResultsPerSource)
+	if err != nil {
+		return nil, err
+	}
+	return emitterResults(docs, q, scorePrefix), nil
+}
+
+func emitterResults(docs []*repository.EmitterDoc, q string, score int) []*Result {
+	results := make([]*Result, 0, len(docs))
+	for _, doc := range docs {
+		chainID := doc.ChainID
+		label := doc.Label
+		if label == "" {
+			label = doc.AppID
+		}
+		results = append(results, &Result{
+			Type:  ResultTypeEmitter,
+			ID:    doc.ID,
+			Chain: &chainID,
+			Match: q,
+			Label: label,
+			Link:  fmt.Sprintf("/api/v1/emitters/%d/%s", doc.ChainID, doc.Address),
+			Score: score,
+		})
+	}
+	return results
+}
+
+func (s *Service) searchToken(_ context.Context, q string) ([]*Result, error) {
+	prefix := strings.ToUpper(q)
+	start := sort.Search(len(s.tokens), func(i int) bool { return s.tokens[i].symbol >= prefix })
+
+	var results []*Result
+	for i := start; i < len(s.tokens) && strings.HasPrefix(s.tokens[i].symbol, prefix) && len(results) < maxResultsPerSource; i++ {
+		t := s.tokens[i].token
+		chainID := t.TokenChain
+		score := scorePrefix
+		if s.tokens[i].symbol == prefix {
+			score = scoreExact
+		}
+		results = append(results, &Result{
+			Type:  ResultTypeToken,
+			ID:    fmt.Sprintf("%d/%s", t.TokenChain, t.TokenAddress),
+			Chain: &chainID,
+			Match: t.Symbol.String(),
+			Label: t.CoingeckoID,
+			Link:  fmt.Sprintf("/api/v1/token/%d/%s", t.TokenChain, t.TokenAddress),
+			Score: score,
+		})
+	}
+	return results, nil
+}
+
+// txHashEncodings returns the encodings a tx hash can be stored with.
+func txHashEncodings(q string) []string {
+	encodings := []string{q}
+	body := trimHexPrefix(q)
+	if hexRegex.MatchString(body) {
+		lower := strings.ToLower(body)
+		encodings = append(encodings, lower, "0x"+lower, strings.ToUpper(body))
+	}
+	return encodings
+}
+
+// txHashPrefixes returns the prefixes of the tx hashes starting with the hex query q, in the
+// format of every chain: the tx hashes are stored lowercase with 0x on the evm chains, lowercase
+// without 0x on the chains whose hash comes from the VAA, and uppercase without 0x on the cosmos chains.
+func txHashPrefixes(q string) []string {
+	body := trimHexPrefix(q)
+	lower, upper := strings.ToLower(body), strings.ToUpper(body)
+	if lower == upper {
+		return []string{lower, "0x" + lower}
+	}
+	return []string{lower, "0x" + lower, upper}
+}
+
+// matchTxHash reports whether hash matches the query q, or starts with one of prefixes.
+func matchTxHash(hash, q string, prefixes []string) bool {
+	if len(prefixes) > 0 {
+		for _, p := range prefixes {
+			if strings.HasPrefix(hash, p) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, e := range txHashEncodings(q) {
+		if hash == e {
+			return true
+		}
+	}
+	return false
+}
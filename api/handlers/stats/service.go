@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/cacheable"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
@@ -18,6 +21,7 @@ type Service struct {
 	repo               *Repository
 	addressRepositorty *stats.AddressRepository
 	holderRepository   *stats.HolderRepositoryReadable
+	symbolRepository   *stats.NTTSymbolRepository
 	cache              cache.Cache
 	expiration         time.Duration
 	metrics            metrics.Metrics
@@ -30,16 +34,20 @@ const (
 	nttSummary             = "wormscan:ntt-summary"
 	nttChainActivity       = "wormscan:ntt-ntt-chain-activity"
 	nttTransferByTime      = "wormscan:ntt-transfer-by-time"
+
+	// defaultNTTSymbol is the only symbol supported until the NTT stats jobs store the available symbols.
+	defaultNTTSymbol = "W"
 )
 
 // NewService create a new Service.
 func NewService(repo *Repository, statsRepository *stats.AddressRepository,
-	holderRepository *stats.HolderRepositoryReadable, cache cache.Cache,
+	holderRepository *stats.HolderRepositoryReadable, symbolRepository *stats.NTTSymbolRepository, cache cache.Cache,
 	expiration time.Duration, metrics metrics.Metrics, logger *zap.Logger) *Service {
 	return &Service{
 		repo:               repo,
 		addressRepositorty: statsRepository,
 		holderRepository:   holderRepository,
+		symbolRepository:   symbolRepository,
 		cache:              cache,
 		expiration:         expiration,
 		metrics:            metrics,
@@ -65,19 +73,21 @@ func (s *Service) GetTopCorridors(ctx context.Context, ts TopCorridorsTimeSpan)
 }
 
 func (s *Service) GetNativeTokenTransferSummary(ctx context.Context, symbol string) (*NativeTokenTransferSummary, error) {
-	if strings.ToUpper(symbol) != "W" {
-		return nil, errors.New("symbol not supported")
+	symbol = strings.ToUpper(symbol)
+	if err := s.checkNTTSymbol(ctx, symbol, stats.NTTStats...); err != nil {
+		return nil, err
 	}
 
-	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, nttSummary, s.metrics,
+	key := fmt.Sprintf("%s:%s", nttSummary, symbol)
+	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
 		func() (*NativeTokenTransferSummary, error) {
 			return s.repo.GetNativeTokenTransferSummary(ctx, symbol)
 		})
 }
 
 func (s *Service) GetNativeTokenTransferActivity(ctx context.Context, isNotional bool, symbol string) ([]NativeTokenTransferActivity, error) {
-	if symbol != "W" {
-		return nil, errors.New("symbol not supported")
+	if err := s.checkNTTSymbol(ctx, symbol, stats.NTTStats...); err != nil {
+		return nil, err
 	}
 	key := fmt.Sprintf("%s:%s:%t", nttChainActivity, symbol, isNotional)
 	return cacheable.GetOrLoad(ctx, s.logger, s.cache, s.expiration, key, s.metrics,
@@ -87,8 +97,8 @@ func (s *Service) GetNativeTokenTransferActivity(ctx context.Context, isNotional
 }
 
 func (s *Service) GetNativeTokenTransferByTime(ctx context.Context, timespan NttTimespan, symbol string, isNotional bool, from, to time.Time) ([]NativeTokenTransferByTime, error) {
-	if symbol != "W" {
-		return nil, errors.New("symbol not supported")
+	if err := s.checkNTTSymbol(ctx, symbol, stats.NTTStats...); err != nil {
+		return nil, err
 	}
 
 	timeDuration := to.Sub(from)
@@ -138,16 +148,64 @@ func (s *Service) GetNativeTokenTransferByTime(ctx context.Context, timespan Ntt
 }
 
 func (s *Service) GetNativeTokenTransferAddressTop(ctx context.Context, symbol string, isNotional bool) ([]stats.NativeTokenTransferTopAddress, error) {
-	if symbol != "W" {
-		return nil, errors.New("symbol not supported")
+	if err := s.checkNTTSymbol(ctx, symbol, stats.NTTStatTopAddress); err != nil {
+		return nil, err
 	}
 
 	return s.addressRepositorty.GetNativeTokenTransferTopAddress(ctx, symbol, isNotional)
 }
 
 func (s *Service) GetNativeTokenTransferTopHolder(ctx context.Context, symbol string) ([]stats.NativeTokenTransferTopHolder, error) {
-	if symbol != "W" {
-		return nil, errors.New("symbol not supported")
+	if err := s.checkNTTSymbol(ctx, symbol, stats.NTTStatTopHolder); err != nil {
+		return nil, err
 	}
 	return s.holderRepository.GetNativeTokenTransferTopHolder(ctx, symbol)
 }
+
+// GetNativeTokenTransferSymbols returns the symbols with NTT stats available and the stats of each symbol.
+func (s *Service) GetNativeTokenTransferSymbols(ctx context.Context) ([]NativeTokenTransferSymbol, error) {
+	statsBySymbol := make(map[string][]string)
+	for _, stat := range stats.NTTStats {
+		symbols, err := s.symbolRepository.GetSymbols(ctx, stat)
+		if errors.Is(err, cache.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, symbol := range symbols {
+			statsBySymbol[symbol] = append(statsBySymbol[symbol], stat)
+		}
+	}
+
+	result := make([]NativeTokenTransferSymbol, 0, len(statsBySymbol))
+	for symbol, symbolStats := range statsBySymbol {
+		result = append(result, NativeTokenTransferSymbol{Symbol: symbol, Stats: symbolStats})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+	return result, nil
+}
+
+// checkNTTSymbol returns errs.ErrNotFound if none of the stats is available for the symbol.
+// Until the NTT stats jobs store the available symbols, only the default symbol is supported.
+func (s *Service) checkNTTSymbol(ctx context.Context, symbol string, nttStats ...string) error {
+	found := false
+	for _, stat := range nttStats {
+		symbols, err := s.symbolRepository.GetSymbols(ctx, stat)
+		if errors.Is(err, cache.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to get NTT symbols", zap.String("stat", stat), zap.Error(err))
+			continue
+		}
+		found = true
+		if slices.Contains(symbols, symbol) {
+			return nil
+		}
+	}
+	if !found && symbol == defaultNTTSymbol {
+		return nil
+	}
+	return errs.ErrNotFound
+}
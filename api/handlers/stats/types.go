@@ -102,3 +102,9 @@ type NativeTokenTransferByTime struct {
 	Symbol string          `json:"symbol"`
 	Value  decimal.Decimal `json:"value"`
 }
+
+// NativeTokenTransferSymbol is a symbol with NTT stats available.
+type NativeTokenTransferSymbol struct {
+	Symbol string   `json:"symbol"`
+	Stats  []string `json:"stats"`
+}
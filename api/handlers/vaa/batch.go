@@ -0,0 +1,141 @@
+package vaa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxSignedVaaIDs is the max number of vaa ids that can be requested in a single bulk lookup.
+	MaxSignedVaaIDs = 100
+	// maxBatchVaas is the max number of vaas emitted by a single transaction that are read to build a batch.
+	maxBatchVaas = 1000
+)
+
+// ParseVaaID parses a vaa id formatted as chain/emitter/sequence, the emitter in wormhole hex format.
+func ParseVaaID(id string) (*VaaID, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid vaa id %s", id)
+	}
+	chain, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain in vaa id %s: %w", id, err)
+	}
+	emitter, err := types.StringToAddress(parts[1], false /*acceptSolanaFormat*/)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emitter in vaa id %s: %w", id, err)
+	}
+	seq, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence in vaa id %s: %w", id, err)
+	}
+	return &VaaID{Chain: sdk.ChainID(chain), Emitter: emitter, Sequence: strconv.FormatUint(seq, 10)}, nil
+}
+
+// FindSignedBatch returns the vaas emitted on chain by the transaction txHash with the given nonce,
+// sorted by sequence. It returns errs.ErrNotFound if there is no vaa for the batch.
+func (s *Service) FindSignedBatch(ctx context.Context, chain sdk.ChainID, txHash *types.TxHash, nonce uint32) ([]*VaaDoc, error) {
+
+	// the nonce is not stored, so the vaas of the transaction are filtered by the nonce of their payload
+	query := Query().
+		SetChain(chain).
+		SetTxHash(txHash.String())
+	query.Limit = maxBatchVaas
+	docs, err := s.repo.FindVaasByTxHashWorkaround(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.selectBatch(ctx, docs, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if len(batch) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	sortBySequence(batch)
+	return batch, nil
+}
+
+// FindSignedByIds returns the signed vaas of the given ids, in the same order. The vaas that are not found,
+// or whose sequence is not indexed yet, are returned without bytes.
+func (s *Service) FindSignedByIds(ctx context.Context, ids []*VaaID) ([]*SignedVaa, error) {
+
+	result := make([]*SignedVaa, 0, len(ids))
+	var lookup []string
+	for _, id := range ids {
+		result = append(result, &SignedVaa{ID: id.String()})
+
+		// check vaa sequence indexed
+		if s.discardVaaNotIndexed(ctx, id.Chain, id.Emitter, id.Sequence) {
+			continue
+		}
+		lookup = append(lookup, id.String())
+	}
+	if len(lookup) == 0 {
+		return result, nil
+	}
+
+	query := Query().SetIDs(lookup)
+	query.Limit = int64(len(lookup))
+	docs, err := s.repo.FindVaas(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	vaaByID := make(map[string][]byte, len(docs))
+	for _, doc := range docs {
+		vaaByID[doc.ID] = doc.Vaa
+	}
+	for _, r := range result {
+		r.Vaa = vaaByID[r.ID]
+	}
+	return result, nil
+}
+
+// selectBatch returns the vaas with the given nonce whose sequence is already indexed.
+func (s *Service) selectBatch(ctx context.Context, docs []*VaaDoc, nonce uint32) ([]*VaaDoc, error) {
+	batch := make([]*VaaDoc, 0, len(docs))
+	for _, doc := range docs {
+		v, err := sdk.Unmarshal(doc.Vaa)
+		if err != nil {
+			requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+			s.logger.Error("failed to unmarshal vaa of batch",
+				zap.Error(err), zap.String("id", doc.ID), zap.String("requestID", requestID))
+			continue
+		}
+		if v.Nonce != nonce {
+			continue
+		}
+
+		// check vaa sequence indexed
+		emitter, err := types.BytesToAddress(v.EmitterAddress.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if s.discardVaaNotIndexed(ctx, v.EmitterChain, emitter, doc.Sequence) {
+			continue
+		}
+		batch = append(batch, doc)
+	}
+	return batch, nil
+}
+
+// sortBySequence sorts the vaas by sequence number.
+func sortBySequence(docs []*VaaDoc) {
+	seq := func(d *VaaDoc) uint64 {
+		n, _ := strconv.ParseUint(d.Sequence, 10, 64)
+		return n
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return seq(docs[i]) < seq(docs[j]) })
+}
@@ -0,0 +1,95 @@
+package vaa
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// newMaxSequenceService returns a service whose cache holds the given max sequence of every emitter.
+func newMaxSequenceService(maxSequence map[string]string) *Service {
+	getCache := func(_ context.Context, key string) (string, error) {
+		if seq, ok := maxSequence[key]; ok {
+			return seq, nil
+		}
+		return "", cache.ErrNotFound
+	}
+	return NewService(nil, nil, getCache, nil, zap.NewNop())
+}
+
+func newBatchVaaDoc(t *testing.T, emitter sdk.Address, nonce uint32, sequence uint64) *VaaDoc {
+	v := &sdk.VAA{
+		Version:          1,
+		Nonce:            nonce,
+		EmitterChain:     sdk.ChainIDEthereum,
+		EmitterAddress:   emitter,
+		Sequence:         sequence,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1},
+	}
+	data, err := v.Marshal()
+	assert.NoError(t, err)
+	return &VaaDoc{ID: v.MessageID(), Sequence: strconv.FormatUint(sequence, 10), Vaa: data}
+}
+
+func TestParseVaaID(t *testing.T) {
+	emitter := strings.Repeat("ab", 32)
+
+	id, err := ParseVaaID("2/0x" + strings.ToUpper(emitter) + "/0042")
+	assert.NoError(t, err)
+	assert.Equal(t, sdk.ChainIDEthereum, id.Chain)
+	assert.Equal(t, "42", id.Sequence)
+	assert.Equal(t, "2/"+emitter+"/42", id.String())
+
+	for _, invalid := range []string{"", "2/" + emitter, "x/" + emitter + "/1", "2/zz/1", "2/" + emitter + "/-1", "2/" + emitter + "/1/2"} {
+		_, err := ParseVaaID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSortBySequence(t *testing.T) {
+	docs := []*VaaDoc{{Sequence: "10"}, {Sequence: "9"}, {Sequence: "100"}}
+	sortBySequence(docs)
+	assert.Equal(t, "9", docs[0].Sequence)
+	assert.Equal(t, "10", docs[1].Sequence)
+	assert.Equal(t, "100", docs[2].Sequence)
+}
+
+func TestSelectBatch(t *testing.T) {
+	emitter := sdk.Address{1}
+	key := "wormscan:vaa-max-sequence:2:" + emitter.String()
+	docs := []*VaaDoc{
+		newBatchVaaDoc(t, emitter, 7, 3),
+		newBatchVaaDoc(t, emitter, 8, 4),
+		newBatchVaaDoc(t, emitter, 7, 5),
+		{ID: "invalid", Sequence: "6", Vaa: []byte{1}},
+	}
+
+	// the vaas of other nonces and the invalid vaas are skipped
+	batch, err := newMaxSequenceService(map[string]string{}).selectBatch(context.Background(), docs, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*VaaDoc{docs[0], docs[2]}, batch)
+
+	// the vaas whose sequence is not indexed yet are skipped
+	batch, err = newMaxSequenceService(map[string]string{key: "4"}).selectBatch(context.Background(), docs, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*VaaDoc{docs[0]}, batch)
+}
+
+func TestFindSignedByIds_SequenceNotIndexed(t *testing.T) {
+	emitter := strings.Repeat("ab", 32)
+	id, err := ParseVaaID("2/" + emitter + "/5")
+	assert.NoError(t, err)
+
+	// the repository is not queried when no sequence is indexed
+	svc := newMaxSequenceService(map[string]string{"wormscan:vaa-max-sequence:2:" + emitter: "4"})
+	result, err := svc.FindSignedByIds(context.Background(), []*VaaID{id})
+	assert.NoError(t, err)
+	assert.Equal(t, []*SignedVaa{{ID: "2/" + emitter + "/5"}}, result)
+}
@@ -1,10 +1,13 @@
 package vaa
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -63,3 +66,54 @@ type VaaStats struct {
 	ChainID vaa.ChainID `bson:"_id" json:"chainId"`
 	Count   int64       `bson:"count" json:"count"`
 }
+
+// VaaRecord defines the model of the VAAs export.
+type VaaRecord struct {
+	ID               string      `bson:"_id" json:"id"`
+	EmitterChain     vaa.ChainID `bson:"emitterChain" json:"emitterChain"`
+	EmitterAddr      string      `bson:"emitterAddr" json:"emitterAddr"`
+	Sequence         string      `bson:"sequence" json:"sequence"`
+	GuardianSetIndex uint32      `bson:"guardianSetIndex" json:"guardianSetIndex"`
+	Timestamp        time.Time   `bson:"timestamp" json:"timestamp"`
+	TxHash           string      `bson:"txHash" json:"txHash"`
+	Digest           string      `bson:"digest" json:"digest"`
+	Vaa              []byte      `bson:"vaas" json:"vaa"`
+}
+
+// VaaRecordHeader returns the column names of the VAAs export.
+func VaaRecordHeader() []string {
+	return []string{"id", "emitterChain", "emitterAddr", "sequence", "guardianSetIndex", "timestamp", "txHash", "digest", "vaa"}
+}
+
+// Values returns the column values of the VAA, in the same order as VaaRecordHeader.
+func (r *VaaRecord) Values() []string {
+	return []string{
+		r.ID,
+		strconv.Itoa(int(r.EmitterChain)),
+		r.EmitterAddr,
+		r.Sequence,
+		strconv.FormatUint(uint64(r.GuardianSetIndex), 10),
+		r.Timestamp.Format(time.RFC3339),
+		r.TxHash,
+		r.Digest,
+		base64.StdEncoding.EncodeToString(r.Vaa),
+	}
+}
+
+// VaaID identifies a vaa by its emitter chain, emitter address and sequence.
+type VaaID struct {
+	Chain    vaa.ChainID
+	Emitter  *types.Address
+	Sequence string
+}
+
+// String returns the id of the vaa in the vaas collection.
+func (id *VaaID) String() string {
+	return fmt.Sprintf("%d/%s/%s", id.Chain, id.Emitter.Hex(), id.Sequence)
+}
+
+// SignedVaa is a signed vaa requested by id. Vaa is empty if the vaa was not found.
+type SignedVaa struct {
+	ID  string `json:"id"`
+	Vaa []byte `json:"vaaBytes"`
+}
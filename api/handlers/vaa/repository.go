@@ -3,9 +3,11 @@ package vaa
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
@@ -71,15 +73,40 @@ func (r *Repository) FindVaasByTxHashWorkaround(
 	query *VaaQuery,
 ) ([]*VaaDoc, error) {
 
+	ids, err := r.findIDsByTxHash(ctx, query.txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// If no documents were found, look up the transaction hash in the `vaas` collection instead.
+	if len(ids) == 0 {
+		return r.FindVaas(ctx, query)
+	}
+
+	// Find VAAs that match the given VAA ID
+	q := *query // making a copy to avoid modifying the struct passed by the caller
+	q.SetIDs(ids)
+	// Disable txHash filter, but keep all the other filters.
+	// We have to do this because the transaction hashes in the `globalTransactions` collection
+	// may be different that the transaction hash in the `vaas` collection. This is the case
+	// for Aptos and Solana VAAs.
+	q.txHash = ""
+	return r.FindVaas(ctx, &q)
+}
+
+// findIDsByTxHash returns the ids of the VAAs whose transaction hash in the `globalTransactions` collection
+// matches the given one, with or without the 0x prefix.
+func (r *Repository) findIDsByTxHash(ctx context.Context, txHash string) ([]string, error) {
+
 	// Find globalTransactions that match the given TxHash
 	cur, err := r.collections.globalTransactions.Find(
 		ctx,
 		bson.D{
 			{"$or", bson.A{
-				bson.D{{"originTx.nativeTxHash", bson.M{"$eq": query.txHash}}},
-				bson.D{{"originTx.nativeTxHash", bson.M{"$eq": "0x" + query.txHash}}},
-				bson.D{{"originTx.attribute.value.originTxHash", bson.M{"$eq": query.txHash}}},
-				bson.D{{"originTx.attribute.value.originTxHash", bson.M{"$eq": "0x" + query.txHash}}},
+				bson.D{{"originTx.nativeTxHash", bson.M{"$eq": txHash}}},
+				bson.D{{"originTx.nativeTxHash", bson.M{"$eq": "0x" + txHash}}},
+				bson.D{{"originTx.attribute.value.originTxHash", bson.M{"$eq": txHash}}},
+				bson.D{{"originTx.attribute.value.originTxHash", bson.M{"$eq": "0x" + txHash}}},
 			}},
 		},
 		nil,
@@ -106,24 +133,11 @@ func (r *Repository) FindVaasByTxHashWorkaround(
 		return nil, errors.WithStack(err)
 	}
 
-	// If no documents were found, look up the transaction hash in the `vaas` collection instead.
-	if len(globalTxs) == 0 {
-		return r.FindVaas(ctx, query)
-	}
-
-	// Find VAAs that match the given VAA ID
-	q := *query // making a copy to avoid modifying the struct passed by the caller
 	var ids []string
 	for i := range globalTxs {
 		ids = append(ids, globalTxs[i].ID)
 	}
-	q.SetIDs(ids)
-	// Disable txHash filter, but keep all the other filters.
-	// We have to do this because the transaction hashes in the `globalTransactions` collection
-	// may be different that the transaction hash in the `vaas` collection. This is the case
-	// for Aptos and Solana VAAs.
-	q.txHash = ""
-	return r.FindVaas(ctx, &q)
+	return ids, nil
 }
 
 // FindVaasByEmitterAndToChain searches the database for VAAs that match a given emitter chain, address and toChain.
@@ -219,45 +233,8 @@ func (r *Repository) FindVaas(
 			{"$sort", bson.D{q.getSortPredicate()}},
 		})
 
-		// filter by VAA ids (potentially more than one)
-		if len(q.ids) > 0 {
-			var array bson.A
-			for _, id := range q.ids {
-				predicate := bson.D{bson.E{"_id", id}}
-				array = append(array, predicate)
-			}
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{{"$or", array}}},
-			})
-		}
-
-		// filter by emitterChain
-		if q.chainId != 0 {
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{bson.E{"emitterChain", q.chainId}}},
-			})
-		}
-
-		// filter by emitterAddr
-		if q.emitter != "" {
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{bson.E{"emitterAddr", q.emitter}}},
-			})
-		}
-
-		// filter by sequence
-		if q.sequence != "" {
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{bson.E{"sequence", q.sequence}}},
-			})
-		}
-
-		// filter by txHash
-		if q.txHash != "" {
-			pipeline = append(pipeline, bson.D{
-				{"$match", bson.D{bson.E{"txHash", q.txHash}}},
-			})
-		}
+		// filter by ids, emitterChain, emitterAddr, sequence and txHash
+		pipeline = append(pipeline, q.filters()...)
 
 		// left outer join on the `parsedVaa` collection
 		pipeline = append(pipeline, bson.D{
@@ -452,6 +429,161 @@ func (r *Repository) FindDuplicatedByID(ctx context.Context, chain sdk.ChainID,
 	return append(duplicateVaas, &vaa), nil
 }
 
+// VaaExportQuery is a query of the VAAs export.
+type VaaExportQuery struct {
+	// filters are the filters of FindVaas applied to the export.
+	filters *VaaQuery
+	appId   string
+	// cursor is the position of the last exported VAA, nil to start from the latest one.
+	cursor *export.Cursor
+	// until is the position of the last VAA of the export, nil to export every VAA after cursor.
+	until *export.Cursor
+	limit int64
+}
+
+// vaasCollection returns the name of the collection of the VAAs of the export query.
+func (q *VaaExportQuery) vaasCollection() string {
+	if q.filters.chainId == sdk.ChainIDPythNet {
+		return "vaasPythnet"
+	}
+	return repository.Vaas
+}
+
+// cursorStages returns the stages that match the VAAs after the cursor and up to the until cursor.
+func (q *VaaExportQuery) cursorStages() mongo.Pipeline {
+	var pipeline mongo.Pipeline
+	if q.cursor != nil {
+		pipeline = append(pipeline, q.cursor.Match("timestamp"))
+	}
+	if q.until != nil {
+		pipeline = append(pipeline, q.until.MatchUntil("timestamp"))
+	}
+	return pipeline
+}
+
+// buildExportFilters returns the stages that filter the VAAs by the export query,
+// starting after the cursor, up to the until cursor, and sorted from the latest VAA.
+//
+// Without appId the stages run on the collection of the VAAs. The appId is only stored in the
+// parsedVaa collection, so with appId the stages run on the parsedVaa collection, matched by the
+// indexed appIds before the sort, and the VAAs are joined after it.
+func buildExportFilters(q *VaaExportQuery) mongo.Pipeline {
+
+	if q.appId == "" {
+		pipeline := q.filters.filters()
+		pipeline = append(pipeline, q.cursorStages()...)
+		return append(pipeline, bson.D{{"$sort", bson.D{{"timestamp", -1}, {"_id", -1}}}})
+	}
+
+	pipeline := mongo.Pipeline{
+		bson.D{{"$match", bson.D{{"rawStandardizedProperties.appIds", q.appId}}}},
+	}
+
+	// the txHash is not stored in the parsedVaa collection, it is matched after the join.
+	filters := *q.filters
+	filters.txHash = ""
+	pipeline = append(pipeline, filters.filters()...)
+
+	pipeline = append(pipeline, q.cursorStages()...)
+	pipeline = append(pipeline, bson.D{{"$sort", bson.D{{"timestamp", -1}, {"_id", -1}}}})
+
+	pipeline = append(pipeline, bson.D{{"$lookup", bson.D{
+		{"from", q.vaasCollection()},
+		{"localField", "_id"},
+		{"foreignField", "_id"},
+		{"as", "vaa"},
+	}}})
+	pipeline = append(pipeline, bson.D{{"$unwind", "$vaa"}})
+	pipeline = append(pipeline, bson.D{{"$replaceRoot", bson.D{{"newRoot", "$vaa"}}}})
+
+	if q.filters.txHash != "" {
+		pipeline = append(pipeline, bson.D{{"$match", bson.D{{"txHash", q.filters.txHash}}}})
+	}
+	return pipeline
+}
+
+// aggregateExport runs the pipeline of the export query on the collection that the export filters
+// start from. Like FindVaasByTxHashWorkaround, the txHash is first looked up in the
+// `globalTransactions` collection, whose transaction hashes are the real ones for Aptos and Solana.
+func (r *Repository) aggregateExport(ctx context.Context, q *VaaExportQuery, stages ...bson.D) (*mongo.Cursor, error) {
+
+	query := *q // making a copy to avoid modifying the struct passed by the caller
+	filters := *q.filters
+	query.filters = &filters
+	if filters.txHash != "" {
+		ids, err := r.findIDsByTxHash(ctx, filters.txHash)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			filters.SetIDs(ids)
+			filters.txHash = ""
+		}
+	}
+
+	pipeline := append(buildExportFilters(&query), stages...)
+
+	collection := r.db.Collection(query.vaasCollection())
+	if query.appId != "" {
+		collection = r.collections.parsedVaa
+	}
+	cur, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed execute export aggregation pipeline", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+	return cur, nil
+}
+
+// Export returns a cursor over the VAAs of the export query. The VAAs are bounded by the until cursor
+// of the query instead of its limit, so the VAAs indexed after FindExportNextCursor are neither skipped
+// nor repeated by the next export. The caller must close the cursor.
+func (r *Repository) Export(ctx context.Context, q *VaaExportQuery) (*mongo.Cursor, error) {
+	return r.aggregateExport(ctx, q,
+		bson.D{{"$project", bson.D{
+			{"emitterChain", 1},
+			{"emitterAddr", 1},
+			{"sequence", 1},
+			{"guardianSetIndex", 1},
+			{"timestamp", 1},
+			{"txHash", 1},
+			{"digest", 1},
+			{"vaas", 1},
+		}}},
+	)
+}
+
+// FindExportNextCursor returns the position of the last VAA of the export query, to resume the export
+// after it, or nil if there are no more VAAs to export.
+func (r *Repository) FindExportNextCursor(ctx context.Context, q *VaaExportQuery) (*export.Cursor, error) {
+
+	cur, err := r.aggregateExport(ctx, q,
+		bson.D{{"$skip", q.limit - 1}},
+		bson.D{{"$limit", 2}},
+		bson.D{{"$project", bson.D{{"timestamp", 1}}}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []struct {
+		ID        string    `bson:"_id"`
+		Timestamp time.Time `bson:"timestamp"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		r.logger.Error("failed to decode cursor", zap.Error(err), zap.String("requestID", requestID))
+		return nil, errors.WithStack(err)
+	}
+
+	// the last VAA of the export is only followed by more VAAs if the page is full.
+	if len(docs) < 2 {
+		return nil, nil
+	}
+	return &export.Cursor{Timestamp: docs[0].Timestamp, ID: docs[0].ID}, nil
+}
+
 // VaaQuery respresent a query for the vaa mongodb document.
 type VaaQuery struct {
 	pagination.Pagination
@@ -515,6 +647,54 @@ func (q *VaaQuery) IncludeParsedPayload(val bool) *VaaQuery {
 	return q
 }
 
+// filters returns the stages that match the VAAs by ids, emitterChain, emitterAddr, sequence and txHash.
+func (q *VaaQuery) filters() mongo.Pipeline {
+
+	var pipeline mongo.Pipeline
+
+	// filter by VAA ids (potentially more than one)
+	if len(q.ids) > 0 {
+		var array bson.A
+		for _, id := range q.ids {
+			predicate := bson.D{bson.E{"_id", id}}
+			array = append(array, predicate)
+		}
+		pipeline = append(pipeline, bson.D{
+			{"$match", bson.D{{"$or", array}}},
+		})
+	}
+
+	// filter by emitterChain
+	if q.chainId != 0 {
+		pipeline = append(pipeline, bson.D{
+			{"$match", bson.D{bson.E{"emitterChain", q.chainId}}},
+		})
+	}
+
+	// filter by emitterAddr
+	if q.emitter != "" {
+		pipeline = append(pipeline, bson.D{
+			{"$match", bson.D{bson.E{"emitterAddr", q.emitter}}},
+		})
+	}
+
+	// filter by sequence
+	if q.sequence != "" {
+		pipeline = append(pipeline, bson.D{
+			{"$match", bson.D{bson.E{"sequence", q.sequence}}},
+		})
+	}
+
+	// filter by txHash
+	if q.txHash != "" {
+		pipeline = append(pipeline, bson.D{
+			{"$match", bson.D{bson.E{"txHash", q.txHash}}},
+		})
+	}
+
+	return pipeline
+}
+
 func (q *VaaQuery) getSortPredicate() bson.E {
 	return bson.E{"timestamp", q.GetSortInt()}
 }
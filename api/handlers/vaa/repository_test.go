@@ -0,0 +1,62 @@
+package vaa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// stageNames returns the name of the operator of every stage of the pipeline.
+func stageNames(pipeline []bson.D) []string {
+	names := make([]string, 0, len(pipeline))
+	for _, stage := range pipeline {
+		names = append(names, stage[0].Key)
+	}
+	return names
+}
+
+func TestBuildExportFilters(t *testing.T) {
+	cursor := &export.Cursor{Timestamp: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), ID: "2/abc/1"}
+	until := &export.Cursor{Timestamp: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), ID: "2/abc/0"}
+	query := VaaExportQuery{
+		filters: Query().SetChain(sdk.ChainIDEthereum).SetTxHash("abcd"),
+		cursor:  cursor,
+		until:   until,
+		limit:   10,
+	}
+
+	pipeline := buildExportFilters(&query)
+
+	// the filters of FindVaas are applied before the cursors and the sort.
+	assert.Equal(t, []string{"$match", "$match", "$match", "$match", "$sort"}, stageNames(pipeline))
+	assert.Equal(t, bson.D{{"$match", bson.D{{"emitterChain", sdk.ChainIDEthereum}}}}, pipeline[0])
+	assert.Equal(t, bson.D{{"$match", bson.D{{"txHash", "abcd"}}}}, pipeline[1])
+	assert.Equal(t, cursor.Match("timestamp"), pipeline[2])
+	assert.Equal(t, until.MatchUntil("timestamp"), pipeline[3])
+	assert.Equal(t, "vaas", query.vaasCollection())
+}
+
+func TestBuildExportFilters_AppId(t *testing.T) {
+	query := VaaExportQuery{
+		filters: Query().SetChain(sdk.ChainIDPythNet).SetTxHash("abcd"),
+		appId:   "PORTAL_TOKEN_BRIDGE",
+		limit:   10,
+	}
+
+	pipeline := buildExportFilters(&query)
+
+	// the appId is matched by the indexed field of the parsedVaa collection before the sort,
+	// and the txHash of the vaas collection after the join.
+	assert.Equal(t, []string{"$match", "$match", "$sort", "$lookup", "$unwind", "$replaceRoot", "$match"}, stageNames(pipeline))
+	assert.Equal(t, bson.D{{"$match", bson.D{{"rawStandardizedProperties.appIds", "PORTAL_TOKEN_BRIDGE"}}}}, pipeline[0])
+	assert.Equal(t, bson.D{{"$match", bson.D{{"emitterChain", sdk.ChainIDPythNet}}}}, pipeline[1])
+	assert.Contains(t, pipeline[3][0].Value, bson.E{"from", "vaasPythnet"})
+	assert.Equal(t, bson.D{{"$match", bson.D{{"txHash", "abcd"}}}}, pipeline[6])
+
+	// the query passed by the caller is not modified.
+	assert.Equal(t, "abcd", query.filters.txHash)
+}
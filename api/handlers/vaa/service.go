@@ -7,31 +7,47 @@ import (
 	"strconv"
 
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/pagination"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.uber.org/zap"
 )
 
+// vaaRepository decouples the service from the vaa repository.
+type vaaRepository interface {
+	FindVaas(ctx context.Context, q *VaaQuery) ([]*VaaDoc, error)
+	FindVaasByTxHashWorkaround(ctx context.Context, query *VaaQuery) ([]*VaaDoc, error)
+	FindVaasByEmitterAndToChain(ctx context.Context, query *VaaQuery, toChain sdk.ChainID) ([]*VaaDoc, error)
+	FindDuplicatedByID(ctx context.Context, chain sdk.ChainID, emitter *types.Address, seq string) ([]*VaaDoc, error)
+	GetVaaCount(ctx context.Context, q *VaaQuery) ([]*VaaStats, error)
+	Export(ctx context.Context, q *VaaExportQuery) (*mongo.Cursor, error)
+	FindExportNextCursor(ctx context.Context, q *VaaExportQuery) (*export.Cursor, error)
+}
+
 // Service definition.
 type Service struct {
-	repo         *Repository
-	getCacheFunc cache.CacheGetFunc
-	parseVaaFunc vaaPayloadParser.ParseVaaFunc
-	logger       *zap.Logger
+	repo            vaaRepository
+	guardianSetRepo guardianSetFinder
+	getCacheFunc    cache.CacheGetFunc
+	parseVaaFunc    vaaPayloadParser.ParseVaaFunc
+	logger          *zap.Logger
 }
 
 // NewService creates a new VAA Service.
-func NewService(r *Repository, getCacheFunc cache.CacheGetFunc, parseVaaFunc vaaPayloadParser.ParseVaaFunc, logger *zap.Logger) *Service {
+func NewService(r *Repository, guardianSetRepo *repository.GuardianSetRepository, getCacheFunc cache.CacheGetFunc, parseVaaFunc vaaPayloadParser.ParseVaaFunc, logger *zap.Logger) *Service {
 
 	s := Service{
-		repo:         r,
-		getCacheFunc: getCacheFunc,
-		parseVaaFunc: parseVaaFunc,
-		logger:       logger.With(zap.String("module", "VaaService")),
+		repo:            r,
+		guardianSetRepo: guardianSetRepo,
+		getCacheFunc:    getCacheFunc,
+		parseVaaFunc:    parseVaaFunc,
+		logger:          logger.With(zap.String("module", "VaaService")),
 	}
 
 	return &s
@@ -86,6 +102,44 @@ func (s *Service) FindAll(
 	return &res, nil
 }
 
+// ExportParams passes input data to the function `Export`.
+type ExportParams struct {
+	TxHash *types.TxHash
+	AppId  string
+	Cursor *export.Cursor
+	Limit  int64
+}
+
+// Export returns the VAAs matching the params, and the cursor to resume the export after them.
+// The cursor is nil if there are no more VAAs to export.
+func (s *Service) Export(ctx context.Context, params *ExportParams) (export.Source, *export.Cursor, error) {
+
+	// the export uses the same filters as FindAll
+	filters := Query()
+	if params.TxHash != nil {
+		filters.SetTxHash(params.TxHash.String())
+	}
+	query := VaaExportQuery{
+		filters: filters,
+		appId:   params.AppId,
+		cursor:  params.Cursor,
+		limit:   params.Limit,
+	}
+
+	next, err := s.repo.FindExportNextCursor(ctx, &query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the export stops at the next cursor, so it ends at the same VAA as the next export starts.
+	query.until = next
+	cur, err := s.repo.Export(ctx, &query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return export.NewCursorSource[VaaRecord](cur), next, nil
+}
+
 // FindByChain get all the vaa by chainID.
 func (s *Service) FindByChain(
 	ctx context.Context,
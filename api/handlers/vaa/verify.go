@@ -0,0 +1,179 @@
+package vaa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidVaa is returned when the bytes to verify are not a vaa.
+var ErrInvalidVaa = errors.New("INVALID VAA")
+
+// SignatureCheck is the result of the verification of a guardian signature.
+type SignatureCheck struct {
+	GuardianIndex uint8 `json:"guardianIndex"`
+	// Guardian is the address of the guardian at GuardianIndex in the guardian set, if it exists.
+	Guardian string `json:"guardian,omitempty"`
+	// Signer is the address recovered from the signature.
+	Signer string `json:"signer,omitempty"`
+	Valid  bool   `json:"valid"`
+}
+
+// VerifyResult is the result of the verification of a vaa.
+type VerifyResult struct {
+	ID     string `json:"id"`
+	Digest string `json:"digest"`
+	// Valid is true if the guardian set is known and not expired, every signature is valid and quorum was reached.
+	Valid                     bool              `json:"valid"`
+	GuardianSetIndex          uint32            `json:"guardianSetIndex"`
+	GuardianSetFound          bool              `json:"guardianSetFound"`
+	GuardianSetSize           int               `json:"guardianSetSize"`
+	GuardianSetExpired        bool              `json:"guardianSetExpired"`
+	GuardianSetExpirationTime *time.Time        `json:"guardianSetExpirationTime,omitempty"`
+	Signatures                []*SignatureCheck `json:"signatures"`
+	// SignedBy are the indices of the guardians with a valid signature.
+	SignedBy      []uint8 `json:"signedBy"`
+	Quorum        int     `json:"quorum"`
+	QuorumReached bool    `json:"quorumReached"`
+	// Indexed is true if the explorer has indexed a vaa with the same id.
+	Indexed bool `json:"indexed"`
+	// MatchesIndexed is true if the digest of the indexed vaa is the digest of the verified vaa.
+	MatchesIndexed bool     `json:"matchesIndexed"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// guardianSetFinder finds the guardian sets of the history by index.
+type guardianSetFinder interface {
+	FindByIndex(ctx context.Context, index uint32) (*repository.GuardianSetDoc, error)
+}
+
+// Verify checks the signatures of a vaa against the guardian set at its guardian set index, and compares it
+// with the vaa indexed by the explorer. It returns ErrInvalidVaa if vaaBytes can not be unmarshalled.
+func (s *Service) Verify(ctx context.Context, vaaBytes []byte) (*VerifyResult, error) {
+	v, err := sdk.Unmarshal(vaaBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVaa, err.Error())
+	}
+
+	result := &VerifyResult{
+		ID:               v.MessageID(),
+		Digest:           utils.NormalizeHex(v.HexDigest()),
+		GuardianSetIndex: v.GuardianSetIndex,
+		Signatures:       make([]*SignatureCheck, 0, len(v.Signatures)),
+		SignedBy:         []uint8{},
+	}
+
+	// get the guardian set of the vaa from the history
+	gs, err := s.guardianSetRepo.FindByIndex(ctx, v.GuardianSetIndex)
+	if err != nil {
+		requestID := fmt.Sprintf("%v", ctx.Value("requestid"))
+		s.logger.Error("failed to get guardian set", zap.Error(err),
+			zap.Uint32("index", v.GuardianSetIndex), zap.String("requestID", requestID))
+		return nil, errs.ErrInternalError
+	}
+	var keys []eth_common.Address
+	if gs == nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("guardian set %d not found", v.GuardianSetIndex))
+	} else {
+		result.GuardianSetFound = true
+		keys = guardianSetKeys(gs)
+		result.GuardianSetSize = len(keys)
+		result.Quorum = sdk.CalculateQuorum(len(keys))
+		if gs.ExpirationTime != nil && !gs.ExpirationTime.IsZero() {
+			result.GuardianSetExpirationTime = gs.ExpirationTime
+			if gs.ExpirationTime.Before(time.Now()) {
+				result.GuardianSetExpired = true
+				result.Errors = append(result.Errors, fmt.Sprintf("guardian set %d expired", v.GuardianSetIndex))
+			}
+		}
+	}
+
+	// check each signature against the guardian of its index. As in the core contracts, the signatures
+	// must be sorted by strictly ascending guardian index, which also rejects duplicated signatures.
+	allValid := true
+	digest := v.SigningDigest()
+	for i, sig := range v.Signatures {
+		check := &SignatureCheck{GuardianIndex: sig.Index}
+		ascending := i == 0 || sig.Index > v.Signatures[i-1].Index
+		if signer, err := recoverSigner(digest.Bytes(), sig.Signature[:]); err == nil {
+			check.Signer = utils.NormalizeHex(signer.Hex())
+			if int(sig.Index) < len(keys) {
+				check.Guardian = utils.NormalizeHex(keys[sig.Index].Hex())
+				check.Valid = signer == keys[sig.Index] && ascending
+			}
+		}
+		switch {
+		case check.Valid:
+			result.SignedBy = append(result.SignedBy, sig.Index)
+		case !ascending:
+			allValid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("signature of guardian %d is not in ascending guardian index order", sig.Index))
+		default:
+			allValid = false
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid signature of guardian %d", sig.Index))
+		}
+		result.Signatures = append(result.Signatures, check)
+	}
+
+	result.QuorumReached = result.GuardianSetFound && len(result.SignedBy) >= result.Quorum
+	if result.GuardianSetFound && !result.QuorumReached {
+		result.Errors = append(result.Errors, fmt.Sprintf("quorum not reached: %d of %d signatures", len(result.SignedBy), result.Quorum))
+	}
+	result.Valid = result.GuardianSetFound && !result.GuardianSetExpired && allValid && result.QuorumReached
+
+	// compare with the indexed vaa
+	emitter, err := types.BytesToAddress(v.EmitterAddress[:])
+	if err != nil {
+		return nil, errs.ErrInternalError
+	}
+	indexed, err := s.findById(ctx, v.EmitterChain, emitter, strconv.FormatUint(v.Sequence, 10), false)
+	switch {
+	case errors.Is(err, errs.ErrNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		result.compareIndexed(indexed)
+	}
+
+	return result, nil
+}
+
+// compareIndexed compares the digest of the verified vaa with the digest of the indexed vaa.
+func (r *VerifyResult) compareIndexed(indexed *VaaDoc) {
+	r.Indexed = true
+	r.MatchesIndexed = utils.NormalizeHex(indexed.Digest) == r.Digest
+	if !r.MatchesIndexed {
+		r.Errors = append(r.Errors, "digest does not match the indexed vaa")
+	}
+}
+
+// guardianSetKeys returns the addresses of the guardians of a guardian set, sorted by index.
+func guardianSetKeys(gs *repository.GuardianSetDoc) []eth_common.Address {
+	keys := make([]eth_common.Address, len(gs.Keys))
+	for _, k := range gs.Keys {
+		if int(k.Index) < len(keys) {
+			keys[k.Index] = eth_common.BytesToAddress(k.Address)
+		}
+	}
+	return keys
+}
+
+// recoverSigner returns the address of the key that signed digest.
+func recoverSigner(digest, signature []byte) (eth_common.Address, error) {
+	pubKey, err := crypto.Ecrecover(digest, signature)
+	if err != nil {
+		return eth_common.Address{}, err
+	}
+	return eth_common.BytesToAddress(crypto.Keccak256(pubKey[1:])[12:]), nil
+}
@@ -0,0 +1,199 @@
+package vaa
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	eth_common "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// stubGuardianSetFinder finds the guardian sets of a map.
+type stubGuardianSetFinder map[uint32]*repository.GuardianSetDoc
+
+func (f stubGuardianSetFinder) FindByIndex(_ context.Context, index uint32) (*repository.GuardianSetDoc, error) {
+	return f[index], nil
+}
+
+// memoryVaaRepository returns the same indexed vaas for every query.
+type memoryVaaRepository struct {
+	vaaRepository
+	docs []*VaaDoc
+}
+
+func (r *memoryVaaRepository) FindVaas(context.Context, *VaaQuery) ([]*VaaDoc, error) {
+	return r.docs, nil
+}
+
+// newGuardianSet returns the keys of a guardian set of the given size and its document.
+func newGuardianSet(t *testing.T, index uint32, size int, expirationTime *time.Time) ([]*ecdsa.PrivateKey, *repository.GuardianSetDoc) {
+	keys := make([]*ecdsa.PrivateKey, 0, size)
+	doc := &repository.GuardianSetDoc{GuardianSetIndex: index, ExpirationTime: expirationTime}
+	for i := 0; i < size; i++ {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys = append(keys, key)
+		doc.Keys = append(doc.Keys, repository.GuardianSetKeyDoc{Index: uint32(i), Address: crypto.PubkeyToAddress(key.PublicKey).Bytes()})
+	}
+	return keys, doc
+}
+
+// newSignedVaa returns a vaa of the guardian set signed by the guardians in the given order.
+func newSignedVaa(t *testing.T, guardianSetIndex uint32, keys []*ecdsa.PrivateKey, signers ...uint8) (*sdk.VAA, []byte) {
+	v := &sdk.VAA{
+		Version:          1,
+		GuardianSetIndex: guardianSetIndex,
+		Timestamp:        time.Unix(1700000000, 0),
+		EmitterChain:     sdk.ChainIDEthereum,
+		EmitterAddress:   sdk.Address{1},
+		Sequence:         7,
+		ConsistencyLevel: 1,
+		Payload:          []byte{1},
+	}
+	for _, index := range signers {
+		v.AddSignature(keys[index], index)
+	}
+	data, err := v.Marshal()
+	require.NoError(t, err)
+	return v, data
+}
+
+func newVerifyService(guardianSets stubGuardianSetFinder, indexed ...*VaaDoc) *Service {
+	return &Service{
+		repo:            &memoryVaaRepository{docs: indexed},
+		guardianSetRepo: guardianSets,
+		logger:          zap.NewNop(),
+	}
+}
+
+func TestVerify(t *testing.T) {
+	keys, gs := newGuardianSet(t, 4, 3, nil)
+	v, data := newSignedVaa(t, 4, keys, 0, 1, 2)
+	svc := newVerifyService(stubGuardianSetFinder{4: gs}, &VaaDoc{Digest: v.HexDigest()})
+
+	result, err := svc.Verify(context.Background(), data)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.True(t, result.GuardianSetFound)
+	assert.Equal(t, 3, result.GuardianSetSize)
+	assert.Equal(t, 3, result.Quorum)
+	assert.True(t, result.QuorumReached)
+	assert.Equal(t, []uint8{0, 1, 2}, result.SignedBy)
+	assert.True(t, result.Indexed)
+	assert.True(t, result.MatchesIndexed)
+	assert.Empty(t, result.Errors)
+}
+
+func TestVerify_ExpiredGuardianSet(t *testing.T) {
+	expirationTime := time.Now().Add(-time.Hour)
+	keys, gs := newGuardianSet(t, 3, 1, &expirationTime)
+	_, data := newSignedVaa(t, 3, keys, 0)
+	svc := newVerifyService(stubGuardianSetFinder{3: gs})
+
+	result, err := svc.Verify(context.Background(), data)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.True(t, result.GuardianSetExpired)
+	assert.True(t, result.QuorumReached)
+	assert.False(t, result.Indexed)
+	assert.Equal(t, []string{"guardian set 3 expired"}, result.Errors)
+}
+
+func TestVerify_DigestMismatch(t *testing.T) {
+	keys, gs := newGuardianSet(t, 4, 1, nil)
+	_, data := newSignedVaa(t, 4, keys, 0)
+	svc := newVerifyService(stubGuardianSetFinder{4: gs}, &VaaDoc{Digest: "0xabcd"})
+
+	result, err := svc.Verify(context.Background(), data)
+	require.NoError(t, err)
+	// the signatures are valid, but the explorer indexed a different vaa with the same id
+	assert.True(t, result.Valid)
+	assert.True(t, result.Indexed)
+	assert.False(t, result.MatchesIndexed)
+	assert.Equal(t, []string{"digest does not match the indexed vaa"}, result.Errors)
+}
+
+func TestVerify_SignaturesNotInAscendingOrder(t *testing.T) {
+	keys, gs := newGuardianSet(t, 4, 4, nil)
+	svc := newVerifyService(stubGuardianSetFinder{4: gs})
+
+	cases := []struct {
+		name     string
+		signers  []uint8
+		signedBy []uint8
+		errors   []string
+	}{
+		{
+			name:     "unsorted",
+			signers:  []uint8{1, 0, 2, 3},
+			signedBy: []uint8{1, 2, 3},
+			errors:   []string{"signature of guardian 0 is not in ascending guardian index order"},
+		},
+		{
+			name:     "duplicated",
+			signers:  []uint8{0, 1, 1, 2},
+			signedBy: []uint8{0, 1, 2},
+			errors:   []string{"signature of guardian 1 is not in ascending guardian index order"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, data := newSignedVaa(t, 4, keys, tc.signers...)
+			result, err := svc.Verify(context.Background(), data)
+			require.NoError(t, err)
+			assert.False(t, result.Valid)
+			assert.True(t, result.QuorumReached)
+			assert.Equal(t, tc.signedBy, result.SignedBy)
+			assert.Equal(t, tc.errors, result.Errors)
+		})
+	}
+}
+
+func TestVerify_GuardianSetNotFound(t *testing.T) {
+	keys, _ := newGuardianSet(t, 4, 1, nil)
+	_, data := newSignedVaa(t, 4, keys, 0)
+
+	result, err := newVerifyService(stubGuardianSetFinder{}).Verify(context.Background(), data)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.False(t, result.GuardianSetFound)
+	assert.Equal(t, []uint8{}, result.SignedBy)
+	assert.Equal(t, []string{"guardian set 4 not found", "invalid signature of guardian 0"}, result.Errors)
+}
+
+func TestVerify_InvalidVaa(t *testing.T) {
+	_, err := newVerifyService(stubGuardianSetFinder{}).Verify(context.Background(), []byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrInvalidVaa)
+}
+
+func TestRecoverSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	digest := crypto.Keccak256([]byte("vaa body"))
+	sig, err := crypto.Sign(digest, key)
+	assert.NoError(t, err)
+
+	signer, err := recoverSigner(digest, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	_, err = recoverSigner(digest, sig[:64])
+	assert.Error(t, err)
+}
+
+func TestGuardianSetKeys(t *testing.T) {
+	gs := &repository.GuardianSetDoc{Keys: []repository.GuardianSetKeyDoc{
+		{Index: 1, Address: []byte{2}},
+		{Index: 0, Address: []byte{1}},
+	}}
+	keys := guardianSetKeys(gs)
+	assert.Equal(t, []eth_common.Address{eth_common.BytesToAddress([]byte{1}), eth_common.BytesToAddress([]byte{2})}, keys)
+}
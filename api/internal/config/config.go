@@ -40,6 +40,8 @@ type AppConfig struct {
 		Prefix                   string
 		ProtocolsStatsKey        string
 		ProtocolsStatsExpiration int
+		// NotionalChannel is the pubsub channel where the notional job announces the updated token prices
+		NotionalChannel string
 	}
 	PORT         int
 	LogLevel     string
@@ -74,8 +76,22 @@ type AppConfig struct {
 		//Api Tokens
 		Tokens string
 	}
-	Protocols    []string
-	MayanBaseURL string
+	Admin struct {
+		// Api Tokens allowed to call the admin endpoints
+		Tokens string
+	}
+	Export struct {
+		// Max number of exports per api token and day
+		DailyQuota int
+	}
+	OpenAPI struct {
+		// Reject the requests whose query parameters do not match the OpenAPI document
+		ValidateRequests bool
+	}
+	Protocols []string
+	// ProtocolsJson declares the protocols whose stats are written by the protocols stats job, as its PROTOCOLS_JSON
+	ProtocolsJson string
+	MayanBaseURL  string
 }
 
 // GetLogLevel get zapcore.Level define in the configuraion.
@@ -94,6 +110,7 @@ func defaulConfig() *AppConfig {
 			Prefix                   string
 			ProtocolsStatsKey        string
 			ProtocolsStatsExpiration int
+			NotionalChannel          string
 		}{
 			MetricExpiration: 10,
 		},
@@ -141,3 +158,14 @@ func Get() (*AppConfig, error) {
 func (c *AppConfig) GetApiTokens() []string {
 	return strings.Split(c.RateLimit.Tokens, ",")
 }
+
+// GetAdminTokens returns the api tokens allowed to call the admin endpoints.
+func (c *AppConfig) GetAdminTokens() []string {
+	var tokens []string
+	for _, t := range strings.Split(c.Admin.Tokens, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,62 @@
+package export
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ErrInvalidCursor is returned when an export cursor can not be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of the last exported document. Exports are sorted by timestamp
+// and id in descending order, so an export resumed from a cursor returns the documents
+// older than it.
+type Cursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// Encode returns the opaque representation of the cursor sent to the clients.
+func (c *Cursor) Encode() string {
+	s := c.Timestamp.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeCursor decodes a cursor created by Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	timestamp, id, found := strings.Cut(string(b), "|")
+	if !found || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{Timestamp: t, ID: id}, nil
+}
+
+// Match returns the match stage of the documents after the cursor, using timestampField
+// as the timestamp of the documents.
+func (c *Cursor) Match(timestampField string) bson.D {
+	return bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{timestampField: bson.M{"$lt": c.Timestamp}},
+		bson.M{timestampField: c.Timestamp, "_id": bson.M{"$lt": c.ID}},
+	}}}}
+}
+
+// MatchUntil returns the match stage of the documents up to the cursor, including the document of
+// the cursor, using timestampField as the timestamp of the documents.
+func (c *Cursor) MatchUntil(timestampField string) bson.D {
+	return bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{timestampField: bson.M{"$gt": c.Timestamp}},
+		bson.M{timestampField: c.Timestamp, "_id": bson.M{"$gte": c.ID}},
+	}}}}
+}
@@ -0,0 +1,88 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type testRecord struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func (r *testRecord) Values() []string {
+	return []string{r.ID, r.Value}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatCSV, format)
+
+	format, err = ParseFormat("NDJSON")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatNDJSON, format)
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+}
+
+func TestCursor(t *testing.T) {
+	cursor := Cursor{
+		Timestamp: time.Date(2024, 5, 1, 10, 0, 0, 123000000, time.UTC),
+		ID:        "2/000000000000000000000000b6f6d86a8f9879a9c87f643768d9efc38c1da6e7/1",
+	}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, cursor.Timestamp.Equal(decoded.Timestamp))
+
+	_, err = DecodeCursor("invalid")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	expected := bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{"timestamp": bson.M{"$lt": cursor.Timestamp}},
+		bson.M{"timestamp": cursor.Timestamp, "_id": bson.M{"$lt": cursor.ID}},
+	}}}}
+	assert.Equal(t, expected, cursor.Match("timestamp"))
+
+	// the document of the cursor is the last one matched by MatchUntil and the first one not matched by Match.
+	expected = bson.D{{Key: "$match", Value: bson.M{"$or": bson.A{
+		bson.M{"timestamp": bson.M{"$gt": cursor.Timestamp}},
+		bson.M{"timestamp": cursor.Timestamp, "_id": bson.M{"$gte": cursor.ID}},
+	}}}}
+	assert.Equal(t, expected, cursor.MatchUntil("timestamp"))
+}
+
+func TestWriter(t *testing.T) {
+	records := []Record{
+		&testRecord{ID: "1", Value: "a,b"},
+		&testRecord{ID: "2"},
+	}
+
+	testCases := []struct {
+		format   Format
+		expected string
+	}{
+		{format: FormatCSV, expected: "id,value\n1,\"a,b\"\n2,\n"},
+		{format: FormatNDJSON, expected: "{\"id\":\"1\",\"value\":\"a,b\"}\n{\"id\":\"2\",\"value\":\"\"}\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(tc.format, &buf, []string{"id", "value"})
+			assert.NoError(t, err)
+			for _, r := range records {
+				assert.NoError(t, w.Write(r))
+			}
+			assert.NoError(t, w.Close())
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
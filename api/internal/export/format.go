@@ -0,0 +1,64 @@
+// Package export streams the results of the bulk export endpoints in CSV, NDJSON and Parquet.
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the output format of an export.
+type Format string
+
+// export format constants.
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat parses an export format. An empty value defaults to CSV.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown export format %s", s)
+	}
+}
+
+// ContentType returns the HTTP content type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Filename returns the name of the exported file for the given base name.
+func (f Format) Filename(name string) string {
+	return fmt.Sprintf("%s.%s", name, f)
+}
+
+// limit constants of the exports.
+const (
+	DefaultLimit int64 = 10_000
+	MaxLimit     int64 = 100_000
+)
+
+// HeaderNextCursor is the response header with the cursor to resume an export.
+const HeaderNextCursor = "X-Next-Cursor"
+
+// Params are the query parameters shared by all the exports.
+type Params struct {
+	Format Format
+	// Cursor is the position to resume the export from, nil to start from the latest document.
+	Cursor *Cursor
+	// Limit is the max number of records of the export.
+	Limit int64
+}
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+const (
+	// streamTimeout is the max duration of an export stream.
+	streamTimeout = 30 * time.Minute
+	// flushEvery is the number of records written between flushes of the response body.
+	flushEvery = 1000
+)
+
+// Source is an iterator over the records of an export.
+type Source interface {
+	// Next returns the next record, or io.EOF when there are no more records.
+	Next(ctx context.Context) (Record, error)
+	Close(ctx context.Context) error
+}
+
+type cursorSource[T any, R interface {
+	*T
+	Record
+}] struct {
+	cur *mongo.Cursor
+}
+
+// NewCursorSource creates a Source that decodes each document of a mongo cursor in a T.
+func NewCursorSource[T any, R interface {
+	*T
+	Record
+}](cur *mongo.Cursor) Source {
+	return &cursorSource[T, R]{cur: cur}
+}
+
+func (s *cursorSource[T, R]) Next(ctx context.Context) (Record, error) {
+	if !s.cur.Next(ctx) {
+		if err := s.cur.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	record := R(new(T))
+	if err := s.cur.Decode(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *cursorSource[T, R]) Close(ctx context.Context) error {
+	return s.cur.Close(ctx)
+}
+
+// Stream writes the records of source to the response body as they are read from the database,
+// so the export is never loaded in memory. The source is closed when the stream ends.
+func Stream(c *fiber.Ctx, format Format, name string, header []string, source Source, logger *zap.Logger) error {
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", format.Filename(name)))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// the request context is released when the handler returns, before the body is streamed.
+		ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+		defer cancel()
+		defer source.Close(ctx)
+
+		count, err := writeRecords(ctx, format, w, header, source)
+		if err != nil {
+			logger.Error("failed to stream export",
+				zap.String("name", name),
+				zap.String("format", string(format)),
+				zap.Int("records", count),
+				zap.Error(err))
+			return
+		}
+		logger.Debug("export streamed",
+			zap.String("name", name),
+			zap.String("format", string(format)),
+			zap.Int("records", count))
+	})
+	return nil
+}
+
+func writeRecords(ctx context.Context, format Format, w *bufio.Writer, header []string, source Source) (int, error) {
+	writer, err := NewWriter(format, w, header)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for {
+		record, err := source.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+		if err := writer.Write(record); err != nil {
+			return count, err
+		}
+		count++
+
+		// flush periodically so the client receives the records as they are read and
+		// the stream is aborted as soon as the client disconnects.
+		if count%flushEvery == 0 {
+			if err := writer.Flush(); err != nil {
+				return count, err
+			}
+			if err := w.Flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return count, err
+	}
+	return count, w.Flush()
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetRowGroupSize is the size of the row groups buffered before they are flushed to the stream.
+const parquetRowGroupSize = 8 * 1024 * 1024
+
+// Record is a row of an export.
+type Record interface {
+	// Values returns the column values of the record, in the same order as the header.
+	Values() []string
+}
+
+// Writer writes records to a stream in a given format.
+type Writer interface {
+	Write(record Record) error
+	// Flush writes the buffered records to the stream. Formats that buffer records in
+	// blocks, like Parquet row groups, flush them when the block is complete.
+	Flush() error
+	// Close flushes the buffered records and writes the trailer of the format, if any.
+	Close() error
+}
+
+// NewWriter creates a Writer of the given format with the column names of header.
+func NewWriter(format Format, w io.Writer, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, header)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatParquet:
+		return newParquetWriter(w, header)
+	default:
+		return nil, fmt.Errorf("unknown export format %s", format)
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, header []string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (w *csvWriter) Write(record Record) error {
+	return w.writer.Write(record.Values())
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvWriter) Close() error {
+	return w.Flush()
+}
+
+// ndjsonWriter writes the JSON representation of each record in a separate line.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(record Record) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return nil
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+// parquetWriter writes every column as an optional UTF8 string, empty values are written as null.
+type parquetWriter struct {
+	writer *writer.CSVWriter
+}
+
+func newParquetWriter(w io.Writer, header []string) (*parquetWriter, error) {
+	md := make([]string, 0, len(header))
+	for _, column := range header {
+		md = append(md, fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", column))
+	}
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.RowGroupSize = parquetRowGroupSize
+	return &parquetWriter{writer: pw}, nil
+}
+
+func (w *parquetWriter) Write(record Record) error {
+	values := record.Values()
+	row := make([]*string, len(values))
+	for i := range values {
+		if values[i] != "" {
+			row[i] = &values[i]
+		}
+	}
+	return w.writer.WriteString(row)
+}
+
+func (w *parquetWriter) Flush() error {
+	return nil
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.WriteStop()
+}
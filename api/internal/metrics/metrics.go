@@ -5,4 +5,7 @@ const serviceName = "wormscan-api"
 type Metrics interface {
 	IncExpiredCacheResponse(key string)
 	IncOrigin(origin string)
+	IncApiKeyRequest(id, owner string)
+	IncApiKeyLimited(id, owner, reason string)
+	IncResponseCache(group, status string)
 }
@@ -9,23 +9,27 @@ import (
 type PrometheusMetrics struct {
 	expiredCacheResponseCount *prometheus.CounterVec
 	originRequestsCount       *prometheus.CounterVec
+	apiKeyRequestsCount       *prometheus.CounterVec
+	apiKeyLimitedCount        *prometheus.CounterVec
+	responseCacheCount        *prometheus.CounterVec
 }
 
-// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
 	constLabels := map[string]string{
 		"environment": environment,
 		"service":     serviceName,
 	}
 
-	vaaTxTrackerCount := promauto.NewCounterVec(
+	vaaTxTrackerCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "expired_cache_response",
 			Help:        "Total expired cache response by key",
 			ConstLabels: constLabels,
 		}, []string{"key"})
 
-	originRequestsCount := promauto.NewCounterVec(
+	originRequestsCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "http_requests_origin_requests_total",
 			Help:        "Count all http requests by origin, method and path.",
@@ -34,9 +38,39 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		[]string{"origin"},
 	)
 
+	apiKeyRequestsCount := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "api_key_requests_total",
+			Help:        "Count all http requests made with a managed api key by key and owner.",
+			ConstLabels: constLabels,
+		},
+		[]string{"key", "owner"},
+	)
+
+	apiKeyLimitedCount := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "api_key_limited_requests_total",
+			Help:        "Count all http requests rejected by the limits of a managed api key by key, owner and reason.",
+			ConstLabels: constLabels,
+		},
+		[]string{"key", "owner", "reason"},
+	)
+
+	responseCacheCount := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "response_cache_requests_total",
+			Help:        "Count all http requests handled by the response cache by group and status.",
+			ConstLabels: constLabels,
+		},
+		[]string{"group", "status"},
+	)
+
 	return &PrometheusMetrics{
 		expiredCacheResponseCount: vaaTxTrackerCount,
 		originRequestsCount:       originRequestsCount,
+		apiKeyRequestsCount:       apiKeyRequestsCount,
+		apiKeyLimitedCount:        apiKeyLimitedCount,
+		responseCacheCount:        responseCacheCount,
 	}
 }
 
@@ -48,12 +82,30 @@ func (m *PrometheusMetrics) IncOrigin(origin string) {
 	m.originRequestsCount.WithLabelValues(origin).Inc()
 }
 
+func (m *PrometheusMetrics) IncApiKeyRequest(id, owner string) {
+	m.apiKeyRequestsCount.WithLabelValues(id, owner).Inc()
+}
+
+func (m *PrometheusMetrics) IncApiKeyLimited(id, owner, reason string) {
+	m.apiKeyLimitedCount.WithLabelValues(id, owner, reason).Inc()
+}
+
+func (m *PrometheusMetrics) IncResponseCache(group, status string) {
+	m.responseCacheCount.WithLabelValues(group, status).Inc()
+}
+
 type noOpMetrics struct{}
 
 func (s *noOpMetrics) IncExpiredCacheResponse(_ string) {}
 
 func (s *noOpMetrics) IncOrigin(_ string) {}
 
+func (s *noOpMetrics) IncApiKeyRequest(_, _ string) {}
+
+func (s *noOpMetrics) IncApiKeyLimited(_, _, _ string) {}
+
+func (s *noOpMetrics) IncResponseCache(_, _ string) {}
+
 func NewNoOpMetrics() Metrics {
 	return &noOpMetrics{}
 }
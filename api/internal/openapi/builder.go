@@ -0,0 +1,291 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+)
+
+// Param describes a parameter of an operation.
+type Param struct {
+	Name        string
+	Description string
+	Required    bool
+	// Type is the JSON schema type of the parameter, string by default.
+	Type string
+	Enum []any
+	// Min and Max bound integer parameters when set.
+	Min, Max *float64
+}
+
+// Spec describes an operation of a route registered in fiber.
+type Spec struct {
+	ID          string
+	Summary     string
+	Description string
+	Tags        []string
+	// PathParams overrides the parameters inferred from the path of the route.
+	PathParams  []Param
+	QueryParams []Param
+	Headers     []Param
+	// Body is a value of the type of the request body, if any.
+	Body any
+	// Response is a value of the type of the JSON response, nil for operations without JSON response.
+	Response any
+	// Status is the status of a successful response, 200 by default.
+	Status int
+	// ContentType is the type of non JSON responses, e.g. text/csv.
+	ContentType string
+}
+
+// Builder builds the OpenAPI document of the routes of a fiber app.
+type Builder struct {
+	info      Info
+	generator *Generator
+	specs     map[string]*Spec
+}
+
+// NewBuilder creates a new Builder.
+func NewBuilder(info Info) *Builder {
+	return &Builder{
+		info:      info,
+		generator: NewGenerator(),
+		specs:     make(map[string]*Spec),
+	}
+}
+
+// Generator returns the schema generator of the builder.
+func (b *Builder) Generator() *Generator {
+	return b.generator
+}
+
+// Describe sets the spec of the route with the given method and fiber path, e.g. /api/v1/vaas/:chain.
+func (b *Builder) Describe(method, path string, spec Spec) {
+	b.specs[routeKey(method, path)] = &spec
+}
+
+// Spec returns the spec of a route, or nil if it was not described.
+func (b *Builder) Spec(method, path string) *Spec {
+	return b.specs[routeKey(method, path)]
+}
+
+// Build returns the document of the routes whose path starts with one of prefixes.
+// Routes that were not described are documented with their path parameters only.
+func (b *Builder) Build(routes []fiber.Route, prefixes ...string) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    b.info,
+		Paths:   make(map[string]*PathItem),
+	}
+
+	errorSchema := b.generator.SchemaOf(response.APIError{})
+	for _, route := range routes {
+		if !isOperationMethod(route.Method) || !hasPrefix(route.Path, prefixes) {
+			continue
+		}
+		path := normalizePath(route.Path)
+		item, ok := doc.Paths[toOpenAPIPath(path)]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[toOpenAPIPath(path)] = item
+		}
+		method := strings.ToLower(route.Method)
+		if _, ok := (*item)[method]; ok {
+			continue
+		}
+		(*item)[method] = b.operation(route.Method, path, errorSchema)
+	}
+
+	doc.Components.Schemas = b.generator.Schemas()
+	return doc
+}
+
+func (b *Builder) operation(method, path string, errorSchema *Schema) *Operation {
+	spec := b.specs[routeKey(method, path)]
+	if spec == nil {
+		spec = &Spec{}
+	}
+
+	op := &Operation{
+		OperationID: spec.ID,
+		Summary:     spec.Summary,
+		Description: spec.Description,
+		Tags:        spec.Tags,
+		Responses:   make(map[string]*Response),
+	}
+
+	// path parameters are inferred from the route, and can be described by the spec
+	described := make(map[string]Param)
+	for _, p := range spec.PathParams {
+		described[p.Name] = p
+	}
+	for _, name := range pathParams(path) {
+		p, ok := described[name]
+		if !ok {
+			p = Param{Name: name}
+		}
+		p.Required = true
+		op.Parameters = append(op.Parameters, b.parameter(p, InPath))
+	}
+	for _, p := range spec.QueryParams {
+		op.Parameters = append(op.Parameters, b.parameter(p, InQuery))
+	}
+	for _, p := range spec.Headers {
+		op.Parameters = append(op.Parameters, b.parameter(p, InHeader))
+	}
+
+	if spec.Body != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: b.generator.SchemaOf(spec.Body)}},
+		}
+	}
+
+	status := spec.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status)}
+	switch {
+	case spec.ContentType != "":
+		success.Content = map[string]*MediaType{spec.ContentType: {Schema: &Schema{Type: []string{TypeString}, Format: "binary"}}}
+	case spec.Response != nil:
+		success.Content = map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: b.generator.SchemaOf(spec.Response)}}
+	}
+	op.Responses[fmt.Sprint(status)] = success
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: errorSchema}},
+	}
+	return op
+}
+
+func (b *Builder) parameter(p Param, in string) *Parameter {
+	t := p.Type
+	if t == "" {
+		t = TypeString
+	}
+	return &Parameter{
+		Name:        p.Name,
+		In:          in,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema:      &Schema{Type: []string{t}, Enum: p.Enum, Minimum: p.Min, Maximum: p.Max},
+	}
+}
+
+// Operation returns the operation of the document that matches a request, and its path template.
+func (doc *Document) Operation(method, path string) (*Operation, string) {
+	method = strings.ToLower(method)
+	segments := splitPath(normalizePath(path))
+
+	// static segments take precedence over parameters, as in the fiber router
+	var candidates []string
+	for template := range doc.Paths {
+		if matchPath(splitPath(template), segments) {
+			candidates = append(candidates, template)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return strings.Count(candidates[i], "{") < strings.Count(candidates[j], "{")
+	})
+	for _, template := range candidates {
+		if op, ok := (*doc.Paths[template])[method]; ok {
+			return op, template
+		}
+	}
+	return nil, ""
+}
+
+// Param returns the parameter of an operation with the given name and location.
+func (op *Operation) Param(name, in string) *Parameter {
+	for _, p := range op.Parameters {
+		if p.Name == name && p.In == in {
+			return p
+		}
+	}
+	return nil
+}
+
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + normalizePath(path)
+}
+
+func isOperationMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePath removes the trailing slash of a path, and adds the leading one.
+func normalizePath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// toOpenAPIPath converts the parameters of a fiber path to OpenAPI, e.g. /vaas/:chain to /vaas/{chain}.
+func toOpenAPIPath(path string) string {
+	segments := splitPath(path)
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") {
+			segments[i] = "{" + strings.TrimSuffix(strings.TrimPrefix(s, ":"), "?") + "}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// pathParams returns the names of the parameters of a fiber path.
+func pathParams(path string) []string {
+	var names []string
+	for _, s := range splitPath(path) {
+		if strings.HasPrefix(s, ":") {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(s, ":"), "?"))
+		}
+	}
+	return names
+}
+
+func matchPath(template, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, t := range template {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			if segments[i] == "" {
+				return false
+			}
+			continue
+		}
+		if t != segments[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+// Package openapi generates the OpenAPI 3.1 document of the API from its route registrations
+// and response structs, and validates requests and responses against it.
+package openapi
+
+import "encoding/json"
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a server of the API.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem are the operations of a path, by lowercase http method.
+type PathItem map[string]*Operation
+
+// Operation is an operation of a path.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path, query or header parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the content of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components are the reusable schemas of the document.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a JSON schema.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	OneOf       []*Schema          `json:"oneOf,omitempty"`
+	Type        []string           `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Enum        []any              `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	// AdditionalProperties is nil when any property is allowed.
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// Closed disallows the properties that are not in Properties.
+	Closed bool `json:"-"`
+}
+
+// JSON schema types.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeNull    = "null"
+)
+
+// Parameter locations.
+const (
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+)
+
+// MarshalJSON writes additionalProperties false for closed objects, which has no Schema representation.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	if !s.Closed {
+		return json.Marshal((*alias)(s))
+	}
+	return json.Marshal(&struct {
+		*alias
+		AdditionalProperties bool `json:"additionalProperties"`
+	}{alias: (*alias)(s)})
+}
+
+// HasType reports whether t is one of the types of the schema.
+func (s *Schema) HasType(t string) bool {
+	for _, st := range s.Type {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Nullable returns a copy of the schema that also accepts null.
+func (s *Schema) Nullable() *Schema {
+	if s.Ref != "" {
+		return &Schema{OneOf: []*Schema{{Ref: s.Ref}, {Type: []string{TypeNull}}}}
+	}
+	if len(s.Type) == 0 || s.HasType(TypeNull) {
+		return s
+	}
+	n := *s
+	n.Type = append(append([]string{}, s.Type...), TypeNull)
+	return &n
+}
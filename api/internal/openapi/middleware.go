@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+)
+
+// Validator validates requests and responses against an OpenAPI document.
+// The document is set after the routes are registered, so the middleware can be installed before them.
+type Validator struct {
+	doc atomic.Pointer[Document]
+}
+
+// NewValidator creates a new Validator.
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// SetDocument sets the document used to validate requests.
+func (v *Validator) SetDocument(doc *Document) {
+	v.doc.Store(doc)
+}
+
+// Handler returns a middleware that validates the query parameters of the requests of the operations in the document.
+// Requests that do not match any operation are not validated.
+func (v *Validator) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		doc := v.doc.Load()
+		if doc == nil {
+			return c.Next()
+		}
+		op, _ := doc.Operation(c.Method(), c.Path())
+		if op == nil {
+			return c.Next()
+		}
+		if err := ValidateQuery(op, c.Queries()); err != nil {
+			return response.NewInvalidQueryParamError(c, strings.ToUpper(err.Error()), err)
+		}
+		return c.Next()
+	}
+}
+
+// ValidateQuery validates the query parameters of a request against the parameters of an operation.
+func ValidateQuery(op *Operation, query map[string]string) error {
+	for _, p := range op.Parameters {
+		if p.In != InQuery {
+			continue
+		}
+		value, ok := query[p.Name]
+		if !ok || value == "" {
+			if p.Required {
+				return fmt.Errorf("missing query parameter %s", p.Name)
+			}
+			continue
+		}
+		if err := validateParam(p, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateParam(p *Parameter, value string) error {
+	s := p.Schema
+	if s == nil {
+		return nil
+	}
+
+	var number *float64
+	switch {
+	case s.HasType(TypeInteger):
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("query parameter %s must be an integer", p.Name)
+		}
+		f := float64(n)
+		number = &f
+	case s.HasType(TypeNumber):
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("query parameter %s must be a number", p.Name)
+		}
+		number = &f
+	case s.HasType(TypeBoolean):
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("query parameter %s must be a boolean", p.Name)
+		}
+	}
+
+	if number != nil {
+		if s.Minimum != nil && *number < *s.Minimum {
+			return fmt.Errorf("query parameter %s must be greater than or equal to %v", p.Name, *s.Minimum)
+		}
+		if s.Maximum != nil && *number > *s.Maximum {
+			return fmt.Errorf("query parameter %s must be less than or equal to %v", p.Name, *s.Maximum)
+		}
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return fmt.Errorf("query parameter %s must be one of %v", p.Name, s.Enum)
+	}
+	return nil
+}
+
+// ValidateResponse validates the body of a response against the schema of the operation that matches the request.
+// It is meant to be used in tests, to detect handlers whose response diverges from the document.
+func (doc *Document) ValidateResponse(method, path string, status int, body []byte) error {
+	op, template := doc.Operation(method, path)
+	if op == nil {
+		return fmt.Errorf("no operation for %s %s", method, path)
+	}
+	r, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		r, ok = op.Responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("%s %s: undocumented status %d", method, template, status)
+	}
+	mt, ok := r.Content[fiber.MIMEApplicationJSON]
+	if !ok {
+		// responses that are not JSON are not validated
+		return nil
+	}
+	if err := doc.ValidateJSON(mt.Schema, body); err != nil {
+		return fmt.Errorf("%s %s: %w", method, template, err)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+)
+
+type testItem struct {
+	ID       string     `json:"id"`
+	Count    int        `json:"count"`
+	Label    string     `json:"label,omitempty"`
+	Tags     []string   `json:"tags"`
+	Time     *time.Time `json:"time"`
+	Amount   uint64     `json:"amount,string"`
+	Next     *testItem  `json:"next,omitempty"`
+	internal string
+	Ignored  string `json:"-"`
+}
+
+type testCustom struct {
+	Sequence string `json:"-"`
+}
+
+func (c *testCustom) MarshalJSON() ([]byte, error) {
+	return []byte(`{"sequence":1}`), nil
+}
+
+func TestSchemaOf(t *testing.T) {
+	g := NewGenerator()
+
+	s := g.SchemaOf(testItem{})
+	assert.Equal(t, "#/components/schemas/openapi.testItem", s.Ref)
+
+	item := g.Schemas()["openapi.testItem"]
+	if assert.NotNil(t, item) {
+		assert.True(t, item.Closed)
+		assert.Equal(t, []string{"id", "count", "tags", "time", "amount"}, item.Required)
+		assert.Equal(t, []string{TypeString}, item.Properties["id"].Type)
+		assert.Equal(t, []string{TypeInteger}, item.Properties["count"].Type)
+		assert.Equal(t, []string{TypeArray, TypeNull}, item.Properties["tags"].Type)
+		assert.Equal(t, []string{TypeString, TypeNull}, item.Properties["time"].Type)
+		assert.Equal(t, "date-time", item.Properties["time"].Format)
+		assert.Equal(t, []string{TypeString}, item.Properties["amount"].Type)
+		// recursive types reference their own schema
+		assert.Equal(t, "#/components/schemas/openapi.testItem", item.Properties["next"].OneOf[0].Ref)
+		assert.NotContains(t, item.Properties, "internal")
+		assert.NotContains(t, item.Properties, "-")
+	}
+
+	s = g.SchemaOf(response.Response[[]*testItem]{})
+	assert.Equal(t, "#/components/schemas/response.Response_Listopenapi.testItem", s.Ref)
+
+	// custom marshalers are open, and can be completed with a patch
+	assert.False(t, g.schemas[refName(g.SchemaOf(testCustom{}))].Closed)
+	g = NewGenerator()
+	g.Patch(testCustom{}, func(s *Schema) {
+		s.Properties["sequence"] = &Schema{Type: []string{TypeInteger}}
+		s.Closed = true
+	})
+	custom := g.schemas[refName(g.SchemaOf(&testCustom{}).OneOf[0])]
+	assert.True(t, custom.Closed)
+	assert.Contains(t, custom.Properties, "sequence")
+}
+
+func TestValidateJSON(t *testing.T) {
+	g := NewGenerator()
+	schema := g.SchemaOf(response.Response[[]*testItem]{})
+	doc := &Document{Components: Components{Schemas: g.Schemas()}}
+
+	now := time.Now()
+	valid, _ := json.Marshal(response.Response[[]*testItem]{
+		Data: []*testItem{
+			{ID: "1", Count: 2, Time: &now, Amount: 3, Next: &testItem{ID: "2"}},
+			nil,
+		},
+	})
+	assert.NoError(t, doc.ValidateJSON(schema, valid))
+
+	testCases := []struct {
+		name string
+		body string
+		path string
+	}{
+		{"missing required property", `{"data":[{"id":"1","count":1,"tags":null,"time":null}],"pagination":{"next":""}}`, "$.data[0]"},
+		{"wrong type", `{"data":[{"id":1,"count":1,"tags":null,"time":null,"amount":"1"}],"pagination":{"next":""}}`, "$.data[0].id"},
+		{"number for integer", `{"data":[{"id":"1","count":1.5,"tags":null,"time":null,"amount":"1"}],"pagination":{"next":""}}`, "$.data[0].count"},
+		{"unexpected property", `{"data":[{"id":"1","count":1,"tags":null,"time":null,"amount":"1","other":true}],"pagination":{"next":""}}`, "$.data[0].other"},
+		{"nested", `{"data":[{"id":"1","count":1,"tags":[1],"time":null,"amount":"1"}],"pagination":{"next":""}}`, "$.data[0].tags[0]"},
+		{"invalid json", `{"data":`, "$"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := doc.ValidateJSON(schema, []byte(tc.body))
+			var verr *ValidationError
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, tc.path, verr.Path)
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	app := fiber.New()
+	noop := func(c *fiber.Ctx) error { return nil }
+	api := app.Group("/api/v1")
+	api.Get("/items/", noop)
+	api.Get("/items/count", noop)
+	api.Get("/items/:chain/:id", noop)
+	api.Post("/items", noop)
+	app.Get("/other", noop)
+
+	min1 := 1.0
+	b := NewBuilder(Info{Title: "test", Version: "1"})
+	b.Describe(http.MethodGet, "/api/v1/items", Spec{
+		ID:          "find-items",
+		QueryParams: []Param{{Name: "pageSize", Type: TypeInteger, Min: &min1}, {Name: "by", Enum: []any{"a", "b"}}},
+		Response:    response.Response[[]*testItem]{},
+	})
+	b.Describe(http.MethodGet, "/api/v1/items/:chain/:id", Spec{
+		PathParams: []Param{{Name: "chain", Type: TypeInteger}},
+		Response:   &testItem{},
+	})
+	b.Describe(http.MethodPost, "/api/v1/items", Spec{Body: testItem{}, Status: http.StatusCreated})
+	doc := b.Build(app.GetRoutes(true), "/api/v1")
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 3)
+	assert.NotContains(t, doc.Paths, "/other")
+
+	op, template := doc.Operation(http.MethodGet, "/api/v1/items/count")
+	assert.Equal(t, "/api/v1/items/count", template)
+	assert.NotNil(t, op)
+
+	op, template = doc.Operation(http.MethodGet, "/api/v1/items/2/abc/")
+	assert.Equal(t, "/api/v1/items/{chain}/{id}", template)
+	if assert.NotNil(t, op) && assert.Len(t, op.Parameters, 2) {
+		assert.Equal(t, []string{TypeInteger}, op.Param("chain", InPath).Schema.Type)
+		assert.True(t, op.Param("id", InPath).Required)
+	}
+
+	op, _ = doc.Operation(http.MethodPost, "/api/v1/items")
+	if assert.NotNil(t, op) {
+		assert.NotNil(t, op.RequestBody)
+		assert.Contains(t, op.Responses, "201")
+		assert.Contains(t, op.Responses, "default")
+	}
+
+	op, _ = doc.Operation(http.MethodDelete, "/api/v1/items")
+	assert.Nil(t, op)
+
+	// the document is valid JSON
+	_, err := json.Marshal(doc)
+	assert.NoError(t, err)
+
+	// responses
+	assert.NoError(t, doc.ValidateResponse(http.MethodGet, "/api/v1/items", http.StatusOK, []byte(`{"data":[],"pagination":{"next":""}}`)))
+	assert.Error(t, doc.ValidateResponse(http.MethodGet, "/api/v1/items", http.StatusOK, []byte(`{"data":{},"pagination":{"next":""}}`)))
+	assert.NoError(t, doc.ValidateResponse(http.MethodGet, "/api/v1/items", http.StatusBadRequest, []byte(`{"code":3,"message":"error","details":[{"request_id":"1"}]}`)))
+	assert.Error(t, doc.ValidateResponse(http.MethodGet, "/api/v1/unknown", http.StatusOK, []byte(`{}`)))
+}
+
+func TestValidator(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			if apiErr, ok := err.(response.APIError); ok {
+				return c.Status(apiErr.StatusCode).JSON(apiErr)
+			}
+			return fiber.DefaultErrorHandler(c, err)
+		},
+	})
+	v := NewValidator()
+	app.Use(v.Handler())
+	app.Get("/api/v1/items", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	min1 := 1.0
+	b := NewBuilder(Info{})
+	b.Describe(http.MethodGet, "/api/v1/items", Spec{
+		QueryParams: []Param{
+			{Name: "pageSize", Type: TypeInteger, Min: &min1},
+			{Name: "parsed", Type: TypeBoolean},
+			{Name: "by", Enum: []any{"notional", "tx"}},
+			{Name: "q", Required: true},
+		},
+	})
+
+	testCases := []struct {
+		name       string
+		url        string
+		statusCode int
+	}{
+		{"valid", "/api/v1/items?q=1&pageSize=10&parsed=true&by=tx", http.StatusOK},
+		{"missing required", "/api/v1/items?pageSize=10", http.StatusBadRequest},
+		{"invalid integer", "/api/v1/items?q=1&pageSize=ten", http.StatusBadRequest},
+		{"below minimum", "/api/v1/items?q=1&pageSize=0", http.StatusBadRequest},
+		{"invalid boolean", "/api/v1/items?q=1&parsed=maybe", http.StatusBadRequest},
+		{"invalid enum", "/api/v1/items?q=1&by=volume", http.StatusBadRequest},
+		{"not documented", "/api/v1/other", http.StatusNotFound},
+	}
+
+	// requests are not validated until the document is set
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/items", nil))
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	v.SetDocument(b.Build(app.GetRoutes(true)))
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tc.url, nil))
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.statusCode, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func refName(s *Schema) string {
+	const prefix = "#/components/schemas/"
+	return s.Ref[len(prefix):]
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	schemaNameRegex   = regexp.MustCompile(`[^A-Za-z0-9_.]+`)
+)
+
+// Generator generates the schemas of Go types from their JSON encoding.
+// Named struct types are added to the components of the document and referenced.
+type Generator struct {
+	schemas   map[string]*Schema
+	names     map[reflect.Type]string
+	overrides map[reflect.Type]*Schema
+	patches   map[reflect.Type]func(*Schema)
+}
+
+// NewGenerator creates a new Generator.
+func NewGenerator() *Generator {
+	return &Generator{
+		schemas:   make(map[string]*Schema),
+		names:     make(map[reflect.Type]string),
+		overrides: make(map[reflect.Type]*Schema),
+		patches:   make(map[reflect.Type]func(*Schema)),
+	}
+}
+
+// Override sets the schema of the type of v, for the types whose JSON encoding can not be inferred from their fields.
+func (g *Generator) Override(v any, schema *Schema) {
+	g.overrides[reflect.TypeOf(v)] = schema
+}
+
+// Patch sets a function that completes the schema generated from the fields of the struct type of v,
+// for the structs whose custom marshaler adds or changes properties.
+func (g *Generator) Patch(v any, patch func(*Schema)) {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	g.patches[t] = patch
+}
+
+// Schemas returns the schemas of the named types generated so far, by name.
+func (g *Generator) Schemas() map[string]*Schema {
+	return g.schemas
+}
+
+// SchemaOf returns the schema of the JSON encoding of v.
+func (g *Generator) SchemaOf(v any) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	return g.schemaOf(reflect.TypeOf(v))
+}
+
+func (g *Generator) schemaOf(t reflect.Type) *Schema {
+	if s, ok := g.overrides[t]; ok {
+		return s
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: []string{TypeString}, Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		return g.schemaOf(t.Elem()).Nullable()
+	case implements(t, jsonMarshalerType):
+		// the encoding of custom marshalers can not be inferred, except for the structs
+		// that extend the encoding of their fields
+		if t.Kind() != reflect.Struct || !hasExportedFields(t) {
+			return &Schema{}
+		}
+	case implements(t, textMarshalerType):
+		return &Schema{Type: []string{TypeString}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: []string{TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: []string{TypeInteger}}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: []string{TypeNumber}}
+	case reflect.String:
+		return &Schema{Type: []string{TypeString}}
+	case reflect.Slice:
+		// []byte is encoded as a base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: []string{TypeString, TypeNull}, Format: "byte"}
+		}
+		// nil slices are encoded as null
+		return &Schema{Type: []string{TypeArray, TypeNull}, Items: g.schemaOf(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: []string{TypeArray}, Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: []string{TypeObject, TypeNull}, AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+// structSchema returns the schema of a struct, or a reference to it for named structs.
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	name := g.schemaName(t)
+	g.names[t] = name
+	// reserve the name before generating the fields, so recursive types reference it
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.objectSchema(t)
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// objectSchema returns the schema of the fields of a struct, following the rules of encoding/json.
+func (g *Generator) objectSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:       []string{TypeObject},
+		Properties: make(map[string]*Schema),
+		// structs with a custom marshaler usually add or rename fields, so they are not closed
+		Closed: !implements(t, jsonMarshalerType),
+	}
+	g.addFields(s, t)
+	if patch, ok := g.patches[t]; ok {
+		patch(s)
+	}
+	return s
+}
+
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// embedded structs without name are flattened
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fs := g.schemaOf(f.Type)
+		if strings.Contains(opts, "string") {
+			fs = &Schema{Type: []string{TypeString}}
+		}
+		s.Properties[name] = fs
+		if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// implements reports whether t or a pointer to t implements the interface it.
+func implements(t, it reflect.Type) bool {
+	return t.Implements(it) || reflect.PointerTo(t).Implements(it)
+}
+
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaName returns a readable and unique name for a named type, e.g. response.Response_Listvaa.VaaDoc
+// for the generic type response.Response[[]*vaa.VaaDoc].
+func (g *Generator) schemaName(t reflect.Type) string {
+	base := t.Name()
+	if i := strings.Index(base, "["); i >= 0 {
+		// drop the package path of the type arguments
+		args := strings.Split(strings.TrimSuffix(base[i+1:], "]"), ",")
+		for j, arg := range args {
+			prefix := ""
+			if strings.HasPrefix(arg, "[]") {
+				prefix = "List"
+			}
+			if k := strings.LastIndex(arg, "/"); k >= 0 {
+				arg = arg[k+1:]
+			}
+			args[j] = prefix + strings.TrimLeft(arg, "[]*")
+		}
+		base = base[:i] + "_" + strings.Join(args, "_")
+	}
+	pkg := t.PkgPath()
+	if k := strings.LastIndex(pkg, "/"); k >= 0 {
+		pkg = pkg[k+1:]
+	}
+	name := schemaNameRegex.ReplaceAllString(pkg+"."+base, "_")
+	name = strings.Trim(name, "_")
+
+	// types with the same name in packages with the same name
+	unique := name
+	for i := 2; g.schemas[unique] != nil; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	return unique
+}
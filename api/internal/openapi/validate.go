@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidationError is an error of the validation of a value against a schema.
+type ValidationError struct {
+	// Path is the JSON path of the invalid value, e.g. $.data[0].id.
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidateJSON validates a JSON document against a schema. References are resolved in the components of doc.
+func (doc *Document) ValidateJSON(schema *Schema, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Path: "$", Message: "invalid JSON: " + err.Error()}
+	}
+	return doc.validate(schema, value, "$", 0)
+}
+
+// maxValidationDepth bounds the resolution of recursive references.
+const maxValidationDepth = 64
+
+func (doc *Document) validate(schema *Schema, value any, path string, depth int) error {
+	if schema == nil {
+		return nil
+	}
+	if depth > maxValidationDepth {
+		return &ValidationError{Path: path, Message: "max depth exceeded"}
+	}
+
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		if !ok {
+			return &ValidationError{Path: path, Message: "unknown schema " + schema.Ref}
+		}
+		return doc.validate(resolved, value, path, depth+1)
+	}
+
+	if len(schema.OneOf) > 0 {
+		var matches int
+		var firstErr error
+		for _, s := range schema.OneOf {
+			if err := doc.validate(s, value, path, depth+1); err == nil {
+				matches++
+			} else if firstErr == nil {
+				firstErr = err
+			}
+		}
+		if matches != 1 {
+			if firstErr != nil && matches == 0 {
+				return firstErr
+			}
+			return &ValidationError{Path: path, Message: fmt.Sprintf("matches %d schemas of oneOf", matches)}
+		}
+		return nil
+	}
+
+	if len(schema.Type) > 0 && !schema.HasType(jsonType(value)) {
+		// integers are also numbers
+		if !(jsonType(value) == TypeInteger && schema.HasType(TypeNumber)) {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("expected %s, got %s", strings.Join(schema.Type, " or "), jsonType(value))}
+		}
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		return &ValidationError{Path: path, Message: fmt.Sprintf("value %v is not one of %v", value, schema.Enum)}
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return &ValidationError{Path: path, Message: "invalid number"}
+		}
+		if schema.Minimum != nil && f < *schema.Minimum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("%v is less than %v", v, *schema.Minimum)}
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			return &ValidationError{Path: path, Message: fmt.Sprintf("%v is greater than %v", v, *schema.Maximum)}
+		}
+	case []any:
+		for i, item := range v {
+			if err := doc.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i), depth+1); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if _, ok := v[name]; !ok {
+				return &ValidationError{Path: path, Message: "missing required property " + name}
+			}
+		}
+		// sort the properties so the reported error is deterministic
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propertyPath := path + "." + name
+			if ps, ok := schema.Properties[name]; ok {
+				if err := doc.validate(ps, v[name], propertyPath, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if schema.Closed {
+				return &ValidationError{Path: propertyPath, Message: "unexpected property"}
+			}
+			if err := doc.validate(schema.AdditionalProperties, v[name], propertyPath, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonType returns the JSON schema type of a decoded JSON value.
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return TypeNull
+	case bool:
+		return TypeBoolean
+	case string:
+		return TypeString
+	case json.Number:
+		if _, err := v.Int64(); err == nil || !strings.ContainsAny(v.String(), ".eE") {
+			return TypeInteger
+		}
+		return TypeNumber
+	case []any:
+		return TypeArray
+	case map[string]any:
+		return TypeObject
+	default:
+		return ""
+	}
+}
+
+func inEnum(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
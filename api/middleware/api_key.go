@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+)
+
+const apiKeyIdentityLocal = "apiKeyIdentity"
+
+// ApiKeyAuthenticator resolves and accounts the api keys of the requests.
+type ApiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*apikeys.Identity, error)
+	Track(ctx context.Context, identity *apikeys.Identity) error
+}
+
+// ApiKeyAuth resolves the api key of the X-API-KEY header and stores its identity in the request locals.
+// Requests without a known api key are handled as anonymous, requests with an invalid managed api key are
+// rejected, and requests exceeding the limits of their api key are rejected with a 429.
+func ApiKeyAuth(auth ApiKeyAuthenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("X-API-KEY")
+		if key == "" {
+			return c.Next()
+		}
+
+		identity, err := auth.Authenticate(c.Context(), key)
+		if errors.Is(err, apikeys.ErrInvalidApiKey) {
+			return response.NewApiError(c, fiber.StatusUnauthorized, response.Unauthenticated, err.Error(), nil)
+		}
+		if err != nil {
+			return err
+		}
+		if identity == nil {
+			return c.Next()
+		}
+
+		if err := auth.Track(c.Context(), identity); err != nil {
+			return response.NewApiError(c, fiber.StatusTooManyRequests, response.ResourceExhausted, err.Error(), nil)
+		}
+
+		c.Locals(apiKeyIdentityLocal, identity)
+		return c.Next()
+	}
+}
+
+// GetApiKeyIdentity returns the identity of the api key of the request, or nil if the request is anonymous.
+func GetApiKeyIdentity(c *fiber.Ctx) *apikeys.Identity {
+	identity, _ := c.Locals(apiKeyIdentityLocal).(*apikeys.Identity)
+	return identity
+}
+
+// RequireScope only allows requests with an api key that was granted scope.
+func RequireScope(scope apikeys.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		identity := GetApiKeyIdentity(c)
+		if identity == nil {
+			return response.NewApiError(c, fiber.StatusUnauthorized, response.Unauthenticated, "INVALID API KEY", nil)
+		}
+		if !identity.HasScope(scope) {
+			return response.NewApiError(c, fiber.StatusForbidden, response.PermissionDenied, "API KEY DOES NOT HAVE THE "+string(scope)+" SCOPE", nil)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+)
+
+// stubAuthenticator authenticates the keys of a map and returns trackErr for every request.
+type stubAuthenticator struct {
+	identities map[string]*apikeys.Identity
+	trackErr   error
+	tracked    int
+}
+
+func (a *stubAuthenticator) Authenticate(_ context.Context, key string) (*apikeys.Identity, error) {
+	if identity, ok := a.identities[key]; ok {
+		return identity, nil
+	}
+	if apikeys.IsManagedKey(key) {
+		return nil, apikeys.ErrInvalidApiKey
+	}
+	return nil, nil
+}
+
+func (a *stubAuthenticator) Track(context.Context, *apikeys.Identity) error {
+	a.tracked++
+	return a.trackErr
+}
+
+func newApiKeyApp(auth ApiKeyAuthenticator) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(ApiKeyAuth(auth))
+	app.Get("/public", func(c *fiber.Ctx) error {
+		if GetApiKeyIdentity(c) != nil {
+			return c.SendString(GetApiKeyIdentity(c).ID)
+		}
+		return c.SendString("anonymous")
+	})
+	app.Get("/export", RequireScope(apikeys.ScopeExport), func(c *fiber.Ctx) error {
+		return c.SendString("export")
+	})
+	return app
+}
+
+func TestApiKeyAuth(t *testing.T) {
+	auth := &stubAuthenticator{identities: map[string]*apikeys.Identity{
+		"wsk_export_key": {ID: "export", Scopes: []apikeys.Scope{apikeys.ScopeExport}},
+		"wsk_admin_key":  {ID: "admin", Scopes: []apikeys.Scope{apikeys.ScopeAdmin}},
+	}}
+	app := newApiKeyApp(auth)
+
+	cases := []struct {
+		name     string
+		path     string
+		key      string
+		expected int
+	}{
+		{name: "anonymous public route", path: "/public", expected: fiber.StatusOK},
+		{name: "unknown key is anonymous", path: "/public", key: "other", expected: fiber.StatusOK},
+		{name: "invalid managed key", path: "/public", key: "wsk_unknown_key", expected: fiber.StatusUnauthorized},
+		{name: "valid key public route", path: "/public", key: "wsk_export_key", expected: fiber.StatusOK},
+		{name: "anonymous scoped route", path: "/export", expected: fiber.StatusUnauthorized},
+		{name: "key with scope", path: "/export", key: "wsk_export_key", expected: fiber.StatusOK},
+		{name: "key without scope", path: "/export", key: "wsk_admin_key", expected: fiber.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tc.path, nil)
+			if tc.key != "" {
+				req.Header.Set("X-API-KEY", tc.key)
+			}
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, res.StatusCode)
+		})
+	}
+}
+
+func TestApiKeyAuthLimited(t *testing.T) {
+	auth := &stubAuthenticator{
+		identities: map[string]*apikeys.Identity{"wsk_export_key": {ID: "export", Scopes: []apikeys.Scope{apikeys.ScopeExport}}},
+		trackErr:   apikeys.ErrRateLimited,
+	}
+	app := newApiKeyApp(auth)
+
+	req := httptest.NewRequest("GET", "/export", nil)
+	req.Header.Set("X-API-KEY", "wsk_export_key")
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+	assert.Equal(t, 1, auth.tracked)
+
+	// anonymous requests are not tracked
+	res, err = app.Test(httptest.NewRequest("GET", "/public", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, 1, auth.tracked)
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+)
+
+// ExtractExportParams get the format, cursor and limit query parameters of the export endpoints.
+func ExtractExportParams(c *fiber.Ctx) (*export.Params, error) {
+
+	format, err := export.ParseFormat(c.Query("format"))
+	if err != nil {
+		return nil, response.NewInvalidQueryParamError(c, "INVALID <format> QUERY PARAMETER", err)
+	}
+
+	var cursor *export.Cursor
+	if value := c.Query("cursor"); value != "" {
+		cursor, err = export.DecodeCursor(value)
+		if err != nil {
+			return nil, response.NewInvalidQueryParamError(c, "INVALID <cursor> QUERY PARAMETER", err)
+		}
+	}
+
+	limit := int64(c.QueryInt("limit", int(export.DefaultLimit)))
+	if limit <= 0 || limit > export.MaxLimit {
+		msg := fmt.Sprintf("INVALID <limit> QUERY PARAMETER, MUST BE BETWEEN 1 AND %d", export.MaxLimit)
+		return nil, response.NewInvalidQueryParamError(c, msg, nil)
+	}
+
+	return &export.Params{Format: format, Cursor: cursor, Limit: limit}, nil
+}
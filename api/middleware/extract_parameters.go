@@ -61,6 +61,20 @@ func ExtractToChain(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
 	return &result, nil
 }
 
+// ExtractChainQueryParam obtains the "chain" query parameter from the request.
+//
+// When the parameter is not present, the function returns: a nil ChainID and a nil error.
+func ExtractChainQueryParam(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
+	return extractChainQueryParam(c, l, "chain")
+}
+
+// ExtractTokenChainQueryParam obtains the "tokenChain" query parameter from the request.
+//
+// When the parameter is not present, the function returns: a nil ChainID and a nil error.
+func ExtractTokenChainQueryParam(c *fiber.Ctx, l *zap.Logger) (*sdk.ChainID, error) {
+	return extractChainQueryParam(c, l, "tokenChain")
+}
+
 func ExtractSourceChain(c *fiber.Ctx, l *zap.Logger) ([]sdk.ChainID, error) {
 	param := c.Query("sourceChain")
 	if param == "" {
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"go.uber.org/zap"
+)
+
+// cachedResponse is a serialized response stored in the response cache.
+type cachedResponse struct {
+	Body         []byte    `json:"body"`
+	ContentType  string    `json:"contentType"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+// ResponseCache caches the serialized responses of the routes in the distributed cache,
+// and answers conditional requests with a 304 when the response did not change.
+type ResponseCache struct {
+	cache   cache.Cache
+	metrics metrics.Metrics
+	logger  *zap.Logger
+}
+
+// NewResponseCache creates a new ResponseCache.
+func NewResponseCache(c cache.Cache, m metrics.Metrics, logger *zap.Logger) *ResponseCache {
+	return &ResponseCache{
+		cache:   c,
+		metrics: m,
+		logger:  logger.With(zap.String("module", "ResponseCache")),
+	}
+}
+
+// Handler returns the middleware that caches the successful responses of a route for expiration.
+// Responses are stored under the current generation of group, so they can be invalidated with a cache.ResponseInvalidator.
+func (r *ResponseCache) Handler(group string, expiration time.Duration) fiber.Handler {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(expiration.Seconds()))
+
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet {
+			return c.Next()
+		}
+
+		key := cache.ResponseKey(group, r.generation(c, group), normalizeRequest(c))
+
+		// serve the cached response
+		if value, err := r.cache.Get(c.Context(), key); err == nil {
+			var cached cachedResponse
+			if err := json.Unmarshal([]byte(value), &cached); err == nil {
+				r.metrics.IncResponseCache(group, "hit")
+				return r.send(c, &cached, cacheControl, "HIT")
+			}
+			r.logger.Warn("invalid cached response", zap.String("key", key), zap.Error(err))
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+		r.metrics.IncResponseCache(group, "miss")
+
+		body := c.Response().Body()
+		hash := sha256.Sum256(body)
+		cached := cachedResponse{
+			Body:         append([]byte(nil), body...),
+			ContentType:  string(c.Response().Header.ContentType()),
+			ETag:         `W/"` + hex.EncodeToString(hash[:16]) + `"`,
+			LastModified: time.Now().UTC().Truncate(time.Second),
+		}
+
+		value, err := json.Marshal(&cached)
+		if err == nil {
+			err = r.cache.Set(c.Context(), key, string(value), expiration)
+		}
+		if err != nil && !errors.Is(err, cache.ErrCacheNotEnabled) {
+			requestID := fmt.Sprintf("%v", c.Locals("requestid"))
+			r.logger.Error("failed to cache response", zap.String("key", key), zap.Error(err), zap.String("requestID", requestID))
+		}
+
+		return r.send(c, &cached, cacheControl, "MISS")
+	}
+}
+
+// generation returns the current generation of the responses of group. A group that was never
+// invalidated has no generation stored.
+func (r *ResponseCache) generation(c *fiber.Ctx, group string) string {
+	generation, err := r.cache.Get(c.Context(), cache.ResponseGenerationKey(group))
+	if err != nil {
+		return "0"
+	}
+	return generation
+}
+
+// send writes a cached response, or a 304 if the client already has it.
+func (r *ResponseCache) send(c *fiber.Ctx, cached *cachedResponse, cacheControl, status string) error {
+	c.Set(fiber.HeaderETag, cached.ETag)
+	c.Set(fiber.HeaderLastModified, cached.LastModified.Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, cacheControl)
+	c.Set("X-Cache", status)
+
+	if matchETag(c.Get(fiber.HeaderIfNoneMatch), cached.ETag) {
+		c.Response().ResetBody()
+		c.Status(fiber.StatusNotModified)
+		return nil
+	}
+
+	c.Set(fiber.HeaderContentType, cached.ContentType)
+	return c.Status(fiber.StatusOK).Send(cached.Body)
+}
+
+// normalizeRequest returns the path of the request without trailing slash, followed by its query
+// parameters sorted by key, so equivalent requests share the same cache key.
+func normalizeRequest(c *fiber.Ctx) string {
+	path := strings.TrimSuffix(c.Path(), "/")
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil || len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// matchETag reports whether the If-None-Match header matches etag, comparing etags weakly.
+func matchETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"go.uber.org/zap"
+)
+
+type memoryCache struct {
+	values map[string]string
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) (string, error) {
+	value, ok := m.values[key]
+	if !ok {
+		return "", cache.ErrNotFound
+	}
+	return value, nil
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	m.values[key] = value.(string)
+	return nil
+}
+
+func (m *memoryCache) Close() error {
+	return nil
+}
+
+func TestResponseCache(t *testing.T) {
+	c := &memoryCache{values: map[string]string{}}
+	responseCache := NewResponseCache(c, metrics.NewNoOpMetrics(), zap.NewNop())
+
+	calls := 0
+	app := fiber.New()
+	app.Get("/scorecards", responseCache.Handler("scorecards", time.Minute), func(ctx *fiber.Ctx) error {
+		calls++
+		return ctx.JSON(fiber.Map{"calls": 1})
+	})
+
+	// first request is served by the handler and cached
+	res, err := app.Test(httptest.NewRequest("GET", "/scorecards?b=2&a=1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, "MISS", res.Header.Get("X-Cache"))
+	assert.Equal(t, "public, max-age=60", res.Header.Get(fiber.HeaderCacheControl))
+	assert.Contains(t, c.values, cache.ResponseKey("scorecards", "0", "/scorecards?a=1&b=2"))
+	etag := res.Header.Get(fiber.HeaderETag)
+	assert.NotEmpty(t, etag)
+
+	// equivalent request is served from the cache
+	res, err = app.Test(httptest.NewRequest("GET", "/scorecards?a=1&b=2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	assert.Equal(t, "HIT", res.Header.Get("X-Cache"))
+	assert.Equal(t, etag, res.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, fiber.MIMEApplicationJSON, res.Header.Get(fiber.HeaderContentType))
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, `{"calls":1}`, string(body))
+	assert.Equal(t, 1, calls)
+
+	// conditional request is answered with a 304
+	req := httptest.NewRequest("GET", "/scorecards?a=1&b=2", nil)
+	req.Header.Set(fiber.HeaderIfNoneMatch, etag)
+	res, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotModified, res.StatusCode)
+	body, _ = io.ReadAll(res.Body)
+	assert.Empty(t, body)
+	assert.Equal(t, 1, calls)
+
+	// a new generation of the group invalidates the cached responses
+	c.values[cache.ResponseGenerationKey("scorecards")] = "1"
+	res, err = app.Test(httptest.NewRequest("GET", "/scorecards?a=1&b=2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "MISS", res.Header.Get("X-Cache"))
+	assert.Equal(t, 2, calls)
+	assert.Contains(t, c.values, cache.ResponseKey("scorecards", "1", "/scorecards?a=1&b=2"))
+}
+
+func TestMatchETag(t *testing.T) {
+	assert.True(t, matchETag(`W/"abc"`, `W/"abc"`))
+	assert.True(t, matchETag(`"abc"`, `W/"abc"`))
+	assert.True(t, matchETag(`"x", W/"abc"`, `W/"abc"`))
+	assert.True(t, matchETag(`*`, `W/"abc"`))
+	assert.False(t, matchETag(``, `W/"abc"`))
+	assert.False(t, matchETag(`W/"other"`, `W/"abc"`))
+}
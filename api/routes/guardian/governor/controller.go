@@ -3,6 +3,7 @@ package governor
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
@@ -144,11 +145,22 @@ func (c *Controller) IsVaaEnqueued(ctx *fiber.Ctx) error {
 		return err
 	}
 
+	// the quorum release time is not part of the node grpc api.
+	var quorumReleaseTime *time.Time
+	if isEnqueued {
+		quorumReleaseTime, err = c.srv.GetQuorumReleaseTime(ctx.Context(), chainID, emitter, strconv.FormatUint(seq, 10))
+		if err != nil {
+			return err
+		}
+	}
+
 	// build reponse compatible with node grpc api.
 	response := struct {
-		IsEnqueued bool `json:"isEnqueued"`
+		IsEnqueued        bool       `json:"isEnqueued"`
+		QuorumReleaseTime *time.Time `json:"quorumReleaseTime,omitempty"`
 	}{
-		IsEnqueued: isEnqueued,
+		IsEnqueued:        isEnqueued,
+		QuorumReleaseTime: quorumReleaseTime,
 	}
 	return ctx.JSON(response)
 }
@@ -39,6 +39,7 @@ func RegisterRoutes(
 	signedVAA.Get("/:chain/:emitter/:sequence", vaaCtrl.FindSignedVAAByID)
 	signedBatchVAA := apiV1.Group("/signed_batch_vaa")
 	signedBatchVAA.Get("/:chain/:trxID/:nonce", vaaCtrl.FindSignedBatchVAAByID)
+	apiV1.Post("/signed_vaas", vaaCtrl.FindSignedVAAs)
 
 	// guardianSet resource
 	guardianSet := apiV1.Group("/guardianset")
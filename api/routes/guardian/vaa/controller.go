@@ -2,12 +2,15 @@
 package vaa
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"go.uber.org/zap"
 )
 
@@ -66,16 +69,93 @@ func (c *Controller) FindSignedVAAByID(ctx *fiber.Ctx) error {
 }
 
 // FindSignedBatchVAAByID godoc
-// @Description get a batch of VAA []byte from a chainID, emitter address and sequence.
+// @Description get the batch of VAA []byte emitted by a transaction with a nonce, sorted by sequence.
 // @Tags Guardian
 // @ID guardians-find-signed-batch-vaa
 // @Param chain_id path integer true "id of the blockchain"
-// @Param emitter path string true "address of the emitter"
-// @Param seq path integer true "sequence of the VAA"
-// @Success 200 {object} object{vaaBytes=[]byte}
+// @Param trx_id path string true "hash of the transaction that emitted the VAAs"
+// @Param nonce path integer true "nonce of the VAAs"
+// @Success 200 {object} object{vaaBytes=[][]byte}
 // @Failure 400
+// @Failure 404
 // @Failure 500
-// @Router /v1/signed_batch_vaa/:chain_id/:emitter/sequence/:seq [get]
+// @Router /v1/signed_batch_vaa/:chain_id/:trx_id/:nonce [get]
 func (c *Controller) FindSignedBatchVAAByID(ctx *fiber.Ctx) error {
-	return response.NewApiError(ctx, fiber.StatusNotImplemented, response.Unimplemented, "not yet implemented", nil)
+
+	chainID, err := middleware.ExtractChainID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := types.ParseTxHash(ctx.Params("trxID"))
+	if err != nil {
+		return response.NewInvalidParamError(ctx, "MALFORMED TX HASH", errors.WithStack(err))
+	}
+
+	nonce, err := strconv.ParseUint(ctx.Params("nonce"), 10, 32)
+	if err != nil {
+		return response.NewInvalidParamError(ctx, "MALFORMED NONCE", errors.WithStack(err))
+	}
+
+	vaas, err := c.srv.FindSignedBatch(ctx.Context(), chainID, txHash, uint32(nonce))
+	if err != nil {
+		return err
+	}
+
+	vaaBytes := make([][]byte, 0, len(vaas))
+	for _, v := range vaas {
+		vaaBytes = append(vaaBytes, v.Vaa)
+	}
+	response := struct {
+		VaaBytes [][]byte `json:"vaaBytes"`
+	}{
+		VaaBytes: vaaBytes,
+	}
+	return ctx.JSON(response)
+}
+
+// FindSignedVAAs godoc
+// @Description get the VAA []byte of a list of VAA ids formatted as chain/emitter/sequence, in the same order.
+// @Description VAAs that are not found are returned without vaaBytes.
+// @Tags Guardian
+// @ID guardians-find-signed-vaas
+// @Param request body object{ids=[]string} true "ids of the VAAs, at most 100"
+// @Success 200 {object} object{vaas=[]vaa.SignedVaa}
+// @Failure 400
+// @Failure 500
+// @Router /v1/signed_vaas [post]
+func (c *Controller) FindSignedVAAs(ctx *fiber.Ctx) error {
+
+	var request struct {
+		IDs []string `json:"ids"`
+	}
+	if err := ctx.BodyParser(&request); err != nil {
+		return response.NewRequestBodyError(ctx, "INVALID BODY", errors.WithStack(err))
+	}
+	if len(request.IDs) == 0 {
+		return response.NewRequestBodyError(ctx, "MISSING VAA IDS", nil)
+	}
+	if len(request.IDs) > vaa.MaxSignedVaaIDs {
+		return response.NewRequestBodyError(ctx, fmt.Sprintf("AT MOST %d VAA IDS ARE ALLOWED", vaa.MaxSignedVaaIDs), nil)
+	}
+
+	ids := make([]*vaa.VaaID, 0, len(request.IDs))
+	for _, id := range request.IDs {
+		vaaID, err := vaa.ParseVaaID(id)
+		if err != nil {
+			return response.NewRequestBodyError(ctx, "MALFORMED VAA ID "+id, errors.WithStack(err))
+		}
+		ids = append(ids, vaaID)
+	}
+
+	vaas, err := c.srv.FindSignedByIds(ctx.Context(), ids)
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Vaas []*vaa.SignedVaa `json:"vaas"`
+	}{
+		Vaas: vaas,
+	}
+	return ctx.JSON(response)
 }
@@ -61,3 +61,40 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(response)
 }
+
+// GetPortfolio godoc
+// @Description Returns the cross-chain portfolio of an address: per-token totals sent and received with their
+// @Description USD notional at transfer time, counterparties, chains used, first/last activity and pending inbound transfers.
+// @Tags wormholescan
+// @ID get-address-portfolio
+// @Param address path string true "address"
+// @Param chain query integer false "id of the blockchain of the address, required to decode native addresses"
+// @Success 200 {object} response.Response[address.Portfolio]
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/address/{address}/portfolio [get]
+func (c *Controller) GetPortfolio(ctx *fiber.Ctx) error {
+
+	addr := middleware.ExtractAddressFromPath(ctx, c.logger)
+
+	chainID, err := middleware.ExtractChainQueryParam(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	encodings, err := address.AddressEncodings(addr, chainID)
+	if err != nil {
+		return response.NewInvalidParamError(ctx, "INVALID ADDRESS FOR CHAIN", err)
+	}
+
+	response, err := c.srv.GetPortfolio(ctx.Context(), addr, encodings)
+	if err != nil {
+		return err
+	}
+	if response.Data.Operations == 0 {
+		return errors.ErrNotFound
+	}
+
+	return ctx.JSON(response)
+}
@@ -0,0 +1,218 @@
+package apikeys
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUsageDays = 7
+	maxUsageDays     = 35
+	// defaultGracePeriod is the time a rotated key remains valid when the gracePeriod query parameter is missing.
+	defaultGracePeriod = 24 * time.Hour
+	maxGracePeriod     = 30 * 24 * time.Hour
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *apikeys.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *apikeys.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "ApiKeysController")),
+	}
+}
+
+// FindAll godoc
+// @Description Returns the managed api keys. Requires an admin api key.
+// @Tags wormholescan
+// @ID find-api-keys
+// @Param X-API-KEY header string true "admin api key"
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Param sortOrder query string false "Sort results in ascending or descending order." Enums(ASC, DESC)
+// @Success 200 {object} response.Response[[]apikeys.ApiKey]
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/api-keys [get]
+func (c *Controller) FindAll(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Check pagination max limit
+	if p.Limit > 1000 {
+		return response.NewInvalidParamError(ctx, "pageSize cannot be greater than 1000", nil)
+	}
+
+	result, err := c.srv.FindAll(ctx.Context(), p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// Create godoc
+// @Description Creates a managed api key. The key is only returned in this response. Requires an admin api key.
+// @Tags wormholescan
+// @ID create-api-key
+// @Param X-API-KEY header string true "admin api key"
+// @Param request body apikeys.UpsertApiKey true "api key information"
+// @Success 201 {object} apikeys.CreatedApiKey
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 500
+// @Router /api/v1/api-keys [post]
+func (c *Controller) Create(ctx *fiber.Ctx) error {
+	body, err := c.parseBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.srv.Create(ctx.Context(), body)
+	if err != nil {
+		return err
+	}
+	return ctx.Status(fiber.StatusCreated).JSON(result)
+}
+
+// Update godoc
+// @Description Updates the owner, scopes and limits of a managed api key. Requires an admin api key.
+// @Tags wormholescan
+// @ID update-api-key
+// @Param id path string true "id of the api key"
+// @Param X-API-KEY header string true "admin api key"
+// @Param request body apikeys.UpsertApiKey true "api key information"
+// @Success 200 {object} apikeys.ApiKey
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/api-keys/{id} [put]
+func (c *Controller) Update(ctx *fiber.Ctx) error {
+	body, err := c.parseBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.srv.Update(ctx.Context(), ctx.Params("id"), body)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// Rotate godoc
+// @Description Generates a new key for a managed api key. The previous key remains valid during the grace period. Requires an admin api key.
+// @Tags wormholescan
+// @ID rotate-api-key
+// @Param id path string true "id of the api key"
+// @Param X-API-KEY header string true "admin api key"
+// @Param gracePeriod query string false "time the previous key remains valid, e.g. 1h (default 24h, max 720h)"
+// @Success 200 {object} apikeys.CreatedApiKey
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/api-keys/{id}/rotate [post]
+func (c *Controller) Rotate(ctx *fiber.Ctx) error {
+	gracePeriod := defaultGracePeriod
+	if param := ctx.Query("gracePeriod"); param != "" {
+		d, err := time.ParseDuration(param)
+		if err != nil || d < 0 || d > maxGracePeriod {
+			return response.NewInvalidQueryParamError(ctx, "INVALID <gracePeriod> QUERY PARAMETER", errors.WithStack(err))
+		}
+		gracePeriod = d
+	}
+
+	result, err := c.srv.Rotate(ctx.Context(), ctx.Params("id"), gracePeriod)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// Revoke godoc
+// @Description Revokes a managed api key. Requires an admin api key.
+// @Tags wormholescan
+// @ID revoke-api-key
+// @Param id path string true "id of the api key"
+// @Param X-API-KEY header string true "admin api key"
+// @Success 204
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/api-keys/{id} [delete]
+func (c *Controller) Revoke(ctx *fiber.Ctx) error {
+	if err := c.srv.Revoke(ctx.Context(), ctx.Params("id")); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// FindUsage godoc
+// @Description Returns the number of requests made with a managed api key in each of the last days. Requires an admin api key.
+// @Tags wormholescan
+// @ID find-api-key-usage
+// @Param id path string true "id of the api key"
+// @Param X-API-KEY header string true "admin api key"
+// @Param days query integer false "number of days (default 7, max 35)"
+// @Success 200 {object} []apikeys.Usage
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/api-keys/{id}/usage [get]
+func (c *Controller) FindUsage(ctx *fiber.Ctx) error {
+	days := ctx.QueryInt("days", defaultUsageDays)
+	if days <= 0 || days > maxUsageDays {
+		return response.NewInvalidQueryParamError(ctx, fmt.Sprintf("INVALID <days> QUERY PARAMETER, MUST BE BETWEEN 1 AND %d", maxUsageDays), nil)
+	}
+
+	result, err := c.srv.FindUsage(ctx.Context(), ctx.Params("id"), days)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+func (c *Controller) parseBody(ctx *fiber.Ctx) (*apikeys.UpsertApiKey, error) {
+	var body apikeys.UpsertApiKey
+	if err := ctx.BodyParser(&body); err != nil {
+		return nil, response.NewRequestBodyError(ctx, "invalid api key request, unable to parse", errors.WithStack(err))
+	}
+	if body.Owner == "" {
+		return nil, response.NewRequestBodyError(ctx, "invalid api key request, owner is required", nil)
+	}
+	if len(body.Scopes) == 0 {
+		return nil, response.NewRequestBodyError(ctx, "invalid api key request, at least one scope is required", nil)
+	}
+	for _, s := range body.Scopes {
+		if _, err := apikeys.ParseScope(string(s)); err != nil {
+			return nil, response.NewRequestBodyError(ctx, "invalid api key request, "+err.Error(), errors.WithStack(err))
+		}
+	}
+	if body.RateLimit < 0 || body.DailyQuota < 0 {
+		return nil, response.NewRequestBodyError(ctx, "invalid api key request, limits cannot be negative", nil)
+	}
+	return &body, nil
+}
@@ -0,0 +1,141 @@
+package emitters
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/emitters"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// Controller definition.
+type Controller struct {
+	srv    *emitters.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controler.
+func NewController(srv *emitters.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "EmittersController")),
+	}
+}
+
+// FindAll godoc
+// @Description Returns the emitters of the registry with their native address, app and number of VAAs.
+// @Tags wormholescan
+// @ID find-emitters
+// @Param chain query integer false "id of the blockchain"
+// @Param appId query string false "id of the app that owns the emitter"
+// @Param page query integer false "Page number."
+// @Param pageSize query integer false "Number of elements per page."
+// @Success 200 {object} response.Response[[]emitters.Emitter]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/emitters [get]
+func (c *Controller) FindAll(ctx *fiber.Ctx) error {
+	p, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Check pagination max limit
+	if p.Limit > 1000 {
+		return response.NewInvalidParamError(ctx, "pageSize cannot be greater than 1000", nil)
+	}
+
+	chainID, err := middleware.ExtractChainQueryParam(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	appID := middleware.ExtractAppId(ctx, c.logger)
+
+	result, err := c.srv.FindAll(ctx.Context(), chainID, appID, p)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// FindOne godoc
+// @Description Returns an emitter of the registry by chainID and emitter address.
+// @Tags wormholescan
+// @ID find-emitter
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Success 200 {object} emitters.Emitter
+// @Failure 400
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/emitters/{chain_id}/{emitter} [get]
+func (c *Controller) FindOne(ctx *fiber.Ctx) error {
+	chainID, emitter, err := middleware.ExtractVAAChainIDEmitter(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.srv.FindOne(ctx.Context(), chainID, emitter.Hex())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// Upsert godoc
+// @Description Creates or updates an emitter of the registry. Requires an admin api key.
+// @Tags wormholescan
+// @ID upsert-emitter
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param X-API-KEY header string true "admin api key"
+// @Param request body emitters.UpsertEmitter true "emitter information"
+// @Success 200 {object} emitters.Emitter
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /api/v1/emitters/{chain_id}/{emitter} [put]
+func (c *Controller) Upsert(ctx *fiber.Ctx) error {
+	chainID, emitter, err := middleware.ExtractVAAChainIDEmitter(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	var body emitters.UpsertEmitter
+	if err := ctx.BodyParser(&body); err != nil {
+		return response.NewRequestBodyError(ctx, "invalid emitter request, unable to parse", errors.WithStack(err))
+	}
+
+	result, err := c.srv.Upsert(ctx.Context(), chainID, emitter.Hex(), &body)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(result)
+}
+
+// Delete godoc
+// @Description Deletes an emitter of the registry. Requires an admin api key.
+// @Tags wormholescan
+// @ID delete-emitter
+// @Param chain_id path integer true "id of the blockchain"
+// @Param emitter path string true "address of the emitter"
+// @Param X-API-KEY header string true "admin api key"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /api/v1/emitters/{chain_id}/{emitter} [delete]
+func (c *Controller) Delete(ctx *fiber.Ctx) error {
+	chainID, emitter, err := middleware.ExtractVAAChainIDEmitter(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	if err := c.srv.Delete(ctx.Context(), chainID, emitter.Hex()); err != nil {
+		return err
+	}
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
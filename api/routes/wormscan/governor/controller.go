@@ -3,12 +3,16 @@ package governor
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	_ "github.com/wormhole-foundation/wormhole-explorer/api/response" // needed by swaggo docs
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"go.uber.org/zap"
 )
 
@@ -421,16 +425,154 @@ func (c *Controller) GetGovernorVaas(ctx *fiber.Ctx) error {
 			status = "issued"
 		}
 		result = append(result, GovernorVaasResponse{
-			VaaID:          v.ID,
-			ChainID:        v.ChainID,
-			EmitterAddress: v.EmitterAddress,
-			Sequence:       v.Sequence,
-			TxHash:         v.TxHash,
-			ReleaseTime:    v.ReleaseTime,
-			Amount:         uint64(v.Amount),
-			Status:         status,
+			VaaID:             v.ID,
+			ChainID:           v.ChainID,
+			EmitterAddress:    v.EmitterAddress,
+			Sequence:          v.Sequence,
+			TxHash:            v.TxHash,
+			ReleaseTime:       v.ReleaseTime,
+			Amount:            uint64(v.Amount),
+			Status:            status,
+			QuorumReleaseTime: v.QuorumReleaseTime,
 		})
 	}
 
 	return ctx.JSON(result)
 }
+
+// maxHistoryPeriod is the maximum period of the governor history queries.
+const maxHistoryPeriod = 90 * 24 * time.Hour
+
+// extractHistoryQuery extracts the chain and the period of a governor history query.
+// The period defaults to the last 30 days.
+func (c *Controller) extractHistoryQuery(ctx *fiber.Ctx) (*governor.GovernorHistoryQuery, error) {
+	chainID, err := middleware.ExtractChainQueryParam(ctx, c.logger)
+	if err != nil {
+		return nil, err
+	}
+	from, err := middleware.ExtractTime(ctx, time.RFC3339, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := middleware.ExtractTime(ctx, time.RFC3339, "to")
+	if err != nil {
+		return nil, err
+	}
+
+	q := &governor.GovernorHistoryQuery{ChainID: chainID, To: time.Now().UTC()}
+	if to != nil {
+		q.To = *to
+	}
+	q.From = q.To.Add(-30 * 24 * time.Hour)
+	if from != nil {
+		q.From = *from
+	}
+	if !q.From.Before(q.To) {
+		return nil, response.NewInvalidParamError(ctx, "from must be before to", nil)
+	}
+	if q.To.Sub(q.From) > maxHistoryPeriod {
+		return nil, response.NewInvalidParamError(ctx, "the period cannot be longer than 90 days", nil)
+	}
+	return q, nil
+}
+
+// GetNotionalHistory godoc
+// @Description Returns the daily limit utilization of the blockchains.
+// @Tags wormholescan
+// @ID governor-notional-history
+// @Param chain query integer false "id of the blockchain"
+// @Param from query string false "beginning of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to 30 days ago"
+// @Param to query string false "end of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to now"
+// @Success 200 {object} []governor.NotionalHistory
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/history/notional [get]
+func (c *Controller) GetNotionalHistory(ctx *fiber.Ctx) error {
+	q, err := c.extractHistoryQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	history, err := c.srv.GetNotionalHistory(ctx.Context(), q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(history)
+}
+
+// GetEnqueuedVaaHistory godoc
+// @Description Returns the number of enqueued and released VAAs and the release latency percentiles of the blockchains.
+// @Tags wormholescan
+// @ID governor-enqueued-vaas-history
+// @Param chain query integer false "id of the blockchain"
+// @Param from query string false "beginning of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to 30 days ago"
+// @Param to query string false "end of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to now"
+// @Success 200 {object} []governor.EnqueuedVaaHistory
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/history/enqueued_vaas [get]
+func (c *Controller) GetEnqueuedVaaHistory(ctx *fiber.Ctx) error {
+	q, err := c.extractHistoryQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	history, err := c.srv.GetEnqueuedVaaHistory(ctx.Context(), q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(history)
+}
+
+// SimulateTransfer godoc
+// @Description Returns whether the governor of a quorum of guardians would delay a transfer, the expected delay and
+// @Description the estimated time until enough notional is freed to release it.
+// @Tags wormholescan
+// @ID governor-simulate
+// @Param chain query integer true "id of the blockchain the transfer is sent from"
+// @Param tokenChain query integer false "id of the original blockchain of the token, defaults to chain"
+// @Param tokenAddress query string true "original address of the token"
+// @Param amount query string true "amount of tokens transferred, in token units"
+// @Success 200 {object} governor.GovernorSimulation
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/governor/simulate [get]
+func (c *Controller) SimulateTransfer(ctx *fiber.Ctx) error {
+	chainID, err := middleware.ExtractChainQueryParam(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	if chainID == nil {
+		return response.NewInvalidParamError(ctx, "chain is required", nil)
+	}
+	tokenChainID, err := middleware.ExtractTokenChainQueryParam(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+	if tokenChainID == nil {
+		tokenChainID = chainID
+	}
+
+	tokenAddress, err := types.StringToAddress(ctx.Query("tokenAddress"), true)
+	if err != nil {
+		return response.NewInvalidParamError(ctx, "invalid tokenAddress", errors.WithStack(err))
+	}
+	amount, err := decimal.NewFromString(ctx.Query("amount"))
+	if err != nil {
+		return response.NewInvalidParamError(ctx, "invalid amount", errors.WithStack(err))
+	}
+	if !amount.IsPositive() {
+		return response.NewInvalidParamError(ctx, "amount must be greater than 0", nil)
+	}
+
+	simulation, err := c.srv.SimulateTransfer(ctx.Context(), &governor.GovernorSimulationQuery{
+		ChainID:      *chainID,
+		TokenChainID: *tokenChainID,
+		TokenAddress: tokenAddress.Hex(),
+		Amount:       amount,
+	})
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(simulation)
+}
@@ -15,4 +15,6 @@ type GovernorVaasResponse struct {
 	ReleaseTime    time.Time   `json:"releaseTime"`
 	Amount         uint64      `json:"amount"`
 	Status         string      `json:"status"`
+	// QuorumReleaseTime is the time at which a quorum of guardians releases the vaa.
+	QuorumReleaseTime *time.Time `json:"quorumReleaseTime,omitempty"`
 }
@@ -0,0 +1,443 @@
+package wormscan
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	addrsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	apikeyssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	emitterssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/emitters"
+	govsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
+	obssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
+	protocolssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
+	searchsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/search"
+	statssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
+	trxsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
+	vaasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/openapi"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/governor"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/infrastructure"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/relays"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/supply"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/transactions"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/vaa"
+	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
+	commonstats "github.com/wormhole-foundation/wormhole-explorer/common/stats"
+)
+
+// OpenAPIPrefix is the prefix of the routes of the Wormscan API.
+const OpenAPIPrefix = "/api/v1"
+
+// OpenAPI returns the OpenAPI document of the Wormscan API routes registered in app.
+func OpenAPI(app *fiber.App) *openapi.Document {
+	return NewOpenAPIBuilder().Build(app.GetRoutes(true), OpenAPIPrefix)
+}
+
+// NewOpenAPIBuilder returns a builder with the description of the Wormscan API routes.
+// Every route registered in RegisterRoutes must be described here.
+func NewOpenAPIBuilder() *openapi.Builder {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "Wormholescan API",
+		Version:     "1.0",
+		Description: "Wormholescan API for the Wormhole network.",
+	})
+
+	// the custom marshalers of these documents encode the sequence as a number
+	sequenceAsNumber := func(s *openapi.Schema) {
+		s.Properties["sequence"] = &openapi.Schema{Type: []string{openapi.TypeInteger}}
+		if !slices.Contains(s.Required, "sequence") {
+			s.Required = append(s.Required, "sequence")
+		}
+		s.Closed = true
+	}
+	b.Generator().Patch(vaasvc.VaaDoc{}, sequenceAsNumber)
+	b.Generator().Patch(obssvc.ObservationDoc{}, sequenceAsNumber)
+	b.Generator().Patch(govsvc.EnqueuedVaa{}, sequenceAsNumber)
+	b.Generator().Patch(govsvc.EnqueuedVaaDetail{}, sequenceAsNumber)
+
+	var (
+		min0 = 0.0
+		min1 = 1.0
+
+		tags = []string{"wormholescan"}
+
+		page      = openapi.Param{Name: "page", Type: openapi.TypeInteger, Min: &min0, Description: "page number, starts at 0"}
+		pageSize  = openapi.Param{Name: "pageSize", Type: openapi.TypeInteger, Min: &min1, Description: "number of elements per page"}
+		sortOrder = openapi.Param{Name: "sortOrder", Description: "sort results in ascending or descending order (ASC or DESC)"}
+		paginated = []openapi.Param{page, pageSize, sortOrder}
+
+		chain        = openapi.Param{Name: "chain", Type: openapi.TypeInteger, Description: "id of the blockchain"}
+		emitter      = openapi.Param{Name: "emitter", Description: "address of the emitter"}
+		sequence     = openapi.Param{Name: "sequence", Type: openapi.TypeInteger, Min: &min0, Description: "sequence of the VAA"}
+		vaaID        = []openapi.Param{chain, emitter, sequence}
+		guardianAddr = openapi.Param{Name: "guardian_address", Description: "address of the guardian"}
+		apiKey       = openapi.Param{Name: "X-API-KEY", Required: true, Description: "api key"}
+		adminKey     = openapi.Param{Name: "X-API-KEY", Required: true, Description: "admin api key"}
+
+		parsedPayload = openapi.Param{Name: "parsedPayload", Type: openapi.TypeBoolean, Description: "include the parsed contents of the VAA, if available"}
+		txHash        = openapi.Param{Name: "txHash", Description: "hash of the transaction"}
+		appID         = openapi.Param{Name: "appId", Description: "id of the application"}
+		symbol        = openapi.Param{Name: "symbol", Required: true, Description: "symbol of the token"}
+		by            = openapi.Param{Name: "by", Description: "render the results using notional or tx count", Enum: []any{"notional", "tx"}}
+		from          = openapi.Param{Name: "from", Required: true, Description: "beginning of the period, formatted as 2006-01-02T15:04:05Z07:00"}
+		to            = openapi.Param{Name: "to", Required: true, Description: "end of the period, formatted as 2006-01-02T15:04:05Z07:00"}
+		exportFormat  = openapi.Param{Name: "format", Description: "format of the export", Enum: []any{"csv", "ndjson", "parquet"}}
+		exportCursor  = openapi.Param{Name: "cursor", Description: "cursor returned in the X-Next-Cursor header of the previous export"}
+		exportLimit   = openapi.Param{Name: "limit", Type: openapi.TypeInteger, Min: &min1, Description: "max number of records to export"}
+		sourceChain   = openapi.Param{Name: "sourceChain", Description: "source chains, separated by comma"}
+		targetChain   = openapi.Param{Name: "targetChain", Description: "target chains, separated by comma"}
+		opStatus      = openapi.Param{Name: "status", Description: "lifecycle status of the operation, separated by comma"}
+
+		governorHistory = []openapi.Param{chain,
+			{Name: "from", Description: "beginning of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to 30 days ago"},
+			{Name: "to", Description: "end of the period, formatted as 2006-01-02T15:04:05Z07:00, defaults to now"}}
+
+		vaaBody = struct {
+			Vaa string `json:"vaa"`
+		}{}
+	)
+
+	describe := func(method, path string, spec openapi.Spec) {
+		if spec.Tags == nil {
+			spec.Tags = tags
+		}
+		b.Describe(method, OpenAPIPrefix+path, spec)
+	}
+
+	// monitoring
+	describe(http.MethodGet, "/health", openapi.Spec{ID: "health-check", Summary: "Health check",
+		Response: struct {
+			Status string `json:"status"`
+		}{}})
+	describe(http.MethodGet, "/ready", openapi.Spec{ID: "ready-check", Summary: "Ready check",
+		Response: struct {
+			Ready string `json:"ready"`
+		}{}})
+	describe(http.MethodGet, "/version", openapi.Spec{ID: "get-version", Summary: "Get version/release information",
+		Response: infrastructure.VersionResponse{}})
+
+	// circulating supply
+	describe(http.MethodGet, "/supply/circulating", openapi.Spec{ID: "circulating-supply", Summary: "Get W token circulating supply",
+		ContentType: fiber.MIMETextPlainCharsetUTF8})
+	describe(http.MethodGet, "/supply/total", openapi.Spec{ID: "total-supply", Summary: "Get W token total supply",
+		ContentType: fiber.MIMETextPlainCharsetUTF8})
+	describe(http.MethodGet, "/supply", openapi.Spec{ID: "supply-info", Summary: "Get W token circulating and total supply",
+		Response: supply.SupplyInfoResponse{}})
+
+	// accounts resource
+	describe(http.MethodGet, "/address/:id", openapi.Spec{ID: "find-address-by-id", Summary: "Lookup an address",
+		PathParams:  []openapi.Param{{Name: "id", Description: "address"}},
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[*addrsvc.AddressOverview]{}})
+	describe(http.MethodGet, "/address/:id/portfolio", openapi.Spec{ID: "get-address-portfolio", Summary: "Cross-chain portfolio of an address",
+		PathParams:  []openapi.Param{{Name: "id", Description: "address"}},
+		QueryParams: []openapi.Param{{Name: "chain", Type: openapi.TypeInteger, Description: "id of the blockchain of the address, required to decode native addresses"}},
+		Response:    response.Response[*addrsvc.Portfolio]{}})
+
+	// search resource
+	describe(http.MethodGet, "/search", openapi.Spec{ID: "search", Summary: "Search transactions, addresses, tokens and emitters",
+		QueryParams: []openapi.Param{{Name: "q", Required: true, Description: "search query"}},
+		Response:    response.Response[*searchsvc.SearchResult]{}})
+
+	// analytics, transactions, custom endpoints
+	describe(http.MethodGet, "/global-tx/:chain/:emitter/:sequence", openapi.Spec{ID: "find-global-transaction-by-id", Summary: "Find a global transaction by VAA ID",
+		PathParams: vaaID,
+		Response:   &trxsvc.GlobalTransactionDoc{}})
+	describe(http.MethodGet, "/last-txs", openapi.Spec{ID: "get-last-transactions", Summary: "Number of transactions by time span and sample rate",
+		QueryParams: []openapi.Param{
+			{Name: "timeSpan", Description: "time span", Enum: []any{"1d", "1w", "1mo"}},
+			{Name: "sampleRate", Description: "sample rate", Enum: []any{"1h", "1d"}},
+		},
+		Response: []trxsvc.TransactionCountResult{}})
+	describe(http.MethodGet, "/scorecards", openapi.Spec{ID: "get-scorecards", Summary: "KPIs of Wormhole",
+		Response: transactions.ScorecardsResponse{}})
+	describe(http.MethodGet, "/x-chain-activity", openapi.Spec{ID: "x-chain-activity", Summary: "Chain pairs by origin and destination chain",
+		QueryParams: []openapi.Param{
+			{Name: "timeSpan", Description: "time span", Enum: []any{"7d", "30d", "90d", "1y", "all-time"}},
+			by,
+			{Name: "apps", Description: "apps, separated by comma"},
+		},
+		Response: transactions.ChainActivity{}})
+	describe(http.MethodGet, "/x-chain-activity/tops", openapi.Spec{ID: "x-chain-activity-tops", Summary: "Number of transactions and volume by period",
+		QueryParams: []openapi.Param{
+			{Name: "timespan", Required: true, Description: "time span", Enum: []any{"1h", "1d", "1mo", "1y"}},
+			from, to, appID, sourceChain, targetChain,
+		},
+		Response: trxsvc.ChainActivityTopResults{}})
+	describe(http.MethodGet, "/top-assets-by-volume", openapi.Spec{ID: "get-top-assets-by-volume", Summary: "Emitter chain and asset pairs ordered by volume",
+		QueryParams: []openapi.Param{{Name: "timeSpan", Required: true, Description: "time span", Enum: []any{"7d", "15d", "30d"}}},
+		Response:    transactions.TopAssetsResponse{}})
+	describe(http.MethodGet, "/top-chain-pairs-by-num-transfers", openapi.Spec{ID: "get-top-chain-pairs-by-num-transfers", Summary: "Chain pairs ordered by transfer count",
+		QueryParams: []openapi.Param{{Name: "timeSpan", Required: true, Description: "time span", Enum: []any{"7d", "15d", "30d"}}},
+		Response:    transactions.TopChainPairsResponse{}})
+	describe(http.MethodGet, "/token/:chain/:token_address", openapi.Spec{ID: "get-token-by-chain-and-address", Summary: "Find a token by chain and address",
+		PathParams: []openapi.Param{chain, {Name: "token_address", Description: "address of the token"}},
+		Response:   &trxsvc.Token{}})
+	describe(http.MethodGet, "/transactions", openapi.Spec{ID: "list-transactions", Summary: "List transactions",
+		QueryParams: append(paginated, openapi.Param{Name: "address", Description: "filter transactions by address"}),
+		Response:    transactions.ListTransactionsResponse{}})
+	describe(http.MethodGet, "/transactions/:chain/:emitter/:sequence", openapi.Spec{ID: "get-transaction-by-id", Summary: "Find a transaction by VAA ID",
+		PathParams: vaaID,
+		Response:   &transactions.TransactionDetail{}})
+	describe(http.MethodGet, "/application-activity", openapi.Spec{ID: "application-activity", Summary: "Number of transactions and volume per application",
+		QueryParams: []openapi.Param{
+			{Name: "timespan", Required: true, Description: "time span", Enum: []any{"1h", "1d", "1mo"}},
+			from, to, appID,
+			{Name: "exclusiveAppId", Type: openapi.TypeBoolean, Description: "single appId of the application"},
+		},
+		Response: []trxsvc.AppActivityTotalData{}})
+	describe(http.MethodGet, "/tokens-symbol-volume", openapi.Spec{ID: "get-tokens-symbol-volume", Summary: "Tokens by volume",
+		QueryParams: []openapi.Param{{Name: "limit", Type: openapi.TypeInteger, Description: "max number of tokens"}},
+		Response:    []trxsvc.TokenVolume{}})
+	describe(http.MethodGet, "/tokens-symbol-activity", openapi.Spec{ID: "get-tokens-symbol-activity", Summary: "Activity of tokens by symbol",
+		QueryParams: []openapi.Param{
+			{Name: "timespan", Required: true, Description: "time span", Enum: []any{"1h", "1d", "1mo"}},
+			from, to,
+			{Name: "symbol", Description: "symbols of the tokens, separated by comma"},
+			sourceChain, targetChain,
+		},
+		Response: trxsvc.TokenSymbolActivityResponse{}})
+
+	// stats custom endpoints
+	describe(http.MethodGet, "/top-symbols-by-volume", openapi.Spec{ID: "top-symbols-by-volume", Summary: "Symbols by origin chain and tokens",
+		QueryParams: []openapi.Param{{Name: "timeSpan", Description: "time span", Enum: []any{"7d", "15d", "30d"}}},
+		Response:    stats.TopSymbolByVolumeResult{}})
+	describe(http.MethodGet, "/top-100-corridors", openapi.Spec{ID: "top-100-corridors", Summary: "Top 100 corridors by number of transactions",
+		QueryParams: []openapi.Param{{Name: "timeSpan", Description: "time span", Enum: []any{"2d", "7d"}}},
+		Response:    stats.TopCorridorsResult{}})
+	describe(http.MethodGet, "/protocols/stats", openapi.Spec{ID: "get-top-protocols-stats", Summary: "Representative stats of the top protocols",
+		Response: []protocolssvc.ProtocolTotalValuesDTO{}})
+	describe(http.MethodGet, "/native-token-transfer/summary", openapi.Spec{ID: "native-token-transfer-summary", Summary: "Summary of a native token transfer",
+		QueryParams: []openapi.Param{symbol},
+		Response:    &statssvc.NativeTokenTransferSummary{}})
+	describe(http.MethodGet, "/native-token-transfer/activity", openapi.Spec{ID: "native-token-transfer-activity", Summary: "Activity of a native token transfer by emitter and destination chain",
+		QueryParams: []openapi.Param{symbol, by},
+		Response:    []statssvc.NativeTokenTransferActivity{}})
+	describe(http.MethodGet, "/native-token-transfer/transfer-by-time", openapi.Spec{ID: "native-token-transfer-by-time", Summary: "Transfers of a native token transfer by time",
+		QueryParams: []openapi.Param{
+			from, to, symbol, by,
+			{Name: "timeSpan", Required: true, Description: "time span", Enum: []any{"1h", "1d", "1mo", "1y"}},
+		},
+		Response: []statssvc.NativeTokenTransferByTime{}})
+	describe(http.MethodGet, "/native-token-transfer/top-address", openapi.Spec{ID: "native-token-transfer-top-address", Summary: "Top addresses of a native token transfer",
+		QueryParams: []openapi.Param{symbol, by},
+		Response:    []commonstats.NativeTokenTransferTopAddress{}})
+	describe(http.MethodGet, "/native-token-transfer/top-holder", openapi.Spec{ID: "native-token-transfer-top-holder", Summary: "Top holders of a native token transfer",
+		QueryParams: []openapi.Param{symbol},
+		Response:    []commonstats.NativeTokenTransferTopHolder{}})
+	describe(http.MethodGet, "/native-token-transfer/symbols", openapi.Spec{ID: "native-token-transfer-symbols", Summary: "Symbols of the native token transfers with stats available",
+		Response: []statssvc.NativeTokenTransferSymbol{}})
+
+	// operations resource
+	describe(http.MethodGet, "/operations", openapi.Spec{ID: "get-operations", Summary: "Find all operations",
+		QueryParams: []openapi.Param{
+			{Name: "address", Description: "address of the emitter"},
+			txHash, page, pageSize, sourceChain, targetChain, appID,
+			{Name: "exclusiveAppId", Type: openapi.TypeBoolean, Description: "single appId of the operation"},
+			{Name: "payloadType", Description: "payload types of the operation, separated by comma"},
+			{Name: "from", Description: "beginning of the period"},
+			{Name: "to", Description: "end of the period"},
+			opStatus,
+		},
+		Response: operations.ListOperationResponse{}})
+	describe(http.MethodGet, "/operations/export", openapi.Spec{ID: "export-operations", Summary: "Export operations",
+		Headers: []openapi.Param{apiKey},
+		QueryParams: []openapi.Param{
+			exportFormat, exportCursor, exportLimit,
+			{Name: "address", Description: "address of the emitter"},
+			sourceChain, targetChain, appID,
+			{Name: "exclusiveAppId", Type: openapi.TypeBoolean, Description: "single appId of the operation"},
+			{Name: "payloadType", Description: "payload types of the operation, separated by comma"},
+			{Name: "from", Description: "beginning of the period"},
+			{Name: "to", Description: "end of the period"},
+			opStatus,
+		},
+		ContentType: fiber.MIMEOctetStream})
+	describe(http.MethodGet, "/operations/stuck", openapi.Spec{ID: "get-stuck-operations", Summary: "Operations that were not redeemed on the target chain",
+		QueryParams: []openapi.Param{
+			page, pageSize, sortOrder, sourceChain, targetChain,
+			{Name: "appId", Description: "ids of the applications, separated by comma"},
+			{Name: "cause", Description: "likely causes, separated by comma"},
+		},
+		Response: operations.ListStuckOperationResponse{}})
+	describe(http.MethodGet, "/operations/sla", openapi.Spec{ID: "get-operations-sla", Summary: "Time to redeem percentiles of the operations",
+		QueryParams: []openapi.Param{{Name: "groupBy", Description: "group of the percentiles", Enum: []any{"targetChain", "appId"}}},
+		Response:    operations.ListOperationSlaResponse{}})
+	describe(http.MethodGet, "/operations/:chain/:emitter/:sequence", openapi.Spec{ID: "get-operation-by-id", Summary: "Find an operation by VAA ID",
+		PathParams: vaaID,
+		Response:   &operations.OperationResponse{}})
+
+	// vaas resource
+	describe(http.MethodGet, "/vaas/vaa-counts", openapi.Spec{ID: "get-vaa-counts", Summary: "Number of VAAs by emitter chain",
+		Response: response.Response[[]*vaasvc.VaaStats]{}})
+	describe(http.MethodGet, "/vaas", openapi.Spec{ID: "find-all-vaas", Summary: "Find all VAAs",
+		QueryParams: append(paginated, txHash, parsedPayload, appID),
+		Response:    response.Response[[]*vaasvc.VaaDoc]{}})
+	describe(http.MethodGet, "/vaas/export", openapi.Spec{ID: "export-vaas", Summary: "Export VAAs",
+		Headers:     []openapi.Param{apiKey},
+		QueryParams: []openapi.Param{exportFormat, exportCursor, exportLimit, txHash, appID},
+		ContentType: fiber.MIMEOctetStream})
+	describe(http.MethodGet, "/vaas/:chain", openapi.Spec{ID: "find-vaas-by-chain", Summary: "Find VAAs by chain",
+		PathParams:  []openapi.Param{chain},
+		QueryParams: paginated,
+		Response:    response.Response[[]*vaasvc.VaaDoc]{}})
+	describe(http.MethodGet, "/vaas/:chain/:emitter", openapi.Spec{ID: "find-vaas-by-emitter", Summary: "Find VAAs by emitter",
+		PathParams:  []openapi.Param{chain, emitter},
+		QueryParams: append(paginated, openapi.Param{Name: "toChain", Type: openapi.TypeInteger, Description: "destination chain"}),
+		Response:    response.Response[[]*vaasvc.VaaDoc]{}})
+	describe(http.MethodGet, "/vaas/:chain/:emitter/:sequence", openapi.Spec{ID: "find-vaa-by-id", Summary: "Find a VAA by ID",
+		PathParams:  vaaID,
+		QueryParams: []openapi.Param{parsedPayload},
+		Response:    response.Response[*vaasvc.VaaDoc]{}})
+	describe(http.MethodGet, "/vaas/:chain/:emitter/:sequence/duplicated", openapi.Spec{ID: "find-duplicated-vaa-by-id", Summary: "Find the duplicated VAAs of a VAA ID",
+		PathParams: vaaID,
+		Response:   response.Response[[]vaa.DuplicateVaaResponse]{}})
+	describe(http.MethodPost, "/vaas/parse", openapi.Spec{ID: "parse-vaa", Summary: "Parse a VAA",
+		Body:     vaaBody,
+		Response: vaaPayloadParser.ParseVaaWithStandarizedPropertiesdResponse{}})
+	describe(http.MethodPost, "/vaas/verify", openapi.Spec{ID: "verify-vaa", Summary: "Verify the signatures of a VAA against its guardian set",
+		Body:     vaaBody,
+		Response: response.Response[*vaasvc.VerifyResult]{}})
+
+	// observations resource
+	describe(http.MethodGet, "/observations", openapi.Spec{ID: "find-observations", Summary: "Find all observations",
+		QueryParams: append(paginated, txHash),
+		Response:    []*obssvc.ObservationDoc{}})
+	describe(http.MethodGet, "/observations/:chain", openapi.Spec{ID: "find-observations-by-chain", Summary: "Find observations by chain",
+		PathParams:  []openapi.Param{chain},
+		QueryParams: paginated,
+		Response:    []*obssvc.ObservationDoc{}})
+	describe(http.MethodGet, "/observations/:chain/:emitter", openapi.Spec{ID: "find-observations-by-emitter", Summary: "Find observations by emitter",
+		PathParams:  []openapi.Param{chain, emitter},
+		QueryParams: paginated,
+		Response:    []*obssvc.ObservationDoc{}})
+	describe(http.MethodGet, "/observations/:chain/:emitter/:sequence", openapi.Spec{ID: "find-observations-by-sequence", Summary: "Find observations by VAA ID",
+		PathParams:  vaaID,
+		QueryParams: paginated,
+		Response:    []*obssvc.ObservationDoc{}})
+	describe(http.MethodGet, "/observations/:chain/:emitter/:sequence/:signer/:hash", openapi.Spec{ID: "find-observations-by-id", Summary: "Find an observation",
+		PathParams: append(vaaID,
+			openapi.Param{Name: "signer", Description: "address of the guardian"},
+			openapi.Param{Name: "hash", Description: "hash of the observation"}),
+		Response: &obssvc.ObservationDoc{}})
+
+	// governor resources
+	describe(http.MethodGet, "/governor/limit", openapi.Spec{ID: "governor-notional-limit", Summary: "Governor limit for all blockchains",
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.GovernorLimit]{}})
+	describe(http.MethodGet, "/governor/config", openapi.Spec{ID: "governor-config", Summary: "Governor configuration for all guardians",
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.GovConfig]{}})
+	describe(http.MethodGet, "/governor/config/:guardian_address", openapi.Spec{ID: "governor-config-by-guardian-address", Summary: "Governor configuration for a guardian",
+		PathParams: []openapi.Param{guardianAddr},
+		Response:   response.Response[*govsvc.GovConfig]{}})
+	describe(http.MethodGet, "/governor/status", openapi.Spec{ID: "governor-status", Summary: "Governor status for all guardians",
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.GovStatus]{}})
+	describe(http.MethodGet, "/governor/status/:guardian_address", openapi.Spec{ID: "governor-status-by-guardian-address", Summary: "Governor status for a guardian",
+		PathParams:  []openapi.Param{guardianAddr},
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[*govsvc.GovStatus]{}})
+	describe(http.MethodGet, "/governor/notional/limit", openapi.Spec{ID: "governor-notional-limit-detail", Summary: "Notional limit for all blockchains",
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.NotionalLimit]{}})
+	describe(http.MethodGet, "/governor/notional/limit/:chain", openapi.Spec{ID: "governor-notional-limit-detail-by-chain", Summary: "Notional limit for a blockchain",
+		PathParams:  []openapi.Param{chain},
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.NotionalLimitDetail]{}})
+	describe(http.MethodGet, "/governor/notional/available", openapi.Spec{ID: "governor-notional-available", Summary: "Notional value available for each blockchain",
+		QueryParams: paginated,
+		Response:    response.Response[[]*govsvc.NotionalAvailable]{}})
+	describe(http.MethodGet, "/governor/notional/available/:chain", openapi.Spec{ID: "governor-notional-available-by-chain", Summary: "Notional value available for a blockchain",
+		PathParams:  []openapi.Param{chain},
+		QueryParams: []openapi.Param{page, pageSize},
+		Response:    response.Response[[]*govsvc.NotionalAvailableDetail]{}})
+	describe(http.MethodGet, "/governor/notional/max_available/:chain", openapi.Spec{ID: "governor-max-notional-available-by-chain", Summary: "Maximum notional value available for a blockchain",
+		PathParams: []openapi.Param{chain},
+		Response:   response.Response[*govsvc.MaxNotionalAvailableRecord]{}})
+	describe(http.MethodGet, "/governor/enqueued_vaas", openapi.Spec{ID: "governor-enqueued-vaas", Summary: "Enqueued VAAs for each blockchain",
+		QueryParams: paginated,
+		Response:    response.Response[[]*govsvc.EnqueuedVaas]{}})
+	describe(http.MethodGet, "/governor/enqueued_vaas/:chain", openapi.Spec{ID: "governor-enqueued-vaas-by-chain", Summary: "Enqueued VAAs for a blockchain",
+		PathParams:  []openapi.Param{chain},
+		QueryParams: paginated,
+		Response:    response.Response[[]*govsvc.EnqueuedVaaDetail]{}})
+	describe(http.MethodGet, "/governor/vaas", openapi.Spec{ID: "governor-vaas", Summary: "VAAs in the governor",
+		Response: []governor.GovernorVaasResponse{}})
+	describe(http.MethodGet, "/governor/history/notional", openapi.Spec{ID: "governor-notional-history", Summary: "Daily limit utilization of the blockchains",
+		QueryParams: governorHistory,
+		Response:    []*govsvc.NotionalHistory{}})
+	describe(http.MethodGet, "/governor/history/enqueued_vaas", openapi.Spec{ID: "governor-enqueued-vaas-history", Summary: "Enqueued VAAs and release latency of the blockchains",
+		QueryParams: governorHistory,
+		Response:    []*govsvc.EnqueuedVaaHistory{}})
+	describe(http.MethodGet, "/governor/simulate", openapi.Spec{ID: "governor-simulate", Summary: "Simulate whether the governor would delay a transfer",
+		QueryParams: []openapi.Param{
+			{Name: "chain", Type: openapi.TypeInteger, Required: true, Description: "id of the blockchain the transfer is sent from"},
+			{Name: "tokenChain", Type: openapi.TypeInteger, Description: "id of the original blockchain of the token, defaults to chain"},
+			{Name: "tokenAddress", Required: true, Description: "original address of the token"},
+			{Name: "amount", Required: true, Description: "amount of tokens transferred, in token units"}},
+		Response: &govsvc.GovernorSimulation{}})
+
+	// relays resource
+	describe(http.MethodGet, "/relays/:chain/:emitter/:sequence", openapi.Spec{ID: "find-relay-by-vaa-id", Summary: "Find a relay by VAA ID",
+		PathParams: vaaID,
+		Response:   &relays.RelayResponse{}})
+
+	// emitters resource
+	emitterID := []openapi.Param{chain, emitter}
+	describe(http.MethodGet, "/emitters", openapi.Spec{ID: "find-emitters", Summary: "Find the emitters of the registry",
+		QueryParams: []openapi.Param{
+			{Name: "chain", Type: openapi.TypeInteger, Description: "id of the blockchain"},
+			appID, page, pageSize,
+		},
+		Response: response.Response[[]*emitterssvc.Emitter]{}})
+	describe(http.MethodGet, "/emitters/:chain/:emitter", openapi.Spec{ID: "find-emitter", Summary: "Find an emitter of the registry",
+		PathParams: emitterID,
+		Response:   &emitterssvc.Emitter{}})
+	describe(http.MethodPut, "/emitters/:chain/:emitter", openapi.Spec{ID: "upsert-emitter", Summary: "Create or update an emitter of the registry",
+		PathParams: emitterID,
+		Headers:    []openapi.Param{adminKey},
+		Body:       emitterssvc.UpsertEmitter{},
+		Response:   &emitterssvc.Emitter{}})
+	describe(http.MethodDelete, "/emitters/:chain/:emitter", openapi.Spec{ID: "delete-emitter", Summary: "Delete an emitter of the registry",
+		PathParams: emitterID,
+		Headers:    []openapi.Param{adminKey},
+		Status:     http.StatusNoContent})
+
+	// api keys resource
+	keyID := []openapi.Param{{Name: "id", Description: "id of the api key"}}
+	describe(http.MethodGet, "/api-keys", openapi.Spec{ID: "find-api-keys", Summary: "Find the managed api keys",
+		Headers:     []openapi.Param{adminKey},
+		QueryParams: paginated,
+		Response:    response.Response[[]*apikeyssvc.ApiKey]{}})
+	describe(http.MethodPost, "/api-keys", openapi.Spec{ID: "create-api-key", Summary: "Create a managed api key",
+		Headers:  []openapi.Param{adminKey},
+		Body:     apikeyssvc.UpsertApiKey{},
+		Response: &apikeyssvc.CreatedApiKey{},
+		Status:   http.StatusCreated})
+	describe(http.MethodPut, "/api-keys/:id", openapi.Spec{ID: "update-api-key", Summary: "Update a managed api key",
+		PathParams: keyID,
+		Headers:    []openapi.Param{adminKey},
+		Body:       apikeyssvc.UpsertApiKey{},
+		Response:   &apikeyssvc.ApiKey{}})
+	describe(http.MethodDelete, "/api-keys/:id", openapi.Spec{ID: "revoke-api-key", Summary: "Revoke a managed api key",
+		PathParams: keyID,
+		Headers:    []openapi.Param{adminKey},
+		Status:     http.StatusNoContent})
+	describe(http.MethodPost, "/api-keys/:id/rotate", openapi.Spec{ID: "rotate-api-key", Summary: "Rotate a managed api key",
+		PathParams:  keyID,
+		Headers:     []openapi.Param{adminKey},
+		QueryParams: []openapi.Param{{Name: "gracePeriod", Description: "time the previous key remains valid, e.g. 1h"}},
+		Response:    &apikeyssvc.CreatedApiKey{}})
+	describe(http.MethodGet, "/api-keys/:id/usage", openapi.Spec{ID: "find-api-key-usage", Summary: "Number of requests of a managed api key by day",
+		PathParams:  keyID,
+		Headers:     []openapi.Param{adminKey},
+		QueryParams: []openapi.Param{{Name: "days", Type: openapi.TypeInteger, Min: &min1, Description: "number of days"}},
+		Response:    []apikeyssvc.Usage{}})
+
+	return b
+}
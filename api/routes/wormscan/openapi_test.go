@@ -0,0 +1,121 @@
+package wormscan_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan"
+	"go.uber.org/zap"
+)
+
+// newTestApp registers the Wormscan routes without services, only the route registrations are needed.
+func newTestApp() *fiber.App {
+	app := fiber.New()
+	next := func(c *fiber.Ctx) error { return c.Next() }
+	wormscan.RegisterRoutes(&config.AppConfig{}, next, next, nil, app, zap.NewNop(),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return app
+}
+
+func TestOpenAPI_RoutesAreDescribed(t *testing.T) {
+	app := newTestApp()
+	b := wormscan.NewOpenAPIBuilder()
+
+	for _, route := range app.GetRoutes(true) {
+		if !strings.HasPrefix(route.Path, wormscan.OpenAPIPrefix) || route.Method == fiber.MethodHead {
+			continue
+		}
+		assert.NotNil(t, b.Spec(route.Method, route.Path), "%s %s is not described in the OpenAPI document", route.Method, route.Path)
+	}
+}
+
+func TestOpenAPI_Document(t *testing.T) {
+	doc := wormscan.OpenAPI(newTestApp())
+
+	_, err := json.Marshal(doc)
+	assert.NoError(t, err)
+
+	op, template := doc.Operation(fiber.MethodGet, "/api/v1/vaas/vaa-counts")
+	if assert.NotNil(t, op) {
+		assert.Equal(t, "get-vaa-counts", op.OperationID)
+		assert.Equal(t, "/api/v1/vaas/vaa-counts", template)
+	}
+	op, template = doc.Operation(fiber.MethodGet, "/api/v1/vaas/2/0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585/1")
+	if assert.NotNil(t, op) {
+		assert.Equal(t, "find-vaa-by-id", op.OperationID)
+		assert.Equal(t, "/api/v1/vaas/{chain}/{emitter}/{sequence}", template)
+	}
+}
+
+// TestOpenAPI_ResponseSchemas checks that the encoding of the response types of the routes matches their schema,
+// so the document does not diverge from the responses of the handlers.
+func TestOpenAPI_ResponseSchemas(t *testing.T) {
+	app := newTestApp()
+	b := wormscan.NewOpenAPIBuilder()
+	doc := b.Build(app.GetRoutes(true), wormscan.OpenAPIPrefix)
+
+	for _, route := range app.GetRoutes(true) {
+		spec := b.Spec(route.Method, route.Path)
+		if spec == nil || spec.Response == nil {
+			continue
+		}
+		t.Run(route.Method+" "+route.Path, func(t *testing.T) {
+			body, err := json.Marshal(sample(reflect.TypeOf(spec.Response), 0).Addr().Interface())
+			if !assert.NoError(t, err) {
+				return
+			}
+			status := spec.Status
+			if status == 0 {
+				status = fiber.StatusOK
+			}
+			assert.NoError(t, doc.ValidateResponse(route.Method, route.Path, status, body))
+		})
+	}
+}
+
+// sample returns a value of type t with all its exported fields, slices and maps populated.
+// Strings are numeric, so the custom marshalers that parse them succeed.
+func sample(t reflect.Type, depth int) reflect.Value {
+	v := reflect.New(t).Elem()
+	if depth > 8 {
+		return v
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		v.Set(sample(t.Elem(), depth+1).Addr())
+	case reflect.String:
+		v.SetString("1")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Slice:
+		v.Set(reflect.Append(reflect.MakeSlice(t, 0, 1), sample(t.Elem(), depth+1)))
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			v.Set(reflect.MakeMap(t))
+			v.SetMapIndex(reflect.ValueOf("1").Convert(t.Key()), sample(t.Elem(), depth+1))
+		}
+	case reflect.Struct:
+		if t == reflect.TypeOf(time.Time{}) {
+			v.Set(reflect.ValueOf(time.Now()))
+			break
+		}
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				v.Field(i).Set(sample(t.Field(i).Type, depth+1))
+			}
+		}
+	}
+	return v
+}
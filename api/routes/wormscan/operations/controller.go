@@ -8,8 +8,12 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	commonexport "github.com/wormhole-foundation/wormhole-explorer/common/export"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -25,6 +29,9 @@ type Controller struct {
 type operationService interface {
 	FindById(ctx context.Context, chainID vaa.ChainID, emitter *types.Address, seq string) (*operations.OperationDto, error)
 	FindAll(ctx context.Context, filter operations.OperationFilter) ([]*operations.OperationDto, error)
+	FindStuck(ctx context.Context, filter operations.StuckOperationFilter) ([]*repository.StuckOperationDoc, error)
+	FindSla(ctx context.Context, groupBy string) ([]*repository.OperationSlaDoc, error)
+	Export(ctx context.Context, filter operations.ExportFilter) (export.Source, *export.Cursor, error)
 }
 
 // NewController create a new controler.
@@ -49,6 +56,7 @@ func NewController(operationService operationService, logger *zap.Logger) *Contr
 // @Param exclusiveAppId query boolean false "single appId of the operation".
 // @Param from query string false "beginning of period"
 // @Param to query string false "end of period"
+// @Param status query string false "lifecycle status of the operation, separated by comma" Enums(observed, governor_enqueued, vaa_signed, target_failed, redeemed)
 // @Success 200 {object} []OperationResponse
 // @Failure 400
 // @Failure 500
@@ -106,17 +114,9 @@ func (c *Controller) FindAll(ctx *fiber.Ctx) error {
 		return response.NewInvalidParamError(ctx, "address/txHash cannot be combined with sourceChain/targetChain/appId query filter", nil)
 	}
 
-	payloadTypeParam := ctx.Query("payloadType")
-	var payloadType []int
-	if payloadTypeParam != "" {
-		payloadTypes := strings.Split(payloadTypeParam, ",")
-		for _, pt := range payloadTypes {
-			ptype, errPtype := strconv.Atoi(pt)
-			if errPtype != nil {
-				return response.NewInvalidParamError(ctx, "invalid payloadType", errPtype)
-			}
-			payloadType = append(payloadType, ptype)
-		}
+	payloadType, err := extractPayloadTypes(ctx)
+	if err != nil {
+		return err
 	}
 
 	from, err := middleware.ExtractTime(ctx, time.RFC3339, "from")
@@ -133,6 +133,11 @@ func (c *Controller) FindAll(ctx *fiber.Ctx) error {
 		return response.NewInvalidParamError(ctx, "invalid date range", nil)
 	}
 
+	statuses, err := extractStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
 	filter := operations.OperationFilter{
 		TxHash:         txHash,
 		Address:        address,
@@ -144,6 +149,7 @@ func (c *Controller) FindAll(ctx *fiber.Ctx) error {
 		Pagination:     *pagination,
 		From:           from,
 		To:             to,
+		Statuses:       statuses,
 	}
 
 	// Find operations by q search param.
@@ -157,6 +163,146 @@ func (c *Controller) FindAll(ctx *fiber.Ctx) error {
 	return ctx.JSON(resp)
 }
 
+// Export godoc
+// @Description Export operations as a CSV, newline-delimited JSON or Parquet stream, in the transfer report column set.
+// @Description The export is sorted from the latest operation. When there are more operations than the limit,
+// @Description the X-Next-Cursor response header contains the cursor to resume the export. Requires an API key.
+// @Tags wormholescan
+// @ID export-operations
+// @Param X-API-KEY header string true "API key"
+// @Param format query string false "format of the export" Enums(csv, ndjson, parquet)
+// @Param cursor query string false "cursor returned in the X-Next-Cursor header of the previous export"
+// @Param limit query integer false "max number of operations to export". Maximum value is 100000.
+// @Param address query string false "address of the emitter"
+// @Param sourceChain query string false "source chains of the operation, separated by comma".
+// @Param targetChain query string false "target chains of the operation, separated by comma".
+// @Param appId query string false "appID of the operation".
+// @Param exclusiveAppId query boolean false "single appId of the operation".
+// @Param payloadType query string false "payload types of the operation, separated by comma".
+// @Param from query string false "beginning of period"
+// @Param to query string false "end of period"
+// @Param status query string false "lifecycle status of the operation, separated by comma" Enums(observed, governor_enqueued, vaa_signed, target_failed, redeemed)
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 429
+// @Failure 500
+// @Router /api/v1/operations/export [get]
+func (c *Controller) Export(ctx *fiber.Ctx) error {
+	params, err := middleware.ExtractExportParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Query("txHash") != "" {
+		return response.NewInvalidParamError(ctx, "txHash is not supported by the export", nil)
+	}
+	address := middleware.ExtractAddressFromQueryParams(ctx, c.logger)
+
+	sourceChain, err := middleware.ExtractSourceChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	targetChain, err := middleware.ExtractTargetChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	var appIDs []string
+	appIDQueryParam := ctx.Query("appId")
+	if appIDQueryParam != "" {
+		appIDs = strings.Split(appIDQueryParam, ",")
+	}
+
+	exclusiveAppId, err := middleware.ExtractExclusiveAppId(ctx)
+	if err != nil {
+		return err
+	}
+
+	payloadType, err := extractPayloadTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	from, err := middleware.ExtractTime(ctx, time.RFC3339, "from")
+	if err != nil {
+		return err
+	}
+
+	to, err := middleware.ExtractTime(ctx, time.RFC3339, "to")
+	if err != nil {
+		return err
+	}
+
+	if from != nil && to != nil && to.Before(*from) {
+		return response.NewInvalidParamError(ctx, "invalid date range", nil)
+	}
+
+	statuses, err := extractStatuses(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := operations.ExportFilter{
+		Address:        address,
+		SourceChainIDs: sourceChain,
+		TargetChainIDs: targetChain,
+		AppIDs:         appIDs,
+		ExclusiveAppId: exclusiveAppId,
+		PayloadType:    payloadType,
+		From:           from,
+		To:             to,
+		Statuses:       statuses,
+		Cursor:         params.Cursor,
+		Limit:          params.Limit,
+	}
+
+	// The query is executed before streaming, so that errors are returned with the proper status code.
+	source, next, err := c.srv.Export(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if next != nil {
+		ctx.Set(export.HeaderNextCursor, next.Encode())
+	}
+	return export.Stream(ctx, params.Format, "operations", commonexport.TransferHeader(), source, c.logger)
+}
+
+// extractPayloadTypes get the payloadType query parameter, separated by comma.
+func extractPayloadTypes(ctx *fiber.Ctx) ([]int, error) {
+	payloadTypeParam := ctx.Query("payloadType")
+	var payloadType []int
+	if payloadTypeParam != "" {
+		payloadTypes := strings.Split(payloadTypeParam, ",")
+		for _, pt := range payloadTypes {
+			ptype, errPtype := strconv.Atoi(pt)
+			if errPtype != nil {
+				return nil, response.NewInvalidParamError(ctx, "invalid payloadType", errPtype)
+			}
+			payloadType = append(payloadType, ptype)
+		}
+	}
+	return payloadType, nil
+}
+
+// extractStatuses get the status query parameter, separated by comma.
+func extractStatuses(ctx *fiber.Ctx) ([]domain.OperationStatus, error) {
+	var statuses []domain.OperationStatus
+	statusParam := ctx.Query("status")
+	if statusParam != "" {
+		for _, s := range strings.Split(statusParam, ",") {
+			status, errStatus := domain.ParseOperationStatus(s)
+			if errStatus != nil {
+				return nil, response.NewInvalidParamError(ctx, "invalid status", errStatus)
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
 // FindById godoc
 // @Description Find operations by ID (chainID/emitter/sequence).
 // @Tags wormholescan
@@ -188,3 +334,94 @@ func (c *Controller) FindById(ctx *fiber.Ctx) error {
 	}
 	return ctx.JSON(response)
 }
+
+// FindStuck godoc
+// @Description Find the operations that were not redeemed on the target chain.
+// @Tags wormholescan
+// @ID get-stuck-operations
+// @Param page query integer false "page number"
+// @Param pageSize query integer false "pageSize". Maximum value is 100.
+// @Param sortOrder query string false "Sort results in ascending or descending order by stuckSince." Enums(ASC, DESC)
+// @Param sourceChain query string false "source chains of the operation, separated by comma".
+// @Param targetChain query string false "target chains of the operation, separated by comma. The operations enqueued by the governor whose target chain is not known yet are included".
+// @Param appId query string false "appIDs of the operation, separated by comma".
+// @Param cause query string false "likely cause, separated by comma" Enums(governor_enqueued, target_not_tracked, relayer_not_delivered, redeem_failed)
+// @Success 200 {object} ListStuckOperationResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/operations/stuck [get]
+func (c *Controller) FindStuck(ctx *fiber.Ctx) error {
+	pagination, err := middleware.ExtractPagination(ctx)
+	if err != nil {
+		return err
+	}
+	if pagination.Limit > 100 {
+		return response.NewInvalidParamError(ctx, "pageSize cannot be greater than 100", nil)
+	}
+
+	sourceChain, err := middleware.ExtractSourceChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	targetChain, err := middleware.ExtractTargetChain(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	var appIDs []string
+	appIDQueryParam := ctx.Query("appId")
+	if appIDQueryParam != "" {
+		appIDs = strings.Split(appIDQueryParam, ",")
+	}
+
+	var causes []domain.StuckCause
+	causeParam := ctx.Query("cause")
+	if causeParam != "" {
+		for _, s := range strings.Split(causeParam, ",") {
+			cause, errCause := domain.ParseStuckCause(s)
+			if errCause != nil {
+				return response.NewInvalidParamError(ctx, "invalid cause", errCause)
+			}
+			causes = append(causes, cause)
+		}
+	}
+
+	filter := operations.StuckOperationFilter{
+		SourceChainIDs: sourceChain,
+		TargetChainIDs: targetChain,
+		AppIDs:         appIDs,
+		Causes:         causes,
+		Pagination:     *pagination,
+	}
+
+	stuck, err := c.srv.FindStuck(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(toListStuckOperationResponse(stuck, time.Now()))
+}
+
+// FindSla godoc
+// @Description Get the time to redeem percentiles (p50, p90, p99) of the operations, in seconds, per target chain and per app.
+// @Tags wormholescan
+// @ID get-operations-sla
+// @Param groupBy query string false "group of the percentiles" Enums(targetChain, appId)
+// @Success 200 {object} ListOperationSlaResponse
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/operations/sla [get]
+func (c *Controller) FindSla(ctx *fiber.Ctx) error {
+	groupBy := ctx.Query("groupBy")
+	switch groupBy {
+	case "", repository.SlaGroupByTargetChain, repository.SlaGroupByAppID:
+	default:
+		return response.NewInvalidParamError(ctx, "invalid groupBy", nil)
+	}
+
+	sla, err := c.srv.FindSla(ctx.Context(), groupBy)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(toListOperationSlaResponse(sla))
+}
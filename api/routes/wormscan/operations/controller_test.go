@@ -5,15 +5,21 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/test-go/testify/mock"
 	ops "github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/operations"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	commonexport "github.com/wormhole-foundation/wormhole-explorer/common/export"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 	"io"
 	"net/http"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func Test_FindAll(t *testing.T) {
@@ -56,6 +62,25 @@ func Test_FindAll(t *testing.T) {
 			expectedResponse:   `{"code":3,"message":"invalid payloadType","details":[{"request_id":"\u003cnil\u003e"}]}`,
 			setupServiceMock:   func(mockService *mockOpsService) {},
 		},
+		{
+			name:               "Test_FindAll_MultipleStatus",
+			requestURL:         "/api/v1/operations?status=vaa_signed,governor_enqueued",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"operations":[]}`,
+			setupServiceMock: func(mockService *mockOpsService) {
+				statusMatcher := mock.MatchedBy(func(filter ops.OperationFilter) bool {
+					return slices.Equal(filter.Statuses, []domain.OperationStatus{domain.OperationStatusVaaSigned, domain.OperationStatusGovernorEnqueued})
+				})
+				mockService.On("FindAll", mock.Anything, statusMatcher).Return([]*ops.OperationDto{}, nil)
+			},
+		},
+		{
+			name:               "Test_FindAll_InvalidStatus",
+			requestURL:         "/api/v1/operations?status=vaa_signed,stuck",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"code":3,"message":"invalid status","details":[{"request_id":"\u003cnil\u003e"}]}`,
+			setupServiceMock:   func(mockService *mockOpsService) {},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -97,6 +122,208 @@ func Test_FindAll(t *testing.T) {
 
 }
 
+func Test_FindById_GovernorEnqueued(t *testing.T) {
+
+	emitter := "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585"
+	releaseTime := time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)
+	mockService := &mockOpsService{}
+	mockService.On("FindById", mock.Anything, vaa.ChainIDEthereum, mock.Anything, "1").Return(&ops.OperationDto{
+		ID:       "2/" + emitter + "/1",
+		Status:   string(domain.OperationStatusObserved),
+		Governor: &ops.GovernorVaaDto{ReleaseTime: releaseTime},
+	}, nil)
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler:          middleware.ErrorHandler,
+		DisableStartupMessage: true,
+		Immutable:             true,
+	})
+	app.Get("/api/v1/operations/:chain/:emitter/:sequence", operations.NewController(mockService, zap.NewNop()).FindById)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/operations/2/"+emitter+"/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, _ := app.Test(req, 1000)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the vaa is enqueued by the governor, so the operation is further in its lifecycle than the stored status.
+	if !strings.Contains(string(respBytes), `"status":"governor_enqueued"`) {
+		t.Fatalf("expected governor_enqueued status, got %s", string(respBytes))
+	}
+}
+
+func Test_FindStuck(t *testing.T) {
+
+	testCases := []struct {
+		name               string
+		requestURL         string
+		expectedStatusCode int
+		expectedResponse   string
+		setupServiceMock   func(*mockOpsService)
+	}{
+		{
+			name:               "Test_FindStuck_MultipleCause",
+			requestURL:         "/api/v1/operations/stuck?cause=redeem_failed,relayer_not_delivered",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"operations":[]}`,
+			setupServiceMock: func(mockService *mockOpsService) {
+				causeMatcher := mock.MatchedBy(func(filter ops.StuckOperationFilter) bool {
+					return slices.Equal(filter.Causes, []domain.StuckCause{domain.StuckCauseRedeemFailed, domain.StuckCauseRelayerNotDelivered})
+				})
+				mockService.On("FindStuck", mock.Anything, causeMatcher).Return([]*repository.StuckOperationDoc{}, nil)
+			},
+		},
+		{
+			name:               "Test_FindStuck_InvalidCause",
+			requestURL:         "/api/v1/operations/stuck?cause=redeem_failed,unknown",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"code":3,"message":"invalid cause","details":[{"request_id":"\u003cnil\u003e"}]}`,
+			setupServiceMock:   func(mockService *mockOpsService) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			req, err := http.NewRequest(http.MethodGet, testCase.requestURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mockService := &mockOpsService{}
+			testCase.setupServiceMock(mockService)
+
+			app := fiber.New(fiber.Config{
+				ErrorHandler:          middleware.ErrorHandler,
+				DisableStartupMessage: true,
+				Immutable:             true,
+			})
+			app.Get("/api/v1/operations/stuck", operations.NewController(mockService, zap.NewNop()).FindStuck)
+
+			resp, _ := app.Test(req, 1000)
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d", testCase.expectedStatusCode, resp.StatusCode)
+			}
+
+			respBytes, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(respBytes) != testCase.expectedResponse {
+				t.Fatalf("expected response %s, got %s", testCase.expectedResponse, string(respBytes))
+			}
+
+		})
+	}
+
+}
+
+func Test_Export(t *testing.T) {
+
+	timestamp := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	next := &export.Cursor{Timestamp: timestamp, ID: "2/000000000000000000000000b6f6d86a8f9879a9c87f643768d9efc38c1da6e7/1"}
+
+	testCases := []struct {
+		name               string
+		requestURL         string
+		expectedStatusCode int
+		expectedCursor     string
+		expectedResponse   string
+		setupServiceMock   func(*mockOpsService)
+	}{
+		{
+			name:               "Test_Export_CSV",
+			requestURL:         "/api/v1/operations/export?sourceChain=2&limit=1",
+			expectedStatusCode: http.StatusOK,
+			expectedCursor:     next.Encode(),
+			expectedResponse: strings.Join(commonexport.TransferHeader(), ",") + "\n" +
+				"2/000000000000000000000000b6f6d86a8f9879a9c87f643768d9efc38c1da6e7/1,,2,,1,2024-05-01T10:00:00Z,,,,,,,PORTAL_TOKEN_BRIDGE,,,,,,,,\n",
+			setupServiceMock: func(mockService *mockOpsService) {
+				filterMatcher := mock.MatchedBy(func(filter ops.ExportFilter) bool {
+					return slices.Equal(filter.SourceChainIDs, []vaa.ChainID{vaa.ChainIDEthereum}) && filter.Limit == 1 && filter.Cursor == nil
+				})
+				source := &mockSource{records: []export.Record{&commonexport.TransferRecord{
+					VaaID:       "2/000000000000000000000000b6f6d86a8f9879a9c87f643768d9efc38c1da6e7/1",
+					SourceChain: vaa.ChainIDEthereum,
+					Sequence:    "1",
+					Timestamp:   timestamp,
+					AppIDs:      []string{domain.AppIdPortalTokenBridge},
+				}}}
+				mockService.On("Export", mock.Anything, filterMatcher).Return(source, next, nil)
+			},
+		},
+		{
+			name:               "Test_Export_InvalidFormat",
+			requestURL:         "/api/v1/operations/export?format=xml",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"code":3,"message":"INVALID \u003cformat\u003e QUERY PARAMETER","details":[{"request_id":"\u003cnil\u003e"}]}`,
+			setupServiceMock:   func(mockService *mockOpsService) {},
+		},
+		{
+			name:               "Test_Export_InvalidCursor",
+			requestURL:         "/api/v1/operations/export?cursor=invalid",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"code":3,"message":"INVALID \u003ccursor\u003e QUERY PARAMETER","details":[{"request_id":"\u003cnil\u003e"}]}`,
+			setupServiceMock:   func(mockService *mockOpsService) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+
+			req, err := http.NewRequest(http.MethodGet, testCase.requestURL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			mockService := &mockOpsService{}
+			testCase.setupServiceMock(mockService)
+
+			app := fiber.New(fiber.Config{
+				ErrorHandler:          middleware.ErrorHandler,
+				DisableStartupMessage: true,
+				Immutable:             true,
+			})
+			app.Get("/api/v1/operations/export", operations.NewController(mockService, zap.NewNop()).Export)
+
+			resp, _ := app.Test(req, 1000)
+			defer resp.Body.Close()
+
+			if resp.StatusCode != testCase.expectedStatusCode {
+				t.Fatalf("expected status code %d, got %d", testCase.expectedStatusCode, resp.StatusCode)
+			}
+
+			if cursor := resp.Header.Get(export.HeaderNextCursor); cursor != testCase.expectedCursor {
+				t.Fatalf("expected cursor %s, got %s", testCase.expectedCursor, cursor)
+			}
+
+			respBytes, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(respBytes) != testCase.expectedResponse {
+				t.Fatalf("expected response %s, got %s", testCase.expectedResponse, string(respBytes))
+			}
+
+		})
+	}
+
+}
+
 type mockOpsService struct {
 	mock.Mock
 }
@@ -109,3 +336,32 @@ func (m *mockOpsService) FindAll(ctx context.Context, filter ops.OperationFilter
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]*ops.OperationDto), args.Error(1)
 }
+func (m *mockOpsService) FindStuck(ctx context.Context, filter ops.StuckOperationFilter) ([]*repository.StuckOperationDoc, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*repository.StuckOperationDoc), args.Error(1)
+}
+func (m *mockOpsService) FindSla(ctx context.Context, groupBy string) ([]*repository.OperationSlaDoc, error) {
+	args := m.Called(ctx, groupBy)
+	return args.Get(0).([]*repository.OperationSlaDoc), args.Error(1)
+}
+func (m *mockOpsService) Export(ctx context.Context, filter ops.ExportFilter) (export.Source, *export.Cursor, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(export.Source), args.Get(1).(*export.Cursor), args.Error(2)
+}
+
+type mockSource struct {
+	records []export.Record
+}
+
+func (s *mockSource) Next(ctx context.Context) (export.Record, error) {
+	if len(s.records) == 0 {
+		return nil, io.EOF
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func (s *mockSource) Close(ctx context.Context) error {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -24,6 +25,25 @@ type OperationResponse struct {
 	SourceChain    *SourceChain   `json:"sourceChain,omitempty"`
 	TargetChain    *TargetChain   `json:"targetChain,omitempty"`
 	Data           map[string]any `json:"data,omitempty"`
+	Status         string         `json:"status"`
+	Durations      *Durations     `json:"durations,omitempty"`
+	Governor       *Governor      `json:"governor,omitempty"`
+}
+
+// Governor contains the release times of an operation whose VAA is enqueued by the governor.
+type Governor struct {
+	// ReleaseTime is the latest release time reported by a guardian.
+	ReleaseTime time.Time `json:"releaseTime"`
+	// QuorumReleaseTime is the estimated time at which a quorum of guardians releases the VAA.
+	QuorumReleaseTime *time.Time `json:"quorumReleaseTime,omitempty"`
+}
+
+// Durations contains the time spent by the operation in each stage of its lifecycle, in seconds.
+type Durations struct {
+	// TimeToVaa is the time between the source transaction and the VAA being signed.
+	TimeToVaa *float64 `json:"timeToVaa,omitempty"`
+	// TimeToRedeem is the time between the VAA being signed and the redeem on the target chain.
+	TimeToRedeem *float64 `json:"timeToRedeem,omitempty"`
 }
 
 // EmitterAddress definition.
@@ -141,11 +161,74 @@ func toOperationResponse(operation *operations.OperationDto, log *zap.Logger) (*
 		Data:        getAdditionalData(operation),
 		SourceChain: sourceChain,
 		TargetChain: targetChain,
+		Status:      string(getStatus(operation)),
+		Durations:   getDurations(operation),
+		Governor:    getGovernor(operation),
 	}
 
 	return &r, nil
 }
 
+// getStatus returns the lifecycle status of the operation.
+//
+// Operations processed before the status was stored don't have it, so the status derived from
+// the operation data is used when it is later in the lifecycle than the stored one.
+func getStatus(operation *operations.OperationDto) domain.OperationStatus {
+	var destinationTxStatus string
+	if operation.DestinationTx != nil {
+		destinationTxStatus = operation.DestinationTx.Status
+	}
+	status := domain.DeriveOperationStatus(operation.Vaa != nil, operation.Governor != nil, destinationTxStatus)
+	if stored, err := domain.ParseOperationStatus(operation.Status); err == nil && stored.IsAfter(status) {
+		return stored
+	}
+	return status
+}
+
+// getGovernor returns the governor release times of the operation, or nil if its VAA is not enqueued.
+func getGovernor(operation *operations.OperationDto) *Governor {
+	if operation.Governor == nil {
+		return nil
+	}
+	return &Governor{
+		ReleaseTime:       operation.Governor.ReleaseTime,
+		QuorumReleaseTime: operation.Governor.QuorumReleaseTime,
+	}
+}
+
+// getDurations returns the time to VAA and the time to redeem of the operation.
+func getDurations(operation *operations.OperationDto) *Durations {
+	if operation.Vaa == nil || operation.Vaa.Timestamp == nil {
+		return nil
+	}
+
+	// the VAA timestamp is the timestamp of the block that contains the source transaction.
+	signedAt := operation.Vaa.IndexedAt
+	if operation.Lifecycle != nil && operation.Lifecycle.VaaSignedAt != nil {
+		signedAt = operation.Lifecycle.VaaSignedAt
+	}
+	if signedAt == nil {
+		return nil
+	}
+
+	var durations Durations
+	durations.TimeToVaa = secondsBetween(*operation.Vaa.Timestamp, *signedAt)
+	if operation.DestinationTx != nil && operation.DestinationTx.Status == domain.DstTxStatusConfirmed &&
+		operation.DestinationTx.Timestamp != nil {
+		durations.TimeToRedeem = secondsBetween(*signedAt, *operation.DestinationTx.Timestamp)
+	}
+	return &durations
+}
+
+// secondsBetween returns the seconds elapsed between from and to, or nil if to is before from.
+func secondsBetween(from, to time.Time) *float64 {
+	if to.Before(from) {
+		return nil
+	}
+	seconds := to.Sub(from).Seconds()
+	return &seconds
+}
+
 // getChainEmitterSequence returns the chainID, address, sequence for the given operation.
 func getChainEmitterSequence(operation *operations.OperationDto) (sdk.ChainID, string, string, error) {
 	if operation.Vaa != nil {
@@ -272,3 +355,82 @@ func toListOperationResponse(operations []*operations.OperationDto, log *zap.Log
 
 	return response
 }
+
+// StuckOperationResponse definition.
+type StuckOperationResponse struct {
+	ID           string                 `json:"id"`
+	Cause        domain.StuckCause      `json:"cause"`
+	Status       domain.OperationStatus `json:"status"`
+	EmitterChain sdk.ChainID            `json:"emitterChain"`
+	TargetChain  *sdk.ChainID           `json:"targetChain,omitempty"`
+	AppIDs       []string               `json:"appIds"`
+	TxHash       string                 `json:"txHash,omitempty"`
+	StuckSince   time.Time              `json:"stuckSince"`
+	// StuckFor is the time elapsed since the operation was signed or enqueued, in seconds.
+	StuckFor   float64   `json:"stuckFor"`
+	DetectedAt time.Time `json:"detectedAt"`
+}
+
+type ListStuckOperationResponse struct {
+	Operations []*StuckOperationResponse `json:"operations"`
+}
+
+// OperationSlaResponse contains the time to redeem percentiles, in seconds, of a target chain or an app id.
+type OperationSlaResponse struct {
+	GroupBy string    `json:"groupBy"`
+	Key     string    `json:"key"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Count   int       `json:"count"`
+	P50     float64   `json:"p50"`
+	P90     float64   `json:"p90"`
+	P99     float64   `json:"p99"`
+}
+
+type ListOperationSlaResponse struct {
+	Sla []*OperationSlaResponse `json:"sla"`
+}
+
+func toListStuckOperationResponse(stuck []*repository.StuckOperationDoc, now time.Time) ListStuckOperationResponse {
+	response := ListStuckOperationResponse{
+		Operations: make([]*StuckOperationResponse, 0, len(stuck)),
+	}
+	for _, s := range stuck {
+		var targetChain *sdk.ChainID
+		if s.TargetChain != sdk.ChainIDUnset {
+			targetChain = &s.TargetChain
+		}
+		response.Operations = append(response.Operations, &StuckOperationResponse{
+			ID:           s.ID,
+			Cause:        s.Cause,
+			Status:       s.Status,
+			EmitterChain: s.EmitterChain,
+			TargetChain:  targetChain,
+			AppIDs:       s.AppIDs,
+			TxHash:       s.TxHash,
+			StuckSince:   s.StuckSince,
+			StuckFor:     now.Sub(s.StuckSince).Seconds(),
+			DetectedAt:   s.DetectedAt,
+		})
+	}
+	return response
+}
+
+func toListOperationSlaResponse(sla []*repository.OperationSlaDoc) ListOperationSlaResponse {
+	response := ListOperationSlaResponse{
+		Sla: make([]*OperationSlaResponse, 0, len(sla)),
+	}
+	for _, s := range sla {
+		response.Sla = append(response.Sla, &OperationSlaResponse{
+			GroupBy: s.GroupBy,
+			Key:     s.Key,
+			From:    s.From,
+			To:      s.To,
+			Count:   s.Count,
+			P50:     s.P50,
+			P90:     s.P90,
+			P99:     s.P99,
+		})
+	}
+	return response
+}
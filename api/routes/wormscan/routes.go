@@ -2,40 +2,53 @@ package wormscan
 
 import (
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	addrsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/address"
+	apikeyssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/apikeys"
+	emitterssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/emitters"
 	govsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/governor"
 	infrasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/infrastructure"
 	obssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/observations"
 	opsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/operations"
 	protocolssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/protocols"
 	relayssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/relays"
+	searchsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/search"
 	statssvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/stats"
 	supplySvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/supply"
 	trxsvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/transactions"
 	vaasvc "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/config"
+	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/address"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/apikeys"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/emitters"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/governor"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/infrastructure"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/observations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/operations"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/protocols"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/relays"
+	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/search"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/stats"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/supply"
 
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/transactions"
 	"github.com/wormhole-foundation/wormhole-explorer/api/routes/wormscan/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 
 	"go.uber.org/zap"
 )
 
 // RegisterRoutes sets up the handlers for the Wormscan API.
 func RegisterRoutes(
+	cfg *config.AppConfig,
 	notSupportedByEnv fiber.Handler,
+	exportQuota fiber.Handler,
+	responseCache *middleware.ResponseCache,
 	app *fiber.App,
 	rootLogger *zap.Logger,
 	addressService *addrsvc.Service,
@@ -49,6 +62,9 @@ func RegisterRoutes(
 	statsService *statssvc.Service,
 	protocolsService *protocolssvc.Service,
 	supplyService *supplySvc.Service,
+	emittersService *emitterssvc.Service,
+	apiKeysService *apikeyssvc.Service,
+	searchService *searchsvc.Service,
 ) {
 
 	// Set up controllers
@@ -63,6 +79,16 @@ func RegisterRoutes(
 	statsCtrl := stats.NewController(statsService, rootLogger)
 	contributorsCtrl := protocols.NewController(rootLogger, protocolsService)
 	supplyCtrl := supply.NewController(supplyService, rootLogger)
+	emittersCtrl := emitters.NewController(emittersService, rootLogger)
+	apiKeysCtrl := apikeys.NewController(apiKeysService, rootLogger)
+	searchCtrl := search.NewController(searchService, rootLogger)
+
+	// Set up cached responses of the dashboard endpoints
+	cachedScorecards := responseCache.Handler(cache.ResponseGroupScorecards, time.Minute)
+	cachedTopAssets := responseCache.Handler(cache.ResponseGroupTopAssets, 5*time.Minute)
+	cachedChainActivity := responseCache.Handler(cache.ResponseGroupChainActivity, 5*time.Minute)
+	cachedGovernor := responseCache.Handler(cache.ResponseGroupGovernor, time.Minute)
+	cachedProtocols := responseCache.Handler(cache.ResponseGroupProtocols, 5*time.Minute)
 
 	// Set up route handlers
 	api := app.Group("/api/v1")
@@ -93,14 +119,18 @@ func RegisterRoutes(
 
 	// accounts resource
 	api.Get("/address/:id", addressCtrl.FindById)
+	api.Get("/address/:id/portfolio", addressCtrl.GetPortfolio)
+
+	// search resource
+	api.Get("/search", searchCtrl.Search)
 
 	// analytics, transactions, custom endpoints
 	api.Get("/global-tx/:chain/:emitter/:sequence", transactionCtrl.FindGlobalTransactionByID)
 	api.Get("/last-txs", transactionCtrl.GetLastTransactions)
-	api.Get("/scorecards", transactionCtrl.GetScorecards)
-	api.Get("/x-chain-activity", transactionCtrl.GetChainActivity)
-	api.Get("/x-chain-activity/tops", transactionCtrl.GetChainActivityTops)
-	api.Get("/top-assets-by-volume", transactionCtrl.GetTopAssets)
+	api.Get("/scorecards", cachedScorecards, transactionCtrl.GetScorecards)
+	api.Get("/x-chain-activity", cachedChainActivity, transactionCtrl.GetChainActivity)
+	api.Get("/x-chain-activity/tops", cachedChainActivity, transactionCtrl.GetChainActivityTops)
+	api.Get("/top-assets-by-volume", cachedTopAssets, transactionCtrl.GetTopAssets)
 	api.Get("/top-chain-pairs-by-num-transfers", transactionCtrl.GetTopChainPairs)
 	api.Get("token/:chain/:token_address", transactionCtrl.GetTokenByChainAndAddress)
 	api.Get("/transactions", transactionCtrl.ListTransactions)
@@ -112,27 +142,37 @@ func RegisterRoutes(
 	// stats custom endpoints
 	api.Get("/top-symbols-by-volume", statsCtrl.GetTopSymbolsByVolume)
 	api.Get("/top-100-corridors", statsCtrl.GetTopCorridors)
-	api.Get("/protocols/stats", contributorsCtrl.GetProtocolsTotalValues)
+	api.Get("/protocols/stats", cachedProtocols, contributorsCtrl.GetProtocolsTotalValues)
 	api.Get("/native-token-transfer/summary", notSupportedByEnv, statsCtrl.GetNativeTokenTransferSummary)
 	api.Get("/native-token-transfer/activity", notSupportedByEnv, statsCtrl.GetNativeTokenTransferActivity)
 	api.Get("/native-token-transfer/transfer-by-time", notSupportedByEnv, statsCtrl.GetNativeTokenTransferByTime)
 	api.Get("/native-token-transfer/top-address", notSupportedByEnv, statsCtrl.GetNativeTokenTransferAddressTop)
 	api.Get("/native-token-transfer/top-holder", notSupportedByEnv, statsCtrl.GetNativeTokenTransferTopHolder)
+	api.Get("/native-token-transfer/symbols", notSupportedByEnv, statsCtrl.GetNativeTokenTransferSymbols)
+
+	// exports require an api key with the export scope and are limited by the export quota of the key
+	requireExportScope := middleware.RequireScope(apikeyssvc.ScopeExport)
+	requireAdminScope := middleware.RequireScope(apikeyssvc.ScopeAdmin)
 
 	// operations resource
 	operations := api.Group("/operations")
 	operations.Get("/", opsCtrl.FindAll)
+	operations.Get("/export", requireExportScope, exportQuota, opsCtrl.Export)
+	operations.Get("/stuck", opsCtrl.FindStuck)
+	operations.Get("/sla", opsCtrl.FindSla)
 	operations.Get("/:chain/:emitter/:sequence", opsCtrl.FindById)
 
 	// vaas resource
 	vaas := api.Group("/vaas")
 	vaas.Get("/vaa-counts", vaaCtrl.GetVaaCount)
 	vaas.Get("/", vaaCtrl.FindAll)
+	vaas.Get("/export", requireExportScope, exportQuota, vaaCtrl.Export)
 	vaas.Get("/:chain", vaaCtrl.FindByChain)
 	vaas.Get("/:chain/:emitter", vaaCtrl.FindByEmitter)
 	vaas.Get("/:chain/:emitter/:sequence", vaaCtrl.FindById)
 	vaas.Get("/:chain/:emitter/:sequence/duplicated", vaaCtrl.FindDuplicatedById)
 	vaas.Post("/parse", vaaCtrl.ParseVaa)
+	vaas.Post("/verify", vaaCtrl.VerifyVaa)
 
 	// oservations resource
 	observations := api.Group("/observations")
@@ -145,7 +185,7 @@ func RegisterRoutes(
 	// governor resources
 	governor := api.Group("/governor")
 	governorLimit := governor.Group("/limit")
-	governorLimit.Get("/", governorCtrl.GetGovernorLimit)
+	governorLimit.Get("/", cachedGovernor, governorCtrl.GetGovernorLimit)
 
 	governorConfigs := governor.Group("/config")
 	governorConfigs.Get("/", governorCtrl.FindGovernorConfigurations)
@@ -156,17 +196,38 @@ func RegisterRoutes(
 	governorStatus.Get("/:guardian_address", governorCtrl.FindGovernorStatusByGuardianAddress)
 
 	governorNotional := governor.Group("/notional")
-	governorNotional.Get("/limit/", governorCtrl.FindNotionalLimit)
-	governorNotional.Get("/limit/:chain", governorCtrl.GetNotionalLimitByChainID)
-	governorNotional.Get("/available/", governorCtrl.GetAvailableNotional)
-	governorNotional.Get("/available/:chain", governorCtrl.GetAvailableNotionalByChainID)
-	governorNotional.Get("/max_available/:chain", governorCtrl.GetMaxNotionalAvailableByChainID)
+	governorNotional.Get("/limit/", cachedGovernor, governorCtrl.FindNotionalLimit)
+	governorNotional.Get("/limit/:chain", cachedGovernor, governorCtrl.GetNotionalLimitByChainID)
+	governorNotional.Get("/available/", cachedGovernor, governorCtrl.GetAvailableNotional)
+	governorNotional.Get("/available/:chain", cachedGovernor, governorCtrl.GetAvailableNotionalByChainID)
+	governorNotional.Get("/max_available/:chain", cachedGovernor, governorCtrl.GetMaxNotionalAvailableByChainID)
 
 	enqueueVaas := governor.Group("/enqueued_vaas")
 	enqueueVaas.Get("/", governorCtrl.GetEnqueuedVaas)
 	enqueueVaas.Get("/:chain", governorCtrl.GetEnqueuedVaasByChainID)
 	governor.Get("/vaas", governorCtrl.GetGovernorVaas)
 
+	governorHistory := governor.Group("/history")
+	governorHistory.Get("/notional", governorCtrl.GetNotionalHistory)
+	governorHistory.Get("/enqueued_vaas", governorCtrl.GetEnqueuedVaaHistory)
+	governor.Get("/simulate", governorCtrl.SimulateTransfer)
+
 	relays := api.Group("/relays")
 	relays.Get("/:chain/:emitter/:sequence", relaysCtrl.FindOne)
+
+	// emitters resource
+	emitters := api.Group("/emitters")
+	emitters.Get("/", emittersCtrl.FindAll)
+	emitters.Get("/:chain/:emitter", emittersCtrl.FindOne)
+	emitters.Put("/:chain/:emitter", requireAdminScope, emittersCtrl.Upsert)
+	emitters.Delete("/:chain/:emitter", requireAdminScope, emittersCtrl.Delete)
+
+	// api keys resource
+	apiKeys := api.Group("/api-keys", requireAdminScope)
+	apiKeys.Get("/", apiKeysCtrl.FindAll)
+	apiKeys.Post("/", apiKeysCtrl.Create)
+	apiKeys.Put("/:id", apiKeysCtrl.Update)
+	apiKeys.Delete("/:id", apiKeysCtrl.Revoke)
+	apiKeys.Post("/:id/rotate", apiKeysCtrl.Rotate)
+	apiKeys.Get("/:id/usage", apiKeysCtrl.FindUsage)
 }
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/search"
+	"github.com/wormhole-foundation/wormhole-explorer/api/response"
+	"go.uber.org/zap"
+)
+
+// Controller is the controller for the search resource.
+type Controller struct {
+	srv    *search.Service
+	logger *zap.Logger
+}
+
+// NewController create a new controller.
+func NewController(srv *search.Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		srv:    srv,
+		logger: logger.With(zap.String("module", "SearchController")),
+	}
+}
+
+// Search godoc
+// @Description Search transactions, addresses, tokens and emitters. The query is classified as a VAA id,
+// @Description a tx hash (hex, base58) or a prefix of it, an address (hex, base58, bech32) or a token symbol,
+// @Description and results are returned ranked by match, exact matches first, with the link to their resource.
+// @Tags wormholescan
+// @ID search
+// @Param q query string true "search query"
+// @Success 200 {object} response.Response[search.SearchResult]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/search [get]
+func (c *Controller) Search(ctx *fiber.Ctx) error {
+	q := ctx.Query("q")
+	if q == "" {
+		return response.NewInvalidQueryParamError(ctx, "MISSING SEARCH QUERY", nil)
+	}
+
+	result, err := c.srv.Search(ctx.Context(), q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(response.Response[*search.SearchResult]{Data: result})
+}
@@ -150,7 +150,7 @@ func createTop100CorridorsResult(corridors []stats.TopCorridorsDTO) []*TopCorrid
 // @Description Returns a summary of the Native Token Transfer.
 // @Tags wormholescan
 // @ID /api/v1/native-token-transfer/summary
-// @Param symbol query string true "Symbol of the token. Currently only supports W."
+// @Param symbol query string true "Symbol of the token, see /api/v1/native-token-transfer/symbols."
 // @Success 200 {object} stats.NativeTokenTransferSummary
 // @Failure 400
 // @Failure 500
@@ -174,7 +174,7 @@ func (c *Controller) GetNativeTokenTransferSummary(ctx *fiber.Ctx) error {
 // @Description Returns a list of values (tx count or notional) of the Native Token Transfer for a emitter and destination chains.
 // @Tags wormholescan
 // @ID /api/v1/native-token-transfer/activity
-// @Param symbol query string true "Symbol of the token. Currently only supports W."
+// @Param symbol query string true "Symbol of the token, see /api/v1/native-token-transfer/symbols."
 // @Param by query string false "Renders the results using notional or tx count (default is notional)."
 // @Success 200 {object} []stats.NativeTokenTransferActivity
 // @Failure 400
@@ -205,7 +205,7 @@ func (c *Controller) GetNativeTokenTransferActivity(ctx *fiber.Ctx) error {
 // @ID /api/v1/native-token-transfer/transfer-by-time
 // @Param from query string true "From date, supported format 2006-01-02T15:04:05Z07:00"
 // @Param to query string true "To date, supported format 2006-01-02T15:04:05Z07:00"
-// @Param symbol query string true "Symbol of the token. Currently only supports W."
+// @Param symbol query string true "Symbol of the token, see /api/v1/native-token-transfer/symbols."
 // @Param by query string false "Renders the results using notional or tx count (default is notional)."
 // @Param timeSpan query string true "Time Span, supported values: [1h, 1d, 1mo, 1y]."
 // @Success 200 {object} []stats.NativeTokenTransferByTime
@@ -249,7 +249,7 @@ func (c *Controller) GetNativeTokenTransferByTime(ctx *fiber.Ctx) error {
 // @Description Returns a list of values (tx count or notional) of the Native Token Transfer for address.
 // @Tags wormholescan
 // @ID /api/v1/native-token-transfer/top-address
-// @Param symbol query string true "Symbol of the token. Currently only supports W."
+// @Param symbol query string true "Symbol of the token, see /api/v1/native-token-transfer/symbols."
 // @Param by query string false "Renders the results using notional or tx count (default is notional)."
 // @Success 200 {object} []stats.NativeTokenTransferTopAddress
 // @Failure 400
@@ -277,9 +277,10 @@ func (c *Controller) GetNativeTokenTransferAddressTop(ctx *fiber.Ctx) error {
 // @Description Returns a list of volume and chain of the Native Token Transfer for top holders.
 // @Tags wormholescan
 // @ID /api/v1/native-token-transfer/top-holder
-// @Param symbol query string true "Symbol of the token. Currently only supports W."
+// @Param symbol query string true "Symbol of the token, see /api/v1/native-token-transfer/symbols."
 // @Success 200 {object} []stats.NativeTokenTransferTopHolder
 // @Failure 400
+// @Failure 404
 // @Failure 500
 // @Router /api/v1/native-token-transfer/top-holder [get]
 func (c *Controller) GetNativeTokenTransferTopHolder(ctx *fiber.Ctx) error {
@@ -296,3 +297,18 @@ func (c *Controller) GetNativeTokenTransferTopHolder(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(holders)
 }
+
+// GetNativeTokenTransferSymbols godoc
+// @Description Returns the symbols of the Native Token Transfer tokens with stats available, and the stats of each symbol.
+// @Tags wormholescan
+// @ID /api/v1/native-token-transfer/symbols
+// @Success 200 {object} []stats.NativeTokenTransferSymbol
+// @Failure 500
+// @Router /api/v1/native-token-transfer/symbols [get]
+func (c *Controller) GetNativeTokenTransferSymbols(ctx *fiber.Ctx) error {
+	symbols, err := c.srv.GetNativeTokenTransferSymbols(ctx.Context())
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(symbols)
+}
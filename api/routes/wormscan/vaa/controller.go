@@ -8,6 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	"github.com/wormhole-foundation/wormhole-explorer/api/internal/export"
 	"github.com/wormhole-foundation/wormhole-explorer/api/middleware"
 	"github.com/wormhole-foundation/wormhole-explorer/api/response"
 	_ "github.com/wormhole-foundation/wormhole-explorer/api/response" // required by swaggo
@@ -79,6 +80,55 @@ func (c *Controller) FindAll(ctx *fiber.Ctx) error {
 	return ctx.JSON(vaas)
 }
 
+// Export godoc
+// @Description Export VAAs as a CSV, newline-delimited JSON or Parquet stream.
+// @Description The export is sorted from the latest VAA. When there are more VAAs than the limit,
+// @Description the X-Next-Cursor response header contains the cursor to resume the export. Requires an API key.
+// @Tags wormholescan
+// @ID export-vaas
+// @Param X-API-KEY header string true "API key"
+// @Param format query string false "format of the export" Enums(csv, ndjson, parquet)
+// @Param cursor query string false "cursor returned in the X-Next-Cursor header of the previous export"
+// @Param limit query integer false "max number of VAAs to export". Maximum value is 100000.
+// @Param txHash query string false "Transaction hash of the VAA"
+// @Param appId query string false "filter by application ID"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 429
+// @Failure 500
+// @Router /api/v1/vaas/export [get]
+func (c *Controller) Export(ctx *fiber.Ctx) error {
+
+	params, err := middleware.ExtractExportParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	txHash, err := middleware.GetTxHash(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	p := vaa.ExportParams{
+		TxHash: txHash,
+		AppId:  middleware.ExtractAppId(ctx, c.logger),
+		Cursor: params.Cursor,
+		Limit:  params.Limit,
+	}
+
+	// The query is executed before streaming, so that errors are returned with the proper status code.
+	source, next, err := c.srv.Export(ctx.Context(), &p)
+	if err != nil {
+		return err
+	}
+
+	if next != nil {
+		ctx.Set(export.HeaderNextCursor, next.Encode())
+	}
+	return export.Stream(ctx, params.Format, "vaas", vaa.VaaRecordHeader(), source, c.logger)
+}
+
 // FindByChain godoc
 // @Description Returns all the VAAs generated in specific blockchain.
 // @Tags wormholescan
@@ -238,37 +288,76 @@ func (c *Controller) GetVaaCount(ctx *fiber.Ctx) error {
 // @Router /api/v1/vaas/parse [post]
 func (c *Controller) ParseVaa(ctx *fiber.Ctx) error {
 
-	parseVaaBody := struct {
+	vaa, err := extractVaaBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	parsedVaa, err := c.srv.ParseVaa(ctx.Context(), vaa)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(parsedVaa)
+}
+
+// VerifyVaa godoc
+// @Description Verify the signatures of a VAA against the guardian set at its guardian set index.
+// @Description Returns which guardians signed, whether quorum was reached, whether the guardian set expired,
+// @Description and whether the VAA matches the one indexed by the explorer.
+// @Tags wormholescan
+// @ID verify-vaa
+// @Param request body object{vaa=string} true "base64 encoded VAA"
+// @Success 200 {object} response.Response[vaa.VerifyResult]
+// @Failure 400
+// @Failure 500
+// @Router /api/v1/vaas/verify [post]
+func (c *Controller) VerifyVaa(ctx *fiber.Ctx) error {
+
+	vaaBytes, err := extractVaaBody(ctx)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.srv.Verify(ctx.Context(), vaaBytes)
+	if err != nil {
+		if errors.Is(err, vaa.ErrInvalidVaa) {
+			return response.NewRequestBodyError(ctx, "invalid vaa request, unable to unmarshal vaa", errors.WithStack(err))
+		}
+		return err
+	}
+
+	return ctx.JSON(response.Response[*vaa.VerifyResult]{Data: result})
+}
+
+// extractVaaBody returns the bytes of the base64 encoded vaa of the request body.
+func extractVaaBody(ctx *fiber.Ctx) ([]byte, error) {
+
+	body := struct {
 		Vaa string `json:"vaa"`
 	}{}
 
-	err := ctx.BodyParser(&parseVaaBody)
+	err := ctx.BodyParser(&body)
 	if err != nil {
-		return response.NewRequestBodyError(ctx,
+		return nil, response.NewRequestBodyError(ctx,
 			"invalid vaa request, unable to parse",
 			errors.WithStack(err))
 	}
 
-	if len(parseVaaBody.Vaa) == 0 {
-		return response.NewRequestBodyError(
+	if len(body.Vaa) == 0 {
+		return nil, response.NewRequestBodyError(
 			ctx,
 			"invalid vaa request, vaa is empty",
 			nil)
 	}
 
-	vaa, err := base64.StdEncoding.DecodeString(parseVaaBody.Vaa)
+	vaa, err := base64.StdEncoding.DecodeString(body.Vaa)
 	if err != nil {
-		return response.NewRequestBodyError(ctx,
+		return nil, response.NewRequestBodyError(ctx,
 			"invalid vaa request, vaa is not base64 encoded",
 			errors.WithStack(err))
 	}
-
-	parsedVaa, err := c.srv.ParseVaa(ctx.Context(), vaa)
-	if err != nil {
-		return err
-	}
-
-	return ctx.JSON(parsedVaa)
+	return vaa, nil
 }
 
 // FindDuplicatedById godoc
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	rpcv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/common/types"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetSignedBatchVAA get the signed VAAs emitted by a transaction with a nonce, sorted by sequence.
+func (h *Handler) GetSignedBatchVAA(ctx context.Context, request *rpcv1.GetSignedBatchVAARequest) (*rpcv1.GetSignedBatchVAAResponse, error) {
+	if request.GetBatchId() == nil {
+		return nil, status.Error(codes.InvalidArgument, "no batch ID specified")
+	}
+
+	chainID := vaa.ChainID(request.BatchId.EmitterChain)
+
+	// This interface is not supported for PythNet messages because those VAAs are not stored in the database.
+	if chainID == vaa.ChainIDPythNet {
+		return nil, status.Error(codes.InvalidArgument, "not supported for PythNet")
+	}
+
+	txHash, err := types.ParseTxHash(request.BatchId.TxId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to parse tx id: %v", err))
+	}
+
+	vaas, err := h.vaaSrv.FindSignedBatch(ctx, chainID, txHash, request.BatchId.Nonce)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "requested batch VAA not found in store")
+		}
+		h.logger.Error("failed to fetch batch VAA", zap.Error(err), zap.Any("request", request))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	response := &rpcv1.GetSignedBatchVAAResponse{VaaBytes: make([][]byte, 0, len(vaas))}
+	for _, v := range vaas {
+		response.VaaBytes = append(response.VaaBytes, v.Vaa)
+	}
+	return response, nil
+}
+
+// GetSignedVAAs get the signed VAAs of a list of message ids.
+func (h *Handler) GetSignedVAAs(ctx context.Context, request *rpcv1.GetSignedVAAsRequest) (*rpcv1.GetSignedVAAsResponse, error) {
+	if len(request.MessageIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no message IDs specified")
+	}
+	if len(request.MessageIds) > vaaservice.MaxSignedVaaIDs {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d message IDs can be requested", vaaservice.MaxSignedVaaIDs))
+	}
+
+	ids := make([]*vaaservice.VaaID, 0, len(request.MessageIds))
+	for _, m := range request.MessageIds {
+		if m == nil {
+			return nil, status.Error(codes.InvalidArgument, "empty message ID")
+		}
+		if vaa.ChainID(m.EmitterChain) == vaa.ChainIDPythNet {
+			return nil, status.Error(codes.InvalidArgument, "not supported for PythNet")
+		}
+		addr, err := types.StringToAddress(m.EmitterAddress, false /*acceptSolanaFormat*/)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to decode address %s: %v", m.EmitterAddress, err))
+		}
+		ids = append(ids, &vaaservice.VaaID{
+			Chain:    vaa.ChainID(m.EmitterChain),
+			Emitter:  addr,
+			Sequence: strconv.FormatUint(m.Sequence, 10),
+		})
+	}
+
+	vaas, err := h.vaaSrv.FindSignedByIds(ctx, ids)
+	if err != nil {
+		h.logger.Error("failed to fetch VAAs", zap.Error(err), zap.Int("count", len(ids)))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	response := &rpcv1.GetSignedVAAsResponse{Entries: make([]*rpcv1.GetSignedVAAsResponseEntry, 0, len(vaas))}
+	for i, v := range vaas {
+		response.Entries = append(response.Entries, &rpcv1.GetSignedVAAsResponseEntry{
+			MessageId: request.MessageIds[i],
+			VaaBytes:  v.Vaa,
+		})
+	}
+	return response, nil
+}
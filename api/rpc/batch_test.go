@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	rpcv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/v1"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSignedBatchVAA_InvalidArgument(t *testing.T) {
+	h := NewHandler(nil, nil, nil, nil, zap.NewNop())
+
+	requests := []*rpcv1.GetSignedBatchVAARequest{
+		{},
+		{BatchId: &rpcv1.BatchID{EmitterChain: uint32(vaa.ChainIDPythNet), TxId: strings.Repeat("ab", 32)}},
+		{BatchId: &rpcv1.BatchID{EmitterChain: uint32(vaa.ChainIDEthereum), TxId: "invalid"}},
+	}
+	for _, request := range requests {
+		_, err := h.GetSignedBatchVAA(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), request.String())
+	}
+}
+
+func TestGetSignedVAAs_InvalidArgument(t *testing.T) {
+	h := NewHandler(nil, nil, nil, nil, zap.NewNop())
+	emitter := strings.Repeat("ab", 32)
+
+	tooMany := make([]*rpcv1.MessageID, vaaservice.MaxSignedVaaIDs+1)
+	for i := range tooMany {
+		tooMany[i] = &rpcv1.MessageID{EmitterChain: uint32(vaa.ChainIDEthereum), EmitterAddress: emitter, Sequence: uint64(i)}
+	}
+
+	requests := []*rpcv1.GetSignedVAAsRequest{
+		{},
+		{MessageIds: tooMany},
+		{MessageIds: []*rpcv1.MessageID{nil}},
+		{MessageIds: []*rpcv1.MessageID{{EmitterChain: uint32(vaa.ChainIDPythNet), EmitterAddress: emitter}}},
+		{MessageIds: []*rpcv1.MessageID{{EmitterChain: uint32(vaa.ChainIDEthereum), EmitterAddress: "invalid"}}},
+	}
+	for _, request := range requests {
+		_, err := h.GetSignedVAAs(context.Background(), request)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
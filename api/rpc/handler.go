@@ -14,6 +14,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/heartbeats"
 	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	errs "github.com/wormhole-foundation/wormhole-explorer/api/internal/errors"
+	rpcv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/v1"
 	"github.com/wormhole-foundation/wormhole-explorer/common/types"
 	"github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -24,6 +25,8 @@ import (
 // Handler rpc handler.
 type Handler struct {
 	publicrpcv1.UnimplementedPublicRPCServiceServer
+	rpcv1.UnimplementedBatchVAAServiceServer
+	rpcv1.UnimplementedVerifyVAAServiceServer
 	gs          guardian.GuardianSet
 	vaaSrv      *vaaservice.Service
 	hbSrv       *heartbeats.Service
@@ -88,11 +91,6 @@ func (h *Handler) GetSignedVAA(ctx context.Context, request *publicrpcv1.GetSign
 	}, nil
 }
 
-// GetSignedBatchVAA get signed batch VAA.
-func (h *Handler) GetSignedBatchVAA(ctx context.Context, _ any) (any, error) {
-	return nil, status.Error(codes.Unimplemented, "not yet implemented")
-}
-
 // GetLastHeartbeats get last heartbeats.
 func (h *Handler) GetLastHeartbeats(ctx context.Context, request *publicrpcv1.GetLastHeartbeatsRequest) (*publicrpcv1.GetLastHeartbeatsResponse, error) {
 	// check guardianSet exists.
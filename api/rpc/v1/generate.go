@@ -0,0 +1,4 @@
+// Package rpcv1 contains the protobuf services of the explorer that are not part of the public RPC of the guardians.
+package rpcv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative batch.proto verify.proto
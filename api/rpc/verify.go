@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	rpcv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// VerifyVAA verifies the signatures of a VAA against the guardian set at its guardian set index.
+func (h *Handler) VerifyVAA(ctx context.Context, request *rpcv1.VerifyVAARequest) (*rpcv1.VerifyVAAResponse, error) {
+	if len(request.VaaBytes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no VAA specified")
+	}
+
+	result, err := h.vaaSrv.Verify(ctx, request.VaaBytes)
+	if err != nil {
+		if errors.Is(err, vaaservice.ErrInvalidVaa) {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to unmarshal VAA: %v", err))
+		}
+		h.logger.Error("failed to verify VAA", zap.Error(err))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return toVerifyVAAResponse(result), nil
+}
+
+// toVerifyVAAResponse converts the result of the verification of a VAA to a VerifyVAAResponse.
+func toVerifyVAAResponse(result *vaaservice.VerifyResult) *rpcv1.VerifyVAAResponse {
+	response := &rpcv1.VerifyVAAResponse{
+		Id:                 result.ID,
+		Digest:             result.Digest,
+		Valid:              result.Valid,
+		GuardianSetIndex:   result.GuardianSetIndex,
+		GuardianSetFound:   result.GuardianSetFound,
+		GuardianSetSize:    uint32(result.GuardianSetSize),
+		GuardianSetExpired: result.GuardianSetExpired,
+		Signatures:         make([]*rpcv1.SignatureCheck, 0, len(result.Signatures)),
+		SignedBy:           make([]uint32, 0, len(result.SignedBy)),
+		Quorum:             uint32(result.Quorum),
+		QuorumReached:      result.QuorumReached,
+		Indexed:            result.Indexed,
+		MatchesIndexed:     result.MatchesIndexed,
+		Errors:             result.Errors,
+	}
+	if result.GuardianSetExpirationTime != nil {
+		response.GuardianSetExpirationTime = result.GuardianSetExpirationTime.Unix()
+	}
+	for _, s := range result.Signatures {
+		response.Signatures = append(response.Signatures, &rpcv1.SignatureCheck{
+			GuardianIndex: uint32(s.GuardianIndex),
+			Guardian:      s.Guardian,
+			Signer:        s.Signer,
+			Valid:         s.Valid,
+		})
+	}
+	for _, index := range result.SignedBy {
+		response.SignedBy = append(response.SignedBy, uint32(index))
+	}
+	return response
+}
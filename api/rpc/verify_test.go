@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	vaaservice "github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
+	rpcv1 "github.com/wormhole-foundation/wormhole-explorer/api/rpc/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyVAA_InvalidArgument(t *testing.T) {
+	h := NewHandler(nil, nil, nil, nil, zap.NewNop())
+	_, err := h.VerifyVAA(context.Background(), &rpcv1.VerifyVAARequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestToVerifyVAAResponse(t *testing.T) {
+	expirationTime := time.Unix(1700000000, 0)
+	response := toVerifyVAAResponse(&vaaservice.VerifyResult{
+		ID:                        "2/0001/7",
+		GuardianSetIndex:          3,
+		GuardianSetFound:          true,
+		GuardianSetSize:           19,
+		GuardianSetExpired:        true,
+		GuardianSetExpirationTime: &expirationTime,
+		Signatures:                []*vaaservice.SignatureCheck{{GuardianIndex: 2, Guardian: "0x2", Signer: "0x2", Valid: true}},
+		SignedBy:                  []uint8{2},
+		Quorum:                    13,
+		Errors:                    []string{"guardian set 3 expired"},
+	})
+
+	assert.Equal(t, "2/0001/7", response.Id)
+	assert.Equal(t, uint32(19), response.GuardianSetSize)
+	assert.Equal(t, int64(1700000000), response.GuardianSetExpirationTime)
+	assert.Equal(t, []uint32{2}, response.SignedBy)
+	assert.Equal(t, uint32(13), response.Quorum)
+	assert.Len(t, response.Signatures, 1)
+	assert.Equal(t, uint32(2), response.Signatures[0].GuardianIndex)
+	assert.True(t, response.Signatures[0].Valid)
+	assert.Equal(t, []string{"guardian set 3 expired"}, response.Errors)
+}
@@ -2,6 +2,7 @@ package alert
 
 import (
 	"fmt"
+	"strings"
 
 	opsgenieAlert "github.com/opsgenie/opsgenie-go-sdk-v2/alert"
 )
@@ -37,6 +38,55 @@ type Alert struct {
 	Responder   []Responder
 	VisibleTo   []Responder
 	context     AlertContext
+	key         string
+}
+
+// chainDetailKeys are the alert context details that contain the chain of the alert.
+var chainDetailKeys = []string{"chainID", "chainId", "emitterChain", "chain"}
+
+// Key returns the key used to create the alert.
+func (a Alert) Key() string {
+	return a.key
+}
+
+// Service returns the service that raised the alert.
+func (a Alert) Service() string {
+	return a.Entity
+}
+
+// Chain returns the chain of the alert found in the alert context details.
+func (a Alert) Chain() string {
+	for _, k := range chainDetailKeys {
+		if v, ok := a.context.Details[k]; ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Context returns the alert execution context.
+func (a Alert) Context() AlertContext {
+	return a.context
+}
+
+// fullDescription returns the alert description including the error.
+func (a Alert) fullDescription() string {
+	if a.context.Error != nil {
+		return fmt.Sprintf("%s\n%s", a.Description, a.context.Error.Error())
+	}
+	return a.Description
+}
+
+// dedupKey returns the key used to deduplicate the alert.
+func (a Alert) dedupKey() string {
+	key := a.key
+	if key == "" {
+		key = a.Alias
+	}
+	if chain := a.Chain(); chain != "" {
+		key = fmt.Sprintf("%s:%s", key, strings.ToLower(chain))
+	}
+	return key
 }
 
 // AlertContext contains the alert execution context.
@@ -106,10 +156,7 @@ func (a Alert) toOpsgenieRequest() opsgenieAlert.CreateAlertRequest {
 	}
 
 	// add error details to opsgenie alert details data.
-	description := a.Description
-	if a.context.Error != nil {
-		description = fmt.Sprintf("%s\n%s", a.Description, a.context.Error.Error())
-	}
+	description := a.fullDescription()
 
 	return opsgenieAlert.CreateAlertRequest{
 		Message:     a.Message,
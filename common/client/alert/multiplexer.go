@@ -0,0 +1,400 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// Multiplexer is an AlertClient that routes the alerts to several sinks.
+//
+// Repeated alerts are suppressed during the deduplication window, and each sink
+// is rate limited so a failing dependency doesn't flood the on-call channels.
+type Multiplexer struct {
+	enabled      bool
+	alerts       map[string]Alert
+	sinks        map[string]*routedSink
+	sinkNames    []string
+	routes       []Route
+	defaultSinks []string
+	silences     []Silence
+	dedupWindow  time.Duration
+	dedup        *deduplicator
+	logger       *zap.Logger
+	now          func() time.Time
+}
+
+// limitedExpiration is how long the count of the alerts dropped by the rate limiter of a sink
+// is kept when no alert with the same deduplication key is sent.
+const limitedExpiration = time.Hour
+
+type routedSink struct {
+	sink    Sink
+	limiter *rate.Limiter
+
+	mu sync.Mutex
+	// limited are the alerts dropped by the rate limiter since the last one sent, by deduplication key.
+	limited     map[string]*limitedAlerts
+	lastCleanup time.Time
+}
+
+type limitedAlerts struct {
+	count     int
+	limitedAt time.Time
+}
+
+// allow returns whether an alert can be sent to the sink and how many alerts with its
+// deduplication key were rate limited since the last one sent.
+func (rs *routedSink) allow(key string, now time.Time) (bool, int) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.cleanup(now)
+	if !rs.limiter.Allow() {
+		l, ok := rs.limited[key]
+		if !ok {
+			l = &limitedAlerts{}
+			rs.limited[key] = l
+		}
+		l.count++
+		l.limitedAt = now
+		return false, 0
+	}
+	l, ok := rs.limited[key]
+	if !ok {
+		return true, 0
+	}
+	delete(rs.limited, key)
+	return true, l.count
+}
+
+// unsent keeps the count of the rate limited alerts reported by an alert the sink failed to send.
+func (rs *routedSink) unsent(key string, limited int, now time.Time) {
+	if limited == 0 {
+		return
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	l, ok := rs.limited[key]
+	if !ok {
+		l = &limitedAlerts{}
+		rs.limited[key] = l
+	}
+	l.count += limited
+	l.limitedAt = now
+}
+
+// cleanup removes the expired counts of rate limited alerts once a minute.
+func (rs *routedSink) cleanup(now time.Time) {
+	if now.Sub(rs.lastCleanup) < time.Minute {
+		return
+	}
+	rs.lastCleanup = now
+	for k, l := range rs.limited {
+		if now.Sub(l.limitedAt) >= limitedExpiration {
+			delete(rs.limited, k)
+		}
+	}
+}
+
+// NewMultiplexer creates a new alert client that sends the alerts to the sinks of the routing
+// configuration. When an opsgenie api key is set, an "opsgenie" sink is added.
+func NewMultiplexer(cfg AlertConfig, registerAlertsFunc RegisterAlertsFunc, logger *zap.Logger) (*Multiplexer, error) {
+	routing, err := LoadRoutingConfig(cfg.Routing)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ApiKey != "" && !hasSink(routing.Sinks, SinkTypeOpsgenie) {
+		routing.Sinks = append(routing.Sinks, SinkConfig{Name: SinkTypeOpsgenie, Type: SinkTypeOpsgenie, ApiKey: cfg.ApiKey})
+	}
+
+	sinks := make(map[string]Sink, len(routing.Sinks))
+	for _, sc := range routing.Sinks {
+		if sc.Name == "" {
+			sc.Name = sc.Type
+		}
+		if _, ok := sinks[sc.Name]; ok {
+			return nil, fmt.Errorf("duplicated alert sink %s", sc.Name)
+		}
+		sink, err := newSink(sc, cfg.Environment)
+		if err != nil {
+			return nil, err
+		}
+		sinks[sc.Name] = sink
+	}
+
+	return newMultiplexer(cfg, registerAlertsFunc(cfg), routing, sinks, logger)
+}
+
+func newMultiplexer(cfg AlertConfig, alerts map[string]Alert, routing *RoutingConfig, sinks map[string]Sink, logger *zap.Logger) (*Multiplexer, error) {
+	defaultLimit := RateLimitConfig{PerMinute: DefaultRateLimitPerMinute, Burst: DefaultRateLimitBurst}
+	if routing.RateLimit != nil {
+		defaultLimit = *routing.RateLimit
+	}
+	limits := make(map[string]*RateLimitConfig, len(routing.Sinks))
+	for _, sc := range routing.Sinks {
+		name := sc.Name
+		if name == "" {
+			name = sc.Type
+		}
+		limits[name] = sc.RateLimit
+	}
+
+	m := &Multiplexer{
+		enabled:      cfg.Enabled,
+		alerts:       alerts,
+		sinks:        make(map[string]*routedSink, len(sinks)),
+		routes:       routing.Routes,
+		defaultSinks: routing.DefaultSinks,
+		silences:     routing.Silences,
+		dedupWindow:  DefaultDedupWindow,
+		dedup:        newDeduplicator(),
+		logger:       logger.With(zap.String("module", "AlertMultiplexer")),
+		now:          time.Now,
+	}
+	if routing.DedupWindow != nil {
+		m.dedupWindow = time.Duration(*routing.DedupWindow)
+	}
+
+	for name, sink := range sinks {
+		limit := defaultLimit
+		if l := limits[name]; l != nil {
+			limit = *l
+		}
+		m.sinks[name] = &routedSink{sink: sink, limiter: newLimiter(limit), limited: make(map[string]*limitedAlerts)}
+		m.sinkNames = append(m.sinkNames, name)
+	}
+
+	// check that routes only reference configured sinks.
+	check := func(names []string) error {
+		for _, n := range names {
+			if _, ok := m.sinks[n]; !ok {
+				return fmt.Errorf("unknown alert sink %s", n)
+			}
+		}
+		return nil
+	}
+	for _, r := range m.routes {
+		if err := check(r.Sinks); err != nil {
+			return nil, err
+		}
+	}
+	if err := check(m.defaultSinks); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CreateAlert creates an alert by key and alert context.
+// The key is the alert name, and with it we can get the alert from the registerd alerts.
+func (m *Multiplexer) CreateAlert(key string, alertCtx AlertContext) (Alert, error) {
+	if !m.enabled {
+		return Alert{}, errors.New("alert not enabled")
+	}
+	alert, ok := m.alerts[key]
+	if !ok {
+		return Alert{}, errors.New("alert not found")
+	}
+	alert.context = alertCtx
+	alert.key = key
+	return alert, nil
+}
+
+// Send routes an alert to the matching sinks, unless it is silenced, duplicated or rate limited.
+func (m *Multiplexer) Send(ctx context.Context, alert Alert) error {
+	if !m.enabled {
+		return errors.New("alert not enabled")
+	}
+	if alert.Message == "" {
+		return errors.New("message can not be empty")
+	}
+
+	now := m.now()
+	for _, s := range m.silences {
+		if s.active(now) && s.Match.matches(alert) {
+			m.logger.Debug("alert silenced", zap.String("key", alert.key), zap.String("alias", alert.Alias))
+			return nil
+		}
+	}
+
+	sinkNames, window := m.route(alert)
+
+	// suppress the alert if it was already sent in the deduplication window.
+	send, suppressed := m.dedup.check(alert.dedupKey(), window, now)
+	if !send {
+		m.logger.Debug("alert deduplicated", zap.String("key", alert.key), zap.String("alias", alert.Alias))
+		return nil
+	}
+
+	var errs []error
+	sent := false
+	for _, name := range sinkNames {
+		rs := m.sinks[name]
+		ok, limited := rs.allow(alert.dedupKey(), now)
+		if !ok {
+			m.logger.Warn("alert rate limited", zap.String("sink", name), zap.String("key", alert.key))
+			continue
+		}
+		// the alerts dropped by the rate limiter of the sink are also reported as suppressed.
+		if err := rs.sink.Send(ctx, withSuppressedCount(alert, suppressed+limited)); err != nil {
+			rs.unsent(alert.dedupKey(), limited, now)
+			errs = append(errs, fmt.Errorf("sink %s: %w", name, err))
+			continue
+		}
+		sent = true
+	}
+	// an alert that did not reach any sink is not deduplicated, so the next one is sent.
+	if !sent {
+		m.dedup.unsent(alert.dedupKey(), suppressed)
+	}
+	return errors.Join(errs...)
+}
+
+// CreateAndSend creates an alert by key and alert context and sends it to the matching sinks.
+func (m *Multiplexer) CreateAndSend(ctx context.Context, key string, alertCtx AlertContext) error {
+	alert, err := m.CreateAlert(key, alertCtx)
+	if err != nil {
+		return err
+	}
+	return m.Send(ctx, alert)
+}
+
+// withSuppressedCount returns a copy of the alert with the number of suppressed alerts in its details.
+func withSuppressedCount(alert Alert, suppressed int) Alert {
+	if suppressed == 0 {
+		return alert
+	}
+	details := make(map[string]string, len(alert.context.Details)+1)
+	for k, v := range alert.context.Details {
+		details[k] = v
+	}
+	details["suppressedCount"] = fmt.Sprint(suppressed)
+	alert.context.Details = details
+	return alert
+}
+
+// route returns the sinks and the deduplication window of an alert.
+func (m *Multiplexer) route(alert Alert) ([]string, time.Duration) {
+	var names []string
+	seen := make(map[string]bool)
+	window := m.dedupWindow
+	matched := false
+	for _, r := range m.routes {
+		if !r.Match.matches(alert) {
+			continue
+		}
+		if !matched && r.DedupWindow != nil {
+			window = time.Duration(*r.DedupWindow)
+		}
+		matched = true
+		for _, n := range r.Sinks {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+		if !r.Continue {
+			break
+		}
+	}
+	if matched {
+		return names, window
+	}
+	if len(m.defaultSinks) > 0 {
+		return m.defaultSinks, window
+	}
+	return m.sinkNames, window
+}
+
+func newLimiter(cfg RateLimitConfig) *rate.Limiter {
+	if cfg.PerMinute <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(float64(cfg.PerMinute)/60), burst)
+}
+
+func hasSink(sinks []SinkConfig, sinkType string) bool {
+	for _, s := range sinks {
+		if s.Type == sinkType {
+			return true
+		}
+	}
+	return false
+}
+
+// deduplicator keeps track of the alerts sent in the deduplication window.
+type deduplicator struct {
+	sync.Mutex
+	entries     map[string]*dedupEntry
+	lastCleanup time.Time
+}
+
+type dedupEntry struct {
+	sentAt     time.Time
+	window     time.Duration
+	suppressed int
+}
+
+func newDeduplicator() *deduplicator {
+	return &deduplicator{entries: make(map[string]*dedupEntry)}
+}
+
+// check returns whether the alert must be sent and how many times it was suppressed since
+// the last time it was sent.
+func (d *deduplicator) check(key string, window time.Duration, now time.Time) (bool, int) {
+	if window <= 0 {
+		return true, 0
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	d.cleanup(now)
+
+	e, ok := d.entries[key]
+	if !ok {
+		d.entries[key] = &dedupEntry{sentAt: now, window: window}
+		return true, 0
+	}
+	if now.Sub(e.sentAt) < window {
+		e.suppressed++
+		return false, 0
+	}
+	suppressed := e.suppressed
+	e.sentAt, e.window, e.suppressed = now, window, 0
+	return true, suppressed
+}
+
+// unsent forgets that an alert was sent, so the next one with the same key is sent and reports
+// the alerts suppressed before it.
+func (d *deduplicator) unsent(key string, suppressed int) {
+	d.Lock()
+	defer d.Unlock()
+	e, ok := d.entries[key]
+	if !ok {
+		return
+	}
+	e.sentAt = time.Time{}
+	e.suppressed += suppressed
+}
+
+// cleanup removes the expired entries without suppressed alerts once a minute.
+func (d *deduplicator) cleanup(now time.Time) {
+	if now.Sub(d.lastCleanup) < time.Minute {
+		return
+	}
+	d.lastCleanup = now
+	for k, e := range d.entries {
+		if e.suppressed == 0 && now.Sub(e.sentAt) >= e.window {
+			delete(d.entries, k)
+		}
+	}
+}
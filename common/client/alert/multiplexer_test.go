@@ -0,0 +1,258 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakeSink struct {
+	sync.Mutex
+	alerts []Alert
+	err    error
+}
+
+func (f *fakeSink) Send(ctx context.Context, alert Alert) error {
+	f.Lock()
+	defer f.Unlock()
+	f.alerts = append(f.alerts, alert)
+	return f.err
+}
+
+func (f *fakeSink) count() int {
+	f.Lock()
+	defer f.Unlock()
+	return len(f.alerts)
+}
+
+func testAlerts(cfg AlertConfig) map[string]Alert {
+	return map[string]Alert{
+		"ERROR_SAVE_VAA": {Message: "error saving vaa", Alias: "save vaa", Entity: "fly", Priority: CRITICAL},
+		"ERROR_PARSE":    {Message: "error parsing vaa", Alias: "parse", Entity: "parser", Priority: LOW},
+	}
+}
+
+func newTestMultiplexer(t *testing.T, routing *RoutingConfig, sinks map[string]Sink) *Multiplexer {
+	cfg := AlertConfig{Environment: "test", Enabled: true}
+	for name := range sinks {
+		routing.Sinks = append(routing.Sinks, SinkConfig{Name: name, Type: SinkTypeWebhook})
+	}
+	m, err := newMultiplexer(cfg, testAlerts(cfg), routing, sinks, zap.NewNop())
+	assert.NoError(t, err)
+	return m
+}
+
+func duration(d time.Duration) *Duration {
+	v := Duration(d)
+	return &v
+}
+
+func TestMultiplexer_Routing(t *testing.T) {
+	slack, pagerduty, webhook := &fakeSink{}, &fakeSink{}, &fakeSink{}
+	routing := &RoutingConfig{
+		Routes: []Route{
+			{Match: Match{Priorities: []Priority{CRITICAL}, Chains: []string{"1"}}, Sinks: []string{"pagerduty"}, Continue: true},
+			{Match: Match{Services: []string{"fly"}}, Sinks: []string{"slack"}},
+		},
+		DefaultSinks: []string{"webhook"},
+	}
+	m := newTestMultiplexer(t, routing, map[string]Sink{"slack": slack, "pagerduty": pagerduty, "webhook": webhook})
+
+	ctx := context.Background()
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{Details: map[string]string{"emitterChain": "solana"}}))
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{Details: map[string]string{"emitterChain": "ethereum"}}))
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_PARSE", AlertContext{}))
+
+	assert.Equal(t, 1, pagerduty.count())
+	assert.Equal(t, 2, slack.count())
+	assert.Equal(t, 1, webhook.count())
+	assert.Equal(t, "ERROR_PARSE", webhook.alerts[0].Key())
+}
+
+func TestMultiplexer_Deduplication(t *testing.T) {
+	sink := &fakeSink{}
+	m := newTestMultiplexer(t, &RoutingConfig{DedupWindow: duration(time.Minute)}, map[string]Sink{"sink": sink})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	}
+	assert.Equal(t, 1, sink.count())
+
+	// a different chain is a different alert.
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{Details: map[string]string{"chainID": "solana"}}))
+	assert.Equal(t, 2, sink.count())
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.Equal(t, 3, sink.count())
+	assert.Equal(t, "9", sink.alerts[2].Context().Details["suppressedCount"])
+}
+
+func TestMultiplexer_DeduplicationSendFailed(t *testing.T) {
+	sink := &fakeSink{err: errors.New("boom")}
+	m := newTestMultiplexer(t, &RoutingConfig{DedupWindow: duration(time.Minute)}, map[string]Sink{"sink": sink})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	// an alert that failed to be sent is not deduplicated.
+	ctx := context.Background()
+	assert.ErrorContains(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}), "boom")
+	assert.ErrorContains(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}), "boom")
+	assert.Equal(t, 2, sink.count())
+
+	// once an alert is sent, the next ones are deduplicated.
+	sink.err = nil
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.Equal(t, 3, sink.count())
+}
+
+func TestMultiplexer_Silence(t *testing.T) {
+	sink := &fakeSink{}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	routing := &RoutingConfig{
+		Silences: []Silence{{Match: Match{Keys: []string{"ERROR_PARSE"}}, Start: now.Add(-time.Hour), End: now.Add(time.Hour)}},
+	}
+	m := newTestMultiplexer(t, routing, map[string]Sink{"sink": sink})
+	m.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_PARSE", AlertContext{}))
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.Equal(t, 1, sink.count())
+
+	now = now.Add(2 * time.Hour)
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_PARSE", AlertContext{}))
+	assert.Equal(t, 2, sink.count())
+}
+
+func TestMultiplexer_RateLimit(t *testing.T) {
+	sink := &fakeSink{}
+	routing := &RoutingConfig{
+		DedupWindow: duration(0),
+		RateLimit:   &RateLimitConfig{PerMinute: 1, Burst: 3},
+	}
+	m := newTestMultiplexer(t, routing, map[string]Sink{"sink": sink})
+
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	}
+	assert.Equal(t, 3, sink.count())
+}
+
+func TestMultiplexer_RateLimitSuppressedCount(t *testing.T) {
+	limited, other := &fakeSink{}, &fakeSink{}
+	routing := &RoutingConfig{
+		DedupWindow: duration(0),
+		RateLimit:   &RateLimitConfig{PerMinute: 0},
+	}
+	m := newTestMultiplexer(t, routing, map[string]Sink{"limited": limited, "other": other})
+	m.sinks["limited"].limiter = newLimiter(RateLimitConfig{PerMinute: 1, Burst: 1})
+
+	ctx := context.Background()
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	}
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_PARSE", AlertContext{}))
+	assert.Equal(t, 1, limited.count())
+	assert.Equal(t, 7, other.count())
+
+	// once the sink accepts alerts again, the rate limited alerts are reported by key.
+	m.sinks["limited"].limiter = newLimiter(RateLimitConfig{})
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.NoError(t, m.CreateAndSend(ctx, "ERROR_SAVE_VAA", AlertContext{}))
+	assert.Equal(t, "5", limited.alerts[1].Context().Details["suppressedCount"])
+	assert.NotContains(t, limited.alerts[2].Context().Details, "suppressedCount")
+	assert.NotContains(t, other.alerts[7].Context().Details, "suppressedCount")
+}
+
+func TestRoutedSink_LimitedExpiration(t *testing.T) {
+	rs := &routedSink{limiter: newLimiter(RateLimitConfig{PerMinute: 1, Burst: 1}), limited: make(map[string]*limitedAlerts)}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ok, _ := rs.allow("a", now)
+	assert.True(t, ok)
+	ok, _ = rs.allow("a", now)
+	assert.False(t, ok)
+	assert.Len(t, rs.limited, 1)
+
+	// the counts of the keys not sent again are removed once expired.
+	rs.allow("b", now.Add(limitedExpiration))
+	assert.NotContains(t, rs.limited, "a")
+}
+
+func TestMultiplexer_SinkErrors(t *testing.T) {
+	failing := &fakeSink{err: errors.New("boom")}
+	ok := &fakeSink{}
+	m := newTestMultiplexer(t, &RoutingConfig{}, map[string]Sink{"failing": failing, "ok": ok})
+
+	err := m.CreateAndSend(context.Background(), "ERROR_SAVE_VAA", AlertContext{})
+	assert.ErrorContains(t, err, "boom")
+	assert.Equal(t, 1, ok.count())
+}
+
+func TestMultiplexer_UnknownSink(t *testing.T) {
+	routing := &RoutingConfig{Routes: []Route{{Sinks: []string{"missing"}}}}
+	_, err := newMultiplexer(AlertConfig{Enabled: true}, nil, routing, map[string]Sink{}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestPagerDutySink(t *testing.T) {
+	var event pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := NewPagerDutySink(server.URL, "routing-key", server.Client())
+	a := Alert{Message: "error saving vaa", Entity: "fly", Priority: CRITICAL, key: "ERROR_SAVE_VAA",
+		context: AlertContext{Details: map[string]string{"chainID": "solana"}, Error: errors.New("timeout")}}
+	assert.NoError(t, sink.Send(context.Background(), a))
+
+	assert.Equal(t, "routing-key", event.RoutingKey)
+	assert.Equal(t, "trigger", event.EventAction)
+	assert.Equal(t, "ERROR_SAVE_VAA:solana", event.DedupKey)
+	assert.Equal(t, "critical", event.Payload.Severity)
+	assert.Equal(t, "fly", event.Payload.Source)
+	assert.Contains(t, event.Payload.CustomDetails["description"], "timeout")
+}
+
+func TestSlackSink_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("invalid_token"))
+	}))
+	defer server.Close()
+
+	sink := NewSlackSink(server.URL, server.Client())
+	err := sink.Send(context.Background(), Alert{Message: "error", Priority: HIGH})
+	assert.ErrorContains(t, err, "invalid_token")
+}
+
+func TestLoadRoutingConfig(t *testing.T) {
+	cfg, err := LoadRoutingConfig(`{
+		"sinks": [{"name": "pd", "type": "pagerduty", "routingKey": "key"}],
+		"routes": [{"match": {"priorities": ["CRITICAL"]}, "sinks": ["pd"], "dedupWindow": "10m"}],
+		"dedupWindow": "1m"
+	}`)
+	assert.NoError(t, err)
+	assert.Len(t, cfg.Sinks, 1)
+	assert.Equal(t, Duration(10*time.Minute), *cfg.Routes[0].DedupWindow)
+	assert.Equal(t, Duration(time.Minute), *cfg.DedupWindow)
+
+	_, err = LoadRoutingConfig(`{"dedupWindow": "forever"}`)
+	assert.Error(t, err)
+}
@@ -21,6 +21,8 @@ type AlertConfig struct {
 	Environment string
 	ApiKey      string
 	Enabled     bool
+	// Routing is the sinks and routing rules configuration, see LoadRoutingConfig.
+	Routing string
 }
 
 // OpsgenieClient is the alert client.
@@ -61,6 +63,7 @@ func (s *OpsgenieClient) CreateAlert(key string, alertCtx AlertContext) (Alert,
 	}
 
 	alert.context = alertCtx
+	alert.key = key
 	return alert, nil
 }
 
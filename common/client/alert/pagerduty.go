@@ -0,0 +1,104 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DefaultPagerDutyURL is the pagerduty events api v2 url.
+const DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutySink sends alerts to the pagerduty events api v2.
+type PagerDutySink struct {
+	url        string
+	routingKey string
+	client     *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key,omitempty"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// NewPagerDutySink creates a new pagerduty sink. If url is empty, DefaultPagerDutyURL is used.
+func NewPagerDutySink(url, routingKey string, client *http.Client) *PagerDutySink {
+	if url == "" {
+		url = DefaultPagerDutyURL
+	}
+	return &PagerDutySink{url: url, routingKey: routingKey, client: client}
+}
+
+// Send triggers a pagerduty event.
+func (s *PagerDutySink) Send(ctx context.Context, alert Alert) error {
+	if alert.Message == "" {
+		return errors.New("message can not be empty")
+	}
+	return postJSON(ctx, s.client, s.url, nil, s.toEvent(alert))
+}
+
+func (s *PagerDutySink) toEvent(a Alert) pagerDutyEvent {
+	details := make(map[string]string, len(a.context.Details)+2)
+	for k, v := range a.context.Details {
+		details[k] = v
+	}
+	details["description"] = a.fullDescription()
+	if a.context.Note != "" {
+		details["note"] = a.context.Note
+	}
+
+	// the summary can not be longer than 1024 characters.
+	summary := a.Message
+	if len(summary) > 1024 {
+		summary = summary[:1024]
+	}
+
+	source := a.Entity
+	if source == "" {
+		source = "wormhole-explorer"
+	}
+
+	return pagerDutyEvent{
+		RoutingKey:  s.routingKey,
+		EventAction: "trigger",
+		DedupKey:    a.dedupKey(),
+		Payload: pagerDutyPayload{
+			Summary:       summary,
+			Source:        source,
+			Severity:      a.Priority.toPagerDutySeverity(),
+			Timestamp:     time.Now().UTC().Format(time.RFC3339),
+			Component:     a.Chain(),
+			Group:         a.Entity,
+			Class:         a.key,
+			CustomDetails: details,
+		},
+	}
+}
+
+// toPagerDutySeverity converts a Priority to a pagerduty severity.
+func (p Priority) toPagerDutySeverity() string {
+	switch p {
+	case CRITICAL:
+		return "critical"
+	case HIGH:
+		return "error"
+	case MODERATE, LOW:
+		return "warning"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,180 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Default values of the routing configuration.
+const (
+	DefaultDedupWindow        = 5 * time.Minute
+	DefaultRateLimitPerMinute = 30
+	DefaultRateLimitBurst     = 10
+)
+
+// Duration is a time.Duration that is decoded from a json string like "5m".
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// RoutingConfig defines the alert sinks and the rules to route the alerts to them.
+type RoutingConfig struct {
+	Sinks []SinkConfig `json:"sinks"`
+	// Routes are evaluated in order. An alert is sent to the sinks of the first matching route,
+	// or of every matching route while the matched routes have Continue set.
+	Routes []Route `json:"routes"`
+	// DefaultSinks receive the alerts that don't match any route.
+	// When empty, those alerts are sent to every sink.
+	DefaultSinks []string `json:"defaultSinks"`
+	// Silences suppress the matching alerts during a time window.
+	Silences []Silence `json:"silences"`
+	// DedupWindow is the time during which repeated alerts are suppressed.
+	DedupWindow *Duration `json:"dedupWindow"`
+	// RateLimit is the default rate limit of each sink.
+	RateLimit *RateLimitConfig `json:"rateLimit"`
+}
+
+// RateLimitConfig limits the number of alerts sent to a sink.
+type RateLimitConfig struct {
+	PerMinute int `json:"perMinute"`
+	Burst     int `json:"burst"`
+}
+
+// Match selects alerts by key, priority, service and chain. Empty fields match any alert.
+type Match struct {
+	Keys       []string   `json:"keys"`
+	Priorities []Priority `json:"priorities"`
+	Services   []string   `json:"services"`
+	// Chains are chain names (e.g. "solana") or chain ids (e.g. "1").
+	Chains []string `json:"chains"`
+}
+
+// Route sends the matching alerts to a set of sinks.
+type Route struct {
+	Match    Match    `json:"match"`
+	Sinks    []string `json:"sinks"`
+	Continue bool     `json:"continue"`
+	// DedupWindow overrides the default deduplication window for the matching alerts.
+	DedupWindow *Duration `json:"dedupWindow"`
+}
+
+// Silence suppresses the matching alerts between Start and End.
+// A zero Start or End leaves the window open on that side.
+type Silence struct {
+	Match Match     `json:"match"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// LoadRoutingConfig parses a routing configuration. The value can be a json document
+// or a path to a json file.
+func LoadRoutingConfig(value string) (*RoutingConfig, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return &RoutingConfig{}, nil
+	}
+
+	data := []byte(value)
+	if !strings.HasPrefix(value, "{") {
+		b, err := os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read alert routing config: %w", err)
+		}
+		data = b
+	}
+
+	var cfg RoutingConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse alert routing config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// matches checks if the alert matches all the criteria.
+func (m Match) matches(a Alert) bool {
+	if len(m.Keys) > 0 && !contains(m.Keys, a.key) {
+		return false
+	}
+	if len(m.Priorities) > 0 {
+		found := false
+		for _, p := range m.Priorities {
+			if strings.EqualFold(string(p), string(a.Priority)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(m.Services) > 0 && !contains(m.Services, a.Service()) {
+		return false
+	}
+	if len(m.Chains) > 0 {
+		chain := a.Chain()
+		if chain == "" {
+			return false
+		}
+		found := false
+		for _, c := range m.Chains {
+			if sameChain(c, chain) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// active checks if the silence applies at the given time.
+func (s Silence) active(now time.Time) bool {
+	if !s.Start.IsZero() && now.Before(s.Start) {
+		return false
+	}
+	if !s.End.IsZero() && !now.Before(s.End) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameChain compares two chains given by name or by id.
+func sameChain(a, b string) bool {
+	return strings.EqualFold(chainName(a), chainName(b))
+}
+
+func chainName(chain string) string {
+	if id, err := strconv.ParseUint(chain, 10, 16); err == nil {
+		return sdk.ChainID(id).String()
+	}
+	return chain
+}
@@ -0,0 +1,104 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	opsgenieAlert "github.com/opsgenie/opsgenie-go-sdk-v2/alert"
+	"github.com/opsgenie/opsgenie-go-sdk-v2/client"
+)
+
+// Sink types.
+const (
+	SinkTypeOpsgenie  = "opsgenie"
+	SinkTypeSlack     = "slack"
+	SinkTypePagerDuty = "pagerduty"
+	SinkTypeWebhook   = "webhook"
+)
+
+// defaultSinkTimeout is the timeout of the http requests sent by the sinks.
+const defaultSinkTimeout = 10 * time.Second
+
+// Sink delivers alerts to an external system.
+type Sink interface {
+	Send(ctx context.Context, alert Alert) error
+}
+
+// SinkConfig is the configuration of an alert sink.
+type SinkConfig struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// URL is the slack incoming webhook, the pagerduty events api or the generic webhook url.
+	URL string `json:"url"`
+	// ApiKey is the opsgenie api key.
+	ApiKey string `json:"apiKey"`
+	// RoutingKey is the pagerduty integration key.
+	RoutingKey string `json:"routingKey"`
+	// Headers are added to the generic webhook requests.
+	Headers map[string]string `json:"headers"`
+	// RateLimit overrides the default rate limit of the sink.
+	RateLimit *RateLimitConfig `json:"rateLimit"`
+}
+
+// newSink creates a sink from its configuration.
+func newSink(cfg SinkConfig, environment string) (Sink, error) {
+	httpClient := &http.Client{Timeout: defaultSinkTimeout}
+	switch cfg.Type {
+	case SinkTypeOpsgenie:
+		alertClient, err := opsgenieAlert.NewClient(&client.Config{ApiKey: cfg.ApiKey})
+		if err != nil {
+			return nil, err
+		}
+		return &OpsgenieClient{client: alertClient, enabled: true}, nil
+	case SinkTypeSlack:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("sink %s: slack webhook url can not be empty", cfg.Name)
+		}
+		return NewSlackSink(cfg.URL, httpClient), nil
+	case SinkTypePagerDuty:
+		if cfg.RoutingKey == "" {
+			return nil, fmt.Errorf("sink %s: pagerduty routing key can not be empty", cfg.Name)
+		}
+		return NewPagerDutySink(cfg.URL, cfg.RoutingKey, httpClient), nil
+	case SinkTypeWebhook:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("sink %s: webhook url can not be empty", cfg.Name)
+		}
+		return NewWebhookSink(cfg.URL, cfg.Headers, environment, httpClient), nil
+	default:
+		return nil, fmt.Errorf("sink %s: unknown sink type %s", cfg.Name, cfg.Type)
+	}
+}
+
+// postJSON sends the body encoded as json to the url and checks the response status.
+func postJSON(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, body any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("unexpected status code %d: %s", res.StatusCode, string(msg))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SlackSink sends alerts to a slack incoming webhook.
+type SlackSink struct {
+	url    string
+	client *http.Client
+}
+
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Title  string       `json:"title"`
+	Text   string       `json:"text"`
+	Fields []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// NewSlackSink creates a new slack sink.
+func NewSlackSink(url string, client *http.Client) *SlackSink {
+	return &SlackSink{url: url, client: client}
+}
+
+// Send sends an alert to slack.
+func (s *SlackSink) Send(ctx context.Context, alert Alert) error {
+	if alert.Message == "" {
+		return errors.New("message can not be empty")
+	}
+	return postJSON(ctx, s.client, s.url, nil, toSlackMessage(alert))
+}
+
+func toSlackMessage(a Alert) slackMessage {
+	fields := []slackField{{Title: "Priority", Value: string(a.Priority), Short: true}}
+	if a.Entity != "" {
+		fields = append(fields, slackField{Title: "Service", Value: a.Entity, Short: true})
+	}
+	if len(a.Tags) > 0 {
+		fields = append(fields, slackField{Title: "Tags", Value: strings.Join(a.Tags, ", ")})
+	}
+
+	// sort details to get a stable message.
+	keys := make([]string, 0, len(a.context.Details))
+	for k := range a.context.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = append(fields, slackField{Title: k, Value: a.context.Details[k], Short: true})
+	}
+
+	text := a.fullDescription()
+	if a.context.Note != "" {
+		text = fmt.Sprintf("%s\n%s", text, a.context.Note)
+	}
+
+	return slackMessage{
+		Text: a.Message,
+		Attachments: []slackAttachment{{
+			Color:  a.Priority.toSlackColor(),
+			Title:  a.Alias,
+			Text:   text,
+			Fields: fields,
+		}},
+	}
+}
+
+// toSlackColor converts a Priority to a slack attachment color.
+func (p Priority) toSlackColor() string {
+	switch p {
+	case CRITICAL:
+		return "danger"
+	case HIGH, MODERATE:
+		return "warning"
+	default:
+		return "#439FE0"
+	}
+}
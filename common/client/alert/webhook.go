@@ -0,0 +1,68 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// WebhookSink sends alerts as json to a generic webhook.
+type WebhookSink struct {
+	url         string
+	headers     map[string]string
+	environment string
+	client      *http.Client
+}
+
+// WebhookPayload is the body sent by the WebhookSink.
+type WebhookPayload struct {
+	Key         string            `json:"key"`
+	Environment string            `json:"environment"`
+	Service     string            `json:"service"`
+	Chain       string            `json:"chain,omitempty"`
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description"`
+	Priority    Priority          `json:"priority"`
+	Tags        []string          `json:"tags"`
+	Details     map[string]string `json:"details,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	Note        string            `json:"note,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// NewWebhookSink creates a new generic webhook sink.
+func NewWebhookSink(url string, headers map[string]string, environment string, client *http.Client) *WebhookSink {
+	return &WebhookSink{url: url, headers: headers, environment: environment, client: client}
+}
+
+// Send posts an alert to the webhook.
+func (s *WebhookSink) Send(ctx context.Context, alert Alert) error {
+	if alert.Message == "" {
+		return errors.New("message can not be empty")
+	}
+	return postJSON(ctx, s.client, s.url, s.headers, s.toPayload(alert))
+}
+
+func (s *WebhookSink) toPayload(a Alert) WebhookPayload {
+	var errMsg string
+	if a.context.Error != nil {
+		errMsg = a.context.Error.Error()
+	}
+	return WebhookPayload{
+		Key:         a.key,
+		Environment: s.environment,
+		Service:     a.Entity,
+		Chain:       a.Chain(),
+		Message:     a.Message,
+		Alias:       a.Alias,
+		Description: a.Description,
+		Priority:    a.Priority,
+		Tags:        a.Tags,
+		Details:     a.context.Details,
+		Error:       errMsg,
+		Note:        a.context.Note,
+		Timestamp:   time.Now().UTC(),
+	}
+}
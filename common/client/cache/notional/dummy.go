@@ -1,7 +1,5 @@
 package notional
 
-import "github.com/wormhole-foundation/wormhole-explorer/common/domain"
-
 // DummyNotionalCache is a dummy notional cache.
 type DummyNotionalCache struct {
 }
@@ -12,7 +10,7 @@ func NewDummyNotionalCache() *DummyNotionalCache {
 }
 
 // Get get notional cache value.
-func (c *DummyNotionalCache) Get(symbol domain.Symbol) (PriceData, error) {
+func (c *DummyNotionalCache) Get(tokenID string) (PriceData, error) {
 	return PriceData{}, nil
 }
 
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// Groups of the API responses cached by the response cache.
+// Each group is invalidated as a whole when the data behind its responses is updated.
+const (
+	ResponseGroupScorecards    = "scorecards"
+	ResponseGroupTopAssets     = "top-assets"
+	ResponseGroupChainActivity = "x-chain-activity"
+	ResponseGroupGovernor      = "governor"
+	ResponseGroupProtocols     = "protocols"
+)
+
+const responseKeyFormat = "WORMSCAN:RESPONSE:%s:"
+
+// ResponseGenerationKey returns the cache key of the generation of a group. The responses of a group
+// are stored under its current generation, so the group is invalidated by incrementing it.
+func ResponseGenerationKey(group string) string {
+	return fmt.Sprintf(responseKeyFormat, group) + "GENERATION"
+}
+
+// ResponseKey returns the cache key of the API response of a resource of a group in a generation.
+func ResponseKey(group, generation, resource string) string {
+	return fmt.Sprintf(responseKeyFormat, group) + generation + ":" + resource
+}
+
+// ResponseInvalidator invalidates the cached API responses of a group.
+type ResponseInvalidator interface {
+	Invalidate(ctx context.Context, groups ...string) error
+}
+
+// RedisResponseInvalidator invalidates the cached API responses of a group by incrementing its generation
+// in redis. The responses of the previous generations are no longer read and expire on their own.
+// Groups are invalidated at most once every minInterval, so it can be called on every update: the
+// invalidations requested inside the interval are coalesced into one at the end of the interval.
+type RedisResponseInvalidator struct {
+	client      *redis.Client
+	prefix      string
+	minInterval time.Duration
+	logger      *zap.Logger
+
+	mu     sync.Mutex
+	groups map[string]*groupInvalidation
+}
+
+// groupInvalidation is the invalidation state of a group.
+type groupInvalidation struct {
+	// last is the time of the last successful invalidation.
+	last time.Time
+	// pending is true while an invalidation is scheduled at the end of the interval.
+	pending bool
+}
+
+// NewResponseInvalidator creates a new RedisResponseInvalidator.
+func NewResponseInvalidator(client *redis.Client, prefix string, minInterval time.Duration, logger *zap.Logger) *RedisResponseInvalidator {
+	return &RedisResponseInvalidator{
+		client:      client,
+		prefix:      prefix,
+		minInterval: minInterval,
+		logger:      logger,
+		groups:      make(map[string]*groupInvalidation),
+	}
+}
+
+// Invalidate invalidates the cached API responses of the groups. The groups invalidated in the last
+// minInterval are invalidated at the end of the interval.
+func (i *RedisResponseInvalidator) Invalidate(ctx context.Context, groups ...string) error {
+	for _, group := range groups {
+		delay, ok := i.schedule(group, time.Now())
+		if !ok {
+			continue
+		}
+		if delay > 0 {
+			group := group
+			time.AfterFunc(delay, func() { i.invalidateScheduled(group) })
+			continue
+		}
+		if err := i.invalidate(ctx, group); err != nil {
+			i.logger.Error("failed to invalidate cached responses", zap.String("group", group), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// schedule returns the delay after which group must be invalidated: zero if it was not invalidated
+// in the last minInterval, or the time left until the end of the interval. It returns false if an
+// invalidation is already scheduled, because it also covers this one.
+func (i *RedisResponseInvalidator) schedule(group string, now time.Time) (time.Duration, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	state, ok := i.groups[group]
+	if !ok {
+		state = &groupInvalidation{}
+		i.groups[group] = state
+	}
+	if state.pending {
+		return 0, false
+	}
+	delay := i.minInterval - now.Sub(state.last)
+	if delay <= 0 {
+		return 0, true
+	}
+	state.pending = true
+	return delay, true
+}
+
+// invalidateScheduled runs the invalidation scheduled at the end of the interval.
+func (i *RedisResponseInvalidator) invalidateScheduled(group string) {
+	i.mu.Lock()
+	i.groups[group].pending = false
+	i.mu.Unlock()
+	if err := i.invalidate(context.Background(), group); err != nil {
+		i.logger.Error("failed to invalidate cached responses", zap.String("group", group), zap.Error(err))
+	}
+}
+
+// invalidate increments the generation of group and records the time of the invalidation.
+func (i *RedisResponseInvalidator) invalidate(ctx context.Context, group string) error {
+	key := ResponseGenerationKey(group)
+	if i.prefix != "" {
+		key = fmt.Sprintf("%s:%s", i.prefix, key)
+	}
+	if err := i.client.Incr(ctx, key).Err(); err != nil {
+		return err
+	}
+	i.succeeded(group, time.Now())
+	return nil
+}
+
+// succeeded records that group was invalidated at now.
+func (i *RedisResponseInvalidator) succeeded(group string, now time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.groups[group].last = now
+}
+
+// noopResponseInvalidator does not invalidate any response.
+type noopResponseInvalidator struct{}
+
+// NewNoopResponseInvalidator creates a ResponseInvalidator that does not invalidate any response.
+func NewNoopResponseInvalidator() ResponseInvalidator {
+	return &noopResponseInvalidator{}
+}
+
+func (n *noopResponseInvalidator) Invalidate(_ context.Context, _ ...string) error {
+	return nil
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRedisResponseInvalidator_Schedule(t *testing.T) {
+	invalidator := NewResponseInvalidator(nil, "", time.Minute, zap.NewNop())
+	now := time.Now()
+
+	// a group never invalidated is invalidated immediately.
+	delay, ok := invalidator.schedule(ResponseGroupGovernor, now)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+
+	// the invalidation failed, so the next one is not delayed.
+	delay, ok = invalidator.schedule(ResponseGroupGovernor, now.Add(time.Second))
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+
+	// an invalidation inside the interval is scheduled at the end of the interval.
+	invalidator.succeeded(ResponseGroupGovernor, now)
+	delay, ok = invalidator.schedule(ResponseGroupGovernor, now.Add(10*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 50*time.Second, delay)
+
+	// and the next ones are coalesced into it.
+	_, ok = invalidator.schedule(ResponseGroupGovernor, now.Add(20*time.Second))
+	assert.False(t, ok)
+
+	// the groups are independent.
+	delay, ok = invalidator.schedule(ResponseGroupScorecards, now.Add(20*time.Second))
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	aws_sns "github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sns/types"
 )
 
 // Producer represents SNS producer.
@@ -22,12 +23,28 @@ func NewProducer(awsConfig aws.Config, url string) (*Producer, error) {
 
 // SendMessage sends messages to SQS.
 func (p *Producer) SendMessage(ctx context.Context, groupID, deduplicationID, body string) error {
+	return p.SendMessageWithAttributes(ctx, groupID, deduplicationID, body, nil)
+}
+
+// SendMessageWithAttributes sends messages with string attributes, used by the subscription filter policies.
+func (p *Producer) SendMessageWithAttributes(ctx context.Context, groupID, deduplicationID, body string, attributes map[string]string) error {
+	var attrs map[string]types.MessageAttributeValue
+	if len(attributes) > 0 {
+		attrs = make(map[string]types.MessageAttributeValue, len(attributes))
+		for k, v := range attributes {
+			attrs[k] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(v),
+			}
+		}
+	}
 	_, err := p.api.Publish(ctx,
 		&aws_sns.PublishInput{
 			MessageGroupId:         aws.String(groupID),
 			MessageDeduplicationId: aws.String(deduplicationID),
 			Message:                aws.String(body),
 			TopicArn:               aws.String(p.url),
+			MessageAttributes:      attrs,
 		})
 	return err
 }
@@ -144,20 +144,26 @@ func TranslateEmitterAddress(chainID sdk.ChainID, address string) (string, error
 
 	// Near addresses are arbitrary-length strings. The emitter is the sha256 digest of the program address string.
 	//
-	// We're using a hashmap of known emitters to avoid querying external APIs.
+	// We're using a hashmap of known emitters to avoid querying external APIs,
+	// and the emitter registry for emitters that are not hardcoded.
 	case sdk.ChainIDNear:
 		if nativeAddress, ok := nearKnownEmitters[address]; ok {
 			return nativeAddress, nil
+		} else if nativeAddress, ok := resolveEmitterNativeAddress(chainID, address); ok {
+			return nativeAddress, nil
 		} else {
 			return "", fmt.Errorf(`no mapping found for NEAR emitter address "%s"`, address)
 		}
 
 	// For Sui emitters, an emitter capacity is taken from the core bridge. The capability object ID is used.
 	//
-	// We're using a hashmap of known emitters to avoid querying the contract's state.
+	// We're using a hashmap of known emitters to avoid querying the contract's state,
+	// and the emitter registry for emitters that are not hardcoded.
 	case sdk.ChainIDSui:
 		if nativeAddress, ok := suiKnownEmitters[address]; ok {
 			return nativeAddress, nil
+		} else if nativeAddress, ok := resolveEmitterNativeAddress(chainID, address); ok {
+			return nativeAddress, nil
 		} else {
 			return "", fmt.Errorf(`no mapping found for Sui emitter address "%s"`, address)
 		}
@@ -165,15 +171,21 @@ func TranslateEmitterAddress(chainID sdk.ChainID, address string) (string, error
 	// For Aptos, an emitter capability is taken from the core bridge. The capability object ID is used.
 	// The core bridge generates capabilities in a sequence and the capability object ID is its index in the sequence.
 	//
-	// We're using a hashmap of known emitters to avoid querying the contract's state.
+	// We're using a hashmap of known emitters to avoid querying the contract's state,
+	// and the emitter registry for emitters that are not hardcoded.
 	case sdk.ChainIDAptos:
 		if nativeAddress, ok := aptosKnownEmitters[address]; ok {
 			return nativeAddress, nil
+		} else if nativeAddress, ok := resolveEmitterNativeAddress(chainID, address); ok {
+			return nativeAddress, nil
 		} else {
 			return "", fmt.Errorf(`no mapping found for Aptos emitter address "%s"`, address)
 		}
 
 	default:
+		if nativeAddress, ok := resolveEmitterNativeAddress(chainID, address); ok {
+			return nativeAddress, nil
+		}
 		return "", fmt.Errorf("can't translate emitter address: ChainID=%d not supported", chainID)
 	}
 }
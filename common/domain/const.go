@@ -10,6 +10,8 @@ const (
 const (
 	AppIdUnkonwn           = "UNKONWN"
 	AppIdPortalTokenBridge = "PORTAL_TOKEN_BRIDGE"
+	AppIdNTT               = "NATIVE_TOKEN_TRANSFER"
+	AppIdCCTP              = "CCTP_WORMHOLE_INTEGRATION"
 )
 
 // SourceTxStatus is meant to be a user-facing enum that describes the status of the source transaction.
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+	"sync/atomic"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// EmitterInfo describes an emitter: its native address, the app that owns it
+// and a human readable label.
+type EmitterInfo struct {
+	ChainID sdk.ChainID
+	// Address is the wormhole emitter address, hex encoded without 0x prefix.
+	Address       string
+	NativeAddress string
+	// AppID is the id of the protocol that owns the emitter.
+	AppID string
+	Label string
+}
+
+// EmitterResolver resolves emitter addresses that can not be derived from the address itself.
+type EmitterResolver interface {
+	ResolveEmitter(chainID sdk.ChainID, address string) (*EmitterInfo, bool)
+}
+
+var emitterResolver atomic.Pointer[EmitterResolver]
+
+// SetEmitterResolver sets the resolver used by TranslateEmitterAddress when an emitter
+// is not in the known emitters list. A nil resolver disables the lookup.
+func SetEmitterResolver(r EmitterResolver) {
+	if r == nil {
+		emitterResolver.Store(nil)
+		return
+	}
+	emitterResolver.Store(&r)
+}
+
+// ResolveEmitter returns the emitter information found by the configured resolver.
+func ResolveEmitter(chainID sdk.ChainID, address string) (*EmitterInfo, bool) {
+	r := emitterResolver.Load()
+	if r == nil {
+		return nil, false
+	}
+	return (*r).ResolveEmitter(chainID, strings.ToLower(address))
+}
+
+func resolveEmitterNativeAddress(chainID sdk.ChainID, address string) (string, bool) {
+	info, ok := ResolveEmitter(chainID, address)
+	if !ok || info.NativeAddress == "" {
+		return "", false
+	}
+	return info.NativeAddress, true
+}
+
+// knownEmitterLabels maps the hardcoded emitters to the app that owns them.
+var knownEmitterLabels = map[string]EmitterInfo{
+	"148410499d3fcda4dcfd68a1ebfcdddda16ab28326448d4aae4d2f0465cdfcb7": {AppID: AppIdPortalTokenBridge, Label: "Token Bridge"},
+	"ccceeb29348f71bdd22ffef43a2a19c1f5b5e17c5cca5411529120182672ade5": {AppID: AppIdPortalTokenBridge, Label: "Token Bridge"},
+	"0000000000000000000000000000000000000000000000000000000000000001": {AppID: AppIdPortalTokenBridge, Label: "Token Bridge"},
+	"0000000000000000000000000000000000000000000000000000000000000005": {Label: "NFT Bridge"},
+}
+
+// KnownEmitters returns the hardcoded emitters, used to seed the emitter registry.
+func KnownEmitters() []EmitterInfo {
+	var emitters []EmitterInfo
+	add := func(chainID sdk.ChainID, known map[string]string) {
+		for address, nativeAddress := range known {
+			label := knownEmitterLabels[address]
+			emitters = append(emitters, EmitterInfo{
+				ChainID:       chainID,
+				Address:       address,
+				NativeAddress: nativeAddress,
+				AppID:         label.AppID,
+				Label:         label.Label,
+			})
+		}
+	}
+	add(sdk.ChainIDNear, nearKnownEmitters)
+	add(sdk.ChainIDSui, suiKnownEmitters)
+	add(sdk.ChainIDAptos, aptosKnownEmitters)
+	return emitters
+}
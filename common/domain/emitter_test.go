@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+type mapEmitterResolver map[string]*EmitterInfo
+
+func (m mapEmitterResolver) ResolveEmitter(chainID sdk.ChainID, address string) (*EmitterInfo, bool) {
+	info, ok := m[chainID.String()+"/"+address]
+	return info, ok
+}
+
+func TestTranslateEmitterAddress_UsesEmitterResolver(t *testing.T) {
+	const unknownSuiEmitter = "aaaaeb29348f71bdd22ffef43a2a19c1f5b5e17c5cca5411529120182672ade5"
+
+	_, err := TranslateEmitterAddress(sdk.ChainIDSui, unknownSuiEmitter)
+	assert.Error(t, err)
+
+	SetEmitterResolver(mapEmitterResolver{
+		sdk.ChainIDSui.String() + "/" + unknownSuiEmitter: {NativeAddress: "0xabc"},
+	})
+	defer SetEmitterResolver(nil)
+
+	nativeAddress, err := TranslateEmitterAddress(sdk.ChainIDSui, unknownSuiEmitter)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabc", nativeAddress)
+
+	// hardcoded emitters take precedence over the resolver.
+	nativeAddress, err = TranslateEmitterAddress(sdk.ChainIDSui, "ccceeb29348f71bdd22ffef43a2a19c1f5b5e17c5cca5411529120182672ade5")
+	assert.NoError(t, err)
+	assert.Equal(t, "0xc57508ee0d4595e5a8728974a4a93a787d38f339757230d441e895422c07aba9", nativeAddress)
+}
+
+func TestKnownEmitters(t *testing.T) {
+	emitters := KnownEmitters()
+	assert.Len(t, emitters, len(nearKnownEmitters)+len(suiKnownEmitters)+len(aptosKnownEmitters))
+	for _, e := range emitters {
+		assert.NotEmpty(t, e.NativeAddress)
+		assert.NotEmpty(t, e.Label)
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "fmt"
+
+// OperationStatus describes where an operation is in its lifecycle.
+type OperationStatus string
+
+const (
+	// OperationStatusObserved indicates that the message was observed by at least one guardian,
+	// but the VAA has not reached quorum yet.
+	OperationStatusObserved OperationStatus = "observed"
+
+	// OperationStatusGovernorEnqueued indicates that the message was enqueued by the governor.
+	OperationStatusGovernorEnqueued OperationStatus = "governor_enqueued"
+
+	// OperationStatusVaaSigned indicates that the VAA was signed by a quorum of guardians.
+	OperationStatusVaaSigned OperationStatus = "vaa_signed"
+
+	// OperationStatusTargetFailed indicates that the redeem transaction failed on the target chain.
+	OperationStatusTargetFailed OperationStatus = "target_failed"
+
+	// OperationStatusRedeemed indicates that the VAA was redeemed on the target chain.
+	OperationStatusRedeemed OperationStatus = "redeemed"
+)
+
+// operationStatusRank defines the order of the lifecycle. An operation never moves to a lower rank,
+// except from target_failed to redeemed when the redeem is retried successfully.
+var operationStatusRank = map[OperationStatus]int{
+	OperationStatusObserved:         1,
+	OperationStatusGovernorEnqueued: 2,
+	OperationStatusVaaSigned:        3,
+	OperationStatusTargetFailed:     4,
+	OperationStatusRedeemed:         5,
+}
+
+// OperationStatuses returns all the operation statuses in lifecycle order.
+func OperationStatuses() []OperationStatus {
+	return []OperationStatus{
+		OperationStatusObserved,
+		OperationStatusGovernorEnqueued,
+		OperationStatusVaaSigned,
+		OperationStatusTargetFailed,
+		OperationStatusRedeemed,
+	}
+}
+
+// ParseOperationStatus parses an operation status.
+func ParseOperationStatus(s string) (OperationStatus, error) {
+	status := OperationStatus(s)
+	if _, ok := operationStatusRank[status]; !ok {
+		return "", fmt.Errorf("invalid operation status: %s", s)
+	}
+	return status, nil
+}
+
+// IsAfter returns true if the status is later in the lifecycle than other.
+func (s OperationStatus) IsAfter(other OperationStatus) bool {
+	return operationStatusRank[s] > operationStatusRank[other]
+}
+
+// DeriveOperationStatus computes the status of an operation from the data available for it.
+func DeriveOperationStatus(vaaSigned, governorEnqueued bool, destinationTxStatus string) OperationStatus {
+	switch {
+	case destinationTxStatus == DstTxStatusConfirmed:
+		return OperationStatusRedeemed
+	case destinationTxStatus == DstTxStatusFailedToProcess:
+		return OperationStatusTargetFailed
+	case vaaSigned:
+		return OperationStatusVaaSigned
+	case governorEnqueued:
+		return OperationStatusGovernorEnqueued
+	default:
+		return OperationStatusObserved
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestDeriveOperationStatus(t *testing.T) {
+	var tests = []struct {
+		name                string
+		vaaSigned           bool
+		governorEnqueued    bool
+		destinationTxStatus string
+		want                OperationStatus
+	}{
+		{name: "observed", want: OperationStatusObserved},
+		{name: "enqueued", governorEnqueued: true, want: OperationStatusGovernorEnqueued},
+		{name: "signed", vaaSigned: true, governorEnqueued: true, want: OperationStatusVaaSigned},
+		{name: "unknown redeem", vaaSigned: true, destinationTxStatus: DstTxStatusUnkonwn, want: OperationStatusVaaSigned},
+		{name: "failed", vaaSigned: true, destinationTxStatus: DstTxStatusFailedToProcess, want: OperationStatusTargetFailed},
+		{name: "redeemed", vaaSigned: true, destinationTxStatus: DstTxStatusConfirmed, want: OperationStatusRedeemed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeriveOperationStatus(tt.vaaSigned, tt.governorEnqueued, tt.destinationTxStatus)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOperationStatusOrder(t *testing.T) {
+	assert.True(t, OperationStatusVaaSigned.IsAfter(OperationStatusGovernorEnqueued))
+	assert.True(t, OperationStatusRedeemed.IsAfter(OperationStatusTargetFailed))
+	assert.False(t, OperationStatusObserved.IsAfter(OperationStatusObserved))
+
+	_, err := ParseOperationStatus("stuck")
+	assert.Error(t, err)
+	status, err := ParseOperationStatus("redeemed")
+	assert.NoError(t, err)
+	assert.Equal(t, OperationStatusRedeemed, status)
+}
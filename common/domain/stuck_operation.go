@@ -0,0 +1,55 @@
+package domain
+
+import "fmt"
+
+// StuckCause is the likely reason why an operation was not redeemed on the target chain.
+type StuckCause string
+
+const (
+	// StuckCauseGovernorEnqueued indicates that the message is held by the governor and the VAA is not signed yet.
+	StuckCauseGovernorEnqueued StuckCause = "governor_enqueued"
+
+	// StuckCauseTargetNotTracked indicates that the redeem transactions of the target chain are not tracked,
+	// so the operation may have been redeemed without being recorded.
+	StuckCauseTargetNotTracked StuckCause = "target_not_tracked"
+
+	// StuckCauseRelayerNotDelivered indicates that the VAA was signed but nobody submitted it to the target chain.
+	StuckCauseRelayerNotDelivered StuckCause = "relayer_not_delivered"
+
+	// StuckCauseRedeemFailed indicates that the redeem transaction failed on the target chain.
+	StuckCauseRedeemFailed StuckCause = "redeem_failed"
+)
+
+// StuckCauses returns all the stuck causes.
+func StuckCauses() []StuckCause {
+	return []StuckCause{
+		StuckCauseGovernorEnqueued,
+		StuckCauseTargetNotTracked,
+		StuckCauseRelayerNotDelivered,
+		StuckCauseRedeemFailed,
+	}
+}
+
+// ParseStuckCause parses a stuck cause.
+func ParseStuckCause(s string) (StuckCause, error) {
+	for _, cause := range StuckCauses() {
+		if string(cause) == s {
+			return cause, nil
+		}
+	}
+	return "", fmt.Errorf("invalid stuck cause: %s", s)
+}
+
+// ClassifyStuckCause returns the likely reason why an operation was not redeemed.
+func ClassifyStuckCause(vaaSigned, targetTracked bool, destinationTxStatus string) StuckCause {
+	switch {
+	case !vaaSigned:
+		return StuckCauseGovernorEnqueued
+	case destinationTxStatus == DstTxStatusFailedToProcess:
+		return StuckCauseRedeemFailed
+	case !targetTracked:
+		return StuckCauseTargetNotTracked
+	default:
+		return StuckCauseRelayerNotDelivered
+	}
+}
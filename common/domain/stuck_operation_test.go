@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/test-go/testify/assert"
+)
+
+func TestClassifyStuckCause(t *testing.T) {
+	var tests = []struct {
+		name                string
+		vaaSigned           bool
+		targetTracked       bool
+		destinationTxStatus string
+		want                StuckCause
+	}{
+		{name: "enqueued", targetTracked: true, want: StuckCauseGovernorEnqueued},
+		{name: "failed", vaaSigned: true, targetTracked: true, destinationTxStatus: DstTxStatusFailedToProcess, want: StuckCauseRedeemFailed},
+		{name: "not tracked", vaaSigned: true, want: StuckCauseTargetNotTracked},
+		{name: "not delivered", vaaSigned: true, targetTracked: true, destinationTxStatus: DstTxStatusUnkonwn, want: StuckCauseRelayerNotDelivered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ClassifyStuckCause(tt.vaaSigned, tt.targetTracked, tt.destinationTxStatus)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := ParseStuckCause("unknown")
+	assert.Error(t, err)
+	cause, err := ParseStuckCause("redeem_failed")
+	assert.NoError(t, err)
+	assert.Equal(t, StuckCauseRedeemFailed, cause)
+}
@@ -11,6 +11,10 @@ const (
 	EvmTransactionFoundType = "evm-transaction-found"
 	TransferRedeemedType    = "transfer-redeemed"
 	EvmTransferRedeemedName = "transfer-redeemed"
+
+	GovernorVaaEnqueuedType       = "governor-vaa-enqueued"
+	GovernorVaaReleaseUpdatedType = "governor-vaa-release-updated"
+	GovernorVaaReleasedType       = "governor-vaa-released"
 )
 
 type NotificationEvent struct {
@@ -38,7 +42,7 @@ func NewNotificationEvent[T EventData](trackID, source, _type string, data T) (*
 }
 
 type EventData interface {
-	SignedVaa | LogMessagePublished | EvmTransactionFound | TransferRedeemed
+	SignedVaa | LogMessagePublished | EvmTransactionFound | TransferRedeemed | GovernorVaa
 }
 
 func GetEventData[T EventData](e *NotificationEvent) (T, error) {
@@ -117,3 +121,17 @@ type TransferRedeemedAttributes struct {
 	EffectiveGasPrice *string `json:"effectiveGasPrice"`
 	Fee               *uint64 `json:"fee"`
 }
+
+// GovernorVaa is a vaa enqueued by the governor of the guardians.
+type GovernorVaa struct {
+	ID             string `json:"id"`
+	EmitterChain   uint16 `json:"emitterChain"`
+	EmitterAddress string `json:"emitterAddress"`
+	Sequence       string `json:"sequence"`
+	TxHash         string `json:"txHash"`
+	Amount         uint64 `json:"amount"`
+	// QuorumReleaseTime is the time at which a quorum of guardians releases the vaa,
+	// nil when less than a quorum of guardians hold the vaa.
+	QuorumReleaseTime *time.Time `json:"quorumReleaseTime,omitempty"`
+	Timestamp         time.Time  `json:"timestamp"`
+}
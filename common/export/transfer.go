@@ -0,0 +1,112 @@
+// Package export defines the record formats shared by the bulk exports of the explorer.
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// TransferRecord is a token transfer in the transfer report column set.
+type TransferRecord struct {
+	VaaID               string      `bson:"vaaId" json:"vaaId"`
+	VaaHash             string      `bson:"vaaHash" json:"vaaHash"`
+	SourceChain         sdk.ChainID `bson:"sourceChain" json:"sourceChain"`
+	EmitterAddress      string      `bson:"emitterAddress" json:"emitterAddress"`
+	Sequence            string      `bson:"sequence" json:"sequence"`
+	Timestamp           time.Time   `bson:"timestamp" json:"timestamp"`
+	SourceTxHash        string      `bson:"sourceTxHash" json:"sourceTxHash"`
+	SourceSenderAddress string      `bson:"sourceSenderAddress" json:"sourceSenderAddress"`
+	DestinationChain    sdk.ChainID `bson:"destinationChain" json:"destinationChain"`
+	DestinationAddress  string      `bson:"destinationAddress" json:"destinationAddress"`
+	DestinationTxHash   string      `bson:"destinationTxHash" json:"destinationTxHash"`
+	PortalPayloadType   int         `bson:"portalPayloadType" json:"portalPayloadType"`
+	AppIDs              []string    `bson:"appIds" json:"appIds"`
+	TokenChain          sdk.ChainID `bson:"tokenChain" json:"tokenChain"`
+	TokenAddress        string      `bson:"tokenAddress" json:"tokenAddress"`
+	Amount              string      `bson:"amount" json:"amount"`
+	Decimals            string      `bson:"decimals" json:"decimals"`
+	NotionalUSD         string      `bson:"notionalUSD" json:"notionalUSD"`
+	Fee                 string      `bson:"fee" json:"fee"`
+	CoingeckoID         string      `bson:"coinGeckoId" json:"coinGeckoId"`
+	Symbol              string      `bson:"symbol" json:"symbol"`
+}
+
+// TransferHeader returns the column names of the transfer report.
+func TransferHeader() []string {
+	return []string{
+		"vaaId",
+		"vaaHash",
+		"sourceChain",
+		"emitterAddress",
+		"sequence",
+		"timestamp",
+		"sourceTxHash",
+		"sourceSenderAddress",
+		"destinationChain",
+		"destinationAddress",
+		"destinationTxHash",
+		"portalPayloadType",
+		"appIds",
+		"tokenChain",
+		"tokenAddress",
+		"amount",
+		"decimals",
+		"notionalUSD",
+		"fee",
+		"coinGeckoId",
+		"symbol",
+	}
+}
+
+// Values returns the column values of the transfer, in the same order as TransferHeader.
+func (r *TransferRecord) Values() []string {
+	return []string{
+		r.VaaID,
+		r.VaaHash,
+		ChainIDValue(r.SourceChain),
+		r.EmitterAddress,
+		r.Sequence,
+		r.Timestamp.Format(time.RFC3339),
+		r.SourceTxHash,
+		r.SourceSenderAddress,
+		ChainIDValue(r.DestinationChain),
+		r.DestinationAddress,
+		r.DestinationTxHash,
+		portalPayloadTypeValue(r.PortalPayloadType),
+		AppIDsValue(r.AppIDs),
+		ChainIDValue(r.TokenChain),
+		r.TokenAddress,
+		r.Amount,
+		r.Decimals,
+		r.NotionalUSD,
+		r.Fee,
+		r.CoingeckoID,
+		r.Symbol,
+	}
+}
+
+// ChainIDValue returns the column value of a chain id, empty if the chain is not set.
+func ChainIDValue(chainID sdk.ChainID) string {
+	if chainID == sdk.ChainIDUnset {
+		return ""
+	}
+	return fmt.Sprintf("%d", int16(chainID))
+}
+
+// AppIDsValue returns the column value of a list of app ids.
+func AppIDsValue(appIDs []string) string {
+	if len(appIDs) == 0 {
+		return ""
+	}
+	return strings.Join(appIDs, "|")
+}
+
+func portalPayloadTypeValue(portalPayloadType int) string {
+	if portalPayloadType == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d", portalPayloadType)
+}
@@ -51,3 +51,106 @@ func (n *PricesApi) GetPriceByTime(ctx context.Context, coingeckoID string, date
 
 	return decimal.NewFromString(result.Price)
 }
+
+// PriceRequest is a lookup of a coingecko token price at a datetime.
+type PriceRequest struct {
+	CoingeckoID string    `json:"coingeckoId"`
+	DateTime    time.Time `json:"dateTime"`
+}
+
+// PriceResult is the result of a price lookup inside a batch.
+type PriceResult struct {
+	CoingeckoID       string    `json:"coingeckoId"`
+	Symbol            string    `json:"symbol"`
+	RequestedDateTime time.Time `json:"requestedDateTime"`
+	Price             string    `json:"price"`
+	DateTime          time.Time `json:"dateTime"`
+	Error             string    `json:"error"`
+}
+
+type getPricesRequest struct {
+	Queries []PriceRequest `json:"queries"`
+}
+
+type getPricesResponse struct {
+	Prices []PriceResult `json:"prices"`
+}
+
+// GetPrices returns the prices of many tokens at many datetimes in a single request.
+// Results are returned in the same order as the requests.
+func (n *PricesApi) GetPrices(ctx context.Context, requests []PriceRequest) ([]PriceResult, error) {
+	resp, err := n.client.R().
+		SetContext(ctx).
+		SetBody(getPricesRequest{Queries: requests}).
+		SetResult(&getPricesResponse{}).
+		Post("/api/prices/batch")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	result := resp.Result().(*getPricesResponse)
+	if result == nil || len(result.Prices) != len(requests) {
+		return nil, fmt.Errorf("unexpected response size")
+	}
+
+	return result.Prices, nil
+}
+
+// PricePoint is a point of a price series.
+type PricePoint struct {
+	DateTime       time.Time `json:"dateTime"`
+	Price          string    `json:"price"`
+	SourceDateTime time.Time `json:"sourceDateTime"`
+}
+
+// GetPriceRange returns the price series of a token between from and to. interval must be 1h or 1d.
+func (n *PricesApi) GetPriceRange(ctx context.Context, coingeckoID string, from, to time.Time, interval string) ([]PricePoint, error) {
+	var result []PricePoint
+	err := n.getSeries(ctx, coingeckoID, "range", from, to, interval, &result)
+	return result, err
+}
+
+// OHLC is the open, high, low and close price of a token in an interval.
+type OHLC struct {
+	DateTime time.Time `json:"dateTime"`
+	Open     string    `json:"open"`
+	High     string    `json:"high"`
+	Low      string    `json:"low"`
+	Close    string    `json:"close"`
+	Samples  int       `json:"samples"`
+}
+
+// GetOHLC returns the open, high, low and close price of a token between from and to. interval must be 1d, 1w or 1M.
+func (n *PricesApi) GetOHLC(ctx context.Context, coingeckoID string, from, to time.Time, interval string) ([]OHLC, error) {
+	var result []OHLC
+	err := n.getSeries(ctx, coingeckoID, "ohlc", from, to, interval, &result)
+	return result, err
+}
+
+func (n *PricesApi) getSeries(ctx context.Context, coingeckoID, resource string, from, to time.Time, interval string, result any) error {
+	url := fmt.Sprintf("/api/coingecko/prices/%s/%s", coingeckoID, resource)
+	resp, err := n.client.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"from":     from.UTC().Format(time.RFC3339),
+			"to":       to.UTC().Format(time.RFC3339),
+			"interval": interval,
+		}).
+		SetResult(result).
+		Get(url)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// recentPriceAge is the age under which a price is cached by its exact datetime
+// instead of by day, because the notional service may answer it with the current price.
+const recentPriceAge = 48 * time.Hour
+
+// priceExpiration is how long a resolved price is kept in memory.
+const priceExpiration = time.Hour
+
+// batchPricesAPI is the subset of PricesApi used by BatchPricesClient.
+type batchPricesAPI interface {
+	GetPrices(ctx context.Context, requests []PriceRequest) ([]PriceResult, error)
+}
+
+type pendingPrice struct {
+	done  chan struct{}
+	price decimal.Decimal
+	err   error
+	// expiresAt is set, with the lock held, once the price is resolved.
+	expiresAt time.Time
+}
+
+// BatchPricesClient resolves token prices through the notional batch endpoint.
+// Concurrent calls to GetPriceByTime are coalesced into a single request, either when
+// the batch window elapses or when the batch is full, and the prices found are cached in memory
+// until they expire. The prices that failed are requested again.
+type BatchPricesClient struct {
+	api        batchPricesAPI
+	window     time.Duration
+	maxBatch   int
+	timeout    time.Duration
+	expiration time.Duration
+	log        *zap.Logger
+
+	mu          sync.Mutex
+	prices      map[string]*pendingPrice
+	queue       []PriceRequest
+	queued      []*pendingPrice
+	timerOn     bool
+	lastCleanup time.Time
+}
+
+// NewBatchPricesClient creates a new batch prices client.
+func NewBatchPricesClient(url string, window time.Duration, maxBatch int, log *zap.Logger) *BatchPricesClient {
+	return newBatchPricesClient(NewPricesApi(url, log), window, maxBatch, log)
+}
+
+func newBatchPricesClient(api batchPricesAPI, window time.Duration, maxBatch int, log *zap.Logger) *BatchPricesClient {
+	if maxBatch <= 0 {
+		maxBatch = 500
+	}
+	return &BatchPricesClient{
+		api:        api,
+		window:     window,
+		maxBatch:   maxBatch,
+		timeout:    30 * time.Second,
+		expiration: priceExpiration,
+		log:        log,
+		prices:     make(map[string]*pendingPrice),
+	}
+}
+
+// GetPriceByTime returns the price of a token at a datetime.
+func (c *BatchPricesClient) GetPriceByTime(ctx context.Context, coingeckoID string, dateTime time.Time) (decimal.Decimal, error) {
+	p := c.enqueue(PriceRequest{CoingeckoID: coingeckoID, DateTime: dateTime})
+	select {
+	case <-p.done:
+		return p.price, p.err
+	case <-ctx.Done():
+		return decimal.Zero, ctx.Err()
+	}
+}
+
+// Prefetch resolves the given prices in batches and keeps them in the cache, so
+// later calls to GetPriceByTime do not need a request.
+func (c *BatchPricesClient) Prefetch(ctx context.Context, requests []PriceRequest) error {
+	pendings := make([]*pendingPrice, 0, len(requests))
+	for _, r := range requests {
+		pendings = append(pendings, c.enqueue(r))
+	}
+	c.flushQueued()
+	for _, p := range pendings {
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (c *BatchPricesClient) enqueue(r PriceRequest) *pendingPrice {
+	key := priceKey(r.CoingeckoID, r.DateTime)
+
+	c.mu.Lock()
+	now := time.Now()
+	c.cleanup(now)
+	if p, ok := c.prices[key]; ok && !p.expired(now) {
+		c.mu.Unlock()
+		return p
+	}
+
+	p := &pendingPrice{done: make(chan struct{})}
+	c.prices[key] = p
+	c.queue = append(c.queue, r)
+	c.queued = append(c.queued, p)
+
+	if len(c.queue) >= c.maxBatch {
+		requests, pendings := c.takeQueue()
+		c.mu.Unlock()
+		go c.resolve(requests, pendings)
+		return p
+	}
+
+	if !c.timerOn {
+		c.timerOn = true
+		time.AfterFunc(c.window, c.flushQueued)
+	}
+	c.mu.Unlock()
+	return p
+}
+
+func (c *BatchPricesClient) flushQueued() {
+	c.mu.Lock()
+	requests, pendings := c.takeQueue()
+	c.mu.Unlock()
+	if len(requests) > 0 {
+		c.resolve(requests, pendings)
+	}
+}
+
+// takeQueue must be called with the lock held.
+func (c *BatchPricesClient) takeQueue() ([]PriceRequest, []*pendingPrice) {
+	requests, pendings := c.queue, c.queued
+	c.queue, c.queued = nil, nil
+	c.timerOn = false
+	return requests, pendings
+}
+
+func (c *BatchPricesClient) resolve(requests []PriceRequest, pendings []*pendingPrice) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	results, err := c.api.GetPrices(ctx, requests)
+	if err != nil {
+		c.log.Error("failed to get prices", zap.Int("requests", len(requests)), zap.Error(err))
+		// forget the failed prices so that they can be requested again.
+		c.mu.Lock()
+		for _, r := range requests {
+			delete(c.prices, priceKey(r.CoingeckoID, r.DateTime))
+		}
+		c.mu.Unlock()
+		for _, p := range pendings {
+			p.err = err
+			close(p.done)
+		}
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	for i, p := range pendings {
+		r := results[i]
+		if r.Error != "" {
+			p.err = fmt.Errorf("price not found for %s at %s: %w", requests[i].CoingeckoID,
+				requests[i].DateTime.UTC().Format(time.RFC3339), errors.New(r.Error))
+		} else {
+			p.price, p.err = decimal.NewFromString(r.Price)
+		}
+		// forget the prices not found so that they can be requested again.
+		key := priceKey(requests[i].CoingeckoID, requests[i].DateTime)
+		if p.err != nil && c.prices[key] == p {
+			delete(c.prices, key)
+		}
+		p.expiresAt = now.Add(c.expiration)
+	}
+	c.mu.Unlock()
+	for _, p := range pendings {
+		close(p.done)
+	}
+}
+
+// expired returns whether a resolved price expired. It must be called with the lock held.
+func (p *pendingPrice) expired(now time.Time) bool {
+	return !p.expiresAt.IsZero() && !now.Before(p.expiresAt)
+}
+
+// cleanup removes the expired prices once a minute. It must be called with the lock held.
+func (c *BatchPricesClient) cleanup(now time.Time) {
+	if now.Sub(c.lastCleanup) < time.Minute {
+		return
+	}
+	c.lastCleanup = now
+	for key, p := range c.prices {
+		if p.expired(now) {
+			delete(c.prices, key)
+		}
+	}
+}
+
+func priceKey(coingeckoID string, dateTime time.Time) string {
+	if time.Since(dateTime) > recentPriceAge {
+		dateTime = dateTime.Truncate(24 * time.Hour)
+	}
+	return fmt.Sprintf("%s-%d", coingeckoID, dateTime.UnixMilli())
+}
@@ -0,0 +1,105 @@
+package prices
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type fakePricesAPI struct {
+	calls atomic.Int32
+}
+
+func (f *fakePricesAPI) GetPrices(_ context.Context, requests []PriceRequest) ([]PriceResult, error) {
+	f.calls.Add(1)
+	results := make([]PriceResult, 0, len(requests))
+	for _, r := range requests {
+		if r.CoingeckoID == "unknown" {
+			results = append(results, PriceResult{CoingeckoID: r.CoingeckoID, Error: "token not found"})
+			continue
+		}
+		results = append(results, PriceResult{CoingeckoID: r.CoingeckoID, Price: "1.25"})
+	}
+	return results, nil
+}
+
+func TestBatchPricesClient_CoalescesConcurrentRequests(t *testing.T) {
+	api := &fakePricesAPI{}
+	client := newBatchPricesClient(api, 20*time.Millisecond, 100, zap.NewNop())
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			price, err := client.GetPriceByTime(context.Background(), "ethereum", day.Add(time.Duration(i)*time.Hour))
+			assert.NoError(t, err)
+			assert.Equal(t, "1.25", price.String())
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), api.calls.Load())
+
+	// same day is served from the cache.
+	_, err := client.GetPriceByTime(context.Background(), "ethereum", day.Add(12*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), api.calls.Load())
+}
+
+func TestBatchPricesClient_Prefetch(t *testing.T) {
+	api := &fakePricesAPI{}
+	client := newBatchPricesClient(api, time.Hour, 2, zap.NewNop())
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := client.Prefetch(context.Background(), []PriceRequest{
+		{CoingeckoID: "ethereum", DateTime: day},
+		{CoingeckoID: "solana", DateTime: day},
+		{CoingeckoID: "unknown", DateTime: day},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), api.calls.Load())
+
+	price, err := client.GetPriceByTime(context.Background(), "solana", day)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.25", price.String())
+	assert.Equal(t, int32(2), api.calls.Load())
+}
+
+func TestBatchPricesClient_DoesNotCacheErrors(t *testing.T) {
+	api := &fakePricesAPI{}
+	client := newBatchPricesClient(api, time.Millisecond, 100, zap.NewNop())
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.GetPriceByTime(context.Background(), "unknown", day)
+	assert.Error(t, err)
+	_, err = client.GetPriceByTime(context.Background(), "unknown", day)
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), api.calls.Load())
+	assert.Empty(t, client.prices)
+}
+
+func TestBatchPricesClient_ExpiresPrices(t *testing.T) {
+	api := &fakePricesAPI{}
+	client := newBatchPricesClient(api, time.Millisecond, 100, zap.NewNop())
+	client.expiration = 0
+	day := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := client.GetPriceByTime(context.Background(), "ethereum", day)
+	assert.NoError(t, err)
+	_, err = client.GetPriceByTime(context.Background(), "ethereum", day)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), api.calls.Load())
+
+	// the expired prices are removed by the cleanup.
+	client.lastCleanup = time.Time{}
+	client.mu.Lock()
+	client.cleanup(time.Now())
+	client.mu.Unlock()
+	assert.Empty(t, client.prices)
+}
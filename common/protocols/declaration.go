@@ -0,0 +1,215 @@
+// Package protocols declares the external protocols whose stats are written by the protocols stats job
+// and served by the api, so both read the same declarations.
+package protocols
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the protocols with a built-in declaration.
+const (
+	MayanProtocol        = "mayan"
+	AllBridgeProtocol    = "allbridge"
+	MagpieProtocol       = "magpie"
+	FolksFinanceProtocol = "folks_finance"
+)
+
+// Fields of the stats and activities that can be mapped from the json responses of the protocols.
+const (
+	FieldTotalValueLocked      = "total_value_locked"
+	FieldTotalValueSecure      = "total_value_secure"
+	FieldTotalValueTransferred = "total_value_transferred"
+	FieldTotalMessages         = "total_messages"
+	FieldVolume                = "volume"
+	FieldEmitterChainID        = "emitter_chain_id"
+	FieldDestinationChainID    = "destination_chain_id"
+	FieldTxs                   = "txs"
+	FieldTotalUSD              = "total_usd"
+)
+
+// Formats of the time range query params of the activity requests.
+const (
+	TimeFormatRFC3339 = "rfc3339"
+	TimeFormatUnix    = "unix"
+)
+
+// HttpJsonSource is an endpoint of the api of a protocol whose json response is mapped to the stats or the activity
+// of the protocol.
+type HttpJsonSource struct {
+	// Path is appended to the base url of the protocol.
+	Path string `json:"path"`
+	// Query are static query params sent on every request.
+	Query map[string]string `json:"query,omitempty"`
+	// FromParam and ToParam are the query params of the time range of the activity requests.
+	FromParam  string `json:"from_param,omitempty"`
+	ToParam    string `json:"to_param,omitempty"`
+	TimeFormat string `json:"time_format,omitempty"`
+	// Fields maps the fields of the stats or the activity to dot-separated paths of the response, e.g. "allTime.volume".
+	Fields map[string]string `json:"fields"`
+	// ActivitiesPath is the path of the array of activities by chain pair, whose items are mapped by ActivityFields.
+	ActivitiesPath string            `json:"activities_path,omitempty"`
+	ActivityFields map[string]string `json:"activity_fields,omitempty"`
+}
+
+// FormatTime formats a bound of the time range of the activity requests.
+func (s *HttpJsonSource) FormatTime(t time.Time) string {
+	if s.TimeFormat == TimeFormatUnix {
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Declaration declares a protocol whose stats are read from its api.
+// The protocols without stats or activity sources are read by a built-in client of the protocols stats job.
+type Declaration struct {
+	// Name is the protocol tag written by the protocols stats job, and the name of the protocol in the api response.
+	Name string `json:"name"`
+	// AppID is the app id of the protocol in the vaas.
+	AppID string `json:"app_id,omitempty"`
+	// Url is the base url of the api of the protocol.
+	Url      string          `json:"url"`
+	Stats    *HttpJsonSource `json:"stats,omitempty"`
+	Activity *HttpJsonSource `json:"activity,omitempty"`
+	// ExternalTVL and ExternalActivity declare that a built-in client writes the total value locked and the activity.
+	ExternalTVL      bool `json:"external_tvl,omitempty"`
+	ExternalActivity bool `json:"external_activity,omitempty"`
+}
+
+// HasTVL returns whether the protocols stats job writes the total value locked of the protocol.
+func (d Declaration) HasTVL() bool {
+	if d.ExternalTVL {
+		return true
+	}
+	return d.Stats != nil && d.Stats.Fields[FieldTotalValueLocked] != ""
+}
+
+// HasActivity returns whether the protocols stats job writes the activity of the protocol.
+func (d Declaration) HasActivity() bool {
+	return d.ExternalActivity || d.Activity != nil
+}
+
+// Mayan is read by the built-in mayan client.
+var Mayan = Declaration{
+	Name:        MayanProtocol,
+	AppID:       "MAYAN",
+	ExternalTVL: true,
+}
+
+// AllBridge is read by the built-in allbridge client.
+var AllBridge = Declaration{
+	Name:             AllBridgeProtocol,
+	AppID:            "ALLBRIDGE",
+	ExternalActivity: true,
+}
+
+// Magpie reads the stats of the Magpie cross-chain swaps settled through wormhole.
+var Magpie = Declaration{
+	Name:  MagpieProtocol,
+	AppID: "MAGPIE",
+	Stats: &HttpJsonSource{
+		Path: "/aggregator/stats/wormhole",
+		Fields: map[string]string{
+			FieldTotalMessages: "data.totalSwaps",
+			FieldVolume:        "data.totalVolumeUsd",
+		},
+	},
+	Activity: &HttpJsonSource{
+		Path:       "/aggregator/stats/wormhole/activity",
+		FromParam:  "fromTimestamp",
+		ToParam:    "toTimestamp",
+		TimeFormat: TimeFormatUnix,
+		Fields: map[string]string{
+			FieldTotalValueTransferred: "data.volumeUsd",
+			FieldTotalMessages:         "data.swaps",
+		},
+		ActivitiesPath: "data.routes",
+		ActivityFields: map[string]string{
+			FieldEmitterChainID:     "fromWormholeChainId",
+			FieldDestinationChainID: "toWormholeChainId",
+			FieldTxs:                "swaps",
+			FieldTotalUSD:           "volumeUsd",
+		},
+	},
+}
+
+// FolksFinance reads the stats of the Folks Finance cross-chain lending messages sent through wormhole.
+var FolksFinance = Declaration{
+	Name:  FolksFinanceProtocol,
+	AppID: "FOLKS_FINANCE",
+	Stats: &HttpJsonSource{
+		Path:  "/v1/xchain/stats",
+		Query: map[string]string{"adapter": "wormhole"},
+		Fields: map[string]string{
+			FieldTotalValueLocked: "tvl",
+			FieldTotalMessages:    "messages.total",
+			FieldVolume:           "volume.total",
+		},
+	},
+	Activity: &HttpJsonSource{
+		Path:      "/v1/xchain/activity",
+		Query:     map[string]string{"adapter": "wormhole"},
+		FromParam: "from",
+		ToParam:   "to",
+		Fields: map[string]string{
+			FieldTotalValueSecure:      "tvl",
+			FieldTotalValueTransferred: "volume",
+			FieldTotalMessages:         "messages",
+		},
+		ActivitiesPath: "chains",
+		ActivityFields: map[string]string{
+			FieldEmitterChainID:     "source",
+			FieldDestinationChainID: "destination",
+			FieldTxs:                "messages",
+			FieldTotalUSD:           "volume",
+		},
+	},
+}
+
+var builtins = map[string]Declaration{
+	MayanProtocol:        Mayan,
+	AllBridgeProtocol:    AllBridge,
+	MagpieProtocol:       Magpie,
+	FolksFinanceProtocol: FolksFinance,
+}
+
+// Parse parses a json array of declarations, as set in PROTOCOLS_JSON.
+// A declaration with only the name and the url of a protocol with a built-in declaration uses the built-in
+// app id and sources. An empty value declares no protocols.
+func Parse(value string) ([]Declaration, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var declarations []Declaration
+	if err := json.Unmarshal([]byte(value), &declarations); err != nil {
+		return nil, fmt.Errorf("invalid protocols declarations: %w", err)
+	}
+	for i, d := range declarations {
+		if d.Name == "" {
+			return nil, fmt.Errorf("invalid protocols declarations: protocol %d has no name", i)
+		}
+		builtin, ok := builtins[d.Name]
+		if !ok {
+			if d.AppID == "" {
+				return nil, fmt.Errorf("invalid protocols declarations: protocol %s has no app id", d.Name)
+			}
+			if d.Stats == nil && d.Activity == nil {
+				return nil, fmt.Errorf("invalid protocols declarations: protocol %s has no stats or activity source", d.Name)
+			}
+			continue
+		}
+		if d.AppID == "" {
+			d.AppID = builtin.AppID
+		}
+		if d.Stats == nil && d.Activity == nil {
+			d.Stats, d.Activity = builtin.Stats, builtin.Activity
+			d.ExternalTVL = d.ExternalTVL || builtin.ExternalTVL
+			d.ExternalActivity = d.ExternalActivity || builtin.ExternalActivity
+		}
+		declarations[i] = d
+	}
+	return declarations, nil
+}
@@ -0,0 +1,50 @@
+package protocols
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	declarations, err := Parse(`[
+		{"name":"mayan","url":"https://explorer-api.mayan.finance"},
+		{"name":"folks_finance","url":"https://api.folks.finance"},
+		{"name":"bridge","app_id":"BRIDGE","url":"https://api.bridge.example","activity":{"path":"/activity","fields":{"total_messages":"messages"}}}
+	]`)
+	require.NoError(t, err)
+	require.Len(t, declarations, 3)
+
+	// the built-in client of mayan writes the total value locked.
+	assert.Equal(t, "MAYAN", declarations[0].AppID)
+	assert.Equal(t, "https://explorer-api.mayan.finance", declarations[0].Url)
+	assert.Nil(t, declarations[0].Stats)
+	assert.True(t, declarations[0].HasTVL())
+	assert.False(t, declarations[0].HasActivity())
+
+	// folks finance uses the built-in sources.
+	assert.Equal(t, "FOLKS_FINANCE", declarations[1].AppID)
+	assert.Equal(t, FolksFinance.Stats, declarations[1].Stats)
+	assert.True(t, declarations[1].HasTVL())
+	assert.True(t, declarations[1].HasActivity())
+
+	// a new integrator is declared by its sources.
+	assert.Equal(t, "BRIDGE", declarations[2].AppID)
+	assert.False(t, declarations[2].HasTVL())
+	assert.True(t, declarations[2].HasActivity())
+}
+
+func TestParse_Empty(t *testing.T) {
+	declarations, err := Parse("")
+	assert.NoError(t, err)
+	assert.Empty(t, declarations)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(`[{"name":"bridge","url":"https://api.bridge.example"}]`)
+	assert.EqualError(t, err, "invalid protocols declarations: protocol bridge has no app id")
+
+	_, err = Parse(`[{"name":"bridge","app_id":"BRIDGE","url":"https://api.bridge.example"}]`)
+	assert.EqualError(t, err, "invalid protocols declarations: protocol bridge has no stats or activity source")
+}
@@ -0,0 +1,132 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Queue backend types.
+const (
+	TypeSQS    = "sqs"
+	TypeKafka  = "kafka"
+	TypeNats   = "nats"
+	TypeRedis  = "redis"
+	TypeMemory = "memory"
+)
+
+// ErrSQSBackend is returned by the factory functions for the SQS backend,
+// which is created by each service with its AWS configuration.
+var ErrSQSBackend = errors.New("sqs queues are created with the aws configuration")
+
+// Config is the configuration of the queue backend.
+// It has the tags of both sethvargo/go-envconfig and kelseyhightower/envconfig, used by the services.
+type Config struct {
+	// QueueType is the backend of the queues: sqs, kafka, nats, redis or memory.
+	QueueType string `env:"QUEUE_TYPE,default=sqs" envconfig:"QUEUE_TYPE" default:"sqs"`
+	// QueueGroup is the consumer group of the service, required by kafka, nats and redis.
+	QueueGroup string `env:"QUEUE_GROUP" envconfig:"QUEUE_GROUP"`
+	// QueueAckDeadlineSeconds is the time to acknowledge a message before it is delivered again.
+	QueueAckDeadlineSeconds int `env:"QUEUE_ACK_DEADLINE_SECONDS,default=120" envconfig:"QUEUE_ACK_DEADLINE_SECONDS" default:"120"`
+	// QueueMaxDeliveries is the number of deliveries of a failed message before it is moved to the dead-letter queue.
+	QueueMaxDeliveries int `env:"QUEUE_MAX_DELIVERIES,default=5" envconfig:"QUEUE_MAX_DELIVERIES" default:"5"`
+	// QueueMaxMessages is the maximum number of messages received in a batch.
+	QueueMaxMessages  int    `env:"QUEUE_MAX_MESSAGES,default=10" envconfig:"QUEUE_MAX_MESSAGES" default:"10"`
+	QueueKafkaBrokers string `env:"QUEUE_KAFKA_BROKERS" envconfig:"QUEUE_KAFKA_BROKERS"`
+	QueueNatsURL      string `env:"QUEUE_NATS_URL" envconfig:"QUEUE_NATS_URL"`
+	QueueRedisURL     string `env:"QUEUE_REDIS_URL" envconfig:"QUEUE_REDIS_URL"`
+	// QueueRedisMaxLen is the approximate maximum number of entries of a redis stream.
+	QueueRedisMaxLen int64 `env:"QUEUE_REDIS_MAX_LEN,default=1000000" envconfig:"QUEUE_REDIS_MAX_LEN" default:"1000000"`
+}
+
+// IsSQS checks if the queue backend is SQS.
+func (c *Config) IsSQS() bool {
+	return c.QueueType == "" || strings.EqualFold(c.QueueType, TypeSQS)
+}
+
+// AckDeadline returns the ack deadline of the messages.
+func (c *Config) AckDeadline() time.Duration {
+	return time.Duration(c.QueueAckDeadlineSeconds) * time.Second
+}
+
+// NewReceiver creates a receiver of the queue with the name, which is the topic, subject or stream of the backend.
+func NewReceiver(ctx context.Context, cfg *Config, name string) (Receiver, error) {
+	switch strings.ToLower(cfg.QueueType) {
+	case TypeKafka:
+		if cfg.QueueKafkaBrokers == "" || cfg.QueueGroup == "" {
+			return nil, errors.New("QUEUE_KAFKA_BROKERS and QUEUE_GROUP are required")
+		}
+		return NewKafkaReceiver(strings.Split(cfg.QueueKafkaBrokers, ","), name, cfg.QueueGroup,
+			cfg.QueueMaxMessages, cfg.QueueMaxDeliveries, cfg.AckDeadline()), nil
+	case TypeNats:
+		if cfg.QueueNatsURL == "" || cfg.QueueGroup == "" {
+			return nil, errors.New("QUEUE_NATS_URL and QUEUE_GROUP are required")
+		}
+		return NewNatsReceiver(cfg.QueueNatsURL, name, cfg.QueueGroup,
+			cfg.QueueMaxMessages, cfg.QueueMaxDeliveries, cfg.AckDeadline())
+	case TypeRedis:
+		if cfg.QueueRedisURL == "" || cfg.QueueGroup == "" {
+			return nil, errors.New("QUEUE_REDIS_URL and QUEUE_GROUP are required")
+		}
+		client, err := newRedisClient(cfg.QueueRedisURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisReceiver(ctx, client, name, cfg.QueueGroup,
+			cfg.QueueMaxMessages, cfg.QueueMaxDeliveries, cfg.AckDeadline())
+	case TypeMemory:
+		return GetMemory(name, cfg.QueueGroup, cfg.QueueMaxMessages*10, cfg.QueueMaxMessages, cfg.QueueMaxDeliveries, cfg.AckDeadline()), nil
+	case "", TypeSQS:
+		return nil, ErrSQSBackend
+	default:
+		return nil, fmt.Errorf("unknown queue type %s", cfg.QueueType)
+	}
+}
+
+// NewSender creates a sender of the queue with the name, which is the topic, subject or stream of the backend.
+func NewSender(ctx context.Context, cfg *Config, name string) (Sender, error) {
+	switch strings.ToLower(cfg.QueueType) {
+	case TypeKafka:
+		if cfg.QueueKafkaBrokers == "" {
+			return nil, errors.New("QUEUE_KAFKA_BROKERS is required")
+		}
+		return NewKafkaSender(strings.Split(cfg.QueueKafkaBrokers, ","), name), nil
+	case TypeNats:
+		if cfg.QueueNatsURL == "" {
+			return nil, errors.New("QUEUE_NATS_URL is required")
+		}
+		return NewNatsSender(cfg.QueueNatsURL, name)
+	case TypeRedis:
+		if cfg.QueueRedisURL == "" {
+			return nil, errors.New("QUEUE_REDIS_URL is required")
+		}
+		client, err := newRedisClient(cfg.QueueRedisURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisSender(client, name, cfg.QueueRedisMaxLen), nil
+	case TypeMemory:
+		return NewMemorySender(name), nil
+	case "", TypeSQS:
+		return nil, ErrSQSBackend
+	default:
+		return nil, fmt.Errorf("unknown queue type %s", cfg.QueueType)
+	}
+}
+
+// deadLetterName returns the name of the dead-letter queue of a queue.
+func deadLetterName(name string) string {
+	return name + "-dlq"
+}
+
+func newRedisClient(url string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(opts), nil
+}
@@ -0,0 +1,204 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HookFunc is a function called with the data and the retry of a message.
+type HookFunc[T any] func(data *T, retry uint8)
+
+// ConsumerOption represents a consumer option function.
+type ConsumerOption[T any] func(*Consumer[T])
+
+// Consumer converts the messages of a receiver and sends them to a channel.
+type Consumer[T any] struct {
+	receiver     Receiver
+	converter    ConverterFunc[T]
+	filter       FilterFunc[T]
+	onReceived   HookFunc[T]
+	onDone       HookFunc[T]
+	onFailed     HookFunc[T]
+	ch           chan Message[T]
+	chSize       int
+	errorBackoff time.Duration
+	wg           sync.WaitGroup
+	logger       *zap.Logger
+}
+
+// NewConsumer creates a consumer of the messages of a receiver.
+func NewConsumer[T any](receiver Receiver, converter ConverterFunc[T], logger *zap.Logger, opts ...ConsumerOption[T]) *Consumer[T] {
+	c := &Consumer[T]{
+		receiver:     receiver,
+		converter:    converter,
+		filter:       func(*T) bool { return false },
+		onReceived:   func(*T, uint8) {},
+		onDone:       func(*T, uint8) {},
+		onFailed:     func(*T, uint8) {},
+		chSize:       10,
+		errorBackoff: time.Second,
+		logger:       logger.With(zap.String("queue", receiver.Name())),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.ch = make(chan Message[T], c.chSize)
+	return c
+}
+
+// WithChannelSize allows to specify an channel size when setting a value.
+func WithChannelSize[T any](size int) ConsumerOption[T] {
+	return func(c *Consumer[T]) {
+		c.chSize = size
+	}
+}
+
+// WithFilter allows to discard the messages for which the filter returns true.
+func WithFilter[T any](filter FilterFunc[T]) ConsumerOption[T] {
+	return func(c *Consumer[T]) {
+		c.filter = filter
+	}
+}
+
+// WithOnReceived allows to specify a hook called when a message is converted, before it is filtered.
+func WithOnReceived[T any](hook HookFunc[T]) ConsumerOption[T] {
+	return func(c *Consumer[T]) {
+		c.onReceived = hook
+	}
+}
+
+// WithOnDone allows to specify a hook called when a message is done.
+func WithOnDone[T any](hook HookFunc[T]) ConsumerOption[T] {
+	return func(c *Consumer[T]) {
+		c.onDone = hook
+	}
+}
+
+// WithOnFailed allows to specify a hook called when a message is failed.
+func WithOnFailed[T any](hook HookFunc[T]) ConsumerOption[T] {
+	return func(c *Consumer[T]) {
+		c.onFailed = hook
+	}
+}
+
+// Consume returns the channel with the received messages.
+// The next batch of messages is received when all the messages of the current batch are done or failed.
+func (c *Consumer[T]) Consume(ctx context.Context) <-chan Message[T] {
+	go func() {
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+
+			deliveries, err := c.receiver.Receive(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error("Error getting messages from queue", zap.Error(err))
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.errorBackoff):
+				}
+				continue
+			}
+			c.logger.Debug("Received messages from queue", zap.Int("count", len(deliveries)))
+			expiredAt := time.Now().Add(c.receiver.AckDeadline())
+			for i := range deliveries {
+				delivery := deliveries[i]
+
+				data, err := c.converter(delivery.Body)
+				if err != nil {
+					c.logger.Error("Error converting message", zap.Error(err), zap.String("id", delivery.ID))
+					c.ack(ctx, &delivery)
+					continue
+				}
+				if data == nil {
+					c.logger.Warn("Can not handle message", zap.String("id", delivery.ID))
+					c.ack(ctx, &delivery)
+					continue
+				}
+				c.onReceived(data, delivery.Retry)
+				if c.filter(data) {
+					c.ack(ctx, &delivery)
+					continue
+				}
+
+				c.wg.Add(1)
+				c.ch <- &consumerMessage[T]{
+					data:      data,
+					delivery:  delivery,
+					onDone:    c.onDone,
+					onFailed:  c.onFailed,
+					wg:        &c.wg,
+					logger:    c.logger,
+					expiredAt: expiredAt,
+					ctx:       ctx,
+				}
+			}
+			c.wg.Wait()
+		}
+	}()
+	return c.ch
+}
+
+// Close closes all consumer resources.
+func (c *Consumer[T]) Close() {
+	if err := c.receiver.Close(); err != nil {
+		c.logger.Error("Error closing queue receiver", zap.Error(err))
+	}
+	close(c.ch)
+}
+
+func (c *Consumer[T]) ack(ctx context.Context, delivery *Delivery) {
+	if err := delivery.Ack(ctx); err != nil {
+		c.logger.Error("Error deleting message from queue", zap.Error(err), zap.String("id", delivery.ID))
+	}
+}
+
+type consumerMessage[T any] struct {
+	data      *T
+	delivery  Delivery
+	onDone    HookFunc[T]
+	onFailed  HookFunc[T]
+	wg        *sync.WaitGroup
+	logger    *zap.Logger
+	expiredAt time.Time
+	ctx       context.Context
+}
+
+func (m *consumerMessage[T]) Data() *T {
+	return m.data
+}
+
+func (m *consumerMessage[T]) Done() {
+	if err := m.delivery.Ack(m.ctx); err != nil {
+		m.logger.Error("Error deleting message from queue", zap.Error(err), zap.String("id", m.delivery.ID))
+	}
+	m.onDone(m.data, m.delivery.Retry)
+	m.wg.Done()
+}
+
+func (m *consumerMessage[T]) Failed() {
+	if err := m.delivery.Nack(m.ctx); err != nil {
+		m.logger.Error("Error releasing message from queue", zap.Error(err), zap.String("id", m.delivery.ID))
+	}
+	m.onFailed(m.data, m.delivery.Retry)
+	m.wg.Done()
+}
+
+func (m *consumerMessage[T]) IsExpired() bool {
+	return m.expiredAt.Before(time.Now())
+}
+
+func (m *consumerMessage[T]) Retry() uint8 {
+	return m.delivery.Retry
+}
+
+func (m *consumerMessage[T]) SentTimestamp() *time.Time {
+	return m.delivery.SentTimestamp
+}
@@ -0,0 +1,157 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testEvent struct {
+	Value int
+}
+
+func testConverter(body string) (*testEvent, error) {
+	if body == "skip" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(body)
+	if err != nil {
+		return nil, errors.New("invalid body")
+	}
+	return &testEvent{Value: value}, nil
+}
+
+func receive(t *testing.T, ch <-chan Message[testEvent]) Message[testEvent] {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for a message")
+		return nil
+	}
+}
+
+func TestConsumer_ConvertsAndDiscardsMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	memory := NewMemory("test", 10, 10, 3, time.Minute)
+	for _, body := range []string{"invalid", "skip", "1", "2"} {
+		require.NoError(t, memory.Send(ctx, &OutgoingMessage{Body: body}))
+	}
+
+	filter := func(e *testEvent) bool { return e.Value == 1 }
+	consumer := NewConsumer[testEvent](memory, testConverter, zap.NewNop(), WithFilter[testEvent](filter))
+	ch := consumer.Consume(ctx)
+
+	msg := receive(t, ch)
+	assert.Equal(t, 2, msg.Data().Value)
+	assert.Equal(t, uint8(1), msg.Retry())
+	assert.NotNil(t, msg.SentTimestamp())
+	assert.False(t, msg.IsExpired())
+	msg.Done()
+}
+
+func TestConsumer_FailedMessagesAreDeliveredAgain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	memory := NewMemory("test", 10, 10, 2, time.Minute)
+	require.NoError(t, memory.Send(ctx, &OutgoingMessage{Body: "7"}))
+
+	consumer := NewConsumer[testEvent](memory, testConverter, zap.NewNop())
+	ch := consumer.Consume(ctx)
+
+	msg := receive(t, ch)
+	assert.Equal(t, uint8(1), msg.Retry())
+	msg.Failed()
+
+	msg = receive(t, ch)
+	assert.Equal(t, 7, msg.Data().Value)
+	assert.Equal(t, uint8(2), msg.Retry())
+	msg.Failed()
+
+	assert.Eventually(t, func() bool {
+		return len(memory.DeadLetters()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"7"}, memory.DeadLetters())
+}
+
+func TestConsumer_IsExpired(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	memory := NewMemory("test", 10, 10, 2, -time.Second)
+	require.NoError(t, memory.Send(ctx, &OutgoingMessage{Body: "1"}))
+
+	consumer := NewConsumer[testEvent](memory, testConverter, zap.NewNop())
+	msg := receive(t, consumer.Consume(ctx))
+	assert.True(t, msg.IsExpired())
+	msg.Done()
+}
+
+func kafkaMessage(partition int, offset int64) kafka.Message {
+	return kafka.Message{Partition: partition, Offset: offset}
+}
+
+func TestKafkaOffsets_CommitsInOrder(t *testing.T) {
+	offsets := newKafkaOffsets()
+	msgs := []struct {
+		partition int
+		offset    int64
+	}{{0, 10}, {0, 11}, {0, 12}, {1, 5}}
+	for _, m := range msgs {
+		offsets.fetched(kafkaMessage(m.partition, m.offset))
+	}
+
+	_, ok := offsets.done(kafkaMessage(0, 11))
+	assert.False(t, ok)
+
+	last, ok := offsets.done(kafkaMessage(0, 10))
+	assert.True(t, ok)
+	assert.Equal(t, int64(11), last.Offset)
+
+	last, ok = offsets.done(kafkaMessage(1, 5))
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), last.Offset)
+
+	last, ok = offsets.done(kafkaMessage(0, 12))
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), last.Offset)
+}
+
+func TestKafkaReceiver_HoldsRetriesUntilNotBefore(t *testing.T) {
+	r := &KafkaReceiver{maxMessages: 10, offsets: newKafkaOffsets()}
+	now := time.UnixMilli(1700000000000)
+	retried := kafkaMessage(0, 1)
+	retried.Headers = []kafka.Header{{Key: kafkaNotBeforeHeader, Value: []byte(strconv.FormatInt(now.Add(time.Minute).UnixMilli(), 10))}}
+
+	assert.False(t, r.hold(kafkaMessage(0, 0), now))
+	assert.True(t, r.hold(retried, now))
+	assert.Equal(t, now.Add(time.Minute), r.nextDue())
+
+	assert.Empty(t, r.due(now))
+	deliveries := r.due(now.Add(time.Minute))
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "-0-1", deliveries[0].ID)
+	assert.True(t, r.nextDue().IsZero())
+}
+
+func TestRedisStreamTimestamp(t *testing.T) {
+	ts := redisStreamTimestamp("1700000000123-0")
+	require.NotNil(t, ts)
+	assert.Equal(t, int64(1700000000123), ts.UnixMilli())
+	assert.Nil(t, redisStreamTimestamp("invalid"))
+}
+
+func TestNatsStreamName(t *testing.T) {
+	assert.Equal(t, "WORMSCAN_PIPELINE", natsStreamName("wormscan.pipeline"))
+}
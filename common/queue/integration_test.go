@@ -0,0 +1,102 @@
+//go:build integration
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// The integration tests use the local brokers of docker-compose.yml:
+//
+//	docker compose -f common/queue/docker-compose.yml up -d
+//	QUEUE_TEST_KAFKA_BROKERS=localhost:9092 QUEUE_TEST_NATS_URL=nats://localhost:4222 \
+//	QUEUE_TEST_REDIS_URL=redis://localhost:6379 go test -tags integration ./queue/...
+
+func TestIntegration_Kafka(t *testing.T) {
+	brokers := os.Getenv("QUEUE_TEST_KAFKA_BROKERS")
+	if brokers == "" {
+		t.Skip("QUEUE_TEST_KAFKA_BROKERS is not set")
+	}
+	testBackend(t, &Config{QueueType: TypeKafka, QueueKafkaBrokers: brokers})
+}
+
+func TestIntegration_Nats(t *testing.T) {
+	url := os.Getenv("QUEUE_TEST_NATS_URL")
+	if url == "" {
+		t.Skip("QUEUE_TEST_NATS_URL is not set")
+	}
+	testBackend(t, &Config{QueueType: TypeNats, QueueNatsURL: url})
+}
+
+func TestIntegration_Redis(t *testing.T) {
+	url := os.Getenv("QUEUE_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("QUEUE_TEST_REDIS_URL is not set")
+	}
+	testBackend(t, &Config{QueueType: TypeRedis, QueueRedisURL: url, QueueRedisMaxLen: 1000})
+}
+
+// testBackend checks that a message is delivered once when it is done, delivered again when it
+// fails, and moved to the dead-letter queue when it reaches the maximum deliveries.
+func testBackend(t *testing.T, cfg *Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	name := fmt.Sprintf("wormscan-test-%d", time.Now().UnixNano())
+	cfg.QueueGroup = "test"
+	cfg.QueueAckDeadlineSeconds = 2
+	cfg.QueueMaxDeliveries = 2
+	cfg.QueueMaxMessages = 10
+
+	sender, err := NewSender(ctx, cfg, name)
+	require.NoError(t, err)
+	defer sender.Close()
+
+	receiver, err := NewReceiver(ctx, cfg, name)
+	require.NoError(t, err)
+	consumer := NewConsumer[testEvent](receiver, testConverter, zap.NewNop())
+	defer consumer.Close()
+	defer cancel()
+	ch := consumer.Consume(ctx)
+
+	require.NoError(t, sender.Send(ctx, &OutgoingMessage{GroupID: "1", DeduplicationID: name + "-1", Body: "1"}))
+	require.NoError(t, sender.Send(ctx, &OutgoingMessage{GroupID: "2", DeduplicationID: name + "-2", Body: "2"}))
+
+	received := map[int]uint8{}
+	for len(received) < 2 || received[2] < 2 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for the messages")
+		case msg := <-ch:
+			received[msg.Data().Value] = msg.Retry()
+			if msg.Data().Value == 1 {
+				msg.Done()
+			} else {
+				msg.Failed()
+			}
+		}
+	}
+	assert.Equal(t, uint8(1), received[1])
+	assert.Equal(t, uint8(2), received[2])
+
+	dlqReceiver, err := NewReceiver(ctx, cfg, deadLetterName(name))
+	require.NoError(t, err)
+	dlqConsumer := NewConsumer[testEvent](dlqReceiver, testConverter, zap.NewNop())
+	defer dlqConsumer.Close()
+
+	select {
+	case <-ctx.Done():
+		t.Fatal("timeout waiting for the dead letter")
+	case msg := <-dlqConsumer.Consume(ctx):
+		assert.Equal(t, 2, msg.Data().Value)
+		msg.Done()
+	}
+}
@@ -0,0 +1,332 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	kafkaRetryHeader           = "retry"
+	kafkaNotBeforeHeader       = "not-before"
+	kafkaDeduplicationIDHeader = "deduplication-id"
+	kafkaBatchWait             = 500 * time.Millisecond
+)
+
+// KafkaReceiver receives messages from a Kafka topic with a consumer group.
+//
+// Kafka doesn't deliver a message again when it is not committed, so the ack deadline is only used
+// to expire the messages and to delay the retries. A failed message is published again to the topic with
+// the retry incremented and a not-before time one ack deadline later, like the other backends deliver
+// it again when the ack deadline expires. It is published to the dead-letter topic when it reaches the
+// maximum deliveries. The messages fetched before their not-before time are held by the receiver until then.
+type KafkaReceiver struct {
+	reader        *kafka.Reader
+	writer        *kafka.Writer
+	topic         string
+	dlqTopic      string
+	maxMessages   int
+	maxDeliveries uint8
+	ackDeadline   time.Duration
+	offsets       *kafkaOffsets
+	delayed       []kafka.Message
+}
+
+// NewKafkaReceiver creates a receiver of a Kafka topic.
+func NewKafkaReceiver(brokers []string, topic, group string, maxMessages, maxDeliveries int, ackDeadline time.Duration) *KafkaReceiver {
+	return &KafkaReceiver{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			Topic:   topic,
+			GroupID: group,
+		}),
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
+		topic:         topic,
+		dlqTopic:      deadLetterName(topic),
+		maxMessages:   maxMessages,
+		maxDeliveries: toRetry(maxDeliveries),
+		ackDeadline:   ackDeadline,
+		offsets:       newKafkaOffsets(),
+	}
+}
+
+// Receive blocks until a message is due, and returns it with the messages fetched
+// during the batch wait up to the batch size.
+func (r *KafkaReceiver) Receive(ctx context.Context) ([]Delivery, error) {
+	deliveries := r.due(time.Now())
+	for len(deliveries) == 0 {
+		msg, err := r.fetch(ctx, r.nextDue())
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				// a held message is due
+				deliveries = r.due(time.Now())
+				continue
+			}
+			return nil, err
+		}
+		if !r.hold(msg, time.Now()) {
+			deliveries = append(deliveries, r.toDelivery(msg))
+		}
+	}
+
+	batchDeadline := time.Now().Add(kafkaBatchWait)
+	for len(deliveries) < r.maxMessages {
+		msg, err := r.fetch(ctx, batchDeadline)
+		if err != nil {
+			break
+		}
+		if !r.hold(msg, time.Now()) {
+			deliveries = append(deliveries, r.toDelivery(msg))
+		}
+	}
+	return deliveries, nil
+}
+
+// fetch fetches the next message of the topic, waiting until the deadline if it is not zero.
+func (r *KafkaReceiver) fetch(ctx context.Context, deadline time.Time) (kafka.Message, error) {
+	var fetchCtx context.Context
+	var cancel context.CancelFunc
+	if deadline.IsZero() {
+		fetchCtx, cancel = context.WithCancel(ctx)
+	} else {
+		fetchCtx, cancel = context.WithDeadline(ctx, deadline)
+	}
+	defer cancel()
+
+	msg, err := r.reader.FetchMessage(fetchCtx)
+	if err != nil {
+		return msg, err
+	}
+	r.offsets.fetched(msg)
+	return msg, nil
+}
+
+// hold holds a message fetched before its not-before time, and reports whether it was held.
+// The offsets of the held messages are not committed until they are delivered and acknowledged.
+func (r *KafkaReceiver) hold(msg kafka.Message, now time.Time) bool {
+	if !kafkaNotBefore(msg).After(now) {
+		return false
+	}
+	r.delayed = append(r.delayed, msg)
+	return true
+}
+
+// due returns the deliveries of the held messages that are due at now.
+func (r *KafkaReceiver) due(now time.Time) []Delivery {
+	var deliveries []Delivery
+	held := r.delayed[:0]
+	for _, msg := range r.delayed {
+		if len(deliveries) < r.maxMessages && !kafkaNotBefore(msg).After(now) {
+			deliveries = append(deliveries, r.toDelivery(msg))
+		} else {
+			held = append(held, msg)
+		}
+	}
+	r.delayed = held
+	return deliveries
+}
+
+// nextDue returns the time at which the first held message is due, or zero if there are no held messages.
+func (r *KafkaReceiver) nextDue() time.Time {
+	var next time.Time
+	for _, msg := range r.delayed {
+		if notBefore := kafkaNotBefore(msg); next.IsZero() || notBefore.Before(next) {
+			next = notBefore
+		}
+	}
+	return next
+}
+
+// AckDeadline returns the ack deadline of the messages.
+func (r *KafkaReceiver) AckDeadline() time.Duration {
+	return r.ackDeadline
+}
+
+// Name returns the name of the topic.
+func (r *KafkaReceiver) Name() string {
+	return r.topic
+}
+
+// Close closes the reader and the writer of the receiver.
+func (r *KafkaReceiver) Close() error {
+	return errors.Join(r.reader.Close(), r.writer.Close())
+}
+
+func (r *KafkaReceiver) toDelivery(msg kafka.Message) Delivery {
+	retry := toRetry(kafkaRetry(msg) + 1)
+	sentTimestamp := msg.Time
+	return Delivery{
+		ID:            kafkaMessageID(msg),
+		Body:          string(msg.Value),
+		Retry:         retry,
+		SentTimestamp: &sentTimestamp,
+		ack: func(ctx context.Context) error {
+			return r.commit(ctx, msg)
+		},
+		nack: func(ctx context.Context) error {
+			topic := r.topic
+			if retry >= r.maxDeliveries {
+				topic = r.dlqTopic
+			}
+			notBefore := time.Now().Add(r.ackDeadline).UnixMilli()
+			headers := []kafka.Header{
+				{Key: kafkaRetryHeader, Value: []byte(strconv.Itoa(int(retry)))},
+				{Key: kafkaNotBeforeHeader, Value: []byte(strconv.FormatInt(notBefore, 10))},
+			}
+			for _, h := range msg.Headers {
+				if h.Key != kafkaRetryHeader && h.Key != kafkaNotBeforeHeader {
+					headers = append(headers, h)
+				}
+			}
+			err := r.writer.WriteMessages(ctx, kafka.Message{
+				Topic:   topic,
+				Key:     msg.Key,
+				Value:   msg.Value,
+				Headers: headers,
+				Time:    msg.Time,
+			})
+			if err != nil {
+				return err
+			}
+			return r.commit(ctx, msg)
+		},
+	}
+}
+
+// commit commits the offsets of the partition up to the last message acknowledged in order.
+func (r *KafkaReceiver) commit(ctx context.Context, msg kafka.Message) error {
+	last, ok := r.offsets.done(msg)
+	if !ok {
+		return nil
+	}
+	return r.reader.CommitMessages(ctx, last)
+}
+
+// KafkaSender publishes messages to a Kafka topic. The group id is the key of the message,
+// so the messages of the same group are published to the same partition.
+type KafkaSender struct {
+	writer *kafka.Writer
+	topic  string
+}
+
+// NewKafkaSender creates a sender of a Kafka topic.
+func NewKafkaSender(brokers []string, topic string) *KafkaSender {
+	return &KafkaSender{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			Topic:                  topic,
+			Balancer:               &kafka.Hash{},
+			AllowAutoTopicCreation: true,
+		},
+		topic: topic,
+	}
+}
+
+// Send publishes a message to the topic.
+func (s *KafkaSender) Send(ctx context.Context, msg *OutgoingMessage) error {
+	headers := []kafka.Header{{Key: kafkaDeduplicationIDHeader, Value: []byte(msg.DeduplicationID)}}
+	for k, v := range msg.Attributes {
+		headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(msg.GroupID),
+		Value:   []byte(msg.Body),
+		Headers: headers,
+		Time:    time.Now(),
+	})
+}
+
+// Close closes the writer of the sender.
+func (s *KafkaSender) Close() error {
+	return s.writer.Close()
+}
+
+// kafkaRetry returns the number of previous deliveries of a message.
+func kafkaRetry(msg kafka.Message) int {
+	for _, h := range msg.Headers {
+		if h.Key == kafkaRetryHeader {
+			retry, _ := strconv.Atoi(string(h.Value))
+			return retry
+		}
+	}
+	return 0
+}
+
+// kafkaNotBefore returns the time before which a retried message must not be delivered,
+// or zero if the message can be delivered at any time.
+func kafkaNotBefore(msg kafka.Message) time.Time {
+	for _, h := range msg.Headers {
+		if h.Key == kafkaNotBeforeHeader {
+			millis, err := strconv.ParseInt(string(h.Value), 10, 64)
+			if err != nil {
+				return time.Time{}
+			}
+			return time.UnixMilli(millis)
+		}
+	}
+	return time.Time{}
+}
+
+func kafkaMessageID(msg kafka.Message) string {
+	for _, h := range msg.Headers {
+		if h.Key == kafkaDeduplicationIDHeader && len(h.Value) > 0 {
+			return string(h.Value)
+		}
+	}
+	return msg.Topic + "-" + strconv.Itoa(msg.Partition) + "-" + strconv.FormatInt(msg.Offset, 10)
+}
+
+// kafkaOffsets tracks the fetched messages of each partition, so the offsets are committed in order
+// when the messages are acknowledged out of order.
+type kafkaOffsets struct {
+	mu         sync.Mutex
+	partitions map[int]*kafkaPartition
+}
+
+type kafkaPartition struct {
+	pending  []kafka.Message
+	finished map[int64]bool
+}
+
+func newKafkaOffsets() *kafkaOffsets {
+	return &kafkaOffsets{partitions: make(map[int]*kafkaPartition)}
+}
+
+func (o *kafkaOffsets) fetched(msg kafka.Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	p, ok := o.partitions[msg.Partition]
+	if !ok {
+		p = &kafkaPartition{finished: make(map[int64]bool)}
+		o.partitions[msg.Partition] = p
+	}
+	p.pending = append(p.pending, msg)
+}
+
+// done marks a message as finished and returns the last message of the partition that can be committed.
+func (o *kafkaOffsets) done(msg kafka.Message) (kafka.Message, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	p, ok := o.partitions[msg.Partition]
+	if !ok {
+		return kafka.Message{}, false
+	}
+	p.finished[msg.Offset] = true
+
+	var last kafka.Message
+	var found bool
+	for len(p.pending) > 0 && p.finished[p.pending[0].Offset] {
+		last, found = p.pending[0], true
+		delete(p.finished, last.Offset)
+		p.pending = p.pending[1:]
+	}
+	return last, found
+}
@@ -0,0 +1,193 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// memoryQueues are the in-memory queues shared by the senders and receivers of the same process,
+// indexed by name and consumer group.
+var memoryQueues = struct {
+	sync.Mutex
+	queues map[string]map[string]*Memory
+}{queues: make(map[string]map[string]*Memory)}
+
+// memoryDelivery is a message stored in an in-memory queue.
+type memoryDelivery struct {
+	id            string
+	body          string
+	retry         uint8
+	sentTimestamp time.Time
+}
+
+// Memory is an in-memory queue, used to run the services in a single process.
+// Failed messages are delivered again up to the maximum deliveries, and then moved to the dead letters.
+type Memory struct {
+	name          string
+	ch            chan *memoryDelivery
+	maxMessages   int
+	maxDeliveries uint8
+	ackDeadline   time.Duration
+	counter       uint64
+	mu            sync.Mutex
+	deadLetters   []string
+}
+
+// NewMemory creates an in-memory queue.
+func NewMemory(name string, size, maxMessages, maxDeliveries int, ackDeadline time.Duration) *Memory {
+	return &Memory{
+		name:          name,
+		ch:            make(chan *memoryDelivery, size),
+		maxMessages:   maxMessages,
+		maxDeliveries: toRetry(maxDeliveries),
+		ackDeadline:   ackDeadline,
+	}
+}
+
+// GetMemory returns the in-memory queue of the consumer group with the name, and creates it if it doesn't exist.
+// Each consumer group receives its own copy of the messages sent with a MemorySender.
+func GetMemory(name, group string, size, maxMessages, maxDeliveries int, ackDeadline time.Duration) *Memory {
+	memoryQueues.Lock()
+	defer memoryQueues.Unlock()
+	return getMemory(name, group, size, maxMessages, maxDeliveries, ackDeadline)
+}
+
+func getMemory(name, group string, size, maxMessages, maxDeliveries int, ackDeadline time.Duration) *Memory {
+	groups, ok := memoryQueues.queues[name]
+	if !ok {
+		groups = make(map[string]*Memory)
+		memoryQueues.queues[name] = groups
+	}
+	m, ok := groups[group]
+	if !ok {
+		m = NewMemory(name, size, maxMessages, maxDeliveries, ackDeadline)
+		groups[group] = m
+	}
+	return m
+}
+
+// MemorySender sends the messages to the in-memory queues of every consumer group with the name.
+// Like a topic without subscriptions, the messages are discarded when no consumer group exists.
+type MemorySender struct {
+	name string
+}
+
+// NewMemorySender creates a sender of the in-memory queues with the name.
+func NewMemorySender(name string) *MemorySender {
+	return &MemorySender{name: name}
+}
+
+// Send stores a message in the queue of every consumer group.
+func (s *MemorySender) Send(ctx context.Context, msg *OutgoingMessage) error {
+	memoryQueues.Lock()
+	queues := make([]*Memory, 0, len(memoryQueues.queues[s.name]))
+	for _, m := range memoryQueues.queues[s.name] {
+		queues = append(queues, m)
+	}
+	memoryQueues.Unlock()
+
+	for _, m := range queues {
+		if err := m.Send(ctx, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the sender.
+func (s *MemorySender) Close() error {
+	return nil
+}
+
+// Send stores a message in the queue. It blocks while the queue is full.
+func (m *Memory) Send(ctx context.Context, msg *OutgoingMessage) error {
+	m.mu.Lock()
+	m.counter++
+	id := fmt.Sprintf("%s-%d", m.name, m.counter)
+	m.mu.Unlock()
+
+	return m.push(ctx, &memoryDelivery{
+		id:            id,
+		body:          msg.Body,
+		retry:         0,
+		sentTimestamp: time.Now(),
+	})
+}
+
+// Receive blocks until a message is stored, and returns it with the other stored messages up to the batch size.
+func (m *Memory) Receive(ctx context.Context) ([]Delivery, error) {
+	var deliveries []Delivery
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case d := <-m.ch:
+		deliveries = append(deliveries, m.toDelivery(d))
+	}
+	for len(deliveries) < m.maxMessages {
+		select {
+		case d := <-m.ch:
+			deliveries = append(deliveries, m.toDelivery(d))
+		default:
+			return deliveries, nil
+		}
+	}
+	return deliveries, nil
+}
+
+// AckDeadline returns the ack deadline of the queue.
+func (m *Memory) AckDeadline() time.Duration {
+	return m.ackDeadline
+}
+
+// Name returns the name of the queue.
+func (m *Memory) Name() string {
+	return m.name
+}
+
+// Close closes the queue.
+func (m *Memory) Close() error {
+	return nil
+}
+
+// DeadLetters returns the bodies of the messages that exceeded the maximum deliveries.
+func (m *Memory) DeadLetters() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.deadLetters...)
+}
+
+func (m *Memory) toDelivery(d *memoryDelivery) Delivery {
+	d.retry++
+	sentTimestamp := d.sentTimestamp
+	return Delivery{
+		ID:            d.id,
+		Body:          d.body,
+		Retry:         d.retry,
+		SentTimestamp: &sentTimestamp,
+		ack:           noopAck,
+		nack: func(ctx context.Context) error {
+			if d.retry >= m.maxDeliveries {
+				m.mu.Lock()
+				m.deadLetters = append(m.deadLetters, d.body)
+				m.mu.Unlock()
+				return nil
+			}
+			// the message is pushed asynchronously to not block the consumer while the queue is full.
+			go func() {
+				_ = m.push(ctx, d)
+			}()
+			return nil
+		},
+	}
+}
+
+func (m *Memory) push(ctx context.Context, d *memoryDelivery) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.ch <- d:
+		return nil
+	}
+}
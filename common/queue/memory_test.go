@@ -0,0 +1,48 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySender_FansOutToConsumerGroups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	parser := GetMemory("test-fan-out", "parser", 10, 10, 3, time.Minute)
+	analytics := GetMemory("test-fan-out", "analytics", 10, 10, 3, time.Minute)
+	assert.Same(t, parser, GetMemory("test-fan-out", "parser", 10, 10, 3, time.Minute))
+
+	sender := NewMemorySender("test-fan-out")
+	require.NoError(t, sender.Send(ctx, &OutgoingMessage{Body: "1"}))
+
+	for _, m := range []*Memory{parser, analytics} {
+		deliveries, err := m.Receive(ctx)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "1", deliveries[0].Body)
+	}
+}
+
+func TestMemorySender_DiscardsMessagesWithoutConsumerGroups(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	sender := NewMemorySender("test-no-groups")
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sender.Send(ctx, &OutgoingMessage{Body: "discarded"}))
+	}
+
+	// a consumer group only receives the messages sent after it is created
+	m := GetMemory("test-no-groups", "parser", 1, 10, 3, time.Minute)
+	require.NoError(t, sender.Send(ctx, &OutgoingMessage{Body: "1"}))
+
+	deliveries, err := m.Receive(ctx)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "1", deliveries[0].Body)
+}
@@ -0,0 +1,222 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const natsFetchWait = 20 * time.Second
+
+// NatsReceiver receives messages from a NATS JetStream subject with a durable pull consumer.
+//
+// The ack deadline is the ack wait of the consumer, so a message that is not acknowledged in time is
+// delivered again. A failed message is delivered again immediately, and it is published to the dead-letter
+// subject when it reaches the maximum deliveries.
+type NatsReceiver struct {
+	conn          *nats.Conn
+	js            nats.JetStreamContext
+	sub           *nats.Subscription
+	subject       string
+	dlqSubject    string
+	maxMessages   int
+	maxDeliveries uint8
+	ackDeadline   time.Duration
+}
+
+// NewNatsReceiver creates a receiver of a NATS JetStream subject. The stream of the subject is created if it doesn't exist.
+func NewNatsReceiver(url, subject, group string, maxMessages, maxDeliveries int, ackDeadline time.Duration) (*NatsReceiver, error) {
+	conn, js, err := newNatsJetStream(url, subject)
+	if err != nil {
+		return nil, err
+	}
+	if err := addNatsStream(js, deadLetterName(subject)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	sub, err := js.PullSubscribe(subject, group,
+		nats.BindStream(natsStreamName(subject)),
+		nats.AckWait(ackDeadline),
+		nats.ManualAck())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &NatsReceiver{
+		conn:          conn,
+		js:            js,
+		sub:           sub,
+		subject:       subject,
+		dlqSubject:    deadLetterName(subject),
+		maxMessages:   maxMessages,
+		maxDeliveries: toRetry(maxDeliveries),
+		ackDeadline:   ackDeadline,
+	}, nil
+}
+
+// Receive fetches a batch of messages. It returns no messages when the fetch wait expires.
+func (r *NatsReceiver) Receive(ctx context.Context) ([]Delivery, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, natsFetchWait)
+	defer cancel()
+
+	msgs, err := r.sub.Fetch(r.maxMessages, nats.Context(fetchCtx))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, nats.ErrTimeout) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	deliveries := make([]Delivery, 0, len(msgs))
+	for _, msg := range msgs {
+		meta, err := msg.Metadata()
+		if err != nil {
+			return deliveries, err
+		}
+		retry := toRetry(int(meta.NumDelivered))
+
+		// the message was not acknowledged in time after the last delivery.
+		if retry > r.maxDeliveries {
+			if err := r.deadLetter(ctx, msg); err != nil {
+				return deliveries, err
+			}
+			continue
+		}
+
+		m := msg
+		id := m.Header.Get(nats.MsgIdHdr)
+		if id == "" {
+			id = strconv.FormatUint(meta.Sequence.Stream, 10)
+		}
+		sentTimestamp := meta.Timestamp
+		deliveries = append(deliveries, Delivery{
+			ID:            id,
+			Body:          string(m.Data),
+			Retry:         retry,
+			SentTimestamp: &sentTimestamp,
+			ack: func(ctx context.Context) error {
+				return m.AckSync(nats.Context(ctx))
+			},
+			nack: func(ctx context.Context) error {
+				if retry >= r.maxDeliveries {
+					return r.deadLetter(ctx, m)
+				}
+				return m.Nak(nats.Context(ctx))
+			},
+		})
+	}
+	return deliveries, nil
+}
+
+// deadLetter publishes a message to the dead-letter subject and terminates its delivery.
+// The message id is removed, so the message is not discarded as a duplicate of a previous dead letter.
+func (r *NatsReceiver) deadLetter(ctx context.Context, msg *nats.Msg) error {
+	dlqMsg := nats.NewMsg(r.dlqSubject)
+	dlqMsg.Data = msg.Data
+	for k, v := range msg.Header {
+		if k != nats.MsgIdHdr {
+			dlqMsg.Header[k] = v
+		}
+	}
+	_, err := r.js.PublishMsg(dlqMsg, nats.Context(ctx))
+	if err != nil {
+		return err
+	}
+	return msg.Term(nats.Context(ctx))
+}
+
+// AckDeadline returns the ack wait of the consumer.
+func (r *NatsReceiver) AckDeadline() time.Duration {
+	return r.ackDeadline
+}
+
+// Name returns the subject.
+func (r *NatsReceiver) Name() string {
+	return r.subject
+}
+
+// Close drains the subscription and closes the connection.
+func (r *NatsReceiver) Close() error {
+	err := r.sub.Drain()
+	r.conn.Close()
+	return err
+}
+
+// NatsSender publishes messages to a NATS JetStream subject. The deduplication id is the message id,
+// so the duplicated messages are discarded during the duplicate window of the stream.
+type NatsSender struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	subject string
+}
+
+// NewNatsSender creates a sender of a NATS JetStream subject. The stream of the subject is created if it doesn't exist.
+func NewNatsSender(url, subject string) (*NatsSender, error) {
+	conn, js, err := newNatsJetStream(url, subject)
+	if err != nil {
+		return nil, err
+	}
+	return &NatsSender{conn: conn, js: js, subject: subject}, nil
+}
+
+// Send publishes a message to the subject.
+func (s *NatsSender) Send(ctx context.Context, msg *OutgoingMessage) error {
+	m := nats.NewMsg(s.subject)
+	m.Data = []byte(msg.Body)
+	for k, v := range msg.Attributes {
+		m.Header.Set(k, v)
+	}
+	opts := []nats.PubOpt{nats.Context(ctx)}
+	if msg.DeduplicationID != "" {
+		opts = append(opts, nats.MsgId(msg.DeduplicationID))
+	}
+	_, err := s.js.PublishMsg(m, opts...)
+	return err
+}
+
+// Close closes the connection.
+func (s *NatsSender) Close() error {
+	s.conn.Close()
+	return nil
+}
+
+// newNatsJetStream connects to NATS and creates the stream of the subject.
+func newNatsJetStream(url, subject string) (*nats.Conn, nats.JetStreamContext, error) {
+	conn, err := nats.Connect(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err := addNatsStream(js, subject); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, js, nil
+}
+
+// addNatsStream creates the stream of a subject if it doesn't exist.
+func addNatsStream(js nats.JetStreamContext, subject string) error {
+	_, err := js.AddStream(&nats.StreamConfig{
+		Name:     natsStreamName(subject),
+		Subjects: []string{subject},
+	})
+	if err != nil && !errors.Is(err, nats.ErrStreamNameAlreadyInUse) {
+		return err
+	}
+	return nil
+}
+
+// natsStreamName returns the name of the stream of a subject, which can't contain dots, wildcards or spaces.
+func natsStreamName(subject string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "*", "_", ">", "_", " ", "_").Replace(subject))
+}
@@ -0,0 +1,82 @@
+package queue
+
+import (
+	"context"
+	"time"
+)
+
+// Message represents a message received from a queue.
+type Message[T any] interface {
+	// Data returns the converted body of the message.
+	Data() *T
+	// Retry returns the number of times the message was delivered, starting at 1.
+	Retry() uint8
+	// Done acknowledges the message, so it is not delivered again.
+	Done()
+	// Failed releases the message, so it is delivered again or moved to the dead-letter queue.
+	Failed()
+	// IsExpired checks if the ack deadline of the message expired.
+	IsExpired() bool
+	// SentTimestamp returns the time at which the message was published.
+	SentTimestamp() *time.Time
+}
+
+// ConsumeFunc is a function to consume messages.
+type ConsumeFunc[T any] func(context.Context) <-chan Message[T]
+
+// ConverterFunc converts the body of a message. A nil result means that the message can not be handled.
+type ConverterFunc[T any] func(string) (*T, error)
+
+// FilterFunc checks if a message must be discarded.
+type FilterFunc[T any] func(*T) bool
+
+// Delivery is a raw message received from a broker.
+type Delivery struct {
+	ID            string
+	Body          string
+	Retry         uint8
+	SentTimestamp *time.Time
+	ack           func(context.Context) error
+	nack          func(context.Context) error
+}
+
+// Ack acknowledges the delivery.
+func (d *Delivery) Ack(ctx context.Context) error {
+	return d.ack(ctx)
+}
+
+// Nack releases the delivery to be delivered again or moved to the dead-letter queue.
+func (d *Delivery) Nack(ctx context.Context) error {
+	return d.nack(ctx)
+}
+
+// Receiver receives raw messages from a broker.
+type Receiver interface {
+	// Receive blocks until a batch of messages is received or the wait time of the backend expires.
+	Receive(ctx context.Context) ([]Delivery, error)
+	// AckDeadline returns the time a delivery has to be acknowledged before it is delivered again.
+	AckDeadline() time.Duration
+	// Name returns the name of the queue, used for logging.
+	Name() string
+	Close() error
+}
+
+// OutgoingMessage represents a message to publish.
+type OutgoingMessage struct {
+	// GroupID is the key used to keep the order of the messages.
+	GroupID string
+	// DeduplicationID is the id used to discard duplicated messages, when supported by the backend.
+	DeduplicationID string
+	Body            string
+	Attributes      map[string]string
+}
+
+// Sender publishes messages to a broker.
+type Sender interface {
+	Send(ctx context.Context, msg *OutgoingMessage) error
+	Close() error
+}
+
+func noopAck(context.Context) error {
+	return nil
+}
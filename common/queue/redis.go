@@ -0,0 +1,252 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	redisBodyField    = "body"
+	redisGroupIDField = "groupId"
+	redisBlock        = 20 * time.Second
+	// redisDeduplicationWindow is the time a deduplication id is kept, the same as the SNS FIFO topics.
+	redisDeduplicationWindow = 5 * time.Minute
+)
+
+// RedisReceiver receives messages from a Redis stream with a consumer group.
+//
+// A message that is not acknowledged before the ack deadline is claimed again from the pending entries
+// of the group. A failed message is delivered again when the ack deadline expires, and it is added to the
+// dead-letter stream when it reaches the maximum deliveries.
+type RedisReceiver struct {
+	client        *redis.Client
+	stream        string
+	dlqStream     string
+	group         string
+	consumer      string
+	maxMessages   int
+	maxDeliveries uint8
+	ackDeadline   time.Duration
+}
+
+// NewRedisReceiver creates a receiver of a Redis stream. The stream and the consumer group are created if they don't exist.
+func NewRedisReceiver(ctx context.Context, client *redis.Client, stream, group string, maxMessages, maxDeliveries int, ackDeadline time.Duration) (*RedisReceiver, error) {
+	err := client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+	return &RedisReceiver{
+		client:        client,
+		stream:        stream,
+		dlqStream:     deadLetterName(stream),
+		group:         group,
+		consumer:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		maxMessages:   maxMessages,
+		maxDeliveries: toRetry(maxDeliveries),
+		ackDeadline:   ackDeadline,
+	}, nil
+}
+
+// Receive claims the messages whose ack deadline expired, and reads new messages up to the batch size.
+func (r *RedisReceiver) Receive(ctx context.Context) ([]Delivery, error) {
+	deliveries, err := r.claim(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) >= r.maxMessages {
+		return deliveries, nil
+	}
+
+	block := redisBlock
+	if len(deliveries) > 0 {
+		block = -1
+	}
+	streams, err := r.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    r.group,
+		Consumer: r.consumer,
+		Streams:  []string{r.stream, ">"},
+		Count:    int64(r.maxMessages - len(deliveries)),
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return deliveries, nil
+		}
+		return deliveries, err
+	}
+	for _, s := range streams {
+		for _, msg := range s.Messages {
+			deliveries = append(deliveries, r.toDelivery(msg, 1))
+		}
+	}
+	return deliveries, nil
+}
+
+// claim claims the pending messages idle for longer than the ack deadline.
+// The messages that exceeded the maximum deliveries are moved to the dead-letter stream.
+func (r *RedisReceiver) claim(ctx context.Context) ([]Delivery, error) {
+	msgs, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   r.stream,
+		Group:    r.group,
+		Consumer: r.consumer,
+		MinIdle:  r.ackDeadline,
+		Start:    "0-0",
+		Count:    int64(r.maxMessages),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []Delivery
+	for _, msg := range msgs {
+		pending, err := r.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: r.stream,
+			Group:  r.group,
+			Start:  msg.ID,
+			End:    msg.ID,
+			Count:  1,
+		}).Result()
+		if err != nil {
+			return deliveries, err
+		}
+		if len(pending) == 0 {
+			continue
+		}
+
+		retry := toRetry(int(pending[0].RetryCount))
+		if retry > r.maxDeliveries {
+			if err := r.deadLetter(ctx, msg); err != nil {
+				return deliveries, err
+			}
+			continue
+		}
+		deliveries = append(deliveries, r.toDelivery(msg, retry))
+	}
+	return deliveries, nil
+}
+
+func (r *RedisReceiver) toDelivery(msg redis.XMessage, retry uint8) Delivery {
+	body, _ := msg.Values[redisBodyField].(string)
+	return Delivery{
+		ID:            msg.ID,
+		Body:          body,
+		Retry:         retry,
+		SentTimestamp: redisStreamTimestamp(msg.ID),
+		ack: func(ctx context.Context) error {
+			return r.client.XAck(ctx, r.stream, r.group, msg.ID).Err()
+		},
+		nack: func(ctx context.Context) error {
+			if retry >= r.maxDeliveries {
+				return r.deadLetter(ctx, msg)
+			}
+			// the message is claimed again when the ack deadline expires.
+			return nil
+		},
+	}
+}
+
+// deadLetter adds a message to the dead-letter stream and acknowledges it.
+func (r *RedisReceiver) deadLetter(ctx context.Context, msg redis.XMessage) error {
+	err := r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.dlqStream,
+		Values: msg.Values,
+	}).Err()
+	if err != nil {
+		return err
+	}
+	return r.client.XAck(ctx, r.stream, r.group, msg.ID).Err()
+}
+
+// AckDeadline returns the minimum idle time to claim a pending message.
+func (r *RedisReceiver) AckDeadline() time.Duration {
+	return r.ackDeadline
+}
+
+// Name returns the name of the stream.
+func (r *RedisReceiver) Name() string {
+	return r.stream
+}
+
+// Close closes the redis client.
+func (r *RedisReceiver) Close() error {
+	return r.client.Close()
+}
+
+// RedisSender adds messages to a Redis stream. The messages with a deduplication id already added
+// during the deduplication window are discarded.
+type RedisSender struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisSender creates a sender of a Redis stream. The stream is trimmed to approximately maxLen entries.
+func NewRedisSender(client *redis.Client, stream string, maxLen int64) *RedisSender {
+	return &RedisSender{client: client, stream: stream, maxLen: maxLen}
+}
+
+// sendScript adds a message to the stream unless its deduplication id was already added. The message is added
+// before the deduplication key is set, so a message that could not be added is not discarded when it is retried.
+//
+// KEYS[1] is the stream and KEYS[2] the deduplication key, or an empty string when the message has no deduplication id.
+// ARGV[1] is the deduplication window in milliseconds, ARGV[2] the approximate max length of the stream, 0 to not trim it,
+// and the rest of ARGV are the fields and values of the message.
+var sendScript = redis.NewScript(`
+if KEYS[2] ~= "" and redis.call("exists", KEYS[2]) == 1 then
+	return 0
+end
+local args = {}
+if tonumber(ARGV[2]) > 0 then
+	args = {"maxlen", "~", ARGV[2]}
+end
+table.insert(args, "*")
+for i = 3, #ARGV do
+	table.insert(args, ARGV[i])
+end
+redis.call("xadd", KEYS[1], unpack(args))
+if KEYS[2] ~= "" then
+	redis.call("set", KEYS[2], 1, "px", ARGV[1])
+end
+return 1`)
+
+// Send adds a message to the stream.
+func (s *RedisSender) Send(ctx context.Context, msg *OutgoingMessage) error {
+	var dedupKey string
+	if msg.DeduplicationID != "" {
+		dedupKey = fmt.Sprintf("%s:dedup:%s", s.stream, msg.DeduplicationID)
+	}
+
+	args := []interface{}{redisDeduplicationWindow.Milliseconds(), s.maxLen,
+		redisBodyField, msg.Body,
+		redisGroupIDField, msg.GroupID,
+	}
+	for k, v := range msg.Attributes {
+		args = append(args, k, v)
+	}
+	return sendScript.Run(ctx, s.client, []string{s.stream, dedupKey}, args...).Err()
+}
+
+// Close closes the redis client.
+func (s *RedisSender) Close() error {
+	return s.client.Close()
+}
+
+// redisStreamTimestamp returns the time at which an entry was added, which is the first part of its id.
+func redisStreamTimestamp(id string) *time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	millis, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return nil
+	}
+	t := time.UnixMilli(millis)
+	return &t
+}
@@ -0,0 +1,156 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	aws_sqs_types "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	sqs_client "github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
+	"go.uber.org/zap"
+)
+
+// SQSConsumer is the SQS client used by the SQS receiver.
+type SQSConsumer interface {
+	GetMessages(ctx context.Context) ([]aws_sqs_types.Message, error)
+	DeleteMessage(ctx context.Context, id *string) error
+	GetVisibilityTimeout() time.Duration
+	GetQueueUrl() string
+}
+
+// snsEvent is the envelope of a SNS notification delivered to a SQS queue.
+type snsEvent struct {
+	MessageID string `json:"MessageId"`
+	Message   string `json:"Message"`
+}
+
+// SQSReceiverOption represents a SQS receiver option function.
+type SQSReceiverOption func(*SQSReceiver)
+
+// SQSReceiver receives messages from a SQS queue.
+// The visibility timeout is the ack deadline, and the dead-letter queue is configured with the redrive policy of the queue.
+type SQSReceiver struct {
+	consumer    SQSConsumer
+	snsEnvelope bool
+	logger      *zap.Logger
+}
+
+// NewSQSReceiver creates a receiver of a SQS queue subscribed to a SNS topic.
+func NewSQSReceiver(consumer SQSConsumer, logger *zap.Logger, opts ...SQSReceiverOption) *SQSReceiver {
+	r := &SQSReceiver{
+		consumer:    consumer,
+		snsEnvelope: true,
+		logger:      logger.With(zap.String("queueUrl", consumer.GetQueueUrl())),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// WithRawMessages allows to receive the messages published directly to the queue, without the SNS envelope.
+func WithRawMessages() SQSReceiverOption {
+	return func(r *SQSReceiver) {
+		r.snsEnvelope = false
+	}
+}
+
+// Receive receives a batch of messages from the SQS queue.
+func (r *SQSReceiver) Receive(ctx context.Context) ([]Delivery, error) {
+	messages, err := r.consumer.GetMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]Delivery, 0, len(messages))
+	for _, msg := range messages {
+		receiptHandle := msg.ReceiptHandle
+		body := aws.ToString(msg.Body)
+		if r.snsEnvelope {
+			var event snsEvent
+			if err := json.Unmarshal([]byte(body), &event); err != nil {
+				r.logger.Error("Error decoding message from SQS", zap.Error(err), zap.String("body", body))
+				if err = r.consumer.DeleteMessage(ctx, receiptHandle); err != nil {
+					r.logger.Error("Error deleting message from SQS", zap.Error(err))
+				}
+				continue
+			}
+			body = event.Message
+		}
+
+		retry, _ := strconv.Atoi(msg.Attributes[string(aws_sqs_types.MessageSystemAttributeNameApproximateReceiveCount)])
+		deliveries = append(deliveries, Delivery{
+			ID:            aws.ToString(msg.MessageId),
+			Body:          body,
+			Retry:         toRetry(retry),
+			SentTimestamp: sqs_client.GetSentTimestamp(msg),
+			ack: func(ctx context.Context) error {
+				return r.consumer.DeleteMessage(ctx, receiptHandle)
+			},
+			// the message is delivered again when the visibility timeout expires.
+			nack: noopAck,
+		})
+	}
+	return deliveries, nil
+}
+
+// AckDeadline returns the visibility timeout of the queue.
+func (r *SQSReceiver) AckDeadline() time.Duration {
+	return r.consumer.GetVisibilityTimeout()
+}
+
+// Name returns the url of the queue.
+func (r *SQSReceiver) Name() string {
+	return r.consumer.GetQueueUrl()
+}
+
+// Close closes the receiver.
+func (r *SQSReceiver) Close() error {
+	return nil
+}
+
+// AWSProducer is the SNS or SQS client used by the AWS sender.
+type AWSProducer interface {
+	SendMessage(ctx context.Context, groupID, deduplicationID, body string) error
+}
+
+// attributesProducer is implemented by the producers that support message attributes.
+type attributesProducer interface {
+	SendMessageWithAttributes(ctx context.Context, groupID, deduplicationID, body string, attributes map[string]string) error
+}
+
+// AWSSender publishes messages to a SNS topic or a SQS queue.
+type AWSSender struct {
+	producer AWSProducer
+}
+
+// NewAWSSender creates a sender of a SNS topic or a SQS queue.
+func NewAWSSender(producer AWSProducer) *AWSSender {
+	return &AWSSender{producer: producer}
+}
+
+// Send publishes a message.
+func (s *AWSSender) Send(ctx context.Context, msg *OutgoingMessage) error {
+	if p, ok := s.producer.(attributesProducer); ok && len(msg.Attributes) > 0 {
+		return p.SendMessageWithAttributes(ctx, msg.GroupID, msg.DeduplicationID, msg.Body, msg.Attributes)
+	}
+	return s.producer.SendMessage(ctx, msg.GroupID, msg.DeduplicationID, msg.Body)
+}
+
+// Close closes the sender.
+func (s *AWSSender) Close() error {
+	return nil
+}
+
+// toRetry converts a delivery count to a retry.
+func toRetry(deliveries int) uint8 {
+	if deliveries > 255 {
+		return 255
+	}
+	if deliveries < 0 {
+		return 0
+	}
+	return uint8(deliveries)
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Emitter sources.
+const (
+	EmitterSourceSeed      = "seed"
+	EmitterSourceTxTracker = "tx-tracker"
+	EmitterSourceAPI       = "api"
+)
+
+// EmitterDoc is a document for the emitter registry.
+type EmitterDoc struct {
+	ID            string      `bson:"_id" json:"id"`
+	ChainID       sdk.ChainID `bson:"chainId" json:"chainId"`
+	Address       string      `bson:"address" json:"address"`
+	NativeAddress string      `bson:"nativeAddress" json:"nativeAddress"`
+	AppID         string      `bson:"appId" json:"appId"`
+	Label         string      `bson:"label" json:"label"`
+	Source        string      `bson:"source" json:"source"`
+	FirstSeenAt   *time.Time  `bson:"firstSeenAt,omitempty" json:"firstSeenAt,omitempty"`
+	LastSeenAt    *time.Time  `bson:"lastSeenAt,omitempty" json:"lastSeenAt,omitempty"`
+	UpdatedAt     time.Time   `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ToEmitterInfo converts the document into a domain.EmitterInfo.
+func (d *EmitterDoc) ToEmitterInfo() *domain.EmitterInfo {
+	return &domain.EmitterInfo{
+		ChainID:       d.ChainID,
+		Address:       d.Address,
+		NativeAddress: d.NativeAddress,
+		AppID:         d.AppID,
+		Label:         d.Label,
+	}
+}
+
+// EmitterQuery is a query for the emitter registry.
+type EmitterQuery struct {
+	ChainID *sdk.ChainID
+	AppID   string
+	Skip    int64
+	Limit   int64
+}
+
+// EmitterRepository is a repository for the emitter registry.
+type EmitterRepository struct {
+	db       *mongo.Database
+	logger   *zap.Logger
+	emitters *mongo.Collection
+}
+
+// NewEmitterRepository create a new emitter repository.
+func NewEmitterRepository(db *mongo.Database, logger *zap.Logger) *EmitterRepository {
+	return &EmitterRepository{db: db,
+		logger:   logger.With(zap.String("module", "EmitterRepository")),
+		emitters: db.Collection(Emitters),
+	}
+}
+
+// EmitterID returns the id of an emitter document.
+func EmitterID(chainID sdk.ChainID, address string) string {
+	return fmt.Sprintf("%d/%s", chainID, strings.ToLower(address))
+}
+
+// Seed inserts the given emitters. Existing emitters are left untouched.
+func (r *EmitterRepository) Seed(ctx context.Context, emitters []domain.EmitterInfo) error {
+	now := time.Now()
+	for _, e := range emitters {
+		doc := EmitterDoc{
+			ID:            EmitterID(e.ChainID, e.Address),
+			ChainID:       e.ChainID,
+			Address:       strings.ToLower(e.Address),
+			NativeAddress: e.NativeAddress,
+			AppID:         e.AppID,
+			Label:         e.Label,
+			Source:        EmitterSourceSeed,
+			UpdatedAt:     now,
+		}
+		update := bson.M{"$setOnInsert": doc}
+		opts := options.Update().SetUpsert(true)
+		if _, err := r.emitters.UpdateByID(ctx, doc.ID, update, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Upsert creates or replaces the editable fields of an emitter.
+func (r *EmitterRepository) Upsert(ctx context.Context, info *domain.EmitterInfo, source string) (*EmitterDoc, error) {
+	now := time.Now()
+	id := EmitterID(info.ChainID, info.Address)
+	update := bson.M{
+		"$set": bson.M{
+			"chainId":       info.ChainID,
+			"address":       strings.ToLower(info.Address),
+			"nativeAddress": info.NativeAddress,
+			"appId":         info.AppID,
+			"label":         info.Label,
+			"source":        source,
+			"updatedAt":     now,
+		},
+		"$setOnInsert": IndexedAt(now),
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var doc EmitterDoc
+	if err := r.emitters.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// EmitterSighting is an emitter observed between FirstSeenAt and LastSeenAt.
+type EmitterSighting struct {
+	ChainID       sdk.ChainID
+	Address       string
+	NativeAddress string
+	FirstSeenAt   time.Time
+	LastSeenAt    time.Time
+}
+
+// RegisterSeen records that the emitters were observed, with a single bulk write. The emitters are
+// created if they do not exist, but fields edited through the API or seeded are never overwritten,
+// except a missing native address that is filled in.
+func (r *EmitterRepository) RegisterSeen(ctx context.Context, sightings []EmitterSighting) error {
+	if len(sightings) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, 2*len(sightings))
+	for _, s := range sightings {
+		id := EmitterID(s.ChainID, s.Address)
+		update := bson.M{
+			"$setOnInsert": bson.M{
+				"chainId":       s.ChainID,
+				"address":       strings.ToLower(s.Address),
+				"nativeAddress": s.NativeAddress,
+				"appId":         "",
+				"label":         "",
+				"source":        EmitterSourceTxTracker,
+				"updatedAt":     now,
+				"indexedAt":     now,
+			},
+			"$min": bson.M{"firstSeenAt": s.FirstSeenAt},
+			"$max": bson.M{"lastSeenAt": s.LastSeenAt},
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(update).
+			SetUpsert(true))
+
+		if s.NativeAddress != "" {
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": id, "nativeAddress": ""}).
+				SetUpdate(bson.M{"$set": bson.M{"nativeAddress": s.NativeAddress, "updatedAt": now}}))
+		}
+	}
+
+	// the writes are ordered so the native address is filled in after the emitter is created
+	_, err := r.emitters.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true))
+	return err
+}
+
+// FindOne finds an emitter by chain and address.
+func (r *EmitterRepository) FindOne(ctx context.Context, chainID sdk.ChainID, address string) (*EmitterDoc, error) {
+	var doc EmitterDoc
+	err := r.emitters.FindOne(ctx, bson.M{"_id": EmitterID(chainID, address)}).Decode(&doc)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// Find finds the emitters that match the query, sorted by chain and address.
+func (r *EmitterRepository) Find(ctx context.Context, q EmitterQuery) ([]*EmitterDoc, error) {
+	filter := bson.M{}
+	if q.ChainID != nil {
+		filter["chainId"] = *q.ChainID
+	}
+	if q.AppID != "" {
+		filter["appId"] = q.AppID
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "chainId", Value: 1}, {Key: "address", Value: 1}}).
+		SetSkip(q.Skip)
+	if q.Limit > 0 {
+		opts.SetLimit(q.Limit)
+	}
+	cursor, err := r.emitters.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []*EmitterDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Delete deletes an emitter. It returns false if the emitter does not exist.
+func (r *EmitterRepository) Delete(ctx context.Context, chainID sdk.ChainID, address string) (bool, error) {
+	res, err := r.emitters.DeleteOne(ctx, bson.M{"_id": EmitterID(chainID, address)})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Search finds the emitters whose address or native address is address, or whose
+// label or app id starts with text, case insensitive. Either value can be empty.
+func (r *EmitterRepository) Search(ctx context.Context, address, text string, limit int64) ([]*EmitterDoc, error) {
+	var or bson.A
+	if address != "" {
+		or = append(or,
+			bson.M{"address": strings.ToLower(address)},
+			bson.M{"nativeAddress": address},
+		)
+	}
+	if text != "" {
+		prefix := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(text), Options: "i"}
+		or = append(or, bson.M{"label": prefix}, bson.M{"appId": prefix})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "chainId", Value: 1}, {Key: "address", Value: 1}}).
+		SetLimit(limit)
+	cursor, err := r.emitters.Find(ctx, bson.M{"$or": or}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []*EmitterDoc
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
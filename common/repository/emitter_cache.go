@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// EmitterCache is an in-memory copy of the emitter registry that implements domain.EmitterResolver.
+// The whole registry is loaded in memory and refreshed periodically, so lookups never hit the database.
+type EmitterCache struct {
+	repository      *EmitterRepository
+	refreshInterval time.Duration
+	logger          *zap.Logger
+
+	mu       sync.RWMutex
+	emitters map[string]*domain.EmitterInfo
+}
+
+// NewEmitterCache creates a new emitter cache.
+func NewEmitterCache(repository *EmitterRepository, refreshInterval time.Duration, logger *zap.Logger) *EmitterCache {
+	return &EmitterCache{
+		repository:      repository,
+		refreshInterval: refreshInterval,
+		logger:          logger.With(zap.String("module", "EmitterCache")),
+		emitters:        make(map[string]*domain.EmitterInfo),
+	}
+}
+
+// Load loads the emitter registry in memory.
+func (c *EmitterCache) Load(ctx context.Context) error {
+	docs, err := c.repository.Find(ctx, EmitterQuery{})
+	if err != nil {
+		return err
+	}
+	emitters := make(map[string]*domain.EmitterInfo, len(docs))
+	for _, d := range docs {
+		emitters[d.ID] = d.ToEmitterInfo()
+	}
+	c.mu.Lock()
+	c.emitters = emitters
+	c.mu.Unlock()
+	return nil
+}
+
+// Start loads the emitter registry and refreshes it until the context is cancelled.
+func (c *EmitterCache) Start(ctx context.Context) {
+	if err := c.Load(ctx); err != nil {
+		c.logger.Error("failed to load emitter registry", zap.Error(err))
+	}
+	go func() {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Load(ctx); err != nil {
+					c.logger.Error("failed to refresh emitter registry", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Set updates a single emitter in the cache.
+func (c *EmitterCache) Set(info *domain.EmitterInfo) {
+	c.mu.Lock()
+	c.emitters[EmitterID(info.ChainID, info.Address)] = info
+	c.mu.Unlock()
+}
+
+// Remove removes a single emitter from the cache.
+func (c *EmitterCache) Remove(chainID sdk.ChainID, address string) {
+	c.mu.Lock()
+	delete(c.emitters, EmitterID(chainID, address))
+	c.mu.Unlock()
+}
+
+// ResolveEmitter implements domain.EmitterResolver.
+func (c *EmitterCache) ResolveEmitter(chainID sdk.ChainID, address string) (*domain.EmitterInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	info, ok := c.emitters[EmitterID(chainID, strings.ToLower(address))]
+	return info, ok
+}
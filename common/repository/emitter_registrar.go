@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// emitterSightingsWriter writes the observed emitters to the emitter registry.
+type emitterSightingsWriter interface {
+	RegisterSeen(ctx context.Context, sightings []EmitterSighting) error
+}
+
+// EmitterRegistrar buffers the emitters observed while processing VAAs and writes them to the
+// emitter registry periodically, so that the registry is updated once per emitter and interval
+// instead of once per VAA.
+type EmitterRegistrar struct {
+	writer        emitterSightingsWriter
+	flushInterval time.Duration
+	logger        *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]*EmitterSighting
+}
+
+// NewEmitterRegistrar creates a new emitter registrar.
+func NewEmitterRegistrar(repository *EmitterRepository, flushInterval time.Duration, logger *zap.Logger) *EmitterRegistrar {
+	return newEmitterRegistrar(repository, flushInterval, logger)
+}
+
+func newEmitterRegistrar(writer emitterSightingsWriter, flushInterval time.Duration, logger *zap.Logger) *EmitterRegistrar {
+	return &EmitterRegistrar{
+		writer:        writer,
+		flushInterval: flushInterval,
+		logger:        logger.With(zap.String("module", "EmitterRegistrar")),
+		pending:       make(map[string]*EmitterSighting),
+	}
+}
+
+// Register records that the emitter was seen at the given time. The sighting is written on the next flush.
+func (r *EmitterRegistrar) Register(chainID sdk.ChainID, address, nativeAddress string, seenAt time.Time) {
+	id := EmitterID(chainID, address)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.pending[id]
+	if !ok {
+		r.pending[id] = &EmitterSighting{
+			ChainID:       chainID,
+			Address:       address,
+			NativeAddress: nativeAddress,
+			FirstSeenAt:   seenAt,
+			LastSeenAt:    seenAt,
+		}
+		return
+	}
+	if seenAt.Before(s.FirstSeenAt) {
+		s.FirstSeenAt = seenAt
+	}
+	if seenAt.After(s.LastSeenAt) {
+		s.LastSeenAt = seenAt
+	}
+	if s.NativeAddress == "" {
+		s.NativeAddress = nativeAddress
+	}
+}
+
+// Flush writes the pending sightings to the emitter registry. If the write fails, the sightings
+// are kept and retried on the next flush.
+func (r *EmitterRegistrar) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*EmitterSighting)
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	sightings := make([]EmitterSighting, 0, len(pending))
+	for _, s := range pending {
+		sightings = append(sightings, *s)
+	}
+	err := r.writer.RegisterSeen(ctx, sightings)
+	if err != nil {
+		for _, s := range sightings {
+			r.Register(s.ChainID, s.Address, s.NativeAddress, s.FirstSeenAt)
+			r.Register(s.ChainID, s.Address, s.NativeAddress, s.LastSeenAt)
+		}
+	}
+	return err
+}
+
+// Start flushes the pending sightings periodically until the context is cancelled,
+// and flushes the last sightings when it is.
+func (r *EmitterRegistrar) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				if err := r.Flush(flushCtx); err != nil {
+					r.logger.Error("failed to flush emitter sightings", zap.Error(err))
+				}
+				cancel()
+				return
+			case <-ticker.C:
+				if err := r.Flush(ctx); err != nil {
+					r.logger.Warn("failed to flush emitter sightings", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
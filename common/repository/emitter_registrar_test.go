@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+type stubSightingsWriter struct {
+	calls [][]EmitterSighting
+	err   error
+}
+
+func (w *stubSightingsWriter) RegisterSeen(_ context.Context, sightings []EmitterSighting) error {
+	w.calls = append(w.calls, sightings)
+	return w.err
+}
+
+func TestEmitterRegistrar_MergesSightingsOfAnEmitter(t *testing.T) {
+	writer := &stubSightingsWriter{}
+	r := newEmitterRegistrar(writer, time.Minute, zap.NewNop())
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const address = "0000000000000000000000003ee18b2214aff97000d974cf647e7c347e8fa585"
+	r.Register(sdk.ChainIDEthereum, address, "", t0.Add(time.Hour))
+	r.Register(sdk.ChainIDEthereum, address, "0x3ee18b2214aff97000d974cf647e7c347e8fa585", t0)
+	r.Register(sdk.ChainIDEthereum, address, "", t0.Add(2*time.Hour))
+	r.Register(sdk.ChainIDSolana, address, "", t0)
+
+	require.NoError(t, r.Flush(context.Background()))
+	require.Len(t, writer.calls, 1)
+	require.Len(t, writer.calls[0], 2)
+
+	for _, s := range writer.calls[0] {
+		if s.ChainID != sdk.ChainIDEthereum {
+			continue
+		}
+		assert.Equal(t, "0x3ee18b2214aff97000d974cf647e7c347e8fa585", s.NativeAddress)
+		assert.Equal(t, t0, s.FirstSeenAt)
+		assert.Equal(t, t0.Add(2*time.Hour), s.LastSeenAt)
+	}
+
+	// nothing is written when there are no new sightings
+	require.NoError(t, r.Flush(context.Background()))
+	assert.Len(t, writer.calls, 1)
+}
+
+func TestEmitterRegistrar_KeepsSightingsWhenFlushFails(t *testing.T) {
+	writer := &stubSightingsWriter{err: errors.New("mongo unavailable")}
+	r := newEmitterRegistrar(writer, time.Minute, zap.NewNop())
+
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	const address = "ec7372995d5cc8732397fb0ad35c0121e0eaa90d26f828a534cab54391b3a4f5"
+	r.Register(sdk.ChainIDSolana, address, "", t0)
+	r.Register(sdk.ChainIDSolana, address, "", t0.Add(time.Minute))
+
+	require.Error(t, r.Flush(context.Background()))
+
+	writer.err = nil
+	require.NoError(t, r.Flush(context.Background()))
+	require.Len(t, writer.calls, 2)
+	require.Len(t, writer.calls[1], 1)
+	assert.Equal(t, t0, writer.calls[1][0].FirstSeenAt)
+	assert.Equal(t, t0.Add(time.Minute), writer.calls[1][0].LastSeenAt)
+}
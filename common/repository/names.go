@@ -1,12 +1,24 @@
 package repository
 
 const (
-	VaaIdTxHash      = "vaaIdTxHash"
-	TransferPrices   = "transferPrices"
-	Vaas             = "vaas"
-	DuplicateVaas    = "duplicateVaas"
-	GuardianSets     = "guardianSets"
-	NodeGovernorVaas = "nodeGovernorVaas"
-	GovernorVaas     = "governorVaas"
-	Observations     = "observations"
+	VaaIdTxHash        = "vaaIdTxHash"
+	TransferPrices     = "transferPrices"
+	Vaas               = "vaas"
+	DuplicateVaas      = "duplicateVaas"
+	GuardianSets       = "guardianSets"
+	NodeGovernorVaas   = "nodeGovernorVaas"
+	GovernorVaas       = "governorVaas"
+	Observations       = "observations"
+	Emitters           = "emitters"
+	GlobalTransactions = "globalTransactions"
+	ParsedVaa          = "parsedVaa"
+	StuckOperations    = "stuckOperations"
+	OperationsSla      = "operationsSla"
+	ApiKeys            = "apiKeys"
+	JobRuns            = "jobRuns"
+	HolderBalances     = "holderBalances"
+	HolderCheckpoints  = "holderCheckpoints"
+	NTTTokens          = "nttTokens"
+	GovernorNotional   = "governorNotionalHistory"
+	GovernorVaaEvents  = "governorVaaEvents"
 )
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// OperationLifecycle contains the time at which an operation reached each status.
+type OperationLifecycle struct {
+	ObservedAt         *time.Time `bson:"observedAt,omitempty" json:"observedAt,omitempty"`
+	GovernorEnqueuedAt *time.Time `bson:"governorEnqueuedAt,omitempty" json:"governorEnqueuedAt,omitempty"`
+	VaaSignedAt        *time.Time `bson:"vaaSignedAt,omitempty" json:"vaaSignedAt,omitempty"`
+	TargetFailedAt     *time.Time `bson:"targetFailedAt,omitempty" json:"targetFailedAt,omitempty"`
+	RedeemedAt         *time.Time `bson:"redeemedAt,omitempty" json:"redeemedAt,omitempty"`
+}
+
+// lifecycleFields maps each status to the lifecycle field that stores when it was reached.
+var lifecycleFields = map[domain.OperationStatus]string{
+	domain.OperationStatusObserved:         "lifecycle.observedAt",
+	domain.OperationStatusGovernorEnqueued: "lifecycle.governorEnqueuedAt",
+	domain.OperationStatusVaaSigned:        "lifecycle.vaaSignedAt",
+	domain.OperationStatusTargetFailed:     "lifecycle.targetFailedAt",
+	domain.OperationStatusRedeemed:         "lifecycle.redeemedAt",
+}
+
+// OperationStatusRepository updates the lifecycle status of the operations stored in the
+// `globalTransactions` collection.
+type OperationStatusRepository struct {
+	globalTransactions *mongo.Collection
+	logger             *zap.Logger
+}
+
+// NewOperationStatusRepository creates a new operation status repository.
+func NewOperationStatusRepository(db *mongo.Database, logger *zap.Logger) *OperationStatusRepository {
+	return &OperationStatusRepository{
+		globalTransactions: db.Collection(GlobalTransactions),
+		logger:             logger,
+	}
+}
+
+// UpdateStatus moves the operation to the given status and records the time at which it was reached.
+//
+// The status never goes back in the lifecycle: if the operation is already in the same or
+// in a later status, the update is ignored.
+func (r *OperationStatusRepository) UpdateStatus(ctx context.Context, id string, status domain.OperationStatus, at time.Time) error {
+
+	field, ok := lifecycleFields[status]
+	if !ok {
+		return fmt.Errorf("invalid operation status: %s", status)
+	}
+
+	var reached []domain.OperationStatus
+	for _, s := range domain.OperationStatuses() {
+		if !status.IsAfter(s) {
+			reached = append(reached, s)
+		}
+	}
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$nin": reached},
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":          status,
+			"statusUpdatedAt": time.Now(),
+		},
+		"$min": bson.M{field: at},
+	}
+
+	_, err := r.globalTransactions.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		// the operation exists with the same or a later status, so the upsert tried to insert a new document.
+		r.logger.Debug("operation already reached the status",
+			zap.String("id", id),
+			zap.String("status", string(status)))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update operation status: %w", err)
+	}
+	return nil
+}
+
+// InsertObserved creates the operation in the observed status. The first status of the lifecycle
+// is only set when the operation does not exist, so an operation that already moved forward is
+// not updated.
+func (r *OperationStatusRepository) InsertObserved(ctx context.Context, id string, at time.Time) error {
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"status":          domain.OperationStatusObserved,
+			"statusUpdatedAt": time.Now(),
+			lifecycleFields[domain.OperationStatusObserved]: at,
+		},
+	}
+	_, err := r.globalTransactions.UpdateByID(ctx, id, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to insert observed operation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// StuckOperationDoc models a document in the `stuckOperations` collection.
+//
+// The collection contains the operations detected as stuck until they are redeemed.
+type StuckOperationDoc struct {
+	// ID is the message id of the operation (chain/emitter/sequence).
+	ID           string                 `bson:"_id" json:"id"`
+	Cause        domain.StuckCause      `bson:"cause" json:"cause"`
+	Status       domain.OperationStatus `bson:"status" json:"status"`
+	EmitterChain sdk.ChainID            `bson:"emitterChain" json:"emitterChain"`
+	TargetChain  sdk.ChainID            `bson:"targetChain" json:"targetChain"`
+	AppIDs       []string               `bson:"appIds" json:"appIds"`
+	TxHash       string                 `bson:"txHash" json:"txHash"`
+	// StuckSince is the time at which the operation was signed, or enqueued by the governor.
+	StuckSince time.Time `bson:"stuckSince" json:"stuckSince"`
+	DetectedAt time.Time `bson:"detectedAt" json:"detectedAt"`
+	UpdatedAt  time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// SLA group by values.
+const (
+	SlaGroupByTargetChain = "targetChain"
+	SlaGroupByAppID       = "appId"
+)
+
+// OperationSlaDoc models a document in the `operationsSla` collection.
+//
+// It contains the percentiles of the time elapsed between the VAA being signed and the
+// redeem on the target chain, for the operations redeemed in the time range.
+type OperationSlaDoc struct {
+	// ID is the concatenation of GroupBy and Key.
+	ID      string    `bson:"_id" json:"-"`
+	GroupBy string    `bson:"groupBy" json:"groupBy"`
+	Key     string    `bson:"key" json:"key"`
+	From    time.Time `bson:"from" json:"from"`
+	To      time.Time `bson:"to" json:"to"`
+	Count   int       `bson:"count" json:"count"`
+	// P50, P90 and P99 are expressed in seconds.
+	P50       float64   `bson:"p50" json:"p50"`
+	P90       float64   `bson:"p90" json:"p90"`
+	P99       float64   `bson:"p99" json:"p99"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,234 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// topHoldersLimit is the number of top holders of a symbol.
+const topHoldersLimit = 10
+
+// HolderBalance is the balance of an address for a token deployment, indexed from the chain.
+type HolderBalance struct {
+	ID      string      `bson:"_id"`
+	ChainID sdk.ChainID `bson:"chainId"`
+	// TokenAddress is the wormhole address of the token deployment, as in the token registry.
+	TokenAddress string `bson:"tokenAddress"`
+	// Address is the native address of the holder.
+	Address string `bson:"address"`
+	// Balance is the raw balance, it can exceed the 64 bits integers.
+	Balance string `bson:"balance"`
+	// Amount is the balance in token units, it is used to sort the holders.
+	Amount float64 `bson:"amount"`
+	// Block is the last block, or slot, applied to the balance.
+	Block     uint64    `bson:"block"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// HolderCheckpoint is the last block, or slot, indexed for a token deployment.
+type HolderCheckpoint struct {
+	ID           string      `bson:"_id"`
+	ChainID      sdk.ChainID `bson:"chainId"`
+	TokenAddress string      `bson:"tokenAddress"`
+	Block        uint64      `bson:"block"`
+	UpdatedAt    time.Time   `bson:"updatedAt"`
+}
+
+// HolderBalanceID returns the id of the balance of an address for a token deployment.
+func HolderBalanceID(chainID sdk.ChainID, tokenAddress, address string) string {
+	return fmt.Sprintf("%d/%s/%s", chainID, tokenAddress, address)
+}
+
+func holderCheckpointID(chainID sdk.ChainID, tokenAddress string) string {
+	return fmt.Sprintf("%d/%s", chainID, tokenAddress)
+}
+
+// HolderBalanceRepository stores the holder balances indexed from the chains, and computes the
+// NTT top holders from them.
+type HolderBalanceRepository struct {
+	balances      *mongo.Collection
+	checkpoints   *mongo.Collection
+	cache         cache.Cache
+	tokenProvider *domain.TokenProvider
+	notionalCache notional.NotionalLocalCacheReadable
+	log           *zap.Logger
+}
+
+// NewHolderBalanceRepository creates a new instance of HolderBalanceRepository.
+func NewHolderBalanceRepository(db *mongo.Database, cache cache.Cache, tokenProvider *domain.TokenProvider,
+	notionalCache notional.NotionalLocalCacheReadable, log *zap.Logger) *HolderBalanceRepository {
+	return &HolderBalanceRepository{
+		balances:      db.Collection(repository.HolderBalances),
+		checkpoints:   db.Collection(repository.HolderCheckpoints),
+		cache:         cache,
+		tokenProvider: tokenProvider,
+		notionalCache: notionalCache,
+		log:           log,
+	}
+}
+
+// GetCheckpoint returns the checkpoint of a token deployment, or nil if it was never indexed.
+func (r *HolderBalanceRepository) GetCheckpoint(ctx context.Context, chainID sdk.ChainID, tokenAddress string) (*HolderCheckpoint, error) {
+	var checkpoint HolderCheckpoint
+	err := r.checkpoints.FindOne(ctx, bson.M{"_id": holderCheckpointID(chainID, tokenAddress)}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find holder checkpoint of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	return &checkpoint, nil
+}
+
+// SetCheckpoint stores the last block, or slot, indexed for a token deployment.
+func (r *HolderBalanceRepository) SetCheckpoint(ctx context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error {
+	checkpoint := HolderCheckpoint{
+		ID:           holderCheckpointID(chainID, tokenAddress),
+		ChainID:      chainID,
+		TokenAddress: tokenAddress,
+		Block:        block,
+		UpdatedAt:    time.Now(),
+	}
+	_, err := r.checkpoints.ReplaceOne(ctx, bson.M{"_id": checkpoint.ID}, checkpoint, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to store holder checkpoint of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	return nil
+}
+
+// FindBalances returns the balances of the addresses for a token deployment, by address.
+func (r *HolderBalanceRepository) FindBalances(ctx context.Context, chainID sdk.ChainID, tokenAddress string, addresses []string) (map[string]HolderBalance, error) {
+	ids := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		ids = append(ids, HolderBalanceID(chainID, tokenAddress, address))
+	}
+	cur, err := r.balances.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find holder balances of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	var balances []HolderBalance
+	if err := cur.All(ctx, &balances); err != nil {
+		return nil, fmt.Errorf("failed to decode holder balances of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	result := make(map[string]HolderBalance, len(balances))
+	for _, b := range balances {
+		result[b.Address] = b
+	}
+	return result, nil
+}
+
+// SaveBalances inserts or replaces the balances.
+func (r *HolderBalanceRepository) SaveBalances(ctx context.Context, balances []HolderBalance) error {
+	if len(balances) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(balances))
+	for _, b := range balances {
+		models = append(models, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": b.ID}).
+			SetReplacement(b).
+			SetUpsert(true))
+	}
+	if _, err := r.balances.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store holder balances: %w", err)
+	}
+	return nil
+}
+
+// DeleteBalancesBefore deletes the balances of a token deployment not updated since the block, or slot.
+// It removes the holders that are no longer present in a snapshot of the balances.
+func (r *HolderBalanceRepository) DeleteBalancesBefore(ctx context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error {
+	filter := bson.M{"chainId": chainID, "tokenAddress": tokenAddress, "block": bson.M{"$lt": block}}
+	if _, err := r.balances.DeleteMany(ctx, filter); err != nil {
+		return fmt.Errorf("failed to delete holder balances of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	return nil
+}
+
+// FindTopBalances returns the largest positive balances of a token deployment.
+func (r *HolderBalanceRepository) FindTopBalances(ctx context.Context, chainID sdk.ChainID, tokenAddress string, limit int64) ([]HolderBalance, error) {
+	filter := bson.M{"chainId": chainID, "tokenAddress": tokenAddress, "amount": bson.M{"$gt": 0}}
+	opts := options.Find().SetSort(bson.D{{Key: "amount", Value: -1}}).SetLimit(limit)
+	cur, err := r.balances.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find top holder balances of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	var balances []HolderBalance
+	if err := cur.All(ctx, &balances); err != nil {
+		return nil, fmt.Errorf("failed to decode top holder balances of %d/%s: %w", chainID, tokenAddress, err)
+	}
+	return balances, nil
+}
+
+// LoadNativeTokenTransferTopHolder computes the top holders of a symbol from the indexed balances and stores
+// them in the cache, with the same shape as the HolderRepository.
+func (r *HolderBalanceRepository) LoadNativeTokenTransferTopHolder(ctx context.Context, symbol string, expiration time.Duration) error {
+	holders, err := r.getNativeTokenTransferTopHolder(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s:%s", nttTopHolders, symbol)
+	cr := cachedResult[[]NativeTokenTransferTopHolder]{Timestamp: time.Now(), Result: holders}
+	return r.cache.Set(ctx, key, cr, expiration)
+}
+
+func (r *HolderBalanceRepository) getNativeTokenTransferTopHolder(ctx context.Context, symbol string) ([]NativeTokenTransferTopHolder, error) {
+	tokens, found := r.tokenProvider.GetTokensBySymbol(symbol)
+	if !found {
+		return nil, fmt.Errorf("no token found for symbol %s", symbol)
+	}
+
+	// the deployments of a NTT token are the same asset, any of them has the price of the symbol
+	var price *decimal.Decimal
+	for _, t := range tokens {
+		if n, err := r.notionalCache.Get(t.GetTokenID()); err == nil {
+			price = &n.NotionalUsd
+			break
+		}
+	}
+	if price == nil {
+		return nil, fmt.Errorf("no price found for symbol %s", symbol)
+	}
+
+	var holders []NativeTokenTransferTopHolder
+	for _, t := range tokens {
+		balances, err := r.FindTopBalances(ctx, t.TokenChain, t.TokenAddress, topHoldersLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range balances {
+			balance, err := decimal.NewFromString(b.Balance)
+			if err != nil {
+				r.log.Warn("invalid holder balance", zap.String("id", b.ID), zap.String("balance", b.Balance))
+				continue
+			}
+			holders = append(holders, NativeTokenTransferTopHolder{
+				Address: b.Address,
+				ChainID: b.ChainID,
+				Volume:  balance.Shift(-int32(t.Decimals)).Mul(*price),
+			})
+		}
+	}
+
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].Volume.Compare(holders[j].Volume) > 0
+	})
+	if len(holders) > topHoldersLimit {
+		holders = holders[:topHoldersLimit]
+	}
+	return holders, nil
+}
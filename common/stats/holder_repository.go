@@ -2,12 +2,14 @@ package stats
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sort"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/mr-tron/base58"
 	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
@@ -74,72 +76,84 @@ func (r *HolderRepository) GetNativeTokenTransferTopHolder(ctx context.Context,
 	return cached.Result, nil
 }
 
-func (r *HolderRepository) getNativeTokenTransferTopHolder(ctx context.Context, symbol string) ([]NativeTokenTransferTopHolder, error) {
-	holderEVM, err := r.getHolderByWormholeTokenForEVM(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	holderSolana, err := r.getHolderByWormholeTokenForSolana(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// Merge holders from different chains
-	var holders []NativeTokenTransferTopHolder
-	for _, holder := range holderEVM.AddressTopHolders.Ethereum {
-		holders = append(holders, NativeTokenTransferTopHolder{
-			Address: holder.Address.Address,
-			ChainID: sdk.ChainIDEthereum,
-			Volume:  decimal.NewFromFloat(holder.Usd),
-		})
-	}
-
-	for _, holder := range holderEVM.AddressTopHolders.ArbitrumOne {
-		holders = append(holders, NativeTokenTransferTopHolder{
-			Address: holder.Address.Address,
-			ChainID: sdk.ChainIDArbitrum,
-			Volume:  decimal.NewFromFloat(holder.Usd),
-		})
-	}
-
-	for _, holder := range holderEVM.AddressTopHolders.Base {
-		holders = append(holders, NativeTokenTransferTopHolder{
-			Address: holder.Address.Address,
-			ChainID: sdk.ChainIDBase,
-			Volume:  decimal.NewFromFloat(holder.Usd),
-		})
-	}
+// arkhamChains maps the chain names of the arkham top holders response to their chain ids.
+var arkhamChains = map[string]sdk.ChainID{
+	"ethereum":     sdk.ChainIDEthereum,
+	"arbitrum_one": sdk.ChainIDArbitrum,
+	"base":         sdk.ChainIDBase,
+	"optimism":     sdk.ChainIDOptimism,
+	"polygon":      sdk.ChainIDPolygon,
+	"bsc":          sdk.ChainIDBSC,
+	"avalanche":    sdk.ChainIDAvalanche,
+}
 
-	// Get token metadata price for solana
+func (r *HolderRepository) getNativeTokenTransferTopHolder(ctx context.Context, symbol string) ([]NativeTokenTransferTopHolder, error) {
 	tokens, found := r.tokenProvider.GetTokensBySymbol(symbol)
 	if !found {
 		return nil, fmt.Errorf("no token found for symbol %s", symbol)
-
 	}
-	var token *domain.TokenMetadata
+
+	// arkham identifies the tokens by their coingecko id
+	var coingeckoID string
+	var solanaToken *domain.TokenMetadata
 	for _, t := range tokens {
+		if coingeckoID == "" {
+			coingeckoID = t.CoingeckoID
+		}
 		if t.TokenChain == sdk.ChainIDSolana {
-			token = t
-			break
+			solanaToken = t
 		}
 	}
-	if token == nil {
-		return nil, fmt.Errorf("no token found for symbol %s", symbol)
+	if coingeckoID == "" {
+		return nil, fmt.Errorf("no coingecko id found for symbol %s", symbol)
 	}
 
-	tokenPrice, err := r.notionalCache.Get(token.GetTokenID())
+	holderEVM, err := r.getHolderByTokenForEVM(ctx, coingeckoID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add holders from solana with calculated price
-	for _, holder := range holderSolana.Result.Value {
-		holders = append(holders, NativeTokenTransferTopHolder{
-			Address: holder.Address,
-			ChainID: sdk.ChainIDSolana,
-			Volume:  decimal.NewFromFloat(holder.UIAmount).Mul(tokenPrice.NotionalUsd),
-		})
+	// Merge holders from different chains
+	var holders []NativeTokenTransferTopHolder
+	for chain, chainHolders := range holderEVM.AddressTopHolders {
+		chainID, ok := arkhamChains[chain]
+		if !ok {
+			r.log.Debug("skipping top holders of unknown arkham chain", zap.String("chain", chain), zap.String("symbol", symbol))
+			continue
+		}
+		for _, holder := range chainHolders {
+			holders = append(holders, NativeTokenTransferTopHolder{
+				Address: holder.Address.Address,
+				ChainID: chainID,
+				Volume:  decimal.NewFromFloat(holder.Usd),
+			})
+		}
+	}
+
+	// Add holders from solana with calculated price, for the tokens deployed on solana
+	if solanaToken != nil {
+		mint, err := hex.DecodeString(solanaToken.TokenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid solana token address %s: %w", solanaToken.TokenAddress, err)
+		}
+
+		holderSolana, err := r.getHolderByTokenForSolana(ctx, base58.Encode(mint))
+		if err != nil {
+			return nil, err
+		}
+
+		tokenPrice, err := r.notionalCache.Get(solanaToken.GetTokenID())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, holder := range holderSolana.Result.Value {
+			holders = append(holders, NativeTokenTransferTopHolder{
+				Address: holder.Address,
+				ChainID: sdk.ChainIDSolana,
+				Volume:  decimal.NewFromFloat(holder.UIAmount).Mul(tokenPrice.NotionalUsd),
+			})
+		}
 	}
 
 	// Sort holders by price in descending order
@@ -155,8 +169,8 @@ func (r *HolderRepository) getNativeTokenTransferTopHolder(ctx context.Context,
 	return holders, nil
 }
 
-func (r *HolderRepository) getHolderByWormholeTokenForEVM(ctx context.Context) (*topHoldersEVMResponse, error) {
-	url := fmt.Sprintf("%s/token/holders/wormhole", r.arkhamUrl)
+func (r *HolderRepository) getHolderByTokenForEVM(ctx context.Context, coingeckoID string) (*topHoldersEVMResponse, error) {
+	url := fmt.Sprintf("%s/token/holders/%s", r.arkhamUrl, coingeckoID)
 	resp, err := r.client.R().
 		SetContext(ctx).
 		SetHeader("API-Key", r.artkhamApiKey).
@@ -178,15 +192,15 @@ func (r *HolderRepository) getHolderByWormholeTokenForEVM(ctx context.Context) (
 	return result, nil
 }
 
-func (r *HolderRepository) getHolderByWormholeTokenForSolana(ctx context.Context) (*topHoldersSolanaResponse, error) {
+func (r *HolderRepository) getHolderByTokenForSolana(ctx context.Context, mint string) (*topHoldersSolanaResponse, error) {
 	resp, err := r.client.R().
 		SetContext(ctx).
-		SetBody(`{
+		SetBody(map[string]any{
 			"jsonrpc": "2.0",
-			"id": 1,
-			"method": "getTokenLargestAccounts",
-			"params": [ "85VBFQZC9TZkfaptBWjvUw7YbZjy52A6mjtPGjstQAmQ"]
-		}`).
+			"id":      1,
+			"method":  "getTokenLargestAccounts",
+			"params":  []string{mint},
+		}).
 		SetResult(&topHoldersSolanaResponse{}).
 		Post(r.solanaUrl)
 
@@ -205,27 +219,16 @@ func (r *HolderRepository) getHolderByWormholeTokenForSolana(ctx context.Context
 	return result, nil
 }
 
+type arkhamHolder struct {
+	Address struct {
+		Address string `json:"address"`
+	} `json:"address"`
+	Usd float64 `json:"usd"`
+}
+
+// topHoldersEVMResponse is the arkham top holders response, the holders are grouped by chain name.
 type topHoldersEVMResponse struct {
-	AddressTopHolders struct {
-		ArbitrumOne []struct {
-			Address struct {
-				Address string `json:"address"`
-			} `json:"address"`
-			Usd float64 `json:"usd"`
-		} `json:"arbitrum_one"`
-		Base []struct {
-			Address struct {
-				Address string `json:"address"`
-			} `json:"address"`
-			Usd float64 `json:"usd"`
-		} `json:"base"`
-		Ethereum []struct {
-			Address struct {
-				Address string `json:"address"`
-			} `json:"address"`
-			Usd float64 `json:"usd"`
-		} `json:"ethereum"`
-	} `json:"addressTopHolders"`
+	AddressTopHolders map[string][]arkhamHolder `json:"addressTopHolders"`
 }
 
 type topHoldersSolanaResponse struct {
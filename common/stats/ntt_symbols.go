@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+)
+
+const nttSymbols = "wormscan:ntt-symbols"
+
+// NTT stats computed by symbol.
+const (
+	NTTStatTopAddress = "top-address"
+	NTTStatTopHolder  = "top-holder"
+	NTTStatMedian     = "median"
+)
+
+// NTTStats are the NTT stats computed by symbol.
+var NTTStats = []string{NTTStatTopAddress, NTTStatTopHolder, NTTStatMedian}
+
+// NTTSymbolRepository stores the symbols whose NTT stats are available, by stat.
+type NTTSymbolRepository struct {
+	cache cache.Cache
+}
+
+// NewNTTSymbolRepository creates a new instance of NTTSymbolRepository.
+func NewNTTSymbolRepository(cache cache.Cache) *NTTSymbolRepository {
+	return &NTTSymbolRepository{cache: cache}
+}
+
+// SetSymbols stores the symbols whose stat was computed.
+func (r *NTTSymbolRepository) SetSymbols(ctx context.Context, stat string, symbols []string) error {
+	key := fmt.Sprintf("%s:%s", nttSymbols, stat)
+	cr := cachedResult[[]string]{Timestamp: time.Now(), Result: symbols}
+	return r.cache.Set(ctx, key, cr, 0)
+}
+
+// GetSymbols returns the symbols whose stat is available.
+func (r *NTTSymbolRepository) GetSymbols(ctx context.Context, stat string) ([]string, error) {
+	key := fmt.Sprintf("%s:%s", nttSymbols, stat)
+	result, err := r.cache.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedResult[[]string]
+	if err := json.Unmarshal([]byte(result), &cached); err != nil {
+		return nil, err
+	}
+	return cached.Result, nil
+}
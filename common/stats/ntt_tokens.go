@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// nttTokensLookback is subtracted from the last update of the stored NTT tokens when the new parsed NTT VAAs
+// are read, so that the VAAs parsed while the previous run was reading are not missed.
+const nttTokensLookback = time.Hour
+
+// NTTToken is a token transferred with Native Token Transfers.
+type NTTToken struct {
+	ID           string      `bson:"_id"`
+	TokenChain   sdk.ChainID `bson:"tokenChain"`
+	TokenAddress string      `bson:"tokenAddress"`
+	// UpdatedAt is the last update of the parsed NTT VAAs of the token.
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// NTTTokenFinder discovers the tokens transferred with Native Token Transfers.
+type NTTTokenFinder struct {
+	parsedVaa     *mongo.Collection
+	tokens        *mongo.Collection
+	tokenProvider *domain.TokenProvider
+	logger        *zap.Logger
+}
+
+// NewNTTTokenFinder creates a new instance of NTTTokenFinder.
+func NewNTTTokenFinder(db *mongo.Database, tokenProvider *domain.TokenProvider, logger *zap.Logger) *NTTTokenFinder {
+	return &NTTTokenFinder{
+		parsedVaa:     db.Collection(repository.ParsedVaa),
+		tokens:        db.Collection(repository.NTTTokens),
+		tokenProvider: tokenProvider,
+		logger:        logger,
+	}
+}
+
+type nttTokenGroup struct {
+	ID struct {
+		TokenChain   sdk.ChainID `bson:"tokenChain"`
+		TokenAddress string      `bson:"tokenAddress"`
+	} `bson:"_id"`
+	UpdatedAt time.Time `bson:"updatedAt"`
+}
+
+// FindSymbols returns the sorted symbols of the tokens of the parsed NTT VAAs.
+// The tokens are stored, so that only the VAAs parsed since the previous run are read.
+// The tokens that are not in the token registry are skipped, the stats are computed by symbol.
+func (f *NTTTokenFinder) FindSymbols(ctx context.Context) ([]string, error) {
+	if err := f.updateTokens(ctx); err != nil {
+		return nil, err
+	}
+
+	cur, err := f.tokens.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ntt tokens: %w", err)
+	}
+	var tokens []NTTToken
+	if err := cur.All(ctx, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode ntt tokens: %w", err)
+	}
+	return symbolsOf(tokens, f.tokenProvider, f.logger), nil
+}
+
+// updateTokens stores the tokens of the NTT VAAs parsed since the last update of the stored tokens.
+func (f *NTTTokenFinder) updateTokens(ctx context.Context) error {
+	since, err := f.lastUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	cur, err := f.parsedVaa.Aggregate(ctx, nttTokensPipeline(since))
+	if err != nil {
+		return fmt.Errorf("failed to find new ntt tokens: %w", err)
+	}
+	var groups []nttTokenGroup
+	if err := cur.All(ctx, &groups); err != nil {
+		return fmt.Errorf("failed to decode new ntt tokens: %w", err)
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(groups))
+	for _, g := range groups {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: nttTokenID(g.ID.TokenChain, g.ID.TokenAddress)}}).
+			SetUpdate(bson.D{
+				{Key: "$set", Value: bson.D{
+					{Key: "tokenChain", Value: g.ID.TokenChain},
+					{Key: "tokenAddress", Value: g.ID.TokenAddress},
+				}},
+				{Key: "$max", Value: bson.D{{Key: "updatedAt", Value: g.UpdatedAt}}},
+			}).
+			SetUpsert(true))
+	}
+	if _, err := f.tokens.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to store ntt tokens: %w", err)
+	}
+	return nil
+}
+
+// lastUpdate returns the time from which the parsed NTT VAAs are read, or nil if no token is stored yet.
+func (f *NTTTokenFinder) lastUpdate(ctx context.Context) (*time.Time, error) {
+	var last NTTToken
+	opts := options.FindOne().SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+	err := f.tokens.FindOne(ctx, bson.D{}, opts).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the last ntt token: %w", err)
+	}
+	since := last.UpdatedAt.Add(-nttTokensLookback)
+	return &since, nil
+}
+
+// nttTokensPipeline groups by token the NTT VAAs parsed since the given time, or every NTT VAA if since is nil.
+// The VAAs parsed before updatedAt was stored use the time of the VAA.
+func nttTokensPipeline(since *time.Time) mongo.Pipeline {
+	match := bson.D{{Key: "rawStandardizedProperties.appIds", Value: domain.AppIdNTT}}
+	if since != nil {
+		match = append(match, bson.E{Key: "updatedAt", Value: bson.D{{Key: "$gte", Value: *since}}})
+	}
+	return mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "tokenChain", Value: "$rawStandardizedProperties.tokenChain"},
+				{Key: "tokenAddress", Value: "$rawStandardizedProperties.tokenAddress"},
+			}},
+			{Key: "updatedAt", Value: bson.D{{Key: "$max", Value: bson.D{
+				{Key: "$ifNull", Value: bson.A{"$updatedAt", "$timestamp"}},
+			}}}},
+		}}},
+	}
+}
+
+func nttTokenID(tokenChain sdk.ChainID, tokenAddress string) string {
+	return fmt.Sprintf("%d/%s", tokenChain, tokenAddress)
+}
+
+// symbolsOf returns the sorted, upper case, symbols of the tokens in the token registry.
+func symbolsOf(tokens []NTTToken, tokenProvider *domain.TokenProvider, logger *zap.Logger) []string {
+	unique := make(map[string]bool)
+	for _, t := range tokens {
+		metadata, ok := tokenProvider.GetTokenByAddress(t.TokenChain, t.TokenAddress)
+		if !ok {
+			logger.Debug("ntt token not found in the token registry",
+				zap.Uint16("tokenChain", uint16(t.TokenChain)),
+				zap.String("tokenAddress", t.TokenAddress))
+			continue
+		}
+		unique[strings.ToUpper(metadata.Symbol.String())] = true
+	}
+
+	symbols := make([]string, 0, len(unique))
+	for symbol := range unique {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/zap"
+)
+
+func TestNTTTokensPipeline(t *testing.T) {
+	// without stored tokens, every NTT VAA is read
+	match := nttTokensPipeline(nil)[0][0].Value.(bson.D)
+	assert.Equal(t, bson.D{{Key: "rawStandardizedProperties.appIds", Value: domain.AppIdNTT}}, match)
+
+	// with stored tokens, only the VAAs parsed since the last update are read
+	since := time.Unix(1700000000, 0)
+	match = nttTokensPipeline(&since)[0][0].Value.(bson.D)
+	assert.Equal(t, bson.D{
+		{Key: "rawStandardizedProperties.appIds", Value: domain.AppIdNTT},
+		{Key: "updatedAt", Value: bson.D{{Key: "$gte", Value: since}}},
+	}, match)
+}
+
+func TestSymbolsOf(t *testing.T) {
+	tokenProvider := domain.NewTokenProvider(domain.P2pMainNet)
+	tokens := []NTTToken{
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: "000000000000000000000000576e2bed8f7b46d34016198911cdf9886f78bea7"},
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: "000000000000000000000000ac6db8954b73ebf10e84278ac8b9b22a781615d9"},
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: "000000000000000000000000576e2bed8f7b46d34016198911cdf9886f78bea7"},
+		// not in the token registry
+		{TokenChain: sdk.ChainIDEthereum, TokenAddress: "0000000000000000000000000000000000000000000000000000000000000001"},
+	}
+
+	assert.Equal(t, []string{"BWB", "TRUMP"}, symbolsOf(tokens, tokenProvider, zap.NewNop()))
+	assert.Equal(t, []string{}, symbolsOf(nil, tokenProvider, zap.NewNop()))
+}
@@ -0,0 +1,16 @@
+package utils
+
+import "math"
+
+// Percentile returns the p-th percentile of the sorted values using the nearest-rank method,
+// or zero if there are no values.
+func Percentile[T ~int64 | ~float64](sorted []T, p float64) T {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, float64(5), Percentile(values, 50))
+	assert.Equal(t, float64(9), Percentile(values, 90))
+	assert.Equal(t, float64(10), Percentile(values, 99))
+	assert.Equal(t, float64(0), Percentile([]float64(nil), 50))
+
+	seconds := []int64{30, 60, 90}
+	assert.Equal(t, int64(60), Percentile(seconds, 50))
+	assert.Equal(t, int64(90), Percentile(seconds, 99))
+	assert.Equal(t, int64(30), Percentile(seconds, 0))
+}
@@ -0,0 +1,39 @@
+package explorer
+
+import (
+	"context"
+
+	"github.com/joho/godotenv"
+	"github.com/sethvargo/go-envconfig"
+)
+
+// Configuration represents the configuration of explorer-dev with the default values.
+type Configuration struct {
+	Environment   string `env:"ENVIRONMENT,default=local"`
+	LogLevel      string `env:"LOG_LEVEL,default=INFO"`
+	P2pNetwork    string `env:"P2P_NETWORK,default=mainnet"`
+	MongoURI      string `env:"MONGODB_URI,required"`
+	MongoDatabase string `env:"MONGODB_DATABASE,default=wormscan-dev"`
+	// ApiPort is the port of the api.
+	ApiPort int `env:"API_PORT,default=8000"`
+	// BasePort is the first port of the health and metrics servers of the other services.
+	BasePort int `env:"BASE_PORT,default=8100"`
+	// VaaFixtures is the file of the VAAs pushed to fly on start.
+	VaaFixtures string `env:"VAA_FIXTURES,default=testdata/vaas.json"`
+	// RpcFixtures is the file of the recorded RPC responses used by the tx-tracker.
+	RpcFixtures string `env:"RPC_FIXTURES,default=testdata/rpc.json"`
+	// ParserFixtures is the directory of the recorded responses of the VAA payload parser.
+	ParserFixtures string `env:"PARSER_FIXTURES,default=testdata/parser"`
+}
+
+// New creates a configuration with the values from .env file and environment variables.
+func New(ctx context.Context) (*Configuration, error) {
+	_ = godotenv.Load(".env", "../.env")
+
+	var configuration Configuration
+	if err := envconfig.Process(ctx, &configuration); err != nil {
+		return nil, err
+	}
+
+	return &configuration, nil
+}
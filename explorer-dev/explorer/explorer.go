@@ -0,0 +1,308 @@
+// Package explorer runs the services of the explorer in a single process with in-memory queues,
+// to develop and test the whole pipeline without the p2p network, AWS, redis or RPC nodes.
+//
+// fly receives the VAAs of a fixture file instead of the p2p network, the pipeline publishes them
+// to an in-memory queue consumed by the parser, the tx-tracker and analytics, and the api serves
+// the results. The tx-tracker and the VAA payload parser use recorded responses.
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	analyticsService "github.com/wormhole-foundation/wormhole-explorer/analytics/cmd/service"
+	analyticsConfig "github.com/wormhole-foundation/wormhole-explorer/analytics/config"
+	"github.com/wormhole-foundation/wormhole-explorer/analytics/metric"
+	apiService "github.com/wormhole-foundation/wormhole-explorer/api/cmd/service"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	flyService "github.com/wormhole-foundation/wormhole-explorer/fly/cmd/service"
+	flyConfig "github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/txhash"
+	parserService "github.com/wormhole-foundation/wormhole-explorer/parser/cmd/service"
+	parserConfig "github.com/wormhole-foundation/wormhole-explorer/parser/config"
+	pipelineService "github.com/wormhole-foundation/wormhole-explorer/pipeline/cmd/service"
+	pipelineConfig "github.com/wormhole-foundation/wormhole-explorer/pipeline/config"
+	txTrackerService "github.com/wormhole-foundation/wormhole-explorer/txtracker/cmd/service"
+	txTrackerConfig "github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// queue names shared by the services.
+const (
+	pipelineQueueName      = "wormscan-pipeline"
+	notificationsQueueName = "wormscan-notifications"
+)
+
+// offsets of the ports of the health and metrics servers from the base port.
+const (
+	flyPortOffset = iota + 1
+	pipelinePortOffset
+	parserPortOffset
+	txTrackerPortOffset
+	analyticsPortOffset
+)
+
+// Explorer is the set of services running in the process.
+type Explorer struct {
+	fixtures  *FixtureServer
+	fly       *flyService.Service
+	parser    *parserService.Service
+	txTracker *txTrackerService.Service
+	analytics *analyticsService.Service
+	pipeline  *pipelineService.Service
+	api       *apiService.Service
+	logger    *zap.Logger
+}
+
+// Start starts the services. The consumers of the pipeline queue are started before the pipeline,
+// so every consumer receives the VAAs stored after the start. Every service registers its metrics
+// in its own registry, since some of them use the same names.
+func Start(ctx context.Context, cfg *Configuration, logger *zap.Logger) (*Explorer, error) {
+	e := &Explorer{logger: logger}
+
+	fixtures, err := NewFixtureServer(cfg.RpcFixtures, cfg.ParserFixtures, logger.Named("fixtures"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+	fixtures.Start()
+	e.fixtures = fixtures
+
+	e.fly, err = flyService.Start(ctx, newFlyConfig(cfg), logger.Named("fly"), prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start fly: %w", err)
+	}
+
+	e.parser, err = parserService.Start(ctx, newParserConfig(cfg, fixtures), logger.Named("parser"), prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start parser: %w", err)
+	}
+
+	txTrackerCfg, err := newTxTrackerConfig(cfg, fixtures)
+	if err != nil {
+		e.Stop()
+		return nil, err
+	}
+	e.txTracker, err = txTrackerService.Start(ctx, txTrackerCfg, logger.Named("tx-tracker"), prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start tx-tracker: %w", err)
+	}
+
+	e.analytics, err = analyticsService.Start(ctx, newAnalyticsConfig(cfg, fixtures), logger.Named("analytics"), prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start analytics: %w", err)
+	}
+
+	e.pipeline, err = pipelineService.Start(ctx, newPipelineConfig(cfg), logger.Named("pipeline"), prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start pipeline: %w", err)
+	}
+
+	apiCfg, err := apiService.LoadConfig()
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to load api config: %w", err)
+	}
+	apiCfg.DB.URL = cfg.MongoURI
+	apiCfg.DB.Name = cfg.MongoDatabase
+	apiCfg.PORT = cfg.ApiPort
+	apiCfg.LogLevel = cfg.LogLevel
+	apiCfg.P2pNetwork = cfg.P2pNetwork
+	apiCfg.Environment = cfg.Environment
+	apiCfg.RunMode = "DEVELOPMENT"
+	apiCfg.Cache.Enabled = false
+	apiCfg.RateLimit.Enabled = false
+	apiCfg.VaaPayloadParser.Enabled = true
+	apiCfg.VaaPayloadParser.URL = fixtures.URL()
+	apiCfg.VaaPayloadParser.Timeout = 10
+	e.api, err = apiService.Start(ctx, apiCfg, logger.Named("api"), nil, prometheus.NewRegistry())
+	if err != nil {
+		e.Stop()
+		return nil, fmt.Errorf("failed to start api: %w", err)
+	}
+
+	return e, nil
+}
+
+// PushVAA pushes a VAA to fly as if it was received from the p2p network.
+// The tx hash is stored as if fly had received the observations of the VAA.
+func (e *Explorer) PushVAA(ctx context.Context, fixture VaaFixture) error {
+	vaa, err := sdk.Unmarshal(fixture.Vaa)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal vaa: %w", err)
+	}
+
+	if fixture.TxHash != "" {
+		err = e.fly.TxHashStore.Set(ctx, domain.CreateUniqueVaaID(vaa), txhash.TxHash{
+			ChainID:  vaa.EmitterChain,
+			Emitter:  vaa.EmitterAddress.String(),
+			Sequence: strconv.FormatUint(vaa.Sequence, 10),
+			TxHash:   fixture.TxHash,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store tx hash: %w", err)
+		}
+	}
+
+	select {
+	case e.fly.Channels.SignedInChannel <- &gossipv1.SignedVAAWithQuorum{Vaa: fixture.Vaa}:
+		e.logger.Info("pushed vaa", zap.String("vaaId", vaa.MessageID()))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stop stops the services in the reverse order of the start.
+func (e *Explorer) Stop() {
+	if e.api != nil {
+		e.api.Stop()
+	}
+	if e.pipeline != nil {
+		e.pipeline.Stop()
+	}
+	if e.analytics != nil {
+		e.analytics.Stop()
+	}
+	if e.txTracker != nil {
+		e.txTracker.Stop()
+	}
+	if e.parser != nil {
+		e.parser.Stop()
+	}
+	if e.fly != nil {
+		e.fly.Stop()
+	}
+	if e.fixtures != nil {
+		e.fixtures.Stop()
+	}
+}
+
+func newQueueConfig(group string) queue.Config {
+	return queue.Config{
+		QueueType:               queue.TypeMemory,
+		QueueGroup:              group,
+		QueueAckDeadlineSeconds: 30,
+		QueueMaxDeliveries:      3,
+		QueueMaxMessages:        10,
+	}
+}
+
+func newFlyConfig(cfg *Configuration) *flyConfig.Configuration {
+	dedup := flyConfig.Cache{ExpirationInSeconds: 30, NumKeys: 10000, MaxCostsInMB: 10}
+	return &flyConfig.Configuration{
+		P2pNetwork:                cfg.P2pNetwork,
+		Environment:               cfg.Environment,
+		LogLevel:                  cfg.LogLevel,
+		MongoUri:                  cfg.MongoURI,
+		MongoDatabase:             cfg.MongoDatabase,
+		ObservationsChannelSize:   100,
+		VaasChannelSize:           100,
+		HeartbeatsChannelSize:     100,
+		GovernorConfigChannelSize: 100,
+		GovernorStatusChannelSize: 100,
+		VaasWorkersSize:           1,
+		ObservationsWorkersSize:   1,
+		ApiPort:                   uint(cfg.BasePort + flyPortOffset),
+		MaxHealthTimeSeconds:      60,
+		IsLocal:                   true,
+		ObservationsDedup:         dedup,
+		ObservationsTxHash:        dedup,
+		VaasDedup:                 dedup,
+		VaasPythDedup:             dedup,
+	}
+}
+
+func newPipelineConfig(cfg *Configuration) *pipelineConfig.Configuration {
+	return &pipelineConfig.Configuration{
+		Environment:       cfg.Environment,
+		LogLevel:          cfg.LogLevel,
+		Port:              strconv.Itoa(cfg.BasePort + pipelinePortOffset),
+		P2pNetwork:        cfg.P2pNetwork,
+		MongoURI:          cfg.MongoURI,
+		MongoDatabase:     cfg.MongoDatabase,
+		PipelineQueueName: pipelineQueueName,
+		Config:            newQueueConfig("pipeline"),
+	}
+}
+
+func newParserConfig(cfg *Configuration, fixtures *FixtureServer) *parserConfig.ServiceConfiguration {
+	return &parserConfig.ServiceConfiguration{
+		Environment:             cfg.Environment,
+		LogLevel:                cfg.LogLevel,
+		Port:                    strconv.Itoa(cfg.BasePort + parserPortOffset),
+		ConsumerMode:            "QUEUE",
+		MongoURI:                cfg.MongoURI,
+		MongoDatabase:           cfg.MongoDatabase,
+		PipelineQueueName:       pipelineQueueName,
+		NotificationsQueueName:  notificationsQueueName,
+		VaaPayloadParserURL:     fixtures.URL(),
+		VaaPayloadParserTimeout: 10,
+		P2pNetwork:              cfg.P2pNetwork,
+		Config:                  newQueueConfig("parser"),
+	}
+}
+
+func newTxTrackerConfig(cfg *Configuration, fixtures *FixtureServer) (*txTrackerConfig.ServiceSettings, error) {
+	chains, err := fixtures.Chains()
+	if err != nil {
+		return nil, err
+	}
+	var rpcProviders []txTrackerConfig.ChainRpcProviderSettings
+	for _, chainID := range chains {
+		rpcProviders = append(rpcProviders, txTrackerConfig.ChainRpcProviderSettings{
+			ChainId: uint16(chainID),
+			Chain:   chainID.String(),
+			RpcSettings: []txTrackerConfig.RpcSettings{
+				{Url: fixtures.RpcURL(chainID), RequestPerMinute: 600, Priority: 1},
+			},
+		})
+	}
+
+	return &txTrackerConfig.ServiceSettings{
+		MonitoringPort:      strconv.Itoa(cfg.BasePort + txTrackerPortOffset),
+		Environment:         cfg.Environment,
+		LogLevel:            cfg.LogLevel,
+		P2pNetwork:          cfg.P2pNetwork,
+		ConsumerWorkersSize: 1,
+		MongodbSettings: txTrackerConfig.MongodbSettings{
+			MongodbUri:      cfg.MongoURI,
+			MongodbDatabase: cfg.MongoDatabase,
+		},
+		Config:                 newQueueConfig("tx-tracker"),
+		PipelineQueueName:      pipelineQueueName,
+		NotificationsQueueName: notificationsQueueName,
+		RpcProviderSettingsJson: &txTrackerConfig.RpcProviderSettingsJson{
+			RpcProviders: rpcProviders,
+		},
+	}, nil
+}
+
+func newAnalyticsConfig(cfg *Configuration, fixtures *FixtureServer) *analyticsConfig.Configuration {
+	return &analyticsConfig.Configuration{
+		Environment:             cfg.Environment,
+		LogLevel:                cfg.LogLevel,
+		Port:                    strconv.Itoa(cfg.BasePort + analyticsPortOffset),
+		ConsumerMode:            "QUEUE",
+		PipelineQueueName:       pipelineQueueName,
+		NotificationsQueueName:  notificationsQueueName,
+		MongodbURI:              cfg.MongoURI,
+		MongodbDatabase:         cfg.MongoDatabase,
+		P2pNetwork:              cfg.P2pNetwork,
+		VaaPayloadParserURL:     fixtures.URL(),
+		VaaPayloadParserTimeout: 10,
+		MetricSinksConfiguration: analyticsConfig.MetricSinksConfiguration{
+			MetricSinks: metric.SinkNoop,
+		},
+		Config: newQueueConfig("analytics"),
+	}
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package explorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// The e2e test uses the MongoDB replica set of docker-compose.yml:
+//
+//	docker compose -f explorer-dev/docker-compose.yml up -d
+//	EXPLORER_DEV_TEST_MONGODB_URI="mongodb://localhost:27017/?replicaSet=rs0&directConnection=true" \
+//	go test -tags integration ./explorer/...
+
+type operationResponse struct {
+	ID          string `json:"id"`
+	SourceChain *struct {
+		ChainID     uint16 `json:"chainId"`
+		From        string `json:"from"`
+		Transaction struct {
+			TxHash string `json:"txHash"`
+		} `json:"transaction"`
+	} `json:"sourceChain"`
+	Content *struct {
+		Payload                map[string]any `json:"payload"`
+		StandardizedProperties *struct {
+			AppIds      []string `json:"appIds"`
+			FromChain   uint16   `json:"fromChain"`
+			FromAddress string   `json:"fromAddress"`
+		} `json:"standarizedProperties"`
+	} `json:"content"`
+}
+
+func TestExplorer_PushVAA(t *testing.T) {
+	uri := os.Getenv("EXPLORER_DEV_TEST_MONGODB_URI")
+	if uri == "" {
+		t.Skip("EXPLORER_DEV_TEST_MONGODB_URI is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cfg := &Configuration{
+		Environment:    "test",
+		LogLevel:       "INFO",
+		P2pNetwork:     "mainnet",
+		MongoURI:       uri,
+		MongoDatabase:  fmt.Sprintf("wormscan-e2e-%d", time.Now().UnixNano()),
+		ApiPort:        18000,
+		BasePort:       18100,
+		RpcFixtures:    "../testdata/rpc.json",
+		ParserFixtures: "../testdata/parser",
+	}
+
+	fixtures, err := LoadVaaFixtures("../testdata/vaas.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	e, err := Start(ctx, cfg, zap.NewNop())
+	require.NoError(t, err)
+	defer e.Stop()
+
+	require.NoError(t, e.PushVAA(ctx, fixtures[0]))
+
+	// the operation is complete once the tx-tracker and the parser have processed the VAA.
+	url := fmt.Sprintf("http://localhost:%d/api/v1/operations/6/000000000000000000000000c63e43e2f09537a2b07fba1e02c6f4163a956525/733", cfg.ApiPort)
+	var operation operationResponse
+	require.Eventually(t, func() bool {
+		res, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return false
+		}
+		operation = operationResponse{}
+		if err := json.NewDecoder(res.Body).Decode(&operation); err != nil {
+			return false
+		}
+		return operation.SourceChain != nil && operation.Content != nil && operation.Content.StandardizedProperties != nil
+	}, time.Minute, 500*time.Millisecond)
+
+	assert.Equal(t, "6/000000000000000000000000c63e43e2f09537a2b07fba1e02c6f4163a956525/733", operation.ID)
+	assert.Equal(t, uint16(6), operation.SourceChain.ChainID)
+	assert.Equal(t, "0x5131e7e337283395f9609d17f6d230a1fe0dd16b", operation.SourceChain.From)
+	assert.Equal(t, "0x"+fixtures[0].TxHash, operation.SourceChain.Transaction.TxHash)
+	assert.Equal(t, []string{"UNKNOWN"}, operation.Content.StandardizedProperties.AppIds)
+	assert.Equal(t, uint16(6), operation.Content.StandardizedProperties.FromChain)
+	assert.Equal(t, "0x5131e7e337283395f9609d17f6d230a1fe0dd16b", operation.Content.StandardizedProperties.FromAddress)
+	assert.Equal(t, float64(268), operation.Content.Payload["payloadLength"])
+}
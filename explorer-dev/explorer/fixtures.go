@@ -0,0 +1,191 @@
+package explorer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// VaaFixture is a VAA pushed to fly with the tx hash of its observation,
+// which fly receives from the p2p network when it runs as service.
+type VaaFixture struct {
+	Vaa    []byte `json:"vaa"`
+	TxHash string `json:"txHash"`
+}
+
+// LoadVaaFixtures loads the VAA fixtures of the file.
+func LoadVaaFixtures(path string) ([]VaaFixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []VaaFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to decode vaa fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// rpcFixtures are the recorded results of the RPC calls indexed by chain ID, method and first parameter.
+type rpcFixtures map[string]map[string]map[string]json.RawMessage
+
+type rpcRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []any           `json:"params"`
+}
+
+type rpcResponse struct {
+	JsonRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// FixtureServer serves the recorded responses of the RPC nodes and the VAA payload parser.
+type FixtureServer struct {
+	server   *http.Server
+	listener net.Listener
+	rpc      rpcFixtures
+	logger   *zap.Logger
+}
+
+// NewFixtureServer creates a server of the RPC fixtures file and the parser fixtures directory,
+// listening on a random local port.
+func NewFixtureServer(rpcPath, parserDir string, logger *zap.Logger) (*FixtureServer, error) {
+	data, err := os.ReadFile(rpcPath)
+	if err != nil {
+		return nil, err
+	}
+	var rpc rpcFixtures
+	if err := json.Unmarshal(data, &rpc); err != nil {
+		return nil, fmt.Errorf("failed to decode rpc fixtures %s: %w", rpcPath, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rpc/", rpcHandler(rpc, logger))
+	mux.HandleFunc("/vaas/parse", parserHandler(parserDir, logger))
+
+	return &FixtureServer{
+		server:   &http.Server{Handler: mux},
+		listener: listener,
+		rpc:      rpc,
+		logger:   logger,
+	}, nil
+}
+
+// Start serves the fixtures in a separate goroutine.
+func (s *FixtureServer) Start() {
+	go func() {
+		if err := s.server.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("fixture server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// URL returns the base url of the server.
+func (s *FixtureServer) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// RpcURL returns the url of the RPC node of the chain.
+func (s *FixtureServer) RpcURL(chainID sdk.ChainID) string {
+	return fmt.Sprintf("%s/rpc/%d", s.URL(), chainID)
+}
+
+// Chains returns the chains with recorded RPC calls.
+func (s *FixtureServer) Chains() ([]sdk.ChainID, error) {
+	var chains []sdk.ChainID
+	for key := range s.rpc {
+		chainID, err := strconv.ParseUint(key, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain id %s in rpc fixtures: %w", key, err)
+		}
+		chains = append(chains, sdk.ChainID(chainID))
+	}
+	return chains, nil
+}
+
+// Stop stops the server.
+func (s *FixtureServer) Stop() {
+	_ = s.server.Close()
+}
+
+// rpcHandler answers the JSON-RPC calls with the recorded results, or null when there is none.
+func rpcHandler(fixtures rpcFixtures, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		chainID := strings.TrimPrefix(r.URL.Path, "/rpc/")
+
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result := json.RawMessage("null")
+		if len(req.Params) > 0 {
+			key := strings.ToLower(fmt.Sprint(req.Params[0]))
+			if recorded, ok := fixtures[chainID][req.Method][key]; ok {
+				result = recorded
+			} else {
+				logger.Warn("rpc fixture not found", zap.String("chainId", chainID),
+					zap.String("method", req.Method), zap.String("param", key))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(rpcResponse{JsonRPC: "2.0", ID: req.ID, Result: result})
+	}
+}
+
+// parserHandler answers the parse requests with the file <chain>-<emitter>-<sequence>.json of the directory.
+func parserHandler(dir string, logger *zap.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		vaa, err := sdk.Unmarshal(data)
+		if err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		name := fmt.Sprintf("%d-%s-%d.json", vaa.EmitterChain, vaa.EmitterAddress.String(), vaa.Sequence)
+		parsed, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			logger.Warn("parser fixture not found", zap.String("file", name))
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(parsed)
+	}
+}
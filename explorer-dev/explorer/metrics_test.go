@@ -0,0 +1,75 @@
+package explorer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	parserServer "github.com/wormhole-foundation/wormhole-explorer/parser/http/infrastructure"
+	pipelineServer "github.com/wormhole-foundation/wormhole-explorer/pipeline/http/infrastructure"
+	"go.uber.org/zap"
+)
+
+func freePort(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+}
+
+// get waits until the server listens and returns the body of the response to the path.
+func get(t *testing.T, port, path string) string {
+	var body string
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%s%s", port, path))
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		body = string(b)
+		return err == nil && resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	return body
+}
+
+func TestServicesServeTheMetricsOfTheirRegistry(t *testing.T) {
+	logger := zap.NewNop()
+
+	pipelineRegistry := prometheus.NewRegistry()
+	pipelinePort := freePort(t)
+	pipeline := pipelineServer.NewServer(logger, pipelinePort, false, pipelineRegistry)
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	parserRegistry := prometheus.NewRegistry()
+	parserPort := freePort(t)
+	parser := parserServer.NewServer(logger, parserPort, false, nil, parserRegistry)
+	parser.Start()
+	defer parser.Stop()
+
+	get(t, pipelinePort, "/api/health")
+	get(t, parserPort, "/api/health")
+
+	// the default registry is not used, so the services running in the process don't share their metrics.
+	pipelineMetrics := get(t, pipelinePort, "/metrics")
+	assert.Contains(t, pipelineMetrics, `service="wormscan-pipeline"`)
+	assert.NotContains(t, pipelineMetrics, `service="wormscan-parser"`)
+
+	parserMetrics := get(t, parserPort, "/metrics")
+	assert.Contains(t, parserMetrics, `service="wormscan-parser"`)
+	assert.NotContains(t, parserMetrics, `service="wormscan-pipeline"`)
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, f := range families {
+		assert.NotEqual(t, "http_requests_total", f.GetName())
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	"github.com/wormhole-foundation/wormhole-explorer/explorer-dev/explorer"
+	"go.uber.org/zap"
+)
+
+func main() {
+
+	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
+	defer rootCtxCancel()
+
+	cfg, err := explorer.New(rootCtx)
+	if err != nil {
+		log.Fatal("Error creating config", err)
+	}
+
+	logger := logger.New("wormhole-explorer-dev", logger.WithLevel(cfg.LogLevel))
+
+	logger.Info("Starting wormhole-explorer-dev ...")
+
+	fixtures, err := explorer.LoadVaaFixtures(cfg.VaaFixtures)
+	if err != nil {
+		logger.Fatal("failed to load vaa fixtures", zap.Error(err))
+	}
+
+	e, err := explorer.Start(rootCtx, cfg, logger)
+	if err != nil {
+		logger.Fatal("failed to start explorer", zap.Error(err))
+	}
+
+	for _, f := range fixtures {
+		if err := e.PushVAA(rootCtx, f); err != nil {
+			logger.Error("failed to push vaa", zap.Error(err))
+		}
+	}
+
+	logger.Info("Started wormhole-explorer-dev", zap.Int("vaas", len(fixtures)), zap.Int("apiPort", cfg.ApiPort))
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	rootCtxCancel()
+	e.Stop()
+
+	logger.Info("Finished wormhole-explorer-dev")
+}
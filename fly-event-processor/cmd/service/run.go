@@ -13,11 +13,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sns"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
 
 	governorConsumer "github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/consumer/governor"
 	vaaConsumer "github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/consumer/vaa"
@@ -26,6 +29,8 @@ import (
 
 	txTracker "github.com/wormhole-foundation/wormhole-explorer/common/client/txtracker"
 
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/guardian"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/producer"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/queue"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -37,6 +42,9 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
 )
 
+// guardianSetRefreshInterval is the interval at which the guardian set size is read from the database.
+const guardianSetRefreshInterval = 10 * time.Minute
+
 func Run() {
 	rootCtx, rootCtxCancel := context.WithCancel(context.Background())
 
@@ -76,7 +84,9 @@ func Run() {
 
 	// create a new processor
 	dupVaaProcessor := vaaprocessor.NewProcessor(guardianApiProviderPool, repository, logger, metrics)
-	governorProcessor := governorProcessor.NewProcessor(repository, createTxHashFunc, logger, metrics)
+	governorEventsPushFunc := newGovernorEventsPushFunc(rootCtx, cfg, logger)
+	guardianSetSize := newGuardianSetSize(rootCtx, cfg, db.Database, logger)
+	governorProcessor := governorProcessor.NewProcessor(repository, createTxHashFunc, governorEventsPushFunc, guardianSetSize.Size, logger, metrics)
 
 	// start serving /health and /ready endpoints
 	healthChecks, err := makeHealthChecks(rootCtx, cfg, db.Database)
@@ -170,6 +180,19 @@ func newSqsConsumer(ctx context.Context, cfg *config.ServiceConfiguration, sqsUr
 	return consumer, err
 }
 
+// newQueueReceiver creates a receiver of the configured queue backend.
+func newQueueReceiver(ctx context.Context, cfg *config.ServiceConfiguration, sqsUrl, queueName string, logger *zap.Logger) (common_queue.Receiver, error) {
+	if !cfg.IsSQS() {
+		return common_queue.NewReceiver(ctx, &cfg.Config, queueName)
+	}
+
+	sqsConsumer, err := newSqsConsumer(ctx, cfg, sqsUrl)
+	if err != nil {
+		return nil, err
+	}
+	return common_queue.NewSQSReceiver(sqsConsumer, logger), nil
+}
+
 func makeHealthChecks(
 	ctx context.Context,
 	cfg *config.ServiceConfiguration,
@@ -181,9 +204,9 @@ func makeHealthChecks(
 		return nil, err
 	}
 
-	plugins := []health.Check{
-		health.SQS(awsConfig, cfg.DuplicateVaaSQSUrl),
-		health.Mongo(db),
+	plugins := []health.Check{health.Mongo(db)}
+	if cfg.IsSQS() {
+		plugins = append(plugins, health.SQS(awsConfig, cfg.DuplicateVaaSQSUrl))
 	}
 
 	return plugins, nil
@@ -224,13 +247,13 @@ func newDuplicateVaaConsumeFunc(
 	logger *zap.Logger,
 ) queue.ConsumeFunc[queue.EventDuplicateVaa] {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.DuplicateVaaSQSUrl)
+	receiver, err := newQueueReceiver(ctx, cfg, cfg.DuplicateVaaSQSUrl, cfg.DuplicateVaaQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue receiver", zap.Error(err))
 	}
 
-	vaaQueue := queue.NewEventSqs[queue.EventDuplicateVaa](sqsConsumer,
-		metrics.IncDuplicatedVaaConsumedQueue, logger)
+	vaaQueue := common_queue.NewConsumer(receiver,
+		queue.NewEventConverter[queue.EventDuplicateVaa](metrics.IncDuplicatedVaaConsumedQueue), logger)
 	return vaaQueue.Consume
 }
 
@@ -241,16 +264,67 @@ func newGovernorStatusConsumeFunc(
 	logger *zap.Logger,
 ) queue.ConsumeFunc[queue.EventGovernorStatus] {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.GovernorSQSUrl)
+	receiver, err := newQueueReceiver(ctx, cfg, cfg.GovernorSQSUrl, cfg.GovernorQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		logger.Fatal("failed to create queue receiver", zap.Error(err))
 	}
 
-	governorStatusQueue := queue.NewEventSqs[queue.EventGovernorStatus](sqsConsumer,
-		metrics.IncGovernorStatusConsumedQueue, logger)
+	governorStatusQueue := common_queue.NewConsumer(receiver,
+		queue.NewEventConverter[queue.EventGovernorStatus](metrics.IncGovernorStatusConsumedQueue), logger)
 	return governorStatusQueue.Consume
 }
 
+// newGovernorEventsPushFunc creates the PushFunc of the governor vaa notifications.
+func newGovernorEventsPushFunc(
+	ctx context.Context,
+	cfg *config.ServiceConfiguration,
+	logger *zap.Logger,
+) producer.PushFunc {
+	if !cfg.IsSQS() {
+		if cfg.GovernorEventsQueueName == "" {
+			logger.Info("governor events notifications are disabled")
+			return producer.NewNoopProducer().Push
+		}
+		sender, err := common_queue.NewSender(ctx, &cfg.Config, cfg.GovernorEventsQueueName)
+		if err != nil {
+			logger.Fatal("failed to create queue sender", zap.Error(err))
+		}
+		return producer.NewQueueProducer(sender, logger).Push
+	}
+
+	if cfg.GovernorEventsSNSUrl == "" {
+		logger.Info("governor events notifications are disabled")
+		return producer.NewNoopProducer().Push
+	}
+
+	awsConfig, err := newAwsConfig(ctx, cfg)
+	if err != nil {
+		logger.Fatal("failed to create aws config", zap.Error(err))
+	}
+	snsProducer, err := sns.NewProducer(awsConfig, cfg.GovernorEventsSNSUrl)
+	if err != nil {
+		logger.Fatal("failed to create sns producer", zap.Error(err))
+	}
+	return producer.NewSNSProducer(snsProducer, logger).Push
+}
+
+// newGuardianSetSize creates and starts the provider of the number of guardians of the current guardian set.
+func newGuardianSetSize(
+	ctx context.Context,
+	cfg *config.ServiceConfiguration,
+	db *mongo.Database,
+	logger *zap.Logger,
+) *guardian.GuardianSetSize {
+
+	guardianSetRepository := repository.NewGuardianSetRepository(db, logger)
+	guardianSetSize := guardian.NewGuardianSetSize(guardianSetRepository, cfg.P2pNetwork, guardianSetRefreshInterval, logger)
+	if err := guardianSetSize.Refresh(ctx); err != nil {
+		logger.Warn("failed to read the guardian set size, using the hard-coded guardian set", zap.Error(err))
+	}
+	guardianSetSize.Start(ctx)
+	return guardianSetSize
+}
+
 func newCreateTxHashFunc(
 	cfg *config.ServiceConfiguration,
 	logger *zap.Logger,
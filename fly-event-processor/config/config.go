@@ -8,6 +8,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 const (
@@ -46,6 +47,15 @@ type ServiceConfiguration struct {
 	AwsRegion          string `env:"AWS_REGION"`
 	DuplicateVaaSQSUrl string `env:"DUPLICATE_VAA_SQS_URL"`
 	GovernorSQSUrl     string `env:"GOVERNOR_SQS_URL"`
+	// GovernorEventsSNSUrl is the topic of the enqueued and released governor vaa notifications.
+	// The notifications are disabled when it is empty.
+	GovernorEventsSNSUrl string `env:"GOVERNOR_EVENTS_SNS_URL"`
+	// Queue names used with the kafka, nats, redis and memory queue backends.
+	DuplicateVaaQueueName   string `env:"DUPLICATE_VAA_QUEUE_NAME,default=wormscan-duplicate-vaa"`
+	GovernorQueueName       string `env:"GOVERNOR_QUEUE_NAME,default=wormscan-governor-status"`
+	GovernorEventsQueueName string `env:"GOVERNOR_EVENTS_QUEUE_NAME"`
+	// Config is the queue backend. The SQS and SNS urls are used with the sqs backend, and the queue names with the others.
+	queue.Config
 	// Tx-tracker client configuration
 	TxTrackerUrl     string `env:"TX_TRACKER_URL,required"`
 	TxTrackerTimeout int64  `env:"TX_TRACKER_TIMEOUT,default=10"`
@@ -50,7 +50,7 @@ func (c *Consumer) Start(ctx context.Context) {
 	}
 }
 
-func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.ConsumerMessage[queue.EventGovernorStatus]) {
+func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.GovernorStatusMessage) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -61,7 +61,7 @@ func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.ConsumerMes
 	}
 }
 
-func (c *Consumer) processEvent(ctx context.Context, msg queue.ConsumerMessage[queue.EventGovernorStatus]) {
+func (c *Consumer) processEvent(ctx context.Context, msg queue.GovernorStatusMessage) {
 	event := msg.Data()
 
 	// Check if the event is a governor status event.
@@ -86,7 +86,7 @@ func (c *Consumer) processEvent(ctx context.Context, msg queue.ConsumerMessage[q
 
 	params := &govprocessor.Params{
 		TrackID:         event.TrackID,
-		NodeGovernorVaa: domain.ConvertEventToGovernorVaa(&event),
+		NodeGovernorVaa: domain.ConvertEventToGovernorVaa(event),
 	}
 
 	err := c.processor(ctx, params)
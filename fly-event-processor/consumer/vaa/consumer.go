@@ -50,7 +50,7 @@ func (c *Consumer) Start(ctx context.Context) {
 	}
 }
 
-func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.ConsumerMessage[queue.EventDuplicateVaa]) {
+func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.DuplicateVaaMessage) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -61,7 +61,7 @@ func (c *Consumer) producerLoop(ctx context.Context, ch <-chan queue.ConsumerMes
 	}
 }
 
-func (c *Consumer) processEvent(ctx context.Context, msg queue.ConsumerMessage[queue.EventDuplicateVaa]) {
+func (c *Consumer) processEvent(ctx context.Context, msg queue.DuplicateVaaMessage) {
 	event := msg.Data()
 
 	// Check if the event is a duplicate VAA event.
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
@@ -17,6 +18,9 @@ type Node struct {
 type NodeGovernorVaa struct {
 	Node
 	GovernorVaas map[string]GovernorVaa
+	// AvailableNotional is the remaining notional of each chain reported by the node.
+	AvailableNotional map[sdk.ChainID]uint64
+	Timestamp         time.Time
 }
 
 type GovernorVaa struct {
@@ -43,7 +47,9 @@ func ConvertEventToGovernorVaa(event *queue.EventGovernorStatus) *NodeGovernorVa
 	}
 
 	governorVaas := make(map[string]GovernorVaa)
+	availableNotional := make(map[sdk.ChainID]uint64)
 	for _, chain := range event.Data.Chains {
+		availableNotional[sdk.ChainID(chain.ChainId)] = chain.RemainingAvailableNotional
 		for _, emitter := range chain.Emitters {
 			for _, enqueuedVAA := range emitter.EnqueuedVaas {
 
@@ -74,6 +80,32 @@ func ConvertEventToGovernorVaa(event *queue.EventGovernorStatus) *NodeGovernorVa
 			Name:    event.Data.NodeName,
 			Address: event.Data.NodeAddress,
 		},
-		GovernorVaas: governorVaas,
+		GovernorVaas:      governorVaas,
+		AvailableNotional: availableNotional,
+		Timestamp:         statusTime(event.Data.Timestamp),
 	}
 }
+
+// statusTime converts the timestamp of a governor status, in nanoseconds, to a time.
+// The statuses without a timestamp are considered as received now.
+func statusTime(timestamp int64) time.Time {
+	if timestamp <= 0 {
+		return time.Now()
+	}
+	return time.Unix(0, timestamp)
+}
+
+// QuorumReleaseTime returns the time at which a quorum of guardians releases an enqueued vaa, given the release
+// times of the guardians that enqueued it. The guardians that did not enqueue the vaa are considered to have signed
+// it, so it returns nil when less than a quorum of guardians enqueued the vaa. The release times of the nodes that
+// are no longer in the guardian set are still counted.
+func QuorumReleaseTime(releaseTimes []time.Time, guardians, quorum int) *time.Time {
+	signed := max(guardians-len(releaseTimes), 0)
+	if signed >= quorum {
+		return nil
+	}
+	sorted := slices.Clone(releaseTimes)
+	slices.SortFunc(sorted, func(a, b time.Time) int { return a.Compare(b) })
+	releaseTime := sorted[min(quorum-signed, len(sorted))-1]
+	return &releaseTime
+}
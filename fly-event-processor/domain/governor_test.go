@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuorumReleaseTime(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	at := func(hours int) time.Time { return base.Add(time.Duration(hours) * time.Hour) }
+
+	cases := []struct {
+		name         string
+		releaseTimes []time.Time
+		guardians    int
+		quorum       int
+		expected     *time.Time
+	}{
+		{
+			name:      "not enqueued",
+			guardians: 19,
+			quorum:    13,
+		},
+		{
+			name:         "enqueued by less than a quorum",
+			releaseTimes: []time.Time{at(1), at(2), at(3), at(4), at(5), at(6)},
+			guardians:    19,
+			quorum:       13,
+		},
+		{
+			name:         "enqueued by the minimum to block the quorum",
+			releaseTimes: []time.Time{at(7), at(1), at(3), at(5), at(2), at(6), at(4)},
+			guardians:    19,
+			quorum:       13,
+			expected:     ptr(at(1)),
+		},
+		{
+			name:         "enqueued by all the guardians",
+			releaseTimes: []time.Time{at(19), at(18), at(17), at(16), at(15), at(14), at(13), at(12), at(11), at(10), at(9), at(8), at(7), at(6), at(5), at(4), at(3), at(2), at(1)},
+			guardians:    19,
+			quorum:       13,
+			expected:     ptr(at(13)),
+		},
+		{
+			name:         "enqueued by more guardians than the guardian set",
+			releaseTimes: []time.Time{at(3), at(1), at(2)},
+			guardians:    1,
+			quorum:       1,
+			expected:     ptr(at(1)),
+		},
+		{
+			name:         "single guardian",
+			releaseTimes: []time.Time{at(2)},
+			guardians:    1,
+			quorum:       1,
+			expected:     ptr(at(2)),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			releaseTimes := append([]time.Time(nil), tc.releaseTimes...)
+			assert.Equal(t, tc.expected, QuorumReleaseTime(tc.releaseTimes, tc.guardians, tc.quorum))
+			// the release times are not reordered
+			assert.Equal(t, releaseTimes, tc.releaseTimes)
+		})
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,104 @@
+package guardian
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.uber.org/zap"
+)
+
+// guardianSetFinder finds the guardian sets stored by fly.
+type guardianSetFinder interface {
+	FindAll(ctx context.Context) ([]*repository.GuardianSetDoc, error)
+}
+
+// GuardianSetSize provides the number of guardians of the current guardian set.
+// The size is read from the guardian sets stored in the database and refreshed periodically. Until the guardian
+// sets are read, it is the size of the last hard-coded guardian set of the p2p network.
+type GuardianSetSize struct {
+	finder   guardianSetFinder
+	interval time.Duration
+	size     atomic.Int64
+	logger   *zap.Logger
+}
+
+// NewGuardianSetSize creates a new guardian set size provider.
+func NewGuardianSetSize(repository *repository.GuardianSetRepository, p2pNetwork string, interval time.Duration, logger *zap.Logger) *GuardianSetSize {
+	return newGuardianSetSize(repository, p2pNetwork, interval, logger)
+}
+
+func newGuardianSetSize(finder guardianSetFinder, p2pNetwork string, interval time.Duration, logger *zap.Logger) *GuardianSetSize {
+	g := &GuardianSetSize{
+		finder:   finder,
+		interval: interval,
+		logger:   logger.With(zap.String("module", "GuardianSetSize")),
+	}
+	g.size.Store(int64(defaultGuardianSetSize(p2pNetwork)))
+	return g
+}
+
+// Size returns the number of guardians of the current guardian set.
+func (g *GuardianSetSize) Size() int {
+	return int(g.size.Load())
+}
+
+// Refresh reads the number of guardians of the guardian set with the highest index.
+// The size is kept when there is no stored guardian set.
+func (g *GuardianSetSize) Refresh(ctx context.Context) error {
+	guardianSets, err := g.finder.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	var current *repository.GuardianSetDoc
+	for _, gs := range guardianSets {
+		if current == nil || gs.GuardianSetIndex > current.GuardianSetIndex {
+			current = gs
+		}
+	}
+	if current == nil || len(current.Keys) == 0 {
+		return nil
+	}
+
+	if previous := g.size.Swap(int64(len(current.Keys))); previous != int64(len(current.Keys)) {
+		g.logger.Info("guardian set size updated",
+			zap.Uint32("guardianSetIndex", current.GuardianSetIndex),
+			zap.Int("size", len(current.Keys)))
+	}
+	return nil
+}
+
+// Start refreshes the size periodically until the context is cancelled.
+func (g *GuardianSetSize) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := g.Refresh(ctx); err != nil {
+					g.logger.Warn("failed to refresh the guardian set size", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// defaultGuardianSetSize returns the number of guardians of the last hard-coded guardian set of the p2p network.
+func defaultGuardianSetSize(p2pNetwork string) int {
+	switch p2pNetwork {
+	case domain.P2pMainNet:
+		guardianSets, _ := domain.GetMainnetGuardianSet()
+		return len(guardianSets[len(guardianSets)-1].Keys)
+	case domain.P2pTestNet:
+		guardianSets, _ := domain.GetTestnetGuardianSet()
+		return len(guardianSets[len(guardianSets)-1].Keys)
+	default:
+		return 1
+	}
+}
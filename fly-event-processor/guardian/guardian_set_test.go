@@ -0,0 +1,51 @@
+package guardian
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.uber.org/zap"
+)
+
+type stubGuardianSetFinder struct {
+	guardianSets []*repository.GuardianSetDoc
+	err          error
+}
+
+func (f *stubGuardianSetFinder) FindAll(context.Context) ([]*repository.GuardianSetDoc, error) {
+	return f.guardianSets, f.err
+}
+
+func guardianSetDoc(index uint32, size int) *repository.GuardianSetDoc {
+	return &repository.GuardianSetDoc{GuardianSetIndex: index, Keys: make([]repository.GuardianSetKeyDoc, size)}
+}
+
+func TestGuardianSetSize_Refresh(t *testing.T) {
+	finder := &stubGuardianSetFinder{}
+	g := newGuardianSetSize(finder, domain.P2pMainNet, time.Minute, zap.NewNop())
+	assert.Equal(t, 19, g.Size())
+
+	// the hard-coded size is kept until a guardian set is stored
+	assert.NoError(t, g.Refresh(context.Background()))
+	assert.Equal(t, 19, g.Size())
+
+	// the size of the guardian set with the highest index is used
+	finder.guardianSets = []*repository.GuardianSetDoc{guardianSetDoc(3, 19), guardianSetDoc(4, 20), guardianSetDoc(2, 18)}
+	assert.NoError(t, g.Refresh(context.Background()))
+	assert.Equal(t, 20, g.Size())
+
+	// the last size is kept when the guardian sets cannot be read
+	finder.err = errors.New("unavailable")
+	assert.Error(t, g.Refresh(context.Background()))
+	assert.Equal(t, 20, g.Size())
+}
+
+func TestDefaultGuardianSetSize(t *testing.T) {
+	assert.Equal(t, 19, defaultGuardianSetSize(domain.P2pMainNet))
+	assert.Equal(t, 1, defaultGuardianSetSize(domain.P2pDevNet))
+}
@@ -4,26 +4,50 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	txTracker "github.com/wormhole-foundation/wormhole-explorer/common/client/txtracker"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/producer"
 	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
 )
 
+// governorRepository is the storage of the governor data of the nodes.
+type governorRepository interface {
+	FindNodeGovernorVaaByNodeAddress(ctx context.Context, nodeAddress string) ([]storage.NodeGovernorVaaDoc, error)
+	FindNodeGovernorVaaByVaaIDs(ctx context.Context, vaaID []string) ([]storage.NodeGovernorVaaDoc, error)
+	FindGovernorVaaByVaaIDs(ctx context.Context, vaaID []string) ([]storage.GovernorVaaDoc, error)
+	UpdateGovernor(ctx context.Context,
+		nodeGovernorVaaDocToInsert []storage.NodeGovernorVaaDoc,
+		nodeGovernorVaaDocToDelete []string,
+		governorVaasToInsert []storage.GovernorVaaDoc,
+		governorVaaIdsToDelete []string) error
+	UpdateGovernorVaaQuorumReleaseTime(ctx context.Context, vaaID string, quorumReleaseTime *time.Time) error
+	SaveGovernorNotional(ctx context.Context, docs []storage.GovernorNotionalDoc) error
+}
+
 // Processor is a governor processor.
 type Processor struct {
-	repository       *storage.Repository
+	repository       governorRepository
 	createTxHashFunc txTracker.CreateTxHashFunc
+	pushFunc         producer.PushFunc
+	guardianSetSize  GuardianSetSizeFunc
 	logger           *zap.Logger
 	metrics          metrics.Metrics
 }
 
 // NewProcessor creates a new governor processor.
+// The guardianSetSize returns the number of guardians of the current guardian set, used to compute the time at which
+// a quorum of guardians releases an enqueued vaa.
 func NewProcessor(
 	repository *storage.Repository,
 	createTxHashFunc txTracker.CreateTxHashFunc,
+	pushFunc producer.PushFunc,
+	guardianSetSize GuardianSetSizeFunc,
 	logger *zap.Logger,
 	metrics metrics.Metrics,
 ) *Processor {
@@ -31,6 +55,8 @@ func NewProcessor(
 	return &Processor{
 		repository:       repository,
 		createTxHashFunc: createTxHashFunc,
+		pushFunc:         pushFunc,
+		guardianSetSize:  guardianSetSize,
 		logger:           logger,
 		metrics:          metrics,
 	}
@@ -56,7 +82,10 @@ func (p *Processor) Process(
 		return errors.New("node is invalid")
 	}
 
-	// 2. Get new and current governorVaa by node.
+	// 2. Save the remaining notional of the chains for the governor history.
+	p.saveAvailableNotional(ctx, params.NodeGovernorVaa, logger)
+
+	// 3. Get new and current governorVaa by node.
 	newNodeGovernorVaas := params.NodeGovernorVaa
 	nodeGovernorVaaIds, err := p.getNodeGovernorVaaIds(ctx, node, logger)
 	if err != nil {
@@ -66,13 +95,13 @@ func (p *Processor) Process(
 		return err
 	}
 
-	// 3. Get nodeGovernorVaa to add and delete.
+	// 4. Get nodeGovernorVaa to add and delete.
 	nodeGovernorVaasToAdd := getNodeGovernorVaasToAdd(
 		newNodeGovernorVaas.GovernorVaas, nodeGovernorVaaIds)
 	nodeGovernorVaaIdsToDelete := getNodeGovernorVaasToDelete(
 		newNodeGovernorVaas.GovernorVaas, nodeGovernorVaaIds)
 
-	// 4. Get governorVaa to add and delete.
+	// 5. Get governorVaa to add and delete.
 	governorVaasToAdd, err := p.getGovernorVaaToAdd(ctx, nodeGovernorVaasToAdd, logger)
 	if err != nil {
 		logger.Error("failed to get governorVaa to insert",
@@ -88,7 +117,7 @@ func (p *Processor) Process(
 		return err
 	}
 
-	// 5. Check if there are no changes in governor.
+	// 6. Check if there are no changes in governor.
 	changeNodeGovernorVaas := len(nodeGovernorVaasToAdd) > 0 || len(nodeGovernorVaaIdsToDelete) > 0
 	changeGovernorVaas := len(governorVaasToAdd) > 0 || len(governorVaaIdsToDelete) > 0
 	if !changeNodeGovernorVaas && !changeGovernorVaas {
@@ -97,7 +126,10 @@ func (p *Processor) Process(
 		return nil
 	}
 
-	// 6. Update governor data for the node.
+	// 7. Get the governor vaas released by the quorum before deleting them.
+	releasedGovernorVaas := p.getReleasedGovernorVaas(ctx, governorVaaIdsToDelete, logger)
+
+	// 8. Update governor data for the node.
 	err = p.updateGovernor(ctx,
 		node,
 		nodeGovernorVaasToAdd,
@@ -112,9 +144,50 @@ func (p *Processor) Process(
 		return err
 	}
 
+	// 9. Update the quorum release time of the governor vaas and notify the changes.
+	p.updateQuorumReleaseTimes(ctx,
+		params.TrackID,
+		nodeGovernorVaasToAdd,
+		nodeGovernorVaaIdsToDelete,
+		governorVaasToAdd,
+		governorVaaIdsToDelete,
+		releasedGovernorVaas,
+		logger)
+
 	return nil
 }
 
+// saveAvailableNotional saves the remaining notional of the chains reported by the node.
+// Failures are only logged because the notional is only used for the governor history.
+func (p *Processor) saveAvailableNotional(
+	ctx context.Context,
+	nodeGovernorVaa *domain.NodeGovernorVaa,
+	logger *zap.Logger,
+) {
+
+	hour := nodeGovernorVaa.Timestamp.UTC().Truncate(time.Hour)
+	docs := make([]storage.GovernorNotionalDoc, 0, len(nodeGovernorVaa.AvailableNotional))
+	for chainID, notional := range nodeGovernorVaa.AvailableNotional {
+		docs = append(docs, storage.GovernorNotionalDoc{
+			ID:                   fmt.Sprintf("%s/%d/%d", nodeGovernorVaa.Address, chainID, hour.Unix()),
+			NodeName:             nodeGovernorVaa.Name,
+			NodeAddress:          nodeGovernorVaa.Address,
+			ChainID:              chainID,
+			Hour:                 hour,
+			AvailableNotional:    storage.Uint64(notional),
+			MinAvailableNotional: storage.Uint64(notional),
+			UpdatedAt:            nodeGovernorVaa.Timestamp,
+		})
+	}
+
+	err := p.repository.SaveGovernorNotional(ctx, docs)
+	if err != nil {
+		logger.Warn("failed to save available notional",
+			zap.Error(err),
+			zap.String("nodeAddress", nodeGovernorVaa.Address))
+	}
+}
+
 // getNodeGovernorVaaIds gets the current governor vaaIds stored in the database by node address.
 func (p *Processor) getNodeGovernorVaaIds(
 	ctx context.Context,
@@ -282,12 +355,14 @@ func (p *Processor) updateGovernor(ctx context.Context,
 
 	// convert nodeGovernorVaasToAdd to []storage.NodeGovernorVaaDoc
 	var nodeGovernorVaasToAddDoc []storage.NodeGovernorVaaDoc
-	for vaaID, _ := range nodeGovernorVaasToAdd {
+	for vaaID, governorVaa := range nodeGovernorVaasToAdd {
+		releaseTime := governorVaa.ReleaseTime
 		nodeGovernorVaasToAddDoc = append(nodeGovernorVaasToAddDoc, storage.NodeGovernorVaaDoc{
 			ID:          fmt.Sprintf("%s-%s", node.Address, vaaID),
 			NodeName:    node.Name,
 			NodeAddress: node.Address,
 			VaaID:       vaaID,
+			ReleaseTime: &releaseTime,
 		})
 	}
 
@@ -318,3 +393,157 @@ func (p *Processor) updateGovernor(ctx context.Context,
 		governorVaasToAddDoc,
 		governorVaaIdsToDelete.ToSlice())
 }
+
+// getReleasedGovernorVaas gets the governor vaas to delete, which are released by the quorum of guardians.
+// Failures are only logged because the governor vaas are only used to notify the release.
+func (p *Processor) getReleasedGovernorVaas(
+	ctx context.Context,
+	governorVaaIdsToDelete Set[string],
+	logger *zap.Logger,
+) []storage.GovernorVaaDoc {
+
+	if governorVaaIdsToDelete.Len() == 0 {
+		return nil
+	}
+	governorVaas, err := p.repository.FindGovernorVaaByVaaIDs(ctx, governorVaaIdsToDelete.ToSlice())
+	if err != nil {
+		logger.Warn("failed to find released governor vaas",
+			zap.Error(err),
+			zap.Strings("vaaIDs", governorVaaIdsToDelete.ToSlice()))
+		return nil
+	}
+	return governorVaas
+}
+
+// updateQuorumReleaseTimes updates the quorum release time of the governor vaas added or deleted by the node,
+// and notifies the enqueued vaas, the changes of the quorum release time and the released vaas.
+// Failures are only logged because the governor data of the node is already updated.
+func (p *Processor) updateQuorumReleaseTimes(
+	ctx context.Context,
+	trackID string,
+	nodeGovernorVaasToAdd map[string]domain.GovernorVaa,
+	nodeGovernorVaaIdsToDelete Set[string],
+	governorVaasToAdd []domain.GovernorVaa,
+	governorVaaIdsToDelete Set[string],
+	releasedGovernorVaas []storage.GovernorVaaDoc,
+	logger *zap.Logger,
+) {
+
+	// get the governor vaas still enqueued whose guardians changed.
+	vaaIds := make(Set[string])
+	for vaaID := range nodeGovernorVaasToAdd {
+		vaaIds.Add(vaaID)
+	}
+	for vaaID := range nodeGovernorVaaIdsToDelete {
+		vaaIds.Add(vaaID)
+	}
+	for vaaID := range governorVaaIdsToDelete {
+		delete(vaaIds, vaaID)
+	}
+	enqueuedVaaIds := make(Set[string])
+	for _, governorVaa := range governorVaasToAdd {
+		enqueuedVaaIds.Add(governorVaa.ID)
+	}
+
+	if vaaIds.Len() > 0 {
+		governorVaas, err := p.repository.FindGovernorVaaByVaaIDs(ctx, vaaIds.ToSlice())
+		if err != nil {
+			logger.Warn("failed to find governor vaas to update the quorum release time",
+				zap.Error(err),
+				zap.Strings("vaaIDs", vaaIds.ToSlice()))
+			return
+		}
+		nodeGovernorVaas, err := p.repository.FindNodeGovernorVaaByVaaIDs(ctx, vaaIds.ToSlice())
+		if err != nil {
+			logger.Warn("failed to find node governor vaas to update the quorum release time",
+				zap.Error(err),
+				zap.Strings("vaaIDs", vaaIds.ToSlice()))
+			return
+		}
+
+		guardians := p.guardianSetSize()
+		quorum := sdk.CalculateQuorum(guardians)
+		for _, governorVaa := range governorVaas {
+			releaseTimes := getNodeReleaseTimes(governorVaa, nodeGovernorVaas)
+			quorumReleaseTime := domain.QuorumReleaseTime(releaseTimes, guardians, quorum)
+
+			enqueued := enqueuedVaaIds.Contains(governorVaa.ID)
+			if !enqueued && equalTimes(quorumReleaseTime, governorVaa.QuorumReleaseTime) {
+				continue
+			}
+			err := p.repository.UpdateGovernorVaaQuorumReleaseTime(ctx, governorVaa.ID, quorumReleaseTime)
+			if err != nil {
+				logger.Warn("failed to update the quorum release time",
+					zap.Error(err),
+					zap.String("vaaID", governorVaa.ID))
+				continue
+			}
+
+			eventType := events.GovernorVaaReleaseUpdatedType
+			if enqueued {
+				eventType = events.GovernorVaaEnqueuedType
+			}
+			governorVaa.QuorumReleaseTime = quorumReleaseTime
+			p.notify(ctx, trackID, eventType, governorVaa, logger)
+		}
+	}
+
+	for _, governorVaa := range releasedGovernorVaas {
+		p.notify(ctx, trackID, events.GovernorVaaReleasedType, governorVaa, logger)
+	}
+}
+
+// getNodeReleaseTimes gets the release times of the nodes that enqueued a governor vaa.
+// The nodes enqueued before the release time was stored use the release time of the governor vaa.
+func getNodeReleaseTimes(governorVaa storage.GovernorVaaDoc, nodeGovernorVaas []storage.NodeGovernorVaaDoc) []time.Time {
+	var releaseTimes []time.Time
+	for _, n := range nodeGovernorVaas {
+		if n.VaaID != governorVaa.ID {
+			continue
+		}
+		if n.ReleaseTime != nil {
+			releaseTimes = append(releaseTimes, *n.ReleaseTime)
+		} else {
+			releaseTimes = append(releaseTimes, governorVaa.ReleaseTime)
+		}
+	}
+	return releaseTimes
+}
+
+// equalTimes checks if two optional times are equal.
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// notify pushes a governor vaa event. Failures are only logged.
+func (p *Processor) notify(ctx context.Context, trackID, eventType string, governorVaa storage.GovernorVaaDoc, logger *zap.Logger) {
+	event, err := events.NewNotificationEvent[events.GovernorVaa](trackID, "fly-event-processor", eventType,
+		events.GovernorVaa{
+			ID:                governorVaa.ID,
+			EmitterChain:      uint16(governorVaa.ChainID),
+			EmitterAddress:    governorVaa.EmitterAddress,
+			Sequence:          governorVaa.Sequence,
+			TxHash:            governorVaa.TxHash,
+			Amount:            uint64(governorVaa.Amount),
+			QuorumReleaseTime: governorVaa.QuorumReleaseTime,
+			Timestamp:         time.Now(),
+		})
+	if err != nil {
+		logger.Warn("failed to create governor vaa event",
+			zap.Error(err),
+			zap.String("vaaID", governorVaa.ID),
+			zap.String("event", eventType))
+		return
+	}
+
+	err = p.pushFunc(ctx, &producer.Notification{ID: governorVaa.ID, Event: event})
+	if err != nil {
+		logger.Warn("failed to push governor vaa event",
+			zap.Error(err),
+			zap.String("vaaID", governorVaa.ID),
+			zap.String("event", eventType))
+	}
+}
@@ -0,0 +1,150 @@
+package governor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/producer"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/storage"
+	"go.uber.org/zap"
+)
+
+// memoryRepository is a governor repository that keeps the governor vaas in memory.
+type memoryRepository struct {
+	governorRepository
+	governorVaas     map[string]storage.GovernorVaaDoc
+	nodeGovernorVaas []storage.NodeGovernorVaaDoc
+	updated          map[string]*time.Time
+}
+
+func (r *memoryRepository) FindGovernorVaaByVaaIDs(_ context.Context, vaaIDs []string) ([]storage.GovernorVaaDoc, error) {
+	var docs []storage.GovernorVaaDoc
+	for _, vaaID := range vaaIDs {
+		if doc, ok := r.governorVaas[vaaID]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func (r *memoryRepository) FindNodeGovernorVaaByVaaIDs(_ context.Context, vaaIDs []string) ([]storage.NodeGovernorVaaDoc, error) {
+	var docs []storage.NodeGovernorVaaDoc
+	for _, doc := range r.nodeGovernorVaas {
+		for _, vaaID := range vaaIDs {
+			if doc.VaaID == vaaID {
+				docs = append(docs, doc)
+			}
+		}
+	}
+	return docs, nil
+}
+
+func (r *memoryRepository) UpdateGovernorVaaQuorumReleaseTime(_ context.Context, vaaID string, quorumReleaseTime *time.Time) error {
+	r.updated[vaaID] = quorumReleaseTime
+	return nil
+}
+
+func newTestProcessor(repository governorRepository, guardians int, notifications map[string]string) *Processor {
+	return &Processor{
+		repository: repository,
+		pushFunc: func(_ context.Context, n *producer.Notification) error {
+			notifications[n.ID] = n.Event.Event
+			return nil
+		},
+		guardianSetSize: func() int { return guardians },
+		logger:          zap.NewNop(),
+		metrics:         metrics.NewDummyMetrics(),
+	}
+}
+
+func nodeReleaseTime(node, vaaID string, releaseTime time.Time) storage.NodeGovernorVaaDoc {
+	return storage.NodeGovernorVaaDoc{ID: node + "-" + vaaID, NodeAddress: node, VaaID: vaaID, ReleaseTime: &releaseTime}
+}
+
+func TestGetNodeReleaseTimes(t *testing.T) {
+	releaseTime := time.Unix(1700000000, 0)
+	governorVaa := storage.GovernorVaaDoc{ID: "2/emitter/1", ReleaseTime: releaseTime}
+	nodeGovernorVaas := []storage.NodeGovernorVaaDoc{
+		nodeReleaseTime("node1", "2/emitter/1", releaseTime.Add(time.Hour)),
+		nodeReleaseTime("node1", "2/emitter/2", releaseTime.Add(2*time.Hour)),
+		{ID: "node2-2/emitter/1", NodeAddress: "node2", VaaID: "2/emitter/1"},
+	}
+
+	releaseTimes := getNodeReleaseTimes(governorVaa, nodeGovernorVaas)
+	assert.Equal(t, []time.Time{releaseTime.Add(time.Hour), releaseTime}, releaseTimes)
+}
+
+func TestUpdateQuorumReleaseTimes(t *testing.T) {
+	releaseTime := time.Unix(1700000000, 0)
+	quorumReleaseTime := releaseTime.Add(time.Hour)
+	repository := &memoryRepository{
+		governorVaas: map[string]storage.GovernorVaaDoc{
+			"enqueued":  {ID: "enqueued", ReleaseTime: releaseTime},
+			"unchanged": {ID: "unchanged", ReleaseTime: releaseTime, QuorumReleaseTime: &quorumReleaseTime},
+			"changed":   {ID: "changed", ReleaseTime: releaseTime, QuorumReleaseTime: &quorumReleaseTime},
+			"released":  {ID: "released", ReleaseTime: releaseTime},
+		},
+		nodeGovernorVaas: []storage.NodeGovernorVaaDoc{
+			nodeReleaseTime("node1", "enqueued", releaseTime),
+			nodeReleaseTime("node1", "unchanged", quorumReleaseTime),
+			nodeReleaseTime("node2", "unchanged", quorumReleaseTime),
+			nodeReleaseTime("node1", "changed", releaseTime.Add(2*time.Hour)),
+		},
+		updated: make(map[string]*time.Time),
+	}
+	notifications := make(map[string]string)
+
+	// with 2 guardians, the quorum is 2 and a single node blocks the release.
+	p := newTestProcessor(repository, 2, notifications)
+	p.updateQuorumReleaseTimes(context.Background(),
+		"trackID",
+		map[string]domain.GovernorVaa{"enqueued": {ID: "enqueued"}, "unchanged": {ID: "unchanged"}},
+		Set[string]{"changed": {}, "released": {}},
+		[]domain.GovernorVaa{{ID: "enqueued"}},
+		Set[string]{"released": {}},
+		[]storage.GovernorVaaDoc{repository.governorVaas["released"]},
+		zap.NewNop())
+
+	assert.Equal(t, map[string]string{
+		"enqueued": events.GovernorVaaEnqueuedType,
+		"changed":  events.GovernorVaaReleaseUpdatedType,
+		"released": events.GovernorVaaReleasedType,
+	}, notifications)
+	assert.Len(t, repository.updated, 2)
+	assert.Equal(t, releaseTime, *repository.updated["enqueued"])
+	assert.Equal(t, releaseTime.Add(2*time.Hour), *repository.updated["changed"])
+}
+
+func TestUpdateQuorumReleaseTimes_UsesCurrentGuardianSetSize(t *testing.T) {
+	releaseTime := time.Unix(1700000000, 0)
+	repository := &memoryRepository{
+		governorVaas: map[string]storage.GovernorVaaDoc{
+			"vaa": {ID: "vaa", ReleaseTime: releaseTime},
+		},
+		nodeGovernorVaas: []storage.NodeGovernorVaaDoc{
+			nodeReleaseTime("node1", "vaa", releaseTime),
+		},
+		updated: make(map[string]*time.Time),
+	}
+	notifications := make(map[string]string)
+
+	// with 19 guardians, a single node does not block the quorum.
+	p := newTestProcessor(repository, 19, notifications)
+	p.updateQuorumReleaseTimes(context.Background(),
+		"trackID",
+		map[string]domain.GovernorVaa{"vaa": {ID: "vaa"}},
+		Set[string]{},
+		[]domain.GovernorVaa{{ID: "vaa"}},
+		Set[string]{},
+		nil,
+		zap.NewNop())
+
+	assert.Equal(t, map[string]string{"vaa": events.GovernorVaaEnqueuedType}, notifications)
+	assert.Contains(t, repository.updated, "vaa")
+	assert.Nil(t, repository.updated["vaa"])
+}
@@ -41,3 +41,6 @@ type Params struct {
 
 // ProcessorFunc is a function to process a governor message.
 type ProcessorFunc func(context.Context, *Params) error
+
+// GuardianSetSizeFunc is a function to get the number of guardians of the current guardian set.
+type GuardianSetSizeFunc func() int
@@ -0,0 +1,16 @@
+package producer
+
+import "context"
+
+// NoopProducer is a producer that discards the notifications.
+type NoopProducer struct{}
+
+// NewNoopProducer creates a new NoopProducer.
+func NewNoopProducer() *NoopProducer {
+	return &NoopProducer{}
+}
+
+// Push discards the notification.
+func (p *NoopProducer) Push(context.Context, *Notification) error {
+	return nil
+}
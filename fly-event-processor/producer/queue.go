@@ -0,0 +1,38 @@
+package producer
+
+import (
+	"context"
+	"encoding/json"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"go.uber.org/zap"
+)
+
+// QueueProducer is a producer for the kafka, nats, redis and memory queue backends.
+type QueueProducer struct {
+	sender common_queue.Sender
+	logger *zap.Logger
+}
+
+// NewQueueProducer creates a new QueueProducer.
+func NewQueueProducer(sender common_queue.Sender, logger *zap.Logger) *QueueProducer {
+	return &QueueProducer{
+		sender: sender,
+		logger: logger,
+	}
+}
+
+// Push pushes a notification to the queue.
+// The notifications are grouped and deduplicated the same as the SNS notifications.
+func (p *QueueProducer) Push(ctx context.Context, n *Notification) error {
+	body, err := json.Marshal(n.Event)
+	if err != nil {
+		return err
+	}
+	p.logger.Debug("Publishing governor event", zap.String("groupID", n.ID), zap.String("event", n.Event.Event))
+	return p.sender.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         n.ID,
+		DeduplicationID: deduplicationID(n),
+		Body:            string(body),
+	})
+}
@@ -0,0 +1,44 @@
+package producer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/sns"
+	"go.uber.org/zap"
+)
+
+// SNSProducer is a producer for SNS.
+type SNSProducer struct {
+	producer *sns.Producer
+	logger   *zap.Logger
+}
+
+// NewSNSProducer creates a new SNSProducer.
+func NewSNSProducer(producer *sns.Producer, logger *zap.Logger) *SNSProducer {
+	return &SNSProducer{
+		producer: producer,
+		logger:   logger,
+	}
+}
+
+// Push pushes a notification to SNS.
+// The notifications of a vaa are grouped by the vaa id, and deduplicated by the event and its timestamp.
+func (p *SNSProducer) Push(ctx context.Context, n *Notification) error {
+	body, err := json.Marshal(n.Event)
+	if err != nil {
+		return err
+	}
+	p.logger.Debug("Publishing governor event", zap.String("groupID", n.ID), zap.String("event", n.Event.Event))
+	return p.producer.SendMessage(ctx, n.ID, deduplicationID(n), string(body))
+}
+
+// deduplicationID returns the deduplication id of a notification, built from the event and its timestamp.
+func deduplicationID(n *Notification) string {
+	// the deduplication id cannot be longer than 128 characters.
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s-%s-%d", n.Event.Event, n.ID, n.Event.Timestamp.UnixNano())))
+	return hex.EncodeToString(hash[:])
+}
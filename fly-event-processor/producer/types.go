@@ -0,0 +1,16 @@
+package producer
+
+import (
+	"context"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/events"
+)
+
+// PushFunc is a function to push a notification.
+type PushFunc func(context.Context, *Notification) error
+
+// Notification is an event notified about a vaa.
+type Notification struct {
+	ID    string
+	Event *events.NotificationEvent
+}
@@ -0,0 +1,20 @@
+package queue
+
+import (
+	"encoding/json"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/fly-event-processor/internal/metrics"
+)
+
+// NewEventConverter creates a converter of the json events received from the queue.
+func NewEventConverter[T Event](incConsumedQueueFunc metrics.IncConsumedQueue) common_queue.ConverterFunc[T] {
+	return func(body string) (*T, error) {
+		incConsumedQueueFunc()
+		var event T
+		if err := json.Unmarshal([]byte(body), &event); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
+}
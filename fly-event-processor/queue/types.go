@@ -3,6 +3,8 @@ package queue
 import (
 	"context"
 	"time"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 const (
@@ -10,12 +12,6 @@ const (
 	GovernorStatusEventType = "governor-status"
 )
 
-// sqsEvent represents a event data from SQS.
-type sqsEvent struct {
-	MessageID string `json:"MessageId"`
-	Message   string `json:"Message"`
-}
-
 // Event represents a event data.
 type Event interface {
 	EventDuplicateVaa | EventGovernorStatus
@@ -80,14 +76,11 @@ type EnqueuedVAA struct {
 	TxHash        string `bson:"txhash" json:"txHash"`
 }
 
-// ConsumerMessage defition.
-type ConsumerMessage[T any] interface {
-	Retry() uint8
-	Data() T
-	Done()
-	Failed()
-	IsExpired() bool
-}
+// DuplicateVaaMessage is a message of a duplicate vaa event.
+type DuplicateVaaMessage = common_queue.Message[EventDuplicateVaa]
+
+// GovernorStatusMessage is a message of a governor status event.
+type GovernorStatusMessage = common_queue.Message[EventGovernorStatus]
 
 // ConsumeFunc is a function to consume Event.
-type ConsumeFunc[T any] func(context.Context) <-chan ConsumerMessage[T]
+type ConsumeFunc[T any] func(context.Context) <-chan common_queue.Message[T]
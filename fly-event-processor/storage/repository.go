@@ -2,9 +2,12 @@ package storage
 
 import (
 	"context"
+	"time"
 
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	commonRepo "github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -16,6 +19,9 @@ type Repository struct {
 	duplicateVaas    *mongo.Collection
 	nodeGovernorVaas *mongo.Collection
 	governorVaas     *mongo.Collection
+	governorNotional *mongo.Collection
+	governorEvents   *mongo.Collection
+	operationStatus  *commonRepo.OperationStatusRepository
 }
 
 // New creates a new repository.
@@ -26,6 +32,9 @@ func NewRepository(logger *zap.Logger, db *mongo.Database) *Repository {
 		duplicateVaas:    db.Collection(commonRepo.DuplicateVaas),
 		nodeGovernorVaas: db.Collection(commonRepo.NodeGovernorVaas),
 		governorVaas:     db.Collection(commonRepo.GovernorVaas),
+		governorNotional: db.Collection(commonRepo.GovernorNotional),
+		governorEvents:   db.Collection(commonRepo.GovernorVaaEvents),
+		operationStatus:  commonRepo.NewOperationStatusRepository(db, logger),
 	}
 	return &r
 }
@@ -251,5 +260,86 @@ func (r *Repository) UpdateGovernor(
 		return err
 	}
 
+	// 7. move the enqueued operations forward in their lifecycle.
+	now := time.Now()
+	for _, doc := range governorVaasToInsert {
+		errStatus := r.operationStatus.UpdateStatus(ctx, doc.ID, domain.OperationStatusGovernorEnqueued, now)
+		if errStatus != nil {
+			r.logger.Warn("failed to update operation status",
+				zap.String("vaaId", doc.ID),
+				zap.Error(errStatus))
+		}
+	}
+
+	// 8. record the enqueue and release of the governor vaas.
+	r.saveGovernorVaaEvents(ctx, governorVaasToInsert, governorVaaIdsToDelete, now)
+
 	return nil
 }
+
+// saveGovernorVaaEvents records the enqueue of the new governor vaas and the release of the deleted ones.
+// Failures are only logged because the events are only used for the governor history.
+func (r *Repository) saveGovernorVaaEvents(ctx context.Context, enqueued []GovernorVaaDoc, releasedIDs []string, now time.Time) {
+	for _, doc := range enqueued {
+		update := bson.M{"$setOnInsert": bson.M{
+			"chainId":        doc.ChainID,
+			"emitterAddress": doc.EmitterAddress,
+			"sequence":       doc.Sequence,
+			"amount":         doc.Amount,
+			"releaseTime":    doc.ReleaseTime,
+			"enqueuedAt":     now,
+			"releasedAt":     nil,
+		}}
+		_, err := r.governorEvents.UpdateOne(ctx, bson.M{"_id": doc.ID}, update, options.Update().SetUpsert(true))
+		if err != nil {
+			r.logger.Warn("failed to save governor vaa enqueue event",
+				zap.String("vaaId", doc.ID),
+				zap.Error(err))
+		}
+	}
+
+	if len(releasedIDs) > 0 {
+		filter := bson.M{"_id": bson.M{"$in": releasedIDs}, "releasedAt": nil}
+		_, err := r.governorEvents.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"releasedAt": now}})
+		if err != nil {
+			r.logger.Warn("failed to save governor vaa release events",
+				zap.Strings("vaaIds", releasedIDs),
+				zap.Error(err))
+		}
+	}
+}
+
+// UpdateGovernorVaaQuorumReleaseTime updates the time at which a quorum of guardians releases a governor vaa.
+func (r *Repository) UpdateGovernorVaaQuorumReleaseTime(ctx context.Context, vaaID string, quorumReleaseTime *time.Time) error {
+	_, err := r.governorVaas.UpdateOne(ctx, bson.M{"_id": vaaID}, bson.M{"$set": bson.M{"quorumReleaseTime": quorumReleaseTime}})
+	return err
+}
+
+// SaveGovernorNotional saves the remaining notional reported by a guardian, keeping the last and the minimum
+// value of each chain by hour.
+func (r *Repository) SaveGovernorNotional(ctx context.Context, docs []GovernorNotionalDoc) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(docs))
+	for _, doc := range docs {
+		update := bson.M{
+			"$set": bson.M{
+				"nodeName":          doc.NodeName,
+				"nodeAddress":       doc.NodeAddress,
+				"chainId":           doc.ChainID,
+				"hour":              doc.Hour,
+				"availableNotional": doc.AvailableNotional,
+				"updatedAt":         doc.UpdatedAt,
+			},
+			"$min": bson.M{"minAvailableNotional": doc.MinAvailableNotional},
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": doc.ID}).
+			SetUpdate(update).
+			SetUpsert(true))
+	}
+	_, err := r.governorNotional.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
@@ -54,6 +54,8 @@ type NodeGovernorVaaDoc struct {
 	NodeName    string `bson:"nodeName"`
 	NodeAddress string `bson:"nodeAddress"`
 	VaaID       string `bson:"vaaId"`
+	// ReleaseTime is the time at which the node releases the vaa.
+	ReleaseTime *time.Time `bson:"releaseTime,omitempty"`
 }
 
 type GovernorVaaDoc struct {
@@ -64,6 +66,32 @@ type GovernorVaaDoc struct {
 	TxHash         string      `bson:"txHash"`
 	ReleaseTime    time.Time   `bson:"releaseTime"`
 	Amount         Uint64      `bson:"amount"`
+	// QuorumReleaseTime is the time at which a quorum of guardians releases the vaa.
+	QuorumReleaseTime *time.Time `bson:"quorumReleaseTime,omitempty"`
+}
+
+// GovernorNotionalDoc is the remaining notional of a chain reported by a guardian during an hour.
+type GovernorNotionalDoc struct {
+	ID                   string      `bson:"_id"`
+	NodeName             string      `bson:"nodeName"`
+	NodeAddress          string      `bson:"nodeAddress"`
+	ChainID              sdk.ChainID `bson:"chainId"`
+	Hour                 time.Time   `bson:"hour"`
+	AvailableNotional    Uint64      `bson:"availableNotional"`
+	MinAvailableNotional Uint64      `bson:"minAvailableNotional"`
+	UpdatedAt            time.Time   `bson:"updatedAt"`
+}
+
+// GovernorVaaEventDoc is the enqueue and release of a vaa by the governor.
+type GovernorVaaEventDoc struct {
+	ID             string      `bson:"_id"`
+	ChainID        sdk.ChainID `bson:"chainId"`
+	EmitterAddress string      `bson:"emitterAddress"`
+	Sequence       string      `bson:"sequence"`
+	Amount         Uint64      `bson:"amount"`
+	ReleaseTime    time.Time   `bson:"releaseTime"`
+	EnqueuedAt     time.Time   `bson:"enqueuedAt"`
+	ReleasedAt     *time.Time  `bson:"releasedAt"`
 }
 
 func (d *DuplicateVaaDoc) ToVaaDoc(duplicatedFixed bool) *VaaDoc {
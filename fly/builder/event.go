@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/event"
 	"go.uber.org/zap"
@@ -14,6 +15,18 @@ func NewEventDispatcher(ctx context.Context, config *config.Configuration, logge
 		return event.NewNoopEventDispatcher(), health.Noop()
 	}
 
+	if !config.IsSQS() {
+		duplicateVaa, err := common_queue.NewSender(ctx, &config.Config, config.DuplicateVaaQueueName)
+		if err != nil {
+			logger.Fatal("could not create duplicate vaa queue sender", zap.Error(err))
+		}
+		governorStatus, err := common_queue.NewSender(ctx, &config.Config, config.GovernorQueueName)
+		if err != nil {
+			logger.Fatal("could not create governor status queue sender", zap.Error(err))
+		}
+		return event.NewQueueEventDispatcher(duplicateVaa, governorStatus), health.Noop()
+	}
+
 	awsConfig, err := NewAwsConfig(ctx, config)
 	if err != nil {
 		logger.Fatal("could not create aws config", zap.Error(err))
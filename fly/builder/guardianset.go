@@ -21,6 +21,13 @@ const (
 )
 
 func NewGuardianSetSynchronizer(ctx context.Context, db *dbutil.Session, heartbeatChannel chan *gossipv1.Heartbeat, logger *zap.Logger, cfg *config.Configuration, alertClient alert.AlertClient) (*guardiansets.GuardianSetSynchronizer, error) {
+	// in local mode the guardian sets are not fetched from ethereum.
+	if cfg.IsLocal {
+		manualGuardianSet := guardiansets.GetManualByEnv(cfg.P2pNetwork, alertClient, logger)
+		gst := common.NewGuardianSetState(heartbeatChannel)
+		return guardiansets.NewGuardianSetSynchronizer(ctx, gst, manualGuardianSet, logger)
+	}
+
 	var ethContract string
 	switch cfg.P2pNetwork {
 	case domain.P2pMainNet:
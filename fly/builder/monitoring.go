@@ -4,15 +4,17 @@ import (
 	"context"
 	"errors"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	healthcheck "github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
 	flyAlert "github.com/wormhole-foundation/wormhole-explorer/fly/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/health"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
+	"go.uber.org/zap"
 )
 
-func NewAlertClient(cfg *config.Configuration) (alert.AlertClient, error) {
+func NewAlertClient(cfg *config.Configuration, logger *zap.Logger) (alert.AlertClient, error) {
 	if !cfg.AlertEnabled {
 		return alert.NewDummyClient(), nil
 	}
@@ -20,15 +22,16 @@ func NewAlertClient(cfg *config.Configuration) (alert.AlertClient, error) {
 		Environment: cfg.Environment,
 		Enabled:     cfg.AlertEnabled,
 		ApiKey:      cfg.AlertApiKey,
+		Routing:     cfg.AlertRouting,
 	}
-	return alert.NewAlertService(alertConfig, flyAlert.LoadAlerts)
+	return alert.NewMultiplexer(alertConfig, flyAlert.LoadAlerts, logger)
 }
 
-func NewMetrics(cfg *config.Configuration) metrics.Metrics {
+func NewMetrics(cfg *config.Configuration, registry prometheus.Registerer) metrics.Metrics {
 	if !cfg.MetricsEnabled {
 		return metrics.NewDummyMetrics()
 	}
-	return metrics.NewPrometheusMetrics(cfg.Environment)
+	return metrics.NewPrometheusMetrics(registry, cfg.Environment)
 }
 
 func CheckGuardian(guardian *health.GuardianCheck) healthcheck.Check {
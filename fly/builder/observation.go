@@ -23,6 +23,12 @@ func NewObservationConsumePublish(ctx context.Context, config *config.Configurat
 		return health.Noop(), obsQueue.Consume, obsQueue.Publish
 	}
 
+	if !config.IsSQS() {
+		sender, receiver := newQueueSenderReceiver(ctx, config, config.ObservationsQueueName, logger)
+		observationQueue := queue.NewObservationQueue(sender, receiver, logger)
+		return health.Noop(), observationQueue.Consume, observationQueue.Publish
+	}
+
 	awsConfig, err := NewAwsConfig(ctx, config)
 	if err != nil {
 		logger.Fatal("could not create aws config", zap.Error(err))
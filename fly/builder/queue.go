@@ -0,0 +1,22 @@
+package builder
+
+import (
+	"context"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	"go.uber.org/zap"
+)
+
+// newQueueSenderReceiver creates the sender and the receiver of a queue of the kafka, nats, redis or memory backends.
+func newQueueSenderReceiver(ctx context.Context, cfg *config.Configuration, name string, logger *zap.Logger) (common_queue.Sender, common_queue.Receiver) {
+	sender, err := common_queue.NewSender(ctx, &cfg.Config, name)
+	if err != nil {
+		logger.Fatal("could not create queue sender", zap.String("queue", name), zap.Error(err))
+	}
+	receiver, err := common_queue.NewReceiver(ctx, &cfg.Config, name)
+	if err != nil {
+		logger.Fatal("could not create queue receiver", zap.String("queue", name), zap.Error(err))
+	}
+	return sender, receiver
+}
@@ -1,10 +1,23 @@
 package builder
 
 import (
+	"time"
+
 	"github.com/go-redis/redis/v8"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	"go.uber.org/zap"
 )
 
 func NewRedisClient(cfg *config.Configuration) *redis.Client {
 	return redis.NewClient(&redis.Options{Addr: cfg.Redis.RedisUri})
 }
+
+// NewResponseInvalidator creates the invalidator of the cached api responses.
+// Governor updates arrive from every guardian, so each group is invalidated at most once per minute.
+func NewResponseInvalidator(cfg *config.Configuration, logger *zap.Logger) cache.ResponseInvalidator {
+	if cfg.IsLocal {
+		return cache.NewNoopResponseInvalidator()
+	}
+	return cache.NewResponseInvalidator(NewRedisClient(cfg), cfg.Redis.RedisPrefix, time.Minute, logger)
+}
@@ -37,6 +37,12 @@ func NewVAAConsumePublish(ctx context.Context, cfg *config.Configuration, logger
 		return health.Noop(), vaaQueue.Consume, vaaQueue.Publish
 	}
 
+	if !cfg.IsSQS() {
+		sender, receiver := newQueueSenderReceiver(ctx, cfg, cfg.VaasQueueName, logger)
+		vaaQueue := queue.NewVAAQueue(sender, receiver, logger)
+		return health.Noop(), vaaQueue.Consume, vaaQueue.Publish
+	}
+
 	awsConfig, err := NewAwsConfig(ctx, cfg)
 	if err != nil {
 		logger.Fatal("could not create aws config", zap.Error(err))
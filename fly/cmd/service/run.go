@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	"github.com/certusone/wormhole/node/pkg/p2p"
+	"github.com/certusone/wormhole/node/pkg/supervisor"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	healthcheck "github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/builder"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/config"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/gossip"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/gossip/recorder"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/health"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/migration"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/processor"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/producer"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/server"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/storage"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/txhash"
+	"go.uber.org/zap"
+)
+
+// Service is a running fly. The gossip messages are pushed to the channels by the p2p network,
+// or by the services that run fly in-process.
+type Service struct {
+	// Channels are the channels of the gossip messages handled by fly.
+	Channels *gossip.GossipChannels
+	// TxHashStore stores the tx hash of the vaas, resolved from the observations.
+	TxHashStore txhash.TxHashStore
+
+	cfg                       *config.Configuration
+	p2pNetworkConfig          *config.P2pNetworkConfig
+	guardianSetState          *common.GuardianSetState
+	db                        *dbutil.Session
+	server                    *server.Server
+	vaaGossipConsumerSplitter *processor.VAAGossipConsumerSplitter
+	observationGossipConsumer interface{ Close() }
+	recorder                  *recorder.Recorder
+	logger                    *zap.Logger
+}
+
+// Start starts fly with the configuration: the storage, the processors and the handlers of the gossip channels.
+// The gossip messages are not received until RunP2p is called or they are pushed to the channels.
+// The metrics are registered in the registry and served by the http server.
+func Start(rootCtx context.Context, cfg *config.Configuration, logger *zap.Logger, registry *prometheus.Registry) (*Service, error) {
+
+	// Get p2p values to connect p2p network
+	p2pNetworkConfig, err := cfg.GetP2pNetwork()
+	if err != nil {
+		return nil, err
+	}
+
+	// New alert client
+	alertClient, err := builder.NewAlertClient(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create alert client: %w", err)
+	}
+
+	// New metrics client
+	metrics := builder.NewMetrics(cfg, registry)
+
+	// New database session
+	db, err := builder.NewDatabase(rootCtx, cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to DB: %w", err)
+	}
+
+	// Run the database migration.
+	err = migration.Run(db.Database)
+	if err != nil {
+		return nil, fmt.Errorf("error running migration: %w", err)
+	}
+
+	// Creates a callback to publish VAA messages to a redis pubsub
+	vaaRedisProducerFunc, err := builder.NewVAARedisProducerFunc(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create vaa redis producer: %w", err)
+	}
+
+	// Creates a composite callback to publish VAA messages to a redis pubsub
+	producerFunc := producer.NewComposite(vaaRedisProducerFunc)
+
+	txHashStore, err := builder.NewTxHashStore(rootCtx, cfg, metrics, db.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create tx hash store: %w", err)
+	}
+	eventDispatcher, healthEvents := builder.NewEventDispatcher(rootCtx, cfg, logger)
+
+	repository := storage.NewRepository(alertClient, metrics, db.Database, producerFunc, txHashStore, eventDispatcher, logger)
+
+	vaaNonPythDedup, err := builder.NewDeduplicator("vaas-dedup", cfg.VaasDedup, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create vaa deduplicator: %w", err)
+	}
+
+	vaaPythDedup, err := builder.NewDeduplicator("vaas-pyth-dedup", cfg.VaasPythDedup, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not create vaa deduplicator: %w", err)
+	}
+
+	channels := builder.NewGossipChannels(cfg)
+
+	guardianSetSyncronizer, err := builder.NewGuardianSetSynchronizer(rootCtx, db, channels.HeartbeatChannel,
+		logger, cfg, alertClient)
+	if err != nil {
+		return nil, fmt.Errorf("could not create guardian set synchronizer: %w", err)
+	}
+	guardianSetSyncronizer.Sync(rootCtx)
+
+	guardianSetHistory := guardianSetSyncronizer.GetGuardianSetHistory()
+	gst := guardianSetSyncronizer.GetLatestGuardianSet()
+
+	// Ignore observation requests
+	// Note: without this, the whole program hangs on observation requests
+	discardMessages(rootCtx, channels.ObsvReqChannel)
+	guardianCheck := health.NewGuardianCheck(cfg.MaxHealthTimeSeconds)
+
+	healthObservations, observationQueueConsume, observationPublish := builder.NewObservationConsumePublish(rootCtx, cfg, logger)
+	observationGossipConsumer := processor.NewObservationGossipConsumer(observationPublish, gst, p2pNetworkConfig.Enviroment,
+		cfg.ObservationsChannelSize, cfg.ObservationsWorkersSize, metrics, txHashStore, repository, logger)
+	observationQueueConsumer := processor.NewObservationQueueConsumer(observationQueueConsume, repository, metrics, logger)
+	observationGossipConsumer.Start(rootCtx)
+	observationQueueConsumer.Start(rootCtx)
+
+	// Log observations
+	observationHandler := gossip.NewObservationHandler(channels.ObsvChannel, channels.BatchObsvC, observationGossipConsumer.Push, observationGossipConsumer.PushBatch, guardianCheck, metrics)
+	observationHandler.Start(rootCtx)
+
+	// Log signed VAAs
+	// Creates two callbacks
+	healthVaas, vaaQueueConsume, nonPythVaaPublish := builder.NewVAAConsumePublish(rootCtx, cfg, logger)
+	// Create a vaa notifier
+	notifierFunc := builder.NewVAANotifierFunc(cfg, logger)
+	// Creates a instance to consume VAA messages from Gossip network and handle the messages
+	// When recive a message, the message filter by deduplicator
+	// if VAA is from pyhnet should be saved directly to repository
+	// if VAA is from non pyhnet should be publish with nonPythVaaPublish
+	vaaGossipConsumer := processor.NewVAAGossipConsumer(guardianSetHistory, vaaNonPythDedup, vaaPythDedup, nonPythVaaPublish, repository.UpsertVaa, metrics, repository, logger)
+	// Creates a instance to consume VAA messages (non pyth) from a queue and store in a storage
+	vaaQueueConsumer := processor.NewVAAQueueConsumer(vaaQueueConsume, repository, notifierFunc, metrics, logger)
+	// Creates a wrapper that splits the incoming VAAs into 2 channels (pyth to non pyth) in order
+	// to be able to process them in a differentiated way
+	vaaGossipConsumerSplitter := processor.NewVAAGossipSplitterConsumer(vaaGossipConsumer.Push, cfg.VaasWorkersSize, logger, processor.WithSize(cfg.VaasChannelSize))
+	vaaQueueConsumer.Start(rootCtx)
+	vaaGossipConsumerSplitter.Start(rootCtx)
+
+	// start fly http server.
+	healthChecks := []healthcheck.Check{healthObservations, healthVaas, healthEvents, builder.CheckGuardian(guardianCheck)}
+	pprofEnabled := cfg.PprofEnabled
+	server := server.NewServer(cfg.ApiPort, guardianCheck, logger, repository, pprofEnabled, alertClient, registry, healthChecks...)
+	server.Start()
+
+	// VAA handler
+	vaaHandler := gossip.NewVaaHandler(p2pNetworkConfig, metrics, channels.SignedInChannel, vaaGossipConsumerSplitter.Push, guardianCheck, logger)
+	vaaHandler.Start(rootCtx)
+
+	// Heartbeats handler
+	hearbeatsHandler := gossip.NewHeartbeatsHandler(channels.HeartbeatChannel, repository, guardianCheck, metrics, logger)
+	hearbeatsHandler.Start(rootCtx)
+
+	// Invalidates the cached api responses when the governor is updated
+	responseInvalidator := builder.NewResponseInvalidator(cfg, logger)
+
+	// Governor config handler
+	governorConfigHandler := gossip.NewGovernorConfigHandler(channels.GovConfigChannel, repository, guardianCheck, metrics, responseInvalidator, logger)
+	governorConfigHandler.Start(rootCtx)
+
+	// Governor status handler
+	governorStatusHandler := gossip.NewGovernorStatusHandler(channels.GovStatusChannel, repository, guardianCheck, metrics, responseInvalidator, logger)
+	governorStatusHandler.Start(rootCtx)
+
+	return &Service{
+		Channels:                  channels,
+		TxHashStore:               txHashStore,
+		cfg:                       cfg,
+		p2pNetworkConfig:          p2pNetworkConfig,
+		guardianSetState:          gst,
+		db:                        db,
+		server:                    server,
+		vaaGossipConsumerSplitter: vaaGossipConsumerSplitter,
+		observationGossipConsumer: observationGossipConsumer,
+		logger:                    logger,
+	}, nil
+}
+
+// RunP2p connects to the p2p network and pushes the received gossip messages to the channels.
+// The rootCtxCancel function is called by the p2p network when it stops.
+func (s *Service) RunP2p(rootCtx context.Context, rootCtxCancel context.CancelFunc, nodeKeyPath string) error {
+
+	// Verify flags
+	if nodeKeyPath == "" {
+		return errors.New("please specify --nodeKey")
+	}
+	if s.p2pNetworkConfig.P2pBootstrap == "" {
+		return errors.New("please specify --bootstrap")
+	}
+
+	// Load p2p private key
+	var priv crypto.PrivKey
+	priv, err := common.GetOrCreateNodeKey(s.logger, nodeKeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load node key: %w", err)
+	}
+
+	components := p2p.DefaultComponents()
+	components.Port = s.cfg.P2pPort
+	components.WarnChannelOverflow = true
+
+	// Record the gossip messages between the p2p network and the handlers
+	channels := s.Channels
+	if s.cfg.GossipRecordDir != "" {
+		rec, err := recorder.NewRecorder(s.cfg.GossipRecordDir, s.cfg.GossipRecordMaxFileSizeMB*1024*1024, s.cfg.GossipRecordMaxFiles, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to create gossip recorder: %w", err)
+		}
+		rec.Start(rootCtx)
+		s.recorder = rec
+		channels = builder.NewGossipChannels(s.cfg)
+		recorder.Tee(rootCtx, channels, s.Channels, rec, s.logger)
+	}
+
+	runParams, errRunParams := p2p.NewRunParams(
+		s.p2pNetworkConfig.P2pBootstrap,
+		s.p2pNetworkConfig.P2pNetworkID,
+		priv,
+		s.guardianSetState,
+		rootCtxCancel,
+		p2p.WithSignedObservationListener(channels.ObsvChannel),
+		p2p.WithSignedObservationBatchListener(channels.BatchObsvC),
+		p2p.WithSignedVAAListener(channels.SignedInChannel),
+		p2p.WithObservationRequestListener(channels.ObsvReqChannel),
+		p2p.WithChainGovernorConfigListener(channels.GovConfigChannel),
+		p2p.WithChainGovernorStatusListener(channels.GovStatusChannel),
+		p2p.WithComponents(components),
+	)
+	if errRunParams != nil {
+		return fmt.Errorf("failed to create run params: %w", errRunParams)
+	}
+
+	// Run supervisor.
+	supervisor.New(rootCtx, s.logger, func(ctx context.Context) error {
+		if err := supervisor.Run(ctx, "p2p",
+			p2p.Run(
+				runParams,
+			)); err != nil {
+			return err
+		}
+
+		s.logger.Info("Started internal services")
+
+		<-ctx.Done()
+		return nil
+	},
+		// It's safer to crash and restart the process in case we encounter a panic,
+		// rather than attempting to reschedule the runnable.
+		supervisor.WithPropagatePanic)
+
+	return nil
+}
+
+// RunReplay pushes the messages of the gossip recording to the channels instead of connecting to the p2p network.
+// The replay runs in a separate goroutine, and fly keeps running when it finishes.
+func (s *Service) RunReplay(rootCtx context.Context) error {
+	if s.cfg.GossipReplayPath == "" {
+		return errors.New("please specify GOSSIP_REPLAY_PATH")
+	}
+
+	replayer := recorder.NewReplayer(s.cfg.GossipReplayPath, s.cfg.GossipReplaySpeed, s.Channels, s.logger)
+	go func() {
+		s.logger.Info("Replaying gossip recording",
+			zap.String("path", s.cfg.GossipReplayPath),
+			zap.Float64("speed", s.cfg.GossipReplaySpeed))
+		stats, err := replayer.Run(rootCtx)
+		if err != nil {
+			s.logger.Error("Error replaying gossip recording", zap.Error(err), zap.Int("messages", stats.Messages))
+			return
+		}
+		s.logger.Info("Finished replaying gossip recording",
+			zap.Int("messages", stats.Messages),
+			zap.Duration("duration", stats.Duration),
+			zap.Float64("messagesPerSecond", float64(stats.Messages)/stats.Duration.Seconds()))
+	}()
+
+	return nil
+}
+
+// Stop closes the gossip consumers, the gossip recorder, the http server and the MongoDB connection.
+func (s *Service) Stop() {
+	// TODO: wait for things to shut down gracefully
+	s.vaaGossipConsumerSplitter.Close()
+	s.observationGossipConsumer.Close()
+	if s.recorder != nil {
+		if err := s.recorder.Close(); err != nil {
+			s.logger.Error("Error closing gossip recorder", zap.Error(err))
+		}
+	}
+	s.server.Stop()
+
+	s.logger.Info("Closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
+}
+
+func discardMessages[T any](ctx context.Context, obsvReqC chan T) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-obsvReqC:
+			}
+		}
+	}()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 // p2p network configuration constants.
@@ -50,6 +51,7 @@ type Configuration struct {
 	ObservationsWorkersSize   int    `env:"OBSERVATIONS_WORKERS_SIZE,default=10"`
 	AlertEnabled              bool   `env:"ALERT_ENABLED"`
 	AlertApiKey               string `env:"ALERT_API_KEY"`
+	AlertRouting              string `env:"ALERT_ROUTING"`
 	MetricsEnabled            bool   `env:"METRICS_ENABLED"`
 	ApiPort                   uint   `env:"API_PORT,required"`
 	P2pPort                   uint   `env:"P2P_PORT,required"`
@@ -64,6 +66,26 @@ type Configuration struct {
 	VaasPythDedup             Cache `env:", prefix=VAAS_PYTH_DEDUP_,required"`
 
 	EthereumUrl string `env:"ETHEREUM_URL,required"`
+
+	// GossipRecordDir is the directory where the gossip messages received from the p2p network are recorded.
+	// Recording is disabled when it is empty.
+	GossipRecordDir string `env:"GOSSIP_RECORD_DIR"`
+	// GossipRecordMaxFileSizeMB is the size of a recording file before a new file is created.
+	GossipRecordMaxFileSizeMB int64 `env:"GOSSIP_RECORD_MAX_FILE_SIZE_MB,default=100"`
+	// GossipRecordMaxFiles is the number of recording files kept, the oldest files are deleted. Every file is kept when it is 0.
+	GossipRecordMaxFiles int `env:"GOSSIP_RECORD_MAX_FILES"`
+	// GossipReplayPath is the recording file or directory replayed instead of connecting to the p2p network.
+	GossipReplayPath string `env:"GOSSIP_REPLAY_PATH"`
+	// GossipReplaySpeed multiplies the speed of the replay. A speed of 0 replays the messages without waiting.
+	GossipReplaySpeed float64 `env:"GOSSIP_REPLAY_SPEED,default=1"`
+
+	// Queue names used with the kafka, nats, redis and memory queue backends.
+	VaasQueueName         string `env:"VAAS_QUEUE_NAME,default=wormscan-fly-vaas"`
+	ObservationsQueueName string `env:"OBSERVATIONS_QUEUE_NAME,default=wormscan-fly-observations"`
+	DuplicateVaaQueueName string `env:"DUPLICATE_VAA_QUEUE_NAME,default=wormscan-duplicate-vaa"`
+	GovernorQueueName     string `env:"GOVERNOR_QUEUE_NAME,default=wormscan-governor-status"`
+	// Config is the queue backend. The aws configuration is only required by the sqs backend.
+	queue.Config
 }
 
 type RedisConfiguration struct {
@@ -106,11 +128,13 @@ func New(ctx context.Context, isLocal *bool) (*Configuration, error) {
 		}
 		configuration.Redis = &redis
 
-		var aws AwsConfiguration
-		if err := envconfig.Process(ctx, &aws); err != nil {
-			return nil, err
+		if configuration.IsSQS() {
+			var aws AwsConfiguration
+			if err := envconfig.Process(ctx, &aws); err != nil {
+				return nil, err
+			}
+			configuration.Aws = &aws
 		}
-		configuration.Aws = &aws
 	}
 
 	return &configuration, nil
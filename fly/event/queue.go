@@ -0,0 +1,63 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/track"
+)
+
+// QueueEventDispatcher sends the events to the kafka, nats, redis or memory queue backends.
+// Unlike the sns topic, whose subscriptions filter the events by the message type, each event
+// type is sent to its own queue.
+type QueueEventDispatcher struct {
+	duplicateVaa   common_queue.Sender
+	governorStatus common_queue.Sender
+}
+
+func NewQueueEventDispatcher(duplicateVaa, governorStatus common_queue.Sender) *QueueEventDispatcher {
+	return &QueueEventDispatcher{
+		duplicateVaa:   duplicateVaa,
+		governorStatus: governorStatus,
+	}
+}
+
+func (q *QueueEventDispatcher) NewDuplicateVaa(ctx context.Context, e DuplicateVaa) error {
+	body, err := json.Marshal(event{
+		TrackID: track.GetTrackIDForDuplicatedVAA(e.VaaID),
+		Type:    "duplicated-vaa",
+		Source:  "fly",
+		Data:    e,
+	})
+	if err != nil {
+		return err
+	}
+	groupID := createDeduplicationIDForDuplicateVaa(e)
+	return q.duplicateVaa.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         groupID,
+		DeduplicationID: groupID,
+		Body:            string(body),
+		Attributes:      map[string]string{"messageType": "duplicated-vaa"},
+	})
+}
+
+func (q *QueueEventDispatcher) NewGovernorStatus(ctx context.Context, e GovernorStatus) error {
+	body, err := json.Marshal(event{
+		TrackID: track.GetTrackIDForGovernorStatus(e.NodeName, e.Timestamp),
+		Type:    "governor-status",
+		Source:  "fly",
+		Data:    e,
+	})
+	if err != nil {
+		return err
+	}
+	groupID := fmt.Sprintf("%s-%v", e.NodeAddress, e.Timestamp)
+	return q.governorStatus.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         groupID,
+		DeduplicationID: groupID,
+		Body:            string(body),
+		Attributes:      map[string]string{"messageType": "governor"},
+	})
+}
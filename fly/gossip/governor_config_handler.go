@@ -4,6 +4,7 @@ import (
 	"context"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/health"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/storage"
@@ -12,11 +13,12 @@ import (
 )
 
 type governorConfigHandler struct {
-	govConfigC chan *gossipv1.SignedChainGovernorConfig
-	repository *storage.Repository
-	guardian   *health.GuardianCheck
-	metrics    metrics.Metrics
-	logger     *zap.Logger
+	govConfigC  chan *gossipv1.SignedChainGovernorConfig
+	repository  *storage.Repository
+	guardian    *health.GuardianCheck
+	metrics     metrics.Metrics
+	invalidator cache.ResponseInvalidator
+	logger      *zap.Logger
 }
 
 func NewGovernorConfigHandler(
@@ -24,14 +26,16 @@ func NewGovernorConfigHandler(
 	repository *storage.Repository,
 	guardian *health.GuardianCheck,
 	metrics metrics.Metrics,
+	invalidator cache.ResponseInvalidator,
 	logger *zap.Logger,
 ) *governorConfigHandler {
 	return &governorConfigHandler{
-		govConfigC: govConfigC,
-		repository: repository,
-		guardian:   guardian,
-		metrics:    metrics,
-		logger:     logger,
+		govConfigC:  govConfigC,
+		repository:  repository,
+		guardian:    guardian,
+		metrics:     metrics,
+		invalidator: invalidator,
+		logger:      logger,
 	}
 }
 
@@ -56,6 +60,8 @@ func (h *governorConfigHandler) Start(ctx context.Context) {
 					h.logger.Error("Error inserting gov config", zap.Error(err))
 				} else {
 					h.metrics.IncGovernorConfigInserted(nodeName)
+					// the governor responses of the api are stale after an update
+					_ = h.invalidator.Invalidate(ctx, cache.ResponseGroupGovernor)
 				}
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/health"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/internal/metrics"
 	"github.com/wormhole-foundation/wormhole-explorer/fly/storage"
@@ -12,11 +13,12 @@ import (
 )
 
 type governorStatusHandler struct {
-	govStatusC chan *gossipv1.SignedChainGovernorStatus
-	repository *storage.Repository
-	guardian   *health.GuardianCheck
-	metrics    metrics.Metrics
-	logger     *zap.Logger
+	govStatusC  chan *gossipv1.SignedChainGovernorStatus
+	repository  *storage.Repository
+	guardian    *health.GuardianCheck
+	metrics     metrics.Metrics
+	invalidator cache.ResponseInvalidator
+	logger      *zap.Logger
 }
 
 func NewGovernorStatusHandler(
@@ -24,14 +26,16 @@ func NewGovernorStatusHandler(
 	repository *storage.Repository,
 	guardian *health.GuardianCheck,
 	metrics metrics.Metrics,
+	invalidator cache.ResponseInvalidator,
 	logger *zap.Logger,
 ) *governorStatusHandler {
 	return &governorStatusHandler{
-		govStatusC: govStatusC,
-		repository: repository,
-		guardian:   guardian,
-		metrics:    metrics,
-		logger:     logger,
+		govStatusC:  govStatusC,
+		repository:  repository,
+		guardian:    guardian,
+		metrics:     metrics,
+		invalidator: invalidator,
+		logger:      logger,
 	}
 }
 
@@ -55,6 +59,8 @@ func (h *governorStatusHandler) Start(ctx context.Context) {
 					h.logger.Error("Error inserting gov status", zap.Error(err))
 				} else {
 					h.metrics.IncGovernorStatusInserted(nodeName)
+					// the governor responses of the api are stale after an update
+					_ = h.invalidator.Invalidate(ctx, cache.ResponseGroupGovernor)
 				}
 			}
 		}
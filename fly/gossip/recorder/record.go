@@ -0,0 +1,135 @@
+// Package recorder records the gossip messages received by fly to rotating files,
+// and replays the recordings to the channels of the gossip handlers.
+package recorder
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Kind is the type of the gossip message of a record.
+type Kind uint8
+
+// Kinds of the recorded gossip messages.
+const (
+	KindSignedVAA Kind = iota + 1
+	KindObservation
+	KindBatchObservation
+	KindHeartbeat
+	KindGovernorConfig
+	KindGovernorStatus
+)
+
+// fileMagic is written at the start of every recording file.
+const fileMagic = "FLYGOSSIP1"
+
+// headerSize is the size of the header of a record: timestamp, kind and message length.
+const headerSize = 8 + 1 + 4
+
+// maxMessageSize is the maximum size of a recorded message, to detect corrupted files.
+const maxMessageSize = 64 * 1024 * 1024
+
+// ErrInvalidFile is returned when a file is not a recording.
+var ErrInvalidFile = errors.New("invalid recording file")
+
+// Record is a gossip message and the time it was received.
+type Record struct {
+	Timestamp time.Time
+	Kind      Kind
+	Message   proto.Message
+}
+
+// newMessage returns an empty message of the kind.
+func newMessage(kind Kind) (proto.Message, error) {
+	switch kind {
+	case KindSignedVAA:
+		return &gossipv1.SignedVAAWithQuorum{}, nil
+	case KindObservation:
+		return &gossipv1.SignedObservation{}, nil
+	case KindBatchObservation:
+		return &gossipv1.SignedObservationBatch{}, nil
+	case KindHeartbeat:
+		return &gossipv1.Heartbeat{}, nil
+	case KindGovernorConfig:
+		return &gossipv1.SignedChainGovernorConfig{}, nil
+	case KindGovernorStatus:
+		return &gossipv1.SignedChainGovernorStatus{}, nil
+	default:
+		return nil, fmt.Errorf("unknown record kind %d", kind)
+	}
+}
+
+// writeRecord writes a record: the timestamp in unix nanoseconds, the kind,
+// the length of the message and the protobuf encoded message. It returns the written bytes.
+func writeRecord(w io.Writer, timestamp time.Time, kind Kind, data []byte) (int, error) {
+	var header [headerSize]byte
+	binary.BigEndian.PutUint64(header[0:8], uint64(timestamp.UnixNano()))
+	header[8] = byte(kind)
+	binary.BigEndian.PutUint32(header[9:13], uint32(len(data)))
+
+	n, err := w.Write(header[:])
+	if err != nil {
+		return n, err
+	}
+	m, err := w.Write(data)
+	return n + m, err
+}
+
+// readRecord reads the next record. It returns io.EOF at the end of the file,
+// and io.ErrUnexpectedEOF when the last record is truncated.
+func readRecord(r io.Reader) (*Record, error) {
+	var header [headerSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	kind := Kind(header[8])
+	size := binary.BigEndian.Uint32(header[9:13])
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("%w: message of %d bytes", ErrInvalidFile, size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	msg, err := newMessage(kind)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFile, err)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %d record: %w", kind, err)
+	}
+
+	return &Record{
+		Timestamp: time.Unix(0, int64(binary.BigEndian.Uint64(header[0:8]))),
+		Kind:      kind,
+		Message:   msg,
+	}, nil
+}
+
+// readMagic checks the start of a recording file. An empty file is truncated, as the magic is
+// buffered until the first flush.
+func readMagic(r io.Reader) error {
+	magic := make([]byte, len(fileMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	if string(magic) != fileMagic {
+		return ErrInvalidFile
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package recorder
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// fileExtension is the extension of the recording files.
+const fileExtension = ".rec"
+
+// flushInterval is the interval to flush the buffered records to the file.
+const flushInterval = time.Second
+
+// Recorder writes the gossip messages with the time they were received to the files of a directory.
+// A new file is created when the current one reaches the maximum size, and the oldest files are deleted
+// when there are more than the maximum number of files.
+type Recorder struct {
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	mu          sync.Mutex
+	file        *os.File
+	writer      *bufio.Writer
+	size        int64
+	now         func() time.Time
+	logger      *zap.Logger
+}
+
+// NewRecorder creates a recorder of the files of the directory, which is created if it doesn't exist.
+// A maximum number of files of 0 keeps every file.
+func NewRecorder(dir string, maxFileSize int64, maxFiles int, logger *zap.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	return &Recorder{
+		dir:         dir,
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+		now:         time.Now,
+		logger:      logger,
+	}, nil
+}
+
+// Start flushes the buffered records to the file every second until the context is cancelled.
+func (r *Recorder) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.Flush(); err != nil {
+					r.logger.Error("Error flushing gossip recording", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Record writes a gossip message with the current time.
+func (r *Recorder) Record(kind Kind, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %d record: %w", kind, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil || r.size >= r.maxFileSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := writeRecord(r.writer, r.now(), kind, data)
+	r.size += int64(n)
+	return err
+}
+
+// Flush writes the buffered records to the file.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.writer == nil {
+		return nil
+	}
+	return r.writer.Flush()
+}
+
+// Close flushes the buffered records and closes the file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeFile()
+}
+
+// rotate closes the current file and creates a new one, named by the current time to sort the files.
+func (r *Recorder) rotate() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+
+	name := filepath.Join(r.dir, "gossip-"+r.now().UTC().Format("20060102T150405.000000000Z")+fileExtension)
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create recording file: %w", err)
+	}
+	r.logger.Info("Recording gossip messages", zap.String("file", name))
+
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	n, err := r.writer.WriteString(fileMagic)
+	r.size = int64(n)
+	if err != nil {
+		return err
+	}
+	return r.deleteOldFiles()
+}
+
+// deleteOldFiles deletes the oldest recording files when there are more than the maximum number of files.
+func (r *Recorder) deleteOldFiles() error {
+	if r.maxFiles <= 0 {
+		return nil
+	}
+	files, err := recordingFiles(r.dir)
+	if err != nil {
+		return fmt.Errorf("failed to list recording files: %w", err)
+	}
+	for len(files) > r.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			return fmt.Errorf("failed to delete recording file: %w", err)
+		}
+		r.logger.Info("Deleted gossip recording", zap.String("file", files[0]))
+		files = files[1:]
+	}
+	return nil
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	flushErr := r.writer.Flush()
+	closeErr := r.file.Close()
+	r.file = nil
+	r.writer = nil
+	if flushErr != nil {
+		return flushErr
+	}
+	return closeErr
+}
@@ -0,0 +1,160 @@
+package recorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/gossip"
+	"go.uber.org/zap/zaptest"
+)
+
+func newChannels(size int) *gossip.GossipChannels {
+	return &gossip.GossipChannels{
+		ObsvChannel:      make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservation], size),
+		BatchObsvC:       make(chan *common.MsgWithTimeStamp[gossipv1.SignedObservationBatch], size),
+		ObsvReqChannel:   make(chan *gossipv1.ObservationRequest, size),
+		SignedInChannel:  make(chan *gossipv1.SignedVAAWithQuorum, size),
+		HeartbeatChannel: make(chan *gossipv1.Heartbeat, size),
+		GovConfigChannel: make(chan *gossipv1.SignedChainGovernorConfig, size),
+		GovStatusChannel: make(chan *gossipv1.SignedChainGovernorStatus, size),
+	}
+}
+
+func TestRecorder_RotatesFilesAndReplaysInOrder(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+
+	r, err := NewRecorder(dir, 64, 0, logger)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{byte(i)}}))
+		require.NoError(t, r.Record(KindObservation, &gossipv1.SignedObservation{MessageId: "2/emitter/" + strconv.Itoa(i)}))
+	}
+	require.NoError(t, r.Record(KindHeartbeat, &gossipv1.Heartbeat{NodeName: "guardian"}))
+	require.NoError(t, r.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+	assert.Greater(t, len(files), 1)
+
+	channels := newChannels(20)
+	stats, err := NewReplayer(dir, 0, channels, logger).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 11, stats.Messages)
+
+	require.Len(t, channels.SignedInChannel, 5)
+	require.Len(t, channels.ObsvChannel, 5)
+	require.Len(t, channels.HeartbeatChannel, 1)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, []byte{byte(i)}, (<-channels.SignedInChannel).Vaa)
+		assert.Equal(t, "2/emitter/"+strconv.Itoa(i), (<-channels.ObsvChannel).Msg.MessageId)
+	}
+	assert.Equal(t, "guardian", (<-channels.HeartbeatChannel).NodeName)
+}
+
+func TestReplayer_SkipsTruncatedRecord(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+
+	r, err := NewRecorder(dir, 1024, 0, logger)
+	require.NoError(t, err)
+	require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{1}}))
+	require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{2}}))
+	require.NoError(t, r.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(files[0], info.Size()-1))
+
+	channels := newChannels(10)
+	stats, err := NewReplayer(files[0], 0, channels, logger).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Messages)
+	assert.Equal(t, []byte{1}, (<-channels.SignedInChannel).Vaa)
+}
+
+func TestReplayer_WaitsRecordedIntervalDividedBySpeed(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+
+	r, err := NewRecorder(dir, 1024, 0, logger)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+	require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{1}}))
+	now = now.Add(2 * time.Second)
+	require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{2}}))
+	require.NoError(t, r.Close())
+
+	stats, err := NewReplayer(dir, 10, newChannels(10), logger).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Messages)
+	assert.GreaterOrEqual(t, stats.Duration, 200*time.Millisecond)
+	assert.Less(t, stats.Duration, 2*time.Second)
+}
+
+func TestRecorder_DeletesOldestFiles(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+
+	r, err := NewRecorder(dir, 16, 2, logger)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, r.Record(KindSignedVAA, &gossipv1.SignedVAAWithQuorum{Vaa: []byte{byte(i)}}))
+	}
+	require.NoError(t, r.Close())
+
+	// every record is written to a new file, and only the files of the last 2 records are kept.
+	files, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	channels := newChannels(10)
+	stats, err := NewReplayer(dir, 0, channels, logger).Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Messages)
+	assert.Equal(t, []byte{3}, (<-channels.SignedInChannel).Vaa)
+	assert.Equal(t, []byte{4}, (<-channels.SignedInChannel).Vaa)
+}
+
+func TestReplayer_KeepsRecordedObservationTime(t *testing.T) {
+	dir := t.TempDir()
+	logger := zaptest.NewLogger(t)
+
+	r, err := NewRecorder(dir, 1024, 0, logger)
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+	require.NoError(t, r.Record(KindObservation, &gossipv1.SignedObservation{MessageId: "2/emitter/1"}))
+	require.NoError(t, r.Record(KindBatchObservation, &gossipv1.SignedObservationBatch{Addr: []byte{1}}))
+	require.NoError(t, r.Close())
+
+	channels := newChannels(10)
+	_, err = NewReplayer(dir, 0, channels, logger).Run(context.Background())
+	require.NoError(t, err)
+	assert.True(t, now.Equal((<-channels.ObsvChannel).Timestamp))
+	assert.True(t, now.Equal((<-channels.BatchObsvC).Timestamp))
+}
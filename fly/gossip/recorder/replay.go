@@ -0,0 +1,178 @@
+package recorder
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/gossip"
+	"go.uber.org/zap"
+)
+
+// Replayer pushes the messages of a recording to the channels of the gossip handlers, in the recorded order.
+// Unlike the p2p network, it waits while a channel is full, so every recorded message is processed.
+type Replayer struct {
+	path     string
+	speed    float64
+	channels *gossip.GossipChannels
+	logger   *zap.Logger
+}
+
+// ReplayStats are the statistics of a replay.
+type ReplayStats struct {
+	Messages int
+	Duration time.Duration
+}
+
+// NewReplayer creates a replayer of the recording file, or of the recording files of the directory.
+// The recorded interval between messages is divided by the speed, and a speed of 0 replays
+// the messages without waiting.
+func NewReplayer(path string, speed float64, channels *gossip.GossipChannels, logger *zap.Logger) *Replayer {
+	return &Replayer{
+		path:     path,
+		speed:    speed,
+		channels: channels,
+		logger:   logger,
+	}
+}
+
+// Run replays the recording until the last message or until the context is cancelled.
+func (r *Replayer) Run(ctx context.Context) (ReplayStats, error) {
+	files, err := recordingFiles(r.path)
+	if err != nil {
+		return ReplayStats{}, err
+	}
+
+	var stats ReplayStats
+	start := time.Now()
+	var first time.Time
+	for _, file := range files {
+		r.logger.Info("Replaying gossip recording", zap.String("file", file))
+		err := readFile(file, func(record *Record) error {
+			if first.IsZero() {
+				first = record.Timestamp
+			}
+			if err := r.wait(ctx, start, record.Timestamp.Sub(first)); err != nil {
+				return err
+			}
+			if err := r.push(ctx, record); err != nil {
+				return err
+			}
+			stats.Messages++
+			return nil
+		})
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			// the last record of a file is truncated when fly stops while recording.
+			r.logger.Warn("Truncated gossip recording", zap.String("file", file))
+			continue
+		}
+		if err != nil {
+			stats.Duration = time.Since(start)
+			return stats, err
+		}
+	}
+
+	stats.Duration = time.Since(start)
+	return stats, nil
+}
+
+// wait waits until the elapsed time of the replay is the recorded elapsed time divided by the speed.
+func (r *Replayer) wait(ctx context.Context, start time.Time, recorded time.Duration) error {
+	if r.speed <= 0 {
+		return ctx.Err()
+	}
+	delay := time.Until(start.Add(time.Duration(float64(recorded) / r.speed)))
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// push pushes the message of the record to the channel of its kind.
+// The observations keep the time they were recorded, as if they had just been received from the p2p network.
+func (r *Replayer) push(ctx context.Context, record *Record) error {
+	switch m := record.Message.(type) {
+	case *gossipv1.SignedVAAWithQuorum:
+		return send(ctx, r.channels.SignedInChannel, m)
+	case *gossipv1.SignedObservation:
+		return send(ctx, r.channels.ObsvChannel, &common.MsgWithTimeStamp[gossipv1.SignedObservation]{Msg: m, Timestamp: record.Timestamp})
+	case *gossipv1.SignedObservationBatch:
+		return send(ctx, r.channels.BatchObsvC, &common.MsgWithTimeStamp[gossipv1.SignedObservationBatch]{Msg: m, Timestamp: record.Timestamp})
+	case *gossipv1.Heartbeat:
+		return send(ctx, r.channels.HeartbeatChannel, m)
+	case *gossipv1.SignedChainGovernorConfig:
+		return send(ctx, r.channels.GovConfigChannel, m)
+	case *gossipv1.SignedChainGovernorStatus:
+		return send(ctx, r.channels.GovStatusChannel, m)
+	default:
+		return fmt.Errorf("unexpected record message %T", m)
+	}
+}
+
+func send[T any](ctx context.Context, c chan<- T, m T) error {
+	select {
+	case c <- m:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recordingFiles returns the file of the path, or the recording files of the directory sorted by name.
+func recordingFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*"+fileExtension))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readFile calls fn with every record of the file.
+func readFile(path string, fn func(*Record) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	if err := readMagic(reader); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for {
+		record, err := readRecord(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package recorder
+
+import (
+	"context"
+
+	"github.com/certusone/wormhole/node/pkg/common"
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	"github.com/wormhole-foundation/wormhole-explorer/fly/gossip"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Tee records the messages of the in channels, filled by the p2p network, and forwards them to the
+// out channels of the gossip handlers. The observation requests are forwarded without recording.
+func Tee(ctx context.Context, in, out *gossip.GossipChannels, r *Recorder, logger *zap.Logger) {
+	go forward(ctx, in.SignedInChannel, out.SignedInChannel, func(m *gossipv1.SignedVAAWithQuorum) proto.Message {
+		return m
+	}, KindSignedVAA, r, logger)
+	go forward(ctx, in.ObsvChannel, out.ObsvChannel, func(m *common.MsgWithTimeStamp[gossipv1.SignedObservation]) proto.Message {
+		return m.Msg
+	}, KindObservation, r, logger)
+	go forward(ctx, in.BatchObsvC, out.BatchObsvC, func(m *common.MsgWithTimeStamp[gossipv1.SignedObservationBatch]) proto.Message {
+		return m.Msg
+	}, KindBatchObservation, r, logger)
+	go forward(ctx, in.HeartbeatChannel, out.HeartbeatChannel, func(m *gossipv1.Heartbeat) proto.Message {
+		return m
+	}, KindHeartbeat, r, logger)
+	go forward(ctx, in.GovConfigChannel, out.GovConfigChannel, func(m *gossipv1.SignedChainGovernorConfig) proto.Message {
+		return m
+	}, KindGovernorConfig, r, logger)
+	go forward(ctx, in.GovStatusChannel, out.GovStatusChannel, func(m *gossipv1.SignedChainGovernorStatus) proto.Message {
+		return m
+	}, KindGovernorStatus, r, logger)
+	go forward(ctx, in.ObsvReqChannel, out.ObsvReqChannel, nil, 0, nil, logger)
+}
+
+// forward records the messages of the in channel with the message returned by toMessage, and pushes them to the out channel.
+func forward[T any](ctx context.Context, in <-chan T, out chan<- T, toMessage func(T) proto.Message, kind Kind, r *Recorder, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m := <-in:
+			if r != nil {
+				if err := r.Record(kind, toMessage(m)); err != nil {
+					logger.Error("Error recording gossip message", zap.Uint8("kind", uint8(kind)), zap.Error(err))
+				}
+			}
+			select {
+			case out <- m:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -56,3 +56,14 @@ func (m *manualGuardianSet) GetGuardianSet(ctx context.Context, index uint32) (*
 func (e *manualGuardianSet) GetGuardianSetHistory(ctx context.Context) (*GuardianSetHistory, error) {
 	return e.gsth, nil
 }
+
+func (m *manualGuardianSet) GetCurrentGuardianSetIndex(ctx context.Context) (uint32, error) {
+	m.gsth.RLock()
+	defer m.gsth.RUnlock()
+	return uint32(len(m.gsth.guardianSetsByIndex) - 1), nil
+}
+
+func (m *manualGuardianSet) AddGuardianSet(ctx context.Context, gs *common.GuardianSet, et time.Time) error {
+	m.gsth.Add(*gs, et)
+	return nil
+}
@@ -28,106 +28,107 @@ type PrometheusMetrics struct {
 	vaaProcessingDuration         *prometheus.HistogramVec
 }
 
-// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
 	constLabels := map[string]string{
 		"environment": environment,
 		"service":     serviceName,
 	}
-	vaaReceivedCount := promauto.NewCounterVec(
+	vaaReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "vaa_count_by_chain",
 			Help:        "Total number of vaa by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "type"})
 
-	vaaTotal := promauto.NewCounter(
+	vaaTotal := factory.NewCounter(
 		prometheus.CounterOpts{
 			Name:        "vaa_total",
 			Help:        "Total number of vaa from Gossip network",
 			ConstLabels: constLabels,
 		})
 
-	observationReceivedCount := promauto.NewCounterVec(
+	observationReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "observation_count_by_chain",
 			Help:        "Total number of observation by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "type"})
 
-	observationTotal := promauto.NewCounter(
+	observationTotal := factory.NewCounter(
 		prometheus.CounterOpts{
 			Name:        "observation_total",
 			Help:        "Total number of observation from Gossip network",
 			ConstLabels: constLabels,
 		})
 
-	batchObservationTotal := promauto.NewCounter(
+	batchObservationTotal := factory.NewCounter(
 		prometheus.CounterOpts{
 			Name:        "batch_observation_total",
 			Help:        "Total number of batch observation messages from Gossip network",
 			ConstLabels: constLabels,
 		})
 
-	batchSizeObservations := promauto.NewGauge(
+	batchSizeObservations := factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name:        "batch_size_observations",
 			Help:        "Batch-observation sizes incoming from Gossip network",
 			ConstLabels: constLabels,
 		})
 
-	observationReceivedByGuardian := promauto.NewCounterVec(
+	observationReceivedByGuardian := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "observation_count_by_guardian",
 			Help:        "Total number of observation by guardian",
 			ConstLabels: constLabels,
 		}, []string{"guardian_address", "type"})
 
-	heartbeatReceivedCount := promauto.NewCounterVec(
+	heartbeatReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "heartbeat_count_by_guardian",
 			Help:        "Total number of heartbeat by guardian",
 			ConstLabels: constLabels,
 		}, []string{"guardian_node", "type"})
 
-	governorConfigReceivedCount := promauto.NewCounterVec(
+	governorConfigReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "governor_config_count_by_guardian",
 			Help:        "Total number of governor config by guardian",
 			ConstLabels: constLabels,
 		}, []string{"guardian_node", "type"})
 
-	governorStatusReceivedCount := promauto.NewCounterVec(
+	governorStatusReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "governor_status_count_by_guardian",
 			Help:        "Total number of governor status by guardian",
 			ConstLabels: constLabels,
 		}, []string{"guardian_node", "type"})
-	maxSequenceCacheCount := promauto.NewCounterVec(
+	maxSequenceCacheCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "max_sequence_cache_count_by_chain",
 			Help:        "Total number of errors when updating max sequence cache",
 			ConstLabels: constLabels,
 		}, []string{"chain"})
-	txHashSearchCount := promauto.NewCounterVec(
+	txHashSearchCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "tx_hash_search_count_by_store",
 			Help:        "Total number of errors when updating max sequence cache",
 			ConstLabels: constLabels,
 		}, []string{"store", "action"})
-	consistenceLevelChainCount := promauto.NewCounterVec(
+	consistenceLevelChainCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "consistence_level_count_by_chain",
 			Help:        "Total number of consistence level by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "consistence_level"})
-	duplicateVaaByChainCount := promauto.NewCounterVec(
+	duplicateVaaByChainCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "duplicate_vaa_count_by_chain",
 			Help:        "Total number of duplicate vaa by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain"})
-	vaaProcessingDuration := promauto.NewHistogramVec(
+	vaaProcessingDuration := factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:        "vaa_processing_duration_seconds",
 			Help:        "Duration of all vaa processing by chain.",
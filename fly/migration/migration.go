@@ -197,6 +197,14 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in globalTransactions collection by status and timestamp/_id sort.
+	indexGlobalTransactionsByStatus := mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "originTx.timestamp", Value: -1}, {Key: "_id", Value: -1}}}
+	_, err = db.Collection("globalTransactions").Indexes().CreateOne(context.TODO(), indexGlobalTransactionsByStatus)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	// create index in parsedVaa collection by standardizedProperties toAddress.
 	indexParsedVaaByStandardizedPropertiesToAddress := mongo.IndexModel{
 		Keys: bson.D{{Key: "standardizedProperties.toAddress", Value: 1}}}
@@ -298,6 +306,40 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in holderBalances collection to find the top holders of a token.
+	indexHolderBalancesByTokenAndAmount := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "chainId", Value: 1},
+			{Key: "tokenAddress", Value: 1},
+			{Key: "amount", Value: -1},
+		}}
+	_, err = db.Collection(repository.HolderBalances).Indexes().CreateOne(context.TODO(), indexHolderBalancesByTokenAndAmount)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in governorNotionalHistory collection to find the notional of the chains by hour.
+	indexGovernorNotionalByChainAndHour := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "chainId", Value: 1},
+			{Key: "hour", Value: 1},
+		}}
+	_, err = db.Collection(repository.GovernorNotional).Indexes().CreateOne(context.TODO(), indexGovernorNotionalByChainAndHour)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
+	// create index in governorVaaEvents collection to find the enqueued vaas by time.
+	indexGovernorVaaEventsByEnqueuedAt := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "enqueuedAt", Value: 1},
+			{Key: "chainId", Value: 1},
+		}}
+	_, err = db.Collection(repository.GovernorVaaEvents).Indexes().CreateOne(context.TODO(), indexGovernorVaaEventsByEnqueuedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,55 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+)
+
+// forward sends the messages of a consumer of the common queue backends to a channel of fly queue messages.
+func forward[T, D any](ctx context.Context, in <-chan common_queue.Message[T], out chan Message[D], data func(*T) D) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-in:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- &commonConsumerMessage[T, D]{msg: msg, data: data(msg.Data())}:
+				}
+			}
+		}
+	}()
+}
+
+// commonConsumerMessage adapts a message of the common queue backends to a fly queue message.
+type commonConsumerMessage[T, D any] struct {
+	msg  common_queue.Message[T]
+	data D
+}
+
+func (m *commonConsumerMessage[T, D]) Data() D {
+	return m.data
+}
+
+func (m *commonConsumerMessage[T, D]) Done(_ context.Context) {
+	m.msg.Done()
+}
+
+func (m *commonConsumerMessage[T, D]) Failed() {
+	m.msg.Failed()
+}
+
+func (m *commonConsumerMessage[T, D]) IsExpired() bool {
+	return m.msg.IsExpired()
+}
+
+func (m *commonConsumerMessage[T, D]) SentTimestamp() *time.Time {
+	return m.msg.SentTimestamp()
+}
@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	gossipv1 "github.com/certusone/wormhole/node/pkg/proto/gossip/v1"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"go.uber.org/zap"
+)
+
+// ObservationQueue represents a observation queue in the kafka, nats, redis or memory queue backends.
+type ObservationQueue struct {
+	sender   common_queue.Sender
+	consumer *common_queue.Consumer[gossipv1.SignedObservation]
+	ch       chan Message[*gossipv1.SignedObservation]
+}
+
+// NewObservationQueue creates a observation queue in a queue backend.
+func NewObservationQueue(sender common_queue.Sender, receiver common_queue.Receiver, logger *zap.Logger) *ObservationQueue {
+	return &ObservationQueue{
+		sender:   sender,
+		consumer: common_queue.NewConsumer[gossipv1.SignedObservation](receiver, decodeObservation, logger),
+		ch:       make(chan Message[*gossipv1.SignedObservation], 10),
+	}
+}
+
+// Publish sends the message to the queue.
+func (q *ObservationQueue) Publish(ctx context.Context, o *gossipv1.SignedObservation) error {
+	body, err := json.Marshal(toObservation(o))
+	if err != nil {
+		return err
+	}
+	deduplicationID := createObservationDeduplicationID(o)
+	return q.sender.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         deduplicationID,
+		DeduplicationID: deduplicationID,
+		Body:            string(body),
+	})
+}
+
+// Consume returns the channel with the received messages from the queue.
+func (q *ObservationQueue) Consume(ctx context.Context) <-chan Message[*gossipv1.SignedObservation] {
+	forward(ctx, q.consumer.Consume(ctx), q.ch,
+		func(o *gossipv1.SignedObservation) *gossipv1.SignedObservation { return o })
+	return q.ch
+}
+
+// Close closes all consumer resources.
+func (q *ObservationQueue) Close() {
+	q.consumer.Close()
+	q.sender.Close()
+}
+
+func decodeObservation(body string) (*gossipv1.SignedObservation, error) {
+	var obs Observation
+	if err := json.Unmarshal([]byte(body), &obs); err != nil {
+		return nil, err
+	}
+	return fromObservation(&obs), nil
+}
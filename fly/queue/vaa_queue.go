@@ -0,0 +1,56 @@
+package queue
+
+import (
+	"context"
+	"encoding/base64"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// VAAQueue represents a VAA queue in the kafka, nats, redis or memory queue backends.
+type VAAQueue struct {
+	sender   common_queue.Sender
+	consumer *common_queue.Consumer[[]byte]
+	ch       chan Message[[]byte]
+}
+
+// NewVAAQueue creates a VAA queue in a queue backend.
+func NewVAAQueue(sender common_queue.Sender, receiver common_queue.Receiver, logger *zap.Logger) *VAAQueue {
+	return &VAAQueue{
+		sender:   sender,
+		consumer: common_queue.NewConsumer[[]byte](receiver, decodeVaa, logger),
+		ch:       make(chan Message[[]byte], 10),
+	}
+}
+
+// Publish sends the message to the queue.
+func (q *VAAQueue) Publish(ctx context.Context, v *sdk.VAA, data []byte) error {
+	deduplicationID := createVaaDeduplicationID(v)
+	return q.sender.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         deduplicationID,
+		DeduplicationID: deduplicationID,
+		Body:            base64.StdEncoding.EncodeToString(data),
+	})
+}
+
+// Consume returns the channel with the received messages from the queue.
+func (q *VAAQueue) Consume(ctx context.Context) <-chan Message[[]byte] {
+	forward(ctx, q.consumer.Consume(ctx), q.ch, func(data *[]byte) []byte { return *data })
+	return q.ch
+}
+
+// Close closes all consumer resources.
+func (q *VAAQueue) Close() {
+	q.consumer.Close()
+	q.sender.Close()
+}
+
+func decodeVaa(body string) (*[]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
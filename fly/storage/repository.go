@@ -48,6 +48,7 @@ type Repository struct {
 		vaaCounts      *mongo.Collection
 		duplicateVaas  *mongo.Collection
 	}
+	operationStatus *repository.OperationStatusRepository
 }
 
 // TODO wrap repository with a service that filters using redis
@@ -74,7 +75,8 @@ func NewRepository(alertService alert.AlertClient, metrics metrics.Metrics,
 		governorStatus: db.Collection("governorStatus"),
 		vaasPythnet:    db.Collection("vaasPythnet"),
 		vaaCounts:      db.Collection("vaaCounts"),
-		duplicateVaas:  db.Collection(repository.DuplicateVaas)}}
+		duplicateVaas:  db.Collection(repository.DuplicateVaas)},
+		repository.NewOperationStatusRepository(db, log)}
 }
 
 func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []byte) error {
@@ -134,6 +136,9 @@ func (s *Repository) UpsertVaa(ctx context.Context, v *vaa.VAA, serializedVaa []
 	if err == nil && s.isNewRecord(result) {
 		s.metrics.IncVaaInserted(v.EmitterChain)
 		s.updateVAACount(v.EmitterChain)
+		if v.EmitterChain != vaa.ChainIDPythNet {
+			s.updateOperationStatus(ctx, id, domain.OperationStatusVaaSigned, now)
+		}
 
 		// send signedvaa event to topic.
 		event, newErr := events.NewNotificationEvent[events.SignedVaa](
@@ -209,7 +214,7 @@ func (s *Repository) UpsertObservation(ctx context.Context, o *gossipv1.SignedOb
 		"$inc":         bson.D{{Key: "revision", Value: 1}},
 	}
 	opts := options.Update().SetUpsert(true)
-	_, err = s.collections.observations.UpdateByID(ctx, id, update, opts)
+	result, err := s.collections.observations.UpdateByID(ctx, id, update, opts)
 	if err != nil {
 		s.log.Error("Error inserting observation", zap.Error(err))
 		// send alert when exists an error saving observation.
@@ -223,6 +228,16 @@ func (s *Repository) UpsertObservation(ctx context.Context, o *gossipv1.SignedOb
 
 	s.metrics.IncObservationInserted(vaa.ChainID(chainID))
 
+	// the operation is created in the observed status by the first observation of the message,
+	// the observations of the other guardians do not change it.
+	if s.isNewRecord(result) {
+		if err := s.operationStatus.InsertObserved(ctx, o.MessageId, now); err != nil {
+			s.log.Warn("Error inserting observed operation",
+				zap.String("id", o.MessageId),
+				zap.Error(err))
+		}
+	}
+
 	if saveTxHash {
 
 		txHash, err := domain.EncodeTrxHashByChainID(vaa.ChainID(chainID), o.GetTxHash())
@@ -366,6 +381,17 @@ func (s *Repository) UpsertGovernorStatus(govS *gossipv1.SignedChainGovernorStat
 	return err3
 }
 
+// updateOperationStatus moves the operation forward in its lifecycle. Failures are only logged
+// because the status is also derived from the stored data when the operation is read.
+func (s *Repository) updateOperationStatus(ctx context.Context, id string, status domain.OperationStatus, at time.Time) {
+	if err := s.operationStatus.UpdateStatus(ctx, id, status, at); err != nil {
+		s.log.Warn("Error updating operation status",
+			zap.String("id", id),
+			zap.String("status", string(status)),
+			zap.Error(err))
+	}
+}
+
 func (s *Repository) updateVAACount(chainID vaa.ChainID) {
 	update := bson.D{{Key: "$inc", Value: bson.D{{Key: "count", Value: uint64(1)}}}}
 	opts := options.Update().SetUpsert(true)
@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -15,6 +15,7 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/stats"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
 	"github.com/wormhole-foundation/wormhole-explorer/common/configuration"
 
@@ -25,13 +26,18 @@ import (
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
-	filePrices "github.com/wormhole-foundation/wormhole-explorer/common/prices"
+	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	"github.com/wormhole-foundation/wormhole-explorer/common/prices"
+	commonProtocols "github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+	commonStats "github.com/wormhole-foundation/wormhole-explorer/common/stats"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/config"
+	jobsAlert "github.com/wormhole-foundation/wormhole-explorer/jobs/internal/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/coingecko"
-	apiPrices "github.com/wormhole-foundation/wormhole-explorer/jobs/internal/prices"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/holders"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/migration"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/notional"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/operations"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/report"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.uber.org/zap"
@@ -50,6 +56,12 @@ func main() {
 	}
 
 	logger := logger.New("wormhole-explorer-jobs", logger.WithLevel(cfg.LogLevel))
+
+	if cfg.Mode == config.ModeScheduler {
+		runScheduler(ctx, logger)
+		return
+	}
+
 	logger.Info("started job execution", zap.String("job_id", cfg.JobID))
 
 	var err error
@@ -106,6 +118,15 @@ func main() {
 	case jobs.JobIDNTTMedianStats:
 		job := initNTTMedianStatsJob(ctx, logger)
 		err = job.Run(ctx)
+	case jobs.JobIDStuckOperations:
+		job := initStuckOperationsJob(ctx, logger)
+		err = job.Run(ctx)
+	case jobs.JobIDHolderBalances:
+		job := initHolderBalancesJob(ctx, logger)
+		err = job.Run(ctx)
+	case jobs.JobIDMigrationOpStatus:
+		job := initMigrateOperationStatusJob(ctx, logger)
+		err = job.Run(ctx)
 	default:
 		logger.Error("Invalid job id", zap.String("job_id", cfg.JobID))
 	}
@@ -140,14 +161,16 @@ func initTransferReportJob(ctx context.Context, cfg *config.TransferReportConfig
 		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
 	}
 	var getPriceByTime report.GetPriceByTimeFn
+	var prefetchPrices report.PrefetchPricesFn
 	switch strings.ToLower(cfg.PricesType) {
 	case "file":
-		pricesCache := filePrices.NewCoinPricesCache(cfg.PricesUri)
+		pricesCache := prices.NewCoinPricesCache(cfg.PricesUri)
 		pricesCache.InitCache()
 		getPriceByTime = pricesCache.GetPriceByTime
 	case "api":
-		api := apiPrices.NewPricesApi(cfg.PricesUri, logger)
+		api := prices.NewBatchPricesClient(cfg.PricesUri, 50*time.Millisecond, int(cfg.PageSize), logger)
 		getPriceByTime = api.GetPriceByTime
+		prefetchPrices = api.Prefetch
 
 	default:
 		logger.Fatal("Invalid prices type", zap.String("prices_type", cfg.PricesType))
@@ -155,7 +178,7 @@ func initTransferReportJob(ctx context.Context, cfg *config.TransferReportConfig
 
 	// init token provider.
 	tokenProvider := domain.NewTokenProvider(cfg.P2pNetwork)
-	return report.NewTransferReportJob(db.Database, cfg.PageSize, getPriceByTime, cfg.OutputPath, tokenProvider, logger)
+	return report.NewTransferReportJob(db.Database, cfg.PageSize, getPriceByTime, prefetchPrices, cfg.OutputPath, tokenProvider, logger)
 }
 
 func initHistoricalPricesJob(ctx context.Context, cfg *config.HistoricalPricesConfiguration, logger *zap.Logger) *notional.HistoryNotionalJob {
@@ -191,67 +214,80 @@ func initMigrateSourceTxJob(ctx context.Context, cfg *config.MigrateSourceTxConf
 }
 
 func initProtocolStatsHourlyJob(ctx context.Context, logger *zap.Logger) *protocols.StatsJob {
-	cfgJob, errCfg := configuration.LoadFromEnv[config.ProtocolsStatsConfiguration](ctx)
-	if errCfg != nil {
-		log.Fatal("error creating config", errCfg)
-	}
-	errUnmarshal := json.Unmarshal([]byte(cfgJob.ProtocolsJson), &cfgJob.Protocols)
-	if errUnmarshal != nil {
-		log.Fatal("error unmarshalling protocols config", errUnmarshal)
-	}
+	to := time.Now().UTC().Truncate(1 * time.Hour)
+	from := to.Add(-1 * time.Hour)
+	return newProtocolStatsHourlyJobBuilder(ctx, logger)(from, to)
+}
+
+func initProtocolStatsDailyJob(ctx context.Context, logger *zap.Logger) *protocols.StatsJob {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	from := to.Add(-24 * time.Hour)
+	return newProtocolStatsDailyJobBuilder(ctx, logger)(from, to)
+}
+
+// protocolStatsJobBuilder creates a protocol stats job for the time range.
+type protocolStatsJobBuilder func(from, to time.Time) *protocols.StatsJob
+
+func newProtocolStatsHourlyJobBuilder(ctx context.Context, logger *zap.Logger) protocolStatsJobBuilder {
+	cfgJob := loadProtocolsStatsConfiguration(ctx)
 	dbClient := influxdb2.NewClient(cfgJob.InfluxUrl, cfgJob.InfluxToken)
 	dbWriter := dbClient.WriteAPIBlocking(cfgJob.InfluxOrganization, cfgJob.InfluxBucket30Days)
+	protocolRepos := newProtocolRepositories(cfgJob, logger)
+	return func(from, to time.Time) *protocols.StatsJob {
+		return protocols.NewStatsJob(dbWriter,
+			from,
+			to,
+			dbconsts.ProtocolsActivityMeasurementHourly,
+			dbconsts.ProtocolsStatsMeasurementHourly,
+			protocolRepos,
+			logger)
+	}
+}
 
-	protocolRepos := make([]repository.ProtocolRepository, 0, len(cfgJob.Protocols))
-	for _, c := range cfgJob.Protocols {
-		builder, ok := repository.ProtocolsRepositoryFactory[c.Name]
-		if !ok {
-			log.Fatal("error creating protocol stats client. Unknown protocol:", c.Name, errCfg)
-		}
-		cs := builder(c.Url, logger.With(zap.String("protocol", c.Name), zap.String("url", c.Url)))
-		protocolRepos = append(protocolRepos, cs)
+func newProtocolStatsDailyJobBuilder(ctx context.Context, logger *zap.Logger) protocolStatsJobBuilder {
+	cfgJob := loadProtocolsStatsConfiguration(ctx)
+	dbClient := influxdb2.NewClient(cfgJob.InfluxUrl, cfgJob.InfluxToken)
+	dbWriter := dbClient.WriteAPIBlocking(cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite)
+	protocolRepos := newProtocolRepositories(cfgJob, logger)
+	return func(from, to time.Time) *protocols.StatsJob {
+		return protocols.NewStatsJob(dbWriter,
+			from,
+			to,
+			dbconsts.ProtocolsActivityMeasurementDaily,
+			dbconsts.ProtocolsStatsMeasurementDaily,
+			protocolRepos,
+			logger)
 	}
-	to := time.Now().UTC().Truncate(1 * time.Hour)
-	from := to.Add(-1 * time.Hour)
-	return protocols.NewStatsJob(dbWriter,
-		from,
-		to,
-		dbconsts.ProtocolsActivityMeasurementHourly,
-		dbconsts.ProtocolsStatsMeasurementHourly,
-		protocolRepos,
-		logger)
 }
 
-func initProtocolStatsDailyJob(ctx context.Context, logger *zap.Logger) *protocols.StatsJob {
+func loadProtocolsStatsConfiguration(ctx context.Context) *config.ProtocolsStatsConfiguration {
 	cfgJob, errCfg := configuration.LoadFromEnv[config.ProtocolsStatsConfiguration](ctx)
 	if errCfg != nil {
 		log.Fatal("error creating config", errCfg)
 	}
-	errUnmarshal := json.Unmarshal([]byte(cfgJob.ProtocolsJson), &cfgJob.Protocols)
-	if errUnmarshal != nil {
-		log.Fatal("error unmarshalling protocols config", errUnmarshal)
+	declarations, errParse := commonProtocols.Parse(cfgJob.ProtocolsJson)
+	if errParse != nil {
+		log.Fatal("error parsing protocols config", errParse)
 	}
-	dbClient := influxdb2.NewClient(cfgJob.InfluxUrl, cfgJob.InfluxToken)
-	dbWriter := dbClient.WriteAPIBlocking(cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite)
+	cfgJob.Protocols = declarations
+	return cfgJob
+}
 
+func newProtocolRepositories(cfgJob *config.ProtocolsStatsConfiguration, logger *zap.Logger) []repository.ProtocolRepository {
 	protocolRepos := make([]repository.ProtocolRepository, 0, len(cfgJob.Protocols))
 	for _, c := range cfgJob.Protocols {
+		protocolLogger := logger.With(zap.String("protocol", c.Name), zap.String("url", c.Url))
+		if c.Stats != nil || c.Activity != nil {
+			protocolRepos = append(protocolRepos, repository.NewHttpJsonAdapter(c, c.Url, protocolLogger, &http.Client{}))
+			continue
+		}
 		builder, ok := repository.ProtocolsRepositoryFactory[c.Name]
 		if !ok {
-			log.Fatal("error creating protocol stats client. Unknown protocol:", c.Name, errCfg)
+			log.Fatal("error creating protocol stats client. Unknown protocol:", c.Name)
 		}
-		cs := builder(c.Url, logger.With(zap.String("protocol", c.Name), zap.String("url", c.Url)))
-		protocolRepos = append(protocolRepos, cs)
+		protocolRepos = append(protocolRepos, builder(c.Url, protocolLogger))
 	}
-	to := time.Now().UTC().Truncate(24 * time.Hour)
-	from := to.Add(-24 * time.Hour)
-	return protocols.NewStatsJob(dbWriter,
-		from,
-		to,
-		dbconsts.ProtocolsActivityMeasurementDaily,
-		dbconsts.ProtocolsStatsMeasurementDaily,
-		protocolRepos,
-		logger)
+	return protocolRepos
 }
 
 func initMigrateNativeTxHashJob(ctx context.Context, logger *zap.Logger) *migration.MigrateNativeTxHash {
@@ -266,6 +302,18 @@ func initMigrateNativeTxHashJob(ctx context.Context, logger *zap.Logger) *migrat
 	return migration.NewMigrationNativeTxHash(db.Database, cfgJob.PageSize, logger)
 }
 
+func initMigrateOperationStatusJob(ctx context.Context, logger *zap.Logger) *migration.MigrateOperationStatus {
+	cfgJob, errCfg := configuration.LoadFromEnv[config.MigrateOperationStatusConfiguration](ctx)
+	if errCfg != nil {
+		log.Fatal("error creating config", errCfg)
+	}
+	db, err := dbutil.Connect(ctx, logger, cfgJob.MongoURI, cfgJob.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	return migration.NewMigrationOperationStatus(db.Database, cfgJob.PageSize, logger)
+}
+
 func initNTTTopAddressStatsJob(ctx context.Context, logger *zap.Logger) *stats.NTTTopAddressJob {
 	cfgJob, errCfg := configuration.LoadFromEnv[config.NTTTopAddressStatsConfiguration](ctx)
 	if errCfg != nil {
@@ -284,7 +332,10 @@ func initNTTTopAddressStatsJob(ctx context.Context, logger *zap.Logger) *stats.N
 		log.Fatal("error creating cache client", err)
 	}
 
-	return stats.NewNTTTopAddressJob(influxClient, cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite, cache, logger)
+	// init ntt token finder.
+	tokenFinder := newNTTTokenFinder(ctx, cfgJob.MongoURI, cfgJob.MongoDatabase, domain.NewTokenProvider(cfgJob.P2pNetwork), logger)
+
+	return stats.NewNTTTopAddressJob(influxClient, cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite, cache, tokenFinder, logger)
 }
 
 func initNTTTopHolderStatsJob(ctx context.Context, logger *zap.Logger) *stats.NTTTopHolderJob {
@@ -311,7 +362,72 @@ func initNTTTopHolderStatsJob(ctx context.Context, logger *zap.Logger) *stats.NT
 	}
 	notionalCache.Init(ctx)
 
-	return stats.NewNTTTopHolderJob(resty.New(), cfgJob.ArkhamUrl, cfgJob.ArkhamApiKey, cfgJob.SolanaUrl, cache, tokenProvider, notionalCache, logger)
+	db, err := dbutil.Connect(ctx, logger, cfgJob.MongoURI, cfgJob.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+
+	// init ntt token finder.
+	tokenFinder := commonStats.NewNTTTokenFinder(db.Database, tokenProvider, logger)
+
+	// init the source of the top holders.
+	switch cfgJob.HoldersSource {
+	case config.HoldersSourceIndexer:
+		repository := commonStats.NewHolderBalanceRepository(db.Database, cache, tokenProvider, notionalCache, logger)
+		return stats.NewNTTTopHolderJob(repository, cache, tokenFinder, logger)
+	case config.HoldersSourceArkham:
+		repository := commonStats.NewHolderRepository(resty.New(), cfgJob.ArkhamUrl, cfgJob.ArkhamApiKey, cfgJob.SolanaUrl, cache, tokenProvider, notionalCache, logger)
+		return stats.NewNTTTopHolderJob(repository, cache, tokenFinder, logger)
+	default:
+		log.Fatal("invalid holders source", cfgJob.HoldersSource)
+		return nil
+	}
+}
+
+func initHolderBalancesJob(ctx context.Context, logger *zap.Logger) *holders.HolderBalanceJob {
+	cfgJob, errCfg := configuration.LoadFromEnv[config.HolderBalancesConfiguration](ctx)
+	if errCfg != nil {
+		log.Fatal("error creating config", errCfg)
+	}
+	providers, err := cfgJob.GetRpcProviders()
+	if err != nil {
+		log.Fatal("error parsing rpc providers", err)
+	}
+	startBlocks, err := cfgJob.GetStartBlocks()
+	if err != nil {
+		log.Fatal("error parsing start blocks", err)
+	}
+
+	db, err := dbutil.Connect(ctx, logger, cfgJob.MongoURI, cfgJob.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+
+	// init rpc pools.
+	pools := make(map[sdk.ChainID]*pool.Pool)
+	for _, p := range providers {
+		poolConfigs := make([]pool.Config, 0, len(p.Rpcs))
+		for _, rpc := range p.Rpcs {
+			poolConfigs = append(poolConfigs, pool.Config{
+				Id:                rpc.Url,
+				Description:       sdk.ChainID(p.ChainID).String(),
+				Priority:          rpc.Priority,
+				RequestsPerMinute: rpc.RequestPerMinute,
+			})
+		}
+		pools[sdk.ChainID(p.ChainID)] = pool.NewPool(poolConfigs)
+	}
+
+	tokenProvider := domain.NewTokenProvider(cfgJob.P2pNetwork)
+	// the notional cache and the cache are only used to compute the top holders.
+	store := commonStats.NewHolderBalanceRepository(db.Database, nil, tokenProvider, nil, logger)
+	tokenFinder := commonStats.NewNTTTokenFinder(db.Database, tokenProvider, logger)
+
+	return holders.NewHolderBalanceJob(resty.New(), pools, store, tokenFinder, tokenProvider, holders.Config{
+		StartBlocks:     startBlocks,
+		BatchSize:       cfgJob.BlockBatchSize,
+		MaxBlocksPerRun: cfgJob.MaxBlocksPerRun,
+	}, logger)
 }
 
 func initNTTMedianStatsJob(ctx context.Context, logger *zap.Logger) *stats.NTTMedian {
@@ -332,7 +448,53 @@ func initNTTMedianStatsJob(ctx context.Context, logger *zap.Logger) *stats.NTTMe
 		log.Fatal("error creating cache client", err)
 	}
 
-	return stats.NewNTTMedian(influxClient, cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite, cache, logger)
+	// init ntt token finder.
+	tokenFinder := newNTTTokenFinder(ctx, cfgJob.MongoURI, cfgJob.MongoDatabase, domain.NewTokenProvider(cfgJob.P2pNetwork), logger)
+
+	return stats.NewNTTMedian(influxClient, cfgJob.InfluxOrganization, cfgJob.InfluxBucketInfinite, cache, tokenFinder, logger)
+}
+
+// newNTTTokenFinder creates the finder of the tokens transferred with NTT, the stats jobs run for each of them.
+func newNTTTokenFinder(ctx context.Context, mongoURI, mongoDatabase string, tokenProvider *domain.TokenProvider, logger *zap.Logger) *commonStats.NTTTokenFinder {
+	db, err := dbutil.Connect(ctx, logger, mongoURI, mongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	return commonStats.NewNTTTokenFinder(db.Database, tokenProvider, logger)
+}
+
+func initStuckOperationsJob(ctx context.Context, logger *zap.Logger) *operations.StuckOperationsJob {
+	cfgJob, errCfg := configuration.LoadFromEnv[config.StuckOperationsConfiguration](ctx)
+	if errCfg != nil {
+		log.Fatal("error creating config", errCfg)
+	}
+	db, err := dbutil.Connect(ctx, logger, cfgJob.MongoURI, cfgJob.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	trackedChains, err := cfgJob.GetTrackedTargetChains()
+	if err != nil {
+		log.Fatal("error parsing tracked target chains", err)
+	}
+
+	// init alert client.
+	var alertClient alert.AlertClient = alert.NewDummyClient()
+	if cfgJob.AlertEnabled {
+		alertConfig := alert.AlertConfig{
+			Environment: cfgJob.Environment,
+			Enabled:     cfgJob.AlertEnabled,
+			ApiKey:      cfgJob.AlertApiKey,
+			Routing:     cfgJob.AlertRouting,
+		}
+		alertClient, err = alert.NewMultiplexer(alertConfig, jobsAlert.LoadAlerts, logger)
+		if err != nil {
+			log.Fatal("error creating alert client", err)
+		}
+	}
+
+	threshold := time.Duration(cfgJob.ThresholdMinutes) * time.Minute
+	lookback := time.Duration(cfgJob.LookbackHours) * time.Hour
+	return operations.NewStuckOperationsJob(db.Database, threshold, lookback, trackedChains, alertClient, logger)
 }
 
 func handleExit() {
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/wormhole-foundation/wormhole-explorer/common/configuration"
+	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/config"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/http/admin"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/http/infrastructure"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/scheduler"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// runScheduler runs the jobs following their cron schedules until the process receives a termination signal.
+func runScheduler(ctx context.Context, logger *zap.Logger) {
+	rootCtx, rootCtxCancel := context.WithCancel(ctx)
+
+	cfg, errCfg := configuration.LoadFromEnv[config.SchedulerConfiguration](rootCtx)
+	if errCfg != nil {
+		log.Fatal("error creating config", errCfg)
+	}
+	schedules, err := cfg.GetSchedules()
+	if err != nil {
+		log.Fatal("error parsing schedules", err)
+	}
+
+	db, err := dbutil.Connect(rootCtx, logger, cfg.MongoURI, cfg.MongoDatabase, false)
+	if err != nil {
+		logger.Fatal("Failed to connect MongoDB", zap.Error(err))
+	}
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.CacheURL})
+
+	s := scheduler.NewScheduler(
+		scheduler.NewRedisLocker(redisClient, cfg.CachePrefix),
+		scheduler.NewMongoRunRepository(db.Database),
+		metrics.NewPrometheusMetrics(cfg.Environment),
+		time.Duration(cfg.LeaseTTLSeconds)*time.Second,
+		logger)
+	for jobID, spec := range schedules {
+		factory, err := newJobFactory(rootCtx, jobID, logger)
+		if err != nil {
+			logger.Fatal("failed to create job", zap.String("job_id", jobID), zap.Error(err))
+		}
+		if err := s.Register(jobID, spec, factory); err != nil {
+			logger.Fatal("failed to register job", zap.String("job_id", jobID), zap.Error(err))
+		}
+	}
+
+	server := infrastructure.NewServer(logger, cfg.Port, admin.NewController(s, logger),
+		health.Mongo(db.Database), health.Redis(redisClient))
+	server.Start()
+	s.Start(rootCtx)
+
+	logger.Info("Started wormhole-explorer-jobs scheduler")
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	// graceful shutdown
+	logger.Info("Cancelling root context...")
+	rootCtxCancel()
+
+	logger.Info("Waiting for running jobs...")
+	s.Wait()
+
+	logger.Info("Closing Http server...")
+	server.Stop()
+
+	logger.Info("Closing MongoDB connection...")
+	db.DisconnectWithTimeout(10 * time.Second)
+
+	logger.Info("Terminated wormhole-explorer-jobs scheduler")
+}
+
+// newJobFactory creates the factory of the runs of a job. The dependencies of the job are created once,
+// and the jobs that process a time range accept the from and to parameters to run a backfill.
+func newJobFactory(ctx context.Context, jobID string, logger *zap.Logger) (scheduler.Factory, error) {
+	switch jobID {
+	case jobs.JobIDNotional:
+		nCfg, errCfg := configuration.LoadFromEnv[config.NotionalConfiguration](ctx)
+		if errCfg != nil {
+			return nil, errCfg
+		}
+		notionalJob := initNotionalJob(ctx, nCfg, logger)
+		return newStaticFactory(jobs.JobFunc(func(_ context.Context) error {
+			return notionalJob.Run()
+		})), nil
+
+	case jobs.JobIDTransferReport:
+		aCfg, errCfg := configuration.LoadFromEnv[config.TransferReportConfiguration](ctx)
+		if errCfg != nil {
+			return nil, errCfg
+		}
+		return newStaticFactory(initTransferReportJob(ctx, aCfg, logger)), nil
+
+	case jobs.JobIDHistoricalPrices:
+		hCfg, errCfg := configuration.LoadFromEnv[config.HistoricalPricesConfiguration](ctx)
+		if errCfg != nil {
+			return nil, errCfg
+		}
+		return newStaticFactory(initHistoricalPricesJob(ctx, hCfg, logger)), nil
+
+	case jobs.JobIDMigrationSourceTx:
+		mCfg, errCfg := configuration.LoadFromEnv[config.MigrateSourceTxConfiguration](ctx)
+		if errCfg != nil {
+			return nil, errCfg
+		}
+		return newStaticFactory(initMigrateSourceTxJob(ctx, mCfg, sdk.ChainID(mCfg.ChainID), logger)), nil
+
+	case jobs.JobIDProtocolsStatsHourly:
+		return newBackfillFactory(time.Hour, newProtocolStatsHourlyJobBuilder(ctx, logger)), nil
+	case jobs.JobIDProtocolsStatsDaily:
+		return newBackfillFactory(24*time.Hour, newProtocolStatsDailyJobBuilder(ctx, logger)), nil
+	case jobs.JobIDMigrationNativeTxHash:
+		return newStaticFactory(initMigrateNativeTxHashJob(ctx, logger)), nil
+	case jobs.JobIDNTTTopAddressStats:
+		return newStaticFactory(initNTTTopAddressStatsJob(ctx, logger)), nil
+	case jobs.JobIDNTTTopHolderStats:
+		return newStaticFactory(initNTTTopHolderStatsJob(ctx, logger)), nil
+	case jobs.JobIDNTTMedianStats:
+		return newStaticFactory(initNTTMedianStatsJob(ctx, logger)), nil
+	case jobs.JobIDStuckOperations:
+		return newStaticFactory(initStuckOperationsJob(ctx, logger)), nil
+	case jobs.JobIDHolderBalances:
+		return newStaticFactory(initHolderBalancesJob(ctx, logger)), nil
+	case jobs.JobIDMigrationOpStatus:
+		return newStaticFactory(initMigrateOperationStatusJob(ctx, logger)), nil
+	default:
+		return nil, fmt.Errorf("invalid job id %s", jobID)
+	}
+}
+
+// newStaticFactory returns a factory that runs the same job on every run, the job does not accept parameters.
+func newStaticFactory(job jobs.Job) scheduler.Factory {
+	return func(_ context.Context, params jobs.Params) (jobs.Job, error) {
+		if len(params) > 0 {
+			return nil, fmt.Errorf("the job does not accept parameters")
+		}
+		return job, nil
+	}
+}
+
+// newBackfillFactory returns a factory of the protocol stats jobs.
+// By default a run processes the last complete period. With the from and to parameters, a run processes every
+// period of the range, e.g. {"from":"2024-01-01T00:00:00Z","to":"2024-01-02T00:00:00Z"} runs the hourly job 24 times.
+func newBackfillFactory(period time.Duration, build protocolStatsJobBuilder) scheduler.Factory {
+	return func(_ context.Context, params jobs.Params) (jobs.Job, error) {
+		to, err := params.Time("to", time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		to = to.Truncate(period)
+		from, err := params.Time("from", to.Add(-period))
+		if err != nil {
+			return nil, err
+		}
+		from = from.Truncate(period)
+		if !from.Before(to) {
+			return nil, fmt.Errorf("invalid range: from %s must be before to %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+
+		return jobs.JobFunc(func(ctx context.Context) error {
+			for start := from; start.Before(to); start = start.Add(period) {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				if err := build(start, start.Add(period)).Run(ctx); err != nil {
+					return fmt.Errorf("failed to process range starting at %s: %w", start.Format(time.RFC3339), err)
+				}
+			}
+			return nil
+		}), nil
+	}
+}
@@ -2,12 +2,54 @@
 // It define a type [Configuration] that represent the aplication configuration
 package config
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// Run modes of the jobs binary.
+const (
+	// ModeJob runs the job selected by JOB_ID and exits.
+	ModeJob = "job"
+	// ModeScheduler runs the jobs of SCHEDULES_JSON following their cron schedules until it is stopped.
+	ModeScheduler = "scheduler"
+)
+
 // Configuration is the configuration for the job
 type Configuration struct {
-	JobID    string `env:"JOB_ID,required"`
+	Mode     string `env:"MODE,default=job"`
+	JobID    string `env:"JOB_ID"`
 	LogLevel string `env:"LOG_LEVEL,default=INFO"`
 }
 
+// SchedulerConfiguration is the configuration of the scheduler mode.
+type SchedulerConfiguration struct {
+	Environment     string `env:"ENVIRONMENT,required"`
+	Port            string `env:"PORT,default=8000"`
+	MongoURI        string `env:"MONGODB_URI,required"`
+	MongoDatabase   string `env:"MONGODB_DATABASE,required"`
+	CacheURL        string `env:"CACHE_URL,required"`
+	CachePrefix     string `env:"CACHE_PREFIX,required"`
+	LeaseTTLSeconds int64  `env:"LEASE_TTL_SECONDS,default=60"`
+	// SchedulesJson maps the job ids to their cron schedules, e.g. {"JOB_STUCK_OPERATIONS":"*/10 * * * *"}.
+	// The jobs with an empty schedule are only run on demand.
+	SchedulesJson string `env:"SCHEDULES_JSON,required"`
+}
+
+// GetSchedules returns the cron schedules of the jobs by job id.
+func (c *SchedulerConfiguration) GetSchedules() (map[string]string, error) {
+	var schedules map[string]string
+	if err := json.Unmarshal([]byte(c.SchedulesJson), &schedules); err != nil {
+		return nil, fmt.Errorf("invalid schedules json: %w", err)
+	}
+	return schedules, nil
+}
+
 type NotionalConfiguration struct {
 	Environment        string `env:"ENVIRONMENT,required"`
 	CoingeckoURL       string `env:"COINGECKO_URL,required"`
@@ -55,18 +97,13 @@ type MigrateSourceTxConfiguration struct {
 }
 
 type ProtocolsStatsConfiguration struct {
-	InfluxUrl            string     `env:"INFLUX_URL"`
-	InfluxToken          string     `env:"INFLUX_TOKEN"`
-	InfluxOrganization   string     `env:"INFLUX_ORGANIZATION"`
-	InfluxBucket30Days   string     `env:"INFLUX_BUCKET_30_DAYS"`
-	InfluxBucketInfinite string     `env:"INFLUX_BUCKET_INFINITE"`
-	ProtocolsJson        string     `env:"PROTOCOLS_JSON"`
-	Protocols            []Protocol `json:"PROTOCOLS"`
-}
-
-type Protocol struct {
-	Name string `json:"name"`
-	Url  string `json:"url"`
+	InfluxUrl            string                  `env:"INFLUX_URL"`
+	InfluxToken          string                  `env:"INFLUX_TOKEN"`
+	InfluxOrganization   string                  `env:"INFLUX_ORGANIZATION"`
+	InfluxBucket30Days   string                  `env:"INFLUX_BUCKET_30_DAYS"`
+	InfluxBucketInfinite string                  `env:"INFLUX_BUCKET_INFINITE"`
+	ProtocolsJson        string                  `env:"PROTOCOLS_JSON"`
+	Protocols            []protocols.Declaration `json:"PROTOCOLS"`
 }
 
 type ProtocolsActivityConfiguration struct {
@@ -79,7 +116,16 @@ type MigrateNativeTxHashConfiguration struct {
 	PageSize      int    `env:"PAGE_SIZE,default=100"`
 }
 
+type MigrateOperationStatusConfiguration struct {
+	MongoURI      string `env:"MONGODB_URI,required"`
+	MongoDatabase string `env:"MONGODB_DATABASE,required"`
+	PageSize      int    `env:"PAGE_SIZE,default=1000"`
+}
+
 type NTTTopAddressStatsConfiguration struct {
+	MongoURI             string `env:"MONGODB_URI,required"`
+	MongoDatabase        string `env:"MONGODB_DATABASE,required"`
+	P2pNetwork           string `env:"P2P_NETWORK,required"`
 	InfluxUrl            string `env:"INFLUX_URL,required"`
 	InfluxToken          string `env:"INFLUX_TOKEN,required"`
 	InfluxOrganization   string `env:"INFLUX_ORGANIZATION,required"`
@@ -88,17 +134,31 @@ type NTTTopAddressStatsConfiguration struct {
 	CachePrefix          string `env:"CACHE_PREFIX,required"`
 }
 
+// Sources of the NTT top holders.
+const (
+	// HoldersSourceIndexer computes the top holders from the balances indexed by the holder balances job.
+	HoldersSourceIndexer = "indexer"
+	// HoldersSourceArkham gets the top holders from the Arkham API and the Solana rpc.
+	HoldersSourceArkham = "arkham"
+)
+
 type NTTTopHolderStatsConfiguration struct {
+	MongoURI             string `env:"MONGODB_URI,required"`
+	MongoDatabase        string `env:"MONGODB_DATABASE,required"`
 	P2pNetwork           string `env:"P2P_NETWORK,required"`
-	ArkhamUrl            string `env:"ARKHAM_URL,required"`
-	ArkhamApiKey         string `env:"ARKHAM_API_KEY,required"`
-	SolanaUrl            string `env:"SOLANA_URL,required"`
+	HoldersSource        string `env:"HOLDERS_SOURCE,default=indexer"`
+	ArkhamUrl            string `env:"ARKHAM_URL"`
+	ArkhamApiKey         string `env:"ARKHAM_API_KEY"`
+	SolanaUrl            string `env:"SOLANA_URL"`
 	CacheUrl             string `env:"CACHE_URL,required"`
 	CachePrefix          string `env:"CACHE_PREFIX,required"`
 	CacheNotionalChannel string `env:"CACHE_NOTIONAL_CHANNEL,required"`
 }
 
 type NTTMedianStatsConfiguration struct {
+	MongoURI             string `env:"MONGODB_URI,required"`
+	MongoDatabase        string `env:"MONGODB_DATABASE,required"`
+	P2pNetwork           string `env:"P2P_NETWORK,required"`
 	InfluxUrl            string `env:"INFLUX_URL,required"`
 	InfluxToken          string `env:"INFLUX_TOKEN,required"`
 	InfluxOrganization   string `env:"INFLUX_ORGANIZATION,required"`
@@ -106,3 +166,87 @@ type NTTMedianStatsConfiguration struct {
 	CacheUrl             string `env:"CACHE_URL,required"`
 	CachePrefix          string `env:"CACHE_PREFIX,required"`
 }
+
+type StuckOperationsConfiguration struct {
+	Environment         string `env:"ENVIRONMENT,required"`
+	MongoURI            string `env:"MONGODB_URI,required"`
+	MongoDatabase       string `env:"MONGODB_DATABASE,required"`
+	ThresholdMinutes    int64  `env:"STUCK_THRESHOLD_MINUTES,default=30"`
+	LookbackHours       int64  `env:"STUCK_LOOKBACK_HOURS,default=72"`
+	TrackedTargetChains string `env:"TRACKED_TARGET_CHAINS"`
+	AlertEnabled        bool   `env:"ALERT_ENABLED,default=false"`
+	AlertApiKey         string `env:"ALERT_API_KEY"`
+	AlertRouting        string `env:"ALERT_ROUTING"`
+}
+
+// GetTrackedTargetChains returns the chains whose redeem transactions are tracked by tx-tracker.
+func (c *StuckOperationsConfiguration) GetTrackedTargetChains() ([]sdk.ChainID, error) {
+	var chains []sdk.ChainID
+	for _, s := range strings.Split(c.TrackedTargetChains, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		chainID, err := strconv.ParseUint(s, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tracked target chain %s: %w", s, err)
+		}
+		chains = append(chains, sdk.ChainID(chainID))
+	}
+	return chains, nil
+}
+
+// HolderBalancesConfiguration is the configuration of the holder balances job.
+type HolderBalancesConfiguration struct {
+	MongoURI      string `env:"MONGODB_URI,required"`
+	MongoDatabase string `env:"MONGODB_DATABASE,required"`
+	P2pNetwork    string `env:"P2P_NETWORK,required"`
+	// RpcProvidersJson are the rpcs of the indexed chains, with the format of the tx-tracker rpc providers,
+	// e.g. [{"chainId":2,"rpcs":[{"url":"https://rpc.ankr.com/eth","requestPerMinute":60,"priority":1}]}].
+	RpcProvidersJson string `env:"RPC_PROVIDERS_JSON,required"`
+	// StartBlocksJson are the blocks where the indexing of the EVM chains starts, e.g. {"2":19000000}.
+	StartBlocksJson string `env:"START_BLOCKS_JSON"`
+	BlockBatchSize  uint64 `env:"BLOCK_BATCH_SIZE,default=2000"`
+	MaxBlocksPerRun uint64 `env:"MAX_BLOCKS_PER_RUN,default=1000000"`
+}
+
+// RpcProvider are the rpcs of a chain.
+type RpcProvider struct {
+	ChainID uint16        `json:"chainId"`
+	Rpcs    []RpcSettings `json:"rpcs"`
+}
+
+// RpcSettings is a rpc of a chain.
+type RpcSettings struct {
+	Url              string `json:"url"`
+	RequestPerMinute uint16 `json:"requestPerMinute"`
+	Priority         uint8  `json:"priority"`
+}
+
+// GetRpcProviders returns the rpcs of the indexed chains.
+func (c *HolderBalancesConfiguration) GetRpcProviders() ([]RpcProvider, error) {
+	var providers []RpcProvider
+	if err := json.Unmarshal([]byte(c.RpcProvidersJson), &providers); err != nil {
+		return nil, fmt.Errorf("invalid rpc providers json: %w", err)
+	}
+	return providers, nil
+}
+
+// GetStartBlocks returns the blocks where the indexing of the EVM chains starts, by chain.
+func (c *HolderBalancesConfiguration) GetStartBlocks() (map[sdk.ChainID]uint64, error) {
+	startBlocks := make(map[sdk.ChainID]uint64)
+	if c.StartBlocksJson == "" {
+		return startBlocks, nil
+	}
+	var blocks map[string]uint64
+	if err := json.Unmarshal([]byte(c.StartBlocksJson), &blocks); err != nil {
+		return nil, fmt.Errorf("invalid start blocks json: %w", err)
+	}
+	for chain, block := range blocks {
+		chainID, err := strconv.ParseUint(chain, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start block chain %s: %w", chain, err)
+		}
+		startBlocks[sdk.ChainID(chainID)] = block
+	}
+	return startBlocks, nil
+}
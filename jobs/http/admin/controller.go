@@ -0,0 +1,85 @@
+package admin
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/scheduler"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRunsLimit = 20
+	maxRunsLimit     = 100
+)
+
+// Controller definition.
+type Controller struct {
+	scheduler *scheduler.Scheduler
+	logger    *zap.Logger
+}
+
+// NewController creates a Controller instance.
+func NewController(scheduler *scheduler.Scheduler, logger *zap.Logger) *Controller {
+	return &Controller{scheduler: scheduler, logger: logger}
+}
+
+// TriggerRequest is the request to run a job on demand.
+type TriggerRequest struct {
+	Params jobs.Params `json:"params"`
+}
+
+// FindJobs handler for the endpoint GET /api/jobs.
+func (c *Controller) FindJobs(ctx *fiber.Ctx) error {
+	return ctx.JSON(c.scheduler.Jobs())
+}
+
+// FindRuns handler for the endpoint GET /api/jobs/:id/runs.
+func (c *Controller) FindRuns(ctx *fiber.Ctx) error {
+	limit := int64(defaultRunsLimit)
+	if v := ctx.Query("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 || n > maxRunsLimit {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid limit")
+		}
+		limit = n
+	}
+
+	runs, err := c.scheduler.Runs(ctx.Context(), ctx.Params("id"), limit)
+	if errors.Is(err, scheduler.ErrJobNotFound) {
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		c.logger.Error("failed to find job runs", zap.String("job_id", ctx.Params("id")), zap.Error(err))
+		return err
+	}
+	return ctx.JSON(runs)
+}
+
+// TriggerRun handler for the endpoint POST /api/jobs/:id/runs.
+// The parameters of the request are passed to the job, e.g. the from and to dates of a backfill.
+func (c *Controller) TriggerRun(ctx *fiber.Ctx) error {
+	var payload TriggerRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&payload); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+		}
+	}
+
+	jobID := ctx.Params("id")
+	run, err := c.scheduler.Trigger(ctx.Context(), jobID, payload.Params)
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, scheduler.ErrJobRunning):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case err != nil:
+		c.logger.Error("failed to trigger job", zap.String("job_id", jobID), zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("job triggered from endpoint", zap.String("job_id", jobID), zap.String("run_id", run.ID))
+	return ctx.Status(fiber.StatusAccepted).JSON(run)
+}
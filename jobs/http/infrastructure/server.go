@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	health "github.com/wormhole-foundation/wormhole-explorer/common/health"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/http/admin"
+	"go.uber.org/zap"
+)
+
+type Server struct {
+	app    *fiber.App
+	port   string
+	logger *zap.Logger
+}
+
+// NewServer creates the admin server of the scheduler.
+// It is meant to be reachable only inside the cluster, the endpoints are not authenticated.
+func NewServer(logger *zap.Logger, port string, adminController *admin.Controller, checks ...health.Check) *Server {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
+	ctrl := health.NewController(checks, logger)
+	api := app.Group("/api")
+	api.Get("/health", ctrl.HealthCheck)
+	api.Get("/ready", ctrl.ReadyCheck)
+
+	api.Get("/jobs", adminController.FindJobs)
+	api.Get("/jobs/:id/runs", adminController.FindRuns)
+	api.Post("/jobs/:id/runs", adminController.TriggerRun)
+
+	return &Server{
+		app:    app,
+		port:   port,
+		logger: logger,
+	}
+}
+
+// Start listen serves HTTP requests from addr.
+func (s *Server) Start() {
+	addr := ":" + s.port
+	s.logger.Info("Listening on " + addr)
+	go func() {
+		s.app.Listen(addr)
+	}()
+}
+
+// Stop gracefull server.
+func (s *Server) Stop() {
+	_ = s.app.Shutdown()
+}
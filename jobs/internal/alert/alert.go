@@ -0,0 +1,77 @@
+package alert
+
+import (
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+)
+
+// alert key constants definition.
+const (
+	StuckOperationGovernorEnqueued    = "STUCK_OPERATION_GOVERNOR_ENQUEUED"
+	StuckOperationTargetNotTracked    = "STUCK_OPERATION_TARGET_NOT_TRACKED"
+	StuckOperationRelayerNotDelivered = "STUCK_OPERATION_RELAYER_NOT_DELIVERED"
+	StuckOperationRedeemFailed        = "STUCK_OPERATION_REDEEM_FAILED"
+)
+
+// StuckOperationAlertKey returns the alert key of a stuck cause.
+func StuckOperationAlertKey(cause domain.StuckCause) string {
+	switch cause {
+	case domain.StuckCauseGovernorEnqueued:
+		return StuckOperationGovernorEnqueued
+	case domain.StuckCauseTargetNotTracked:
+		return StuckOperationTargetNotTracked
+	case domain.StuckCauseRedeemFailed:
+		return StuckOperationRedeemFailed
+	default:
+		return StuckOperationRelayerNotDelivered
+	}
+}
+
+func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
+	alerts := make(map[string]alert.Alert)
+
+	// Alert operations held by the governor.
+	alerts[StuckOperationGovernorEnqueued] = alert.Alert{
+		Alias:       StuckOperationGovernorEnqueued,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Operations enqueued by the governor"),
+		Description: "Operations were enqueued by the governor and the VAA is not signed yet.",
+		Actions:     []string{"check the governor queue of the source chain", "check stuckOperations collection"},
+		Tags:        []string{cfg.Environment, "jobs", "stuckOperations", "governor"},
+		Entity:      "jobs",
+		Priority:    alert.INFORMATIONAL,
+	}
+	// Alert operations redeemed on a chain that is not tracked.
+	alerts[StuckOperationTargetNotTracked] = alert.Alert{
+		Alias:       StuckOperationTargetNotTracked,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Operations without redeem on a not tracked chain"),
+		Description: "Operations were signed but the redeem transactions of the target chain are not tracked by tx-tracker.",
+		Actions:     []string{"check if the target chain should be tracked by tx-tracker", "check stuckOperations collection"},
+		Tags:        []string{cfg.Environment, "jobs", "stuckOperations", "tx-tracker"},
+		Entity:      "jobs",
+		Priority:    alert.LOW,
+	}
+	// Alert operations not delivered by the relayers.
+	alerts[StuckOperationRelayerNotDelivered] = alert.Alert{
+		Alias:       StuckOperationRelayerNotDelivered,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Operations not delivered to the target chain"),
+		Description: "Operations were signed but no redeem was recorded on the target chain.",
+		Actions:     []string{"check the relayers of the target chain", "check tx-tracker is processing the target chain", "check stuckOperations collection"},
+		Tags:        []string{cfg.Environment, "jobs", "stuckOperations", "relayer"},
+		Entity:      "jobs",
+		Priority:    alert.MODERATE,
+	}
+	// Alert operations with a failed redeem.
+	alerts[StuckOperationRedeemFailed] = alert.Alert{
+		Alias:       StuckOperationRedeemFailed,
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Operations with failed redeem"),
+		Description: "The redeem transaction of the operations failed on the target chain.",
+		Actions:     []string{"check the redeem transaction on the target chain", "check stuckOperations collection"},
+		Tags:        []string{cfg.Environment, "jobs", "stuckOperations", "redeem"},
+		Entity:      "jobs",
+		Priority:    alert.HIGH,
+	}
+
+	return alerts
+}
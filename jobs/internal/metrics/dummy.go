@@ -0,0 +1,26 @@
+package metrics
+
+import "time"
+
+// DummyMetrics is a dummy implementation of Metric interface.
+type DummyMetrics struct{}
+
+// NewDummyMetrics returns a new instance of DummyMetrics.
+func NewDummyMetrics() *DummyMetrics {
+	return &DummyMetrics{}
+}
+
+// IncJobSucceeded is a dummy implementation of IncJobSucceeded.
+func (d *DummyMetrics) IncJobSucceeded(jobID string) {}
+
+// IncJobFailed is a dummy implementation of IncJobFailed.
+func (d *DummyMetrics) IncJobFailed(jobID string) {}
+
+// IncJobSkipped is a dummy implementation of IncJobSkipped.
+func (d *DummyMetrics) IncJobSkipped(jobID string) {}
+
+// ObserveJobDuration is a dummy implementation of ObserveJobDuration.
+func (d *DummyMetrics) ObserveJobDuration(jobID string, duration time.Duration) {}
+
+// AddJobItemsProcessed is a dummy implementation of AddJobItemsProcessed.
+func (d *DummyMetrics) AddJobItemsProcessed(jobID string, count int64) {}
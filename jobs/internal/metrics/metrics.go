@@ -0,0 +1,14 @@
+package metrics
+
+import "time"
+
+const serviceName = "wormscan-jobs"
+
+// Metrics is the interface of the metrics of the job executions.
+type Metrics interface {
+	IncJobSucceeded(jobID string)
+	IncJobFailed(jobID string)
+	IncJobSkipped(jobID string)
+	ObserveJobDuration(jobID string, duration time.Duration)
+	AddJobItemsProcessed(jobID string, count int64)
+}
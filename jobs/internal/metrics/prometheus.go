@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// PrometheusMetrics is a Prometheus implementation of Metric interface.
+type PrometheusMetrics struct {
+	jobRuns           *prometheus.CounterVec
+	jobDuration       *prometheus.HistogramVec
+	jobItemsProcessed *prometheus.CounterVec
+	jobLastSuccess    *prometheus.GaugeVec
+}
+
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
+func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+	constLabels := map[string]string{
+		"environment": environment,
+		"service":     serviceName,
+	}
+	jobRuns := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "job_runs_total",
+			Help:        "Total number of job runs by job and status",
+			ConstLabels: constLabels,
+		}, []string{"job", "status"})
+	jobDuration := promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "job_duration_seconds",
+			Help:        "Duration of the job runs",
+			ConstLabels: constLabels,
+			Buckets:     []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+		}, []string{"job"})
+	jobItemsProcessed := promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "job_items_processed_total",
+			Help:        "Total number of items processed by job",
+			ConstLabels: constLabels,
+		}, []string{"job"})
+	jobLastSuccess := promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "job_last_success_timestamp_seconds",
+			Help:        "Unix time of the last successful run by job",
+			ConstLabels: constLabels,
+		}, []string{"job"})
+	return &PrometheusMetrics{
+		jobRuns:           jobRuns,
+		jobDuration:       jobDuration,
+		jobItemsProcessed: jobItemsProcessed,
+		jobLastSuccess:    jobLastSuccess,
+	}
+}
+
+// IncJobSucceeded increments the number of successful runs of a job.
+func (m *PrometheusMetrics) IncJobSucceeded(jobID string) {
+	m.jobRuns.WithLabelValues(jobID, "succeeded").Inc()
+	m.jobLastSuccess.WithLabelValues(jobID).SetToCurrentTime()
+}
+
+// IncJobFailed increments the number of failed runs of a job.
+func (m *PrometheusMetrics) IncJobFailed(jobID string) {
+	m.jobRuns.WithLabelValues(jobID, "failed").Inc()
+}
+
+// IncJobSkipped increments the number of runs of a job skipped because another replica holds the lease.
+func (m *PrometheusMetrics) IncJobSkipped(jobID string) {
+	m.jobRuns.WithLabelValues(jobID, "skipped").Inc()
+}
+
+// ObserveJobDuration adds the duration of a job run.
+func (m *PrometheusMetrics) ObserveJobDuration(jobID string, duration time.Duration) {
+	m.jobDuration.WithLabelValues(jobID).Observe(duration.Seconds())
+}
+
+// AddJobItemsProcessed adds the number of items processed by a job run.
+func (m *PrometheusMetrics) AddJobItemsProcessed(jobID string, count int64) {
+	m.jobItemsProcessed.WithLabelValues(jobID).Add(float64(count))
+}
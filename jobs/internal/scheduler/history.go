@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Run statuses.
+const (
+	RunStatusRunning   = "running"
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+)
+
+// Run triggers.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// Run is the record of a job execution.
+type Run struct {
+	ID             string      `bson:"_id" json:"id"`
+	JobID          string      `bson:"jobId" json:"jobId"`
+	Trigger        string      `bson:"trigger" json:"trigger"`
+	Params         jobs.Params `bson:"params,omitempty" json:"params,omitempty"`
+	Status         string      `bson:"status" json:"status"`
+	Host           string      `bson:"host" json:"host"`
+	StartedAt      time.Time   `bson:"startedAt" json:"startedAt"`
+	EndedAt        *time.Time  `bson:"endedAt,omitempty" json:"endedAt,omitempty"`
+	Error          string      `bson:"error,omitempty" json:"error,omitempty"`
+	ItemsProcessed int64       `bson:"itemsProcessed" json:"itemsProcessed"`
+}
+
+// RunRepository stores the history of the job executions.
+type RunRepository interface {
+	// Start stores a run that started.
+	Start(ctx context.Context, run *Run) error
+	// Finish updates the status, end time, error and items processed of a run.
+	Finish(ctx context.Context, run *Run) error
+	// FindByJobID returns the last runs of a job, the most recent first.
+	FindByJobID(ctx context.Context, jobID string, limit int64) ([]Run, error)
+}
+
+// MongoRunRepository is a RunRepository backed by the `jobRuns` collection.
+type MongoRunRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRunRepository creates a new MongoRunRepository.
+func NewMongoRunRepository(db *mongo.Database) *MongoRunRepository {
+	return &MongoRunRepository{collection: db.Collection(repository.JobRuns)}
+}
+
+// Start stores a run that started.
+func (r *MongoRunRepository) Start(ctx context.Context, run *Run) error {
+	if _, err := r.collection.InsertOne(ctx, run); err != nil {
+		return fmt.Errorf("failed to insert job run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// Finish updates the status, end time, error and items processed of a run.
+func (r *MongoRunRepository) Finish(ctx context.Context, run *Run) error {
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "status", Value: run.Status},
+		{Key: "endedAt", Value: run.EndedAt},
+		{Key: "error", Value: run.Error},
+		{Key: "itemsProcessed", Value: run.ItemsProcessed},
+	}}}
+	if _, err := r.collection.UpdateByID(ctx, run.ID, update); err != nil {
+		return fmt.Errorf("failed to update job run %s: %w", run.ID, err)
+	}
+	return nil
+}
+
+// FindByJobID returns the last runs of a job, the most recent first.
+func (r *MongoRunRepository) FindByJobID(ctx context.Context, jobID string, limit int64) ([]Run, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "startedAt", Value: -1}}).
+		SetLimit(limit)
+	cur, err := r.collection.Find(ctx, bson.D{{Key: "jobId", Value: jobID}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find job runs of %s: %w", jobID, err)
+	}
+	runs := []Run{}
+	if err := cur.All(ctx, &runs); err != nil {
+		return nil, fmt.Errorf("failed to decode job runs of %s: %w", jobID, err)
+	}
+	return runs, nil
+}
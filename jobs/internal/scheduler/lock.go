@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// ErrLeaseLost is returned when a lease expired or was taken by another replica.
+var ErrLeaseLost = errors.New("lease lost")
+
+// Locker acquires the leases that ensure that only one replica runs a job at the same time.
+type Locker interface {
+	// Acquire acquires the lease of the key for the ttl. It returns false if the lease is held by another owner.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (Lease, bool, error)
+}
+
+// Lease is a lease acquired by a Locker.
+type Lease interface {
+	// Renew extends the lease for the ttl, it returns ErrLeaseLost if the lease is no longer held.
+	Renew(ctx context.Context, ttl time.Duration) error
+	// Release releases the lease.
+	Release(ctx context.Context) error
+}
+
+// renewScript extends the expiration of the key only if it is held by the owner.
+var renewScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript deletes the key only if it is held by the owner.
+var releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+// RedisLocker is a Locker backed by redis keys with expiration.
+type RedisLocker struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisLocker creates a new RedisLocker.
+func NewRedisLocker(client *redis.Client, prefix string) *RedisLocker {
+	return &RedisLocker{client: client, prefix: prefix}
+}
+
+// Acquire acquires the lease of the key for the ttl.
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (Lease, bool, error) {
+	lease := &redisLease{
+		client: l.client,
+		key:    fmt.Sprintf("%s:jobs:lock:%s", l.prefix, key),
+		owner:  uuid.NewString(),
+	}
+	ok, err := l.client.SetNX(ctx, lease.key, lease.owner, ttl).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire lease %s: %w", lease.key, err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	return lease, true, nil
+}
+
+type redisLease struct {
+	client *redis.Client
+	key    string
+	owner  string
+}
+
+// Renew extends the lease for the ttl.
+func (l *redisLease) Renew(ctx context.Context, ttl time.Duration) error {
+	n, err := renewScript.Run(ctx, l.client, []string{l.key}, l.owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to renew lease %s: %w", l.key, err)
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Release releases the lease.
+func (l *redisLease) Release(ctx context.Context) error {
+	if err := releaseScript.Run(ctx, l.client, []string{l.key}, l.owner).Err(); err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", l.key, err)
+	}
+	return nil
+}
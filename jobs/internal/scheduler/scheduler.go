@@ -0,0 +1,340 @@
+// Package scheduler runs the jobs periodically following their cron schedules.
+//
+// Each run holds a lease of the job, so when several replicas of the scheduler are deployed only one of them
+// runs a given job at the same time. Besides, each scheduled time of a job is claimed by a single replica,
+// so a replica whose clock is behind does not run again a tick already run by another replica.
+//
+// The runs are recorded in a RunRepository with their status, duration, error and number of items processed.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrJobNotFound is returned when the job is not registered in the scheduler.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when a job is triggered while it is running.
+	ErrJobRunning = errors.New("job is running")
+	// ErrNotStarted is returned when a job is triggered before the scheduler is started.
+	ErrNotStarted = errors.New("scheduler is not started")
+)
+
+// Factory creates the job of a run with the parameters of the run, e.g. the time range of a backfill.
+type Factory func(ctx context.Context, params jobs.Params) (jobs.Job, error)
+
+// JobInfo describes a job registered in the scheduler.
+type JobInfo struct {
+	ID       string     `json:"id"`
+	Schedule string     `json:"schedule,omitempty"`
+	NextRun  *time.Time `json:"nextRun,omitempty"`
+}
+
+type entry struct {
+	id       string
+	schedule *Schedule
+	factory  Factory
+}
+
+// Scheduler runs the registered jobs following their schedules, and on demand.
+type Scheduler struct {
+	entries  map[string]*entry
+	locker   Locker
+	runs     RunRepository
+	metrics  metrics.Metrics
+	leaseTTL time.Duration
+	host     string
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	ctx     context.Context
+	nextRun map[string]time.Time
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler.
+// The leases of the jobs are acquired for the leaseTTL and renewed while the jobs are running.
+func NewScheduler(locker Locker, runs RunRepository, metrics metrics.Metrics, leaseTTL time.Duration, logger *zap.Logger) *Scheduler {
+	host, _ := os.Hostname()
+	return &Scheduler{
+		entries:  make(map[string]*entry),
+		locker:   locker,
+		runs:     runs,
+		metrics:  metrics,
+		leaseTTL: leaseTTL,
+		host:     host,
+		logger:   logger.With(zap.String("module", "Scheduler")),
+		nextRun:  make(map[string]time.Time),
+	}
+}
+
+// Register registers a job with a cron schedule. Jobs with an empty schedule are only run on demand.
+func (s *Scheduler) Register(jobID string, spec string, factory Factory) error {
+	if _, ok := s.entries[jobID]; ok {
+		return fmt.Errorf("job %s is already registered", jobID)
+	}
+	e := &entry{id: jobID, factory: factory}
+	if spec != "" {
+		schedule, err := ParseSchedule(spec)
+		if err != nil {
+			return fmt.Errorf("invalid schedule of job %s: %w", jobID, err)
+		}
+		e.schedule = schedule
+	}
+	s.entries[jobID] = e
+	return nil
+}
+
+// Start starts running the scheduled jobs until the context is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.schedule == nil {
+			continue
+		}
+		s.wg.Add(1)
+		go s.loop(ctx, e)
+	}
+	s.logger.Info("scheduler started", zap.Int("jobs", len(s.entries)))
+}
+
+// Wait waits until the scheduler loops and the running jobs finish, after the context is cancelled.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// Jobs returns the registered jobs sorted by id.
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		info := JobInfo{ID: e.id}
+		if e.schedule != nil {
+			info.Schedule = e.schedule.String()
+		}
+		if next, ok := s.nextRun[e.id]; ok {
+			info.NextRun = &next
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// Runs returns the last runs of a job, the most recent first.
+func (s *Scheduler) Runs(ctx context.Context, jobID string, limit int64) ([]Run, error) {
+	if _, ok := s.entries[jobID]; !ok {
+		return nil, ErrJobNotFound
+	}
+	return s.runs.FindByJobID(ctx, jobID, limit)
+}
+
+// Trigger starts a run of a job with the parameters, without waiting for it to finish.
+// It returns ErrJobRunning if the job is running in this or another replica.
+func (s *Scheduler) Trigger(ctx context.Context, jobID string, params jobs.Params) (Run, error) {
+	e, ok := s.entries[jobID]
+	if !ok {
+		return Run{}, ErrJobNotFound
+	}
+
+	s.mu.Lock()
+	runCtx := s.ctx
+	s.mu.Unlock()
+	if runCtx == nil {
+		return Run{}, ErrNotStarted
+	}
+
+	lease, ok, err := s.locker.Acquire(ctx, e.id, s.leaseTTL)
+	if err != nil {
+		return Run{}, err
+	}
+	if !ok {
+		return Run{}, ErrJobRunning
+	}
+
+	run := s.newRun(e.id, TriggerManual, params)
+	started := *run
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(runCtx, e, lease, run)
+	}()
+	return started, nil
+}
+
+// loop runs a job at the times of its schedule.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+	for {
+		next := e.schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Warn("job schedule has no next run", zap.String("job_id", e.id), zap.String("schedule", e.schedule.String()))
+			return
+		}
+		s.mu.Lock()
+		s.nextRun[e.id] = next
+		s.mu.Unlock()
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !s.claimTick(ctx, e, next) {
+			continue
+		}
+
+		lease, ok, err := s.locker.Acquire(ctx, e.id, s.leaseTTL)
+		if err != nil {
+			s.logger.Error("failed to acquire job lease", zap.String("job_id", e.id), zap.Error(err))
+			continue
+		}
+		if !ok {
+			s.logger.Info("job is running in another replica, skipping run", zap.String("job_id", e.id))
+			s.metrics.IncJobSkipped(e.id)
+			continue
+		}
+		s.execute(ctx, e, lease, s.newRun(e.id, TriggerSchedule, nil))
+	}
+}
+
+// claimTick claims the run of a job at a scheduled time. The claim is never released, it expires after
+// the following scheduled time, so the tick is run once even if the replicas clocks are not in sync.
+func (s *Scheduler) claimTick(ctx context.Context, e *entry, tick time.Time) bool {
+	ttl := s.leaseTTL
+	if following := e.schedule.Next(tick); !following.IsZero() && following.Sub(tick) > ttl {
+		ttl = following.Sub(tick)
+	}
+
+	key := e.id + ":tick:" + strconv.FormatInt(tick.Unix(), 10)
+	_, ok, err := s.locker.Acquire(ctx, key, ttl)
+	if err != nil {
+		s.logger.Error("failed to claim job tick", zap.String("job_id", e.id), zap.Time("tick", tick), zap.Error(err))
+		return false
+	}
+	if !ok {
+		s.logger.Info("job tick was run by another replica, skipping run", zap.String("job_id", e.id), zap.Time("tick", tick))
+		s.metrics.IncJobSkipped(e.id)
+		return false
+	}
+	return true
+}
+
+func (s *Scheduler) newRun(jobID, trigger string, params jobs.Params) *Run {
+	return &Run{
+		ID:        uuid.NewString(),
+		JobID:     jobID,
+		Trigger:   trigger,
+		Params:    params,
+		Status:    RunStatusRunning,
+		Host:      s.host,
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// execute runs a job holding its lease and records the run.
+func (s *Scheduler) execute(ctx context.Context, e *entry, lease Lease, run *Run) {
+	logger := s.logger.With(zap.String("job_id", e.id), zap.String("run_id", run.ID), zap.String("trigger", run.Trigger))
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := lease.Release(releaseCtx); err != nil {
+			logger.Error("failed to release job lease", zap.Error(err))
+		}
+	}()
+
+	// the history is informative, the job runs even if it can not be recorded
+	if err := s.runs.Start(ctx, run); err != nil {
+		logger.Error("failed to record job run", zap.Error(err))
+	}
+	logger.Info("started job execution", zap.Any("params", run.Params))
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	go s.keepAlive(runCtx, lease, cancel, logger)
+	runCtx, counter := jobs.WithItemsCounter(runCtx)
+
+	err := runJob(runCtx, e.factory, run.Params)
+	if err != nil && errors.Is(context.Cause(runCtx), ErrLeaseLost) {
+		err = fmt.Errorf("%w: %w", ErrLeaseLost, err)
+	}
+	cancel(nil)
+
+	endedAt := time.Now().UTC()
+	run.EndedAt = &endedAt
+	run.ItemsProcessed = counter.Load()
+	if err != nil {
+		run.Status = RunStatusFailed
+		run.Error = err.Error()
+		s.metrics.IncJobFailed(e.id)
+		logger.Error("failed job execution", zap.Error(err))
+	} else {
+		run.Status = RunStatusSucceeded
+		s.metrics.IncJobSucceeded(e.id)
+		logger.Info("finish job execution successfully", zap.Int64("items_processed", run.ItemsProcessed))
+	}
+	s.metrics.ObserveJobDuration(e.id, endedAt.Sub(run.StartedAt))
+	s.metrics.AddJobItemsProcessed(e.id, run.ItemsProcessed)
+
+	// the run is recorded even if the scheduler is stopping
+	finishCtx, cancelFinish := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFinish()
+	if err := s.runs.Finish(finishCtx, run); err != nil {
+		logger.Error("failed to record job run", zap.Error(err))
+	}
+}
+
+// keepAlive renews the lease of a running job, and cancels the job if the lease is lost.
+func (s *Scheduler) keepAlive(ctx context.Context, lease Lease, cancel context.CancelCauseFunc, logger *zap.Logger) {
+	ticker := time.NewTicker(s.leaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := lease.Renew(ctx, s.leaseTTL)
+			if errors.Is(err, ErrLeaseLost) {
+				logger.Error("job lease lost, cancelling job execution")
+				cancel(ErrLeaseLost)
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				logger.Warn("failed to renew job lease", zap.Error(err))
+			}
+		}
+	}
+}
+
+// runJob creates and runs a job, the panics of the job are returned as errors so they do not stop the scheduler.
+func runJob(ctx context.Context, factory Factory, params jobs.Params) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	job, err := factory(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return job.Run(ctx)
+}
@@ -0,0 +1,231 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/internal/metrics"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.uber.org/zap"
+)
+
+// memoryLocker is a Locker that holds the leases in memory.
+type memoryLocker struct {
+	mu     sync.Mutex
+	leases map[string]bool
+}
+
+func (l *memoryLocker) Acquire(_ context.Context, key string, _ time.Duration) (Lease, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.leases[key] {
+		return nil, false, nil
+	}
+	l.leases[key] = true
+	return &memoryLease{locker: l, key: key}, true, nil
+}
+
+type memoryLease struct {
+	locker *memoryLocker
+	key    string
+}
+
+func (l *memoryLease) Renew(context.Context, time.Duration) error { return nil }
+
+func (l *memoryLease) Release(context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+	delete(l.locker.leases, l.key)
+	return nil
+}
+
+// memoryRunRepository is a RunRepository that stores the runs in memory.
+type memoryRunRepository struct {
+	mu       sync.Mutex
+	runs     map[string]Run
+	finished chan Run
+}
+
+func (r *memoryRunRepository) Start(_ context.Context, run *Run) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runs[run.ID] = *run
+	return nil
+}
+
+func (r *memoryRunRepository) Finish(_ context.Context, run *Run) error {
+	r.mu.Lock()
+	r.runs[run.ID] = *run
+	r.mu.Unlock()
+	r.finished <- *run
+	return nil
+}
+
+func (r *memoryRunRepository) FindByJobID(_ context.Context, jobID string, _ int64) ([]Run, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var runs []Run
+	for _, run := range r.runs {
+		if run.JobID == jobID {
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+func newTestScheduler() (*Scheduler, *memoryLocker, *memoryRunRepository) {
+	locker := &memoryLocker{leases: make(map[string]bool)}
+	runs := &memoryRunRepository{runs: make(map[string]Run), finished: make(chan Run, 10)}
+	s := NewScheduler(locker, runs, metrics.NewDummyMetrics(), time.Minute, zap.NewNop())
+	return s, locker, runs
+}
+
+func waitRun(t *testing.T, runs *memoryRunRepository) Run {
+	select {
+	case run := <-runs.finished:
+		return run
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for the job run")
+		return Run{}
+	}
+}
+
+func TestScheduler_Trigger(t *testing.T) {
+	s, _, runs := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var received jobs.Params
+	err := s.Register("JOB_TEST", "", func(_ context.Context, params jobs.Params) (jobs.Job, error) {
+		received = params
+		return jobs.JobFunc(func(ctx context.Context) error {
+			jobs.AddItemsProcessed(ctx, 3)
+			return nil
+		}), nil
+	})
+	assert.NoError(t, err)
+	assert.Error(t, s.Register("JOB_TEST", "", nil))
+	assert.Error(t, s.Register("JOB_INVALID", "* * *", nil))
+
+	_, err = s.Trigger(ctx, "JOB_TEST", nil)
+	assert.ErrorIs(t, err, ErrNotStarted)
+
+	s.Start(ctx)
+	_, err = s.Trigger(ctx, "JOB_UNKNOWN", nil)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	params := jobs.Params{"from": "2024-01-01T00:00:00Z"}
+	started, err := s.Trigger(ctx, "JOB_TEST", params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, RunStatusRunning, started.Status)
+		assert.Equal(t, TriggerManual, started.Trigger)
+	}
+
+	run := waitRun(t, runs)
+	assert.Equal(t, started.ID, run.ID)
+	assert.Equal(t, RunStatusSucceeded, run.Status)
+	assert.Equal(t, int64(3), run.ItemsProcessed)
+	assert.NotNil(t, run.EndedAt)
+	assert.Equal(t, params, received)
+
+	history, err := s.Runs(ctx, "JOB_TEST", 10)
+	assert.NoError(t, err)
+	assert.Len(t, history, 1)
+
+	jobsInfo := s.Jobs()
+	if assert.Len(t, jobsInfo, 1) {
+		assert.Equal(t, "JOB_TEST", jobsInfo[0].ID)
+		assert.Nil(t, jobsInfo[0].NextRun)
+	}
+}
+
+func TestScheduler_TriggerRunningJob(t *testing.T) {
+	s, locker, runs := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	_ = s.Register("JOB_TEST", "", func(context.Context, jobs.Params) (jobs.Job, error) {
+		return jobs.JobFunc(func(ctx context.Context) error {
+			<-release
+			return errors.New("failed")
+		}), nil
+	})
+	s.Start(ctx)
+
+	_, err := s.Trigger(ctx, "JOB_TEST", nil)
+	assert.NoError(t, err)
+	_, err = s.Trigger(ctx, "JOB_TEST", nil)
+	assert.ErrorIs(t, err, ErrJobRunning)
+
+	close(release)
+	run := waitRun(t, runs)
+	assert.Equal(t, RunStatusFailed, run.Status)
+	assert.Equal(t, "failed", run.Error)
+
+	// the lease is released when the run finishes
+	s.Wait()
+	locker.mu.Lock()
+	assert.Empty(t, locker.leases)
+	locker.mu.Unlock()
+}
+
+func TestScheduler_Panic(t *testing.T) {
+	s, _, runs := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_ = s.Register("JOB_TEST", "", func(context.Context, jobs.Params) (jobs.Job, error) {
+		return jobs.JobFunc(func(ctx context.Context) error {
+			panic("unexpected")
+		}), nil
+	})
+	s.Start(ctx)
+
+	_, err := s.Trigger(ctx, "JOB_TEST", nil)
+	assert.NoError(t, err)
+	run := waitRun(t, runs)
+	assert.Equal(t, RunStatusFailed, run.Status)
+	assert.Contains(t, run.Error, "unexpected")
+}
+
+func TestScheduler_NextRun(t *testing.T) {
+	s, _, _ := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_ = s.Register("JOB_TEST", "0 0 1 1 *", func(context.Context, jobs.Params) (jobs.Job, error) {
+		return jobs.JobFunc(func(context.Context) error { return nil }), nil
+	})
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		info := s.Jobs()
+		return len(info) == 1 && info[0].NextRun != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the loops finish when the context is cancelled
+	cancel()
+	s.Wait()
+}
+
+func TestScheduler_ClaimTick(t *testing.T) {
+	s, locker, _ := newTestScheduler()
+	_ = s.Register("JOB_TEST", "0 * * * *", func(context.Context, jobs.Params) (jobs.Job, error) {
+		return jobs.JobFunc(func(context.Context) error { return nil }), nil
+	})
+	e := s.entries["JOB_TEST"]
+	tick := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// a tick is run by a single replica, the claim is kept after the run
+	assert.True(t, s.claimTick(context.Background(), e, tick))
+	assert.False(t, s.claimTick(context.Background(), e, tick))
+	assert.True(t, s.claimTick(context.Background(), e, tick.Add(time.Hour)))
+
+	locker.mu.Lock()
+	assert.Len(t, locker.leases, 2)
+	locker.mu.Unlock()
+}
@@ -0,0 +1,217 @@
+package holders
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// transferTopic is the topic of the ERC-20 Transfer(address,address,uint256) event.
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+type ethBlock struct {
+	Number string `json:"number"`
+}
+
+type ethLog struct {
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	BlockNumber string   `json:"blockNumber"`
+}
+
+// evmIndexer indexes the balances of an ERC-20 token from its Transfer events.
+// The balances are updated incrementally, from the checkpoint to the last finalized block.
+type evmIndexer struct {
+	chainID    sdk.ChainID
+	rpc        *rpcClient
+	store      balanceStore
+	startBlock uint64
+	batchSize  uint64
+	maxBlocks  uint64
+	logger     *zap.Logger
+}
+
+// index applies the Transfer events since the checkpoint of the token, and returns the number of balances updated.
+func (i *evmIndexer) index(ctx context.Context, token deployment) (int, error) {
+	var head ethBlock
+	if err := i.rpc.call(ctx, &head, "eth_getBlockByNumber", "finalized", false); err != nil {
+		return 0, fmt.Errorf("failed to get finalized block: %w", err)
+	}
+	finalized, err := parseQuantity(head.Number)
+	if err != nil {
+		return 0, fmt.Errorf("invalid finalized block %s: %w", head.Number, err)
+	}
+
+	from := i.startBlock
+	checkpoint, err := i.store.GetCheckpoint(ctx, i.chainID, token.tokenAddress)
+	if err != nil {
+		return 0, err
+	}
+	if checkpoint != nil {
+		from = checkpoint.Block + 1
+	}
+	to := finalized
+	if i.maxBlocks > 0 && from <= to && to-from+1 > i.maxBlocks {
+		to = from + i.maxBlocks - 1
+	}
+
+	var updated int
+	batchSize := i.batchSize
+	for start := from; start <= to; {
+		end := min(start+batchSize-1, to)
+		var logs []ethLog
+		filter := map[string]any{
+			"fromBlock": toQuantity(start),
+			"toBlock":   toQuantity(end),
+			"address":   token.nativeAddress,
+			"topics":    []string{transferTopic},
+		}
+		if err := i.rpc.call(ctx, &logs, "eth_getLogs", filter); err != nil {
+			// the rpcs limit the size of the responses, retry with a smaller range
+			if batchSize > 1 {
+				batchSize /= 2
+				i.logger.Debug("failed to get logs, reducing the block range", zap.Uint64("batchSize", batchSize), zap.Error(err))
+				continue
+			}
+			return updated, fmt.Errorf("failed to get logs from block %d to %d: %w", start, end, err)
+		}
+
+		n, err := i.apply(ctx, token, logs, end)
+		if err != nil {
+			return updated, err
+		}
+		if err := i.store.SetCheckpoint(ctx, i.chainID, token.tokenAddress, end); err != nil {
+			return updated, err
+		}
+		updated += n
+		start = end + 1
+		batchSize = i.batchSize
+	}
+
+	i.logger.Info("indexed evm holder balances",
+		zap.String("token", token.nativeAddress),
+		zap.Uint64("from", from),
+		zap.Uint64("to", to),
+		zap.Uint64("finalized", finalized),
+		zap.Int("updated", updated))
+	return updated, nil
+}
+
+// apply adds the transfers of a block range to the balances. The balances store the last block applied, and only
+// the transfers after it are added, so applying a range again after a failure does not count the transfers twice,
+// even if the range was extended since.
+func (i *evmIndexer) apply(ctx context.Context, token deployment, logs []ethLog, block uint64) (int, error) {
+	type transfer struct {
+		block uint64
+		value *big.Int
+	}
+	transfers := make(map[string][]transfer)
+	add := func(address string, block uint64, value *big.Int) {
+		if address == zeroAddress {
+			return
+		}
+		transfers[address] = append(transfers[address], transfer{block: block, value: value})
+	}
+	for _, l := range logs {
+		// the ERC-721 Transfer event has the same topic and the value indexed
+		if len(l.Topics) != 3 {
+			continue
+		}
+		value, ok := new(big.Int).SetString(strings.TrimPrefix(l.Data, "0x"), 16)
+		if !ok {
+			return 0, fmt.Errorf("invalid transfer value %s at block %s", l.Data, l.BlockNumber)
+		}
+		logBlock, err := parseQuantity(l.BlockNumber)
+		if err != nil {
+			return 0, fmt.Errorf("invalid transfer block %s: %w", l.BlockNumber, err)
+		}
+		add(topicToAddress(l.Topics[1]), logBlock, new(big.Int).Neg(value))
+		add(topicToAddress(l.Topics[2]), logBlock, value)
+	}
+	if len(transfers) == 0 {
+		return 0, nil
+	}
+
+	addresses := make([]string, 0, len(transfers))
+	for address := range transfers {
+		addresses = append(addresses, address)
+	}
+	current, err := i.store.FindBalances(ctx, i.chainID, token.tokenAddress, addresses)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	balances := make([]stats.HolderBalance, 0, len(transfers))
+	for address, ts := range transfers {
+		balance := new(big.Int)
+		var applied uint64
+		b, found := current[address]
+		if found {
+			if _, ok := balance.SetString(b.Balance, 10); !ok {
+				return 0, fmt.Errorf("invalid balance %s of %s", b.Balance, b.ID)
+			}
+			applied = b.Block
+		}
+		changed := false
+		for _, t := range ts {
+			if found && t.block <= applied {
+				continue
+			}
+			balance.Add(balance, t.value)
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		balances = append(balances, newHolderBalance(i.chainID, token, address, balance, block, now))
+	}
+	if err := i.store.SaveBalances(ctx, balances); err != nil {
+		return 0, err
+	}
+	return len(balances), nil
+}
+
+func newHolderBalance(chainID sdk.ChainID, token deployment, address string, balance *big.Int, block uint64, now time.Time) stats.HolderBalance {
+	amount, _ := decimal.NewFromBigInt(balance, -int32(token.decimals)).Float64()
+	return stats.HolderBalance{
+		ID:           stats.HolderBalanceID(chainID, token.tokenAddress, address),
+		ChainID:      chainID,
+		TokenAddress: token.tokenAddress,
+		Address:      address,
+		Balance:      balance.String(),
+		Amount:       amount,
+		Block:        block,
+		UpdatedAt:    now,
+	}
+}
+
+// topicToAddress returns the address of an indexed address topic, the last 20 bytes of the 32 bytes topic.
+func topicToAddress(topic string) string {
+	topic = strings.ToLower(strings.TrimPrefix(topic, "0x"))
+	if len(topic) > 40 {
+		topic = topic[len(topic)-40:]
+	}
+	return "0x" + topic
+}
+
+func parseQuantity(s string) (uint64, error) {
+	n, ok := new(big.Int).SetString(strings.TrimPrefix(s, "0x"), 16)
+	if !ok || !n.IsUint64() {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return n.Uint64(), nil
+}
+
+func toQuantity(n uint64) string {
+	return fmt.Sprintf("0x%x", n)
+}
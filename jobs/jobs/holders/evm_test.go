@@ -0,0 +1,124 @@
+package holders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var evmToken = deployment{
+	tokenAddress:  "000000000000000000000000b0ffa8000886e57f86dd5264b9582b2ad87b2b91",
+	nativeAddress: "0xb0ffa8000886e57f86dd5264b9582b2ad87b2b91",
+	decimals:      2,
+}
+
+const (
+	holderA = "0x1111111111111111111111111111111111111111"
+	holderB = "0x2222222222222222222222222222222222222222"
+	holderC = "0x3333333333333333333333333333333333333333"
+)
+
+func TestEvmIndexer_Index(t *testing.T) {
+	store := newMemoryStore()
+	i := &evmIndexer{
+		chainID:    sdk.ChainIDEthereum,
+		rpc:        newFixtureRpcClient(t, "evm_rpc.json"),
+		store:      store,
+		startBlock: 90,
+		batchSize:  11,
+		logger:     zap.NewNop(),
+	}
+
+	updated, err := i.index(context.Background(), evmToken)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, updated)
+
+	assert.Equal(t, "700", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderA))
+	assert.Equal(t, "200", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderB))
+	assert.Equal(t, "100", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderC))
+	assert.Equal(t, float64(7), store.balances[holderID(holderA)].Amount)
+	// the mints and burns do not create a balance for the zero address
+	assert.Len(t, store.balances, 3)
+
+	checkpoint, err := store.GetCheckpoint(context.Background(), sdk.ChainIDEthereum, evmToken.tokenAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), checkpoint.Block)
+}
+
+func TestEvmIndexer_Index_UpToDate(t *testing.T) {
+	store := newMemoryStore()
+	_ = store.SetCheckpoint(context.Background(), sdk.ChainIDEthereum, evmToken.tokenAddress, 100)
+	i := &evmIndexer{
+		chainID:   sdk.ChainIDEthereum,
+		rpc:       newFixtureRpcClient(t, "evm_rpc.json"),
+		store:     store,
+		batchSize: 11,
+		logger:    zap.NewNop(),
+	}
+
+	updated, err := i.index(context.Background(), evmToken)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated)
+	assert.Empty(t, store.balances)
+}
+
+func TestEvmIndexer_Apply_Idempotent(t *testing.T) {
+	store := newMemoryStore()
+	i := &evmIndexer{chainID: sdk.ChainIDEthereum, store: store, logger: zap.NewNop()}
+	logs := []ethLog{
+		{Topics: []string{transferTopic, addressTopic(zeroAddress), addressTopic(holderA)}, Data: "0x3e8", BlockNumber: "0x9"},
+		{Topics: []string{transferTopic, addressTopic(holderA), addressTopic(holderB)}, Data: "0x12c", BlockNumber: "0xa"},
+	}
+
+	n, err := i.apply(context.Background(), evmToken, logs, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	// a range applied again after a failure is skipped
+	n, err = i.apply(context.Background(), evmToken, logs, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, "700", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderA))
+	assert.Equal(t, "300", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderB))
+
+	// a range extended after a failure only adds the transfers after the blocks applied
+	extended := append(logs, ethLog{Topics: []string{transferTopic, addressTopic(holderA), addressTopic(holderB)}, Data: "0x12c", BlockNumber: "0xb"})
+	n, err = i.apply(context.Background(), evmToken, extended, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "400", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderA))
+	assert.Equal(t, "600", store.balance(sdk.ChainIDEthereum, evmToken.tokenAddress, holderB))
+}
+
+func TestNewDeployment(t *testing.T) {
+	tokens := []struct {
+		chainID sdk.ChainID
+		address string
+		native  string
+	}{
+		{sdk.ChainIDSolana, "6927fdc01ea906f96d7137874cdd7adad00ca35764619310e54196c781d84d5b", "85VBFQZC9TZkfaptBWjvUw7YbZjy52A6mjtPGjstQAmQ"},
+		{sdk.ChainIDEthereum, "000000000000000000000000B0FFA8000886E57F86DD5264B9582B2AD87B2B91", "0xb0ffa8000886e57f86dd5264b9582b2ad87b2b91"},
+	}
+	for _, tt := range tokens {
+		d, err := newDeployment(&domain.TokenMetadata{TokenChain: tt.chainID, TokenAddress: tt.address, Decimals: 18})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.native, d.nativeAddress)
+		assert.Equal(t, tt.address, d.tokenAddress)
+	}
+
+	_, err := newDeployment(&domain.TokenMetadata{TokenChain: sdk.ChainIDEthereum, TokenAddress: "b0ffa8000886e57f86dd5264b9582b2ad87b2b91"})
+	assert.Error(t, err)
+}
+
+func holderID(address string) string {
+	return stats.HolderBalanceID(sdk.ChainIDEthereum, evmToken.tokenAddress, address)
+}
+
+func addressTopic(address string) string {
+	return "0x000000000000000000000000" + address[2:]
+}
@@ -0,0 +1,125 @@
+package holders
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// rpcFixture is a recorded json-rpc call.
+type rpcFixture struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *rpcError       `json:"error,omitempty"`
+}
+
+// newFixtureRpcClient returns a rpc client whose pool has a single rpc that replies the calls recorded in the
+// testdata file. The calls that are not recorded fail the test.
+func newFixtureRpcClient(t *testing.T, file string) *rpcClient {
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixtures []rpcFixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, f := range fixtures {
+			if f.Method == req.Method && jsonEqual(f.Params, req.Params) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": f.Result, "error": f.Error})
+				return
+			}
+		}
+		t.Errorf("unexpected rpc call %s %s", req.Method, string(req.Params))
+		http.Error(w, "unexpected rpc call", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	p := pool.NewPool([]pool.Config{{Id: server.URL, Description: "fixture", RequestsPerMinute: 60000}})
+	return newRpcClient(resty.New(), p, zap.NewNop())
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb any
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// memoryStore is an in-memory balanceStore.
+type memoryStore struct {
+	balances    map[string]stats.HolderBalance
+	checkpoints map[string]uint64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{balances: map[string]stats.HolderBalance{}, checkpoints: map[string]uint64{}}
+}
+
+func (s *memoryStore) GetCheckpoint(_ context.Context, chainID sdk.ChainID, tokenAddress string) (*stats.HolderCheckpoint, error) {
+	block, ok := s.checkpoints[chainID.String()+tokenAddress]
+	if !ok {
+		return nil, nil
+	}
+	return &stats.HolderCheckpoint{ChainID: chainID, TokenAddress: tokenAddress, Block: block}, nil
+}
+
+func (s *memoryStore) SetCheckpoint(_ context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error {
+	s.checkpoints[chainID.String()+tokenAddress] = block
+	return nil
+}
+
+func (s *memoryStore) FindBalances(_ context.Context, chainID sdk.ChainID, tokenAddress string, addresses []string) (map[string]stats.HolderBalance, error) {
+	result := make(map[string]stats.HolderBalance)
+	for _, address := range addresses {
+		if b, ok := s.balances[stats.HolderBalanceID(chainID, tokenAddress, address)]; ok {
+			result[address] = b
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryStore) SaveBalances(_ context.Context, balances []stats.HolderBalance) error {
+	for _, b := range balances {
+		s.balances[b.ID] = b
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteBalancesBefore(_ context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error {
+	for id, b := range s.balances {
+		if b.ChainID == chainID && b.TokenAddress == tokenAddress && b.Block < block {
+			delete(s.balances, id)
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) balance(chainID sdk.ChainID, tokenAddress, address string) string {
+	return s.balances[stats.HolderBalanceID(chainID, tokenAddress, address)].Balance
+}
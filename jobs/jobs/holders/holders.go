@@ -0,0 +1,190 @@
+// Package holders indexes the balances of the holders of the NTT tokens from the chains.
+//
+// The EVM balances are derived from the Transfer events of the tokens, indexed incrementally with a checkpoint
+// per token deployment. The Solana balances are snapshots of the token accounts of the mints. The balances are
+// stored in the `holderBalances` collection, and the NTT top holder job computes the top holders from them.
+package holders
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/mr-tron/base58"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// evmChains are the EVM chains whose balances are indexed from the Transfer events.
+var evmChains = map[sdk.ChainID]bool{
+	sdk.ChainIDEthereum:  true,
+	sdk.ChainIDBSC:       true,
+	sdk.ChainIDPolygon:   true,
+	sdk.ChainIDAvalanche: true,
+	sdk.ChainIDFantom:    true,
+	sdk.ChainIDCelo:      true,
+	sdk.ChainIDMoonbeam:  true,
+	sdk.ChainIDArbitrum:  true,
+	sdk.ChainIDOptimism:  true,
+	sdk.ChainIDBase:      true,
+	sdk.ChainIDScroll:    true,
+	sdk.ChainIDMantle:    true,
+	sdk.ChainIDBlast:     true,
+	sdk.ChainIDXLayer:    true,
+}
+
+// balanceStore stores the indexed balances and checkpoints, it is implemented by stats.HolderBalanceRepository.
+type balanceStore interface {
+	GetCheckpoint(ctx context.Context, chainID sdk.ChainID, tokenAddress string) (*stats.HolderCheckpoint, error)
+	SetCheckpoint(ctx context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error
+	FindBalances(ctx context.Context, chainID sdk.ChainID, tokenAddress string, addresses []string) (map[string]stats.HolderBalance, error)
+	SaveBalances(ctx context.Context, balances []stats.HolderBalance) error
+	DeleteBalancesBefore(ctx context.Context, chainID sdk.ChainID, tokenAddress string, block uint64) error
+}
+
+// symbolFinder finds the symbols of the NTT tokens, it is implemented by stats.NTTTokenFinder.
+type symbolFinder interface {
+	FindSymbols(ctx context.Context) ([]string, error)
+}
+
+// indexer indexes the balances of a token deployment on a chain.
+type indexer interface {
+	index(ctx context.Context, token deployment) (int, error)
+}
+
+// deployment is the deployment of a token on a chain.
+type deployment struct {
+	// tokenAddress is the wormhole address of the token, as in the token registry.
+	tokenAddress string
+	// nativeAddress is the address of the token on the chain.
+	nativeAddress string
+	decimals      int64
+}
+
+// Config is the configuration of the indexing of the EVM chains.
+type Config struct {
+	// StartBlocks are the blocks where the indexing of the tokens starts, by chain.
+	// The indexing starts at the genesis block for the chains without a start block.
+	StartBlocks map[sdk.ChainID]uint64
+	// BatchSize is the number of blocks of the eth_getLogs requests.
+	BatchSize uint64
+	// MaxBlocksPerRun is the maximum number of blocks indexed by run for a token, 0 means no limit.
+	// The backfill of a token takes several runs.
+	MaxBlocksPerRun uint64
+}
+
+// HolderBalanceJob indexes the balances of the holders of the NTT tokens on the chains with a rpc pool.
+type HolderBalanceJob struct {
+	tokenFinder   symbolFinder
+	tokenProvider *domain.TokenProvider
+	indexers      map[sdk.ChainID]indexer
+	logger        *zap.Logger
+}
+
+// NewHolderBalanceJob creates a new holder balance job.
+func NewHolderBalanceJob(client *resty.Client,
+	pools map[sdk.ChainID]*pool.Pool,
+	store balanceStore,
+	tokenFinder symbolFinder,
+	tokenProvider *domain.TokenProvider,
+	cfg Config,
+	logger *zap.Logger) *HolderBalanceJob {
+
+	indexers := make(map[sdk.ChainID]indexer)
+	for chainID, p := range pools {
+		chainLogger := logger.With(zap.String("chain", chainID.String()))
+		rpc := newRpcClient(client, p, chainLogger)
+		switch {
+		case chainID == sdk.ChainIDSolana:
+			indexers[chainID] = &solanaIndexer{rpc: rpc, store: store, logger: chainLogger}
+		case evmChains[chainID]:
+			indexers[chainID] = &evmIndexer{
+				chainID:    chainID,
+				rpc:        rpc,
+				store:      store,
+				startBlock: cfg.StartBlocks[chainID],
+				batchSize:  max(cfg.BatchSize, 1),
+				maxBlocks:  cfg.MaxBlocksPerRun,
+				logger:     chainLogger,
+			}
+		default:
+			logger.Warn("holder balances are not supported for chain", zap.String("chain", chainID.String()))
+		}
+	}
+
+	return &HolderBalanceJob{
+		tokenFinder:   tokenFinder,
+		tokenProvider: tokenProvider,
+		indexers:      indexers,
+		logger:        logger,
+	}
+}
+
+// Run indexes the balances of every deployment of the NTT tokens on the supported chains.
+func (j *HolderBalanceJob) Run(ctx context.Context) error {
+	symbols, err := j.tokenFinder.FindSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	var indexed, failed int
+	for _, symbol := range symbols {
+		tokens, ok := j.tokenProvider.GetTokensBySymbol(symbol)
+		if !ok {
+			continue
+		}
+		for _, t := range tokens {
+			idx, ok := j.indexers[t.TokenChain]
+			if !ok {
+				continue
+			}
+			logger := j.logger.With(zap.String("symbol", symbol), zap.String("chain", t.TokenChain.String()), zap.String("tokenAddress", t.TokenAddress))
+
+			token, err := newDeployment(t)
+			if err != nil {
+				logger.Error("invalid token address", zap.Error(err))
+				failed++
+				continue
+			}
+			n, err := idx.index(ctx, token)
+			jobs.AddItemsProcessed(ctx, int64(n))
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logger.Error("failed to index holder balances", zap.Error(err))
+				failed++
+				continue
+			}
+			indexed++
+		}
+	}
+
+	j.logger.Info("indexed holder balances", zap.Int("symbols", len(symbols)), zap.Int("deployments", indexed), zap.Int("failed", failed))
+	if failed > 0 && indexed == 0 {
+		return fmt.Errorf("failed to index the holder balances of every token")
+	}
+	return nil
+}
+
+// newDeployment converts the wormhole address of a token to its address on the chain.
+func newDeployment(t *domain.TokenMetadata) (deployment, error) {
+	address, err := hex.DecodeString(t.TokenAddress)
+	if err != nil || len(address) != 32 {
+		return deployment{}, fmt.Errorf("invalid wormhole address %s", t.TokenAddress)
+	}
+
+	var native string
+	if t.TokenChain == sdk.ChainIDSolana {
+		native = base58.Encode(address)
+	} else {
+		native = "0x" + strings.ToLower(hex.EncodeToString(address[12:]))
+	}
+	return deployment{tokenAddress: t.TokenAddress, nativeAddress: native, decimals: t.Decimals}, nil
+}
@@ -0,0 +1,94 @@
+package holders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	"go.uber.org/zap"
+)
+
+// errNoRpc is returned when the pool of a chain has no rpc.
+var errNoRpc = errors.New("no rpc configured")
+
+type rpcRequest struct {
+	Jsonrpc string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// rpcClient calls the json-rpc methods of a chain through a pool of rpcs.
+type rpcClient struct {
+	client *resty.Client
+	pool   *pool.Pool
+	logger *zap.Logger
+}
+
+func newRpcClient(client *resty.Client, pool *pool.Pool, logger *zap.Logger) *rpcClient {
+	return &rpcClient{client: client, pool: pool, logger: logger}
+}
+
+// call calls the method on the rpcs of the pool, sorted by score and priority, until one of them succeeds.
+func (c *rpcClient) call(ctx context.Context, result any, method string, params ...any) error {
+	rpcs := c.pool.GetItems()
+	if len(rpcs) == 0 {
+		return errNoRpc
+	}
+
+	var err error
+	for _, rpc := range rpcs {
+		// Wait for the RPC rate limiter
+		if err = rpc.Wait(ctx); err != nil {
+			return err
+		}
+		err = c.callRpc(ctx, rpc.Id, result, method, params)
+		if err == nil {
+			return nil
+		}
+		c.logger.Debug("failed to call rpc", zap.String("rpc", rpc.Description), zap.String("method", method), zap.Error(err))
+	}
+	return err
+}
+
+func (c *rpcClient) callRpc(ctx context.Context, url string, result any, method string, params []any) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetBody(rpcRequest{Jsonrpc: "2.0", ID: 1, Method: method, Params: params}).
+		Post(url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("status code: %s. %s", resp.Status(), string(resp.Body()))
+	}
+
+	var response rpcResponse
+	if err := json.Unmarshal(resp.Body(), &response); err != nil {
+		return fmt.Errorf("invalid %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package holders
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+// splTokenProgram is the SPL token program, its token accounts have a fixed size.
+// The accounts of the token-2022 program can have extensions, so they are only filtered by mint.
+const (
+	splTokenProgram          = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	splTokenAccountSize      = 165
+	splTokenAccountMintIndex = 0
+)
+
+type solanaAccountInfo struct {
+	Value *struct {
+		Owner string `json:"owner"`
+	} `json:"value"`
+}
+
+type solanaTokenAccounts struct {
+	Context struct {
+		Slot uint64 `json:"slot"`
+	} `json:"context"`
+	Value []struct {
+		Pubkey  string `json:"pubkey"`
+		Account struct {
+			Data struct {
+				Parsed struct {
+					Info struct {
+						Owner       string `json:"owner"`
+						TokenAmount struct {
+							Amount string `json:"amount"`
+						} `json:"tokenAmount"`
+					} `json:"info"`
+				} `json:"parsed"`
+			} `json:"data"`
+		} `json:"account"`
+	} `json:"value"`
+}
+
+// solanaIndexer indexes the balances of a SPL token from its token accounts.
+// Solana has no cheap history of the transfers of a token, so every run takes a snapshot of the token
+// accounts, aggregates them by owner, and deletes the holders that are no longer present.
+type solanaIndexer struct {
+	rpc    *rpcClient
+	store  balanceStore
+	logger *zap.Logger
+}
+
+// index takes a snapshot of the token accounts of the mint, and returns the number of balances updated.
+func (i *solanaIndexer) index(ctx context.Context, token deployment) (int, error) {
+	var mint solanaAccountInfo
+	if err := i.rpc.call(ctx, &mint, "getAccountInfo", token.nativeAddress, map[string]any{"encoding": "jsonParsed"}); err != nil {
+		return 0, fmt.Errorf("failed to get mint %s: %w", token.nativeAddress, err)
+	}
+	if mint.Value == nil {
+		return 0, fmt.Errorf("mint %s not found", token.nativeAddress)
+	}
+
+	filters := []map[string]any{
+		{"memcmp": map[string]any{"offset": splTokenAccountMintIndex, "bytes": token.nativeAddress}},
+	}
+	if mint.Value.Owner == splTokenProgram {
+		filters = append(filters, map[string]any{"dataSize": splTokenAccountSize})
+	}
+	var accounts solanaTokenAccounts
+	config := map[string]any{"encoding": "jsonParsed", "withContext": true, "filters": filters}
+	if err := i.rpc.call(ctx, &accounts, "getProgramAccounts", mint.Value.Owner, config); err != nil {
+		return 0, fmt.Errorf("failed to get token accounts of %s: %w", token.nativeAddress, err)
+	}
+
+	slot := accounts.Context.Slot
+	checkpoint, err := i.store.GetCheckpoint(ctx, sdk.ChainIDSolana, token.tokenAddress)
+	if err != nil {
+		return 0, err
+	}
+	if checkpoint != nil && checkpoint.Block >= slot {
+		i.logger.Info("solana holder balances are up to date", zap.String("mint", token.nativeAddress), zap.Uint64("slot", slot))
+		return 0, nil
+	}
+
+	owners := make(map[string]*big.Int)
+	for _, a := range accounts.Value {
+		info := a.Account.Data.Parsed.Info
+		amount, ok := new(big.Int).SetString(info.TokenAmount.Amount, 10)
+		if !ok {
+			return 0, fmt.Errorf("invalid amount %s of token account %s", info.TokenAmount.Amount, a.Pubkey)
+		}
+		if _, ok := owners[info.Owner]; !ok {
+			owners[info.Owner] = new(big.Int)
+		}
+		owners[info.Owner].Add(owners[info.Owner], amount)
+	}
+
+	now := time.Now()
+	balances := make([]stats.HolderBalance, 0, len(owners))
+	for owner, amount := range owners {
+		balances = append(balances, newHolderBalance(sdk.ChainIDSolana, token, owner, amount, slot, now))
+	}
+	if err := i.store.SaveBalances(ctx, balances); err != nil {
+		return 0, err
+	}
+	if err := i.store.DeleteBalancesBefore(ctx, sdk.ChainIDSolana, token.tokenAddress, slot); err != nil {
+		return 0, err
+	}
+	if err := i.store.SetCheckpoint(ctx, sdk.ChainIDSolana, token.tokenAddress, slot); err != nil {
+		return 0, err
+	}
+
+	i.logger.Info("indexed solana holder balances",
+		zap.String("mint", token.nativeAddress),
+		zap.Uint64("slot", slot),
+		zap.Int("accounts", len(accounts.Value)),
+		zap.Int("holders", len(balances)))
+	return len(balances), nil
+}
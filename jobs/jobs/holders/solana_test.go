@@ -0,0 +1,56 @@
+package holders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.uber.org/zap"
+)
+
+var solanaToken = deployment{
+	tokenAddress:  "6927fdc01ea906f96d7137874cdd7adad00ca35764619310e54196c781d84d5b",
+	nativeAddress: "85VBFQZC9TZkfaptBWjvUw7YbZjy52A6mjtPGjstQAmQ",
+	decimals:      6,
+}
+
+const (
+	ownerX = "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"
+	ownerY = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	ownerZ = "3Kz8UzqHyXrThW6oGQySqLXnKt4t2ZbM6dUJmJtdXmLm"
+)
+
+func TestSolanaIndexer_Index(t *testing.T) {
+	store := newMemoryStore()
+	// a holder that closed its token accounts since the last snapshot
+	_ = store.SaveBalances(context.Background(), []stats.HolderBalance{{
+		ID:           stats.HolderBalanceID(sdk.ChainIDSolana, solanaToken.tokenAddress, ownerZ),
+		ChainID:      sdk.ChainIDSolana,
+		TokenAddress: solanaToken.tokenAddress,
+		Address:      ownerZ,
+		Balance:      "1000",
+		Block:        200,
+	}})
+	i := &solanaIndexer{rpc: newFixtureRpcClient(t, "solana_rpc.json"), store: store, logger: zap.NewNop()}
+
+	updated, err := i.index(context.Background(), solanaToken)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	// the token accounts are aggregated by owner
+	assert.Equal(t, "150000000", store.balance(sdk.ChainIDSolana, solanaToken.tokenAddress, ownerX))
+	assert.Equal(t, float64(150), store.balances[stats.HolderBalanceID(sdk.ChainIDSolana, solanaToken.tokenAddress, ownerX)].Amount)
+	assert.Equal(t, "25000000", store.balance(sdk.ChainIDSolana, solanaToken.tokenAddress, ownerY))
+	assert.Len(t, store.balances, 2)
+
+	checkpoint, err := store.GetCheckpoint(context.Background(), sdk.ChainIDSolana, solanaToken.tokenAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(250), checkpoint.Block)
+
+	// the snapshot of the same slot is not applied again
+	updated, err = i.index(context.Background(), solanaToken)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated)
+}
@@ -1,7 +1,12 @@
 // Package jobs define an interface to execute jobs
 package jobs
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
 
 // JobIDNotional is the job id for notional job.
 const (
@@ -15,9 +20,52 @@ const (
 	JobIDNTTTopHolderStats     = "JOB_NTT_TOP_HOLDER_STATS"
 	JobIDNTTMedianStats        = "JOB_NTT_MEDIAN_STATS"
 	JobIDMigrationNativeTxHash = "JOB_MIGRATE_NATIVE_TX_HASH"
+	JobIDStuckOperations       = "JOB_STUCK_OPERATIONS"
+	JobIDHolderBalances        = "JOB_HOLDER_BALANCES"
+	JobIDMigrationOpStatus     = "JOB_MIGRATE_OPERATION_STATUS"
 )
 
 // Job is the interface for jobs.
 type Job interface {
 	Run(ctx context.Context) error
 }
+
+// JobFunc adapts a function to the Job interface.
+type JobFunc func(ctx context.Context) error
+
+// Run calls f(ctx).
+func (f JobFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Params are the parameters of a job execution, e.g. the time range of a backfill.
+type Params map[string]string
+
+// Time returns the parameter parsed as a RFC3339 date, or def if the parameter is not set.
+func (p Params) Time(key string, def time.Time) (time.Time, error) {
+	v, ok := p[key]
+	if !ok || v == "" {
+		return def, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s parameter %q: %w", key, v, err)
+	}
+	return t.UTC(), nil
+}
+
+type itemsCounterKey struct{}
+
+// WithItemsCounter returns a context where the jobs report the number of items they processed.
+func WithItemsCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := &atomic.Int64{}
+	return context.WithValue(ctx, itemsCounterKey{}, counter), counter
+}
+
+// AddItemsProcessed adds n to the number of items processed by the job running with ctx.
+// It does nothing if the job does not run with an items counter.
+func AddItemsProcessed(ctx context.Context, n int64) {
+	if counter, ok := ctx.Value(itemsCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(n)
+	}
+}
@@ -0,0 +1,151 @@
+package migration
+
+import (
+	"context"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// MigrateOperationStatus is the job to store the lifecycle status of the operations processed
+// before the status was stored in the globalTransactions collection.
+type MigrateOperationStatus struct {
+	pageSize    int64
+	collections struct {
+		globalTransactions *mongo.Collection
+		vaas               *mongo.Collection
+		governorVaas       *mongo.Collection
+	}
+	logger *zap.Logger
+}
+
+// NewMigrationOperationStatus creates a new migration job.
+func NewMigrationOperationStatus(db *mongo.Database, pageSize int, logger *zap.Logger) *MigrateOperationStatus {
+	return &MigrateOperationStatus{
+		pageSize: int64(pageSize),
+		collections: struct {
+			globalTransactions *mongo.Collection
+			vaas               *mongo.Collection
+			governorVaas       *mongo.Collection
+		}{
+			globalTransactions: db.Collection(repository.GlobalTransactions),
+			vaas:               db.Collection(repository.Vaas),
+			governorVaas:       db.Collection(repository.GovernorVaas),
+		},
+		logger: logger}
+}
+
+// operationWithoutStatus is an operation of the globalTransactions collection without status.
+type operationWithoutStatus struct {
+	ID            string `bson:"_id"`
+	DestinationTx *struct {
+		Status string `bson:"status"`
+	} `bson:"destinationTx"`
+}
+
+// Run runs the migration job.
+func (m *MigrateOperationStatus) Run(ctx context.Context) error {
+	var total int
+	lastID := ""
+	for {
+		operations, err := m.getOperationsToMigrate(ctx, lastID)
+		if err != nil {
+			m.logger.Error("failed to get operations", zap.Error(err))
+			return err
+		}
+		if len(operations) == 0 {
+			break
+		}
+
+		if err := m.updateStatus(ctx, operations); err != nil {
+			m.logger.Error("failed to update operations status", zap.String("lastId", lastID), zap.Error(err))
+			return err
+		}
+
+		total += len(operations)
+		lastID = operations[len(operations)-1].ID
+		m.logger.Info("migrating operations status", zap.String("lastId", lastID), zap.Int("total", total))
+	}
+	return nil
+}
+
+// getOperationsToMigrate returns the next page of operations without status, sorted by id.
+func (m *MigrateOperationStatus) getOperationsToMigrate(ctx context.Context, afterID string) ([]operationWithoutStatus, error) {
+	filter := bson.D{
+		{Key: "_id", Value: bson.M{"$gt": afterID}},
+		{Key: "status", Value: bson.M{"$exists": false}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(m.pageSize).
+		SetProjection(bson.D{{Key: "destinationTx.status", Value: 1}})
+
+	cur, err := m.collections.globalTransactions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var operations []operationWithoutStatus
+	if err := cur.All(ctx, &operations); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+// updateStatus stores the status derived from the operation data. Operations that got a status
+// since they were read are not updated.
+func (m *MigrateOperationStatus) updateStatus(ctx context.Context, operations []operationWithoutStatus) error {
+	ids := make([]string, 0, len(operations))
+	for _, o := range operations {
+		ids = append(ids, o.ID)
+	}
+	signed, err := findIDs(ctx, m.collections.vaas, ids)
+	if err != nil {
+		return err
+	}
+	enqueued, err := findIDs(ctx, m.collections.governorVaas, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(operations))
+	for _, o := range operations {
+		var destinationTxStatus string
+		if o.DestinationTx != nil {
+			destinationTxStatus = o.DestinationTx.Status
+		}
+		status := domain.DeriveOperationStatus(signed[o.ID], enqueued[o.ID], destinationTxStatus)
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{{Key: "_id", Value: o.ID}, {Key: "status", Value: bson.M{"$exists": false}}}).
+			SetUpdate(bson.D{{Key: "$set", Value: bson.D{
+				{Key: "status", Value: status},
+				{Key: "statusUpdatedAt", Value: now},
+			}}}))
+	}
+	_, err = m.collections.globalTransactions.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// findIDs returns the ids of the documents of the collection that are in ids.
+func findIDs(ctx context.Context, collection *mongo.Collection, ids []string) (map[string]bool, error) {
+	cur, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(docs))
+	for _, d := range docs {
+		found[d.ID] = true
+	}
+	return found, nil
+}
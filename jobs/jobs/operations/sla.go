@@ -0,0 +1,59 @@
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+// redeemSample is the time elapsed between the VAA being signed and the redeem of an operation.
+type redeemSample struct {
+	targetChain sdk.ChainID
+	appIDs      []string
+	duration    time.Duration
+}
+
+// computeSla returns the time to redeem percentiles of the samples grouped by target chain and by app id.
+func computeSla(samples []redeemSample, from, to, now time.Time) []repository.OperationSlaDoc {
+	groups := make(map[string][]float64)
+	keys := make(map[string][2]string)
+	add := func(groupBy, key string, seconds float64) {
+		id := fmt.Sprintf("%s:%s", groupBy, key)
+		groups[id] = append(groups[id], seconds)
+		keys[id] = [2]string{groupBy, key}
+	}
+
+	for _, s := range samples {
+		seconds := s.duration.Seconds()
+		add(repository.SlaGroupByTargetChain, strconv.Itoa(int(s.targetChain)), seconds)
+		for _, appID := range s.appIDs {
+			add(repository.SlaGroupByAppID, appID, seconds)
+		}
+	}
+
+	docs := make([]repository.OperationSlaDoc, 0, len(groups))
+	for id, values := range groups {
+		sort.Float64s(values)
+		docs = append(docs, repository.OperationSlaDoc{
+			ID:        id,
+			GroupBy:   keys[id][0],
+			Key:       keys[id][1],
+			From:      from,
+			To:        to,
+			Count:     len(values),
+			P50:       utils.Percentile(values, 50),
+			P90:       utils.Percentile(values, 90),
+			P99:       utils.Percentile(values, 99),
+			UpdatedAt: now,
+		})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].ID < docs[j].ID
+	})
+	return docs
+}
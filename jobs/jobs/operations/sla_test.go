@@ -0,0 +1,40 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestComputeSla(t *testing.T) {
+	now := time.Now()
+	from := now.Add(-time.Hour)
+	samples := []redeemSample{
+		{targetChain: sdk.ChainIDEthereum, appIDs: []string{domain.AppIdPortalTokenBridge}, duration: 60 * time.Second},
+		{targetChain: sdk.ChainIDEthereum, appIDs: []string{domain.AppIdNTT}, duration: 120 * time.Second},
+		{targetChain: sdk.ChainIDSolana, appIDs: []string{domain.AppIdPortalTokenBridge}, duration: 30 * time.Second},
+	}
+
+	docs := computeSla(samples, from, now, now)
+
+	byID := make(map[string]repository.OperationSlaDoc)
+	for _, d := range docs {
+		byID[d.ID] = d
+	}
+	assert.Len(t, docs, 4)
+
+	eth := byID["targetChain:2"]
+	assert.Equal(t, 2, eth.Count)
+	assert.Equal(t, float64(60), eth.P50)
+	assert.Equal(t, float64(120), eth.P99)
+
+	tb := byID["appId:PORTAL_TOKEN_BRIDGE"]
+	assert.Equal(t, repository.SlaGroupByAppID, tb.GroupBy)
+	assert.Equal(t, domain.AppIdPortalTokenBridge, tb.Key)
+	assert.Equal(t, 2, tb.Count)
+	assert.Equal(t, float64(30), tb.P50)
+}
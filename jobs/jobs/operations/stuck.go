@@ -0,0 +1,469 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/repository"
+	jobsAlert "github.com/wormhole-foundation/wormhole-explorer/jobs/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// maxAlertIDs is the maximum number of operation ids included in an alert.
+const maxAlertIDs = 10
+
+// StuckOperationsJob is the job that detects the operations that were not redeemed on the target chain.
+//
+// The operations stuck in the current run are stored in the `stuckOperations` collection. The stored
+// operations that are not found in a run are re-checked and kept until they are redeemed, so the
+// operations stuck for longer than the lookback window are not lost. The time to redeem percentiles
+// of the operations redeemed in the lookback window are stored in the `operationsSla` collection.
+type StuckOperationsJob struct {
+	threshold     time.Duration
+	lookback      time.Duration
+	trackedChains map[sdk.ChainID]bool
+	collections   struct {
+		parsedVaa          *mongo.Collection
+		globalTransactions *mongo.Collection
+		stuckOperations    *mongo.Collection
+		operationsSla      *mongo.Collection
+	}
+	alertClient alert.AlertClient
+	logger      *zap.Logger
+}
+
+// NewStuckOperationsJob creates a new stuck operations job.
+func NewStuckOperationsJob(
+	db *mongo.Database,
+	threshold time.Duration,
+	lookback time.Duration,
+	trackedChains []sdk.ChainID,
+	alertClient alert.AlertClient,
+	logger *zap.Logger) *StuckOperationsJob {
+
+	tracked := make(map[sdk.ChainID]bool, len(trackedChains))
+	for _, chainID := range trackedChains {
+		tracked[chainID] = true
+	}
+
+	return &StuckOperationsJob{
+		threshold:     threshold,
+		lookback:      lookback,
+		trackedChains: tracked,
+		collections: struct {
+			parsedVaa          *mongo.Collection
+			globalTransactions *mongo.Collection
+			stuckOperations    *mongo.Collection
+			operationsSla      *mongo.Collection
+		}{
+			parsedVaa:          db.Collection(repository.ParsedVaa),
+			globalTransactions: db.Collection(repository.GlobalTransactions),
+			stuckOperations:    db.Collection(repository.StuckOperations),
+			operationsSla:      db.Collection(repository.OperationsSla),
+		},
+		alertClient: alertClient,
+		logger:      logger,
+	}
+}
+
+// stuckAppIDs are the protocols whose operations are expected to be redeemed on the target chain.
+var stuckAppIDs = []string{domain.AppIdPortalTokenBridge, domain.AppIdNTT, domain.AppIdCCTP}
+
+// signedOperation is an operation with a signed VAA joined with its global transaction.
+type signedOperation struct {
+	ID           string      `bson:"_id"`
+	EmitterChain sdk.ChainID `bson:"emitterChain"`
+	// SignedAt is the time the VAA was signed, the SLA of the operation starts then.
+	SignedAt      time.Time   `bson:"signedAt"`
+	TargetChain   sdk.ChainID `bson:"targetChain"`
+	AppIDs        []string    `bson:"appIds"`
+	TxHash        string      `bson:"txHash"`
+	DestinationTx *struct {
+		Status    string     `bson:"status"`
+		Timestamp *time.Time `bson:"timestamp"`
+	} `bson:"destinationTx"`
+}
+
+// enqueuedOperation is an operation held by the governor.
+type enqueuedOperation struct {
+	ID        string `bson:"_id"`
+	Lifecycle struct {
+		GovernorEnqueuedAt time.Time `bson:"governorEnqueuedAt"`
+	} `bson:"lifecycle"`
+	OriginTx *struct {
+		TxHash string `bson:"nativeTxHash"`
+	} `bson:"originTx"`
+}
+
+// Run runs the stuck operations job.
+func (j *StuckOperationsJob) Run(ctx context.Context) error {
+	now := time.Now().UTC()
+	from := now.Add(-j.lookback)
+	cutoff := now.Add(-j.threshold)
+
+	stuck, samples, err := j.findSignedOperations(ctx, from, now, cutoff)
+	if err != nil {
+		return err
+	}
+
+	enqueued, err := j.findEnqueuedOperations(ctx, from, cutoff)
+	if err != nil {
+		return err
+	}
+	stuck = append(stuck, enqueued...)
+
+	detected, err := j.saveStuckOperations(ctx, stuck, now)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := j.recheckStuckOperations(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	j.sendAlerts(ctx, detected)
+	jobs.AddItemsProcessed(ctx, int64(len(stuck)))
+
+	if err := j.saveSla(ctx, computeSla(samples, from, now, now), now); err != nil {
+		return err
+	}
+
+	j.logger.Info("stuck operations job finished",
+		zap.Int("stuck", len(stuck)),
+		zap.Int("detected", len(detected)),
+		zap.Int64("resolved", resolved),
+		zap.Int("redeemed", len(samples)))
+	return nil
+}
+
+// findSignedOperations returns the operations signed before the cutoff that were not redeemed,
+// and the time to redeem of the operations redeemed in the time range.
+func (j *StuckOperationsJob) findSignedOperations(ctx context.Context, from, to, cutoff time.Time) ([]repository.StuckOperationDoc, []redeemSample, error) {
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "timestamp", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+			{Key: "rawStandardizedProperties.appIds", Value: bson.D{{Key: "$in", Value: stuckAppIDs}}},
+		}}},
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: repository.GlobalTransactions}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: repository.Vaas},
+			{Key: "let", Value: bson.D{{Key: "id", Value: "$_id"}}},
+			{Key: "pipeline", Value: bson.A{
+				bson.D{{Key: "$match", Value: bson.D{{Key: "$expr", Value: bson.D{{Key: "$eq", Value: bson.A{"$_id", "$$id"}}}}}}},
+				bson.D{{Key: "$project", Value: bson.D{{Key: "indexedAt", Value: 1}}}},
+			}},
+			{Key: "as", Value: "vaas"},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "emitterChain", Value: 1},
+			// the timestamp of the VAA is the block time of the source transaction, the VAA is signed later.
+			// The signed time is taken from the operation lifecycle, or from the time the signed VAA was indexed.
+			{Key: "signedAt", Value: bson.D{{Key: "$ifNull", Value: bson.A{
+				bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.lifecycle.vaaSignedAt", 0}}},
+				bson.D{{Key: "$arrayElemAt", Value: bson.A{"$vaas.indexedAt", 0}}},
+				"$timestamp",
+			}}}},
+			{Key: "targetChain", Value: "$rawStandardizedProperties.toChain"},
+			{Key: "appIds", Value: "$rawStandardizedProperties.appIds"},
+			{Key: "txHash", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.originTx.nativeTxHash", 0}}}},
+			{Key: "destinationTx", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx", 0}}}},
+		}}},
+	}
+
+	cur, err := j.collections.parsedVaa.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find signed operations: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	var stuck []repository.StuckOperationDoc
+	var samples []redeemSample
+	for cur.Next(ctx) {
+		var op signedOperation
+		if err := cur.Decode(&op); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode signed operation: %w", err)
+		}
+
+		var destinationTxStatus string
+		if op.DestinationTx != nil {
+			destinationTxStatus = op.DestinationTx.Status
+		}
+
+		if destinationTxStatus == domain.DstTxStatusConfirmed {
+			if op.DestinationTx.Timestamp != nil && op.DestinationTx.Timestamp.After(op.SignedAt) {
+				samples = append(samples, redeemSample{
+					targetChain: op.TargetChain,
+					appIDs:      op.AppIDs,
+					duration:    op.DestinationTx.Timestamp.Sub(op.SignedAt),
+				})
+			}
+			continue
+		}
+
+		if !op.SignedAt.Before(cutoff) {
+			continue
+		}
+
+		status := domain.DeriveOperationStatus(true, false, destinationTxStatus)
+		stuck = append(stuck, repository.StuckOperationDoc{
+			ID:           op.ID,
+			Cause:        domain.ClassifyStuckCause(true, j.trackedChains[op.TargetChain], destinationTxStatus),
+			Status:       status,
+			EmitterChain: op.EmitterChain,
+			TargetChain:  op.TargetChain,
+			AppIDs:       op.AppIDs,
+			TxHash:       op.TxHash,
+			StuckSince:   op.SignedAt,
+		})
+	}
+	if err := cur.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to iterate signed operations: %w", err)
+	}
+	return stuck, samples, nil
+}
+
+// findEnqueuedOperations returns the operations enqueued by the governor before the cutoff that are not signed yet.
+func (j *StuckOperationsJob) findEnqueuedOperations(ctx context.Context, from, cutoff time.Time) ([]repository.StuckOperationDoc, error) {
+
+	filter := bson.D{
+		{Key: "status", Value: domain.OperationStatusGovernorEnqueued},
+		{Key: "lifecycle.governorEnqueuedAt", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: cutoff}}},
+	}
+	opts := options.Find().SetProjection(bson.D{{Key: "lifecycle", Value: 1}, {Key: "originTx.nativeTxHash", Value: 1}})
+
+	cur, err := j.collections.globalTransactions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find enqueued operations: %w", err)
+	}
+
+	var ops []enqueuedOperation
+	if err := cur.All(ctx, &ops); err != nil {
+		return nil, fmt.Errorf("failed to decode enqueued operations: %w", err)
+	}
+
+	stuck := make([]repository.StuckOperationDoc, 0, len(ops))
+	for _, op := range ops {
+		emitterChain, err := parseEmitterChain(op.ID)
+		if err != nil {
+			j.logger.Warn("invalid operation id", zap.String("id", op.ID), zap.Error(err))
+			continue
+		}
+		var txHash string
+		if op.OriginTx != nil {
+			txHash = op.OriginTx.TxHash
+		}
+		// the governor only holds token bridge transfers. The target chain is unknown until the VAA is signed
+		// and parsed, then it is set when the operation is re-checked.
+		stuck = append(stuck, repository.StuckOperationDoc{
+			ID:           op.ID,
+			Cause:        domain.StuckCauseGovernorEnqueued,
+			Status:       domain.OperationStatusGovernorEnqueued,
+			EmitterChain: emitterChain,
+			AppIDs:       []string{domain.AppIdPortalTokenBridge},
+			TxHash:       txHash,
+			StuckSince:   op.Lifecycle.GovernorEnqueuedAt,
+		})
+	}
+	return stuck, nil
+}
+
+// saveStuckOperations upserts the stuck operations and returns the ones detected for the first time.
+func (j *StuckOperationsJob) saveStuckOperations(ctx context.Context, stuck []repository.StuckOperationDoc, now time.Time) ([]repository.StuckOperationDoc, error) {
+	var detected []repository.StuckOperationDoc
+	for _, op := range stuck {
+		update := bson.M{
+			"$set": bson.M{
+				"cause":        op.Cause,
+				"status":       op.Status,
+				"emitterChain": op.EmitterChain,
+				"targetChain":  op.TargetChain,
+				"appIds":       op.AppIDs,
+				"txHash":       op.TxHash,
+				"stuckSince":   op.StuckSince,
+				"updatedAt":    now,
+			},
+			"$setOnInsert": bson.M{"detectedAt": now},
+		}
+		result, err := j.collections.stuckOperations.UpdateByID(ctx, op.ID, update, options.Update().SetUpsert(true))
+		if err != nil {
+			return nil, fmt.Errorf("failed to save stuck operation %s: %w", op.ID, err)
+		}
+		if result.UpsertedCount > 0 {
+			detected = append(detected, op)
+		}
+	}
+	return detected, nil
+}
+
+// storedStuckOperation is a stored stuck operation joined with the current data of the operation.
+type storedStuckOperation struct {
+	ID          string                 `bson:"_id"`
+	Status      domain.OperationStatus `bson:"status"`
+	TargetChain sdk.ChainID            `bson:"targetChain"`
+	// OperationStatus is the lifecycle status stored in the globalTransactions collection.
+	OperationStatus     domain.OperationStatus `bson:"operationStatus"`
+	DestinationTxStatus string                 `bson:"destinationTxStatus"`
+	VaaSignedAt         *time.Time             `bson:"vaaSignedAt"`
+	// ParsedTargetChain is the target chain of the parsed VAA, nil if the VAA is not parsed yet.
+	ParsedTargetChain *sdk.ChainID `bson:"parsedTargetChain"`
+}
+
+// recheckStuckOperations checks the stored stuck operations that were not found in the run, because
+// they were redeemed or are out of the lookback window. The redeemed operations are removed and the
+// others are updated. It returns the number of removed operations.
+func (j *StuckOperationsJob) recheckStuckOperations(ctx context.Context, now time.Time) (int64, error) {
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "updatedAt", Value: bson.D{{Key: "$lt", Value: now}}}}}},
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: repository.GlobalTransactions}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "globalTransactions"}}}},
+		{{Key: "$lookup", Value: bson.D{{Key: "from", Value: repository.ParsedVaa}, {Key: "localField", Value: "_id"}, {Key: "foreignField", Value: "_id"}, {Key: "as", Value: "parsedVaa"}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "targetChain", Value: 1},
+			{Key: "operationStatus", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.status", 0}}}},
+			{Key: "destinationTxStatus", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.destinationTx.status", 0}}}},
+			{Key: "vaaSignedAt", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$globalTransactions.lifecycle.vaaSignedAt", 0}}}},
+			{Key: "parsedTargetChain", Value: bson.D{{Key: "$arrayElemAt", Value: bson.A{"$parsedVaa.rawStandardizedProperties.toChain", 0}}}},
+		}}},
+	}
+
+	cur, err := j.collections.stuckOperations.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find stored stuck operations: %w", err)
+	}
+	var stored []storedStuckOperation
+	if err := cur.All(ctx, &stored); err != nil {
+		return 0, fmt.Errorf("failed to decode stored stuck operations: %w", err)
+	}
+
+	var redeemedIDs []string
+	for _, op := range stored {
+		set, redeemed := refreshStuckOperation(op, j.trackedChains, now)
+		if redeemed {
+			redeemedIDs = append(redeemedIDs, op.ID)
+			continue
+		}
+		if _, err := j.collections.stuckOperations.UpdateByID(ctx, op.ID, bson.M{"$set": set}); err != nil {
+			return 0, fmt.Errorf("failed to update stuck operation %s: %w", op.ID, err)
+		}
+	}
+
+	if len(redeemedIDs) == 0 {
+		return 0, nil
+	}
+	deleted, err := j.collections.stuckOperations.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": redeemedIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete redeemed stuck operations: %w", err)
+	}
+	return deleted.DeletedCount, nil
+}
+
+// refreshStuckOperation returns the fields to update of a stored stuck operation from the current data
+// of the operation, or true if the operation was redeemed.
+func refreshStuckOperation(op storedStuckOperation, trackedChains map[sdk.ChainID]bool, now time.Time) (bson.M, bool) {
+	if op.DestinationTxStatus == domain.DstTxStatusConfirmed || op.OperationStatus == domain.OperationStatusRedeemed {
+		return nil, true
+	}
+
+	targetChain := op.TargetChain
+	if op.ParsedTargetChain != nil {
+		targetChain = *op.ParsedTargetChain
+	}
+	set := bson.M{
+		"targetChain": targetChain,
+		"updatedAt":   now,
+	}
+
+	enqueued := op.Status == domain.OperationStatusGovernorEnqueued
+	signed := !enqueued || op.VaaSignedAt != nil || op.OperationStatus.IsAfter(domain.OperationStatusGovernorEnqueued)
+	if !signed {
+		return set, false
+	}
+
+	set["status"] = domain.DeriveOperationStatus(true, false, op.DestinationTxStatus)
+	set["cause"] = domain.ClassifyStuckCause(true, trackedChains[targetChain], op.DestinationTxStatus)
+	// the operation released by the governor is stuck since its VAA was signed.
+	if enqueued && op.VaaSignedAt != nil {
+		set["stuckSince"] = *op.VaaSignedAt
+	}
+	return set, false
+}
+
+// saveSla replaces the time to redeem percentiles and removes the groups without redeemed operations.
+func (j *StuckOperationsJob) saveSla(ctx context.Context, docs []repository.OperationSlaDoc, now time.Time) error {
+	for _, doc := range docs {
+		_, err := j.collections.operationsSla.ReplaceOne(ctx, bson.M{"_id": doc.ID}, doc, options.Replace().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("failed to save sla %s: %w", doc.ID, err)
+		}
+	}
+	_, err := j.collections.operationsSla.DeleteMany(ctx, bson.M{"updatedAt": bson.M{"$lt": now}})
+	if err != nil {
+		return fmt.Errorf("failed to delete outdated sla: %w", err)
+	}
+	return nil
+}
+
+// sendAlerts sends an alert for each chain and cause with new stuck operations.
+//
+// The alerts are grouped by target chain, except for the operations enqueued by the governor
+// that are grouped by emitter chain.
+func (j *StuckOperationsJob) sendAlerts(ctx context.Context, detected []repository.StuckOperationDoc) {
+	type alertGroup struct {
+		cause   domain.StuckCause
+		chainID sdk.ChainID
+	}
+	groups := make(map[alertGroup][]string)
+	for _, op := range detected {
+		g := alertGroup{cause: op.Cause, chainID: op.TargetChain}
+		if op.Cause == domain.StuckCauseGovernorEnqueued {
+			g.chainID = op.EmitterChain
+		}
+		groups[g] = append(groups[g], op.ID)
+	}
+
+	for g, ids := range groups {
+		sort.Strings(ids)
+		count := len(ids)
+		if len(ids) > maxAlertIDs {
+			ids = ids[:maxAlertIDs]
+		}
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"chainId": g.chainID.String(),
+				"cause":   string(g.cause),
+				"count":   fmt.Sprint(count),
+				"ids":     strings.Join(ids, ","),
+			},
+		}
+		if err := j.alertClient.CreateAndSend(ctx, jobsAlert.StuckOperationAlertKey(g.cause), alertContext); err != nil {
+			j.logger.Error("failed to send stuck operations alert", zap.String("cause", string(g.cause)), zap.Error(err))
+		}
+	}
+}
+
+// parseEmitterChain returns the emitter chain of an operation id (chain/emitter/sequence).
+func parseEmitterChain(id string) (sdk.ChainID, error) {
+	chain, _, ok := strings.Cut(id, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid id %s", id)
+	}
+	chainID, err := strconv.ParseUint(chain, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %s: %w", id, err)
+	}
+	return sdk.ChainID(chainID), nil
+}
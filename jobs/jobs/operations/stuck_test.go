@@ -0,0 +1,87 @@
+package operations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRefreshStuckOperation(t *testing.T) {
+	now := time.Now()
+	signedAt := now.Add(-100 * time.Hour)
+	ethereum := sdk.ChainIDEthereum
+	tracked := map[sdk.ChainID]bool{sdk.ChainIDEthereum: true}
+
+	tests := []struct {
+		name         string
+		op           storedStuckOperation
+		wantSet      bson.M
+		wantRedeemed bool
+	}{
+		{
+			name:         "redeemed",
+			op:           storedStuckOperation{Status: domain.OperationStatusVaaSigned, DestinationTxStatus: domain.DstTxStatusConfirmed},
+			wantRedeemed: true,
+		},
+		{
+			name:         "redeemed status",
+			op:           storedStuckOperation{Status: domain.OperationStatusVaaSigned, OperationStatus: domain.OperationStatusRedeemed},
+			wantRedeemed: true,
+		},
+		{
+			name: "still signed",
+			op:   storedStuckOperation{Status: domain.OperationStatusVaaSigned, TargetChain: sdk.ChainIDSolana, OperationStatus: domain.OperationStatusVaaSigned},
+			wantSet: bson.M{
+				"targetChain": sdk.ChainIDSolana,
+				"updatedAt":   now,
+				"status":      domain.OperationStatusVaaSigned,
+				"cause":       domain.StuckCauseTargetNotTracked,
+			},
+		},
+		{
+			name: "failed redeem",
+			op:   storedStuckOperation{Status: domain.OperationStatusVaaSigned, TargetChain: sdk.ChainIDEthereum, DestinationTxStatus: domain.DstTxStatusFailedToProcess},
+			wantSet: bson.M{
+				"targetChain": sdk.ChainIDEthereum,
+				"updatedAt":   now,
+				"status":      domain.OperationStatusTargetFailed,
+				"cause":       domain.StuckCauseRedeemFailed,
+			},
+		},
+		{
+			name: "still enqueued",
+			op:   storedStuckOperation{Status: domain.OperationStatusGovernorEnqueued, OperationStatus: domain.OperationStatusGovernorEnqueued},
+			wantSet: bson.M{
+				"targetChain": sdk.ChainIDUnset,
+				"updatedAt":   now,
+			},
+		},
+		{
+			name: "released by the governor",
+			op: storedStuckOperation{
+				Status:            domain.OperationStatusGovernorEnqueued,
+				OperationStatus:   domain.OperationStatusVaaSigned,
+				VaaSignedAt:       &signedAt,
+				ParsedTargetChain: &ethereum,
+			},
+			wantSet: bson.M{
+				"targetChain": sdk.ChainIDEthereum,
+				"updatedAt":   now,
+				"status":      domain.OperationStatusVaaSigned,
+				"cause":       domain.StuckCauseRelayerNotDelivered,
+				"stuckSince":  signedAt,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, redeemed := refreshStuckOperation(tt.op, tracked, now)
+			assert.Equal(t, tt.wantRedeemed, redeemed)
+			assert.Equal(t, tt.wantSet, set)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/protocols/internal/commons"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HttpJsonAdapter is the ProtocolRepository of a protocol declared with stats or activity sources.
+// The protocols without an activity source write empty activities.
+type HttpJsonAdapter struct {
+	adapter protocols.Declaration
+	baseURL string
+	client  commons.HttpDo
+	logger  *zap.Logger
+}
+
+func NewHttpJsonAdapter(adapter protocols.Declaration, baseURL string, logger *zap.Logger, httpClient commons.HttpDo) *HttpJsonAdapter {
+	return &HttpJsonAdapter{
+		adapter: adapter,
+		baseURL: baseURL,
+		client:  httpClient,
+		logger:  logger,
+	}
+}
+
+func (a *HttpJsonAdapter) ProtocolName() string {
+	return a.adapter.Name
+}
+
+func (a *HttpJsonAdapter) GetStats(ctx context.Context) (Stats, error) {
+	if a.adapter.Stats == nil {
+		return Stats{}, nil
+	}
+
+	body, err := a.get(ctx, a.adapter.Stats, nil, "stats")
+	if err != nil {
+		return Stats{}, err
+	}
+
+	values, err := mapFields(body, a.adapter.Stats.Fields)
+	if err != nil {
+		return Stats{}, errors.Wrap(err, "failed mapping protocol stats")
+	}
+	return Stats{
+		TotalValueLocked: values[protocols.FieldTotalValueLocked],
+		TotalMessages:    uint64(values[protocols.FieldTotalMessages]),
+		Volume:           values[protocols.FieldVolume],
+	}, nil
+}
+
+func (a *HttpJsonAdapter) GetActivity(ctx context.Context, from, to time.Time) (ProtocolActivity, error) {
+	source := a.adapter.Activity
+	if source == nil {
+		return ProtocolActivity{}, nil
+	}
+
+	params := make(map[string]string, 2)
+	if source.FromParam != "" {
+		params[source.FromParam] = source.FormatTime(from)
+	}
+	if source.ToParam != "" {
+		params[source.ToParam] = source.FormatTime(to)
+	}
+	body, err := a.get(ctx, source, params, "activities")
+	if err != nil {
+		return ProtocolActivity{}, err
+	}
+
+	values, err := mapFields(body, source.Fields)
+	if err != nil {
+		return ProtocolActivity{}, errors.Wrap(err, "failed mapping protocol activities")
+	}
+	result := ProtocolActivity{
+		TotalValueSecure:      values[protocols.FieldTotalValueSecure],
+		TotalValueTransferred: values[protocols.FieldTotalValueTransferred],
+		Volume:                values[protocols.FieldVolume],
+		TotalMessages:         uint64(values[protocols.FieldTotalMessages]),
+	}
+	if source.ActivitiesPath == "" {
+		return result, nil
+	}
+
+	items, ok := lookup(body, source.ActivitiesPath).([]any)
+	if !ok {
+		return ProtocolActivity{}, errors.Errorf("failed mapping protocol activities: %s is not an array", source.ActivitiesPath)
+	}
+	for _, item := range items {
+		act, err := mapFields(item, source.ActivityFields)
+		if err != nil {
+			return ProtocolActivity{}, errors.Wrap(err, "failed mapping protocol activity")
+		}
+		result.Activities = append(result.Activities, Activity{
+			EmitterChainID:     uint64(act[protocols.FieldEmitterChainID]),
+			DestinationChainID: uint64(act[protocols.FieldDestinationChainID]),
+			Txs:                uint64(act[protocols.FieldTxs]),
+			TotalUSD:           act[protocols.FieldTotalUSD],
+		})
+	}
+	return result, nil
+}
+
+// get requests the source and returns the decoded json response.
+func (a *HttpJsonAdapter) get(ctx context.Context, source *protocols.HttpJsonSource, params map[string]string, kind string) (any, error) {
+	decoratedLogger := a.logger
+
+	url := a.baseURL + source.Path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		decoratedLogger.Error("failed creating http request for retrieving protocol "+kind, zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	q := req.URL.Query()
+	for k, v := range source.Query {
+		q.Set(k, v)
+	}
+	for k, v := range params {
+		q.Set(k, v)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	reqId := uuid.New().String()
+	req.Header.Set("X-Request-ID", reqId)
+	decoratedLogger = decoratedLogger.With(zap.String("requestID", reqId))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		decoratedLogger.Error("failed retrieving protocol "+kind, zap.Error(err))
+		return nil, errors.WithStack(err)
+	}
+	defer resp.Body.Close()
+
+	decoratedLogger = decoratedLogger.
+		With(zap.String("status_code", http.StatusText(resp.StatusCode))).
+		With(zap.String("response_headers", commons.ToJson(resp.Header)))
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		decoratedLogger.Error("error retrieving protocol "+kind+": got an invalid response status code", zap.String("response_body", string(body)))
+		return nil, errors.Errorf("failed retrieving protocol %s from url:%s - status_code:%d - response_body:%s", kind, url, resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		decoratedLogger.Error("failed reading response body", zap.Error(err))
+		return nil, errors.Wrapf(errors.WithStack(err), "failed reading response body from protocol %s. url:%s - status_code:%d", kind, url, resp.StatusCode)
+	}
+
+	var result any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		decoratedLogger.Error("failed reading response body", zap.Error(err), zap.String("response_body", string(body)))
+		return nil, errors.Wrapf(errors.WithStack(err), "failed unmarshalling response body from protocol %s. url:%s - status_code:%d - response_body:%s", kind, url, resp.StatusCode, string(body))
+	}
+	return result, nil
+}
+
+// mapFields reads the numeric values of the fields from the paths of the json value.
+// The values can be json numbers or numeric strings, and the missing paths are an error.
+func mapFields(value any, fields map[string]string) (map[string]float64, error) {
+	result := make(map[string]float64, len(fields))
+	for field, path := range fields {
+		v := lookup(value, path)
+		if v == nil {
+			return nil, errors.Errorf("field %s not found at %s", field, path)
+		}
+		n, err := toFloat(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid field %s at %s", field, path)
+		}
+		result[field] = n
+	}
+	return result, nil
+}
+
+// lookup returns the value at the dot-separated path of a decoded json value, or nil if it does not exist.
+func lookup(value any, path string) any {
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = obj[key]
+	}
+	return value
+}
+
+func toFloat(v any) (float64, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case string:
+		return strconv.ParseFloat(n, 64)
+	case float64:
+		return n, nil
+	default:
+		return 0, errors.Errorf("%v is not a number", v)
+	}
+}
@@ -0,0 +1,141 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/protocols"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/protocols/internal/commons/mocks"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/protocols/repository"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fixtureHttpClient replies the recorded response of the testdata file for the path of the request.
+func fixtureHttpClient(t *testing.T, fixtures map[string]string, assertQuery func(path string, query map[string][]string)) mocks.MockHttpClient {
+	return func(req *http.Request) (*http.Response, error) {
+		file, ok := fixtures[req.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request %s", req.URL.String())
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBufferString("not found"))}, nil
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assertQuery != nil {
+			assertQuery(req.URL.Path, req.URL.Query())
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(data))}, nil
+	}
+}
+
+func Test_HttpJsonAdapter_Magpie(t *testing.T) {
+
+	from := time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	client := fixtureHttpClient(t, map[string]string{
+		"/aggregator/stats/wormhole":          "magpie_stats.json",
+		"/aggregator/stats/wormhole/activity": "magpie_activity.json",
+	}, func(path string, query map[string][]string) {
+		if path == "/aggregator/stats/wormhole/activity" {
+			assert.Equal(t, []string{"1718182800"}, query["fromTimestamp"])
+			assert.Equal(t, []string{"1718186400"}, query["toTimestamp"])
+		}
+	})
+	a := repository.NewHttpJsonAdapter(protocols.Magpie, "https://api.magpiefi.xyz", zap.NewNop(), client)
+	assert.Equal(t, "magpie", a.ProtocolName())
+
+	stats, err := a.GetStats(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(48213), stats.TotalMessages)
+	assert.Equal(t, 61249873.42, stats.Volume)
+	assert.Equal(t, float64(0), stats.TotalValueLocked)
+
+	activity, err := a.GetActivity(context.Background(), from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(57), activity.TotalMessages)
+	assert.Equal(t, 84210.55, activity.TotalValueTransferred)
+	assert.Equal(t, 2, len(activity.Activities))
+	assert.Equal(t, repository.Activity{EmitterChainID: 2, DestinationChainID: 30, Txs: 41, TotalUSD: 60100.25}, activity.Activities[0])
+	assert.Equal(t, repository.Activity{EmitterChainID: 4, DestinationChainID: 23, Txs: 16, TotalUSD: 24110.30}, activity.Activities[1])
+}
+
+func Test_HttpJsonAdapter_FolksFinance(t *testing.T) {
+
+	from := time.Date(2024, 6, 12, 9, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	client := fixtureHttpClient(t, map[string]string{
+		"/v1/xchain/stats":    "folks_finance_stats.json",
+		"/v1/xchain/activity": "folks_finance_activity.json",
+	}, func(path string, query map[string][]string) {
+		assert.Equal(t, []string{"wormhole"}, query["adapter"])
+		if path == "/v1/xchain/activity" {
+			assert.Equal(t, []string{"2024-06-12T09:00:00Z"}, query["from"])
+			assert.Equal(t, []string{"2024-06-12T10:00:00Z"}, query["to"])
+		}
+	})
+	a := repository.NewHttpJsonAdapter(protocols.FolksFinance, "https://api.folks.finance", zap.NewNop(), client)
+	assert.Equal(t, "folks_finance", a.ProtocolName())
+
+	stats, err := a.GetStats(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(9215), stats.TotalMessages)
+	assert.Equal(t, 27410032.5, stats.Volume)
+	assert.Equal(t, 18342511.87, stats.TotalValueLocked)
+
+	activity, err := a.GetActivity(context.Background(), from, to)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(9), activity.TotalMessages)
+	assert.Equal(t, 40512.75, activity.TotalValueTransferred)
+	assert.Equal(t, 18342511.87, activity.TotalValueSecure)
+	assert.Equal(t, []repository.Activity{
+		{EmitterChainID: 6, DestinationChainID: 2, Txs: 5, TotalUSD: 22000.5},
+		{EmitterChainID: 30, DestinationChainID: 6, Txs: 4, TotalUSD: 18512.25},
+	}, activity.Activities)
+}
+
+func Test_HttpJsonAdapter_MissingField(t *testing.T) {
+
+	adapter := protocols.Declaration{
+		Name: "test",
+		Stats: &protocols.HttpJsonSource{
+			Path:   "/stats",
+			Fields: map[string]string{protocols.FieldTotalMessages: "data.messages"},
+		},
+	}
+	a := repository.NewHttpJsonAdapter(adapter, "localhost", zap.NewNop(),
+		mocks.MockHttpClient(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"data":{"volume":"12"}}`)),
+			}, nil
+		}))
+	_, err := a.GetStats(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed mapping protocol stats: field total_messages not found at data.messages", err.Error())
+
+	// the protocols without an activity source do not request any activity
+	activity, err := a.GetActivity(context.Background(), time.Now(), time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, repository.ProtocolActivity{}, activity)
+}
+
+func Test_HttpJsonAdapter_Status500(t *testing.T) {
+
+	a := repository.NewHttpJsonAdapter(protocols.Magpie, "localhost", zap.NewNop(),
+		mocks.MockHttpClient(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       io.NopCloser(bytes.NewBufferString("response_body_test")),
+			}, nil
+		}))
+	_, err := a.GetStats(context.Background())
+	assert.NotNil(t, err)
+	assert.Equal(t, "failed retrieving protocol stats from url:localhost/aggregator/stats/wormhole - status_code:500 - response_body:response_body_test", err.Error())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
 	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs/protocols/repository"
 	"go.uber.org/zap"
 	"sync"
@@ -86,6 +87,10 @@ func (s *StatsJob) processProtocol(ctx context.Context, protocolRepo repository.
 		s.logger.Error("failed updating protocol stats in influxdb", zap.Error(errStats), zap.String("protocol", protocolRepo.ProtocolName()))
 	}
 
+	if errAct == nil && errStats == nil {
+		jobs.AddItemsProcessed(ctx, 1)
+	}
+
 }
 
 type protocolData struct {
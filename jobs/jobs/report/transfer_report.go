@@ -7,11 +7,11 @@ import (
 	"math/big"
 	"os"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	"github.com/wormhole-foundation/wormhole-explorer/common/export"
 	"github.com/wormhole-foundation/wormhole-explorer/common/prices"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
@@ -24,6 +24,7 @@ type TransferReportJob struct {
 	pageSize       int64
 	logger         *zap.Logger
 	getPriceByTime GetPriceByTimeFn
+	prefetchPrices PrefetchPricesFn
 	outputPath     string
 	tokenProvider  *domain.TokenProvider
 }
@@ -52,9 +53,12 @@ type transactionResult struct {
 
 type GetPriceByTimeFn func(ctx context.Context, coingeckoID string, day time.Time) (decimal.Decimal, error)
 
+// PrefetchPricesFn loads the prices of a page of transactions in advance. It is optional.
+type PrefetchPricesFn func(ctx context.Context, requests []prices.PriceRequest) error
+
 // NewTransferReportJob creates a new transfer report job.
-func NewTransferReportJob(database *mongo.Database, pageSize int64, getPriceByTime GetPriceByTimeFn, outputPath string, tokenProvider *domain.TokenProvider, logger *zap.Logger) *TransferReportJob {
-	return &TransferReportJob{database: database, pageSize: pageSize, getPriceByTime: getPriceByTime, outputPath: outputPath, tokenProvider: tokenProvider, logger: logger}
+func NewTransferReportJob(database *mongo.Database, pageSize int64, getPriceByTime GetPriceByTimeFn, prefetchPrices PrefetchPricesFn, outputPath string, tokenProvider *domain.TokenProvider, logger *zap.Logger) *TransferReportJob {
+	return &TransferReportJob{database: database, pageSize: pageSize, getPriceByTime: getPriceByTime, prefetchPrices: prefetchPrices, outputPath: outputPath, tokenProvider: tokenProvider, logger: logger}
 }
 
 // Run runs the transfer report job.
@@ -86,6 +90,9 @@ func (j *TransferReportJob) Run(ctx context.Context) error {
 			j.logger.Info("Empty page", zap.Int64("page", page))
 			break
 		}
+
+		j.prefetch(ctx, trxs)
+
 		for _, t := range trxs {
 
 			log := j.logger.With(zap.String("id", t.ID))
@@ -143,6 +150,31 @@ func (j *TransferReportJob) Run(ctx context.Context) error {
 	return nil
 }
 
+// prefetch loads the prices of all the tokens of a page in a single batch.
+func (j *TransferReportJob) prefetch(ctx context.Context, trxs []transactionResult) {
+	if j.prefetchPrices == nil {
+		return
+	}
+	requests := make([]prices.PriceRequest, 0, len(trxs))
+	for _, t := range trxs {
+		if t.TokenAddressHexa == "" {
+			continue
+		}
+		tokenAddress, err := sdk.StringToAddress(t.TokenAddressHexa)
+		if err != nil {
+			continue
+		}
+		m, ok := j.tokenProvider.GetTokenByAddress(sdk.ChainID(t.TokenChain), tokenAddress.String())
+		if !ok || m.CoingeckoID == "" {
+			continue
+		}
+		requests = append(requests, prices.PriceRequest{CoingeckoID: m.CoingeckoID, DateTime: t.Timestamp})
+	}
+	if err := j.prefetchPrices(ctx, requests); err != nil {
+		j.logger.Warn("Failed to prefetch prices", zap.Int("requests", len(requests)), zap.Error(err))
+	}
+}
+
 func (j *TransferReportJob) writeRecord(trx transactionResult, fAmount string, m *domain.TokenMetadata, priceUSD *decimal.Decimal, file *csv.Writer) error {
 	var notionalUSD, decimals, symbol, coingeckoID, tokenAddress string
 	if m != nil {
@@ -159,55 +191,34 @@ func (j *TransferReportJob) writeRecord(trx transactionResult, fAmount string, m
 		tokenAddress, _ = domain.TranslateEmitterAddress(trx.TokenChain, trx.TokenAddressHexa)
 	}
 
-	var record []string
-	record = append(record, trx.ID)
-	record = append(record, trx.VaaHash)
-	record = append(record, chainIDToCsv(trx.SourceChain))
-	record = append(record, trx.EmitterAddress)
-	record = append(record, trx.Sequence)
-	record = append(record, trx.Timestamp.Format(time.RFC3339))
-	record = append(record, trx.SourceTxHash)
-	record = append(record, trx.SourceSenderAddress)
-	record = append(record, chainIDToCsv(trx.DestinationChain))
-	record = append(record, trx.DestinationAddress)
-	record = append(record, trx.DestinationTxHash)
-	record = append(record, portalPayloadTypeToCsv(trx.PortalPayloadType))
-	record = append(record, appIdsToCsv(trx.AppIds))
-	record = append(record, chainIDToCsv(trx.TokenChain))
-	record = append(record, tokenAddress)
-	record = append(record, fAmount)
-	record = append(record, decimals)
-	record = append(record, notionalUSD)
-	record = append(record, trx.Fee)
-	record = append(record, coingeckoID)
-	record = append(record, symbol)
-	return file.Write(record)
+	record := export.TransferRecord{
+		VaaID:               trx.ID,
+		VaaHash:             trx.VaaHash,
+		SourceChain:         trx.SourceChain,
+		EmitterAddress:      trx.EmitterAddress,
+		Sequence:            trx.Sequence,
+		Timestamp:           trx.Timestamp,
+		SourceTxHash:        trx.SourceTxHash,
+		SourceSenderAddress: trx.SourceSenderAddress,
+		DestinationChain:    trx.DestinationChain,
+		DestinationAddress:  trx.DestinationAddress,
+		DestinationTxHash:   trx.DestinationTxHash,
+		PortalPayloadType:   trx.PortalPayloadType,
+		AppIDs:              trx.AppIds,
+		TokenChain:          trx.TokenChain,
+		TokenAddress:        tokenAddress,
+		Amount:              fAmount,
+		Decimals:            decimals,
+		NotionalUSD:         notionalUSD,
+		Fee:                 trx.Fee,
+		CoingeckoID:         coingeckoID,
+		Symbol:              symbol,
+	}
+	return file.Write(record.Values())
 }
 
 func (*TransferReportJob) writeHeader(writer *csv.Writer) error {
-	var record []string
-	record = append(record, "vaaId")
-	record = append(record, "vaaHash")
-	record = append(record, "sourceChain")
-	record = append(record, "emitterAddress")
-	record = append(record, "sequence")
-	record = append(record, "timestamp")
-	record = append(record, "sourceTxHash")
-	record = append(record, "sourceSenderAddress")
-	record = append(record, "destinationChain")
-	record = append(record, "destinationAddress")
-	record = append(record, "destinationTxHash")
-	record = append(record, "portalPayloadType")
-	record = append(record, "appIds")
-	record = append(record, "tokenChain")
-	record = append(record, "tokenAddress")
-	record = append(record, "amount")
-	record = append(record, "decimals")
-	record = append(record, "notionalUSD")
-	record = append(record, "fee")
-	record = append(record, "coinGeckoId")
-	record = append(record, "symbol")
-	return writer.Write(record)
+	return writer.Write(export.TransferHeader())
 }
 
 func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pageSize int64) ([]transactionResult, error) {
@@ -302,25 +313,3 @@ func (j *TransferReportJob) findTransactionsByPage(ctx context.Context, page, pa
 
 	return documents, nil
 }
-
-func chainIDToCsv(chainID sdk.ChainID) string {
-
-	if chainID.String() == sdk.ChainIDUnset.String() {
-		return ""
-	}
-	return fmt.Sprintf("%d", int16(chainID))
-}
-
-func appIdsToCsv(appIds []string) string {
-	if len(appIds) == 0 {
-		return ""
-	}
-	return strings.Join(appIds, "|")
-}
-
-func portalPayloadTypeToCsv(portalPayloadType int) string {
-	if portalPayloadType == 0 {
-		return ""
-	}
-	return fmt.Sprintf("%d", portalPayloadType)
-}
@@ -12,30 +12,33 @@ import (
 
 type NTTMedian struct {
 	nttRepository *stats.NTTRepository
+	symbols       *symbolRunner
 	logger        *zap.Logger
 }
 
 // NewNTTMedian creates a new NTTMedian.
 func NewNTTMedian(influxCli influxdb2.Client, org string, bucketInfiniteRetention string,
-	cacheClient cache.Cache, logger *zap.Logger) *NTTMedian {
+	cacheClient cache.Cache, tokenFinder *stats.NTTTokenFinder, logger *zap.Logger) *NTTMedian {
 	return &NTTMedian{
 		nttRepository: stats.NewNTTRepository(influxCli, org, bucketInfiniteRetention, cacheClient, logger),
-		logger:        logger,
+		symbols: &symbolRunner{
+			stat:             stats.NTTStatMedian,
+			tokenFinder:      tokenFinder,
+			symbolRepository: stats.NewNTTSymbolRepository(cacheClient),
+			logger:           logger,
+		},
+		logger: logger,
 	}
 }
 
-// Run runs the ntt median job.
+// Run runs the ntt median job for every NTT token.
 func (j *NTTMedian) Run(ctx context.Context) error {
 
 	j.logger.Info("running ntt median job")
 
 	// Duration in 0 means no expiration
 	duration := time.Duration(0)
-	err := j.nttRepository.LoadNativeTokenTransferMedian(ctx, "W", duration)
-	if err != nil {
-		j.logger.Error("failed to get ntt median", zap.Error(err))
-		return err
-	}
-
-	return nil
+	return j.symbols.run(ctx, func(ctx context.Context, symbol string) error {
+		return j.nttRepository.LoadNativeTokenTransferMedian(ctx, symbol, duration)
+	})
 }
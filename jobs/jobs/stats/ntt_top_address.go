@@ -2,6 +2,7 @@ package stats
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -12,19 +13,26 @@ import (
 
 type NTTTopAddressJob struct {
 	statsRepositorty *stats.AddressRepository
+	symbols          *symbolRunner
 	logger           *zap.Logger
 }
 
 // NewNTTTopAddressJob creates a new NttTopAddressJob.
 func NewNTTTopAddressJob(influxCli influxdb2.Client, org string, bucketInfiniteRetention string,
-	cacheClient cache.Cache, logger *zap.Logger) *NTTTopAddressJob {
+	cacheClient cache.Cache, tokenFinder *stats.NTTTokenFinder, logger *zap.Logger) *NTTTopAddressJob {
 	return &NTTTopAddressJob{
 		statsRepositorty: stats.NewAddressRepository(influxCli, org, bucketInfiniteRetention, cacheClient, logger),
-		logger:           logger,
+		symbols: &symbolRunner{
+			stat:             stats.NTTStatTopAddress,
+			tokenFinder:      tokenFinder,
+			symbolRepository: stats.NewNTTSymbolRepository(cacheClient),
+			logger:           logger,
+		},
+		logger: logger,
 	}
 }
 
-// Run runs the transfer report job.
+// Run runs the ntt top address job for every NTT token.
 func (j *NTTTopAddressJob) Run(ctx context.Context) error {
 
 	j.logger.Info("running ntt top address job")
@@ -32,17 +40,16 @@ func (j *NTTTopAddressJob) Run(ctx context.Context) error {
 	// Duration in 0 means no expiration
 	duration := time.Duration(0)
 
-	err := j.statsRepositorty.LoadNativeTokenTransferTopAddress(ctx, "W", true, duration)
-	if err != nil {
-		j.logger.Error("failed to get top address by volume", zap.Error(err))
-		return err
-	}
-
-	err = j.statsRepositorty.LoadNativeTokenTransferTopAddress(ctx, "W", false, duration)
-	if err != nil {
-		j.logger.Error("failed to get top address by count", zap.Error(err))
-		return err
-	}
-
-	return nil
+	return j.symbols.run(ctx, func(ctx context.Context, symbol string) error {
+		err := j.statsRepositorty.LoadNativeTokenTransferTopAddress(ctx, symbol, true, duration)
+		if err != nil {
+			return fmt.Errorf("failed to get top address by volume: %w", err)
+		}
+
+		err = j.statsRepositorty.LoadNativeTokenTransferTopAddress(ctx, symbol, false, duration)
+		if err != nil {
+			return fmt.Errorf("failed to get top address by count: %w", err)
+		}
+		return nil
+	})
 }
@@ -2,41 +2,47 @@ package stats
 
 import (
 	"context"
+	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
-	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
-	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/stats"
 	"go.uber.org/zap"
 )
 
+// topHolderLoader computes the top holders of a symbol and stores them in the cache.
+// It is implemented by stats.HolderBalanceRepository and stats.HolderRepository.
+type topHolderLoader interface {
+	LoadNativeTokenTransferTopHolder(ctx context.Context, symbol string, expiration time.Duration) error
+}
+
 type NTTTopHolderJob struct {
-	repository *stats.HolderRepository
+	repository topHolderLoader
+	symbols    *symbolRunner
 	log        *zap.Logger
 }
 
-func NewNTTTopHolderJob(c *resty.Client,
-	arkhamUrl, arkhamApiKey, solanaUrl string,
+func NewNTTTopHolderJob(repository topHolderLoader,
 	cacheClient cache.Cache,
-	tokenProvider *domain.TokenProvider,
-	notionalCache notional.NotionalLocalCacheReadable,
+	tokenFinder *stats.NTTTokenFinder,
 	log *zap.Logger) *NTTTopHolderJob {
 	return &NTTTopHolderJob{
-		repository: stats.NewHolderRepository(c, arkhamUrl, arkhamApiKey, solanaUrl, cacheClient, tokenProvider, notionalCache, log),
-		log:        log,
+		repository: repository,
+		symbols: &symbolRunner{
+			stat:             stats.NTTStatTopHolder,
+			tokenFinder:      tokenFinder,
+			symbolRepository: stats.NewNTTSymbolRepository(cacheClient),
+			logger:           log,
+		},
+		log: log,
 	}
 }
 
+// Run runs the ntt top holder job for every NTT token.
 func (j *NTTTopHolderJob) Run(ctx context.Context) error {
 	j.log.Info("running ntt top holder job")
 
-	// Duration in 0 means no expiration
-	err := j.repository.LoadNativeTokenTransferTopHolder(ctx, "W", 0)
-	if err != nil {
-		j.log.Error("failed to get top holder", zap.Error(err))
-		return err
-	}
-
-	return nil
+	return j.symbols.run(ctx, func(ctx context.Context, symbol string) error {
+		// Duration in 0 means no expiration
+		return j.repository.LoadNativeTokenTransferTopHolder(ctx, symbol, 0)
+	})
 }
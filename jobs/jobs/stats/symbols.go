@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.uber.org/zap"
+)
+
+// symbolFinder finds the symbols of the NTT tokens, it is implemented by stats.NTTTokenFinder.
+type symbolFinder interface {
+	FindSymbols(ctx context.Context) ([]string, error)
+}
+
+// symbolStore stores the symbols whose NTT stat is available, it is implemented by stats.NTTSymbolRepository.
+type symbolStore interface {
+	GetSymbols(ctx context.Context, stat string) ([]string, error)
+	SetSymbols(ctx context.Context, stat string, symbols []string) error
+}
+
+// symbolRunner computes a NTT stat for every NTT token.
+type symbolRunner struct {
+	stat             string
+	tokenFinder      symbolFinder
+	symbolRepository symbolStore
+	logger           *zap.Logger
+}
+
+// run calls load for every symbol of the NTT tokens, and stores the symbols whose stat is available.
+// The symbols that fail keep the stat computed in a previous run, if any.
+func (r *symbolRunner) run(ctx context.Context, load func(ctx context.Context, symbol string) error) error {
+	symbols, err := r.tokenFinder.FindSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]bool)
+	if prevSymbols, err := r.symbolRepository.GetSymbols(ctx, r.stat); err == nil {
+		for _, symbol := range prevSymbols {
+			previous[symbol] = true
+		}
+	}
+
+	available := make([]string, 0, len(symbols))
+	var failed int
+	for _, symbol := range symbols {
+		if err := load(ctx, symbol); err != nil {
+			r.logger.Error("failed to compute ntt stat", zap.String("stat", r.stat), zap.String("symbol", symbol), zap.Error(err))
+			failed++
+			if previous[symbol] {
+				available = append(available, symbol)
+			}
+			continue
+		}
+		available = append(available, symbol)
+		jobs.AddItemsProcessed(ctx, 1)
+	}
+
+	if err := r.symbolRepository.SetSymbols(ctx, r.stat, available); err != nil {
+		return fmt.Errorf("failed to store ntt %s symbols: %w", r.stat, err)
+	}
+
+	r.logger.Info("computed ntt stat", zap.String("stat", r.stat), zap.Int("symbols", len(symbols)), zap.Int("failed", failed))
+	if failed > 0 && failed == len(symbols) {
+		return fmt.Errorf("failed to compute ntt %s for every symbol", r.stat)
+	}
+	return nil
+}
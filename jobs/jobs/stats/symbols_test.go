@@ -0,0 +1,84 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache"
+	"github.com/wormhole-foundation/wormhole-explorer/jobs/jobs"
+	"go.uber.org/zap"
+)
+
+type stubSymbolFinder struct {
+	symbols []string
+	err     error
+}
+
+func (f *stubSymbolFinder) FindSymbols(context.Context) ([]string, error) {
+	return f.symbols, f.err
+}
+
+// memorySymbolStore stores the symbols of each stat in memory.
+type memorySymbolStore map[string][]string
+
+func (s memorySymbolStore) GetSymbols(_ context.Context, stat string) ([]string, error) {
+	symbols, ok := s[stat]
+	if !ok {
+		return nil, cache.ErrNotFound
+	}
+	return symbols, nil
+}
+
+func (s memorySymbolStore) SetSymbols(_ context.Context, stat string, symbols []string) error {
+	s[stat] = symbols
+	return nil
+}
+
+func newTestSymbolRunner(finder symbolFinder, store symbolStore) *symbolRunner {
+	return &symbolRunner{stat: "median", tokenFinder: finder, symbolRepository: store, logger: zap.NewNop()}
+}
+
+// failSymbols returns a load function that fails for the given symbols.
+func failSymbols(symbols ...string) func(ctx context.Context, symbol string) error {
+	return func(_ context.Context, symbol string) error {
+		for _, s := range symbols {
+			if s == symbol {
+				return errors.New("load failed")
+			}
+		}
+		return nil
+	}
+}
+
+func TestSymbolRunner_StoresAvailableSymbols(t *testing.T) {
+	store := memorySymbolStore{"median": {"USDC", "W"}}
+	runner := newTestSymbolRunner(&stubSymbolFinder{symbols: []string{"NEW", "USDC", "W"}}, store)
+
+	ctx, processed := jobs.WithItemsCounter(context.Background())
+	err := runner.run(ctx, failSymbols("NEW", "W"))
+	assert.NoError(t, err)
+
+	// the failed symbols keep the stat of the previous run, the new ones are not available
+	assert.Equal(t, []string{"USDC", "W"}, store["median"])
+	assert.Equal(t, int64(1), processed.Load())
+}
+
+func TestSymbolRunner_FailsWhenEverySymbolFails(t *testing.T) {
+	store := memorySymbolStore{}
+	runner := newTestSymbolRunner(&stubSymbolFinder{symbols: []string{"USDC", "W"}}, store)
+
+	err := runner.run(context.Background(), failSymbols("USDC", "W"))
+	assert.Error(t, err)
+	assert.Equal(t, []string{}, store["median"])
+}
+
+func TestSymbolRunner_FinderError(t *testing.T) {
+	store := memorySymbolStore{"median": {"USDC"}}
+	runner := newTestSymbolRunner(&stubSymbolFinder{err: errors.New("unavailable")}, store)
+
+	err := runner.run(context.Background(), failSymbols())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"USDC"}, store["median"])
+}
@@ -73,6 +73,9 @@ func Run() {
 
 	//create repositories
 	repository := prices.NewPriceRepository(db.Database, logger)
+	if err := repository.CreateIndexes(rootCtx); err != nil {
+		logger.Fatal("failed to create prices indexes", zap.Error(err))
+	}
 
 	//create services
 	priceService := prices.NewPriceService(repository, tokenProvider, notionalCache, logger)
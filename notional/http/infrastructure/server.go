@@ -39,8 +39,13 @@ func NewServer(logger *zap.Logger, port string, pprofEnabled bool, priceControll
 
 	// coingecko prices resource
 	coingeckoPrices := api.Group("/coingecko/prices")
+	coingeckoPrices.Get("/:coingeckoId/range", priceController.FindRangeByCoingeckoID)
+	coingeckoPrices.Get("/:coingeckoId/ohlc", priceController.FindOHLCByCoingeckoID)
 	coingeckoPrices.Get("/:coingeckoId/:datetime", priceController.FindByCoingeckoID)
 
+	// batch prices resource
+	api.Post("/prices/batch", priceController.FindBatch)
+
 	return &Server{
 		app:             app,
 		port:            port,
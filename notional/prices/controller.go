@@ -2,6 +2,7 @@ package prices
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -64,6 +65,144 @@ func (c *Controller) FindByCoingeckoID(ctx *fiber.Ctx) error {
 	return c.handleResponse(err, ctx, price)
 }
 
+// maxBatchSize is the maximum number of token and datetime combinations in a batch request.
+const maxBatchSize = 1000
+
+type batchTokenRequest struct {
+	CoingeckoID  string `json:"coingeckoId"`
+	TokenChainID *int   `json:"tokenChainId"`
+	TokenAddress string `json:"tokenAddress"`
+}
+
+type batchQueryRequest struct {
+	batchTokenRequest
+	Datetime time.Time `json:"dateTime"`
+}
+
+// batchRequest is the body of a batch price request. It accepts explicit token and datetime
+// pairs in queries, and/or every combination of tokens and dateTimes.
+type batchRequest struct {
+	Queries   []batchQueryRequest `json:"queries"`
+	Tokens    []batchTokenRequest `json:"tokens"`
+	Datetimes []time.Time         `json:"dateTimes"`
+}
+
+type batchResponse struct {
+	Prices []BatchPrice `json:"prices"`
+}
+
+// FindBatch returns the prices of many tokens at many datetimes.
+func (c *Controller) FindBatch(ctx *fiber.Ctx) error {
+	var req batchRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid request body")
+	}
+
+	size := len(req.Queries) + len(req.Tokens)*len(req.Datetimes)
+	if size == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "queries or tokens and dateTimes are required")
+	}
+	if size > maxBatchSize {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("batch exceeds %d prices", maxBatchSize))
+	}
+
+	queries := make([]PriceQuery, 0, size)
+	for _, q := range req.Queries {
+		token, err := toTokenQuery(q.batchTokenRequest)
+		if err != nil {
+			return err
+		}
+		queries = append(queries, PriceQuery{Token: token, Datetime: q.Datetime})
+	}
+	for _, t := range req.Tokens {
+		token, err := toTokenQuery(t)
+		if err != nil {
+			return err
+		}
+		for _, dt := range req.Datetimes {
+			queries = append(queries, PriceQuery{Token: token, Datetime: dt})
+		}
+	}
+
+	now := time.Now()
+	for _, q := range queries {
+		if q.Datetime.IsZero() {
+			return fiber.NewError(fiber.StatusBadRequest, "missing dateTime")
+		}
+		if q.Datetime.After(now) {
+			return fiber.NewError(fiber.StatusBadRequest, "datetime must be in the past")
+		}
+	}
+
+	prices, err := c.priceService.GetPrices(ctx.Context(), queries)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(batchResponse{Prices: prices})
+}
+
+// FindRangeByCoingeckoID returns a price series of a token for a datetime range.
+func (c *Controller) FindRangeByCoingeckoID(ctx *fiber.Ctx) error {
+	coingeckoID, err := extractCoingeckoID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := extractTimeRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	interval, err := ParseInterval(ctx.Query("interval", string(IntervalDay)), IntervalHour, IntervalDay)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid interval parameter, must be one of 1h, 1d")
+	}
+
+	series, err := c.priceService.GetPriceRange(ctx.Context(), coingeckoID, *from, *to, interval)
+	if err != nil {
+		return c.handleError(err)
+	}
+
+	return ctx.JSON(series)
+}
+
+// FindOHLCByCoingeckoID returns the open, high, low and close price of a token per interval.
+func (c *Controller) FindOHLCByCoingeckoID(ctx *fiber.Ctx) error {
+	coingeckoID, err := extractCoingeckoID(ctx, c.logger)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := extractTimeRange(ctx)
+	if err != nil {
+		return err
+	}
+
+	interval, err := ParseInterval(ctx.Query("interval", string(IntervalDay)), IntervalDay, IntervalWeek, IntervalMonth)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "invalid interval parameter, must be one of 1d, 1w, 1M")
+	}
+
+	ohlc, err := c.priceService.GetOHLC(ctx.Context(), coingeckoID, *from, *to, interval)
+	if err != nil {
+		return c.handleError(err)
+	}
+
+	return ctx.JSON(ohlc)
+}
+
+func (*Controller) handleError(err error) error {
+	switch {
+	case errors.Is(err, ErrTokenNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "token not found")
+	case errors.Is(err, ErrTooManyPoints):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		return err
+	}
+}
+
 func (*Controller) handleResponse(err error, ctx *fiber.Ctx, price *Price) error {
 	if err != nil {
 		if errors.Is(err, ErrTokenNotFound) {
@@ -127,3 +266,42 @@ func extractDatetime(c *fiber.Ctx, l *zap.Logger) (*time.Time, error) {
 	}
 	return &v, nil
 }
+
+func extractTimeRange(c *fiber.Ctx) (*time.Time, *time.Time, error) {
+	fromParam, toParam := c.Query("from"), c.Query("to")
+	if fromParam == "" {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "missing from parameter")
+	}
+	from, err := time.Parse(time.RFC3339, fromParam)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "invalid from parameter")
+	}
+	to := time.Now()
+	if toParam != "" {
+		to, err = time.Parse(time.RFC3339, toParam)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "invalid to parameter")
+		}
+	}
+	if from.After(to) {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "from must be before to")
+	}
+	return &from, &to, nil
+}
+
+func toTokenQuery(t batchTokenRequest) (TokenQuery, error) {
+	if t.CoingeckoID != "" {
+		return TokenQuery{CoingeckoID: t.CoingeckoID}, nil
+	}
+	if t.TokenChainID == nil || t.TokenAddress == "" {
+		return TokenQuery{}, fiber.NewError(fiber.StatusBadRequest, "each token requires a coingeckoId or a tokenChainId and tokenAddress")
+	}
+	tokenAddress, err := types.StringToAddress(t.TokenAddress, true)
+	if err != nil {
+		return TokenQuery{}, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("invalid token address %s", t.TokenAddress))
+	}
+	return TokenQuery{
+		TokenChainID: sdk.ChainID(*t.TokenChainID),
+		TokenAddress: tokenAddress.Hex(),
+	}, nil
+}
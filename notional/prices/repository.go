@@ -21,6 +21,12 @@ type PriceDb struct {
 	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
 }
 
+// PriceKey identifies a stored price.
+type PriceKey struct {
+	CoingeckoID string
+	Datetime    time.Time
+}
+
 type PriceRepository struct {
 	db         *mongo.Database
 	collection *mongo.Collection
@@ -75,6 +81,75 @@ func (p *PriceRepository) Find(ctx context.Context, coingeckoID string, dateTime
 	return &price, err
 }
 
+// FindMany finds the prices that match the given keys in a single query.
+func (p *PriceRepository) FindMany(ctx context.Context, keys []PriceKey) ([]PriceDb, error) {
+	if len(keys) == 0 {
+		return []PriceDb{}, nil
+	}
+	ids := make([]string, 0, len(keys))
+	for _, k := range keys {
+		ids = append(ids, p.createID(k.CoingeckoID, k.Datetime))
+	}
+	cur, err := p.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	var prices []PriceDb
+	if err := cur.All(ctx, &prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// FindByRange finds the prices of a coingecko id between from (inclusive) and to (inclusive)
+// sorted by datetime ascending.
+func (p *PriceRepository) FindByRange(ctx context.Context, coingeckoID string, from, to time.Time) ([]PriceDb, error) {
+	filter := bson.M{
+		"coingeckoId": coingeckoID,
+		"dateTime":    bson.M{"$gte": from.UTC(), "$lte": to.UTC()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "dateTime", Value: 1}})
+	cur, err := p.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var prices []PriceDb
+	if err := cur.All(ctx, &prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// FindLastBefore finds the most recent price of a coingecko id at or before the given datetime.
+func (p *PriceRepository) FindLastBefore(ctx context.Context, coingeckoID string, dateTime time.Time) (*PriceDb, error) {
+	filter := bson.M{
+		"coingeckoId": coingeckoID,
+		"dateTime":    bson.M{"$lte": dateTime.UTC()},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "dateTime", Value: -1}})
+	var price PriceDb
+	err := p.collection.FindOne(ctx, filter, opts).Decode(&price)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrPriceNotFound
+		}
+		return nil, err
+	}
+	return &price, nil
+}
+
+// CreateIndexes creates the indexes used by the range queries.
+func (p *PriceRepository) CreateIndexes(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "coingeckoId", Value: 1},
+			{Key: "dateTime", Value: 1},
+		},
+	}
+	_, err := p.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
 func (p *PriceRepository) createID(coingeckoID string, dateTime time.Time) string {
 	return fmt.Sprintf("%s-%s", coingeckoID, dateTime.UTC().Format(time.RFC3339))
 }
@@ -0,0 +1,159 @@
+package prices
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Interval is the granularity of a price series.
+type Interval string
+
+const (
+	IntervalHour  Interval = "1h"
+	IntervalDay   Interval = "1d"
+	IntervalWeek  Interval = "1w"
+	IntervalMonth Interval = "1M"
+)
+
+// maxSeriesPoints is the maximum number of points returned by a range or ohlc query.
+const maxSeriesPoints = 5000
+
+var (
+	ErrInvalidInterval = errors.New("invalid interval")
+	ErrTooManyPoints   = errors.New("too many points for the requested range")
+)
+
+// ParseInterval parses an interval and checks it is one of the supported values.
+func ParseInterval(s string, allowed ...Interval) (Interval, error) {
+	for _, i := range allowed {
+		if string(i) == s {
+			return i, nil
+		}
+	}
+	return "", ErrInvalidInterval
+}
+
+// bucketStart returns the start of the interval bucket that contains t.
+func (i Interval) bucketStart(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case IntervalHour:
+		return t.Truncate(time.Hour)
+	case IntervalWeek:
+		// the zero time is a monday, so weeks start on monday.
+		return t.Truncate(7 * 24 * time.Hour)
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return t.Truncate(24 * time.Hour)
+	}
+}
+
+// next returns the start of the bucket following the one that starts at t.
+func (i Interval) next(t time.Time) time.Time {
+	switch i {
+	case IntervalHour:
+		return t.Add(time.Hour)
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// pricePoint is a price observed at a given datetime.
+type pricePoint struct {
+	price    decimal.Decimal
+	datetime time.Time
+}
+
+// SeriesPoint is a point in a price series.
+type SeriesPoint struct {
+	Datetime       time.Time `json:"dateTime"`
+	Price          string    `json:"price"`
+	SourceDatetime time.Time `json:"sourceDateTime"`
+}
+
+// OHLC is the open, high, low and close price of an interval.
+type OHLC struct {
+	Datetime time.Time `json:"dateTime"`
+	Open     string    `json:"open"`
+	High     string    `json:"high"`
+	Low      string    `json:"low"`
+	Close    string    `json:"close"`
+	Samples  int       `json:"samples"`
+}
+
+// buildSeries returns one point per bucket between from and to, using the last known
+// price at or before the start of each bucket. points must be sorted by datetime.
+func buildSeries(points []pricePoint, from, to time.Time, interval Interval) ([]SeriesPoint, error) {
+	series := []SeriesPoint{}
+	idx := -1
+	for t := interval.bucketStart(from); !t.After(to); t = interval.next(t) {
+		for idx+1 < len(points) && !points[idx+1].datetime.After(t) {
+			idx++
+		}
+		if idx < 0 {
+			continue
+		}
+		if len(series) >= maxSeriesPoints {
+			return nil, ErrTooManyPoints
+		}
+		series = append(series, SeriesPoint{
+			Datetime:       t,
+			Price:          points[idx].price.String(),
+			SourceDatetime: points[idx].datetime,
+		})
+	}
+	return series, nil
+}
+
+// aggregateOHLC groups the points into interval buckets and computes the open, high, low
+// and close price of each bucket. points must be sorted by datetime.
+func aggregateOHLC(points []pricePoint, interval Interval) ([]OHLC, error) {
+	result := []OHLC{}
+	var (
+		current                                    time.Time
+		openPrice, highPrice, lowPrice, closePrice decimal.Decimal
+		samples                                    int
+	)
+	flush := func() {
+		if samples == 0 {
+			return
+		}
+		result = append(result, OHLC{
+			Datetime: current,
+			Open:     openPrice.String(),
+			High:     highPrice.String(),
+			Low:      lowPrice.String(),
+			Close:    closePrice.String(),
+			Samples:  samples,
+		})
+	}
+	for _, p := range points {
+		bucket := interval.bucketStart(p.datetime)
+		if samples == 0 || !bucket.Equal(current) {
+			flush()
+			if len(result) >= maxSeriesPoints {
+				return nil, ErrTooManyPoints
+			}
+			current = bucket
+			openPrice, highPrice, lowPrice = p.price, p.price, p.price
+			samples = 0
+		}
+		if p.price.GreaterThan(highPrice) {
+			highPrice = p.price
+		}
+		if p.price.LessThan(lowPrice) {
+			lowPrice = p.price
+		}
+		closePrice = p.price
+		samples++
+	}
+	flush()
+	return result, nil
+}
@@ -0,0 +1,123 @@
+package prices
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func newPoint(price string, datetime string) pricePoint {
+	t, _ := time.Parse(time.RFC3339, datetime)
+	return pricePoint{price: decimal.RequireFromString(price), datetime: t}
+}
+
+func TestBuildSeries_ForwardFillsDailyPrices(t *testing.T) {
+	points := []pricePoint{
+		newPoint("1.5", "2024-01-01T00:00:00Z"),
+		newPoint("2", "2024-01-02T00:00:00Z"),
+	}
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T22:30:00Z")
+	to, _ := time.Parse(time.RFC3339, "2024-01-02T01:00:00Z")
+
+	series, err := buildSeries(points, from, to, IntervalHour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"1.5", "1.5", "2", "2"}
+	if len(series) != len(expected) {
+		t.Fatalf("expected %d points, got %d", len(expected), len(series))
+	}
+	for i, p := range series {
+		if p.Price != expected[i] {
+			t.Errorf("point %d: expected price %s, got %s", i, expected[i], p.Price)
+		}
+	}
+	if !series[0].Datetime.Equal(from.Truncate(time.Hour)) {
+		t.Errorf("expected first point at %s, got %s", from.Truncate(time.Hour), series[0].Datetime)
+	}
+}
+
+func TestBuildSeries_SkipsBucketsWithoutPrice(t *testing.T) {
+	points := []pricePoint{newPoint("3", "2024-01-03T00:00:00Z")}
+	from, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2024-01-04T00:00:00Z")
+
+	series, err := buildSeries(points, from, to, IntervalDay)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(series))
+	}
+}
+
+func TestBuildSeries_TooManyPoints(t *testing.T) {
+	points := []pricePoint{newPoint("1", "2000-01-01T00:00:00Z")}
+	from, _ := time.Parse(time.RFC3339, "2000-01-01T00:00:00Z")
+	to, _ := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+
+	if _, err := buildSeries(points, from, to, IntervalHour); err != ErrTooManyPoints {
+		t.Fatalf("expected ErrTooManyPoints, got %v", err)
+	}
+}
+
+func TestAggregateOHLC(t *testing.T) {
+	points := []pricePoint{
+		newPoint("10", "2024-01-01T00:00:00Z"), // monday
+		newPoint("12", "2024-01-02T00:00:00Z"),
+		newPoint("8", "2024-01-03T00:00:00Z"),
+		newPoint("9", "2024-01-07T00:00:00Z"),
+		newPoint("20", "2024-01-08T00:00:00Z"), // next monday
+	}
+
+	ohlc, err := aggregateOHLC(points, IntervalWeek)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ohlc) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(ohlc))
+	}
+
+	first := ohlc[0]
+	if first.Open != "10" || first.High != "12" || first.Low != "8" || first.Close != "9" || first.Samples != 4 {
+		t.Errorf("unexpected first bucket: %+v", first)
+	}
+	if first.Datetime.Format(time.RFC3339) != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected first bucket datetime: %s", first.Datetime)
+	}
+
+	second := ohlc[1]
+	if second.Open != "20" || second.Close != "20" || second.Samples != 1 {
+		t.Errorf("unexpected second bucket: %+v", second)
+	}
+}
+
+func TestAggregateOHLC_Month(t *testing.T) {
+	points := []pricePoint{
+		newPoint("1", "2024-01-31T00:00:00Z"),
+		newPoint("2", "2024-02-01T00:00:00Z"),
+	}
+
+	ohlc, err := aggregateOHLC(points, IntervalMonth)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ohlc) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(ohlc))
+	}
+	if ohlc[1].Datetime.Format(time.RFC3339) != "2024-02-01T00:00:00Z" {
+		t.Errorf("unexpected second bucket datetime: %s", ohlc[1].Datetime)
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	if _, err := ParseInterval("1w", IntervalHour, IntervalDay); err != ErrInvalidInterval {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
+	}
+	i, err := ParseInterval("1h", IntervalHour, IntervalDay)
+	if err != nil || i != IntervalHour {
+		t.Errorf("expected 1h, got %s %v", i, err)
+	}
+}
@@ -106,11 +106,9 @@ func (s *PriceService) GetPriceBySymbol(ctx context.Context, token *domain.Token
 		return nil, err
 	}
 
-	diffCachePrice := cachePrice.UpdatedAt.Sub(datetime).Abs()
-	diffDayPrice := dayDatetime.Sub(datetime).Abs()
 	var price decimal.Decimal
 	var priceDatetime time.Time
-	if diffCachePrice > diffDayPrice {
+	if !useCachePrice(cachePrice.UpdatedAt, datetime) {
 		p, err := s.priceRepository.Find(ctx, token.CoingeckoID, dayDatetime)
 		if err != nil {
 			if err == ErrPriceNotFound {
@@ -131,3 +129,223 @@ func (s *PriceService) GetPriceBySymbol(ctx context.Context, token *domain.Token
 	}
 	return &priceSymbol{price: price, datetime: priceDatetime}, nil
 }
+
+// useCachePrice returns true when the current cached price is closer to datetime than
+// the daily price stored in the database.
+func useCachePrice(cacheUpdatedAt, datetime time.Time) bool {
+	diffCachePrice := cacheUpdatedAt.Sub(datetime).Abs()
+	diffDayPrice := datetime.Truncate(24 * time.Hour).Sub(datetime).Abs()
+	return diffCachePrice <= diffDayPrice
+}
+
+// TokenQuery identifies a token either by coingecko id or by token chain and address.
+type TokenQuery struct {
+	CoingeckoID  string
+	TokenChainID sdk.ChainID
+	TokenAddress string
+}
+
+// BatchPrice is the result of a price lookup for a token at a datetime inside a batch.
+type BatchPrice struct {
+	CoingeckoID       string    `json:"coingeckoId,omitempty"`
+	TokenChainID      *uint16   `json:"tokenChainId,omitempty"`
+	TokenAddress      string    `json:"tokenAddress,omitempty"`
+	Symbol            string    `json:"symbol,omitempty"`
+	RequestedDatetime time.Time `json:"requestedDateTime"`
+	Price             string    `json:"price,omitempty"`
+	Datetime          time.Time `json:"dateTime,omitempty"`
+	Error             string    `json:"error,omitempty"`
+}
+
+// PriceQuery is a lookup of a token price at a datetime.
+type PriceQuery struct {
+	Token    TokenQuery
+	Datetime time.Time
+}
+
+// GetPrices returns the price of each query.
+// Prices are resolved with a single database query, results that can not be resolved
+// are returned with an error message instead of failing the whole batch.
+func (s *PriceService) GetPrices(ctx context.Context, queries []PriceQuery) ([]BatchPrice, error) {
+
+	type resolvedToken struct {
+		token      *domain.TokenMetadata
+		cachePrice wormscanNotionalCache.PriceData
+		err        error
+	}
+
+	results := make([]BatchPrice, len(queries))
+	resolved := make(map[TokenQuery]resolvedToken)
+	pendings := make(map[int]PriceKey)
+	var keys []PriceKey
+
+	for i, q := range queries {
+		r, ok := resolved[q.Token]
+		if !ok {
+			token, found := s.resolveToken(q.Token)
+			if !found || token.CoingeckoID == "" {
+				r = resolvedToken{err: ErrTokenNotFound}
+			} else {
+				cachePrice, err := s.notionalCache.Get(token.GetTokenID())
+				if err == wormscanNotionalCache.ErrNotFound {
+					err = ErrTokenNotFound
+				} else if err != nil {
+					return nil, err
+				}
+				r = resolvedToken{token: token, cachePrice: cachePrice, err: err}
+			}
+			resolved[q.Token] = r
+		}
+
+		results[i] = newBatchPrice(q.Token, r.token, q.Datetime, r.err)
+		if r.err != nil {
+			continue
+		}
+		if useCachePrice(r.cachePrice.UpdatedAt, q.Datetime) {
+			results[i].Price = r.cachePrice.NotionalUsd.String()
+			results[i].Datetime = r.cachePrice.UpdatedAt
+			continue
+		}
+		key := PriceKey{CoingeckoID: r.token.CoingeckoID, Datetime: q.Datetime.Truncate(24 * time.Hour).UTC()}
+		keys = append(keys, key)
+		pendings[i] = key
+	}
+
+	if len(keys) == 0 {
+		return results, nil
+	}
+
+	stored, err := s.priceRepository.FindMany(ctx, keys)
+	if err != nil {
+		s.logger.Error("Failed to find prices", zap.Int("keys", len(keys)), zap.Error(err))
+		return nil, err
+	}
+	byKey := make(map[PriceKey]PriceDb, len(stored))
+	for _, p := range stored {
+		byKey[PriceKey{CoingeckoID: p.CoingeckoID, Datetime: p.Datetime.UTC()}] = p
+	}
+
+	for i, key := range pendings {
+		p, ok := byKey[key]
+		if !ok {
+			results[i].Error = ErrTokenNotFound.Error()
+			continue
+		}
+		price, err := decimal.NewFromString(p.Price)
+		if err != nil {
+			s.logger.Error("Failed to parse price", zap.String("id", p.ID), zap.Error(err))
+			results[i].Error = "invalid price"
+			continue
+		}
+		results[i].Price = price.String()
+		results[i].Datetime = p.Datetime
+	}
+
+	return results, nil
+}
+
+// GetPriceRange returns a price series of a token between from and to with one point per interval.
+func (s *PriceService) GetPriceRange(ctx context.Context, coingeckoID string, from, to time.Time, interval Interval) ([]SeriesPoint, error) {
+	points, err := s.getPricePoints(ctx, coingeckoID, from, to, true)
+	if err != nil {
+		return nil, err
+	}
+	return buildSeries(points, from, to, interval)
+}
+
+// GetOHLC returns the open, high, low and close price of a token per interval between from and to.
+func (s *PriceService) GetOHLC(ctx context.Context, coingeckoID string, from, to time.Time, interval Interval) ([]OHLC, error) {
+	points, err := s.getPricePoints(ctx, coingeckoID, interval.bucketStart(from), to, false)
+	if err != nil {
+		return nil, err
+	}
+	return aggregateOHLC(points, interval)
+}
+
+// getPricePoints returns the stored prices of a token between from and to, plus the current cached
+// price when it falls in the range. If withPrevious is true, the last price before from is also included.
+func (s *PriceService) getPricePoints(ctx context.Context, coingeckoID string, from, to time.Time, withPrevious bool) ([]pricePoint, error) {
+	log := s.logger.With(zap.String("coingeckoId", coingeckoID))
+	token, found := s.tokenProvider.GetTokenByCoingeckoID(coingeckoID)
+	if !found {
+		log.Warn("Token not found")
+		return nil, ErrTokenNotFound
+	}
+
+	var points []pricePoint
+	if withPrevious {
+		previous, err := s.priceRepository.FindLastBefore(ctx, coingeckoID, from)
+		if err != nil && err != ErrPriceNotFound {
+			log.Error("Failed to find previous price", zap.Error(err))
+			return nil, err
+		}
+		if previous != nil {
+			if p, err := toPricePoint(*previous); err == nil {
+				points = append(points, p)
+			}
+		}
+	}
+
+	stored, err := s.priceRepository.FindByRange(ctx, coingeckoID, from, to)
+	if err != nil {
+		log.Error("Failed to find prices", zap.Error(err))
+		return nil, err
+	}
+	for _, sp := range stored {
+		p, err := toPricePoint(sp)
+		if err != nil {
+			log.Error("Failed to parse price", zap.String("id", sp.ID), zap.Error(err))
+			continue
+		}
+		if len(points) > 0 && p.datetime.Equal(points[len(points)-1].datetime) {
+			continue
+		}
+		points = append(points, p)
+	}
+
+	cachePrice, err := s.notionalCache.Get(token.GetTokenID())
+	if err == nil && !cachePrice.UpdatedAt.Before(from) && !cachePrice.UpdatedAt.After(to) &&
+		(len(points) == 0 || cachePrice.UpdatedAt.After(points[len(points)-1].datetime)) {
+		points = append(points, pricePoint{price: cachePrice.NotionalUsd, datetime: cachePrice.UpdatedAt})
+	}
+
+	if len(points) == 0 {
+		return nil, ErrTokenNotFound
+	}
+	return points, nil
+}
+
+func (s *PriceService) resolveToken(q TokenQuery) (*domain.TokenMetadata, bool) {
+	if q.CoingeckoID != "" {
+		return s.tokenProvider.GetTokenByCoingeckoID(q.CoingeckoID)
+	}
+	return s.tokenProvider.GetTokenByAddress(q.TokenChainID, q.TokenAddress)
+}
+
+func newBatchPrice(q TokenQuery, token *domain.TokenMetadata, datetime time.Time, err error) BatchPrice {
+	r := BatchPrice{
+		CoingeckoID:       q.CoingeckoID,
+		TokenAddress:      q.TokenAddress,
+		RequestedDatetime: datetime,
+	}
+	if q.CoingeckoID == "" {
+		chainID := uint16(q.TokenChainID)
+		r.TokenChainID = &chainID
+	}
+	if token != nil {
+		r.CoingeckoID = token.CoingeckoID
+		r.Symbol = token.Symbol.String()
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	return r
+}
+
+func toPricePoint(p PriceDb) (pricePoint, error) {
+	price, err := decimal.NewFromString(p.Price)
+	if err != nil {
+		return pricePoint{}, err
+	}
+	return pricePoint{price: price, datetime: p.Datetime.UTC()}, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -11,12 +12,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	vaaPayloadParser "github.com/wormhole-foundation/wormhole-explorer/common/client/parser"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/config"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/consumer"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/http/infrastructure"
@@ -57,39 +60,81 @@ func Run() {
 
 	logger.Info("Starting wormhole-explorer-parser ...")
 
+	service, err := Start(rootCtx, config, logger, prometheus.DefaultRegisterer.(*prometheus.Registry))
+	if err != nil {
+		logger.Fatal("failed to start parser", zap.Error(err))
+	}
+
+	logger.Info("Started wormhole-explorer-parser")
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	service.Stop()
+
+	logger.Info("Finished wormhole-explorer-parser")
+}
+
+// Service is a running parser, started by Run or by the services that run the parser in-process.
+type Service struct {
+	db     *dbutil.Session
+	server *infrastructure.Server
+	logger *zap.Logger
+}
+
+// Start starts the parser with the configuration. The consumers run until the context is cancelled.
+// The metrics are registered in the registry and served by the http server.
+func Start(rootCtx context.Context, config *config.ServiceConfiguration, logger *zap.Logger, registry *prometheus.Registry) (*Service, error) {
+
 	// setup DB connection
 	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
 	if err != nil {
-		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+		return nil, fmt.Errorf("failed to connect MongoDB: %w", err)
 	}
 
 	// run the database migration.
 	err = migration.Run(db.Database)
 	if err != nil {
-		logger.Fatal("error running migration", zap.Error(err))
+		return nil, fmt.Errorf("error running migration: %w", err)
 	}
 
 	// get alert client.
-	alertClient, err := newAlertClient(config)
+	alertClient, err := newAlertClient(config, logger)
 	if err != nil {
-		logger.Fatal("failed to create alert client", zap.Error(err))
+		return nil, fmt.Errorf("failed to create alert client: %w", err)
 	}
 
 	// create a metrics
-	metrics := newMetrics(config)
+	metrics := newMetrics(config, registry)
 
 	// create a parserVAAAPIClient
 	parserVAAAPIClient, err := vaaPayloadParser.NewParserVAAAPIClient(config.VaaPayloadParserTimeout,
 		config.VaaPayloadParserURL, logger)
 	if err != nil {
-		logger.Fatal("failed to create parse vaa api client")
+		return nil, fmt.Errorf("failed to create parse vaa api client: %w", err)
 	}
 
 	// get vaa consumer function.
-	vaaConsumeFunc := newVAAConsume(rootCtx, config, metrics, logger)
+	vaaConsumeFunc, err := newVAAConsume(rootCtx, config, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vaa consumer: %w", err)
+	}
 
 	//get notification consumer function.
-	notificationConsumeFunc := newNotificationConsume(rootCtx, config, metrics, logger)
+	notificationConsumeFunc, err := newNotificationConsume(rootCtx, config, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification consumer: %w", err)
+	}
 
 	// create a repository
 	repository := parser.NewRepository(db.Database, logger)
@@ -98,7 +143,7 @@ func Run() {
 	logger.Info("creating health check functions...")
 	healthChecks, err := newHealthChecks(rootCtx, config, db.Database)
 	if err != nil {
-		logger.Fatal("failed to create health checks", zap.Error(err))
+		return nil, fmt.Errorf("failed to create health checks: %w", err)
 	}
 	// create a token provider
 	tokenProvider := domain.NewTokenProvider(config.P2pNetwork)
@@ -116,31 +161,19 @@ func Run() {
 
 	vaaRepository := vaa.NewRepository(db.Database, logger)
 	vaaController := vaa.NewController(vaaRepository, processor.Process, logger)
-	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, vaaController, healthChecks...)
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, vaaController, registry, healthChecks...)
 	server.Start()
 
-	logger.Info("Started wormhole-explorer-parser")
-
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("root context cancelled, exiting...")
-	rootCtxCancel()
-
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+	return &Service{db: db, server: server, logger: logger}, nil
+}
 
-	logger.Info("Closing Http server ...")
-	server.Stop()
+// Stop closes the MongoDB connection and stops the http server.
+func (s *Service) Stop() {
+	s.logger.Info("closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
 
-	logger.Info("Finished wormhole-explorer-parser")
+	s.logger.Info("Closing Http server ...")
+	s.server.Stop()
 }
 
 // Creates a new AWS config depending on whether the execution is local (localstack) or not (AWS)
@@ -170,26 +203,41 @@ func newAwsConfig(appCtx context.Context, cfg *config.ServiceConfiguration) (aws
 	return awsconfig.LoadDefaultConfig(appCtx, awsconfig.WithRegion(region))
 }
 
-func newVAAConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.PipelineSQSUrl)
+func newVAAConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) (queue.ConsumeFunc, error) {
+	receiver, err := newQueueReceiver(appCtx, config, config.PipelineSQSUrl, config.PipelineQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
 	}
 
-	filterConsumeFunc := newFilterFunc(config)
-	vaaQueue := queue.NewEventSQS(sqsConsumer, queue.NewVaaConverter(logger), filterConsumeFunc, metrics, logger)
-	return vaaQueue.Consume
+	filterConsumeFunc := queue.NewMetricsFilter(newFilterFunc(config), metrics)
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewVaaConverter(logger), logger,
+		common_queue.WithFilter(filterConsumeFunc))
+	return vaaQueue.Consume, nil
 }
 
-func newNotificationConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) queue.ConsumeFunc {
-	sqsConsumer, err := newSQSConsumer(appCtx, config, config.NotificationsSQSUrl)
+func newNotificationConsume(appCtx context.Context, config *config.ServiceConfiguration, metrics metrics.Metrics, logger *zap.Logger) (queue.ConsumeFunc, error) {
+	receiver, err := newQueueReceiver(appCtx, config, config.NotificationsSQSUrl, config.NotificationsQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
 	}
 
-	filterConsumeFunc := newFilterFunc(config)
-	vaaQueue := queue.NewEventSQS(sqsConsumer, queue.NewNotificationEvent(logger), filterConsumeFunc, metrics, logger)
-	return vaaQueue.Consume
+	filterConsumeFunc := queue.NewMetricsFilter(newFilterFunc(config), metrics)
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewNotificationEvent(logger), logger,
+		common_queue.WithFilter(filterConsumeFunc))
+	return vaaQueue.Consume, nil
+}
+
+// Create a receiver of the configured queue backend.
+func newQueueReceiver(appCtx context.Context, config *config.ServiceConfiguration, sqsUrl, queueName string, logger *zap.Logger) (common_queue.Receiver, error) {
+	if !config.IsSQS() {
+		return common_queue.NewReceiver(appCtx, &config.Config, queueName)
+	}
+
+	sqsConsumer, err := newSQSConsumer(appCtx, config, sqsUrl)
+	if err != nil {
+		return nil, err
+	}
+	return common_queue.NewSQSReceiver(sqsConsumer, logger), nil
 }
 
 // Create a new SQS consumer.
@@ -213,14 +261,14 @@ func newFilterFunc(cfg *config.ServiceConfiguration) queue.FilterConsumeFunc {
 }
 
 // Creates a metrics depending on whether the execution is local (dummy metrics) or not (Prometheus metrics)
-func newMetrics(cfg *config.ServiceConfiguration) metrics.Metrics {
+func newMetrics(cfg *config.ServiceConfiguration, registry prometheus.Registerer) metrics.Metrics {
 	if !cfg.MetricsEnabled {
 		return metrics.NewDummyMetrics()
 	}
-	return metrics.NewPrometheusMetrics(cfg.Environment)
+	return metrics.NewPrometheusMetrics(registry, cfg.Environment)
 }
 
-func newAlertClient(cfg *config.ServiceConfiguration) (alert.AlertClient, error) {
+func newAlertClient(cfg *config.ServiceConfiguration, logger *zap.Logger) (alert.AlertClient, error) {
 	if !cfg.AlertEnabled {
 		return alert.NewDummyClient(), nil
 	}
@@ -229,9 +277,10 @@ func newAlertClient(cfg *config.ServiceConfiguration) (alert.AlertClient, error)
 		Environment: cfg.Environment,
 		ApiKey:      cfg.AlertApiKey,
 		Enabled:     cfg.AlertEnabled,
+		Routing:     cfg.AlertRouting,
 	}
 
-	return alert.NewAlertService(alertConfig, parserAlert.LoadAlerts)
+	return alert.NewMultiplexer(alertConfig, parserAlert.LoadAlerts, logger)
 }
 
 func newHealthChecks(
@@ -245,10 +294,11 @@ func newHealthChecks(
 		return nil, err
 	}
 
-	healthChecks := []health.Check{
-		health.SQS(awsConfig, config.PipelineSQSUrl),
-		health.SQS(awsConfig, config.NotificationsSQSUrl),
-		health.Mongo(db),
+	healthChecks := []health.Check{health.Mongo(db)}
+	if config.IsSQS() {
+		healthChecks = append(healthChecks,
+			health.SQS(awsConfig, config.PipelineSQSUrl),
+			health.SQS(awsConfig, config.NotificationsSQSUrl))
 	}
 	return healthChecks, nil
 }
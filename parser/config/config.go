@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
@@ -29,13 +30,18 @@ type ServiceConfiguration struct {
 	AwsRegion               string `env:"AWS_REGION"`
 	PipelineSQSUrl          string `env:"PIPELINE_SQS_URL"`
 	NotificationsSQSUrl     string `env:"NOTIFICATIONS_SQS_URL"`
+	PipelineQueueName       string `env:"PIPELINE_QUEUE_NAME,default=wormscan-pipeline"`
+	NotificationsQueueName  string `env:"NOTIFICATIONS_QUEUE_NAME,default=wormscan-notifications"`
 	VaaPayloadParserURL     string `env:"VAA_PAYLOAD_PARSER_URL, required"`
 	VaaPayloadParserTimeout int64  `env:"VAA_PAYLOAD_PARSER_TIMEOUT, required"`
 	PprofEnabled            bool   `env:"PPROF_ENABLED,default=false"`
 	P2pNetwork              string `env:"P2P_NETWORK,required"`
 	AlertEnabled            bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey             string `env:"ALERT_API_KEY"`
+	AlertRouting            string `env:"ALERT_ROUTING"`
 	MetricsEnabled          bool   `env:"METRICS_ENABLED,default=false"`
+	// Config is the queue backend. The SQS urls are used with the sqs backend, and the queue names with the others.
+	queue.Config
 }
 
 // BackfillerConfiguration represents the application configuration when running as backfiller with default values.
@@ -3,7 +3,10 @@ package infrastructure
 import (
 	"github.com/ansrivas/fiberprometheus/v2"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/parser/http/vaa"
 	"go.uber.org/zap"
@@ -15,13 +18,13 @@ type Server struct {
 	logger *zap.Logger
 }
 
-func NewServer(logger *zap.Logger, port string, pprofEnabled bool, vaaController *vaa.Controller, checks ...health.Check) *Server {
+func NewServer(logger *zap.Logger, port string, pprofEnabled bool, vaaController *vaa.Controller, registry *prometheus.Registry, checks ...health.Check) *Server {
 	ctrl := health.NewController(checks, logger)
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	// config use of middlware.
-	prometheus := fiberprometheus.New("wormscan-pipeline")
-	prometheus.RegisterAt(app, "/metrics")
+	prometheus := fiberprometheus.NewWithRegistry(registry, "wormscan-parser", "http", "", nil)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
 	app.Use(prometheus.Middleware)
 
 	if pprofEnabled {
@@ -17,31 +17,32 @@ type PrometheusMetrics struct {
 	vaaProcessingDuration         *prometheus.HistogramVec
 }
 
-// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
 	constLabels := map[string]string{
 		"environment": environment,
 		"service":     serviceName,
 	}
-	vaaParseCount := promauto.NewCounterVec(
+	vaaParseCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "parse_vaa_count_by_chain",
 			Help:        "Total number of vaa parser by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "type"})
-	vaaPayloadParserRequestCount := promauto.NewCounterVec(
+	vaaPayloadParserRequestCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "parse_vaa_payload_request_count_by_chain",
 			Help:        "Total number of request to payload parser component by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain"})
-	vaaPayloadParserResponseCount := promauto.NewCounterVec(
+	vaaPayloadParserResponseCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "parse_vaa_payload_response_count_by_chain",
 			Help:        "Total number of response from payload parser component by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "status"})
-	processedMessage := promauto.NewCounterVec(
+	processedMessage := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "processed_message",
 			Help:        "Total number of processed message",
@@ -49,7 +50,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 		},
 		[]string{"chain", "source", "status"},
 	)
-	vaaProcessingDuration := promauto.NewHistogramVec(
+	vaaProcessingDuration := factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:        "vaa_processing_duration_seconds",
 			Help:        "Duration of all vaa processing by chain.",
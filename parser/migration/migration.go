@@ -24,6 +24,16 @@ func Run(db *mongo.Database) error {
 		return err
 	}
 
+	// create index in parsedVaa collection by appIds and updatedAt, used to find the new NTT tokens.
+	indexAppIdsUpdatedAt := mongo.IndexModel{Keys: bson.D{
+		{Key: "rawStandardizedProperties.appIds", Value: 1},
+		{Key: "updatedAt", Value: 1},
+	}}
+	_, err = db.Collection(parser.ParsedVAACollection).Indexes().CreateOne(context.TODO(), indexAppIdsUpdatedAt)
+	if err != nil && isNotAlreadyExistsError(err) {
+		return err
+	}
+
 	return nil
 }
 
@@ -1,6 +1,9 @@
 package queue
 
-import "github.com/wormhole-foundation/wormhole/sdk/vaa"
+import (
+	"github.com/wormhole-foundation/wormhole-explorer/parser/internal/metrics"
+	"github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
 
 // PythFilter filter vaa event from pyth chain.
 func PythFilter(vaaEvent *Event) bool {
@@ -11,3 +14,15 @@ func PythFilter(vaaEvent *Event) bool {
 func NonFilter(vaaEvent *Event) bool {
 	return false
 }
+
+// NewMetricsFilter wraps a filter to count the consumed and the unfiltered vaa events.
+func NewMetricsFilter(filter FilterConsumeFunc, metrics metrics.Metrics) FilterConsumeFunc {
+	return func(vaaEvent *Event) bool {
+		metrics.IncVaaConsumedQueue(vaaEvent.ChainID)
+		if filter(vaaEvent) {
+			return true
+		}
+		metrics.IncVaaUnfiltered(vaaEvent.ChainID)
+		return false
+	}
+}
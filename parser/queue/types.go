@@ -3,6 +3,8 @@ package queue
 import (
 	"context"
 	"time"
+
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 type sqsEvent struct {
@@ -24,13 +26,13 @@ type Event struct {
 }
 
 // ConsumerMessage defition.
-type ConsumerMessage interface {
-	Data() *Event
-	Done()
-	Failed()
-	IsExpired() bool
-	SentTimestamp() *time.Time
-}
+type ConsumerMessage = common_queue.Message[Event]
+
+// ConverterFunc converts a message from a queue message.
+type ConverterFunc = common_queue.ConverterFunc[Event]
+
+// FilterConsumeFunc filter vaaa func definition.
+type FilterConsumeFunc = common_queue.FilterFunc[Event]
 
 // ConsumeFunc is a function to consume Event.
 type ConsumeFunc func(context.Context) <-chan ConsumerMessage
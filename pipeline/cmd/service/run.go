@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -11,9 +12,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/config"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/healthcheck"
 	"github.com/wormhole-foundation/wormhole-explorer/pipeline/http/infrastructure"
@@ -52,31 +55,69 @@ func Run() {
 
 	logger.Info("Starting wormhole-explorer-pipeline ...")
 
+	service, err := Start(rootCtx, config, logger, prometheus.DefaultRegisterer.(*prometheus.Registry))
+	if err != nil {
+		logger.Fatal("failed to start pipeline", zap.Error(err))
+	}
+
+	logger.Info("Started wormhole-explorer-pipeline")
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	logger.Info("root context cancelled, exiting...")
+	rootCtxCancel()
+
+	service.Stop()
+
+	logger.Info("Finished wormhole-explorer-pipeline")
+
+}
+
+// Service is a running pipeline, started by Run or by the services that run the pipeline in-process.
+type Service struct {
+	db     *dbutil.Session
+	server *infrastructure.Server
+	quit   chan bool
+	logger *zap.Logger
+}
+
+// Start starts the pipeline with the configuration. The pipeline runs until the context is cancelled and Stop is called.
+// The metrics are registered in the registry and served by the http server.
+func Start(rootCtx context.Context, config *config.Configuration, logger *zap.Logger, registry *prometheus.Registry) (*Service, error) {
+
 	//setup DB connection
 	db, err := dbutil.Connect(rootCtx, logger, config.MongoURI, config.MongoDatabase, false)
 	if err != nil {
-		logger.Fatal("failed to connect MongoDB", zap.Error(err))
+		return nil, fmt.Errorf("failed to connect MongoDB: %w", err)
 	}
 
 	// get alert client.
-	alertClient, err := newAlertClient(config)
+	alertClient, err := newAlertClient(config, logger)
 	if err != nil {
-		logger.Fatal("failed to create alert client", zap.Error(err))
+		return nil, fmt.Errorf("failed to create alert client: %w", err)
 	}
 
 	// get metrics.
-	metrics := newMetrics(config)
+	metrics := newMetrics(config, registry)
 
 	// get publish function.
 	pushFunc, err := newTopicProducer(rootCtx, config, alertClient, metrics, logger)
 	if err != nil {
-		logger.Fatal("failed to create publish function", zap.Error(err))
+		return nil, fmt.Errorf("failed to create publish function: %w", err)
 	}
 
 	// get health check functions.
 	healthChecks, err := newHealthChecks(rootCtx, config, db.Database)
 	if err != nil {
-		logger.Fatal("failed to create health checks", zap.Error(err))
+		return nil, fmt.Errorf("failed to create health checks: %w", err)
 	}
 
 	// create a new pipeline repository.
@@ -92,38 +133,26 @@ func Run() {
 	watcher := watcher.NewWatcher(rootCtx, db.Database, config.MongoDatabase, publisher.Publish, alertClient, metrics, logger)
 	err = watcher.Start(rootCtx)
 	if err != nil {
-		logger.Fatal("failed to watch MongoDB", zap.Error(err))
+		close(quit)
+		return nil, fmt.Errorf("failed to watch MongoDB: %w", err)
 	}
 
-	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, healthChecks...)
+	server := infrastructure.NewServer(logger, config.Port, config.PprofEnabled, registry, healthChecks...)
 	server.Start()
 
-	logger.Info("Started wormhole-explorer-pipeline")
-
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
-
-	logger.Info("root context cancelled, exiting...")
-	rootCtxCancel()
-
-	logger.Info("Closing tx hash handler ...")
-	close(quit)
-
-	logger.Info("closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+	return &Service{db: db, server: server, quit: quit, logger: logger}, nil
+}
 
-	logger.Info("Closing Http server ...")
-	server.Stop()
+// Stop stops the tx hash handler, the http server and closes the MongoDB connection.
+func (s *Service) Stop() {
+	s.logger.Info("Closing tx hash handler ...")
+	close(s.quit)
 
-	logger.Info("Finished wormhole-explorer-pipeline")
+	s.logger.Info("closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
 
+	s.logger.Info("Closing Http server ...")
+	s.server.Stop()
 }
 
 func newAwsConfig(appCtx context.Context, cfg *config.Configuration) (aws.Config, error) {
@@ -155,6 +184,14 @@ func newAwsConfig(appCtx context.Context, cfg *config.Configuration) (aws.Config
 }
 
 func newTopicProducer(appCtx context.Context, config *config.Configuration, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) (topic.PushFunc, error) {
+	if !config.IsSQS() {
+		sender, err := common_queue.NewSender(appCtx, &config.Config, config.PipelineQueueName)
+		if err != nil {
+			return nil, err
+		}
+		return topic.NewVAAQueue(sender, alertClient, metrics, logger).Publish, nil
+	}
+
 	awsConfig, err := newAwsConfig(appCtx, config)
 	if err != nil {
 		return nil, err
@@ -173,18 +210,21 @@ func newHealthChecks(ctx context.Context, config *config.Configuration, db *mong
 	if err != nil {
 		return nil, err
 	}
+	if !config.IsSQS() {
+		return []healthcheck.Check{healthcheck.Mongo(db)}, nil
+	}
 	return []healthcheck.Check{healthcheck.Mongo(db), healthcheck.SNS(awsConfig, config.SNSUrl)}, nil
 }
 
-func newMetrics(cfg *config.Configuration) metrics.Metrics {
+func newMetrics(cfg *config.Configuration, registry prometheus.Registerer) metrics.Metrics {
 	metricsEnabled := cfg.MetricsEnabled
 	if !metricsEnabled {
 		return metrics.NewDummyMetrics()
 	}
-	return metrics.NewPrometheusMetrics(cfg.Environment)
+	return metrics.NewPrometheusMetrics(registry, cfg.Environment)
 }
 
-func newAlertClient(cfg *config.Configuration) (alert.AlertClient, error) {
+func newAlertClient(cfg *config.Configuration, logger *zap.Logger) (alert.AlertClient, error) {
 	if !cfg.AlertEnabled {
 		return alert.NewDummyClient(), nil
 	}
@@ -193,6 +233,7 @@ func newAlertClient(cfg *config.Configuration) (alert.AlertClient, error) {
 		Environment: cfg.Environment,
 		ApiKey:      cfg.AlertApiKey,
 		Enabled:     cfg.AlertEnabled,
+		Routing:     cfg.AlertRouting,
 	}
-	return alert.NewAlertService(alertConfig, pipelineAlert.LoadAlerts)
+	return alert.NewMultiplexer(alertConfig, pipelineAlert.LoadAlerts, logger)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 )
 
 // Configuration represents the application configuration with the default values.
@@ -23,7 +24,12 @@ type Configuration struct {
 	PprofEnabled       bool   `env:"PPROF_ENABLED,default=false"`
 	AlertEnabled       bool   `env:"ALERT_ENABLED,default=false"`
 	AlertApiKey        string `env:"ALERT_API_KEY"`
+	AlertRouting       string `env:"ALERT_ROUTING"`
 	MetricsEnabled     bool   `env:"METRICS_ENABLED,default=false"`
+	// PipelineQueueName is the queue of the vaas used with the kafka, nats, redis and memory queue backends.
+	PipelineQueueName string `env:"PIPELINE_QUEUE_NAME,default=wormscan-pipeline"`
+	// Config is the queue backend. The SNS url is used with the sqs backend, and the queue name with the others.
+	queue.Config
 }
 
 type Backfiller struct {
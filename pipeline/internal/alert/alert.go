@@ -11,6 +11,7 @@ const (
 	ErrorDecodeWatcherEvent = "ERROR_DECODE_WATCHER_EVENT"
 	ErrorUpdateVaaTxHash    = "ERROR_UPDATE_VAA_TX_HASH"
 	ErrorPushEventSNS       = "ERROR_PUSH_EVENT_SNS"
+	ErrorPushEventQueue     = "ERROR_PUSH_EVENT_QUEUE"
 )
 
 func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
@@ -49,5 +50,16 @@ func LoadAlerts(cfg alert.AlertConfig) map[string]alert.Alert {
 		Priority:    alert.CRITICAL,
 	}
 
+	// Alert error pushing event to the queue backend.
+	alerts[ErrorPushEventQueue] = alert.Alert{
+		Alias:       "Error pushing event to queue",
+		Message:     fmt.Sprintf("[%s] %s", cfg.Environment, "Error pushing event to queue"),
+		Description: "An error was found pushing the event to the queue backend",
+		Actions:     []string{""},
+		Tags:        []string{cfg.Environment, "pipeline", "push", "queue"},
+		Entity:      "pipeline",
+		Priority:    alert.CRITICAL,
+	}
+
 	return alerts
 }
@@ -12,9 +12,10 @@ type PrometheusMetrics struct {
 	vaaTxHashCount   *prometheus.CounterVec
 }
 
-// NewPrometheusMetrics creates a new PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
-	vaaReceivedCount := promauto.NewCounterVec(
+// NewPrometheusMetrics creates a new PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
+	vaaReceivedCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "vaa_count_by_chain",
 			Help: "Total number of vaa by chain",
@@ -24,7 +25,7 @@ func NewPrometheusMetrics(environment string) *PrometheusMetrics {
 			},
 		}, []string{"chain", "type"})
 
-	vaaTxHashCount := promauto.NewCounterVec(
+	vaaTxHashCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "vaa_txhash_count_by_chain",
 			Help: "Total number of vaa by chain",
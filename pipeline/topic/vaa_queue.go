@@ -0,0 +1,61 @@
+package topic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/wormhole-foundation/wormhole-explorer/common/client/alert"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	pipelineAlert "github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/alert"
+	"github.com/wormhole-foundation/wormhole-explorer/pipeline/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// Queue represents a VAA topic in the kafka, nats, redis or memory queue backends.
+type Queue struct {
+	sender      common_queue.Sender
+	alertClient alert.AlertClient
+	metrics     metrics.Metrics
+	logger      *zap.Logger
+}
+
+// NewVAAQueue creates a VAA topic in a queue backend.
+func NewVAAQueue(sender common_queue.Sender, alertClient alert.AlertClient, metrics metrics.Metrics, logger *zap.Logger) *Queue {
+	return &Queue{
+		sender:      sender,
+		alertClient: alertClient,
+		metrics:     metrics,
+		logger:      logger,
+	}
+}
+
+// Publish sends the message to the queue.
+func (q *Queue) Publish(ctx context.Context, message *Event) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	q.logger.Debug("Publishing message", zap.String("groupID", message.ID))
+	err = q.sender.Send(ctx, &common_queue.OutgoingMessage{
+		GroupID:         message.ID,
+		DeduplicationID: message.ID,
+		Body:            string(body),
+		Attributes:      map[string]string{"chainId": fmt.Sprintf("%d", message.ChainID)},
+	})
+	if err == nil {
+		q.metrics.IncVaaSendNotification(message.ChainID)
+	} else {
+		// Alert error pushing event.
+		alertContext := alert.AlertContext{
+			Details: map[string]string{
+				"groupID":   message.ID,
+				"messageID": message.ID,
+			},
+			Error: err,
+		}
+		q.alertClient.CreateAndSend(ctx, pipelineAlert.ErrorPushEventQueue, alertContext)
+	}
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/internal/metrics"
@@ -24,6 +25,19 @@ type aptosTx struct {
 	Timestamp uint64 `json:"timestamp,string"`
 	Sender    string `json:"sender"`
 	Hash      string `json:"hash"`
+	Payload   struct {
+		// Function is the entry function called by the transaction, e.g. "0x1::coin::transfer".
+		Function string `json:"function"`
+	} `json:"payload"`
+}
+
+// contract returns the address of the module of the entry function called by the transaction.
+func (tx *aptosTx) contract() string {
+	address, _, ok := strings.Cut(tx.Payload.Function, "::")
+	if !ok {
+		return ""
+	}
+	return address
 }
 
 func FetchAptosTx(
@@ -120,6 +134,7 @@ func fetchAptosTxByCreationNumber(
 	TxDetail := TxDetail{
 		NativeTxHash: tx.Hash,
 		From:         tx.Sender,
+		Contract:     tx.contract(),
 	}
 	return &TxDetail, nil
 }
@@ -211,6 +226,7 @@ func fetchAptosTxByTxHash(
 	TxDetail := TxDetail{
 		NativeTxHash: tx.Hash,
 		From:         tx.Sender,
+		Contract:     tx.contract(),
 	}
 	return &TxDetail, nil
 }
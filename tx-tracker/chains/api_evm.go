@@ -37,7 +37,7 @@ type ethGetTransactionReceiptResponse struct {
 
 type apiEvm struct {
 	chainId       sdk.ChainID
-	notionalCache *notional.NotionalCache
+	notionalCache notional.NotionalLocalCacheReadable
 	p2pNetwork    string
 }
 
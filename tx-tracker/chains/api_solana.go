@@ -62,7 +62,7 @@ type getTransactionConfig struct {
 
 type apiSolana struct {
 	timestamp     *time.Time
-	notionalCache *notional.NotionalCache
+	notionalCache notional.NotionalLocalCacheReadable
 	p2pNetwork    string
 }
 
@@ -16,11 +16,28 @@ type suiGetTransactionBlockResponse struct {
 	TimestampMs int64  `json:"timestampMs,string"`
 	Transaction struct {
 		Data struct {
-			Sender string `json:"sender"`
+			Sender      string `json:"sender"`
+			Transaction struct {
+				Transactions []struct {
+					MoveCall *struct {
+						Package string `json:"package"`
+					} `json:"MoveCall"`
+				} `json:"transactions"`
+			} `json:"transaction"`
 		} `json:"data"`
 	} `json:"transaction"`
 }
 
+// contract returns the package of the first move call of the transaction.
+func (r *suiGetTransactionBlockResponse) contract() string {
+	for _, tx := range r.Transaction.Data.Transaction.Transactions {
+		if tx.MoveCall != nil {
+			return tx.MoveCall.Package
+		}
+	}
+	return ""
+}
+
 type suiGetTransactionBlockOpts struct {
 	ShowInput          bool `json:"showInput"`
 	ShowRawInput       bool `json:"showRawInput"`
@@ -90,6 +107,7 @@ func fetchSuiTx(
 	txDetail := TxDetail{
 		NativeTxHash: reply.Digest,
 		From:         reply.Transaction.Data.Sender,
+		Contract:     reply.contract(),
 	}
 	return &txDetail, nil
 }
@@ -28,6 +28,9 @@ type TxDetail struct {
 	Attribute *AttributeTxDetail
 	// FeeDetail contains the fee of the transactions.
 	FeeDetail *FeeDetail
+	// Contract is the address of the contract called by the transaction, encoded in the chain's native format.
+	// It is only set for the chains whose emitters can not be derived from the emitter address.
+	Contract string
 }
 
 type FeeDetail struct {
@@ -52,7 +55,7 @@ func FetchTx(
 	p2pNetwork string,
 	m metrics.Metrics,
 	logger *zap.Logger,
-	notionalCache *notional.NotionalCache,
+	notionalCache notional.NotionalLocalCacheReadable,
 ) (*TxDetail, error) {
 	// Decide which RPC/API service to use based on chain ID
 	var fetchFunc func(ctx context.Context, pool *pool.Pool, txHash string, metrics metrics.Metrics, logger *zap.Logger) (*TxDetail, error)
@@ -150,7 +150,7 @@ func FormatTxHashByChain(chainId sdk.ChainID, txHash string) string {
 	}
 }
 
-func GetGasTokenNotional(chainID sdk.ChainID, notionalCache *notional.NotionalCache) (notional.PriceData, error) {
+func GetGasTokenNotional(chainID sdk.ChainID, notionalCache notional.NotionalLocalCacheReadable) (notional.PriceData, error) {
 	nativeToken := domain.GetGasTokenMetadata(chainID)
 	if nativeToken == nil {
 		return notional.PriceData{}, fmt.Errorf("gas token not found for chain %s", chainID)
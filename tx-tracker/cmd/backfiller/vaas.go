@@ -104,6 +104,7 @@ func RunByVaas(backfillerConfig *VaasBackfiller) {
 	vaaRepository := repository.NewVaaRepository(db.Database, logger)
 	// create a consumer repository.
 	globalTrxRepository := consumer.NewRepository(logger, db.Database)
+	globalTrxRepository.StartEmitterRegistrar(ctx)
 
 	redisClient := redis.NewClient(&redis.Options{Addr: cfg.NotionalCacheURL})
 	notionalCache, errCache := notional.NewNotionalCache(ctx, redisClient, cfg.NotionalCachePrefix, cfg.NotionalCacheChannel, logger)
@@ -160,6 +161,10 @@ func RunByVaas(backfillerConfig *VaasBackfiller) {
 	logger.Info("Waiting for all workers to finish...")
 	wg.Wait()
 
+	if err := globalTrxRepository.FlushEmitters(ctx); err != nil {
+		logger.Error("failed to flush the emitter registry", zap.Error(err))
+	}
+
 	logger.Info("closing MongoDB connection...")
 	db.DisconnectWithTimeout(10 * time.Second)
 
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/cache/notional"
 	"log"
@@ -16,12 +17,16 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/wormhole-foundation/wormhole-explorer/common/client/sqs"
 	"github.com/wormhole-foundation/wormhole-explorer/common/configuration"
 	"github.com/wormhole-foundation/wormhole-explorer/common/dbutil"
+	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
 	"github.com/wormhole-foundation/wormhole-explorer/common/health"
 	"github.com/wormhole-foundation/wormhole-explorer/common/logger"
 	"github.com/wormhole-foundation/wormhole-explorer/common/pool"
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	commonRepo "github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/common/utils"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/config"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/consumer"
@@ -42,38 +47,77 @@ func Run() {
 		log.Fatal("Error loading config: ", err)
 	}
 
-	// initialize metrics
-	metrics := newMetrics(cfg)
-
 	// build logger
 	logger := logger.New("wormhole-explorer-tx-tracker", logger.WithLevel(cfg.LogLevel))
 
 	logger.Info("Starting wormhole-explorer-tx-tracker ...")
 
+	service, err := Start(rootCtx, cfg, logger, prometheus.DefaultRegisterer.(*prometheus.Registry))
+	if err != nil {
+		logger.Fatal("Failed to start tx-tracker", zap.Error(err))
+	}
+
+	logger.Info("Started wormhole-explorer-tx-tracker")
+
+	// Waiting for signal
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-rootCtx.Done():
+		logger.Warn("Terminating with root context cancelled.")
+	case signal := <-sigterm:
+		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
+	}
+
+	// graceful shutdown
+	logger.Info("Cancelling root context...")
+	rootCtxCancel()
+
+	service.Stop()
+
+	logger.Info("Terminated wormhole-explorer-tx-tracker")
+}
+
+// Service is a running tx-tracker, started by Run or by the services that run the tx-tracker in-process.
+type Service struct {
+	db            *dbutil.Session
+	server        *infrastructure.Server
+	notionalCache notional.NotionalLocalCacheReadable
+	logger        *zap.Logger
+}
+
+// Start starts the tx-tracker with the settings. The consumers run until the context is cancelled.
+// The metrics are registered in the registry and served by the http server.
+func Start(rootCtx context.Context, cfg *config.ServiceSettings, logger *zap.Logger, registry *prometheus.Registry) (*Service, error) {
+
+	// initialize metrics
+	metrics := newMetrics(cfg, registry)
+
 	// create rpc pool
 	rpcPool, wormchainRpcPool, err := newRpcPool(cfg)
 	if err != nil {
-		logger.Fatal("Failed to initialize rpc pool: ", zap.Error(err))
+		return nil, fmt.Errorf("failed to initialize rpc pool: %w", err)
 	}
 
 	// initialize the database client
 	db, err := dbutil.Connect(rootCtx, logger, cfg.MongodbUri, cfg.MongodbDatabase, false)
 	if err != nil {
-		log.Fatal("Failed to initialize MongoDB client: ", err)
+		return nil, fmt.Errorf("failed to initialize MongoDB client: %w", err)
 	}
 
 	// create repositories
 	repository := consumer.NewRepository(logger, db.Database)
+	repository.StartEmitterRegistrar(rootCtx)
 	vaaRepository := vaa.NewRepository(db.Database, logger)
 
-	redisClient := redis.NewClient(&redis.Options{Addr: cfg.NotionalCacheURL})
-	notionalCache, errCache := notional.NewNotionalCache(rootCtx, redisClient, cfg.NotionalCachePrefix, cfg.NotionalCacheChannel, logger)
-	if errCache != nil {
-		logger.Fatal("Failed to create notional cache", zap.Error(errCache))
-	}
-	errCache = notionalCache.Init(rootCtx)
-	if errCache != nil {
-		logger.Fatal("Failed to initialize notional cache", zap.Error(errCache))
+	// resolve emitter addresses through the emitter registry
+	emitterCache := commonRepo.NewEmitterCache(commonRepo.NewEmitterRepository(db.Database, logger), 5*time.Minute, logger)
+	emitterCache.Start(rootCtx)
+	domain.SetEmitterResolver(emitterCache)
+
+	notionalCache, err := newNotionalCache(rootCtx, cfg, logger)
+	if err != nil {
+		return nil, err
 	}
 
 	// create controller
@@ -82,44 +126,58 @@ func Run() {
 	// start serving /health and /ready endpoints
 	healthChecks, err := makeHealthChecks(rootCtx, cfg, db.Database)
 	if err != nil {
-		logger.Fatal("Failed to create health checks", zap.Error(err))
+		return nil, fmt.Errorf("failed to create health checks: %w", err)
 	}
-	server := infrastructure.NewServer(logger, cfg.MonitoringPort, cfg.PprofEnabled, vaaController, healthChecks...)
+	server := infrastructure.NewServer(logger, cfg.MonitoringPort, cfg.PprofEnabled, vaaController, registry, healthChecks...)
 	server.Start()
 
 	// create and start a pipeline consumer.
-	vaaConsumeFunc := newVAAConsumeFunc(rootCtx, cfg, metrics, logger)
+	vaaConsumeFunc, err := newVAAConsumeFunc(rootCtx, cfg, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vaa consumer: %w", err)
+	}
 	vaaConsumer := consumer.New(vaaConsumeFunc, rpcPool, wormchainRpcPool, logger, repository, metrics, cfg.P2pNetwork, cfg.ConsumerWorkersSize, notionalCache)
 	vaaConsumer.Start(rootCtx)
 
 	// create and start a notification consumer.
-	notificationConsumeFunc := newNotificationConsumeFunc(rootCtx, cfg, metrics, logger)
+	notificationConsumeFunc, err := newNotificationConsumeFunc(rootCtx, cfg, metrics, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification consumer: %w", err)
+	}
 	notificationConsumer := consumer.New(notificationConsumeFunc, rpcPool, wormchainRpcPool, logger, repository, metrics, cfg.P2pNetwork, cfg.ConsumerWorkersSize, notionalCache)
 	notificationConsumer.Start(rootCtx)
 
-	logger.Info("Started wormhole-explorer-tx-tracker")
+	return &Service{db: db, server: server, notionalCache: notionalCache, logger: logger}, nil
+}
 
-	// Waiting for signal
-	sigterm := make(chan os.Signal, 1)
-	signal.Notify(sigterm, syscall.SIGINT, syscall.SIGTERM)
-	select {
-	case <-rootCtx.Done():
-		logger.Warn("Terminating with root context cancelled.")
-	case signal := <-sigterm:
-		logger.Info("Terminating with signal.", zap.String("signal", signal.String()))
-	}
+// Stop stops the http server and closes the MongoDB connection and the notional cache.
+func (s *Service) Stop() {
+	s.logger.Info("Closing Http server...")
+	s.server.Stop()
 
-	// graceful shutdown
-	logger.Info("Cancelling root context...")
-	rootCtxCancel()
+	s.logger.Info("Closing MongoDB connection...")
+	s.db.DisconnectWithTimeout(10 * time.Second)
 
-	logger.Info("Closing Http server...")
-	server.Stop()
+	_ = s.notionalCache.Close()
+}
 
-	logger.Info("Closing MongoDB connection...")
-	db.DisconnectWithTimeout(10 * time.Second)
+// newNotionalCache creates the notional cache. Without a cache url, the notional of the tokens is not resolved,
+// which is only used to run the tx-tracker in-process without redis.
+func newNotionalCache(ctx context.Context, cfg *config.ServiceSettings, logger *zap.Logger) (notional.NotionalLocalCacheReadable, error) {
+	if cfg.NotionalCacheURL == "" {
+		return notional.NewDummyNotionalCache(), nil
+	}
 
-	logger.Info("Terminated wormhole-explorer-tx-tracker")
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.NotionalCacheURL})
+	notionalCache, err := notional.NewNotionalCache(ctx, redisClient, cfg.NotionalCachePrefix, cfg.NotionalCacheChannel, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notional cache: %w", err)
+	}
+	err = notionalCache.Init(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize notional cache: %w", err)
+	}
+	return notionalCache, nil
 }
 
 func newVAAConsumeFunc(
@@ -127,15 +185,15 @@ func newVAAConsumeFunc(
 	cfg *config.ServiceSettings,
 	metrics metrics.Metrics,
 	logger *zap.Logger,
-) queue.ConsumeFunc {
+) (queue.ConsumeFunc, error) {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.PipelineSqsUrl)
+	receiver, err := newQueueReceiver(ctx, cfg, cfg.PipelineSqsUrl, cfg.PipelineQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewVaaConverter(logger), metrics, logger)
-	return vaaQueue.Consume
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewVaaConverter(logger), logger, queue.NewMetricsOptions(metrics)...)
+	return vaaQueue.Consume, nil
 }
 
 func newNotificationConsumeFunc(
@@ -143,15 +201,28 @@ func newNotificationConsumeFunc(
 	cfg *config.ServiceSettings,
 	metrics metrics.Metrics,
 	logger *zap.Logger,
-) queue.ConsumeFunc {
+) (queue.ConsumeFunc, error) {
 
-	sqsConsumer, err := newSqsConsumer(ctx, cfg, cfg.NotificationsSqsUrl)
+	receiver, err := newQueueReceiver(ctx, cfg, cfg.NotificationsSqsUrl, cfg.NotificationsQueueName, logger)
 	if err != nil {
-		logger.Fatal("failed to create sqs consumer", zap.Error(err))
+		return nil, err
+	}
+
+	vaaQueue := common_queue.NewConsumer(receiver, queue.NewNotificationEvent(logger), logger, queue.NewMetricsOptions(metrics)...)
+	return vaaQueue.Consume, nil
+}
+
+// newQueueReceiver creates a receiver of the configured queue backend.
+func newQueueReceiver(ctx context.Context, cfg *config.ServiceSettings, sqsUrl, queueName string, logger *zap.Logger) (common_queue.Receiver, error) {
+	if !cfg.IsSQS() {
+		return common_queue.NewReceiver(ctx, &cfg.Config, queueName)
 	}
 
-	vaaQueue := queue.NewEventSqs(sqsConsumer, queue.NewNotificationEvent(logger), metrics, logger)
-	return vaaQueue.Consume
+	sqsConsumer, err := newSqsConsumer(ctx, cfg, sqsUrl)
+	if err != nil {
+		return nil, err
+	}
+	return common_queue.NewSQSReceiver(sqsConsumer, logger), nil
 }
 
 func newSqsConsumer(ctx context.Context, cfg *config.ServiceSettings, sqsUrl string) (*sqs.Consumer, error) {
@@ -212,20 +283,21 @@ func makeHealthChecks(
 		return nil, err
 	}
 
-	plugins := []health.Check{
-		health.SQS(awsConfig, config.PipelineSqsUrl),
-		health.SQS(awsConfig, config.NotificationsSqsUrl),
-		health.Mongo(db),
+	plugins := []health.Check{health.Mongo(db)}
+	if config.IsSQS() {
+		plugins = append(plugins,
+			health.SQS(awsConfig, config.PipelineSqsUrl),
+			health.SQS(awsConfig, config.NotificationsSqsUrl))
 	}
 
 	return plugins, nil
 }
 
-func newMetrics(cfg *config.ServiceSettings) metrics.Metrics {
+func newMetrics(cfg *config.ServiceSettings, registry prometheus.Registerer) metrics.Metrics {
 	if !cfg.MetricsEnabled {
 		return metrics.NewDummyMetrics()
 	}
-	return metrics.NewPrometheusMetrics(cfg.Environment)
+	return metrics.NewPrometheusMetrics(registry, cfg.Environment)
 }
 
 func newRpcPool(cfg *config.ServiceSettings) (map[sdk.ChainID]*pool.Pool, map[sdk.ChainID]*pool.Pool, error) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/wormhole-foundation/wormhole-explorer/common/queue"
 
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
@@ -29,6 +30,10 @@ type ServiceSettings struct {
 	NotionalCacheChannel string `split_words:"true" required:"true"`
 	AwsSettings
 	MongodbSettings
+	// Config is the queue backend. The SQS urls are used with the sqs backend, and the queue names with the others.
+	queue.Config
+	PipelineQueueName           string `split_words:"true" default:"wormscan-pipeline"`
+	NotificationsQueueName      string `split_words:"true" default:"wormscan-notifications"`
 	*RpcProviderSettings        `required:"false"`
 	*WormchainProviderSettings  `required:"false"`
 	*TestnetRpcProviderSettings `required:"false"`
@@ -60,8 +65,8 @@ type AwsSettings struct {
 	AwsAccessKeyID      string `split_words:"true" required:"false"`
 	AwsSecretAccessKey  string `split_words:"true" required:"false"`
 	AwsRegion           string `split_words:"true" required:"true"`
-	PipelineSqsUrl      string `split_words:"true" required:"true"`
-	NotificationsSqsUrl string `split_words:"true" required:"true"`
+	PipelineSqsUrl      string `split_words:"true" required:"false"`
+	NotificationsSqsUrl string `split_words:"true" required:"false"`
 }
 
 type MongodbSettings struct {
@@ -25,7 +25,7 @@ type Consumer struct {
 	metrics          metrics.Metrics
 	p2pNetwork       string
 	workersSize      int
-	notionalCache    *notional.NotionalCache
+	notionalCache    notional.NotionalLocalCacheReadable
 }
 
 // New creates a new vaa consumer.
@@ -37,7 +37,7 @@ func New(consumeFunc queue.ConsumeFunc,
 	metrics metrics.Metrics,
 	p2pNetwork string,
 	workersSize int,
-	notionalCache *notional.NotionalCache,
+	notionalCache notional.NotionalLocalCacheReadable,
 ) *Consumer {
 
 	c := Consumer{
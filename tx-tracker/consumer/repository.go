@@ -2,12 +2,16 @@ package consumer
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/wormhole-foundation/wormhole-explorer/api/handlers/vaa"
 	"github.com/wormhole-foundation/wormhole-explorer/common/domain"
+	commonRepo "github.com/wormhole-foundation/wormhole-explorer/common/repository"
 	"github.com/wormhole-foundation/wormhole-explorer/txtracker/chains"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 	"go.mongodb.org/mongo-driver/bson"
@@ -17,6 +21,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// emitterFlushInterval is the interval between the writes of the observed emitters to the emitter registry.
+const emitterFlushInterval = time.Minute
+
 // DestinationTx representa a destination transaction.
 type DestinationTx struct {
 	ChainID     sdk.ChainID `bson:"chainId"`
@@ -51,6 +58,8 @@ type Repository struct {
 	globalTransactions *mongo.Collection
 	vaas               *mongo.Collection
 	vaaIdTxHash        *mongo.Collection
+	emitters           *commonRepo.EmitterRegistrar
+	operationStatus    *commonRepo.OperationStatusRepository
 }
 
 // New creates a new repository.
@@ -61,11 +70,81 @@ func NewRepository(logger *zap.Logger, db *mongo.Database) *Repository {
 		globalTransactions: db.Collection("globalTransactions"),
 		vaas:               db.Collection("vaas"),
 		vaaIdTxHash:        db.Collection("vaaIdTxHash"),
+		emitters:           commonRepo.NewEmitterRegistrar(commonRepo.NewEmitterRepository(db, logger), emitterFlushInterval, logger),
+		operationStatus:    commonRepo.NewOperationStatusRepository(db, logger),
 	}
 
 	return &r
 }
 
+// StartEmitterRegistrar writes the registered emitters to the emitter registry until the context is cancelled.
+func (r *Repository) StartEmitterRegistrar(ctx context.Context) {
+	r.emitters.Start(ctx)
+}
+
+// FlushEmitters writes the registered emitters that are pending to the emitter registry.
+func (r *Repository) FlushEmitters(ctx context.Context) error {
+	return r.emitters.Flush(ctx)
+}
+
+// RegisterEmitter records in the emitter registry that the emitter was seen at the given time.
+// The contract called by the origin transaction resolves the native address of the emitters that are
+// not in the registry yet. The emitters are buffered and written periodically by the emitter registrar.
+func (r *Repository) RegisterEmitter(chainID sdk.ChainID, emitter, contract string, seenAt time.Time) {
+	address, nativeAddress := emitterAddresses(chainID, emitter, contract)
+	r.emitters.Register(chainID, address, nativeAddress, seenAt)
+}
+
+// emitterAddresses returns the wormhole address and the native address of an emitter.
+//
+// Events carry the wormhole address hex encoded, except for some chains where they carry the native
+// address of the emitter. The native address is empty when it can not be translated.
+func emitterAddresses(chainID sdk.ChainID, emitter, contract string) (string, string) {
+	if nativeAddress, err := domain.TranslateEmitterAddress(chainID, emitter); err == nil {
+		return emitter, nativeAddress
+	}
+	if b, err := hex.DecodeString(emitter); err == nil && len(b) == 32 {
+		return emitter, contractEmitterAddress(chainID, emitter, contract)
+	}
+	hexAddress, err := domain.DecodeNativeAddressToHex(chainID, emitter)
+	if err != nil {
+		return emitter, ""
+	}
+	address, err := sdk.StringToAddress(hexAddress)
+	if err != nil {
+		return emitter, ""
+	}
+	return address.String(), emitter
+}
+
+// contractEmitterAddress returns the native address of an emitter of the chains whose emitter address
+// is not derived from the native address, taken from the contract called by the origin transaction.
+//
+// NEAR emitters are the sha256 digest of the account, so the contract is only taken when it matches.
+// Sui and Aptos emitters are capabilities held by the contract that publishes the message.
+func contractEmitterAddress(chainID sdk.ChainID, emitter, contract string) string {
+	if contract == "" {
+		return ""
+	}
+	switch chainID {
+	case sdk.ChainIDNear:
+		digest := sha256.Sum256([]byte(contract))
+		if hex.EncodeToString(digest[:]) != strings.ToLower(emitter) {
+			return ""
+		}
+		return contract
+	case sdk.ChainIDSui, sdk.ChainIDAptos:
+		return contract
+	default:
+		return ""
+	}
+}
+
+// UpdateOperationStatus moves the operation forward in its lifecycle.
+func (r *Repository) UpdateOperationStatus(ctx context.Context, vaaId string, status domain.OperationStatus, at time.Time) error {
+	return r.operationStatus.UpdateStatus(ctx, vaaId, status, at)
+}
+
 // UpsertOriginTxParams is a struct that contains the parameters for the upsertDocument method.
 type UpsertOriginTxParams struct {
 	VaaId     string
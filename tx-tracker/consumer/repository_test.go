@@ -0,0 +1,38 @@
+package consumer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
+)
+
+func TestEmitterAddresses(t *testing.T) {
+	nearDigest := sha256.Sum256([]byte("bridge.example.near"))
+	nearEmitter := hex.EncodeToString(nearDigest[:])
+	unknown := "00000000000000000000000000000000000000000000000000000000000000ff"
+
+	tests := []struct {
+		name       string
+		chainID    sdk.ChainID
+		emitter    string
+		contract   string
+		wantNative string
+	}{
+		{name: "known emitter", chainID: sdk.ChainIDAptos, emitter: "0000000000000000000000000000000000000000000000000000000000000001", contract: "0xother", wantNative: "0x576410486a2da45eee6c949c995670112ddf2fbeedab20350d506328eefc9d4f"},
+		{name: "aptos emitter resolved from the contract", chainID: sdk.ChainIDAptos, emitter: unknown, contract: "0xabc", wantNative: "0xabc"},
+		{name: "sui emitter resolved from the contract", chainID: sdk.ChainIDSui, emitter: unknown, contract: "0xdef", wantNative: "0xdef"},
+		{name: "near emitter resolved from the contract", chainID: sdk.ChainIDNear, emitter: nearEmitter, contract: "bridge.example.near", wantNative: "bridge.example.near"},
+		{name: "near emitter of another contract", chainID: sdk.ChainIDNear, emitter: unknown, contract: "bridge.example.near", wantNative: ""},
+		{name: "unknown contract", chainID: sdk.ChainIDSui, emitter: unknown, wantNative: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, nativeAddress := emitterAddresses(tt.chainID, tt.emitter, tt.contract)
+			assert.Equal(t, tt.emitter, address)
+			assert.Equal(t, tt.wantNative, nativeAddress)
+		})
+	}
+}
@@ -50,7 +50,7 @@ func ProcessSourceTx(
 	repository *Repository,
 	params *ProcessSourceTxParams,
 	p2pNetwork string,
-	notionalCache *notionalCache.NotionalCache,
+	notionalCache notionalCache.NotionalLocalCacheReadable,
 ) (*chains.TxDetail, error) {
 
 	if !params.Overwrite {
@@ -147,6 +147,15 @@ func ProcessSourceTx(
 		return nil, err
 	}
 
+	// Keep track of the emitters that have been seen in the emitter registry
+	if params.Emitter != "" {
+		seenAt := time.Now()
+		if params.Timestamp != nil {
+			seenAt = *params.Timestamp
+		}
+		repository.RegisterEmitter(params.ChainId, params.Emitter, txDetail.Contract, seenAt)
+	}
+
 	params.Metrics.VaaProcessingDuration(params.ChainId.String(), params.SentTimestamp)
 
 	return txDetail, nil
@@ -55,7 +55,7 @@ func ProcessTargetTx(
 	logger *zap.Logger,
 	repository *Repository,
 	params *ProcessTargetTxParams,
-	notionalCache *notional.NotionalCache,
+	notionalCache notional.NotionalLocalCacheReadable,
 ) error {
 
 	feeDetail := calculateFeeDetail(params, logger, notionalCache)
@@ -86,10 +86,32 @@ func ProcessTargetTx(
 		return nil
 	}
 	err = repository.UpsertTargetTx(ctx, update)
-	if err == nil {
-		params.Metrics.IncDestinationTxInserted(params.ChainID.String(), params.Source)
+	if err != nil {
+		return err
 	}
-	return err
+	params.Metrics.IncDestinationTxInserted(params.ChainID.String(), params.Source)
+
+	// update the operation status with the result of the redeem.
+	var status domain.OperationStatus
+	switch params.Status {
+	case domain.DstTxStatusConfirmed:
+		status = domain.OperationStatusRedeemed
+	case domain.DstTxStatusFailedToProcess:
+		status = domain.OperationStatusTargetFailed
+	default:
+		return nil
+	}
+	at := now
+	if params.BlockTimestamp != nil {
+		at = *params.BlockTimestamp
+	}
+	if errStatus := repository.UpdateOperationStatus(ctx, params.VaaId, status, at); errStatus != nil {
+		logger.Warn("failed to update operation status",
+			zap.String("vaaId", params.VaaId),
+			zap.String("status", string(status)),
+			zap.Error(errStatus))
+	}
+	return nil
 }
 
 func checkTxShouldBeUpdated(ctx context.Context, tx *TargetTxUpdate, repository *Repository) (bool, error) {
@@ -123,7 +145,7 @@ func checkTxShouldBeUpdated(ctx context.Context, tx *TargetTxUpdate, repository
 	}
 }
 
-func calculateFeeDetail(params *ProcessTargetTxParams, logger *zap.Logger, notionalCache *notional.NotionalCache) *FeeDetail {
+func calculateFeeDetail(params *ProcessTargetTxParams, logger *zap.Logger, notionalCache notional.NotionalLocalCacheReadable) *FeeDetail {
 
 	// calculate tx fee for evm redeemed tx.
 	var feeDetail *FeeDetail
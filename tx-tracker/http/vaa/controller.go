@@ -24,11 +24,11 @@ type Controller struct {
 	repository       *consumer.Repository
 	metrics          metrics.Metrics
 	p2pNetwork       string
-	notionalCache    *notional.NotionalCache
+	notionalCache    notional.NotionalLocalCacheReadable
 }
 
 // NewController creates a Controller instance.
-func NewController(rpcPool map[sdk.ChainID]*pool.Pool, wormchainRpcPool map[sdk.ChainID]*pool.Pool, vaaRepository *Repository, repository *consumer.Repository, p2pNetwork string, logger *zap.Logger, notionalCache *notional.NotionalCache) *Controller {
+func NewController(rpcPool map[sdk.ChainID]*pool.Pool, wormchainRpcPool map[sdk.ChainID]*pool.Pool, vaaRepository *Repository, repository *consumer.Repository, p2pNetwork string, logger *zap.Logger, notionalCache notional.NotionalLocalCacheReadable) *Controller {
 	return &Controller{
 		metrics:          metrics.NewDummyMetrics(),
 		rpcPool:          rpcPool,
@@ -20,49 +20,50 @@ type PrometheusMetrics struct {
 	vaaProcessingDuration    *prometheus.HistogramVec
 }
 
-// NewPrometheusMetrics returns a new instance of PrometheusMetrics.
-func NewPrometheusMetrics(environment string) *PrometheusMetrics {
+// NewPrometheusMetrics returns a new instance of PrometheusMetrics registered in the registry.
+func NewPrometheusMetrics(registry prometheus.Registerer, environment string) *PrometheusMetrics {
+	factory := promauto.With(registry)
 	constLabels := map[string]string{
 		"environment": environment,
 		"service":     serviceName,
 	}
-	vaaTxTrackerCount := promauto.NewCounterVec(
+	vaaTxTrackerCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "vaa_tx_tracker_count_by_chain",
 			Help:        "Total number of vaa processed by tx tracker by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "source", "type"})
-	vaaProcesedDuration := promauto.NewHistogramVec(prometheus.HistogramOpts{
+	vaaProcesedDuration := factory.NewHistogramVec(prometheus.HistogramOpts{
 		Name:        "vaa_processed_duration",
 		Help:        "Duration of vaa processing",
 		ConstLabels: constLabels,
 		Buckets:     []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300, 600, 1200},
 	}, []string{"chain"})
-	rpcCallCount := promauto.NewCounterVec(
+	rpcCallCount := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "rpc_call_count_by_chain",
 			Help:        "Total number of rpc calls by chain",
 			ConstLabels: constLabels,
 		}, []string{"chain", "rpc", "status"})
-	storeUnprocessedOriginTx := promauto.NewCounterVec(
+	storeUnprocessedOriginTx := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "store_unprocessed_origin_tx",
 			Help:        "Total number of unprocessed origin tx",
 			ConstLabels: constLabels,
 		}, []string{"chain"})
-	vaaProcessed := promauto.NewCounterVec(
+	vaaProcessed := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "vaa_processed",
 			Help:        "Total number of processed vaa with retry context",
 			ConstLabels: constLabels,
 		}, []string{"chain", "retry", "status"})
-	wormchainUnknown := promauto.NewCounterVec(
+	wormchainUnknown := factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        "wormchain_unknown",
 			Help:        "Total number of unknown wormchain",
 			ConstLabels: constLabels,
 		}, []string{"srcChannel", "dstChannel"})
-	vaaProcessingDuration := promauto.NewHistogramVec(
+	vaaProcessingDuration := factory.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:        "vaa_processing_duration_seconds",
 			Help:        "Duration of all vaa processing by chain.",
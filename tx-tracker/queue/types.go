@@ -4,14 +4,11 @@ import (
 	"context"
 	"time"
 
+	common_queue "github.com/wormhole-foundation/wormhole-explorer/common/queue"
+	"github.com/wormhole-foundation/wormhole-explorer/txtracker/internal/metrics"
 	sdk "github.com/wormhole-foundation/wormhole/sdk/vaa"
 )
 
-type sqsEvent struct {
-	MessageID string `json:"MessageId"`
-	Message   string `json:"Message"`
-}
-
 type EventType string
 
 const (
@@ -68,14 +65,25 @@ func GetAttributes[T EventAttributes](e *Event) (T, bool) {
 }
 
 // ConsumerMessage defition.
-type ConsumerMessage interface {
-	Retry() uint8
-	Data() *Event
-	Done()
-	Failed()
-	IsExpired() bool
-	SentTimestamp() *time.Time
-}
+type ConsumerMessage = common_queue.Message[Event]
+
+// ConverterFunc converts a message from a queue message.
+type ConverterFunc = common_queue.ConverterFunc[Event]
 
 // ConsumeFunc is a function to consume Event.
 type ConsumeFunc func(context.Context) <-chan ConsumerMessage
+
+// NewMetricsOptions returns the consumer options to count the consumed, processed and failed events.
+func NewMetricsOptions(metrics metrics.Metrics) []common_queue.ConsumerOption[Event] {
+	return []common_queue.ConsumerOption[Event]{
+		common_queue.WithOnReceived(func(e *Event, _ uint8) {
+			metrics.IncVaaConsumedQueue(e.ChainID.String(), e.Source)
+		}),
+		common_queue.WithOnDone(func(e *Event, retry uint8) {
+			metrics.IncVaaProcessed(uint16(e.ChainID), retry)
+		}),
+		common_queue.WithOnFailed(func(e *Event, retry uint8) {
+			metrics.IncVaaFailed(uint16(e.ChainID), retry)
+		}),
+	}
+}